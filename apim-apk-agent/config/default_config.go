@@ -58,6 +58,17 @@ var defaultConfig = &Config{
 			Location: "/home/wso2/security/truststore",
 		},
 		Mode: "DPtoCP",
+		Reconciliation: reconciliation{
+			Enabled:  false,
+			Interval: 300,
+		},
+		Persistence: persistence{
+			Enabled: false,
+			Path:    "/home/wso2/data/event-store.db",
+		},
+	},
+	DataPlane: dataPlane{
+		CRGenerationMode: "local",
 	},
 	Metrics: metrics{
 		Enabled: false,
@@ -80,10 +80,28 @@ type Config struct {
 	Metrics metrics `toml:"metrics"`
 }
 type agent struct {
-	Enabled    bool
-	Keystore   keystore
-	TrustStore truststore
-	Mode       string
+	Enabled        bool
+	Keystore       keystore
+	TrustStore     truststore
+	Mode           string
+	Reconciliation reconciliation
+	Persistence    persistence
+}
+
+// persistence holds the configurations of the on-disk store of the applications, subscriptions, policies and
+// AI providers received from the control plane
+type persistence struct {
+	Enabled bool
+	// Path is the location of the store file
+	Path string
+}
+
+// reconciliation holds the configurations of the periodic drift reconciliation between the control plane and
+// the cluster
+type reconciliation struct {
+	Enabled bool
+	// Interval is the time between two reconciliations in seconds
+	Interval time.Duration
 }
 type keystore struct {
 	KeyPath  string
@@ -122,6 +140,10 @@ type dataPlane struct {
 	Enabled            bool
 	K8ResourceEndpoint string
 	Namespace          string
+	// CRGenerationMode selects how the Kubernetes resources of an API are generated. "local", the default, builds
+	// them inside the agent while "remote" posts the apk-conf to the config deployer at K8ResourceEndpoint. APIs
+	// with more than one endpoint per environment are only supported by "remote".
+	CRGenerationMode string
 }
 
 type requestWorkerPool struct {
@@ -44,7 +44,9 @@ import (
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
 	logging "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/logging"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/messaging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/reconciler"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/synchronizer"
+	internalutils "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/utils"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/health"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/managementserver"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/metrics"
@@ -109,8 +111,22 @@ func Run(conf *config.Config) {
 	}
 
 	logger.LoggerAgent.Info("Starting apim-apk-agent ....")
+	internalutils.InitializeWorkerPool(conf)
 	eventHubEnabled := conf.ControlPlane.Enabled
 
+	// Restore the data received from the control plane before the last shutdown, so that the gateways are served
+	// with it while it is refreshed from the control plane.
+	snapshotRestored := false
+	if conf.Agent.Persistence.Enabled {
+		restored, errStore := managementserver.LoadEventStore(conf.Agent.Persistence.Path)
+		if errStore != nil {
+			logger.LoggerAgent.Errorf("Error loading the event store, continuing without persistence: %v", errStore)
+		} else {
+			snapshotRestored = restored
+			defer managementserver.CloseEventStore()
+		}
+	}
+
 	var probeAddr string
 	var scheme = runtime.NewScheme()
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
@@ -170,30 +186,47 @@ func Run(conf *config.Config) {
 	AgentMode := conf.Agent.Mode
 	logger.LoggerAgent.Infof("Agent Mode: %v", AgentMode)
 
-	if AgentMode == "CPtoDP" {
-		// Load initial Policy data from control plane
-		synchronizer.FetchRateLimitPoliciesOnEvent("", "", mgr.GetClient())
-	}
-	// Load initial Subscription Rate Limit data from control plane
-	synchronizer.FetchSubscriptionRateLimitPoliciesOnEvent("", "", mgr.GetClient(), true)
-	// Load initial AI Provider data from control plane
-	synchronizer.FetchAIProvidersOnEvent("", "", "", mgr.GetClient(), true)
-
-	// Load initial data from control plane
-	eventhub.LoadInitialData(conf, mgr.GetClient())
-	health.RestService.SetStatus(true)
-
-	if eventHubEnabled {
-		var connectionURLList = conf.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
-		if strings.Contains(connectionURLList[0], amqpProtocol) {
-			go messaging.ProcessEvents(conf, mgr.GetClient())
+	loadControlPlaneData := func() {
+		if AgentMode == "CPtoDP" {
+			// Load initial Policy data from control plane
+			synchronizer.FetchRateLimitPoliciesOnEvent("", "", mgr.GetClient())
+		}
+		// Load initial Subscription Rate Limit data from control plane
+		synchronizer.FetchSubscriptionRateLimitPoliciesOnEvent("", "", mgr.GetClient(), true)
+		// Load initial AI Provider data from control plane
+		synchronizer.FetchAIProvidersOnEvent("", "", "", mgr.GetClient(), true)
+
+		// Load initial data from control plane
+		eventhub.LoadInitialData(conf, mgr.GetClient())
+		health.RestService.SetStatus(true)
+
+		// Load the revoked tokens and the block conditions from control plane
+		go synchronizer.FetchRevokedTokensOnStartUp()
+		go synchronizer.FetchBlockConditionsOnStartUp()
+
+		if eventHubEnabled {
+			var connectionURLList = conf.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
+			if strings.Contains(connectionURLList[0], amqpProtocol) {
+				go messaging.ProcessEvents(conf, mgr.GetClient())
+			}
 		}
-	}
 
-	// Load initial KM data from control plane
-	synchronizer.FetchKeyManagersOnStartUp(mgr.GetClient())
+		// Load initial KM data from control plane
+		synchronizer.FetchKeyManagersOnStartUp(mgr.GetClient())
 
-	health.NotificationListenerService.SetStatus(true)
+		health.NotificationListenerService.SetStatus(true)
+	}
+	if snapshotRestored {
+		// The event holder is guarded by a lock, hence the restored snapshot is served while it is refreshed
+		logger.LoggerAgent.Info("Serving the restored event store snapshot while refreshing the data from the control plane")
+		go loadControlPlaneData()
+	} else {
+		loadControlPlaneData()
+	}
+	if conf.Agent.Reconciliation.Enabled {
+		// The first reconciliation runs after an interval, by which the initial data is loaded
+		go reconciler.Start(ctx, conf, mgr.GetClient())
+	}
 
 	var grpcOptions []grpc.ServerOption
 	grpcOptions = append(grpcOptions, grpc.KeepaliveParams(
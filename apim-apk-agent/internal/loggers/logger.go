@@ -40,6 +40,8 @@ const (
 	pkgSynchronizer = "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/synchronizer"
 	pkgUtils        = "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/utils"
 	pkgEventhub     = "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/eventhub"
+	pkgTransform    = "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/transform"
+	pkgReconciler   = "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/reconciler"
 )
 
 // logger package references
@@ -52,6 +54,8 @@ var (
 	LoggerUtils        logging.Log
 	LoggerAgent        logging.Log
 	LoggerEventhub     logging.Log
+	LoggerTransform    logging.Log
+	LoggerReconciler   logging.Log
 )
 
 func init() {
@@ -69,5 +73,7 @@ func UpdateLoggers() {
 	LoggerUtils = logging.InitPackageLogger(pkgUtils)
 	LoggerAgent = logging.InitPackageLogger(pkgAgent)
 	LoggerEventhub = logging.InitPackageLogger(pkgEventhub)
+	LoggerTransform = logging.InitPackageLogger(pkgTransform)
+	LoggerReconciler = logging.InitPackageLogger(pkgReconciler)
 	logrus.Info("Updated loggers")
 }
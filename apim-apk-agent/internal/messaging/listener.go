@@ -30,4 +30,6 @@ func ProcessEvents(config *config.Config, c client.Client) {
 
 	go handleNotification(c)
 	go handleKMConfiguration(c)
+	go handleTokenRevocation()
+	go handleThrottleData()
 }
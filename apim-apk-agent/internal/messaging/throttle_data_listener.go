@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package messaging holds the implementation for event listeners functions
+package messaging
+
+import (
+	"encoding/json"
+	"strings"
+
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/managementserver"
+	msg "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/messaging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/utils"
+)
+
+// state of an enabled block condition
+const blockConditionEnabled = "true"
+
+// handleThrottleData processes the throttle data events. Block conditions are added to or removed from the block
+// conditions of the management server and sent to the gateways. Throttle decisions and key templates are not used by
+// the gateways and are dropped.
+func handleThrottleData() {
+	for d := range msg.ThrottleDataChannel {
+		var notification msg.EventThrottleData
+		if err := json.Unmarshal(d.Body, &notification); err != nil {
+			logger.LoggerMessaging.Errorf("Error occurred while unmarshalling throttle data event data %v. "+
+				"Hence dropping the event", err)
+			d.Ack(false)
+			continue
+		}
+		processThrottleDataEvent(&notification)
+		d.Ack(false)
+	}
+	logger.LoggerMessaging.Infof("handle: throttle data deliveries channel closed")
+}
+
+func processThrottleDataEvent(notification *msg.EventThrottleData) {
+	payload := notification.Event.PayloadData
+	if payload.BlockingCondition == "" {
+		logger.LoggerMessaging.Debugf("Throttle data event without a block condition is dropped: %+v", payload)
+		return
+	}
+	if !belongsToTenant(payload.TenantDomain) {
+		logger.LoggerMessaging.Infof("Block condition %d is dropped due to having non related tenantDomain : %s",
+			payload.ID, payload.TenantDomain)
+		return
+	}
+	logger.LoggerMessaging.Infof("Block condition %d of type %s is received with state %s", payload.ID,
+		payload.BlockingCondition, payload.State)
+	enabled := strings.EqualFold(payload.State, blockConditionEnabled)
+	if enabled {
+		err := managementserver.AddBlockCondition(payload.ID, payload.BlockingCondition, payload.ConditionValue,
+			payload.TenantDomain)
+		if err != nil {
+			logger.LoggerMessaging.Errorf("Error occurred while adding the block condition %v. "+
+				"Hence dropping the event", err)
+			return
+		}
+	} else {
+		managementserver.DeleteBlockCondition(payload.ID, payload.BlockingCondition, payload.ConditionValue)
+	}
+	go utils.SendEvent(managementserver.NewBlockConditionEvent(payload.ID, payload.BlockingCondition,
+		payload.ConditionValue, enabled, payload.TenantDomain))
+}
@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package messaging holds the implementation for event listeners functions
+package messaging
+
+import (
+	"encoding/json"
+
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/managementserver"
+	msg "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/messaging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/utils"
+)
+
+// handleTokenRevocation processes the token revocation events. The revoked token is added to the revoked tokens of
+// the management server and sent to the gateways.
+func handleTokenRevocation() {
+	for d := range msg.RevokedTokenChannel {
+		var notification msg.EventTokenRevocationNotification
+		if err := json.Unmarshal(d.Body, &notification); err != nil {
+			logger.LoggerMessaging.Errorf("Error occurred while unmarshalling token revocation event data %v. "+
+				"Hence dropping the event", err)
+			d.Ack(false)
+			continue
+		}
+		processTokenRevocationEvent(&notification)
+		d.Ack(false)
+	}
+	logger.LoggerMessaging.Infof("handle: token revocation deliveries channel closed")
+}
+
+func processTokenRevocationEvent(notification *msg.EventTokenRevocationNotification) {
+	payload := notification.Event.PayloadData
+	if payload.RevokedToken == "" {
+		logger.LoggerMessaging.Errorf("Token revocation event %s does not have the revoked token. "+
+			"Hence dropping the event", payload.EventID)
+		return
+	}
+	logger.LoggerMessaging.Infof("Token revocation event %s is received", payload.EventID)
+	revokedToken := types.RevokedToken{JWT: payload.RevokedToken, ExpiryTime: payload.ExpiryTime}
+	managementserver.AddRevokedToken(revokedToken)
+	go utils.SendEvent(managementserver.NewTokenRevokedEvent(revokedToken))
+}
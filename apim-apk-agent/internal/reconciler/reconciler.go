@@ -0,0 +1,302 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package reconciler periodically compares the APIs deployed in the control plane with the CRs in the cluster
+// and fixes the drift caused by lost events or CRs modified outside the agent.
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	k8sclient "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/k8sClient"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/synchronizer"
+	internalutils "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/utils"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/metrics"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/transformer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	apiUUIDLabel     = "apiUUID"
+	revisionIDLabel  = "revisionID"
+	cpToDPAgentMode  = "CPtoDP"
+	defaultInterval  = 300
+	actionDeployed   = "deployed"
+	actionRedeployed = "redeployed"
+	actionRemoved    = "removed"
+	actionFailed     = "failed"
+)
+
+// Result holds the outcome of a reconciliation
+type Result struct {
+	StartTime time.Time
+	Duration  time.Duration
+	// Deployed holds the UUIDs of the APIs which were missing in the cluster
+	Deployed []string
+	// Redeployed holds the UUIDs of the APIs whose revision in the cluster did not match the control plane
+	Redeployed []string
+	// Removed holds the names of the API CRs removed from the cluster
+	Removed []string
+	// Failed holds the UUIDs of the APIs or the names of the API CRs which could not be reconciled
+	Failed []string
+	Err    error
+}
+
+// Succeeded returns whether the reconciliation completed without errors
+func (result *Result) Succeeded() bool {
+	return result.Err == nil && len(result.Failed) == 0
+}
+
+// String returns a summary of the result to be used in logs
+func (result *Result) String() string {
+	if result.Err != nil {
+		return "failed: " + result.Err.Error()
+	}
+	return fmt.Sprintf("deployed: %s, redeployed: %s, removed: %s, failed: %s", strings.Join(result.Deployed, ","),
+		strings.Join(result.Redeployed, ","), strings.Join(result.Removed, ","), strings.Join(result.Failed, ","))
+}
+
+// These are variables so that the control plane and the cluster interactions can be replaced in tests.
+var (
+	fetchAPIArtifacts = internalutils.FetchAPIArtifacts
+	deployAPIArtifact = internalutils.DeployAPIArtifact
+	undeployAPI       = k8sclient.UndeployK8sAPICR
+	retrieveAPIs      = func(k8sClient client.Client) ([]dpv1alpha3.API, error) {
+		apis, _, err := k8sclient.RetrieveAllAPISFromK8s(k8sClient, "")
+		return apis, err
+	}
+	syncPolicies = syncPoliciesAndAIProviders
+)
+
+var (
+	lastResult     *Result
+	lastResultLock sync.RWMutex
+)
+
+// GetLastResult returns the result of the last reconciliation or nil if none has run yet
+func GetLastResult() *Result {
+	lastResultLock.RLock()
+	defer lastResultLock.RUnlock()
+	return lastResult
+}
+
+// Start runs the reconciliation at the configured interval until the context is cancelled
+func Start(ctx context.Context, conf *config.Config, k8sClient client.Client) {
+	interval := conf.Agent.Reconciliation.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	logger.LoggerReconciler.Infof("Starting the drift reconciliation with an interval of %v", interval*time.Second)
+	ticker := time.NewTicker(interval * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LoggerReconciler.Info("Stopping the drift reconciliation")
+			return
+		case <-ticker.C:
+			Reconcile(conf, k8sClient)
+		}
+	}
+}
+
+// Reconcile compares the control plane and the cluster once, fixes the drift and records the result
+func Reconcile(conf *config.Config, k8sClient client.Client) *Result {
+	result := &Result{StartTime: time.Now()}
+	if conf.Agent.Mode == cpToDPAgentMode {
+		reconcileAPIs(conf, k8sClient, result)
+	}
+	syncPolicies(conf, k8sClient)
+	result.Duration = time.Since(result.StartTime)
+
+	lastResultLock.Lock()
+	lastResult = result
+	lastResultLock.Unlock()
+	metrics.RecordReconciliation(result.StartTime, result.Duration, result.Succeeded(), map[string]int{
+		actionDeployed:   len(result.Deployed),
+		actionRedeployed: len(result.Redeployed),
+		actionRemoved:    len(result.Removed),
+		actionFailed:     len(result.Failed),
+	})
+	if result.Succeeded() {
+		logger.LoggerReconciler.Infof("Drift reconciliation completed in %v. %s", result.Duration, result)
+	} else {
+		logger.LoggerReconciler.Errorf("Drift reconciliation completed in %v with errors. %s", result.Duration, result)
+	}
+	return result
+}
+
+// syncPoliciesAndAIProviders redeploys the rate limit policies and the AI providers of the control plane and
+// removes the ones which no longer exist there
+func syncPoliciesAndAIProviders(conf *config.Config, k8sClient client.Client) {
+	if conf.Agent.Mode == cpToDPAgentMode {
+		synchronizer.FetchRateLimitPoliciesOnEvent("", "", k8sClient)
+	}
+	synchronizer.FetchSubscriptionRateLimitPoliciesOnEvent("", "", k8sClient, true)
+	synchronizer.FetchAIProvidersOnEvent("", "", "", k8sClient, true)
+}
+
+// cpAPI is an API deployed in the control plane
+type cpAPI struct {
+	uuid       string
+	revisionID string
+	deployment transformer.Deployment
+	artifact   *transformer.APIArtifact
+}
+
+func reconcileAPIs(conf *config.Config, k8sClient client.Client, result *Result) {
+	// The cluster is read before the control plane. An API deployed in between is then only missing in the cluster
+	// view and gets deployed again, instead of being missing in the control plane view and getting removed.
+	k8sAPIs, err := retrieveAPIs(k8sClient)
+	if err != nil {
+		result.Err = fmt.Errorf("error retrieving the APIs from the cluster: %w", err)
+		return
+	}
+	apiFiles, deployments, err := fetchAPIArtifacts(conf)
+	if err != nil {
+		result.Err = fmt.Errorf("error fetching the APIs from the control plane: %w", err)
+		return
+	}
+
+	// APIs missing in the control plane are only removed when every deployment of the control plane could be read,
+	// since an API which could not be read would otherwise be removed from the cluster.
+	skipRemovalReason := ""
+	if len(deployments) == 0 {
+		skipRemovalReason = "the control plane returned no deployments"
+	}
+	cpAPIs := make(map[string]*cpAPI)
+	for _, deployment := range deployments {
+		apiFile, exists := apiFiles[deployment.APIFile]
+		if !exists {
+			logger.LoggerReconciler.Errorf("API file %s listed in the deployments is not found", deployment.APIFile)
+			result.Failed = append(result.Failed, deployment.APIFile)
+			skipRemovalReason = "the API file " + deployment.APIFile + " is not found"
+			continue
+		}
+		artifact, err := transformer.DecodeAPIArtifact(apiFile)
+		if err != nil {
+			logger.LoggerReconciler.Errorf("Error decoding the API file %s: %v", deployment.APIFile, err)
+			result.Failed = append(result.Failed, deployment.APIFile)
+			skipRemovalReason = "the API file " + deployment.APIFile + " could not be decoded"
+			continue
+		}
+		uuid, revisionID, err := transformer.ReadAPIRevision(artifact.APIJson)
+		if err != nil {
+			logger.LoggerReconciler.Errorf("Error reading the revision of the API file %s: %v", deployment.APIFile, err)
+			result.Failed = append(result.Failed, deployment.APIFile)
+			skipRemovalReason = "the revision of the API file " + deployment.APIFile + " could not be read"
+			continue
+		}
+		cpAPIs[uuid] = &cpAPI{uuid: uuid, revisionID: fmt.Sprint(revisionID), deployment: deployment, artifact: artifact}
+	}
+
+	// Only the API CRs created by the agent carry the API UUID label, hence the rest are left untouched.
+	k8sAPIsByUUID := make(map[string][]dpv1alpha3.API)
+	for _, k8sAPI := range k8sAPIs {
+		uuid, exists := k8sAPI.ObjectMeta.Labels[apiUUIDLabel]
+		if !exists || k8sAPI.Spec.SystemAPI {
+			continue
+		}
+		k8sAPIsByUUID[uuid] = append(k8sAPIsByUUID[uuid], k8sAPI)
+	}
+
+	for _, uuid := range sortedKeys(cpAPIs) {
+		api := cpAPIs[uuid]
+		deployedAPIs := k8sAPIsByUUID[uuid]
+		upToDate := make([]dpv1alpha3.API, 0)
+		stale := make([]dpv1alpha3.API, 0)
+		for _, deployedAPI := range deployedAPIs {
+			if deployedAPI.ObjectMeta.Labels[revisionIDLabel] == api.revisionID {
+				upToDate = append(upToDate, deployedAPI)
+			} else {
+				stale = append(stale, deployedAPI)
+			}
+		}
+		if len(upToDate) > 0 {
+			// The API is in sync, but the CRs of older revisions which were not replaced are left behind.
+			for _, staleAPI := range stale {
+				removeAPI(k8sClient, staleAPI, result)
+			}
+			continue
+		}
+		if len(deployedAPIs) == 0 {
+			logger.LoggerReconciler.Infof("API %s of revision %s is not found in the cluster. Hence deploying it", uuid, api.revisionID)
+		} else {
+			logger.LoggerReconciler.Infof("API %s in the cluster does not match the revision %s of the control plane. Hence redeploying it",
+				uuid, api.revisionID)
+		}
+		_, crName, err := deployAPIArtifact(conf, api.artifact, api.deployment, k8sClient)
+		if err != nil {
+			logger.LoggerReconciler.Errorf("Error deploying the API %s: %v", uuid, err)
+			result.Failed = append(result.Failed, uuid)
+			continue
+		}
+		if len(deployedAPIs) == 0 {
+			result.Deployed = append(result.Deployed, uuid)
+		} else {
+			result.Redeployed = append(result.Redeployed, uuid)
+		}
+		// CRs of older revisions with a different name are not replaced by the deployment.
+		for _, staleAPI := range stale {
+			if staleAPI.Name != crName {
+				removeAPI(k8sClient, staleAPI, result)
+			}
+		}
+	}
+
+	if skipRemovalReason != "" {
+		logger.LoggerReconciler.Warnf("Skipping the removal of the APIs not found in the control plane since %s",
+			skipRemovalReason)
+		return
+	}
+	for _, uuid := range sortedKeys(k8sAPIsByUUID) {
+		if _, exists := cpAPIs[uuid]; exists {
+			continue
+		}
+		for _, orphanedAPI := range k8sAPIsByUUID[uuid] {
+			logger.LoggerReconciler.Infof("API %s is not found in the control plane. Hence removing %s from the cluster",
+				uuid, orphanedAPI.Name)
+			removeAPI(k8sClient, orphanedAPI, result)
+		}
+	}
+}
+
+func removeAPI(k8sClient client.Client, api dpv1alpha3.API, result *Result) {
+	if err := undeployAPI(k8sClient, api); err != nil {
+		logger.LoggerReconciler.Errorf("Error removing the API CR %s: %v", api.Name, err)
+		result.Failed = append(result.Failed, api.Name)
+		return
+	}
+	result.Removed = append(result.Removed, api.Name)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,250 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package reconciler
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	internalutils "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/utils"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/transformer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const fullZipPayload = "../../resources/test-resources/FullZip_Payload_1.zip"
+
+// fakeCluster replaces the control plane and the cluster interactions of the reconciler
+type fakeCluster struct {
+	apis       []dpv1alpha3.API
+	fetchErr   error
+	deployed   []string
+	undeployed []string
+	// calls records the order in which the control plane and the cluster are read
+	calls []string
+	// deployments alters the deployments returned by the control plane when set
+	deployments func(apiFiles map[string]*zip.File, deployments []transformer.Deployment) []transformer.Deployment
+}
+
+func setUpFakeCluster(t *testing.T, cluster *fakeCluster) {
+	content, err := os.ReadFile(fullZipPayload)
+	require.NoError(t, err)
+	originalFetch, originalDeploy, originalUndeploy, originalRetrieve, originalSync :=
+		fetchAPIArtifacts, deployAPIArtifact, undeployAPI, retrieveAPIs, syncPolicies
+	t.Cleanup(func() {
+		fetchAPIArtifacts, deployAPIArtifact, undeployAPI, retrieveAPIs, syncPolicies =
+			originalFetch, originalDeploy, originalUndeploy, originalRetrieve, originalSync
+	})
+	fetchAPIArtifacts = func(conf *config.Config) (map[string]*zip.File, []transformer.Deployment, error) {
+		cluster.calls = append(cluster.calls, "fetch")
+		if cluster.fetchErr != nil {
+			return nil, nil, cluster.fetchErr
+		}
+		apiFiles, deployments, err := internalutils.ReadAPIArtifacts(content)
+		if cluster.deployments != nil {
+			deployments = cluster.deployments(apiFiles, deployments)
+		}
+		return apiFiles, deployments, err
+	}
+	deployAPIArtifact = func(conf *config.Config, artifact *transformer.APIArtifact, deployment transformer.Deployment,
+		k8sClient client.Client) (string, string, error) {
+		uuid, _, err := transformer.ReadAPIRevision(artifact.APIJson)
+		cluster.deployed = append(cluster.deployed, uuid)
+		return uuid, "cr-" + uuid, err
+	}
+	undeployAPI = func(k8sClient client.Client, api dpv1alpha3.API) error {
+		cluster.undeployed = append(cluster.undeployed, api.Name)
+		return nil
+	}
+	retrieveAPIs = func(k8sClient client.Client) ([]dpv1alpha3.API, error) {
+		cluster.calls = append(cluster.calls, "retrieve")
+		return append([]dpv1alpha3.API{}, cluster.apis...), nil
+	}
+	syncPolicies = func(conf *config.Config, k8sClient client.Client) {}
+}
+
+// payloadRevisions returns the revisions of the APIs in the test payload keyed by their UUIDs
+func payloadRevisions(t *testing.T) map[string]string {
+	content, err := os.ReadFile(fullZipPayload)
+	require.NoError(t, err)
+	apiFiles, deployments, err := internalutils.ReadAPIArtifacts(content)
+	require.NoError(t, err)
+	revisions := make(map[string]string)
+	for _, deployment := range deployments {
+		artifact, err := transformer.DecodeAPIArtifact(apiFiles[deployment.APIFile])
+		require.NoError(t, err)
+		uuid, revision, err := transformer.ReadAPIRevision(artifact.APIJson)
+		require.NoError(t, err)
+		revisions[uuid] = fmt.Sprint(revision)
+	}
+	require.Len(t, revisions, 2)
+	return revisions
+}
+
+func k8sAPI(name string, labels map[string]string) dpv1alpha3.API {
+	return dpv1alpha3.API{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func cpToDPConfig() *config.Config {
+	conf := &config.Config{}
+	conf.Agent.Mode = cpToDPAgentMode
+	return conf
+}
+
+func TestReconcileDeploysMissingAndRemovesOrphanedAPIs(t *testing.T) {
+	revisions := payloadRevisions(t)
+	uuids := sortedKeys(revisions)
+	systemAPI := k8sAPI("system-api", map[string]string{apiUUIDLabel: "system"})
+	systemAPI.Spec.SystemAPI = true
+	cluster := &fakeCluster{apis: []dpv1alpha3.API{
+		k8sAPI("in-sync", map[string]string{apiUUIDLabel: uuids[0], revisionIDLabel: revisions[uuids[0]]}),
+		k8sAPI("orphaned", map[string]string{apiUUIDLabel: "deleted-api", revisionIDLabel: "1"}),
+		k8sAPI("unmanaged", map[string]string{}),
+		systemAPI,
+	}}
+	setUpFakeCluster(t, cluster)
+
+	result := Reconcile(cpToDPConfig(), nil)
+
+	assert.True(t, result.Succeeded())
+	assert.Equal(t, []string{uuids[1]}, result.Deployed)
+	assert.Empty(t, result.Redeployed)
+	assert.Equal(t, []string{"orphaned"}, result.Removed)
+	assert.Equal(t, []string{uuids[1]}, cluster.deployed)
+	assert.Equal(t, []string{"orphaned"}, cluster.undeployed)
+	assert.Same(t, result, GetLastResult())
+}
+
+func TestReconcileRedeploysStaleAPIs(t *testing.T) {
+	revisions := payloadRevisions(t)
+	uuids := sortedKeys(revisions)
+	cluster := &fakeCluster{apis: []dpv1alpha3.API{
+		k8sAPI("cr-"+uuids[0], map[string]string{apiUUIDLabel: uuids[0], revisionIDLabel: "stale"}),
+		k8sAPI("old-name", map[string]string{apiUUIDLabel: uuids[0], revisionIDLabel: "older"}),
+		k8sAPI("in-sync", map[string]string{apiUUIDLabel: uuids[1], revisionIDLabel: revisions[uuids[1]]}),
+		k8sAPI("left-behind", map[string]string{apiUUIDLabel: uuids[1], revisionIDLabel: "older"}),
+	}}
+	setUpFakeCluster(t, cluster)
+
+	result := Reconcile(cpToDPConfig(), nil)
+
+	assert.True(t, result.Succeeded())
+	assert.Empty(t, result.Deployed)
+	assert.Equal(t, []string{uuids[0]}, result.Redeployed)
+	assert.ElementsMatch(t, []string{"old-name", "left-behind"}, result.Removed)
+	assert.Equal(t, []string{uuids[0]}, cluster.deployed)
+}
+
+func TestReconcileDoesNotRemoveAPIsWhenControlPlaneFails(t *testing.T) {
+	cluster := &fakeCluster{
+		apis:     []dpv1alpha3.API{k8sAPI("deployed", map[string]string{apiUUIDLabel: "api", revisionIDLabel: "1"})},
+		fetchErr: errors.New("connection refused"),
+	}
+	setUpFakeCluster(t, cluster)
+
+	result := Reconcile(cpToDPConfig(), nil)
+
+	assert.False(t, result.Succeeded())
+	assert.ErrorContains(t, result.Err, "connection refused")
+	assert.Empty(t, cluster.undeployed)
+	assert.Empty(t, cluster.deployed)
+}
+
+func TestReconcileSkipsAPIsInDPtoCPMode(t *testing.T) {
+	cluster := &fakeCluster{apis: []dpv1alpha3.API{k8sAPI("orphaned", map[string]string{apiUUIDLabel: "api"})}}
+	setUpFakeCluster(t, cluster)
+	policiesSynced := false
+	syncPolicies = func(conf *config.Config, k8sClient client.Client) {
+		policiesSynced = true
+	}
+	conf := cpToDPConfig()
+	conf.Agent.Mode = "DPtoCP"
+
+	result := Reconcile(conf, nil)
+
+	assert.True(t, result.Succeeded())
+	assert.True(t, policiesSynced)
+	assert.Empty(t, cluster.undeployed)
+}
+
+func TestReconcileDoesNotRemoveAPIsWhenControlPlaneAPIsCanNotBeRead(t *testing.T) {
+	testCases := map[string]func(apiFiles map[string]*zip.File, deployments []transformer.Deployment) []transformer.Deployment{
+		"null deployments": func(apiFiles map[string]*zip.File, deployments []transformer.Deployment) []transformer.Deployment {
+			return nil
+		},
+		"missing API file": func(apiFiles map[string]*zip.File, deployments []transformer.Deployment) []transformer.Deployment {
+			deployments[0].APIFile = "missing.zip"
+			return deployments
+		},
+		"undecodable API file": func(apiFiles map[string]*zip.File, deployments []transformer.Deployment) []transformer.Deployment {
+			// The deployments.json is not an API project zip
+			deployments[0].APIFile = "deployments.json"
+			return deployments
+		},
+	}
+	for name, deployments := range testCases {
+		t.Run(name, func(t *testing.T) {
+			revisions := payloadRevisions(t)
+			uuids := sortedKeys(revisions)
+			cluster := &fakeCluster{
+				apis: []dpv1alpha3.API{
+					k8sAPI("in-sync", map[string]string{apiUUIDLabel: uuids[0], revisionIDLabel: revisions[uuids[0]]}),
+					k8sAPI("in-sync-2", map[string]string{apiUUIDLabel: uuids[1], revisionIDLabel: revisions[uuids[1]]}),
+					k8sAPI("orphaned", map[string]string{apiUUIDLabel: "deleted-api", revisionIDLabel: "1"}),
+				},
+				deployments: deployments,
+			}
+			setUpFakeCluster(t, cluster)
+
+			result := Reconcile(cpToDPConfig(), nil)
+
+			assert.Empty(t, result.Removed)
+			assert.Empty(t, cluster.undeployed)
+			assert.Empty(t, cluster.deployed)
+		})
+	}
+}
+
+func TestReconcileReadsTheClusterBeforeTheControlPlane(t *testing.T) {
+	revisions := payloadRevisions(t)
+	uuids := sortedKeys(revisions)
+	cluster := &fakeCluster{apis: []dpv1alpha3.API{
+		k8sAPI("in-sync", map[string]string{apiUUIDLabel: uuids[0], revisionIDLabel: revisions[uuids[0]]}),
+	}}
+	// An API is deployed through an event after the cluster is read, while the control plane still responds
+	// without it
+	cluster.deployments = func(apiFiles map[string]*zip.File, deployments []transformer.Deployment) []transformer.Deployment {
+		cluster.apis = append(cluster.apis, k8sAPI("new-api", map[string]string{apiUUIDLabel: "new-api", revisionIDLabel: "1"}))
+		return deployments
+	}
+	setUpFakeCluster(t, cluster)
+
+	result := Reconcile(cpToDPConfig(), nil)
+
+	assert.True(t, result.Succeeded())
+	assert.Equal(t, []string{"retrieve", "fetch"}, cluster.calls)
+	assert.Equal(t, []string{uuids[1]}, result.Deployed)
+	assert.Empty(t, cluster.undeployed)
+}
@@ -163,6 +163,19 @@ func FetchAIProvidersOnEvent(aiProviderName string, aiProviderVersion string, or
 			} else {
 				logger.LoggerSynchronizer.Errorf("Error while fetching aiproviders for cleaning up outdataed crs. Error: %+v", errK8)
 			}
+			// The AI providers restored from the event store may have been deleted while the agent was down.
+			for _, existingProvider := range managementserver.GetAllAIProviders() {
+				found := false
+				for _, aiProviderFromCP := range aiProviders {
+					if aiProviderFromCP.ID == existingProvider.ID {
+						found = true
+						break
+					}
+				}
+				if !found {
+					managementserver.DeleteAIProvider(existingProvider.ID)
+				}
+			}
 		}
 		for _, aiProvider := range aiProviders {
 			managementserver.AddAIProvider(aiProvider)
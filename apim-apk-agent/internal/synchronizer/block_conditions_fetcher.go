@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+/*
+ * Package "synchronizer" contains artifacts relate to fetching APIs and
+ * API related updates from the control plane event-hub.
+ * This file contains functions to retrieve the block conditions.
+ */
+
+package synchronizer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	pkgAuth "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/auth"
+	eventhubTypes "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/managementserver"
+	sync "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/synchronizer"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/tlsutils"
+)
+
+const blockConditionsEndpoint string = "throttle/data/v1/block"
+
+var blockConditionsRetryAttempt int
+
+// FetchBlockConditionsOnStartUp pulls the active block conditions calling to the traffic manager of the API manager
+// and adds them to the block conditions of the management server. The block conditions changed afterwards are
+// received as events.
+func FetchBlockConditionsOnStartUp() {
+	logger.LoggerSynchronizer.Info("Fetching block conditions from Control Plane.")
+
+	conf, errReadConfig := config.ReadConfigs()
+	if errReadConfig != nil {
+		logger.LoggerSynchronizer.Errorf("Error reading configs: %v", errReadConfig)
+		return
+	}
+	ehConfigs := conf.ControlPlane
+	ehURL := ehConfigs.ServiceURL
+	// If the eventHub URL is configured with trailing slash
+	if strings.HasSuffix(ehURL, "/") {
+		ehURL += blockConditionsEndpoint
+	} else {
+		ehURL += "/" + blockConditionsEndpoint
+	}
+	logger.LoggerSynchronizer.Debugf("Fetching block conditions from the URL %v: ", ehURL)
+
+	req, err := http.NewRequest("GET", ehURL, nil)
+	if err != nil {
+		logger.LoggerSynchronizer.Errorf("Error while creating http request for block conditions endpoint : %v", err)
+		return
+	}
+	req.Header.Set(sync.Authorization, "Basic "+pkgAuth.GetBasicAuth(ehConfigs.Username, ehConfigs.Password))
+
+	resp, err := tlsutils.InvokeControlPlane(req, ehConfigs.SkipSSLVerification)
+	if err != nil {
+		retryFetchBlockConditions(conf, "Error occurred while calling the REST API: "+blockConditionsEndpoint, err)
+		return
+	}
+	defer resp.Body.Close()
+	responseBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		retryFetchBlockConditions(conf, "Error occurred while reading the response received for: "+
+			blockConditionsEndpoint, err)
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		retryFetchBlockConditions(conf, "Failed to fetch data! "+blockConditionsEndpoint+" responded with "+
+			strconv.Itoa(resp.StatusCode), nil)
+		return
+	}
+	var blockConditions eventhubTypes.BlockConditions
+	if err := json.Unmarshal(responseBytes, &blockConditions); err != nil {
+		logger.LoggerSynchronizer.Errorf("Error occurred while unmarshalling block conditions %v", err)
+		return
+	}
+	logger.LoggerSynchronizer.Infof("%d block conditions received", len(blockConditions.API)+
+		len(blockConditions.Application)+len(blockConditions.User)+len(blockConditions.Subscription)+
+		len(blockConditions.Custom)+len(blockConditions.IP))
+	managementserver.AddAllBlockConditions(blockConditions)
+}
+
+func retryFetchBlockConditions(conf *config.Config, errorMessage string, err error) {
+	blockConditionsRetryAttempt++
+	if blockConditionsRetryAttempt > retryCount {
+		logger.LoggerSynchronizer.Errorf("%s %v", errorMessage, err)
+		return
+	}
+	logger.LoggerSynchronizer.Warnf("%s %v. Retrying after %v", errorMessage, err,
+		conf.ControlPlane.RetryInterval*time.Second)
+	time.Sleep(conf.ControlPlane.RetryInterval * time.Second)
+	FetchBlockConditionsOnStartUp()
+}
@@ -140,6 +140,21 @@ func FetchRateLimitPoliciesOnEvent(ratelimitName string, organization string, c
 		}
 		logger.LoggerSynchronizer.Debugf("Policies received: %v", rateLimitPolicyList.List)
 		var rateLimitPolicies []eventhubTypes.RateLimitPolicy = rateLimitPolicyList.List
+		if ratelimitName == "" && organization == "" {
+			// The policies restored from the event store may have been deleted while the agent was down.
+			for _, existingPolicy := range managementserver.GetAllRateLimitPolicies() {
+				found := false
+				for _, policy := range rateLimitPolicies {
+					if policy.Name == existingPolicy.Name && policy.TenantDomain == existingPolicy.TenantDomain {
+						found = true
+						break
+					}
+				}
+				if !found {
+					managementserver.DeleteRateLimitPolicy(existingPolicy.Name, existingPolicy.TenantDomain)
+				}
+			}
+		}
 		for _, policy := range rateLimitPolicies {
 			switch policy.DefaultLimit.RequestCount.TimeUnit {
 			case "min":
@@ -304,6 +319,19 @@ func FetchSubscriptionRateLimitPoliciesOnEvent(ratelimitName string, organizatio
 			} else {
 				logger.LoggerSynchronizer.Errorf("Error while fetching subscription ratelimitpolicies for cleaning up outdated crs. Error: %+v", retrieveAllRLErr)
 			}
+			// The policies restored from the event store may have been deleted while the agent was down.
+			for _, existingPolicy := range managementserver.GetSubscriptionPolicies() {
+				found := false
+				for _, policy := range rateLimitPolicies {
+					if policy.Name == existingPolicy.Name && policy.TenantDomain == existingPolicy.TenantDomain {
+						found = true
+						break
+					}
+				}
+				if !found {
+					managementserver.DeleteSubscriptionPolicy(existingPolicy.Name, existingPolicy.TenantDomain)
+				}
+			}
 		}
 
 		for _, rateLimitPolicy := range rateLimitPolicies {
@@ -0,0 +1,291 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package transform contains the offline transform command of the agent which converts an API project exported
+// from the control plane into the apk-conf and the Kubernetes CRs, and writes them to disk instead of applying
+// them to the cluster.
+package transform
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/transformer"
+)
+
+const (
+	// CommandName is the name of the sub command which triggers the offline transformation
+	CommandName = "transform"
+
+	deploymentDescriptorFile = "deployments.json"
+	apiDefinitionYAMLFile    = "api.yaml"
+	apiDefinitionJSONFile    = "api.json"
+	apiProjectExtension      = ".zip"
+	apkConfExtension         = ".apk-conf"
+	defaultEnvironment       = "Default"
+	defaultVhost             = "localhost"
+	defaultDeploymentType    = "hybrid"
+	defaultOrganization      = "carbon.super"
+	defaultOutputDir         = "./crs"
+)
+
+// Options holds the inputs of the transform command
+type Options struct {
+	ZipPath               string
+	Environment           string
+	Vhost                 string
+	DeploymentType        string
+	OrganizationID        string
+	OutputDir             string
+	CRGenerationMode      string
+	K8ResourceGenEndpoint string
+}
+
+// Run parses the transform command arguments and writes the generated artifacts to the output directory.
+func Run(args []string) error {
+	options, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+	written, err := Transform(options)
+	if err != nil {
+		return err
+	}
+	for _, dir := range written {
+		fmt.Println("Artifacts written to " + dir)
+	}
+	return nil
+}
+
+func parseArgs(args []string) (*Options, error) {
+	options := &Options{}
+	flags := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flags.StringVar(&options.ZipPath, "zip", "", "Path to the API project zip or the control plane artifact zip with a deployments.json")
+	flags.StringVar(&options.Environment, "env", defaultEnvironment, "Gateway environment to generate the artifacts for")
+	flags.StringVar(&options.Vhost, "vhost", defaultVhost, "Vhost of the environment when the zip does not contain a deployments.json")
+	flags.StringVar(&options.DeploymentType, "type", defaultDeploymentType, "Deployment type of the environment when the zip does not contain a deployments.json")
+	flags.StringVar(&options.OrganizationID, "org", defaultOrganization, "Organization of the API when the zip does not contain a deployments.json")
+	flags.StringVar(&options.OutputDir, "out", defaultOutputDir, "Directory to write the apk-conf and the CRs to")
+	flags.StringVar(&options.CRGenerationMode, "mode", transformer.CRGenerationModeLocal, "CR generation mode, local or remote")
+	flags.StringVar(&options.K8ResourceGenEndpoint, "endpoint", "", "Config deployer endpoint used to generate the CRs in remote mode")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if options.ZipPath == "" {
+		flags.Usage()
+		return nil, errors.New("the --zip flag is required")
+	}
+	if options.CRGenerationMode == transformer.CRGenerationModeRemote && options.K8ResourceGenEndpoint == "" {
+		return nil, errors.New("the --endpoint flag is required in remote CR generation mode")
+	}
+	return options, nil
+}
+
+// Transform generates the apk-conf and the CRs of every API in the given zip and writes them under a directory
+// per API in the output directory. The paths of the written directories are returned.
+func Transform(options *Options) ([]string, error) {
+	content, err := os.ReadFile(options.ZipPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", options.ZipPath, err)
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("error reading zip %s: %w", options.ZipPath, err)
+	}
+	apiFiles := make(map[string]*zip.File)
+	for _, file := range zipReader.File {
+		apiFiles[file.Name] = file
+	}
+
+	var deployments []transformer.Deployment
+	if descriptorFile, exists := apiFiles[deploymentDescriptorFile]; exists {
+		deployments, err = readDeployments(descriptorFile, options.Environment)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := validateAPIProject(options.ZipPath, apiFiles); err != nil {
+			return nil, err
+		}
+		// The zip is a single API project, hence it is wrapped in a zip in the same way as the control plane
+		// sends it so that it can be decoded as an API file of a deployment.
+		apiFile, err := wrapAPIProject(filepath.Base(options.ZipPath), content)
+		if err != nil {
+			return nil, err
+		}
+		apiFiles = map[string]*zip.File{apiFile.Name: apiFile}
+		deployments = []transformer.Deployment{{
+			APIFile: apiFile.Name,
+			Environments: &[]transformer.Environment{{
+				Name:  options.Environment,
+				Vhost: options.Vhost,
+				Type:  options.DeploymentType,
+			}},
+			OrganizationID: options.OrganizationID,
+		}}
+	}
+	if len(deployments) == 0 {
+		return nil, fmt.Errorf("no API deployments found for the environment %s", options.Environment)
+	}
+
+	written := make([]string, 0, len(deployments))
+	for _, deployment := range deployments {
+		apiFile, exists := apiFiles[deployment.APIFile]
+		if !exists {
+			return nil, fmt.Errorf("API file %s of the deployment not found in %s", deployment.APIFile, options.ZipPath)
+		}
+		dir, err := transformAPI(apiFile, deployment, options)
+		if err != nil {
+			return nil, err
+		}
+		written = append(written, dir)
+	}
+	return written, nil
+}
+
+// readDeployments returns the deployments in the descriptor which are deployed in the given environment, with
+// the environments of each deployment narrowed down to it.
+func readDeployments(descriptorFile *zip.File, environment string) ([]transformer.Deployment, error) {
+	descriptorContent, err := transformer.ReadContent(descriptorFile)
+	if err != nil {
+		return nil, err
+	}
+	descriptor, err := transformer.ProcessDeploymentDescriptor(descriptorContent)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", deploymentDescriptorFile, err)
+	}
+	deployments := make([]transformer.Deployment, 0)
+	if descriptor.Data.Deployments == nil {
+		return deployments, nil
+	}
+	for _, deployment := range *descriptor.Data.Deployments {
+		if deployment.Environments == nil {
+			continue
+		}
+		environments := make([]transformer.Environment, 0)
+		for _, env := range *deployment.Environments {
+			if environment == "" || env.Name == environment {
+				environments = append(environments, env)
+			}
+		}
+		if len(environments) > 0 {
+			deployment.Environments = &environments
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments, nil
+}
+
+// validateAPIProject checks that a zip without a deployments.json is an API project, so that a bundle of API
+// projects which is missing its deployments.json or an unrelated zip is reported as such instead of failing
+// while decoding it.
+func validateAPIProject(zipPath string, files map[string]*zip.File) error {
+	apiProjects := make([]string, 0)
+	hasAPIDefinition := false
+	for name := range files {
+		switch path.Base(name) {
+		case apiDefinitionYAMLFile, apiDefinitionJSONFile:
+			hasAPIDefinition = true
+		}
+		if strings.HasSuffix(name, apiProjectExtension) {
+			apiProjects = append(apiProjects, name)
+		}
+	}
+	if len(apiProjects) > 0 {
+		sort.Strings(apiProjects)
+		return fmt.Errorf("%s is a bundle of the API projects %s but does not contain a %s mapping them to the "+
+			"gateway environments", zipPath, strings.Join(apiProjects, ", "), deploymentDescriptorFile)
+	}
+	if !hasAPIDefinition {
+		return fmt.Errorf("%s is neither an API project with an %s or %s nor a bundle of API projects with a %s",
+			zipPath, apiDefinitionYAMLFile, apiDefinitionJSONFile, deploymentDescriptorFile)
+	}
+	return nil
+}
+
+func wrapAPIProject(name string, content []byte) (*zip.File, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	entry, err := writer.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(entry, bytes.NewReader(content)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return nil, err
+	}
+	return reader.File[0], nil
+}
+
+// transformAPI runs the same pipeline the agent runs on a deployment event and writes the results to disk.
+func transformAPI(apiFile *zip.File, deployment transformer.Deployment, options *Options) (string, error) {
+	artifact, err := transformer.DecodeAPIArtifact(apiFile)
+	if err != nil {
+		return "", fmt.Errorf("error decoding the API project %s: %w", apiFile.Name, err)
+	}
+	apkConf, apiUUID, revisionID, configuredRateLimitPoliciesMap, endpointSecurityData, api, _, _, err :=
+		transformer.GenerateAPKConf(artifact.APIJson, artifact.CertArtifact, artifact.Endpoints, deployment.OrganizationID)
+	if err != nil {
+		return "", fmt.Errorf("error generating the apk-conf of %s: %w", apiFile.Name, err)
+	}
+	certContainer := transformer.CertContainer{
+		ClientCertObj:   artifact.CertMeta,
+		EndpointCertObj: artifact.EndpointCertMeta,
+		SecretData:      endpointSecurityData,
+	}
+	k8sArtifact, err := transformer.GenerateK8sArtifacts(options.CRGenerationMode, apkConf, api, artifact.Schema,
+		certContainer, options.K8ResourceGenEndpoint, deployment.OrganizationID)
+	if err != nil {
+		return "", fmt.Errorf("error generating the CRs of %s: %w", apiFile.Name, err)
+	}
+	transformer.UpdateCRS(k8sArtifact, deployment.Environments, deployment.OrganizationID, apiUUID, fmt.Sprint(revisionID),
+		"namespace", configuredRateLimitPoliciesMap)
+
+	files, err := transformer.MarshalK8sArtifacts(k8sArtifact)
+	if err != nil {
+		return "", err
+	}
+	apiName := api.Name + "-" + api.Version
+	dir := filepath.Join(options.OutputDir, apiName)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("error creating the output directory %s: %w", dir, err)
+	}
+	files[apiName+apkConfExtension] = apkConf
+	for fileName, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, fileName), []byte(content), 0644); err != nil {
+			return "", fmt.Errorf("error writing %s: %w", fileName, err)
+		}
+	}
+	logger.LoggerTransform.Infof("Generated %d artifacts of the API %s in %s", len(files), apiName, dir)
+	return dir, nil
+}
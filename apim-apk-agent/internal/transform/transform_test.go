@@ -0,0 +1,177 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package transform
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/transformer"
+)
+
+const (
+	fullZipPayload = "../../resources/test-resources/FullZip_Payload_1.zip"
+	starWarsAPIZip = "69c6ce1d-25ea-4369-ad5c-93b511406cda-86b353ae-a9f4-4231-ba77-d37b3dd73913.zip"
+)
+
+func TestTransformDeploymentPayload(t *testing.T) {
+	outDir := t.TempDir()
+	written, err := Transform(&Options{
+		ZipPath:          fullZipPayload,
+		Environment:      "Default",
+		OutputDir:        outDir,
+		CRGenerationMode: transformer.CRGenerationModeLocal,
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{filepath.Join(outDir, "PizzaShackAPI-1.0.0"), filepath.Join(outDir, "StarWarsAPI-1.0.0")}, written)
+
+	apkConf, err := os.ReadFile(filepath.Join(outDir, "PizzaShackAPI-1.0.0", "PizzaShackAPI-1.0.0.apk-conf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(apkConf), "name: PizzaShackAPI")
+
+	route, err := os.ReadFile(filepath.Join(outDir, "StarWarsAPI-1.0.0", "gqlroute-e690ee5cf1bde8c13c33adc59f561401896bc529-production-gqlroute-1.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(route), "gw.wso2.com", "Vhost of the deployment is not applied")
+}
+
+func TestTransformUnknownEnvironment(t *testing.T) {
+	_, err := Transform(&Options{
+		ZipPath:          fullZipPayload,
+		Environment:      "Unknown",
+		OutputDir:        t.TempDir(),
+		CRGenerationMode: transformer.CRGenerationModeLocal,
+	})
+	assert.EqualError(t, err, "no API deployments found for the environment Unknown")
+}
+
+func TestTransformAPIProject(t *testing.T) {
+	projectZip := filepath.Join(t.TempDir(), "api.zip")
+	extractZipEntry(t, fullZipPayload, starWarsAPIZip, projectZip)
+
+	outDir := t.TempDir()
+	written, err := Transform(&Options{
+		ZipPath:          projectZip,
+		Environment:      "Default",
+		Vhost:            "api.example.com",
+		DeploymentType:   "hybrid",
+		OrganizationID:   "carbon.super",
+		OutputDir:        outDir,
+		CRGenerationMode: transformer.CRGenerationModeLocal,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(outDir, "StarWarsAPI-1.0.0")}, written)
+
+	entries, err := os.ReadDir(written[0])
+	require.NoError(t, err)
+	var routes []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "gqlroute-") && strings.Contains(entry.Name(), "-production-") {
+			routes = append(routes, entry.Name())
+		}
+	}
+	require.NotEmpty(t, routes)
+	route, err := os.ReadFile(filepath.Join(written[0], routes[0]))
+	require.NoError(t, err)
+	assert.Contains(t, string(route), "api.example.com")
+}
+
+func TestTransformInvalidLayout(t *testing.T) {
+	bundleZip := filepath.Join(t.TempDir(), "bundle.zip")
+	reader, err := zip.OpenReader(fullZipPayload)
+	require.NoError(t, err)
+	defer reader.Close()
+	bundleEntries := make(map[string][]byte)
+	for _, file := range reader.File {
+		if file.Name != deploymentDescriptorFile {
+			content, err := transformer.ReadContent(file)
+			require.NoError(t, err)
+			bundleEntries[file.Name] = content
+		}
+	}
+	writeZip(t, bundleZip, bundleEntries)
+	_, err = Transform(&Options{
+		ZipPath:          bundleZip,
+		Environment:      "Default",
+		OutputDir:        t.TempDir(),
+		CRGenerationMode: transformer.CRGenerationModeLocal,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is a bundle of the API projects")
+	assert.Contains(t, err.Error(), "does not contain a deployments.json")
+
+	otherZip := filepath.Join(t.TempDir(), "other.zip")
+	writeZip(t, otherZip, map[string][]byte{"README.md": []byte("not an API")})
+	_, err = Transform(&Options{
+		ZipPath:          otherZip,
+		Environment:      "Default",
+		OutputDir:        t.TempDir(),
+		CRGenerationMode: transformer.CRGenerationModeLocal,
+	})
+	assert.EqualError(t, err, otherZip+" is neither an API project with an api.yaml or api.json nor a bundle of "+
+		"API projects with a deployments.json")
+}
+
+func TestParseArgs(t *testing.T) {
+	options, err := parseArgs([]string{"--zip", "api.zip", "--env", "Prod", "--out", "out"})
+	require.NoError(t, err)
+	assert.Equal(t, "api.zip", options.ZipPath)
+	assert.Equal(t, "Prod", options.Environment)
+	assert.Equal(t, "out", options.OutputDir)
+	assert.Equal(t, defaultVhost, options.Vhost)
+	assert.Equal(t, transformer.CRGenerationModeLocal, options.CRGenerationMode)
+
+	_, err = parseArgs([]string{"--env", "Prod"})
+	assert.EqualError(t, err, "the --zip flag is required")
+
+	_, err = parseArgs([]string{"--zip", "api.zip", "--mode", transformer.CRGenerationModeRemote})
+	assert.EqualError(t, err, "the --endpoint flag is required in remote CR generation mode")
+}
+
+func extractZipEntry(t *testing.T, zipPath string, entryName string, target string) {
+	reader, err := zip.OpenReader(zipPath)
+	require.NoError(t, err)
+	defer reader.Close()
+	for _, file := range reader.File {
+		if file.Name == entryName {
+			content, err := transformer.ReadContent(file)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(target, content, 0644))
+			return
+		}
+	}
+	t.Fatalf("%s not found in %s", entryName, zipPath)
+}
+
+func writeZip(t *testing.T, target string, entries map[string][]byte) {
+	file, err := os.Create(target)
+	require.NoError(t, err)
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	for name, content := range entries {
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+}
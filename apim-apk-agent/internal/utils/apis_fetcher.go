@@ -43,8 +43,9 @@ import (
 	mapperUtil "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/mapper"
 )
 
-func init() {
-	conf, _ := config.ReadConfigs()
+// InitializeWorkerPool creates the worker pool used to fetch the artifacts from the control plane with the given
+// configuration. It has to be called before any of the artifacts are fetched.
+func InitializeWorkerPool(conf *config.Config) {
 	sync.InitializeWorkerPool(conf.ControlPlane.RequestWorkerPool.PoolSize, conf.ControlPlane.RequestWorkerPool.QueueSizePerPool,
 		conf.ControlPlane.RequestWorkerPool.PauseTimeAfterFailure, conf.Agent.TrustStore.Location,
 		conf.ControlPlane.SkipSSLVerification, conf.ControlPlane.HTTPClient.RequestTimeOut, conf.ControlPlane.RetryInterval,
@@ -70,75 +71,24 @@ func FetchAPIsOnEvent(conf *config.Config, apiUUID *string, k8sClient client.Cli
 	logger.LoggerUtils.Debugf("Receiving data for an API: %v", apiUUID)
 	if data.Resp != nil {
 		if data.Found {
-			// Reading the root zip
-			zipReader, err := zip.NewReader(bytes.NewReader(data.Resp), int64(len(data.Resp)))
-
-			// apiFiles represents zipped API files fetched from API Manager
-			apiFiles := make(map[string]*zip.File)
-			// Read the .zip files within the root apis.zip and add apis to apiFiles array.
-			for _, file := range zipReader.File {
-				apiFiles[file.Name] = file
-				logger.LoggerUtils.Debugf("API file found: " + file.Name)
-				// Todo: Read the apis.zip and extract the api.zip,deployments.json
-			}
+			apiFiles, apiDeployments, err := ReadAPIArtifacts(data.Resp)
 			if err != nil {
-				logger.LoggerUtils.Errorf("Error while reading zip: %v", err)
-				return nil, err
-			}
-			deploymentJSON, exists := apiFiles["deployments.json"]
-			if !exists {
-				logger.LoggerUtils.Errorf("deployments.json not found")
 				return nil, err
 			}
-			deploymentJSONBytes, err := transformer.ReadContent(deploymentJSON)
-			if err != nil {
-				logger.LoggerUtils.Errorf("Error while decoding the API Project Artifact: %v", err)
-				return nil, err
-			}
-			deploymentDescriptor, err := transformer.ProcessDeploymentDescriptor(deploymentJSONBytes)
-			if err != nil {
-				logger.LoggerUtils.Errorf("Error while decoding the API Project Artifact: %v", err)
-				return nil, err
-			}
-			apiDeployments := deploymentDescriptor.Data.Deployments
 			if apiDeployments != nil {
-				for _, apiDeployment := range *apiDeployments {
+				for _, apiDeployment := range apiDeployments {
 					apiZip, exists := apiFiles[apiDeployment.APIFile]
 					if exists {
 						artifact, decodingError := transformer.DecodeAPIArtifact(apiZip)
 						if decodingError != nil {
 							logger.LoggerUtils.Errorf("Error while decoding the API Project Artifact: %v", decodingError)
-							return nil, err
+							return nil, decodingError
 						}
-
-						apkConf, apiUUID, revisionID, configuredRateLimitPoliciesMap, endpointSecurityData, api, prodAIRL, sandAIRL, apkErr := transformer.GenerateAPKConf(artifact.APIJson, artifact.CertArtifact, artifact.Endpoints, apiDeployment.OrganizationID)
-						if prodAIRL == nil {
-							// Try to delete production AI ratelimit for this api
-							k8sclientUtil.DeleteAIRatelimitPolicy(generateSHA1HexHash(api.Name, api.Version, "production"), k8sClient)
+						deployedAPIUUID, _, deployErr := DeployAPIArtifact(conf, artifact, apiDeployment, k8sClient)
+						if deployErr != nil {
+							return nil, deployErr
 						}
-						if sandAIRL == nil {
-							// Try to delete production AI ratelimit for this api
-							k8sclientUtil.DeleteAIRatelimitPolicy(generateSHA1HexHash(api.Name, api.Version, "sandbox"), k8sClient)
-						}
-						if apkErr != nil {
-							logger.LoggerUtils.Errorf("Error while generating APK-Conf: %v", apkErr)
-							return nil, err
-						}
-						logger.LoggerUtils.Debugf("APK Conf: %v", apkConf)
-						certContainer := transformer.CertContainer{
-							ClientCertObj:   artifact.CertMeta,
-							EndpointCertObj: artifact.EndpointCertMeta,
-							SecretData:      endpointSecurityData,
-						}
-						k8ResourceEndpoint := conf.DataPlane.K8ResourceEndpoint
-						crResponse, err := transformer.GenerateCRs(apkConf, artifact.Schema, certContainer, k8ResourceEndpoint, apiDeployment.OrganizationID)
-						if err != nil {
-							logger.LoggerUtils.Errorf("Error occured in receiving the updated CRDs: %v", err)
-							return nil, err
-						}
-						transformer.UpdateCRS(crResponse, apiDeployment.Environments, apiDeployment.OrganizationID, apiUUID, fmt.Sprint(revisionID), "namespace", configuredRateLimitPoliciesMap)
-						mapperUtil.MapAndCreateCR(*crResponse, k8sClient)
-						apis = append(apis, apiUUID)
+						apis = append(apis, deployedAPIUUID)
 						logger.LoggerUtils.Info("API applied successfully.\n")
 					}
 				}
@@ -173,6 +123,102 @@ func FetchAPIsOnEvent(conf *config.Config, apiUUID *string, k8sClient client.Cli
 	return nil, nil
 }
 
+// FetchAPIArtifacts fetches the artifacts of all the APIs deployed in the configured environments from the control
+// plane once, without retrying on failures, and returns the API files along with their deployments.
+func FetchAPIArtifacts(conf *config.Config) (map[string]*zip.File, []transformer.Deployment, error) {
+	envs := conf.ControlPlane.EnvironmentLabels
+	if len(envs) == 0 {
+		return nil, nil, fmt.Errorf("no environment labels are configured")
+	}
+	c := make(chan sync.SyncAPIResponse)
+	GetAPI(c, nil, envs, sync.RuntimeArtifactEndpoint, true)
+	data := <-c
+	if data.Resp != nil {
+		if !data.Found {
+			return map[string]*zip.File{}, []transformer.Deployment{}, nil
+		}
+		return ReadAPIArtifacts(data.Resp)
+	}
+	if data.ErrorCode == 204 {
+		return map[string]*zip.File{}, []transformer.Deployment{}, nil
+	}
+	if data.Err != nil {
+		return nil, nil, data.Err
+	}
+	return nil, nil, fmt.Errorf("control plane responded with the status code %d", data.ErrorCode)
+}
+
+// ReadAPIArtifacts reads the root zip of the runtime artifacts received from the control plane and returns the
+// API files keyed by their names along with the deployments listed in the deployments.json.
+func ReadAPIArtifacts(content []byte) (map[string]*zip.File, []transformer.Deployment, error) {
+	// Reading the root zip
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		logger.LoggerUtils.Errorf("Error while reading zip: %v", err)
+		return nil, nil, err
+	}
+	// apiFiles represents zipped API files fetched from API Manager
+	apiFiles := make(map[string]*zip.File)
+	// Read the .zip files within the root apis.zip and add apis to apiFiles array.
+	for _, file := range zipReader.File {
+		apiFiles[file.Name] = file
+		logger.LoggerUtils.Debugf("API file found: " + file.Name)
+	}
+	deploymentJSON, exists := apiFiles["deployments.json"]
+	if !exists {
+		logger.LoggerUtils.Errorf("deployments.json not found")
+		return nil, nil, fmt.Errorf("deployments.json not found")
+	}
+	deploymentJSONBytes, err := transformer.ReadContent(deploymentJSON)
+	if err != nil {
+		logger.LoggerUtils.Errorf("Error while decoding the API Project Artifact: %v", err)
+		return nil, nil, err
+	}
+	deploymentDescriptor, err := transformer.ProcessDeploymentDescriptor(deploymentJSONBytes)
+	if err != nil {
+		logger.LoggerUtils.Errorf("Error while decoding the API Project Artifact: %v", err)
+		return nil, nil, err
+	}
+	if deploymentDescriptor.Data.Deployments == nil {
+		return apiFiles, nil, nil
+	}
+	return apiFiles, *deploymentDescriptor.Data.Deployments, nil
+}
+
+// DeployAPIArtifact generates the CRs of a decoded API artifact and applies them to the cluster. The UUID of the
+// API and the name of the API CR are returned.
+func DeployAPIArtifact(conf *config.Config, artifact *transformer.APIArtifact, apiDeployment transformer.Deployment,
+	k8sClient client.Client) (string, string, error) {
+	apkConf, apiUUID, revisionID, configuredRateLimitPoliciesMap, endpointSecurityData, api, prodAIRL, sandAIRL, apkErr := transformer.GenerateAPKConf(artifact.APIJson, artifact.CertArtifact, artifact.Endpoints, apiDeployment.OrganizationID)
+	if apkErr != nil {
+		logger.LoggerUtils.Errorf("Error while generating APK-Conf: %v", apkErr)
+		return "", "", apkErr
+	}
+	if prodAIRL == nil {
+		// Try to delete production AI ratelimit for this api
+		k8sclientUtil.DeleteAIRatelimitPolicy(generateSHA1HexHash(api.Name, api.Version, "production"), k8sClient)
+	}
+	if sandAIRL == nil {
+		// Try to delete production AI ratelimit for this api
+		k8sclientUtil.DeleteAIRatelimitPolicy(generateSHA1HexHash(api.Name, api.Version, "sandbox"), k8sClient)
+	}
+	logger.LoggerUtils.Debugf("APK Conf: %v", apkConf)
+	certContainer := transformer.CertContainer{
+		ClientCertObj:   artifact.CertMeta,
+		EndpointCertObj: artifact.EndpointCertMeta,
+		SecretData:      endpointSecurityData,
+	}
+	k8ResourceEndpoint := conf.DataPlane.K8ResourceEndpoint
+	crResponse, err := transformer.GenerateK8sArtifacts(conf.DataPlane.CRGenerationMode, apkConf, api, artifact.Schema, certContainer, k8ResourceEndpoint, apiDeployment.OrganizationID)
+	if err != nil {
+		logger.LoggerUtils.Errorf("Error occured in receiving the updated CRDs: %v", err)
+		return "", "", err
+	}
+	transformer.UpdateCRS(crResponse, apiDeployment.Environments, apiDeployment.OrganizationID, apiUUID, fmt.Sprint(revisionID), "namespace", configuredRateLimitPoliciesMap)
+	mapperUtil.MapAndCreateCR(*crResponse, k8sClient)
+	return apiUUID, crResponse.API.Name, nil
+}
+
 // generateSHA1HexHash hashes the concatenated strings and returns the SHA-1 hash in base16 (hex) encoding.
 func generateSHA1HexHash(name, version, env string) string {
 	data := name + version + env
@@ -18,13 +18,24 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/agent"
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/internal/loggers"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/logging"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/transform"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == transform.CommandName {
+		if err := transform.Run(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error: "+err.Error())
+			os.Exit(1)
+		}
+		return
+	}
 	conf, errReadConfig := config.ReadConfigs()
 	if errReadConfig != nil {
 		logger.LoggerAgent.ErrorC(logging.PrintError(logging.Error1102, logging.CRITICAL, "Error reading the log configs, error: %v", errReadConfig))
@@ -289,3 +289,37 @@ type Claim struct {
 	RemoteClaim string `json:"remoteClaim"`
 	LocalClaim  string `json:"localClaim"`
 }
+
+// RevokedToken contains the JWT and the expirty time of the
+// revoked JWT token.
+type RevokedToken struct {
+	JWT        string `json:"jwt_signature"`
+	ExpiryTime int64  `json:"expiry_time"`
+}
+
+// RevokedTokenList for struct list of RevokedToken
+type RevokedTokenList struct {
+	List []RevokedToken `json:"list"`
+}
+
+// BlockConditions defines a blocking condition retrieved from traffic manager
+type BlockConditions struct {
+	API          []string      `json:"api"`
+	Application  []string      `json:"application"`
+	User         []string      `json:"user"`
+	Subscription []string      `json:"subscription"`
+	Custom       []string      `json:"custom"`
+	IP           []IPCondition `json:"ip"`
+}
+
+// IPCondition defines a IP condition
+type IPCondition struct {
+	Type         string `json:"type"`
+	ID           int32  `json:"id"`
+	FixedIP      string `json:"fixedIp,omitempty"`
+	StartingIP   string `json:"startingIp,omitempty"`
+	EndingIP     string `json:"endingIp,omitempty"`
+	Invert       bool   `json:"invert"`
+	TenantDomain string `json:"tenantDomain"`
+	State        string `json:"state"`
+}
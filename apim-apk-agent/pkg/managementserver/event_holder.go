@@ -17,6 +17,16 @@
 package managementserver
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wso2/apk/common-go-libs/pkg/discovery/api/wso2/discovery/subscription"
 	eventHub "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/utils"
@@ -30,9 +40,19 @@ var (
 	rateLimitPolicyMap       map[string]eventHub.RateLimitPolicy
 	aiProviderMap            map[string]eventHub.AIProvider
 	subscriptionPolicyMap    map[string]eventHub.SubscriptionPolicy
+	revokedTokenMap          map[string]eventHub.RevokedToken
+	blockConditionMap        map[int32]blockCondition
+	// eventHolderMutex guards the maps holding the data received from the control plane, which are updated by the
+	// control plane loaders and the event listeners while the gateways read them
+	eventHolderMutex sync.RWMutex
+	// throttleDataMutex guards the revoked tokens and the block conditions which are updated by the event listeners
+	// while the gateways read them
+	throttleDataMutex sync.RWMutex
 )
 
 func init() {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	applicationMap = make(map[string]Application)
 	subscriptionMap = make(map[string]Subscription)
 	applicationMappingMap = make(map[string]ApplicationMapping)
@@ -40,25 +60,37 @@ func init() {
 	rateLimitPolicyMap = make(map[string]eventHub.RateLimitPolicy)
 	aiProviderMap = make(map[string]eventHub.AIProvider)
 	subscriptionPolicyMap = make(map[string]eventHub.SubscriptionPolicy)
+	revokedTokenMap = make(map[string]eventHub.RevokedToken)
+	blockConditionMap = make(map[int32]blockCondition)
 }
 
 // AddAIProvider adds an AI provider to the aiProviderMap
 func AddAIProvider(aiProvider eventHub.AIProvider) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	aiProviderMap[aiProvider.ID] = aiProvider
+	persistEntry(aiProviderBucket, aiProvider.ID, aiProvider)
 }
 
 // GetAIProvider returns an AI provider from the aiProviderMap
 func GetAIProvider(id string) eventHub.AIProvider {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	return aiProviderMap[id]
 }
 
 // DeleteAIProvider deletes an AI provider from the aiProviderMap
 func DeleteAIProvider(id string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	delete(aiProviderMap, id)
+	removeEntry(aiProviderBucket, id)
 }
 
 // GetAllAIProviders returns all the AI providers in the aiProviderMap
 func GetAllAIProviders() []eventHub.AIProvider {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	var aiProviders []eventHub.AIProvider
 	for _, aiProvider := range aiProviderMap {
 		aiProviders = append(aiProviders, aiProvider)
@@ -68,26 +100,42 @@ func GetAllAIProviders() []eventHub.AIProvider {
 
 // AddRateLimitPolicy adds a rate limit policy to the rateLimitPolicyMap
 func AddRateLimitPolicy(rateLimitPolicy eventHub.RateLimitPolicy) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	rateLimitPolicyMap[rateLimitPolicy.Name+rateLimitPolicy.TenantDomain] = rateLimitPolicy
+	persistEntry(rateLimitPolicyBucket, rateLimitPolicy.Name+rateLimitPolicy.TenantDomain, rateLimitPolicy)
 }
 
 // AddSubscriptionPolicy adds a rate limit policy to the subscriptionPolicyMap
 func AddSubscriptionPolicy(rateLimitPolicy eventHub.SubscriptionPolicy) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	subscriptionPolicyMap[rateLimitPolicy.Name+rateLimitPolicy.TenantDomain] = rateLimitPolicy
+	persistEntry(subscriptionPolicyBucket, rateLimitPolicy.Name+rateLimitPolicy.TenantDomain, rateLimitPolicy)
 }
 
-// GetSubscriptionPolicies return the subscription policy map
+// GetSubscriptionPolicies returns a copy of the subscription policy map
 func GetSubscriptionPolicies() map[string]eventHub.SubscriptionPolicy {
-	return subscriptionPolicyMap
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
+	subscriptionPolicies := make(map[string]eventHub.SubscriptionPolicy, len(subscriptionPolicyMap))
+	for key, subscriptionPolicy := range subscriptionPolicyMap {
+		subscriptionPolicies[key] = subscriptionPolicy
+	}
+	return subscriptionPolicies
 }
 
 // GetRateLimitPolicy returns a rate limit policy from the rateLimitPolicyMap
 func GetRateLimitPolicy(name string, tenantDomain string) eventHub.RateLimitPolicy {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	return rateLimitPolicyMap[name+tenantDomain]
 }
 
 // GetAllRateLimitPolicies returns all the rate limit policies in the rateLimitPolicyMap
 func GetAllRateLimitPolicies() []eventHub.RateLimitPolicy {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	var rateLimitPolicies []eventHub.RateLimitPolicy
 	for _, rateLimitPolicy := range rateLimitPolicyMap {
 		rateLimitPolicies = append(rateLimitPolicies, rateLimitPolicy)
@@ -97,45 +145,68 @@ func GetAllRateLimitPolicies() []eventHub.RateLimitPolicy {
 
 // DeleteRateLimitPolicy deletes a rate limit policy from the rateLimitPolicyMap
 func DeleteRateLimitPolicy(name string, tenantDomain string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	delete(rateLimitPolicyMap, name+tenantDomain)
+	removeEntry(rateLimitPolicyBucket, name+tenantDomain)
 }
 
 // DeleteSubscriptionPolicy deletes a subscription policy from the subscriptionPolicyMap
 func DeleteSubscriptionPolicy(name string, tenantDomain string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	delete(subscriptionPolicyMap, name+tenantDomain)
+	removeEntry(subscriptionPolicyBucket, name+tenantDomain)
 }
 
 // UpdateRateLimitPolicy updates a rate limit policy in the rateLimitPolicyMap
 func UpdateRateLimitPolicy(name string, tenantDomain string, rateLimitPolicy eventHub.RateLimitPolicy) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	rateLimitPolicyMap[name+tenantDomain] = rateLimitPolicy
+	persistEntry(rateLimitPolicyBucket, name+tenantDomain, rateLimitPolicy)
 }
 
 // AddApplication adds an application to the applicationMap
 func AddApplication(application Application) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	applicationMap[application.UUID] = application
+	persistEntry(applicationBucket, application.UUID, application)
 }
 
 // AddSubscription adds a subscription to the subscriptionMap
 func AddSubscription(subscription Subscription) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	loggers.LoggerMgtServer.Debugf("Adding subscription to map - UUID: %s, RateLimit: '%s', PolicyName: '%s', Organization: %s",
 		subscription.UUID, subscription.RateLimit, subscription.PolicyName, subscription.Organization)
 	subscriptionMap[subscription.UUID] = subscription
+	persistEntry(subscriptionBucket, subscription.UUID, subscription)
 }
 
 // AddApplicationMapping adds an application mapping to the applicationMappingMap
 func AddApplicationMapping(applicationMapping ApplicationMapping) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	applicationMappingMap[applicationMapping.UUID] = applicationMapping
+	persistEntry(applicationMappingBucket, applicationMapping.UUID, applicationMapping)
 }
 
 // AddApplicationKeyMapping adds an application key mapping to the applicationKeyMappingMap
 func AddApplicationKeyMapping(applicationKeyMapping ApplicationKeyMapping) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	uuid := utils.GetUniqueIDOfApplicationKeyMapping(applicationKeyMapping.ApplicationUUID, applicationKeyMapping.KeyType, applicationKeyMapping.SecurityScheme, applicationKeyMapping.EnvID, applicationKeyMapping.Organization)
 	loggers.LoggerMgtServer.Infof("Adding application key mapping with uuid: %v", uuid)
 	applicationKeyMappingMap[uuid] = applicationKeyMapping
+	persistEntry(applicationKeyMappingBucket, uuid, applicationKeyMapping)
 }
 
 // GetAllApplications returns all the applications in the applicationMap
 func GetAllApplications() []ResolvedApplication {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	var applications []ResolvedApplication
 	for _, application := range applicationMap {
 		resolvedApplication := marshalApplication(application)
@@ -156,6 +227,8 @@ func marshalApplication(application Application) ResolvedApplication {
 
 // GetAllSubscriptions returns all the subscriptions in the subscriptionMap
 func GetAllSubscriptions() []Subscription {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	var subscriptions []Subscription
 	for _, subscription := range subscriptionMap {
 		loggers.LoggerMgtServer.Debugf("Returning subscription - UUID: %s, RateLimit: '%s', PolicyName: '%s', Organization: %s, SubStatus: %s",
@@ -167,6 +240,8 @@ func GetAllSubscriptions() []Subscription {
 
 // GetAllApplicationMappings returns all the application mappings in the applicationMappingMap
 func GetAllApplicationMappings() []ApplicationMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	var applicationMappings []ApplicationMapping
 	for _, applicationMapping := range applicationMappingMap {
 		applicationMappings = append(applicationMappings, applicationMapping)
@@ -176,69 +251,103 @@ func GetAllApplicationMappings() []ApplicationMapping {
 
 // GetApplication returns an application from the applicationMap
 func GetApplication(uuid string) Application {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	return applicationMap[uuid]
 }
 
 // GetSubscription returns a subscription from the subscriptionMap
 func GetSubscription(uuid string) Subscription {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	return subscriptionMap[uuid]
 }
 
 // GetApplicationMapping returns an application mapping from the applicationMappingMap
 func GetApplicationMapping(uuid string) ApplicationMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	return applicationMappingMap[uuid]
 }
 
 // GetApplicationKeyMapping returns an application key mapping from the applicationKeyMappingMap
 func GetApplicationKeyMapping(uuid string) ApplicationKeyMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	return applicationKeyMappingMap[uuid]
 }
 
 // DeleteApplication deletes an application from the applicationMap
 func DeleteApplication(uuid string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	delete(applicationMap, uuid)
+	removeEntry(applicationBucket, uuid)
 }
 
 // DeleteSubscription deletes a subscription from the subscriptionMap
 func DeleteSubscription(uuid string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	delete(subscriptionMap, uuid)
+	removeEntry(subscriptionBucket, uuid)
 }
 
 // DeleteApplicationMapping deletes an application mapping from the applicationMappingMap
 func DeleteApplicationMapping(uuid string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	delete(applicationMappingMap, uuid)
+	removeEntry(applicationMappingBucket, uuid)
 }
 
 // DeleteApplicationKeyMapping deletes an application key mapping from the applicationKeyMappingMap
 func DeleteApplicationKeyMapping(uuid string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	loggers.LoggerMgtServer.Infof("Deleting application key mapping with uuid: %v", uuid)
 	delete(applicationKeyMappingMap, uuid)
+	removeEntry(applicationKeyMappingBucket, uuid)
 }
 
 // UpdateApplication updates an application in the applicationMap
 func UpdateApplication(uuid string, application Application) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	applicationMap[uuid] = application
+	persistEntry(applicationBucket, uuid, application)
 }
 
 // UpdateSubscription updates a subscription in the subscriptionMap
 func UpdateSubscription(uuid string, subscription Subscription) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	loggers.LoggerMgtServer.Debugf("Updating subscription - UUID: %s, RateLimit: '%s', Organization: %s",
 		uuid, subscription.RateLimit, subscription.Organization)
 	subscriptionMap[uuid] = subscription
+	persistEntry(subscriptionBucket, uuid, subscription)
 }
 
 // UpdateApplicationMapping updates an application mapping in the applicationMappingMap
 func UpdateApplicationMapping(uuid string, applicationMapping ApplicationMapping) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	applicationMappingMap[uuid] = applicationMapping
+	persistEntry(applicationMappingBucket, uuid, applicationMapping)
 }
 
 // UpdateApplicationKeyMapping updates an application key mapping in the applicationKeyMappingMap
 func UpdateApplicationKeyMapping(uuid string, applicationKeyMapping ApplicationKeyMapping) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	applicationKeyMappingMap[uuid] = applicationKeyMapping
+	persistEntry(applicationKeyMappingBucket, uuid, applicationKeyMapping)
 }
 
 // GetApplicationKeyMappingByApplicationUUID returns an application key mapping from the applicationKeyMappingMap
 func GetApplicationKeyMappingByApplicationUUID(uuid string) ApplicationKeyMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	for _, applicationKeyMapping := range applicationKeyMappingMap {
 		if applicationKeyMapping.ApplicationUUID == uuid {
 			return applicationKeyMapping
@@ -249,6 +358,8 @@ func GetApplicationKeyMappingByApplicationUUID(uuid string) ApplicationKeyMappin
 
 // GetApplicationKeyMappingByApplicationUUIDAndEnvID returns an application key mapping from the applicationKeyMappingMap
 func GetApplicationKeyMappingByApplicationUUIDAndEnvID(uuid string, envID string) ApplicationKeyMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	for _, applicationKeyMapping := range applicationKeyMappingMap {
 		if applicationKeyMapping.ApplicationUUID == uuid && applicationKeyMapping.EnvID == envID {
 			return applicationKeyMapping
@@ -259,6 +370,8 @@ func GetApplicationKeyMappingByApplicationUUIDAndEnvID(uuid string, envID string
 
 // GetApplicationKeyMappingByApplicationUUIDAndSecurityScheme returns an application key mapping from the applicationKeyMappingMap
 func GetApplicationKeyMappingByApplicationUUIDAndSecurityScheme(uuid string, securityScheme string) ApplicationKeyMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	for _, applicationKeyMapping := range applicationKeyMappingMap {
 		if applicationKeyMapping.ApplicationUUID == uuid && applicationKeyMapping.SecurityScheme == securityScheme {
 			return applicationKeyMapping
@@ -269,6 +382,8 @@ func GetApplicationKeyMappingByApplicationUUIDAndSecurityScheme(uuid string, sec
 
 // GetApplicationKeyMappingByApplicationUUIDAndSecuritySchemeAndEnvID returns an application key mapping from the applicationKeyMappingMap
 func GetApplicationKeyMappingByApplicationUUIDAndSecuritySchemeAndEnvID(uuid string, securityScheme string, envID string) ApplicationKeyMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	for _, applicationKeyMapping := range applicationKeyMappingMap {
 		if applicationKeyMapping.ApplicationUUID == uuid && applicationKeyMapping.SecurityScheme == securityScheme && applicationKeyMapping.EnvID == envID {
 			return applicationKeyMapping
@@ -279,6 +394,8 @@ func GetApplicationKeyMappingByApplicationUUIDAndSecuritySchemeAndEnvID(uuid str
 
 // GetApplicationMappingByApplicationUUID returns an application mapping from the applicationMappingMap
 func GetApplicationMappingByApplicationUUID(uuid string) ApplicationMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	for _, applicationMapping := range applicationMappingMap {
 		if applicationMapping.ApplicationRef == uuid {
 			return applicationMapping
@@ -289,6 +406,8 @@ func GetApplicationMappingByApplicationUUID(uuid string) ApplicationMapping {
 
 // GetApplicationMappingByApplicationUUIDAndSubscriptionUUID returns an application mapping from the applicationMappingMap
 func GetApplicationMappingByApplicationUUIDAndSubscriptionUUID(uuid string, subscriptionUUID string) ApplicationMapping {
+	eventHolderMutex.RLock()
+	defer eventHolderMutex.RUnlock()
 	for _, applicationMapping := range applicationMappingMap {
 		if applicationMapping.ApplicationRef == uuid && applicationMapping.SubscriptionRef == subscriptionUUID {
 			return applicationMapping
@@ -299,64 +418,281 @@ func GetApplicationMappingByApplicationUUIDAndSubscriptionUUID(uuid string, subs
 
 // DeleteAllApplications deletes all the applications in the applicationMap
 func DeleteAllApplications() {
-	applicationMap = make(map[string]Application)
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
+	refreshEntries(applicationBucket, applicationMap, map[string]Application{})
 }
 
 // DeleteAllSubscriptions deletes all the subscriptions in the subscriptionMap
 func DeleteAllSubscriptions() {
-	subscriptionMap = make(map[string]Subscription)
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
+	refreshEntries(subscriptionBucket, subscriptionMap, map[string]Subscription{})
 }
 
 // DeleteAllApplicationMappings deletes all the application mappings in the applicationMappingMap
 func DeleteAllApplicationMappings() {
-	applicationMappingMap = make(map[string]ApplicationMapping)
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
+	refreshEntries(applicationMappingBucket, applicationMappingMap, map[string]ApplicationMapping{})
 }
 
 // DeleteAllApplicationKeyMappings deletes all the application key mappings in the applicationKeyMappingMap
 func DeleteAllApplicationKeyMappings() {
-	applicationKeyMappingMap = make(map[string]ApplicationKeyMapping)
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
+	refreshEntries(applicationKeyMappingBucket, applicationKeyMappingMap, map[string]ApplicationKeyMapping{})
 }
 
-// AddAllSubscriptions adds all the subscriptions in the subscriptionMap
+// AddAllSubscriptions refreshes the subscriptionMap with all the subscriptions, keeping the unchanged ones
 func AddAllSubscriptions(subscriptionMapTemp map[string]Subscription) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	loggers.LoggerMgtServer.Infof("AddAllSubscriptions called with %d subscriptions", len(subscriptionMapTemp))
 	for uuid, sub := range subscriptionMapTemp {
 		loggers.LoggerMgtServer.Infof("  Adding subscription UUID=%s, PolicyName='%s', RateLimit='%s', Org=%s",
 			uuid, sub.PolicyName, sub.RateLimit, sub.Organization)
 	}
-	subscriptionMap = subscriptionMapTemp
+	refreshEntries(subscriptionBucket, subscriptionMap, subscriptionMapTemp)
 	loggers.LoggerMgtServer.Infof("Subscription map now contains %d subscriptions", len(subscriptionMap))
 }
 
-// AddAllApplications adds all the applications in the applicationMap
+// AddAllApplications refreshes the applicationMap with all the applications, keeping the unchanged ones
 func AddAllApplications(applicationMapTemp map[string]Application) {
-	applicationMap = applicationMapTemp
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
+	refreshEntries(applicationBucket, applicationMap, applicationMapTemp)
 }
 
-// AddAllApplicationMappings adds all the application mappings in the applicationMappingMap
+// AddAllApplicationMappings refreshes the applicationMappingMap with all the application mappings, keeping the
+// unchanged ones
 func AddAllApplicationMappings(applicationMappingMapTemp map[string]ApplicationMapping) {
-	applicationMappingMap = applicationMappingMapTemp
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
+	refreshEntries(applicationMappingBucket, applicationMappingMap, applicationMappingMapTemp)
 }
 
-// AddAllApplicationKeyMappings adds all the application key mappings in the applicationKeyMappingMap
+// AddAllApplicationKeyMappings refreshes the applicationKeyMappingMap with all the application key mappings,
+// keeping the unchanged ones
 func AddAllApplicationKeyMappings(applicationKeyMappingMapTemp map[string]ApplicationKeyMapping) {
-	applicationKeyMappingMap = applicationKeyMappingMapTemp
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
+	refreshEntries(applicationKeyMappingBucket, applicationKeyMappingMap, applicationKeyMappingMapTemp)
 }
 
 // DeleteAllSubscriptionsByApplicationsUUID deletes all the subscriptions in the subscriptionMap
 func DeleteAllSubscriptionsByApplicationsUUID(uuid string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	for _, subscription := range subscriptionMap {
 		if subscription.Organization == uuid {
 			delete(subscriptionMap, subscription.UUID)
+			removeEntry(subscriptionBucket, subscription.UUID)
 		}
 	}
 }
 
 // DeleteAllApplicationMappingsByApplicationsUUID deletes all the application mappings in the applicationMappingMap
 func DeleteAllApplicationMappingsByApplicationsUUID(uuid string) {
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
 	for _, applicationMapping := range applicationMappingMap {
 		if applicationMapping.UUID == uuid {
 			delete(applicationMappingMap, applicationMapping.UUID)
+			removeEntry(applicationMappingBucket, applicationMapping.UUID)
+		}
+	}
+}
+
+// Types of the block conditions of the traffic manager
+const (
+	BlockConditionAPI          = "API"
+	BlockConditionApplication  = "APPLICATION"
+	BlockConditionUser         = "USER"
+	BlockConditionSubscription = "SUBSCRIPTION"
+	BlockConditionCustom       = "CUSTOM"
+	BlockConditionIP           = "IP"
+	BlockConditionIPRange      = "IPRANGE"
+)
+
+// blockCondition is an active block condition of the traffic manager
+type blockCondition struct {
+	conditionType string
+	value         string
+	ip            *eventHub.IPCondition
+}
+
+// NewTokenRevokedEvent returns the event sent to the gateways with a revoked token
+func NewTokenRevokedEvent(revokedToken eventHub.RevokedToken) *subscription.Event {
+	return newThrottleDataEvent(TokenRevokedEvent, map[string]string{
+		EventAttributeRevokedToken: revokedToken.JWT,
+		EventAttributeExpiryTime:   strconv.FormatInt(revokedToken.ExpiryTime, 10),
+	})
+}
+
+// NewBlockConditionEvent returns the event sent to the gateways with a block condition which is added or enabled if
+// the state is true, or else removed
+func NewBlockConditionEvent(id int32, conditionType string, value string, state bool,
+	tenantDomain string) *subscription.Event {
+	return newThrottleDataEvent(BlockConditionsUpdatedEvent, map[string]string{
+		EventAttributeBlockConditionID:    strconv.FormatInt(int64(id), 10),
+		EventAttributeBlockConditionType:  strings.ToUpper(conditionType),
+		EventAttributeBlockConditionValue: value,
+		EventAttributeBlockConditionState: strconv.FormatBool(state),
+		EventAttributeTenantDomain:        tenantDomain,
+	})
+}
+
+func newThrottleDataEvent(eventType string, attributes map[string]string) *subscription.Event {
+	return &subscription.Event{Type: eventType, Uuid: uuid.New().String(), TimeStamp: time.Now().UnixMilli(),
+		Application: &subscription.Application{Attributes: attributes}}
+}
+
+// AddRevokedToken adds a revoked token to the revokedTokenMap
+func AddRevokedToken(revokedToken eventHub.RevokedToken) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	revokedTokenMap[revokedToken.JWT] = revokedToken
+}
+
+// AddAllRevokedTokens adds the revoked tokens to the revokedTokenMap
+func AddAllRevokedTokens(revokedTokens []eventHub.RevokedToken) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	for _, revokedToken := range revokedTokens {
+		revokedTokenMap[revokedToken.JWT] = revokedToken
+	}
+}
+
+// GetAllRevokedTokens returns the revoked tokens which are not expired yet. The expired tokens are removed from the
+// revokedTokenMap.
+func GetAllRevokedTokens() []eventHub.RevokedToken {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	now := time.Now().UnixMilli()
+	revokedTokens := make([]eventHub.RevokedToken, 0, len(revokedTokenMap))
+	for jti, revokedToken := range revokedTokenMap {
+		if revokedToken.ExpiryTime > 0 && revokedToken.ExpiryTime < now {
+			delete(revokedTokenMap, jti)
+			continue
+		}
+		revokedTokens = append(revokedTokens, revokedToken)
+	}
+	sort.Slice(revokedTokens, func(i, j int) bool { return revokedTokens[i].JWT < revokedTokens[j].JWT })
+	return revokedTokens
+}
+
+// AddBlockCondition adds a block condition to the blockConditionMap. The value of an IP or IP range condition is
+// the JSON object sent by the traffic manager.
+func AddBlockCondition(id int32, conditionType string, value string, tenantDomain string) error {
+	condition := blockCondition{conditionType: strings.ToUpper(conditionType), value: value}
+	switch condition.conditionType {
+	case BlockConditionAPI, BlockConditionApplication, BlockConditionUser, BlockConditionSubscription,
+		BlockConditionCustom:
+	case BlockConditionIP, BlockConditionIPRange:
+		var ipValue struct {
+			FixedIP    string `json:"fixedIp"`
+			StartingIP string `json:"startingIp"`
+			EndingIP   string `json:"endingIp"`
+			Invert     bool   `json:"invert"`
+		}
+		if err := json.Unmarshal([]byte(value), &ipValue); err != nil {
+			return fmt.Errorf("invalid value of the %s block condition %d: %v", condition.conditionType, id, err)
+		}
+		condition.ip = &eventHub.IPCondition{Type: condition.conditionType, ID: id, FixedIP: ipValue.FixedIP,
+			StartingIP: ipValue.StartingIP, EndingIP: ipValue.EndingIP, Invert: ipValue.Invert,
+			TenantDomain: tenantDomain, State: "true"}
+	default:
+		return fmt.Errorf("unknown type %s of the block condition %d", conditionType, id)
+	}
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	if key, found := findBlockCondition(condition.conditionType, condition.value); found && key < 0 {
+		delete(blockConditionMap, key)
+	}
+	blockConditionMap[id] = condition
+	return nil
+}
+
+// AddAllBlockConditions adds the block conditions loaded from the traffic manager to the blockConditionMap. Only the
+// IP conditions have their IDs, hence the other conditions are kept by negative keys unless they are already held.
+func AddAllBlockConditions(blockConditions eventHub.BlockConditions) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	for _, ip := range blockConditions.IP {
+		condition := ip
+		condition.Type = strings.ToUpper(condition.Type)
+		blockConditionMap[condition.ID] = blockCondition{conditionType: condition.Type, ip: &condition}
+	}
+	key := int32(0)
+	for conditionType, values := range map[string][]string{BlockConditionAPI: blockConditions.API,
+		BlockConditionApplication: blockConditions.Application, BlockConditionUser: blockConditions.User,
+		BlockConditionSubscription: blockConditions.Subscription, BlockConditionCustom: blockConditions.Custom} {
+		for _, value := range values {
+			if _, found := findBlockCondition(conditionType, value); found {
+				continue
+			}
+			for key--; ; key-- {
+				if _, ok := blockConditionMap[key]; !ok {
+					break
+				}
+			}
+			blockConditionMap[key] = blockCondition{conditionType: conditionType, value: value}
+		}
+	}
+}
+
+// findBlockCondition returns the key of a block condition other than an IP condition in the blockConditionMap
+func findBlockCondition(conditionType string, value string) (int32, bool) {
+	for id, condition := range blockConditionMap {
+		if condition.ip == nil && condition.conditionType == conditionType && condition.value == value {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// DeleteBlockCondition deletes a block condition from the blockConditionMap. A condition loaded without its ID is
+// deleted by its type and value.
+func DeleteBlockCondition(id int32, conditionType string, value string) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	if _, ok := blockConditionMap[id]; ok {
+		delete(blockConditionMap, id)
+		return
+	}
+	if key, found := findBlockCondition(strings.ToUpper(conditionType), value); found {
+		delete(blockConditionMap, key)
+	}
+}
+
+// GetBlockConditions returns the active block conditions grouped by their types
+func GetBlockConditions() eventHub.BlockConditions {
+	throttleDataMutex.RLock()
+	defer throttleDataMutex.RUnlock()
+	ids := make([]int32, 0, len(blockConditionMap))
+	for id := range blockConditionMap {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	blockConditions := eventHub.BlockConditions{API: []string{}, Application: []string{}, User: []string{},
+		Subscription: []string{}, Custom: []string{}, IP: []eventHub.IPCondition{}}
+	for _, id := range ids {
+		condition := blockConditionMap[id]
+		switch condition.conditionType {
+		case BlockConditionAPI:
+			blockConditions.API = append(blockConditions.API, condition.value)
+		case BlockConditionApplication:
+			blockConditions.Application = append(blockConditions.Application, condition.value)
+		case BlockConditionUser:
+			blockConditions.User = append(blockConditions.User, condition.value)
+		case BlockConditionSubscription:
+			blockConditions.Subscription = append(blockConditions.Subscription, condition.value)
+		case BlockConditionCustom:
+			blockConditions.Custom = append(blockConditions.Custom, condition.value)
+		default:
+			blockConditions.IP = append(blockConditions.IP, *condition.ip)
 		}
 	}
+	return blockConditions
 }
@@ -0,0 +1,446 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package managementserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	eventHub "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+	bolt "go.etcd.io/bbolt"
+)
+
+// eventStoreSchemaVersion is the version of the layout of the persisted event holder data. It has to be increased
+// whenever the buckets or the persisted types change in an incompatible way, so that older snapshots are discarded.
+const eventStoreSchemaVersion = 1
+
+const (
+	metadataBucket              = "metadata"
+	schemaVersionKey            = "schemaVersion"
+	applicationBucket           = "applications"
+	subscriptionBucket          = "subscriptions"
+	applicationMappingBucket    = "applicationMappings"
+	applicationKeyMappingBucket = "applicationKeyMappings"
+	rateLimitPolicyBucket       = "rateLimitPolicies"
+	subscriptionPolicyBucket    = "subscriptionPolicies"
+	aiProviderBucket            = "aiProviders"
+	corruptSnapshotSuffix       = ".corrupt"
+	eventStoreOpenTimeout       = 5 * time.Second
+	minStoreWriteRetryInterval  = 1 * time.Second
+	maxStoreWriteRetryInterval  = 1 * time.Minute
+)
+
+var dataBuckets = []string{applicationBucket, subscriptionBucket, applicationMappingBucket,
+	applicationKeyMappingBucket, rateLimitPolicyBucket, subscriptionPolicyBucket, aiProviderBucket}
+
+// storeOperation is a write of an entry of the event holder to the store. The entry is removed if the value is nil.
+type storeOperation struct {
+	bucketName string
+	key        string
+	value      []byte
+}
+
+var (
+	// eventStore persists the event holder data on disk. It is nil when the persistence is disabled. It is guarded
+	// by pendingOperationsMutex.
+	eventStore *bolt.DB
+	// pendingOperations are the changes of the event holder which are not written to the store yet. The changes are
+	// queued while the event holder is locked and are written by the store writer, hence the event holder is not
+	// locked while the store syncs to the disk. Changes which fail to be written stay queued and are retried.
+	pendingOperations      []storeOperation
+	pendingOperationsMutex sync.Mutex
+	storeWriterSignal      chan struct{}
+	storeWriterDone        chan struct{}
+	// storeWriteRetryInterval is the delay before retrying the pending operations after a failed write
+	storeWriteRetryInterval = minStoreWriteRetryInterval
+	// snapshotInvalidated is set when the schema version of the snapshot is removed after a failed write
+	snapshotInvalidated bool
+)
+
+// LoadEventStore opens the on-disk store of the event holder data and loads the persisted snapshot into the event
+// holder. Every change made to the event holder afterwards is written to the store. It returns whether a snapshot
+// was restored. A snapshot which can not be read or which was written with a different schema version is discarded.
+func LoadEventStore(path string) (bool, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, fmt.Errorf("error creating the directory of the event store %s: %w", path, err)
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: eventStoreOpenTimeout})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return false, fmt.Errorf("event store %s is locked by another process: %w", path, err)
+		}
+		// The file is not a valid store. It is kept aside for troubleshooting and a new store is created.
+		loggers.LoggerMgtServer.Warnf("Discarding the event store %s as it can not be opened: %v", path, err)
+		if renameErr := os.Rename(path, path+corruptSnapshotSuffix); renameErr != nil {
+			return false, fmt.Errorf("error discarding the corrupt event store %s: %w", path, renameErr)
+		}
+		db, err = bolt.Open(path, 0600, &bolt.Options{Timeout: eventStoreOpenTimeout})
+		if err != nil {
+			return false, fmt.Errorf("error creating the event store %s: %w", path, err)
+		}
+	}
+
+	restored, err := loadSnapshot(db)
+	if err != nil {
+		loggers.LoggerMgtServer.Warnf("Discarding the snapshot in the event store %s: %v", path, err)
+	}
+	if !restored {
+		if err := resetEventStore(db); err != nil {
+			db.Close()
+			return false, fmt.Errorf("error initializing the event store %s: %w", path, err)
+		}
+	}
+	startStoreWriter(db)
+	if restored {
+		eventHolderMutex.RLock()
+		defer eventHolderMutex.RUnlock()
+		loggers.LoggerMgtServer.Infof("Restored %d applications, %d subscriptions, %d application mappings and %d application key mappings from the event store %s",
+			len(applicationMap), len(subscriptionMap), len(applicationMappingMap), len(applicationKeyMappingMap), path)
+	}
+	return restored, nil
+}
+
+// CloseEventStore writes the pending changes and closes the on-disk store of the event holder data
+func CloseEventStore() {
+	pendingOperationsMutex.Lock()
+	db := eventStore
+	eventStore = nil
+	pendingOperationsMutex.Unlock()
+	if db == nil {
+		return
+	}
+	stopStoreWriter(db)
+	if err := db.Close(); err != nil {
+		loggers.LoggerMgtServer.Errorf("Error closing the event store: %v", err)
+	}
+}
+
+// loadSnapshot reads every bucket of the store into the event holder. The event holder is only updated when the
+// whole snapshot is read successfully.
+func loadSnapshot(db *bolt.DB) (bool, error) {
+	var (
+		applications           map[string]Application
+		subscriptions          map[string]Subscription
+		applicationMappings    map[string]ApplicationMapping
+		applicationKeyMappings map[string]ApplicationKeyMapping
+		rateLimitPolicies      map[string]eventHub.RateLimitPolicy
+		subscriptionPolicies   map[string]eventHub.SubscriptionPolicy
+		aiProviders            map[string]eventHub.AIProvider
+	)
+	found := false
+	err := db.View(func(tx *bolt.Tx) error {
+		metadata := tx.Bucket([]byte(metadataBucket))
+		if metadata == nil {
+			// A new store
+			return nil
+		}
+		version := string(metadata.Get([]byte(schemaVersionKey)))
+		if version != strconv.Itoa(eventStoreSchemaVersion) {
+			return fmt.Errorf("snapshot schema version %q does not match the supported version %d", version,
+				eventStoreSchemaVersion)
+		}
+		found = true
+		var err error
+		if applications, err = readBucket[Application](tx, applicationBucket); err != nil {
+			return err
+		}
+		if subscriptions, err = readBucket[Subscription](tx, subscriptionBucket); err != nil {
+			return err
+		}
+		if applicationMappings, err = readBucket[ApplicationMapping](tx, applicationMappingBucket); err != nil {
+			return err
+		}
+		if applicationKeyMappings, err = readBucket[ApplicationKeyMapping](tx, applicationKeyMappingBucket); err != nil {
+			return err
+		}
+		if rateLimitPolicies, err = readBucket[eventHub.RateLimitPolicy](tx, rateLimitPolicyBucket); err != nil {
+			return err
+		}
+		if subscriptionPolicies, err = readBucket[eventHub.SubscriptionPolicy](tx, subscriptionPolicyBucket); err != nil {
+			return err
+		}
+		aiProviders, err = readBucket[eventHub.AIProvider](tx, aiProviderBucket)
+		return err
+	})
+	if err != nil || !found {
+		return false, err
+	}
+	eventHolderMutex.Lock()
+	defer eventHolderMutex.Unlock()
+	applicationMap = applications
+	subscriptionMap = subscriptions
+	applicationMappingMap = applicationMappings
+	applicationKeyMappingMap = applicationKeyMappings
+	rateLimitPolicyMap = rateLimitPolicies
+	subscriptionPolicyMap = subscriptionPolicies
+	aiProviderMap = aiProviders
+	return true, nil
+}
+
+func readBucket[V any](tx *bolt.Tx, bucketName string) (map[string]V, error) {
+	entries := make(map[string]V)
+	bucket := tx.Bucket([]byte(bucketName))
+	if bucket == nil {
+		return nil, fmt.Errorf("bucket %s is missing", bucketName)
+	}
+	err := bucket.ForEach(func(key, value []byte) error {
+		var entry V
+		if err := json.Unmarshal(value, &entry); err != nil {
+			return fmt.Errorf("invalid entry %s in the bucket %s: %w", key, bucketName, err)
+		}
+		entries[string(key)] = entry
+		return nil
+	})
+	return entries, err
+}
+
+// resetEventStore removes all the persisted data and stamps the store with the current schema version
+func resetEventStore(db *bolt.DB) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, bucketName := range append([]string{metadataBucket}, dataBuckets...) {
+			if tx.Bucket([]byte(bucketName)) != nil {
+				if err := tx.DeleteBucket([]byte(bucketName)); err != nil {
+					return err
+				}
+			}
+		}
+		for _, bucketName := range dataBuckets {
+			if _, err := tx.CreateBucket([]byte(bucketName)); err != nil {
+				return err
+			}
+		}
+		metadata, err := tx.CreateBucket([]byte(metadataBucket))
+		if err != nil {
+			return err
+		}
+		return metadata.Put([]byte(schemaVersionKey), []byte(strconv.Itoa(eventStoreSchemaVersion)))
+	})
+}
+
+// isEventStoreOpen returns whether the changes of the event holder are written to the store
+func isEventStoreOpen() bool {
+	pendingOperationsMutex.Lock()
+	defer pendingOperationsMutex.Unlock()
+	return eventStore != nil
+}
+
+// persistEntry queues the write of an entry of the event holder to the store. It is called while the event holder
+// is locked, so that the writes are queued in the order of the changes.
+func persistEntry(bucketName string, key string, entry interface{}) {
+	if !isEventStoreOpen() {
+		return
+	}
+	value, err := json.Marshal(entry)
+	if err != nil {
+		loggers.LoggerMgtServer.Errorf("Error marshalling the entry %s of %s for the event store: %v", key, bucketName, err)
+		return
+	}
+	queueStoreOperation(storeOperation{bucketName: bucketName, key: key, value: value})
+}
+
+// removeEntry queues the removal of an entry of the event holder from the store. It is called while the event
+// holder is locked, so that the removals are queued in the order of the changes.
+func removeEntry(bucketName string, key string) {
+	if !isEventStoreOpen() {
+		return
+	}
+	queueStoreOperation(storeOperation{bucketName: bucketName, key: key})
+}
+
+// refreshEntries makes the entries of the event holder match the latest entries from the control plane. The
+// entries are updated in place and only the entries which were added, changed or removed are written to the store,
+// hence a refresh after restoring a snapshot does not rewrite the whole snapshot.
+func refreshEntries[V any](bucketName string, entries map[string]V, latest map[string]V) {
+	var written, removed int
+	for key := range entries {
+		if _, exists := latest[key]; !exists {
+			delete(entries, key)
+			removeEntry(bucketName, key)
+			removed++
+		}
+	}
+	for key, entry := range latest {
+		if current, exists := entries[key]; exists && reflect.DeepEqual(current, entry) {
+			continue
+		}
+		entries[key] = entry
+		persistEntry(bucketName, key, entry)
+		written++
+	}
+	loggers.LoggerMgtServer.Debugf("Refreshed %s. written: %d, removed: %d", bucketName, written, removed)
+}
+
+// queueStoreOperation adds an operation to the pending operations and wakes up the store writer. The operation is
+// dropped if the store was closed after the caller checked it.
+func queueStoreOperation(operation storeOperation) {
+	pendingOperationsMutex.Lock()
+	defer pendingOperationsMutex.Unlock()
+	if eventStore == nil {
+		return
+	}
+	pendingOperations = append(pendingOperations, operation)
+	signalStoreWriter()
+}
+
+// signalStoreWriter wakes up the store writer. It is called while pendingOperationsMutex is locked.
+func signalStoreWriter() {
+	if storeWriterSignal == nil {
+		// The store writer is stopped and writes the pending operations itself
+		return
+	}
+	select {
+	case storeWriterSignal <- struct{}{}:
+	default:
+		// The store writer is already signalled and writes this operation with the others pending
+	}
+}
+
+// startStoreWriter starts writing the pending operations to the store in the background
+func startStoreWriter(db *bolt.DB) {
+	signal := make(chan struct{}, 1)
+	done := make(chan struct{})
+	pendingOperationsMutex.Lock()
+	eventStore = db
+	storeWriterSignal = signal
+	storeWriterDone = done
+	pendingOperationsMutex.Unlock()
+	go func() {
+		defer close(done)
+		for range signal {
+			if err := writePendingOperations(db); err != nil {
+				scheduleStoreWriteRetry()
+			}
+		}
+	}()
+}
+
+// scheduleStoreWriteRetry wakes up the store writer after storeWriteRetryInterval to retry the operations which
+// failed to be written. The interval is doubled after every failed write up to maxStoreWriteRetryInterval.
+func scheduleStoreWriteRetry() {
+	pendingOperationsMutex.Lock()
+	defer pendingOperationsMutex.Unlock()
+	interval := storeWriteRetryInterval
+	storeWriteRetryInterval = min(storeWriteRetryInterval*2, maxStoreWriteRetryInterval)
+	time.AfterFunc(interval, func() {
+		pendingOperationsMutex.Lock()
+		defer pendingOperationsMutex.Unlock()
+		signalStoreWriter()
+	})
+}
+
+// stopStoreWriter stops the store writer and writes the operations which are still pending. If they can not be
+// written the snapshot stays marked invalid and is discarded when the store is loaded again.
+func stopStoreWriter(db *bolt.DB) {
+	pendingOperationsMutex.Lock()
+	signal, done := storeWriterSignal, storeWriterDone
+	storeWriterSignal = nil
+	pendingOperationsMutex.Unlock()
+	if signal == nil {
+		return
+	}
+	close(signal)
+	<-done
+	if err := writePendingOperations(db); err != nil {
+		loggers.LoggerMgtServer.Errorf("Discarding the changes of the event holder which could not be written. The snapshot in the event store will be discarded on the next start.")
+	}
+	pendingOperationsMutex.Lock()
+	pendingOperations = nil
+	storeWriteRetryInterval = minStoreWriteRetryInterval
+	snapshotInvalidated = false
+	pendingOperationsMutex.Unlock()
+}
+
+// writePendingOperations writes all the pending operations to the store in a single transaction, hence a burst of
+// changes is synced to the disk once. If the write fails, the operations are queued again ahead of the newer ones
+// and the schema version of the snapshot is removed, so that a snapshot missing the changes is not restored if the
+// agent stops before they are written. The next successful write stamps the schema version again.
+func writePendingOperations(db *bolt.DB) error {
+	pendingOperationsMutex.Lock()
+	operations := pendingOperations
+	pendingOperations = nil
+	invalidated := snapshotInvalidated
+	pendingOperationsMutex.Unlock()
+	if len(operations) == 0 {
+		return nil
+	}
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, operation := range operations {
+			bucket, err := tx.CreateBucketIfNotExists([]byte(operation.bucketName))
+			if err != nil {
+				return err
+			}
+			if operation.value == nil {
+				err = bucket.Delete([]byte(operation.key))
+			} else {
+				err = bucket.Put([]byte(operation.key), operation.value)
+			}
+			if err != nil {
+				return fmt.Errorf("error writing the entry %s of %s: %w", operation.key, operation.bucketName, err)
+			}
+		}
+		if !invalidated {
+			return nil
+		}
+		metadata, err := tx.CreateBucketIfNotExists([]byte(metadataBucket))
+		if err != nil {
+			return err
+		}
+		return metadata.Put([]byte(schemaVersionKey), []byte(strconv.Itoa(eventStoreSchemaVersion)))
+	})
+	if err != nil {
+		loggers.LoggerMgtServer.Errorf("Error persisting %d changes of the event holder, retrying: %v", len(operations), err)
+		pendingOperationsMutex.Lock()
+		pendingOperations = append(operations, pendingOperations...)
+		pendingOperationsMutex.Unlock()
+		invalidateSnapshot(db)
+		return err
+	}
+	pendingOperationsMutex.Lock()
+	storeWriteRetryInterval = minStoreWriteRetryInterval
+	snapshotInvalidated = false
+	pendingOperationsMutex.Unlock()
+	loggers.LoggerMgtServer.Debugf("Persisted %d changes of the event holder", len(operations))
+	return nil
+}
+
+// invalidateSnapshot removes the schema version of the snapshot, hence the snapshot is discarded when the store is
+// loaded again
+func invalidateSnapshot(db *bolt.DB) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		metadata := tx.Bucket([]byte(metadataBucket))
+		if metadata == nil {
+			return nil
+		}
+		return metadata.Delete([]byte(schemaVersionKey))
+	})
+	if err != nil {
+		loggers.LoggerMgtServer.Errorf("Error invalidating the snapshot in the event store: %v", err)
+		return
+	}
+	pendingOperationsMutex.Lock()
+	snapshotInvalidated = true
+	pendingOperationsMutex.Unlock()
+}
@@ -17,9 +17,17 @@
 package managementserver
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	eventHub "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
+	bolt "go.etcd.io/bbolt"
 )
 
 func TestAddApplication(t *testing.T) {
@@ -343,3 +351,347 @@ func TestDeleteAllApplicationMappingsByApplicationsUUID(t *testing.T) {
 		assert.NotEqual(t, uuid, appMapping.ApplicationRef)
 	}
 }
+
+func TestGetAllRevokedTokens(t *testing.T) {
+	revokedTokenMap = make(map[string]eventHub.RevokedToken)
+	now := time.Now().UnixMilli()
+	AddAllRevokedTokens([]eventHub.RevokedToken{{JWT: "jti1", ExpiryTime: now + 60000}, {JWT: "jti2", ExpiryTime: now - 1}})
+	AddRevokedToken(eventHub.RevokedToken{JWT: "jti3", ExpiryTime: now + 60000})
+
+	revokedTokens := GetAllRevokedTokens()
+	assert.Equal(t, []eventHub.RevokedToken{{JWT: "jti1", ExpiryTime: now + 60000},
+		{JWT: "jti3", ExpiryTime: now + 60000}}, revokedTokens)
+	_, ok := revokedTokenMap["jti2"]
+	assert.False(t, ok, "Expired token is not removed from the map")
+}
+
+func TestGetBlockConditions(t *testing.T) {
+	blockConditionMap = make(map[int32]blockCondition)
+	assert.Nil(t, AddBlockCondition(1, "API", "/pizzashack/1.0.0", "carbon.super"))
+	assert.Nil(t, AddBlockCondition(2, "USER", "admin", "carbon.super"))
+	assert.Nil(t, AddBlockCondition(3, "IP", `{"fixedIp":"10.0.0.1","invert":false}`, "carbon.super"))
+	assert.Nil(t, AddBlockCondition(4, "IPRANGE", `{"startingIp":"10.0.1.1","endingIp":"10.0.1.9","invert":true}`, "carbon.super"))
+	assert.Nil(t, AddBlockCondition(5, "APPLICATION", "admin:DefaultApplication", "carbon.super"))
+	assert.NotNil(t, AddBlockCondition(6, "IP", "10.0.0.2", "carbon.super"))
+	assert.NotNil(t, AddBlockCondition(7, "UNKNOWN", "value", "carbon.super"))
+	DeleteBlockCondition(5, "APPLICATION", "admin:DefaultApplication")
+
+	blockConditions := GetBlockConditions()
+	assert.Equal(t, []string{"/pizzashack/1.0.0"}, blockConditions.API)
+	assert.Equal(t, []string{"admin"}, blockConditions.User)
+	assert.Empty(t, blockConditions.Application)
+	assert.Equal(t, []eventHub.IPCondition{
+		{Type: "IP", ID: 3, FixedIP: "10.0.0.1", TenantDomain: "carbon.super", State: "true"},
+		{Type: "IPRANGE", ID: 4, StartingIP: "10.0.1.1", EndingIP: "10.0.1.9", Invert: true, TenantDomain: "carbon.super", State: "true"},
+	}, blockConditions.IP)
+}
+
+func TestThrottleDataEventsCarryTheData(t *testing.T) {
+	revoked := NewTokenRevokedEvent(eventHub.RevokedToken{JWT: "jti1", ExpiryTime: 1700000000000})
+	assert.Equal(t, TokenRevokedEvent, revoked.Type)
+	assert.NotEmpty(t, revoked.Uuid)
+	assert.Equal(t, map[string]string{EventAttributeRevokedToken: "jti1", EventAttributeExpiryTime: "1700000000000"},
+		revoked.Application.Attributes)
+
+	blocked := NewBlockConditionEvent(4, "ipRange", `{"startingIp":"10.0.1.1","endingIp":"10.0.1.9"}`, false,
+		"carbon.super")
+	assert.Equal(t, BlockConditionsUpdatedEvent, blocked.Type)
+	assert.Equal(t, map[string]string{
+		EventAttributeBlockConditionID:    "4",
+		EventAttributeBlockConditionType:  "IPRANGE",
+		EventAttributeBlockConditionValue: `{"startingIp":"10.0.1.1","endingIp":"10.0.1.9"}`,
+		EventAttributeBlockConditionState: "false",
+		EventAttributeTenantDomain:        "carbon.super",
+	}, blocked.Application.Attributes)
+}
+
+func TestAddAllBlockConditions(t *testing.T) {
+	blockConditionMap = make(map[int32]blockCondition)
+	assert.Nil(t, AddBlockCondition(1, "API", "/pizzashack/1.0.0", "carbon.super"))
+	AddAllBlockConditions(eventHub.BlockConditions{API: []string{"/pizzashack/1.0.0", "/menu/1.0.0"},
+		User: []string{"admin"}, IP: []eventHub.IPCondition{{Type: "ip", ID: 3, FixedIP: "10.0.0.1",
+			TenantDomain: "carbon.super", State: "true"}}})
+
+	blockConditions := GetBlockConditions()
+	assert.ElementsMatch(t, []string{"/pizzashack/1.0.0", "/menu/1.0.0"}, blockConditions.API,
+		"The loaded conditions should not duplicate the held conditions")
+	assert.Equal(t, []string{"admin"}, blockConditions.User)
+	assert.Equal(t, []eventHub.IPCondition{{Type: "IP", ID: 3, FixedIP: "10.0.0.1", TenantDomain: "carbon.super",
+		State: "true"}}, blockConditions.IP)
+
+	assert.Nil(t, AddBlockCondition(2, "API", "/menu/1.0.0", "carbon.super"))
+	assert.Len(t, GetBlockConditions().API, 2, "The added condition should replace the loaded condition")
+
+	DeleteBlockCondition(8, "user", "admin")
+	DeleteBlockCondition(3, "IP", `{"fixedIp":"10.0.0.1"}`)
+	blockConditions = GetBlockConditions()
+	assert.Empty(t, blockConditions.User, "A loaded condition should be deleted by its type and value")
+	assert.Empty(t, blockConditions.IP)
+}
+
+// resetEventHolder clears the event holder without touching the event store
+func resetEventHolder() {
+	applicationMap = make(map[string]Application)
+	subscriptionMap = make(map[string]Subscription)
+	applicationMappingMap = make(map[string]ApplicationMapping)
+	applicationKeyMappingMap = make(map[string]ApplicationKeyMapping)
+	rateLimitPolicyMap = make(map[string]eventHub.RateLimitPolicy)
+	aiProviderMap = make(map[string]eventHub.AIProvider)
+	subscriptionPolicyMap = make(map[string]eventHub.SubscriptionPolicy)
+}
+
+// reopenEventStore simulates a restart of the agent
+func reopenEventStore(t *testing.T, path string) bool {
+	CloseEventStore()
+	resetEventHolder()
+	restored, err := LoadEventStore(path)
+	require.NoError(t, err)
+	return restored
+}
+
+func TestEventStoreRestoresSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "event-store.db")
+	resetEventHolder()
+	restored, err := LoadEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		CloseEventStore()
+		resetEventHolder()
+	})
+	assert.False(t, restored)
+
+	AddApplication(Application{UUID: "app1", Name: "App1", Organization: "org1"})
+	AddApplication(Application{UUID: "app2", Name: "App2", Organization: "org1"})
+	AddSubscription(Subscription{UUID: "sub1", Organization: "org1", SubscribedAPI: &SubscribedAPI{Name: "API1", Version: "1.0"}})
+	AddApplicationMapping(ApplicationMapping{UUID: "map1", ApplicationRef: "app1", SubscriptionRef: "sub1"})
+	AddApplicationKeyMapping(ApplicationKeyMapping{ApplicationUUID: "app1", SecurityScheme: "OAuth2", KeyType: "PRODUCTION", EnvID: "Default", Organization: "org1"})
+	AddRateLimitPolicy(eventHub.RateLimitPolicy{Name: "10PerMin", TenantDomain: "carbon.super"})
+	AddSubscriptionPolicy(eventHub.SubscriptionPolicy{Name: "Gold", TenantDomain: "carbon.super"})
+	AddAIProvider(eventHub.AIProvider{ID: "provider1", Name: "OpenAI"})
+	UpdateApplication("app2", Application{UUID: "app2", Name: "App2-updated", Organization: "org1"})
+	DeleteApplication("app1")
+
+	assert.True(t, reopenEventStore(t, path))
+	assert.Equal(t, map[string]Application{"app2": {UUID: "app2", Name: "App2-updated", Organization: "org1"}}, applicationMap)
+	assert.Equal(t, "API1", GetSubscription("sub1").SubscribedAPI.Name)
+	assert.Equal(t, "app1", GetApplicationMapping("map1").ApplicationRef)
+	assert.Equal(t, "OAuth2", GetApplicationKeyMappingByApplicationUUID("app1").SecurityScheme)
+	assert.Equal(t, "10PerMin", GetRateLimitPolicy("10PerMin", "carbon.super").Name)
+	assert.Contains(t, GetSubscriptionPolicies(), "Goldcarbon.super")
+	assert.Equal(t, "OpenAI", GetAIProvider("provider1").Name)
+}
+
+func TestEventStoreIncrementalRefresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event-store.db")
+	resetEventHolder()
+	_, err := LoadEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		CloseEventStore()
+		resetEventHolder()
+	})
+	AddAllApplications(map[string]Application{
+		"app1": {UUID: "app1", Name: "App1"},
+		"app2": {UUID: "app2", Name: "App2"},
+	})
+	assert.True(t, reopenEventStore(t, path))
+
+	// A refresh from the control plane where app1 is deleted, app2 is changed and app3 is added
+	refreshed := map[string]Application{
+		"app2": {UUID: "app2", Name: "App2-updated"},
+		"app3": {UUID: "app3", Name: "App3"},
+	}
+	AddAllApplications(refreshed)
+	assert.True(t, reopenEventStore(t, path))
+	assert.Equal(t, refreshed, applicationMap)
+
+	DeleteAllApplications()
+	assert.True(t, reopenEventStore(t, path))
+	assert.Empty(t, applicationMap)
+}
+
+func TestEventStoreQueuesOnlyTheChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event-store.db")
+	resetEventHolder()
+	_, err := LoadEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		CloseEventStore()
+		resetEventHolder()
+	})
+	AddAllApplications(map[string]Application{
+		"app1": {UUID: "app1", Name: "App1"},
+		"app2": {UUID: "app2", Name: "App2"},
+	})
+	// The writer is stopped so that the queued changes can be inspected
+	stopStoreWriter(eventStore)
+	t.Cleanup(func() {
+		pendingOperations = nil
+		startStoreWriter(eventStore)
+	})
+
+	AddAllApplications(map[string]Application{
+		"app2": {UUID: "app2", Name: "App2"},
+		"app3": {UUID: "app3", Name: "App3"},
+	})
+	var changes []string
+	for _, operation := range pendingOperations {
+		changes = append(changes, fmt.Sprintf("%s %s %t", operation.bucketName, operation.key, operation.value != nil))
+	}
+	assert.ElementsMatch(t, []string{"applications app1 false", "applications app3 true"}, changes,
+		"Only the removed and the added applications should be written")
+}
+
+func TestEventStoreKeepsFailedWritesQueued(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event-store.db")
+	resetEventHolder()
+	_, err := LoadEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		CloseEventStore()
+		resetEventHolder()
+	})
+	AddApplication(Application{UUID: "app1", Name: "App1"})
+	db := eventStore
+	// The writer is stopped so that the writes are made by the test
+	stopStoreWriter(db)
+
+	// An operation without a bucket fails the whole transaction
+	AddApplication(Application{UUID: "app2", Name: "App2"})
+	pendingOperations = append(pendingOperations, storeOperation{key: "broken", value: []byte("{}")})
+	assert.Error(t, writePendingOperations(db))
+	require.Len(t, pendingOperations, 2, "The failed changes should stay queued")
+	assert.Equal(t, "app2", pendingOperations[0].key)
+	require.NoError(t, db.View(func(tx *bolt.Tx) error {
+		assert.Nil(t, tx.Bucket([]byte(metadataBucket)).Get([]byte(schemaVersionKey)),
+			"The snapshot missing the changes should be marked invalid")
+		return nil
+	}))
+
+	pendingOperations = pendingOperations[:1]
+	assert.NoError(t, writePendingOperations(db))
+	assert.Empty(t, pendingOperations)
+	startStoreWriter(db)
+	assert.True(t, reopenEventStore(t, path), "The snapshot should be valid once the changes are written")
+	assert.ElementsMatch(t, []string{"app1", "app2"}, applicationUUIDs())
+}
+
+func TestEventStoreDiscardsSnapshotWithUnwrittenChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event-store.db")
+	resetEventHolder()
+	_, err := LoadEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		CloseEventStore()
+		resetEventHolder()
+	})
+	AddApplication(Application{UUID: "app1", Name: "App1"})
+
+	pendingOperationsMutex.Lock()
+	pendingOperations = append(pendingOperations, storeOperation{key: "broken", value: []byte("{}")})
+	pendingOperationsMutex.Unlock()
+	assert.False(t, reopenEventStore(t, path), "A snapshot missing changes should not be restored")
+	assert.Empty(t, applicationMap)
+}
+
+func TestEventStoreDiscardsIncompatibleSnapshots(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(tx *bolt.Tx) error
+	}{
+		{
+			name: "older schema version",
+			tamper: func(tx *bolt.Tx) error {
+				return tx.Bucket([]byte(metadataBucket)).Put([]byte(schemaVersionKey), []byte("0"))
+			},
+		},
+		{
+			name: "corrupt entry",
+			tamper: func(tx *bolt.Tx) error {
+				return tx.Bucket([]byte(applicationBucket)).Put([]byte("broken"), []byte("{not json"))
+			},
+		},
+		{
+			name: "missing bucket",
+			tamper: func(tx *bolt.Tx) error {
+				return tx.DeleteBucket([]byte(subscriptionBucket))
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "event-store.db")
+			resetEventHolder()
+			_, err := LoadEventStore(path)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				CloseEventStore()
+				resetEventHolder()
+			})
+			AddApplication(Application{UUID: "app1", Name: "App1"})
+			require.NoError(t, eventStore.Update(test.tamper))
+
+			assert.False(t, reopenEventStore(t, path))
+			assert.Empty(t, applicationMap)
+
+			// The discarded store is usable again
+			AddApplication(Application{UUID: "app2", Name: "App2"})
+			assert.True(t, reopenEventStore(t, path))
+			assert.Equal(t, []string{"app2"}, applicationUUIDs())
+		})
+	}
+}
+
+func TestEventStoreDiscardsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event-store.db")
+	require.NoError(t, os.WriteFile(path, []byte("not a bolt database"), 0600))
+	resetEventHolder()
+	restored, err := LoadEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		CloseEventStore()
+		resetEventHolder()
+	})
+	assert.False(t, restored)
+	assert.FileExists(t, path+corruptSnapshotSuffix)
+
+	AddApplication(Application{UUID: "app1", Name: "App1"})
+	assert.True(t, reopenEventStore(t, path))
+	assert.Equal(t, []string{"app1"}, applicationUUIDs())
+}
+
+func applicationUUIDs() []string {
+	uuids := make([]string, 0, len(applicationMap))
+	for uuid := range applicationMap {
+		uuids = append(uuids, uuid)
+	}
+	return uuids
+}
+
+func TestEventHolderConcurrentAccess(t *testing.T) {
+	resetEventHolder()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		// A control plane refresh replacing the maps while the gateways read them
+		go func(i int) {
+			defer wg.Done()
+			uuid := fmt.Sprintf("app%d", i)
+			AddAllApplications(map[string]Application{uuid: {UUID: uuid, Organization: "org1"}})
+			AddAllSubscriptions(map[string]Subscription{uuid: {UUID: uuid, Organization: "org1"}})
+			AddSubscriptionPolicy(eventHub.SubscriptionPolicy{Name: uuid, TenantDomain: "carbon.super"})
+			AddApplicationKeyMapping(ApplicationKeyMapping{ApplicationUUID: uuid, KeyType: "PRODUCTION"})
+		}(i)
+		go func() {
+			defer wg.Done()
+			GetAllApplications()
+			GetAllSubscriptions()
+			for name := range GetSubscriptionPolicies() {
+				DeleteSubscriptionPolicy(name, "")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, GetAllApplications(), 1)
+	assert.Len(t, GetAllSubscriptions(), 1)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/constants"
+	eventHub "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/eventhub/types"
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/utils"
 	"gopkg.in/yaml.v2"
@@ -63,6 +64,12 @@ func StartInternalServer(port uint) {
 		applicationMappingList := GetAllApplicationMappings()
 		c.JSON(http.StatusOK, ApplicationMappingList{List: applicationMappingList})
 	})
+	r.GET("/revokedtokens", func(c *gin.Context) {
+		c.JSON(http.StatusOK, eventHub.RevokedTokenList{List: GetAllRevokedTokens()})
+	})
+	r.GET("/blockconditions", func(c *gin.Context) {
+		c.JSON(http.StatusOK, GetBlockConditions())
+	})
 	r.POST("/apis", func(c *gin.Context) {
 		var event APICPEvent
 		if err := c.ShouldBindJSON(&event); err != nil {
@@ -123,6 +123,35 @@ const (
 	DeleteEvent EventType = "DELETE"
 )
 
+// Types of the events sent to the gateways when a token is revoked or a block condition changes. The subscription
+// event has no fields for them, hence the revoked token or the block condition is carried in the attributes of the
+// application of the event. The gateways load the complete data from /revokedtokens and /blockconditions of the
+// internal server when they connect and apply the events afterwards.
+const (
+	// TokenRevokedEvent is sent when a token is revoked
+	TokenRevokedEvent = "TOKEN_REVOKED"
+	// BlockConditionsUpdatedEvent is sent when a block condition is added, enabled, disabled or deleted
+	BlockConditionsUpdatedEvent = "BLOCK_CONDITIONS_UPDATED"
+)
+
+// Attributes of the TOKEN_REVOKED and BLOCK_CONDITIONS_UPDATED events
+const (
+	// EventAttributeRevokedToken is the JTI or the signature of the revoked token
+	EventAttributeRevokedToken = "revokedToken"
+	// EventAttributeExpiryTime is the expiry time of the revoked token in milliseconds
+	EventAttributeExpiryTime = "expiryTime"
+	// EventAttributeBlockConditionID is the ID of the block condition
+	EventAttributeBlockConditionID = "blockConditionId"
+	// EventAttributeBlockConditionType is the type of the block condition, such as API or IPRANGE
+	EventAttributeBlockConditionType = "blockConditionType"
+	// EventAttributeBlockConditionValue is the value of the block condition, which is a JSON object for IP conditions
+	EventAttributeBlockConditionValue = "blockConditionValue"
+	// EventAttributeBlockConditionState is true if the block condition is enabled and false if it is removed
+	EventAttributeBlockConditionState = "blockConditionState"
+	// EventAttributeTenantDomain is the tenant domain of the block condition
+	EventAttributeTenantDomain = "tenantDomain"
+)
+
 // API holds the api data from adapter api event
 type API struct {
 	APIUUID                string                  `json:"apiUUID"`
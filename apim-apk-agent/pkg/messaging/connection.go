@@ -44,8 +44,8 @@ var (
 func init() {
 	NotificationChannel = make(chan amqp.Delivery)
 	KeyManagerChannel = make(chan amqp.Delivery)
-	RevokedTokenChannel = make(chan amqp.Delivery)
-	ThrottleDataChannel = make(chan amqp.Delivery)
+	RevokedTokenChannel = make(chan amqp.Delivery, eventChannelBufferSize)
+	ThrottleDataChannel = make(chan amqp.Delivery, eventChannelBufferSize)
 }
 
 // EventListeningEndpoints represents the list of endpoints
@@ -271,16 +271,27 @@ func handleEvent(c *Consumer, key string) error {
 		}
 	} else if strings.EqualFold(key, tokenRevocation) {
 		for event := range deliveries {
-			RevokedTokenChannel <- event
+			forwardEvent(event, RevokedTokenChannel, key)
 		}
 	} else if strings.EqualFold(key, throttleData) {
 		for event := range deliveries {
-			ThrottleDataChannel <- event
+			forwardEvent(event, ThrottleDataChannel, key)
 		}
 	}
 	return nil
 }
 
+// forwardEvent passes the event to the channel. When the channel is full the consumer waits until the listener takes
+// the events, which holds back the deliveries of the queue rather than losing a token revocation or a block condition.
+func forwardEvent(event amqp.Delivery, channel chan amqp.Delivery, key string) {
+	select {
+	case channel <- event:
+	default:
+		logger.LoggerMsg.Warnf("The %s event channel is full. Hence waiting to pass the event %s", key, event.MessageId)
+		channel <- event
+	}
+}
+
 // InitiateJMSConnection to pass event consumption
 func InitiateJMSConnection(eventListeningEndpoints []string) error {
 	var err error
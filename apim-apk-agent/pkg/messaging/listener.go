@@ -36,6 +36,8 @@ var (
 
 const (
 	consumerTag string = "jms-consumer"
+	// eventChannelBufferSize is the number of token revocation or throttle data events held until they are processed
+	eventChannelBufferSize int = 1000
 )
 
 const (
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (https://www.wso2.com)
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	k8smetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const reconciliationMetricPrefix = "apim_apk_agent_reconciliation_"
+
+var (
+	reconciliationTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: reconciliationMetricPrefix + "last_run_timestamp_seconds",
+		Help: "Unix time at which the last drift reconciliation started.",
+	})
+	reconciliationDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: reconciliationMetricPrefix + "last_run_duration_seconds",
+		Help: "Time taken by the last drift reconciliation.",
+	})
+	reconciliationSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: reconciliationMetricPrefix + "last_run_success",
+		Help: "Whether the last drift reconciliation completed without errors (1) or not (0).",
+	})
+	reconciliationAPIs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: reconciliationMetricPrefix + "last_run_apis",
+		Help: "Number of APIs per action taken by the last drift reconciliation.",
+	}, []string{"action"})
+	reconciliationRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: reconciliationMetricPrefix + "runs_total",
+		Help: "Number of drift reconciliations per result.",
+	}, []string{"result"})
+)
+
+// registerReconciliationMetrics registers the drift reconciliation metrics.
+func registerReconciliationMetrics() {
+	k8smetrics.Registry.MustRegister(reconciliationTimestamp, reconciliationDuration, reconciliationSuccess,
+		reconciliationAPIs, reconciliationRuns)
+}
+
+// RecordReconciliation records the outcome of a drift reconciliation. apiCounts holds the number of APIs keyed by
+// the action taken on them.
+func RecordReconciliation(startTime time.Time, duration time.Duration, succeeded bool, apiCounts map[string]int) {
+	reconciliationTimestamp.Set(float64(startTime.Unix()))
+	reconciliationDuration.Set(duration.Seconds())
+	result := "failure"
+	reconciliationSuccess.Set(0)
+	if succeeded {
+		result = "success"
+		reconciliationSuccess.Set(1)
+	}
+	reconciliationRuns.WithLabelValues(result).Inc()
+	for action, count := range apiCounts {
+		reconciliationAPIs.WithLabelValues(action).Set(float64(count))
+	}
+}
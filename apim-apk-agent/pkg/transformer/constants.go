@@ -106,4 +106,21 @@ const (
 	// Version constants
 	v1 = "v1"
 	v2 = "v2"
+
+	// Local CR generation constants
+	productionEnv             = "production"
+	sandboxEnv                = "sandbox"
+	apiBackendSuffix          = "api"
+	gatewayListenerName       = "httpslistener"
+	defaultProductionHostname = "default.gw.wso2.com"
+	defaultSandboxHostname    = "default.sandbox.gw.wso2.com"
+	maxRulesPerRoute          = 8
+)
+
+// CR generation modes which can be configured under the data plane configurations
+const (
+	// CRGenerationModeLocal generates the CRs of an API inside the agent
+	CRGenerationModeLocal = "local"
+	// CRGenerationModeRemote generates the CRs of an API by calling the config deployer
+	CRGenerationModeRemote = "remote"
 )
@@ -0,0 +1,998 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package transformer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	neturl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	dpv1alpha1 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha1"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	dpv1alpha4 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha4"
+	"github.com/wso2/product-apim-tooling/apim-apk-agent/internal/constants"
+	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// pathParamRegex matches a templated path parameter segment such as {orderId}
+var pathParamRegex = regexp.MustCompile(`\{[^}]*\}`)
+
+// GenerateK8sArtifacts generates the CR set of an API using the given CR generation mode. The remote mode posts
+// the apk-conf to the config deployer, while the local mode builds the CRs from the API model inside the agent.
+func GenerateK8sArtifacts(mode string, apkConf string, apk *API, apiDefinition string, certContainer CertContainer, k8ResourceGenEndpoint string, organizationID string) (*K8sArtifacts, error) {
+	switch mode {
+	case CRGenerationModeRemote:
+		return GenerateCRs(apkConf, apiDefinition, certContainer, k8ResourceGenEndpoint, organizationID)
+	case CRGenerationModeLocal:
+		return GenerateCRsLocally(apk, apiDefinition, certContainer, organizationID)
+	default:
+		logger.LoggerTransformer.Errorf("Unknown CR generation mode %q provided. Unable to generate CRDs.", mode)
+		return nil, fmt.Errorf("unknown CR generation mode %q, expected %q or %q", mode, CRGenerationModeRemote, CRGenerationModeLocal)
+	}
+}
+
+// GenerateCRsLocally builds the CR set for the given apk-conf API model without calling the config deployer.
+// The API, route, backend, authentication and API level policy CRs are named the way the config deployer names
+// them, hence switching the CR generation mode updates the resources already in the cluster instead of adding
+// new ones.
+func GenerateCRsLocally(apk *API, apiDefinition string, certContainer CertContainer, organizationID string) (*K8sArtifacts, error) {
+	if apk == nil {
+		logger.LoggerTransformer.Error("Empty API model provided. Unable to generate CRDs.")
+		return nil, errors.New("Error: API can't be empty")
+	}
+
+	if apiDefinition == "" {
+		logger.LoggerTransformer.Error("Empty api definition provided. Unable to generate CRDs.")
+		return nil, errors.New("Error: API Definition can't be empty")
+	}
+
+	k8sArtifact := newK8sArtifacts()
+	uniqueID := apk.ID
+	if uniqueID == "" {
+		uniqueID = GetUniqueIDForAPI(apk.Name, apk.Version, organizationID)
+	}
+	generator := &crGenerator{api: apk, organization: organizationID, uniqueID: uniqueID, artifact: &k8sArtifact}
+	if err := generator.generate(apiDefinition); err != nil {
+		logger.LoggerTransformer.Errorf("Error while generating the CRs for API %s:%s: %v", apk.Name, apk.Version, err)
+		return nil, err
+	}
+
+	addCertificatesAndSecrets(certContainer, &k8sArtifact)
+	return &k8sArtifact, nil
+}
+
+// crGenerator holds the state shared while mapping a single apk-conf API model into CRs
+type crGenerator struct {
+	api          *API
+	organization string
+	uniqueID     string
+	artifact     *K8sArtifacts
+	// resourcePolicy is the name of the APIPolicy attached to every operation, empty when the API has none
+	resourcePolicy string
+}
+
+// generate populates the artifact with every CR required by the API
+func (g *crGenerator) generate(apiDefinition string) error {
+	definitionConfigMap, err := g.definitionConfigMap(apiDefinition)
+	if err != nil {
+		return err
+	}
+	g.artifact.ConfigMaps[definitionConfigMap.Name] = definitionConfigMap
+
+	apiType := restType
+	if g.isGraphQL() {
+		apiType = "GraphQL"
+	}
+	g.artifact.API = dpv1alpha3.API{
+		TypeMeta:   metav1.TypeMeta{Kind: k8sKindAPI, APIVersion: dpv1alpha3.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(g.uniqueID),
+		Spec: dpv1alpha3.APISpec{
+			APIName:           g.api.Name,
+			APIVersion:        g.api.Version,
+			IsDefaultVersion:  g.api.DefaultVersion,
+			DefinitionFileRef: definitionConfigMap.Name,
+			DefinitionPath:    g.api.DefinitionPath,
+			APIType:           apiType,
+			BasePath:          fullBasePath(g.api.Context, g.api.Version),
+			Organization:      g.organization,
+			SystemAPI:         false,
+		},
+	}
+	if g.api.AdditionalProperties != nil {
+		for _, property := range *g.api.AdditionalProperties {
+			g.artifact.API.Spec.APIProperties = append(g.artifact.API.Spec.APIProperties,
+				dpv1alpha3.Property{Name: property.Name, Value: property.Value})
+		}
+	}
+
+	// Backends are generated first so that the ones created for policies never replace an endpoint backend
+	backendNames := make(map[string]string)
+	for _, env := range []string{productionEnv, sandboxEnv} {
+		endpoint, err := g.endpointOf(env)
+		if err != nil {
+			return err
+		}
+		if endpoint == nil {
+			continue
+		}
+		backendName, err := g.generateBackend(*endpoint, apiBackendSuffix, env)
+		if err != nil {
+			return err
+		}
+		backendNames[env] = backendName
+		g.generateAIRateLimitPolicy(*endpoint, env, backendName)
+		g.generateAuthentication(env)
+	}
+
+	if err := g.generateAPIPolicy(); err != nil {
+		return err
+	}
+	g.generateAPIRateLimitPolicy()
+
+	for _, env := range []string{productionEnv, sandboxEnv} {
+		backendName, found := backendNames[env]
+		if !found {
+			continue
+		}
+		routeRefs, err := g.generateRoutes(env, backendName)
+		if err != nil {
+			return err
+		}
+		envConfigs := []dpv1alpha3.EnvConfig{{RouteRefs: routeRefs}}
+		if env == productionEnv {
+			g.artifact.API.Spec.Production = envConfigs
+		} else {
+			g.artifact.API.Spec.Sandbox = envConfigs
+		}
+	}
+	return nil
+}
+
+// labels returns a new copy of the labels added to every CR of the API
+func (g *crGenerator) labels() map[string]string {
+	return map[string]string{
+		"api-name":           generateSHA1Hash(g.api.Name),
+		"api-version":        generateSHA1Hash(g.api.Version),
+		k8sOrganizationField: generateSHA1Hash(g.organization),
+		"managed-by":         "apk",
+	}
+}
+
+func (g *crGenerator) objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Labels: g.labels()}
+}
+
+func (g *crGenerator) isGraphQL() bool {
+	return strings.EqualFold(g.api.Type, "GRAPHQL")
+}
+
+func (g *crGenerator) operations() []Operation {
+	if g.api.Operations == nil {
+		return nil
+	}
+	return *g.api.Operations
+}
+
+// endpointOf returns the endpoint configured for the given environment, nil if there is none. The CRs are generated
+// with a single backend per environment, hence an API with several endpoints for an environment is rejected instead
+// of dropping the others.
+func (g *crGenerator) endpointOf(env string) (*EndpointConfiguration, error) {
+	if g.api.EndpointConfigurations == nil {
+		return nil, nil
+	}
+	endpoints := g.api.EndpointConfigurations.Production
+	if env == sandboxEnv {
+		endpoints = g.api.EndpointConfigurations.Sandbox
+	}
+	if endpoints == nil {
+		return nil, nil
+	}
+	var configured *EndpointConfiguration
+	for i := range *endpoints {
+		if (*endpoints)[i].Endpoint == "" {
+			continue
+		}
+		if configured != nil {
+			return nil, fmt.Errorf("the API has more than one %s endpoint, which is not supported by the %q CR "+
+				"generation mode, use the %q mode instead", env, CRGenerationModeLocal, CRGenerationModeRemote)
+		}
+		configured = &(*endpoints)[i]
+	}
+	return configured, nil
+}
+
+func (g *crGenerator) certConfigMapName(certFile string) string {
+	return g.uniqueID + "-" + strings.Split(certFile, ".")[0]
+}
+
+// definitionConfigMap stores the gzipped API definition in the ConfigMap referred by the API CR
+func (g *crGenerator) definitionConfigMap(apiDefinition string) (*corev1.ConfigMap, error) {
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write([]byte(apiDefinition)); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: g.objectMeta(g.uniqueID + "-definition"),
+		BinaryData: map[string][]byte{"definition": compressed.Bytes()},
+	}, nil
+}
+
+// generateBackend creates the Backend CR for an endpoint and returns its name. As in the config deployer, the name
+// is the hash of the organization, API name, API version and the given qualifiers, hence an existing Backend with
+// the same name is reused.
+func (g *crGenerator) generateBackend(endpoint EndpointConfiguration, suffix string, qualifiers ...string) (string, error) {
+	nameParts := append([]string{g.organization, g.api.Name, g.api.Version}, qualifiers...)
+	name := "backend-" + generateSHA1Hash(strings.Join(nameParts, "-")) + "-" + suffix
+	if _, found := g.artifact.Backends[name]; found {
+		return name, nil
+	}
+	spec, err := parseBackendURL(endpoint.Endpoint)
+	if err != nil {
+		return "", err
+	}
+	if endpoint.EndCertificate.Key != "" {
+		spec.TLS = &dpv1alpha2.TLSConfig{
+			ConfigMapRef: &dpv1alpha2.RefConfig{Name: g.certConfigMapName(endpoint.EndCertificate.Key), Key: endpoint.EndCertificate.Key},
+		}
+	}
+	if endpoint.EndSecurity.Enabled {
+		securityType := endpoint.EndSecurity.SecurityType
+		if securityType.APIKeyValueKey != "" {
+			spec.Security = &dpv1alpha2.SecurityConfig{
+				APIKey: &dpv1alpha2.APIKeySecurityConfig{
+					In:        securityType.In,
+					Name:      securityType.APIKeyNameKey,
+					ValueFrom: dpv1alpha2.ValueRef{Name: securityType.SecretName, ValueKey: securityType.APIKeyValueKey},
+				},
+			}
+		} else {
+			spec.Security = &dpv1alpha2.SecurityConfig{
+				Basic: &dpv1alpha2.BasicSecurityConfig{
+					SecretRef: dpv1alpha2.SecretRef{
+						Name:        securityType.SecretName,
+						UsernameKey: securityType.UsernameKey,
+						PasswordKey: securityType.PasswordKey,
+					},
+				},
+			}
+		}
+	}
+	g.artifact.Backends[name] = &dpv1alpha2.Backend{
+		TypeMeta:   metav1.TypeMeta{Kind: "Backend", APIVersion: dpv1alpha2.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec:       spec,
+	}
+	return name, nil
+}
+
+// parseBackendURL maps an endpoint URL into the service, protocol and base path of a Backend CR
+func parseBackendURL(endpointURL string) (dpv1alpha2.BackendSpec, error) {
+	parsedURL, err := neturl.Parse(endpointURL)
+	if err != nil || parsedURL.Hostname() == "" {
+		return dpv1alpha2.BackendSpec{}, fmt.Errorf("invalid endpoint URL %q", endpointURL)
+	}
+	protocol := dpv1alpha2.BackendProtocolType(strings.ToLower(parsedURL.Scheme))
+	port := uint64(80)
+	if protocol == dpv1alpha2.HTTPSProtocol || protocol == dpv1alpha2.WSSProtocol {
+		port = 443
+	}
+	if parsedURL.Port() != "" {
+		port, err = strconv.ParseUint(parsedURL.Port(), 10, 32)
+		if err != nil {
+			return dpv1alpha2.BackendSpec{}, fmt.Errorf("invalid port in endpoint URL %q", endpointURL)
+		}
+	}
+	return dpv1alpha2.BackendSpec{
+		Services: []dpv1alpha2.Service{{Host: parsedURL.Hostname(), Port: uint32(port)}},
+		Protocol: protocol,
+		BasePath: parsedURL.Path,
+	}, nil
+}
+
+// generateAIRateLimitPolicy creates the AIRateLimitPolicy CR of an endpoint when AI rate limiting is enabled
+func (g *crGenerator) generateAIRateLimitPolicy(endpoint EndpointConfiguration, env string, backendName string) {
+	if !endpoint.AIRatelimit.Enabled {
+		return
+	}
+	// The name matches the one used when deleting AI rate limit policies of an API
+	name := generateSHA1Hash(g.api.Name + g.api.Version + env)
+	g.artifact.AIRateLimitPolicies[name] = &dpv1alpha3.AIRateLimitPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "AIRateLimitPolicy", APIVersion: dpv1alpha3.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: dpv1alpha3.AIRateLimitPolicySpec{
+			Override: &dpv1alpha3.AIRateLimit{
+				Organization: g.organization,
+				TokenCount: &dpv1alpha3.TokenCount{
+					Unit:               endpoint.AIRatelimit.Token.Unit,
+					RequestTokenCount:  uint32(endpoint.AIRatelimit.Token.PromptLimit),
+					ResponseTokenCount: uint32(endpoint.AIRatelimit.Token.CompletionLimit),
+					TotalTokenCount:    uint32(endpoint.AIRatelimit.Token.TotalLimit),
+				},
+				RequestCount: &dpv1alpha3.RequestCount{
+					RequestsPerUnit: uint32(endpoint.AIRatelimit.Request.RequestLimit),
+					Unit:            endpoint.AIRatelimit.Request.Unit,
+				},
+			},
+			TargetRef: policyTargetRef("Backend", backendName),
+		},
+	}
+}
+
+// generateAPIPolicy creates the API level APIPolicy CR carrying the CORS, subscription validation, AI provider
+// and API level mediation policies
+func (g *crGenerator) generateAPIPolicy() error {
+	policySpec, err := g.policySpec(g.api.APIPolicies, apiBackendSuffix)
+	if err != nil {
+		return err
+	}
+	if policySpec == nil {
+		policySpec = &dpv1alpha4.PolicySpec{}
+	}
+	if g.api.CorsConfig != nil && g.api.CorsConfig.CORSConfigurationEnabled {
+		policySpec.CORSPolicy = &dpv1alpha4.CORSPolicy{
+			Enabled:                       true,
+			AccessControlAllowCredentials: g.api.CorsConfig.AccessControlAllowCredentials,
+			AccessControlAllowHeaders:     g.api.CorsConfig.AccessControlAllowHeaders,
+			AccessControlAllowMethods:     g.api.CorsConfig.AccessControlAllowMethods,
+			AccessControlAllowOrigins:     g.api.CorsConfig.AccessControlAllowOrigins,
+		}
+	}
+	policySpec.SubscriptionValidation = g.api.SubscriptionValidation
+	if g.api.AIProvider != nil && g.api.AIProvider.Name != "" {
+		policySpec.AIProvider = &dpv1alpha4.AIProviderReference{Name: g.api.AIProvider.Name}
+	}
+	if isEmptyPolicySpec(policySpec) {
+		return nil
+	}
+	name := g.uniqueID + "-api-policy"
+	g.artifact.APIPolicies[name] = g.apiPolicy(name, policySpec, k8sKindAPI)
+	// The config deployer attaches a copy of the API level policy to every operation as well
+	g.resourcePolicy = g.uniqueID + "-resource-policy"
+	g.artifact.APIPolicies[g.resourcePolicy] = g.apiPolicy(g.resourcePolicy, policySpec.DeepCopy(), "Resource")
+	return nil
+}
+
+// generateResourcePolicy creates the APIPolicy CR holding the mediation policies of an operation and returns its
+// name. An empty name is returned when the operation has no policies that need an APIPolicy.
+func (g *crGenerator) generateResourcePolicy(operation Operation) (string, error) {
+	qualifier := operationHash(operation)
+	policySpec, err := g.policySpec(operation.OperationPolicies, qualifier)
+	if err != nil || policySpec == nil || isEmptyPolicySpec(policySpec) {
+		return "", err
+	}
+	name := g.uniqueID + "-resource-policy-" + qualifier
+	g.artifact.APIPolicies[name] = g.apiPolicy(name, policySpec, "Resource")
+	return name, nil
+}
+
+func (g *crGenerator) apiPolicy(name string, policySpec *dpv1alpha4.PolicySpec, targetKind string) *dpv1alpha4.APIPolicy {
+	return &dpv1alpha4.APIPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "APIPolicy", APIVersion: dpv1alpha4.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: dpv1alpha4.APIPolicySpec{
+			Default:   policySpec,
+			TargetRef: policyTargetRef(targetKind, g.uniqueID),
+		},
+	}
+}
+
+// policySpec maps the interceptor, backend JWT and model based round robin policies into an APIPolicy spec.
+// Header modification, redirect and mirror policies are handled as HTTPRoute filters instead.
+func (g *crGenerator) policySpec(policies *OperationPolicies, qualifier string) (*dpv1alpha4.PolicySpec, error) {
+	if policies == nil {
+		return nil, nil
+	}
+	policySpec := &dpv1alpha4.PolicySpec{}
+	for _, policy := range policies.Request {
+		switch parameters := policyParameters(policy.Parameters).(type) {
+		case InterceptorService:
+			name, err := g.generateInterceptorService(parameters, "request", qualifier)
+			if err != nil {
+				return nil, err
+			}
+			policySpec.RequestInterceptors = append(policySpec.RequestInterceptors, dpv1alpha4.InterceptorReference{Name: name})
+		case BackendJWT:
+			policySpec.BackendJWTPolicy = &dpv1alpha4.BackendJWTToken{Name: g.generateBackendJWT(parameters)}
+		case ModelBasedRoundRobin:
+			modelBasedRoundRobin, err := g.modelBasedRoundRobin(parameters)
+			if err != nil {
+				return nil, err
+			}
+			policySpec.ModelBasedRoundRobin = modelBasedRoundRobin
+		}
+	}
+	for _, policy := range policies.Response {
+		if parameters, ok := policyParameters(policy.Parameters).(InterceptorService); ok {
+			name, err := g.generateInterceptorService(parameters, "response", qualifier)
+			if err != nil {
+				return nil, err
+			}
+			policySpec.ResponseInterceptors = append(policySpec.ResponseInterceptors, dpv1alpha4.InterceptorReference{Name: name})
+		}
+	}
+	return policySpec, nil
+}
+
+// policyParameters dereferences the parameters that are stored as pointers in the apk-conf model
+func policyParameters(parameters Parameter) Parameter {
+	switch value := parameters.(type) {
+	case *InterceptorService:
+		return *value
+	case *BackendJWT:
+		return *value
+	}
+	return parameters
+}
+
+func isEmptyPolicySpec(policySpec *dpv1alpha4.PolicySpec) bool {
+	return len(policySpec.RequestInterceptors) == 0 && len(policySpec.ResponseInterceptors) == 0 &&
+		policySpec.BackendJWTPolicy == nil && policySpec.CORSPolicy == nil && !policySpec.SubscriptionValidation &&
+		policySpec.AIProvider == nil && policySpec.ModelBasedRoundRobin == nil
+}
+
+// generateInterceptorService creates the InterceptorService CR and the Backend of the interceptor service
+func (g *crGenerator) generateInterceptorService(interceptor InterceptorService, flow string, qualifier string) (string, error) {
+	backendName, err := g.generateBackend(EndpointConfiguration{Endpoint: interceptor.BackendURL}, "interceptor", flow, interceptor.BackendURL)
+	if err != nil {
+		return "", err
+	}
+	if interceptor.TLSSecretName != "" {
+		g.artifact.Backends[backendName].Spec.TLS = &dpv1alpha2.TLSConfig{
+			SecretRef: &dpv1alpha2.RefConfig{Name: interceptor.TLSSecretName, Key: interceptor.TLSSecretKey},
+		}
+	}
+	var includes []dpv1alpha1.InterceptorInclusion
+	if interceptor.HeadersEnabled {
+		includes = append(includes, dpv1alpha1.InterceptorInclusion(flow+"_headers"))
+	}
+	if interceptor.BodyEnabled {
+		includes = append(includes, dpv1alpha1.InterceptorInclusion(flow+"_body"))
+	}
+	if interceptor.TrailersEnabled {
+		includes = append(includes, dpv1alpha1.InterceptorInclusion(flow+"_trailers"))
+	}
+	if interceptor.ContextEnabled {
+		includes = append(includes, dpv1alpha1.InterceptorInclusionInvocationContext)
+	}
+	name := strings.Join([]string{g.uniqueID, flow, "interceptor", qualifier}, "-")
+	g.artifact.InterceptorServices[name] = &dpv1alpha1.InterceptorService{
+		TypeMeta:   metav1.TypeMeta{Kind: "InterceptorService", APIVersion: dpv1alpha1.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: dpv1alpha1.InterceptorServiceSpec{
+			BackendRef: dpv1alpha1.BackendReference{Name: backendName},
+			Includes:   includes,
+		},
+	}
+	return name, nil
+}
+
+// generateBackendJWT creates the BackendJWT CR of the API and returns its name
+func (g *crGenerator) generateBackendJWT(backendJWT BackendJWT) string {
+	name := g.uniqueID + "-backend-jwt"
+	g.artifact.BackendJWT = &dpv1alpha1.BackendJWT{
+		TypeMeta:   metav1.TypeMeta{Kind: "BackendJWT", APIVersion: dpv1alpha1.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: dpv1alpha1.BackendJWTSpec{
+			Encoding:         backendJWT.Encoding,
+			Header:           backendJWT.Header,
+			SigningAlgorithm: backendJWT.SigningAlgorithm,
+			TokenTTL:         uint32(backendJWT.TokenTTL),
+		},
+	}
+	return name
+}
+
+// modelBasedRoundRobin maps the model endpoints of the policy into weighted backend references
+func (g *crGenerator) modelBasedRoundRobin(policy ModelBasedRoundRobin) (*dpv1alpha4.ModelBasedRoundRobin, error) {
+	modelWeights := func(models []ModelEndpoints, env string) ([]dpv1alpha4.ModelWeight, error) {
+		weights := []dpv1alpha4.ModelWeight{}
+		for _, model := range models {
+			backendName, err := g.generateBackend(EndpointConfiguration{Endpoint: model.Endpoint}, env, apiBackendSuffix)
+			if err != nil {
+				return nil, err
+			}
+			weights = append(weights, dpv1alpha4.ModelWeight{Model: model.Model, BackendRef: backendRef(backendName), Weight: model.Weight})
+		}
+		return weights, nil
+	}
+	productionModels, err := modelWeights(policy.ProductionModels, productionEnv)
+	if err != nil {
+		return nil, err
+	}
+	sandboxModels, err := modelWeights(policy.SandboxModels, sandboxEnv)
+	if err != nil {
+		return nil, err
+	}
+	return &dpv1alpha4.ModelBasedRoundRobin{
+		OnQuotaExceedSuspendDuration: policy.OnQuotaExceedSuspendDuration,
+		ProductionModels:             productionModels,
+		SandboxModels:                sandboxModels,
+	}, nil
+}
+
+// generateAuthentication creates the API level Authentication CR of an environment from the authentication
+// configurations
+func (g *crGenerator) generateAuthentication(env string) {
+	if g.api.Authentication == nil {
+		return
+	}
+	authTypes := &dpv1alpha2.APIAuth{}
+	for _, auth := range *g.api.Authentication {
+		switch auth.AuthType {
+		case oAuth2:
+			authTypes.OAuth2 = dpv1alpha2.OAuth2Auth{
+				Required:            auth.Required,
+				Disabled:            !auth.Enabled,
+				Header:              auth.HeaderName,
+				SendTokenToUpstream: auth.SendTokenUpStream,
+			}
+		case jwt:
+			disabled := !auth.Enabled
+			authTypes.JWT = dpv1alpha2.JWT{
+				Disabled:            &disabled,
+				Header:              auth.HeaderName,
+				SendTokenToUpstream: auth.SendTokenUpStream,
+				Audience:            auth.Audience,
+			}
+		case apiKey:
+			if !auth.Enabled {
+				continue
+			}
+			var keys []dpv1alpha2.APIKey
+			if auth.HeaderEnabled {
+				keys = append(keys, dpv1alpha2.APIKey{In: "Header", Name: auth.HeaderName, SendTokenToUpstream: auth.SendTokenUpStream})
+			}
+			if auth.QueryParamEnable {
+				keys = append(keys, dpv1alpha2.APIKey{In: "Query", Name: auth.QueryParamName, SendTokenToUpstream: auth.SendTokenUpStream})
+			}
+			authTypes.APIKey = &dpv1alpha2.APIKeyAuth{Required: auth.Required, Keys: keys}
+		case mTLS:
+			mutualSSL := &dpv1alpha2.MutualSSLConfig{Disabled: !auth.Enabled, Required: auth.Required}
+			for _, certificate := range auth.Certificates {
+				mutualSSL.ConfigMapRefs = append(mutualSSL.ConfigMapRefs,
+					&dpv1alpha2.RefConfig{Name: g.certConfigMapName(certificate.Key), Key: certificate.Key})
+			}
+			authTypes.MutualSSL = mutualSSL
+		}
+	}
+	disabled := false
+	name := g.uniqueID + "-" + env + "-authentication"
+	g.artifact.Authentication[name] = &dpv1alpha2.Authentication{
+		TypeMeta:   metav1.TypeMeta{Kind: "Authentication", APIVersion: dpv1alpha2.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: dpv1alpha2.AuthenticationSpec{
+			Default:   &dpv1alpha2.AuthSpec{Disabled: &disabled, AuthTypes: authTypes},
+			TargetRef: policyTargetRef(k8sKindAPI, g.uniqueID),
+		},
+	}
+}
+
+// generateDisabledAuthentication creates the Authentication CR referred by operations that are not secured
+func (g *crGenerator) generateDisabledAuthentication() string {
+	name := g.uniqueID + "-resource-authentication-disabled"
+	disabled := true
+	g.artifact.Authentication[name] = &dpv1alpha2.Authentication{
+		TypeMeta:   metav1.TypeMeta{Kind: "Authentication", APIVersion: dpv1alpha2.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: dpv1alpha2.AuthenticationSpec{
+			Default:   &dpv1alpha2.AuthSpec{Disabled: &disabled},
+			TargetRef: policyTargetRef("Resource", g.uniqueID),
+		},
+	}
+	return name
+}
+
+// generateAPIRateLimitPolicy creates the API level RateLimitPolicy CR
+func (g *crGenerator) generateAPIRateLimitPolicy() {
+	if g.api.RateLimit == nil {
+		return
+	}
+	name := g.uniqueID + "-api-ratelimit"
+	g.artifact.RateLimitPolicies[name] = g.rateLimitPolicy(name, *g.api.RateLimit, k8sKindAPI)
+}
+
+// generateResourceRateLimitPolicy creates the RateLimitPolicy CR of an operation and returns its name
+func (g *crGenerator) generateResourceRateLimitPolicy(operation Operation) string {
+	name := g.uniqueID + "-resource-ratelimit-" + operationHash(operation)
+	g.artifact.RateLimitPolicies[name] = g.rateLimitPolicy(name, *operation.RateLimit, "Resource")
+	return name
+}
+
+func (g *crGenerator) rateLimitPolicy(name string, rateLimit RateLimit, targetKind string) *dpv1alpha1.RateLimitPolicy {
+	return &dpv1alpha1.RateLimitPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "RateLimitPolicy", APIVersion: dpv1alpha1.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: dpv1alpha1.RateLimitPolicySpec{
+			Override: &dpv1alpha1.RateLimitAPIPolicy{
+				API: &dpv1alpha1.APIRateLimitPolicy{RequestsPerUnit: uint32(rateLimit.RequestsPerUnit), Unit: rateLimit.Unit},
+			},
+			TargetRef: policyTargetRef(targetKind, g.uniqueID),
+		},
+	}
+}
+
+// generateScope creates the Scope CR of a scope name and returns the CR name
+func (g *crGenerator) generateScope(scope string) string {
+	name := g.uniqueID + "-scope-" + generateSHA1Hash(scope)
+	g.artifact.Scopes[name] = &dpv1alpha1.Scope{
+		TypeMeta:   metav1.TypeMeta{Kind: "Scope", APIVersion: dpv1alpha1.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec:       dpv1alpha1.ScopeSpec{Names: []string{scope}},
+	}
+	return name
+}
+
+// operationExtensionRefs generates the CRs attached to a single operation and returns the route filter references
+// pointing to them
+func (g *crGenerator) operationExtensionRefs(operation Operation) ([]*gwapiv1.LocalObjectReference, error) {
+	var refs []*gwapiv1.LocalObjectReference
+	if g.resourcePolicy != "" {
+		refs = append(refs, extensionRef("APIPolicy", g.resourcePolicy))
+	}
+	resourcePolicyName, err := g.generateResourcePolicy(operation)
+	if err != nil {
+		return nil, err
+	}
+	if resourcePolicyName != "" {
+		refs = append(refs, extensionRef("APIPolicy", resourcePolicyName))
+	}
+	for _, scope := range operation.Scopes {
+		refs = append(refs, extensionRef("Scope", g.generateScope(scope)))
+	}
+	if operation.RateLimit != nil && g.api.RateLimit == nil {
+		refs = append(refs, extensionRef("RateLimitPolicy", g.generateResourceRateLimitPolicy(operation)))
+	}
+	if !operation.Secured {
+		refs = append(refs, extensionRef("Authentication", g.generateDisabledAuthentication()))
+	}
+	return refs, nil
+}
+
+// generateRoutes creates the HTTPRoutes or GQLRoutes of an environment and returns their names. Operations are
+// split across several routes since a route can only hold a limited number of rules.
+func (g *crGenerator) generateRoutes(env string, backendName string) ([]string, error) {
+	operations := g.operations()
+	routeKind := "httproute"
+	if g.isGraphQL() {
+		routeKind = "gqlroute"
+	}
+	routeRefs := []string{}
+	for start := 0; start < len(operations); start += maxRulesPerRoute {
+		end := start + maxRulesPerRoute
+		if end > len(operations) {
+			end = len(operations)
+		}
+		name := fmt.Sprintf("%s-%s-%s-%d", g.uniqueID, env, routeKind, start/maxRulesPerRoute+1)
+		var err error
+		if g.isGraphQL() {
+			err = g.generateGQLRoute(name, env, backendName, operations[start:end])
+		} else {
+			err = g.generateHTTPRoute(name, env, backendName, operations[start:end])
+		}
+		if err != nil {
+			return nil, err
+		}
+		routeRefs = append(routeRefs, name)
+	}
+	return routeRefs, nil
+}
+
+func (g *crGenerator) generateHTTPRoute(name string, env string, backendName string, operations []Operation) error {
+	rules := []gwapiv1.HTTPRouteRule{}
+	for _, operation := range operations {
+		rule, err := g.httpRouteRule(operation, env, backendName)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+	g.artifact.HTTPRoutes[name] = &gwapiv1.HTTPRoute{
+		TypeMeta:   metav1.TypeMeta{Kind: k8sKindHTTPRoute, APIVersion: gwapiv1.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: gwapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gwapiv1.CommonRouteSpec{ParentRefs: parentRefs()},
+			Hostnames:       []gwapiv1.Hostname{defaultHostname(env)},
+			Rules:           rules,
+		},
+	}
+	return nil
+}
+
+// httpRouteRule maps an operation into an HTTPRoute rule together with the filters of its policies
+func (g *crGenerator) httpRouteRule(operation Operation, env string, backendName string) (gwapiv1.HTTPRouteRule, error) {
+	pathType := gwapiv1.PathMatchRegularExpression
+	matchPath := retrievePathPrefix(operation.Target)
+	method := gwapiv1.HTTPMethod(strings.ToUpper(operation.Verb))
+	rule := gwapiv1.HTTPRouteRule{
+		Matches: []gwapiv1.HTTPRouteMatch{{
+			Path:   &gwapiv1.HTTPPathMatch{Type: &pathType, Value: &matchPath},
+			Method: &method,
+		}},
+	}
+
+	headerFilters, redirectFilter, mirrorFilters, err := g.routeFilters(env, g.api.APIPolicies, operation.OperationPolicies)
+	if err != nil {
+		return rule, err
+	}
+	// A redirected request never reaches the backend, hence neither the rewrite nor the backend is added
+	if redirectFilter == nil {
+		rewritePath := generateRewritePath(operation.Target)
+		rule.Filters = append(rule.Filters, gwapiv1.HTTPRouteFilter{
+			Type: gwapiv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gwapiv1.HTTPURLRewriteFilter{
+				Path: &gwapiv1.HTTPPathModifier{Type: gwapiv1.FullPathHTTPPathModifier, ReplaceFullPath: &rewritePath},
+			},
+		})
+	}
+	refs, err := g.operationExtensionRefs(operation)
+	if err != nil {
+		return rule, err
+	}
+	for _, ref := range refs {
+		rule.Filters = append(rule.Filters, gwapiv1.HTTPRouteFilter{Type: gwapiv1.HTTPRouteFilterExtensionRef, ExtensionRef: ref})
+	}
+	rule.Filters = append(rule.Filters, headerFilters...)
+	rule.Filters = append(rule.Filters, mirrorFilters...)
+	if redirectFilter != nil {
+		rule.Filters = append(rule.Filters, *redirectFilter)
+	} else {
+		rule.BackendRefs = []gwapiv1.HTTPBackendRef{backendRef(backendName)}
+	}
+	return rule, nil
+}
+
+// routeFilters maps the header modification, redirect and mirror policies of the API and the operation into
+// HTTPRoute filters
+func (g *crGenerator) routeFilters(env string, policyList ...*OperationPolicies) ([]gwapiv1.HTTPRouteFilter, *gwapiv1.HTTPRouteFilter, []gwapiv1.HTTPRouteFilter, error) {
+	requestHeaders := &gwapiv1.HTTPHeaderFilter{}
+	responseHeaders := &gwapiv1.HTTPHeaderFilter{}
+	var redirectFilter *gwapiv1.HTTPRouteFilter
+	var mirrorFilters []gwapiv1.HTTPRouteFilter
+	for _, policies := range policyList {
+		if policies == nil {
+			continue
+		}
+		for _, policy := range policies.Request {
+			switch parameters := policy.Parameters.(type) {
+			case Header:
+				addHeaderModification(requestHeaders, policy.PolicyName, parameters)
+			case RedirectPolicy:
+				filter, err := requestRedirectFilter(parameters)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				redirectFilter = filter
+			case URLList:
+				for _, mirrorURL := range parameters.URLs {
+					backendName, err := g.generateBackend(EndpointConfiguration{Endpoint: mirrorURL}, env, "mirror")
+					if err != nil {
+						return nil, nil, nil, err
+					}
+					mirrorFilters = append(mirrorFilters, gwapiv1.HTTPRouteFilter{
+						Type:          gwapiv1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gwapiv1.HTTPRequestMirrorFilter{BackendRef: backendRef(backendName).BackendObjectReference},
+					})
+				}
+			}
+		}
+		for _, policy := range policies.Response {
+			if parameters, ok := policy.Parameters.(Header); ok {
+				addHeaderModification(responseHeaders, policy.PolicyName, parameters)
+			}
+		}
+	}
+	var headerFilters []gwapiv1.HTTPRouteFilter
+	if len(requestHeaders.Add) > 0 || len(requestHeaders.Remove) > 0 {
+		headerFilters = append(headerFilters, gwapiv1.HTTPRouteFilter{Type: gwapiv1.HTTPRouteFilterRequestHeaderModifier, RequestHeaderModifier: requestHeaders})
+	}
+	if len(responseHeaders.Add) > 0 || len(responseHeaders.Remove) > 0 {
+		headerFilters = append(headerFilters, gwapiv1.HTTPRouteFilter{Type: gwapiv1.HTTPRouteFilterResponseHeaderModifier, ResponseHeaderModifier: responseHeaders})
+	}
+	return headerFilters, redirectFilter, mirrorFilters, nil
+}
+
+func addHeaderModification(headerFilter *gwapiv1.HTTPHeaderFilter, policyName string, header Header) {
+	if policyName == removeHeaderPolicy {
+		headerFilter.Remove = append(headerFilter.Remove, header.HeaderName)
+		return
+	}
+	headerFilter.Add = append(headerFilter.Add, gwapiv1.HTTPHeader{Name: gwapiv1.HTTPHeaderName(header.HeaderName), Value: header.HeaderValue})
+}
+
+// requestRedirectFilter maps a redirect policy into a RequestRedirect filter
+func requestRedirectFilter(redirect RedirectPolicy) (*gwapiv1.HTTPRouteFilter, error) {
+	parsedURL, err := neturl.Parse(redirect.URL)
+	if err != nil || parsedURL.Hostname() == "" {
+		return nil, fmt.Errorf("invalid redirect URL %q", redirect.URL)
+	}
+	scheme := strings.ToLower(parsedURL.Scheme)
+	hostname := gwapiv1.PreciseHostname(parsedURL.Hostname())
+	requestRedirect := &gwapiv1.HTTPRequestRedirectFilter{Scheme: &scheme, Hostname: &hostname}
+	if parsedURL.Path != "" {
+		path := parsedURL.Path
+		requestRedirect.Path = &gwapiv1.HTTPPathModifier{Type: gwapiv1.FullPathHTTPPathModifier, ReplaceFullPath: &path}
+	}
+	if parsedURL.Port() != "" {
+		port, err := strconv.ParseInt(parsedURL.Port(), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port in redirect URL %q", redirect.URL)
+		}
+		portNumber := gwapiv1.PortNumber(port)
+		requestRedirect.Port = &portNumber
+	}
+	if redirect.StatusCode != 0 {
+		statusCode := redirect.StatusCode
+		requestRedirect.StatusCode = &statusCode
+	}
+	return &gwapiv1.HTTPRouteFilter{Type: gwapiv1.HTTPRouteFilterRequestRedirect, RequestRedirect: requestRedirect}, nil
+}
+
+func (g *crGenerator) generateGQLRoute(name string, env string, backendName string, operations []Operation) error {
+	rules := []dpv1alpha2.GQLRouteRules{}
+	for _, operation := range operations {
+		gqlType := dpv1alpha2.GQLType(strings.ToUpper(operation.Verb))
+		path := operation.Target
+		rule := dpv1alpha2.GQLRouteRules{Matches: []dpv1alpha2.GQLRouteMatch{{Type: &gqlType, Path: &path}}}
+		refs, err := g.operationExtensionRefs(operation)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			rule.Filters = append(rule.Filters, dpv1alpha2.GQLRouteFilter{ExtensionRef: ref})
+		}
+		rules = append(rules, rule)
+	}
+	g.artifact.GQLRoutes[name] = &dpv1alpha2.GQLRoute{
+		TypeMeta:   metav1.TypeMeta{Kind: "GQLRoute", APIVersion: dpv1alpha2.GroupVersion.String()},
+		ObjectMeta: g.objectMeta(name),
+		Spec: dpv1alpha2.GQLRouteSpec{
+			CommonRouteSpec: gwapiv1.CommonRouteSpec{ParentRefs: parentRefs()},
+			Hostnames:       []gwapiv1.Hostname{defaultHostname(env)},
+			BackendRefs:     []gwapiv1.HTTPBackendRef{backendRef(backendName)},
+			Rules:           rules,
+		},
+	}
+	return nil
+}
+
+// operationHash returns a stable identifier of an operation to be used in CR names
+func operationHash(operation Operation) string {
+	return generateSHA1Hash(strings.ToUpper(operation.Verb) + "-" + operation.Target)
+}
+
+func defaultHostname(env string) gwapiv1.Hostname {
+	if env == sandboxEnv {
+		return defaultSandboxHostname
+	}
+	return defaultProductionHostname
+}
+
+// fullBasePath returns the base path of the API CR, which ends with the version of the API the same way the config
+// deployer sets it
+func fullBasePath(basePath string, version string) string {
+	if strings.HasSuffix(basePath, version) {
+		return basePath
+	}
+	return strings.TrimSuffix(basePath, "/") + "/" + version
+}
+
+func parentRefs() []gwapiv1.ParentReference {
+	group := gwapiv1.Group(constants.GatewayGroup)
+	kind := gwapiv1.Kind(constants.GatewayKind)
+	sectionName := gwapiv1.SectionName(gatewayListenerName)
+	return []gwapiv1.ParentReference{{
+		Group:       &group,
+		Kind:        &kind,
+		Name:        gwapiv1.ObjectName(constants.GatewayName),
+		SectionName: &sectionName,
+	}}
+}
+
+func backendRef(backendName string) gwapiv1.HTTPBackendRef {
+	group := gwapiv1.Group(dpv1alpha2.GroupVersion.Group)
+	kind := gwapiv1.Kind("Backend")
+	return gwapiv1.HTTPBackendRef{
+		BackendRef: gwapiv1.BackendRef{
+			BackendObjectReference: gwapiv1.BackendObjectReference{Group: &group, Kind: &kind, Name: gwapiv1.ObjectName(backendName)},
+		},
+	}
+}
+
+func extensionRef(kind string, name string) *gwapiv1.LocalObjectReference {
+	return &gwapiv1.LocalObjectReference{
+		Group: gwapiv1.Group(dpv1alpha2.GroupVersion.Group),
+		Kind:  gwapiv1.Kind(kind),
+		Name:  gwapiv1.ObjectName(name),
+	}
+}
+
+func policyTargetRef(kind string, name string) gwapiv1a2.NamespacedPolicyTargetReference {
+	return gwapiv1a2.NamespacedPolicyTargetReference{
+		Group: gwapiv1.Group(dpv1alpha2.GroupVersion.Group),
+		Kind:  gwapiv1.Kind(kind),
+		Name:  gwapiv1.ObjectName(name),
+	}
+}
+
+// retrievePathPrefix converts an operation target into the regular expression matched by the route rule.
+// Path parameters and a trailing wildcard are captured as groups.
+func retrievePathPrefix(target string) string {
+	switch target {
+	case "", "/*":
+		return "(.*)"
+	case "/":
+		return "/"
+	}
+	var generatedPath strings.Builder
+	for _, segment := range strings.Split(target, "/") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+		generatedPath.WriteString("/" + pathParamRegex.ReplaceAllString(segment, "(.*)"))
+	}
+	path := generatedPath.String()
+	if strings.HasSuffix(path, "/*") {
+		path = strings.TrimSuffix(path, "/*") + "(.*)"
+	}
+	return path
+}
+
+// generateRewritePath converts an operation target into the backend path, referring the groups captured by
+// retrievePathPrefix in the same order
+func generateRewritePath(target string) string {
+	switch target {
+	case "", "/*":
+		return "\\1"
+	case "/":
+		return "/"
+	}
+	paramCount := 1
+	var generatedPath strings.Builder
+	for _, segment := range strings.Split(target, "/") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+		generatedPath.WriteString("/" + pathParamRegex.ReplaceAllStringFunc(segment, func(string) string {
+			group := "\\" + strconv.Itoa(paramCount)
+			paramCount++
+			return group
+		}))
+	}
+	path := generatedPath.String()
+	if strings.HasSuffix(path, "/*") {
+		path = strings.TrimSuffix(path, "/*") + "\\" + strconv.Itoa(paramCount)
+	}
+	return path
+}
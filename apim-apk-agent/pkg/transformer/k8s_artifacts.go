@@ -1,12 +1,17 @@
 package transformer
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"github.com/wso2/apk/common-go-libs/apis/dp/v1alpha1"
 	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
 	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
 	dpv1alpha4 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha4"
 	corev1 "k8s.io/api/core/v1"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8Yaml "sigs.k8s.io/yaml"
 )
 
 // K8sArtifacts k8s artifact representation of API
@@ -25,3 +30,66 @@ type K8sArtifacts struct {
 	RateLimitPolicies   map[string]*v1alpha1.RateLimitPolicy
 	AIRateLimitPolicies map[string]*dpv1alpha3.AIRateLimitPolicy
 }
+
+// MarshalK8sArtifacts serializes every CR of the artifact and returns the content keyed by a file name made of the
+// kind and the name of the CR. CRs which can not be represented in YAML, such as ConfigMaps holding binary
+// certificate data, are serialized as JSON instead.
+func MarshalK8sArtifacts(k8sArtifact *K8sArtifacts) (map[string]string, error) {
+	files := make(map[string]string)
+	var marshalErr error
+	add := func(kind string, name string, cr interface{}) {
+		if marshalErr != nil {
+			return
+		}
+		fileName := strings.ToLower(kind) + "-" + name
+		content, err := k8Yaml.Marshal(cr)
+		if err == nil {
+			files[fileName+".yaml"] = string(content)
+			return
+		}
+		content, err = json.MarshalIndent(cr, "", "  ")
+		if err != nil {
+			marshalErr = fmt.Errorf("error marshalling %s %s: %w", kind, name, err)
+			return
+		}
+		files[fileName+".json"] = string(content)
+	}
+	add("API", k8sArtifact.API.Name, k8sArtifact.API)
+	for name, cr := range k8sArtifact.HTTPRoutes {
+		add("HTTPRoute", name, cr)
+	}
+	for name, cr := range k8sArtifact.GQLRoutes {
+		add("GQLRoute", name, cr)
+	}
+	for name, cr := range k8sArtifact.Backends {
+		add("Backend", name, cr)
+	}
+	for name, cr := range k8sArtifact.Scopes {
+		add("Scope", name, cr)
+	}
+	for name, cr := range k8sArtifact.Authentication {
+		add("Authentication", name, cr)
+	}
+	for name, cr := range k8sArtifact.APIPolicies {
+		add("APIPolicy", name, cr)
+	}
+	for name, cr := range k8sArtifact.InterceptorServices {
+		add("InterceptorService", name, cr)
+	}
+	for name, cr := range k8sArtifact.ConfigMaps {
+		add("ConfigMap", name, cr)
+	}
+	for name, cr := range k8sArtifact.Secrets {
+		add("Secret", name, cr)
+	}
+	for name, cr := range k8sArtifact.RateLimitPolicies {
+		add("RateLimitPolicy", name, cr)
+	}
+	for name, cr := range k8sArtifact.AIRateLimitPolicies {
+		add("AIRateLimitPolicy", name, cr)
+	}
+	if k8sArtifact.BackendJWT != nil {
+		add("BackendJWT", k8sArtifact.BackendJWT.Name, k8sArtifact.BackendJWT)
+	}
+	return files, marshalErr
+}
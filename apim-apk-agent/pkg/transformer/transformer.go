@@ -994,7 +994,7 @@ func getMultiEndpointConfigs(endpointList []Endpoint, primaryProdEndpointID stri
 // GenerateCRs takes the .apk-conf, api definition, vHost and the organization for a particular API and then generate and returns
 // the relavant CRD set as a zip
 func GenerateCRs(apkConf string, apiDefinition string, certContainer CertContainer, k8ResourceGenEndpoint string, organizationID string) (*K8sArtifacts, error) {
-	k8sArtifact := K8sArtifacts{HTTPRoutes: make(map[string]*gwapiv1.HTTPRoute), GQLRoutes: make(map[string]*dpv1alpha2.GQLRoute), Backends: make(map[string]*dpv1alpha2.Backend), Scopes: make(map[string]*dpv1alpha1.Scope), Authentication: make(map[string]*dpv1alpha2.Authentication), APIPolicies: make(map[string]*dpv1alpha4.APIPolicy), InterceptorServices: make(map[string]*dpv1alpha1.InterceptorService), ConfigMaps: make(map[string]*corev1.ConfigMap), Secrets: make(map[string]*corev1.Secret), RateLimitPolicies: make(map[string]*dpv1alpha1.RateLimitPolicy), AIRateLimitPolicies: make(map[string]*dpv1alpha3.AIRateLimitPolicy)}
+	k8sArtifact := newK8sArtifacts()
 	if apkConf == "" {
 		logger.LoggerTransformer.Error("Empty apk-conf parameter provided. Unable to generate CRDs.")
 		return nil, errors.New("Error: APK-Conf can't be empty")
@@ -1207,19 +1207,29 @@ func GenerateCRs(apkConf string, apiDefinition string, certContainer CertContain
 			logger.LoggerSync.Errorf("[!]Unknown Kind parsed from the YAML File: %v", kind)
 		}
 	}
+	addCertificatesAndSecrets(certContainer, &k8sArtifact)
+
+	return &k8sArtifact, nil
+}
+
+// newK8sArtifacts returns a K8sArtifacts instance with all the CR maps initialized
+func newK8sArtifacts() K8sArtifacts {
+	return K8sArtifacts{HTTPRoutes: make(map[string]*gwapiv1.HTTPRoute), GQLRoutes: make(map[string]*dpv1alpha2.GQLRoute), Backends: make(map[string]*dpv1alpha2.Backend), Scopes: make(map[string]*dpv1alpha1.Scope), Authentication: make(map[string]*dpv1alpha2.Authentication), APIPolicies: make(map[string]*dpv1alpha4.APIPolicy), InterceptorServices: make(map[string]*dpv1alpha1.InterceptorService), ConfigMaps: make(map[string]*corev1.ConfigMap), Secrets: make(map[string]*corev1.Secret), RateLimitPolicies: make(map[string]*dpv1alpha1.RateLimitPolicy), AIRateLimitPolicies: make(map[string]*dpv1alpha3.AIRateLimitPolicy)}
+}
+
+// addCertificatesAndSecrets adds the certificate ConfigMaps and the endpoint security Secrets of an API
+func addCertificatesAndSecrets(certContainer CertContainer, k8sArtifact *K8sArtifacts) {
 	// Create ConfigMap to store the cert data if mTLS has enabled
 	if certContainer.ClientCertObj.CertAvailable {
-		createConfigMaps(certContainer.ClientCertObj.ClientCertFiles, &k8sArtifact)
+		createConfigMaps(certContainer.ClientCertObj.ClientCertFiles, k8sArtifact)
 	}
 
 	// Create ConfigMap to store the cert data if endpoint security has enabled
 	if certContainer.EndpointCertObj.CertAvailable {
-		createConfigMaps(certContainer.EndpointCertObj.EndpointCertFiles, &k8sArtifact)
+		createConfigMaps(certContainer.EndpointCertObj.EndpointCertFiles, k8sArtifact)
 	}
 
-	createEndpointSecrets(certContainer.SecretData, &k8sArtifact)
-
-	return &k8sArtifact, nil
+	createEndpointSecrets(certContainer.SecretData, k8sArtifact)
 }
 
 // UpdateCRS cr update
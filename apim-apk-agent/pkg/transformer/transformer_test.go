@@ -20,7 +20,12 @@ package transformer
 import (
 	"archive/zip"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -46,6 +51,18 @@ var GQLk8Json = string(gqlBytes)
 
 var sampleK8Artifacts = []string{HTTPk8Json, GQLk8Json}
 
+// updateGolden regenerates the golden CR files from the local CR generator when set
+var updateGolden = flag.Bool("update", false, "update the golden CR files")
+
+var goldenResourcesDir = filepath.Join(testResourcesDir, "golden")
+
+// goldenAPIs maps the API projects inside Base/Test_Payload.zip to the directory holding the CRs generated locally
+// for them
+var goldenAPIs = map[string]string{
+	"1ae833a2-03a1-4b41-9f1e-c8d8fb750ece-0a5b1039-9836-4b05-baa8-e06c9b91ebda.zip": "PizzaShackAPI-1.0.0",
+	"69c6ce1d-25ea-4369-ad5c-93b511406cda-86b353ae-a9f4-4231-ba77-d37b3dd73913.zip": "StarWarsAPI-1.0.0",
+}
+
 func TestAPIArtifactDecoding(t *testing.T) {
 	apiFiles := make(map[string]*zip.File)
 	testResourcesDir := "../../resources/test-resources/"
@@ -153,6 +170,11 @@ func TestAPKConfGeneration(t *testing.T) {
 				assert.NotEqual(t, uint32(0), revisionID)
 				assert.NotNil(t, configuredRateLimitPoliciesMap)
 				assert.IsType(t, []EndpointSecurityConfig{}, endpointSecurityData) // Need to be refined maybe
+
+				revisionedAPIID, revision, err := ReadAPIRevision(apiArtifact.APIJson)
+				assert.NoError(t, err)
+				assert.Equal(t, apiUUID, revisionedAPIID)
+				assert.Equal(t, revisionID, revision)
 			}
 		}
 	}
@@ -385,3 +407,292 @@ func TestBrokenZipHandlingFlow(t *testing.T) {
 		}
 	}
 }
+
+// goldenAPI holds the inputs used for CR generation of an API project listed in goldenAPIs
+type goldenAPI struct {
+	apkConf       string
+	api           *API
+	definition    string
+	certContainer CertContainer
+}
+
+func loadGoldenAPI(t *testing.T, apiZipName string) goldenAPI {
+	zipFileBytes, err := os.ReadFile(filepath.Join(testResourcesDir, "Base", "Test_Payload.zip"))
+	if err != nil {
+		t.Fatal("Error reading the test payload:", err)
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(zipFileBytes), int64(len(zipFileBytes)))
+	if err != nil {
+		t.Fatal("Error creating zip reader for the test payload:", err)
+	}
+	for _, zipFile := range zipReader.File {
+		if zipFile.Name != apiZipName {
+			continue
+		}
+		apiArtifact, err := DecodeAPIArtifact(zipFile)
+		if err != nil {
+			t.Fatal("Error decoding the API artifact:", err)
+		}
+		apkConf, _, _, _, _, api, _, _, err := GenerateAPKConf(apiArtifact.APIJson, apiArtifact.CertArtifact, apiArtifact.Endpoints, "default")
+		if err != nil {
+			t.Fatal("Error generating the apk-conf:", err)
+		}
+		// Certificate ConfigMaps and endpoint Secrets are added the same way in both CR generation modes, hence
+		// they are left out of the golden files
+		return goldenAPI{apkConf: apkConf, api: api, definition: apiArtifact.Schema}
+	}
+	t.Fatalf("API project %s not found in the test payload", apiZipName)
+	return goldenAPI{}
+}
+
+func marshalK8sArtifacts(t *testing.T, k8sArtifact *K8sArtifacts) map[string]string {
+	files, err := MarshalK8sArtifacts(k8sArtifact)
+	if err != nil {
+		t.Fatal("Error marshalling the CRs:", err)
+	}
+	return files
+}
+
+func readGoldenFiles(t *testing.T, dir string) map[string]string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal("Error reading the golden directory:", err)
+	}
+	files := make(map[string]string)
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal("Error reading the golden file:", err)
+		}
+		files[entry.Name()] = string(content)
+	}
+	return files
+}
+
+func TestGenerateCRsLocallyGolden(t *testing.T) {
+	for apiZipName, goldenDir := range goldenAPIs {
+		t.Run(goldenDir, func(t *testing.T) {
+			input := loadGoldenAPI(t, apiZipName)
+			crResponse, err := GenerateK8sArtifacts(CRGenerationModeLocal, input.apkConf, input.api, input.definition, input.certContainer, "", "default")
+			assert.NoError(t, err)
+			files := marshalK8sArtifacts(t, crResponse)
+
+			dir := filepath.Join(goldenResourcesDir, goldenDir)
+			if *updateGolden {
+				assert.NoError(t, os.RemoveAll(dir))
+				assert.NoError(t, os.MkdirAll(dir, 0755))
+				for name, content := range files {
+					assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+				}
+			}
+			assert.Equal(t, readGoldenFiles(t, dir), files)
+		})
+	}
+}
+
+// configDeployerStubCRs are the CRs served by the stub config deployer for the API projects listed in goldenAPIs
+var configDeployerStubCRs = map[string]string{
+	"PizzaShackAPI-1.0.0": HTTPk8Json,
+	"StarWarsAPI-1.0.0":   GQLk8Json,
+}
+
+// newConfigDeployerStub starts a config deployer responding with the given CRs as a zip, the same way the config
+// deployer does
+func newConfigDeployerStub(t *testing.T, definition string, files map[string]string) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "default", r.URL.Query().Get("organization"))
+		assert.Equal(t, definition, r.FormValue(definitionFileMultipartField))
+		zipWriter := zip.NewWriter(w)
+		for fileName, content := range files {
+			fileWriter, err := zipWriter.Create(fileName)
+			assert.NoError(t, err)
+			_, err = fileWriter.Write([]byte(content))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, zipWriter.Close())
+	}))
+}
+
+func TestGenerateCRsRemotelyReturnsConfigDeployerCRs(t *testing.T) {
+	for apiZipName, goldenDir := range goldenAPIs {
+		t.Run(goldenDir, func(t *testing.T) {
+			var deployerCRs K8sArtifacts
+			if err := json.Unmarshal([]byte(configDeployerStubCRs[goldenDir]), &deployerCRs); err != nil {
+				t.Fatal("Error reading the config deployer CRs:", err)
+			}
+			deployerFiles := marshalK8sArtifacts(t, &deployerCRs)
+			input := loadGoldenAPI(t, apiZipName)
+			server := newConfigDeployerStub(t, input.definition, deployerFiles)
+			defer server.Close()
+
+			crResponse, err := GenerateK8sArtifacts(CRGenerationModeRemote, input.apkConf, input.api, input.definition, input.certContainer, server.URL, "default")
+			assert.NoError(t, err)
+			assert.Equal(t, deployerFiles, marshalK8sArtifacts(t, crResponse))
+		})
+	}
+}
+
+// configDeployerURL captures the CRs generated by the config deployer at the given URL for the API projects listed
+// in goldenAPIs when set
+var configDeployerURL = flag.String("config-deployer", "", "URL of the config deployer to capture the CRs from")
+
+var configDeployerCapturesDir = filepath.Join(testResourcesDir, "config-deployer")
+
+// configDeployerInputsFile records the digest of the inputs a capture of the config deployer was generated from
+const configDeployerInputsFile = "inputs.sha256"
+
+func configDeployerInputsDigest(input goldenAPI) string {
+	digest := sha256.Sum256([]byte(input.apkConf + "\n---\n" + input.definition))
+	return hex.EncodeToString(digest[:])
+}
+
+func TestGenerateCRsLocallyMatchesConfigDeployer(t *testing.T) {
+	for apiZipName, goldenDir := range goldenAPIs {
+		t.Run(goldenDir, func(t *testing.T) {
+			input := loadGoldenAPI(t, apiZipName)
+			dir := filepath.Join(configDeployerCapturesDir, goldenDir)
+			if *configDeployerURL != "" {
+				crResponse, err := GenerateK8sArtifacts(CRGenerationModeRemote, input.apkConf, input.api, input.definition, input.certContainer, *configDeployerURL, "default")
+				if err != nil {
+					t.Fatal("Error capturing the CRs of the config deployer:", err)
+				}
+				assert.NoError(t, os.RemoveAll(dir))
+				assert.NoError(t, os.MkdirAll(dir, 0755))
+				for name, content := range marshalK8sArtifacts(t, crResponse) {
+					assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+				}
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, configDeployerInputsFile),
+					[]byte(configDeployerInputsDigest(input)), 0644))
+			}
+
+			inputsDigest, err := os.ReadFile(filepath.Join(dir, configDeployerInputsFile))
+			if os.IsNotExist(err) {
+				t.Skip("No CRs of the config deployer are captured, capture them with -config-deployer <url>")
+			}
+			assert.NoError(t, err)
+			if string(inputsDigest) != configDeployerInputsDigest(input) {
+				t.Fatal("The CRs of the config deployer were captured for other inputs, capture them again with " +
+					"-config-deployer <url>")
+			}
+			captured := readGoldenFiles(t, dir)
+			delete(captured, configDeployerInputsFile)
+
+			crResponse, err := GenerateK8sArtifacts(CRGenerationModeLocal, input.apkConf, input.api, input.definition, input.certContainer, "", "default")
+			assert.NoError(t, err)
+			assert.Equal(t, captured, marshalK8sArtifacts(t, crResponse))
+		})
+	}
+}
+
+func TestGenerateCRsLocallyRejectsSeveralEndpoints(t *testing.T) {
+	endpoints := []EndpointConfiguration{{Endpoint: "http://backend-1.default.svc:8080"},
+		{Endpoint: "http://backend-2.default.svc:8080"}}
+	api := &API{Name: "OrdersAPI", Version: "1.0.0", Context: "/orders", Type: restType, DefinitionPath: "/definition",
+		EndpointConfigurations: &EndpointConfigurations{Production: &endpoints}}
+
+	crResponse, err := GenerateCRsLocally(api, "openapi: 3.0.1", CertContainer{}, "default")
+	assert.Error(t, err, "Should not drop the endpoints after the first one")
+	assert.Nil(t, crResponse)
+}
+
+func TestGenerateRoutePaths(t *testing.T) {
+	testCases := []struct {
+		target      string
+		matchPath   string
+		rewritePath string
+	}{
+		{target: "/*", matchPath: "(.*)", rewritePath: "\\1"},
+		{target: "/", matchPath: "/", rewritePath: "/"},
+		{target: "/menu", matchPath: "/menu", rewritePath: "/menu"},
+		{target: "/order/{orderId}", matchPath: "/order/(.*)", rewritePath: "/order/\\1"},
+		{target: "/order/{orderId}/items/{itemId}", matchPath: "/order/(.*)/items/(.*)", rewritePath: "/order/\\1/items/\\2"},
+		{target: "/order/{orderId}/*", matchPath: "/order/(.*)(.*)", rewritePath: "/order/\\1\\2"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.matchPath, retrievePathPrefix(testCase.target), testCase.target)
+		assert.Equal(t, testCase.rewritePath, generateRewritePath(testCase.target), testCase.target)
+	}
+}
+
+func TestGenerateCRsLocallyWithPolicies(t *testing.T) {
+	productionEndpoints := []EndpointConfiguration{{Endpoint: "http://backend.default.svc:8080/orders"}}
+	operations := []Operation{
+		{Target: "/orders", Verb: "GET", Scopes: []string{"read:orders"}, Secured: true, RateLimit: &RateLimit{RequestsPerUnit: 10, Unit: "Minute"},
+			OperationPolicies: &OperationPolicies{
+				Request:  []OperationPolicy{{PolicyName: addHeaderPolicy, Parameters: Header{HeaderName: "x-trace", HeaderValue: "true"}}},
+				Response: []OperationPolicy{{PolicyName: removeHeaderPolicy, Parameters: Header{HeaderName: "server"}}},
+			}},
+		{Target: "/orders/{orderId}", Verb: "POST", Secured: false,
+			OperationPolicies: &OperationPolicies{
+				Request: []OperationPolicy{
+					{PolicyName: interceptorPolicy, Parameters: &InterceptorService{BackendURL: "https://interceptor.default.svc:8443", HeadersEnabled: true, BodyEnabled: true, TLSSecretName: "interceptor-tls", TLSSecretKey: tlsKey}},
+					{PolicyName: requestMirrorPolicy, Parameters: URLList{URLs: []string{"http://mirror.default.svc"}}},
+				},
+			}},
+		{Target: "/legacy", Verb: "GET", Secured: true,
+			OperationPolicies: &OperationPolicies{
+				Request: []OperationPolicy{{PolicyName: requestRedirectPolicy, Parameters: RedirectPolicy{URL: "https://new.example.com/orders", StatusCode: 301}}},
+			}},
+	}
+	api := &API{Name: "OrdersAPI", Version: "1.0.0", Context: "/orders/1.0.0", Type: restType, DefinitionPath: "/definition",
+		EndpointConfigurations: &EndpointConfigurations{Production: &productionEndpoints}, Operations: &operations}
+
+	crResponse, err := GenerateCRsLocally(api, "openapi: 3.0.1", CertContainer{}, "default")
+	assert.NoError(t, err)
+	uniqueID := GetUniqueIDForAPI("OrdersAPI", "1.0.0", "default")
+	assert.Equal(t, uniqueID, crResponse.API.Name)
+	assert.Nil(t, crResponse.API.Spec.Sandbox)
+	assert.Equal(t, []string{uniqueID + "-production-httproute-1"}, crResponse.API.Spec.Production[0].RouteRefs)
+
+	route := crResponse.HTTPRoutes[uniqueID+"-production-httproute-1"]
+	assert.NotNil(t, route)
+	assert.Len(t, route.Spec.Rules, 3)
+
+	filterTypes := func(rule gwapiv1.HTTPRouteRule) []string {
+		var types []string
+		for _, filter := range rule.Filters {
+			if filter.ExtensionRef != nil {
+				types = append(types, string(filter.ExtensionRef.Kind))
+			} else {
+				types = append(types, string(filter.Type))
+			}
+		}
+		return types
+	}
+	assert.Equal(t, []string{"URLRewrite", "Scope", "RateLimitPolicy", "RequestHeaderModifier", "ResponseHeaderModifier"}, filterTypes(route.Spec.Rules[0]))
+	assert.Equal(t, []string{"URLRewrite", "APIPolicy", "Authentication", "RequestMirror"}, filterTypes(route.Spec.Rules[1]))
+	assert.Equal(t, []string{"RequestRedirect"}, filterTypes(route.Spec.Rules[2]))
+	assert.Empty(t, route.Spec.Rules[2].BackendRefs)
+	assert.Equal(t, "/orders/\\1", *route.Spec.Rules[1].Filters[0].URLRewrite.Path.ReplaceFullPath)
+
+	assert.Len(t, crResponse.Scopes, 1)
+	assert.Len(t, crResponse.RateLimitPolicies, 1)
+	for name, rateLimitPolicy := range crResponse.RateLimitPolicies {
+		assert.Contains(t, name, "resource-")
+		assert.Equal(t, uint32(10), rateLimitPolicy.Spec.Override.API.RequestsPerUnit)
+	}
+	assert.Len(t, crResponse.InterceptorServices, 1)
+	for _, interceptorService := range crResponse.InterceptorServices {
+		assert.Len(t, interceptorService.Spec.Includes, 2)
+		backend := crResponse.Backends[interceptorService.Spec.BackendRef.Name]
+		assert.NotNil(t, backend)
+		assert.Equal(t, "interceptor-tls", backend.Spec.TLS.SecretRef.Name)
+	}
+	// The endpoint, interceptor and mirror backends
+	assert.Len(t, crResponse.Backends, 3)
+	assert.Contains(t, crResponse.ConfigMaps, uniqueID+"-definition")
+
+	_, err = GenerateCRsLocally(api, "", CertContainer{}, "default")
+	assert.Error(t, err)
+	_, err = GenerateCRsLocally(nil, "openapi: 3.0.1", CertContainer{}, "default")
+	assert.Error(t, err)
+}
+
+func TestGenerateK8sArtifactsWithUnknownMode(t *testing.T) {
+	api := &API{Name: "OrdersAPI", Version: "1.0.0", Context: "/orders/1.0.0", Type: restType}
+	for _, mode := range []string{"", "Local", "deployer"} {
+		crResponse, err := GenerateK8sArtifacts(mode, "", api, "openapi: 3.0.1", CertContainer{}, "", "default")
+		assert.Error(t, err, mode)
+		assert.Nil(t, crResponse, mode)
+	}
+}
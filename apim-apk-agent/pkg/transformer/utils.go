@@ -29,6 +29,7 @@ import (
 	"strings"
 
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
+	"gopkg.in/yaml.v2"
 )
 
 // DecodeAPIArtifact decodes a zip-encoded API payload, extracting API details like JSON, Swagger, and deployment configuration.
@@ -192,3 +193,15 @@ func GetSha1Value(input string) string {
 	hashBytes := hasher.Sum(nil)
 	return hex.EncodeToString(hashBytes)
 }
+
+// ReadAPIRevision returns the UUID and the revision of the API from the api.json/api.yaml content of an API
+// project without generating the apk-conf
+func ReadAPIRevision(apiJSON string) (string, uint32, error) {
+	var apiYaml APIYaml
+	if err := json.Unmarshal([]byte(apiJSON), &apiYaml); err != nil {
+		if yamlErr := yaml.Unmarshal([]byte(apiJSON), &apiYaml); yamlErr != nil {
+			return "", 0, yamlErr
+		}
+	}
+	return apiYaml.Data.RevisionedAPIID, apiYaml.Data.RevisionID, nil
+}
@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/wso2/product-apim-tooling/apim-apk-agent/config"
 	logger "github.com/wso2/product-apim-tooling/apim-apk-agent/pkg/loggers"
@@ -113,9 +114,18 @@ var (
 	clientID             string
 	clientSecret         string
 	basicAuthHeaderValue string
+
+	onceControlPlaneConfigRead sync.Once
 )
 
-func init() {
+// readControlPlaneConfigs derives the publisher REST API details from the configuration. It is read on the first
+// call to the control plane instead of at the package initialization, so that the packages which import this
+// package without calling the control plane (e.g. the offline transform command) do not require the config.toml.
+func readControlPlaneConfigs() {
+	onceControlPlaneConfigRead.Do(loadControlPlaneConfigs)
+}
+
+func loadControlPlaneConfigs() {
 	// Read configurations and derive the eventHub details
 	conf, errReadConfig := config.ReadConfigs()
 	if errReadConfig != nil {
@@ -158,6 +168,7 @@ func GetBasicAuthHeaderValue(username, password string) string {
 
 // GetToken retrieves an OAuth token using the provided credentials and scopes.
 func GetToken(scopes []string, clientID string, clientSecret string) (string, error) {
+	readControlPlaneConfigs()
 	form := url.Values{}
 	form.Set("grant_type", "password")
 	form.Set("username", username)
@@ -204,6 +215,7 @@ func GetToken(scopes []string, clientID string, clientSecret string) (string, er
 
 // GetSuitableAuthHeadervalue returns an appropriate authentication header value based on whether client credentials are provided.
 func GetSuitableAuthHeadervalue(scopes []string) (string, error) {
+	readControlPlaneConfigs()
 	if clientID != "" && clientSecret != "" {
 		token, err := GetToken(scopes, clientID, clientSecret)
 		if err != nil {
@@ -216,6 +228,7 @@ func GetSuitableAuthHeadervalue(scopes []string) (string, error) {
 
 // ImportAPI imports an API from a zip file, returning the ID of the imported API.
 func ImportAPI(apiZipName string, zipFileBytes *bytes.Buffer) (string, string, error) {
+	readControlPlaneConfigs()
 	authHeaderVal, err := GetSuitableAuthHeadervalue([]string{string(AdminScope), string(ImportExportScope)})
 	if err != nil {
 		return "", "", err
@@ -266,6 +279,7 @@ func ImportAPI(apiZipName string, zipFileBytes *bytes.Buffer) (string, string, e
 
 // DeleteAPIRevision deletes an API given its UUID.
 func DeleteAPIRevision(apiUUID string, revisionID string, body string) error {
+	readControlPlaneConfigs()
 	deleteURL := apiDeleteURL + apiUUID + "/undeploy-revision?revisionId=" + revisionID
 	authheaderval, err := GetSuitableAuthHeadervalue([]string{string(AdminScope), string(ImportExportScope)})
 	if err != nil {
@@ -36,6 +36,10 @@ var flagMiManagementEndpoint string // mi management endpoint of the environment
 var flagAIServiceEndpoint string // ai service endpoint of the environment to be added
 var flagAITokenServiceEndpoint string // ai token service endpoint of the environment to be added
 var flagAIKey string // base-64 encoded client_id and client_secret of the environment to be added
+var flagProxy string             // HTTP(S) proxy used to reach the environment to be added
+var flagNoProxy string           // hosts of the environment to be added reached without the proxy
+var flagClientCertificate string // client certificate for mutual TLS with the environment to be added
+var flagClientKey string         // key of the client certificate of the environment to be added
 
 // AddEnv command related Info
 const AddEnvCmdLiteral = "env [environment]"
@@ -80,11 +84,20 @@ const addEnvCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnv
 --registration https://idp.com:9443 \
 --token https://gw.com:9443/oauth2/token
 
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` prod \
+--apim https://apim.com:9443 \
+--proxy http://proxy.com:3128 \
+--no-proxy internal.apim.com \
+--client-cert /home/wso2user/certs/client.pem \
+--client-key /home/wso2user/certs/client-key.pem
+
 You can either provide only the flag --apim , or all the other 4 flags (--registration --publisher --devportal --admin) without providing --apim flag.
 If you are omitting any of --registration --publisher --devportal --admin flags, you need to specify --apim flag with the API Manager endpoint. In both of the
 cases --token flag is optional and use it to specify the gateway token endpoint. This will be used for "apictl get-keys" operation.
 To add a micro integrator instance to an environment you can use the --mi flag.
-To add ai related service endpoints to an environment you can use the --ai-service, --ai-token-endpoint and --ai-key flags.`
+To add ai related service endpoints to an environment you can use the --ai-service, --ai-token-endpoint and --ai-key flags.
+To reach the environment through a proxy use the --proxy and --no-proxy flags, and to authenticate using a client certificate
+(mutual TLS) use the --client-cert and --client-key flags.`
 
 // addEnvCmd represents the addEnv command
 var addEnvCmd = &cobra.Command{
@@ -113,6 +126,10 @@ func executeAddEnvCmd(mainConfigFilePath string) {
 	envEndpoints.AIServiceEndpoint = flagAIServiceEndpoint
 	envEndpoints.AITokenServiceEndpoint = flagAITokenServiceEndpoint
 	envEndpoints.AIKey = flagAIKey
+	envEndpoints.Proxy = flagProxy
+	envEndpoints.NoProxy = flagNoProxy
+	envEndpoints.ClientCertificate = flagClientCertificate
+	envEndpoints.ClientKey = flagClientKey
 	err := impl.AddEnv(envToBeAdded, envEndpoints, mainConfigFilePath, AddEnvCmdLiteral)
 	if err != nil {
 		utils.HandleErrorAndExit("Error adding environment", err)
@@ -134,5 +151,11 @@ func init() {
 	addEnvCmd.Flags().StringVar(&flagAIServiceEndpoint, "ai-service", "", "AI service endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagAITokenServiceEndpoint, "ai-token-endpoint", "", "AI token service endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagAIKey, "ai-key", "", "Base64 encoded client_id and client_secret for the environment")
+	addEnvCmd.Flags().StringVar(&flagProxy, "proxy", "", "HTTP(S) proxy used to reach the environment")
+	addEnvCmd.Flags().StringVar(&flagNoProxy, "no-proxy", "",
+		"Comma separated hosts of the environment reached without the proxy")
+	addEnvCmd.Flags().StringVar(&flagClientCertificate, "client-cert", "",
+		"PEM file of the client certificate used for mutual TLS with the environment")
+	addEnvCmd.Flags().StringVar(&flagClientKey, "client-key", "", "PEM file of the key of the client certificate")
 	_ = addEnvCmd.MarkFlagRequired("environment")
 }
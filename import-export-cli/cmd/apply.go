@@ -0,0 +1,130 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	applyDir              string
+	applyEnvironment      string
+	applyParamsDir        string
+	applyPrune            bool
+	applyDryRun           bool
+	applyPreserveProvider bool
+	applyRotateRevision   bool
+	applySkipCleanup      bool
+)
+
+const (
+	// Apply command related usage info
+	ApplyCmdLiteral   = "apply"
+	applyCmdShortDesc = "Apply a directory of projects to an environment"
+	applyCmdLongDesc  = `Apply all the API, API Product, Application, API Policy and Rate Limiting Policy projects in the directory
+specified by flag (--file, -f) to the environment specified by flag (--environment, -e).
+Projects are applied in dependency order (policies, APIs, API Products and then Applications). Projects that already
+exist in the environment are updated if they differ from the environment and the others are created. If --prune is
+specified, the APIs, API Products, Applications and Rate Limiting Policies in the environment that are not available in
+the directory are deleted from the environment, except the Rate Limiting Policies shipped with API Manager and the
+apictl application. The versions of the API Policies in the directory that are not available in it are deleted, which
+keeps the API Policies shipped with API Manager. An API used by an API Product that is kept is skipped with the reason.
+This does not require a git repository.`
+)
+
+const applyCmdExamples = utils.ProjectName + ` ` + ApplyCmdLiteral + ` -f ~/projects -e dev
+` + utils.ProjectName + ` ` + ApplyCmdLiteral + ` -f ~/projects -e production --params ~/deployment --rotate-revision
+` + utils.ProjectName + ` ` + ApplyCmdLiteral + ` -f ~/projects -e production --prune --dry-run
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+
+// ApplyCmd represents the apply command
+var ApplyCmd = &cobra.Command{
+	Use:         ApplyCmdLiteral + " --file <path-to-projects-dir> --environment <environment>",
+	Short:       applyCmdShortDesc,
+	Long:        applyCmdLongDesc,
+	Example:     applyCmdExamples,
+	Annotations: resultAnnotations("project", resultActionApplied),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ApplyCmdLiteral + " called")
+		cred, err := GetCredentials(applyEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessOAuthToken, err := credentials.GetOAuthAccessToken(cred, applyEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for applying the projects", err)
+		}
+		options := impl.ApplyOptions{
+			ParamsDir:      applyParamsDir,
+			Prune:          applyPrune,
+			DryRun:         applyDryRun,
+			RotateRevision: applyRotateRevision,
+			SkipCleanup:    applySkipCleanup,
+		}
+		if cmd.Flags().Changed("preserve-provider") {
+			options.PreserveProvider = &applyPreserveProvider
+		}
+		failedProjects, err := impl.ApplyProjectsToEnv(accessOAuthToken, applyEnvironment, applyDir, options)
+		if err != nil {
+			utils.HandleErrorAndExit("Error applying projects", err)
+		}
+		if len(failedProjects) > 0 {
+			fmt.Fprintln(utils.Stdout, "\nFailed projects ("+strconv.Itoa(len(failedProjects))+"):")
+			for _, project := range failedProjects {
+				fmt.Fprintln(utils.Stdout, "  "+project.Type+": "+project.Name+" "+project.Version+" ("+project.Path()+
+					"): "+project.Error.Error())
+				utils.AddCommandWarning("Failed to " + project.Action + " " + project.Type + " " + project.Name + " " +
+					project.Version + " (" + project.Path() + "): " + project.Error.Error())
+			}
+			utils.HandleErrorAndExit("There are project failures while applying "+applyDir,
+				errors.New(strconv.Itoa(len(failedProjects))+" project(s) failed"))
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(ApplyCmd)
+	ApplyCmd.Flags().StringVarP(&applyDir, "file", "f", "",
+		"Path to the directory containing the projects to be applied")
+	ApplyCmd.Flags().StringVarP(&applyEnvironment, "environment", "e", "",
+		"Environment to which the projects should be applied")
+	ApplyCmd.Flags().StringVarP(&applyParamsDir, "params", "", "", "Directory containing the deployment "+
+		"directories generated using \"gen deployment-dir\" in the same relative paths as the projects")
+	ApplyCmd.Flags().BoolVar(&applyPrune, "prune", false, "Delete the APIs, API Products, "+
+		"Applications and policies in the environment that are not available in the directory")
+	ApplyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Print the actions to be taken without "+
+		"applying them")
+	ApplyCmd.Flags().BoolVar(&applyPreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of APIs and API Products after applying. Overrides preserveProvider "+
+			"of the meta files of the projects")
+	ApplyCmd.Flags().BoolVar(&applyRotateRevision, "rotate-revision", false, "Rotate the "+
+		"revisions of APIs and API Products with each update")
+	ApplyCmd.Flags().BoolVarP(&applySkipCleanup, "skip-cleanup", "", false, "Leave "+
+		"all temporary files created during the apply process")
+	_ = ApplyCmd.MarkFlagRequired("file")
+	_ = ApplyCmd.MarkFlagRequired("environment")
+}
@@ -411,7 +411,8 @@ func initializeProject() error {
 	initCmdInitialState := "CREATED"
 	initCmdApiDefinitionPath := ""
 	advertiseOnly := true
-	err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, path, initCmdApiDefinitionPath, advertiseOnly)
+	err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, path, impl.InitSourceOAS,
+		initCmdApiDefinitionPath, advertiseOnly)
 	if err != nil {
 		utils.HandleErrorAndContinue("Error initializing project", err)
 		// Remove the already created project with its content since it is partially created and wrong
@@ -0,0 +1,96 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var blockSubscriptionCmdAppName string
+var blockSubscriptionCmdAppOwner string
+var blockSubscriptionCmdProductionOnly bool
+var blockSubscriptionCmdEnvironment string
+var blockSubscriptionCmdArtifactFlags subscriptionArtifactFlags
+
+// BlockSubscriptionCmd command related usage info
+const BlockSubscriptionCmdLiteral = "subscription"
+const blockSubscriptionCmdShortDesc = "Block a subscription of an application"
+
+const blockSubscriptionCmdLongDesc = "Block the subscription of an application to an API, API Product or MCP Server in the environment specified by flag (--environment, -e). " +
+	"Only the production keys of the application are blocked if the flag (--production-only) is provided"
+
+const blockSubscriptionCmdExamples = utils.ProjectName + ` ` + BlockCmdLiteral + ` ` + BlockSubscriptionCmdLiteral + ` --app SampleApp --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + BlockCmdLiteral + ` ` + BlockSubscriptionCmdLiteral + ` --app SampleApp --owner alice --api-product LeasingAPIProduct -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + BlockCmdLiteral + ` ` + BlockSubscriptionCmdLiteral + ` --app SampleApp --mcp-server WeatherMCPServer -v 1.0.0 --production-only -e dev
+NOTE: The 3 flags (--app, --version (-v), --environment (-e)) and one of the flags (--api), (--api-product) or (--mcp-server) are mandatory.
+The flag (--owner) is required if applications of multiple owners having the same name are subscribed.`
+
+// BlockSubscriptionCmd represents the block subscription command
+var BlockSubscriptionCmd = &cobra.Command{
+	Use:         BlockSubscriptionCmdLiteral,
+	Short:       blockSubscriptionCmdShortDesc,
+	Long:        blockSubscriptionCmdLongDesc,
+	Example:     blockSubscriptionCmdExamples,
+	Annotations: resultAnnotations("subscription", resultActionBlocked),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + BlockCmdLiteral + " " + BlockSubscriptionCmdLiteral + " called")
+		artifact := blockSubscriptionCmdArtifactFlags.getArtifact(true)
+		cred, err := GetCredentials(blockSubscriptionCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, blockSubscriptionCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to block the subscription", err)
+		}
+		blockState := impl.SubscriptionBlockStateBlocked
+		if blockSubscriptionCmdProductionOnly {
+			blockState = impl.SubscriptionBlockStateProdOnlyBlocked
+		}
+		subscriptionId, err := impl.BlockSubscription(accessToken, blockSubscriptionCmdEnvironment,
+			blockSubscriptionCmdAppName, blockSubscriptionCmdAppOwner, artifact, blockState)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while blocking the subscription of "+blockSubscriptionCmdAppName, err)
+		}
+		utils.GetCommandResult().ID = subscriptionId
+		fmt.Fprintln(utils.Stdout, "Subscription of application "+blockSubscriptionCmdAppName+" to the "+artifact.Type+" "+
+			artifact.Name+" "+artifact.Version+" blocked successfully")
+	},
+}
+
+// init using Cobra
+func init() {
+	BlockCmd.AddCommand(BlockSubscriptionCmd)
+	addSubscriptionArtifactFlags(BlockSubscriptionCmd, &blockSubscriptionCmdArtifactFlags)
+	BlockSubscriptionCmd.Flags().StringVarP(&blockSubscriptionCmdAppName, "app", "", "", "Name of the application")
+	BlockSubscriptionCmd.Flags().StringVarP(&blockSubscriptionCmdAppOwner, "owner", "", "",
+		"Owner of the application")
+	BlockSubscriptionCmd.Flags().BoolVarP(&blockSubscriptionCmdProductionOnly, "production-only", "", false,
+		"Block only the production keys of the application")
+	BlockSubscriptionCmd.Flags().StringVarP(&blockSubscriptionCmdEnvironment, "environment", "e", "",
+		"Environment of the subscription")
+	_ = BlockSubscriptionCmd.MarkFlagRequired("app")
+	_ = BlockSubscriptionCmd.MarkFlagRequired("version")
+	_ = BlockSubscriptionCmd.MarkFlagRequired("environment")
+}
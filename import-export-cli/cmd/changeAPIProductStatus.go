@@ -47,9 +47,10 @@ NOTE: The 3 flags (--action (-a), --name (-n), --version (-v) and --environment
 var ChangeAPIProductStatusCmd = &cobra.Command{
 	Use: changeAPIProductStatusCmdLiteral + " (--action <action-of-the-api-product-state-change> --name <name-of-the-api-product> --version <version-of-the-api-product> --provider " +
 		"<provider-of-the-api-product> --environment <environment-from-which-the-api-product-state-should-be-changed>)",
-	Short:   changeAPIProductStatusCmdShortDesc,
-	Long:    changeAPIProductStatusCmdLongDesc,
-	Example: changeAPIProductStatusCmdExamples,
+	Short:       changeAPIProductStatusCmdShortDesc,
+	Long:        changeAPIProductStatusCmdLongDesc,
+	Example:     changeAPIProductStatusCmdExamples,
+	Annotations: resultAnnotations("api-product", resultActionStatusChanged),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + changeAPIProductStatusCmdLiteral + " called")
 		cred, err := GetCredentials(apiProductStateChangeEnvironment)
@@ -73,17 +74,13 @@ func executeChangeAPIProductStatusCmd(credential credentials.Credential) {
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
 			// 200 OK
-			fmt.Println(apiNameForStateChange + " API Product state changed successfully!")
-		} else if resp.StatusCode() == http.StatusInternalServerError {
-			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(utils.Stdout, apiNameForStateChange+" API Product state changed successfully!")
 		} else {
-			// Neither 200 nor 500
-			fmt.Println("Error while changing API Product Status: ", resp.Status(), "\n", string(resp.Body()))
+			utils.HandleErrorResponseAndExit("Error while changing API Product Status", resp)
 		}
 	} else {
 		// Error changing the API Product status
-		fmt.Println("Error getting OAuth tokens while changing status of the API Product : " + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while changing status of the API Product", preCommandErr)
 	}
 }
 
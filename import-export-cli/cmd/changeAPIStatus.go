@@ -48,9 +48,10 @@ NOTE: The 4 flags (--action (-a), --name (-n), --version (-v), and --environment
 var ChangeAPIStatusCmd = &cobra.Command{
 	Use: changeAPIStatusCmdLiteral + " (--action <action-of-the-api-state-change> --name <name-of-the-api> --version <version-of-the-api> --provider " +
 		"<provider-of-the-api> --environment <environment-from-which-the-api-state-should-be-changed>)",
-	Short:   changeAPIStatusCmdShortDesc,
-	Long:    changeAPIStatusCmdLongDesc,
-	Example: changeAPIStatusCmdExamples,
+	Short:       changeAPIStatusCmdShortDesc,
+	Long:        changeAPIStatusCmdLongDesc,
+	Example:     changeAPIStatusCmdExamples,
+	Annotations: resultAnnotations("api", resultActionStatusChanged),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + changeAPIStatusCmdLiteral + " called")
 		cred, err := GetCredentials(apiStateChangeEnvironment)
@@ -74,17 +75,13 @@ func executeChangeAPIStatusCmd(credential credentials.Credential) {
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
 			// 200 OK
-			fmt.Println(apiNameForStateChange + " API state changed successfully!")
-		} else if resp.StatusCode() == http.StatusInternalServerError {
-			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(utils.Stdout, apiNameForStateChange+" API state changed successfully!")
 		} else {
-			// Neither 200 nor 500
-			fmt.Println("Error while changing API Status: ", resp.Status(), "\n", string(resp.Body()))
+			utils.HandleErrorResponseAndExit("Error while changing API Status", resp)
 		}
 	} else {
 		// Error changing the API status
-		fmt.Println("Error getting OAuth tokens while changing status of the API:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while changing status of the API", preCommandErr)
 	}
 }
 
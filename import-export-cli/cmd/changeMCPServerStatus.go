@@ -48,9 +48,10 @@ NOTE: The 4 flags (--action (-a), --name (-n), --version (-v), and --environment
 var ChangeMCPServerStatusCmd = &cobra.Command{
 	Use: changeMCPServerStatusCmdLiteral + " (--action <action-of-the-mcpserver-state-change> --name <name-of-the-mcpserver> --version <version-of-the-mcpserver> --provider " +
 		"<provider-of-the-mcpserver> --environment <environment-from-which-the-mcpserver-state-should-be-changed>)",
-	Short:   changeMCPServerStatusCmdShortDesc,
-	Long:    changeMCPServerStatusCmdLongDesc,
-	Example: changeMCPServerStatusCmdExamples,
+	Short:       changeMCPServerStatusCmdShortDesc,
+	Long:        changeMCPServerStatusCmdLongDesc,
+	Example:     changeMCPServerStatusCmdExamples,
+	Annotations: resultAnnotations("mcp-server", resultActionStatusChanged),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + changeMCPServerStatusCmdLiteral + " called")
 		cred, err := GetCredentials(mcpServerStateChangeEnvironment)
@@ -74,17 +75,13 @@ func executeChangeMCPServerStatusCmd(credential credentials.Credential) {
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
 			// 200 OK
-			fmt.Println(mcpServerNameForStateChange + " MCP Server state changed successfully!")
-		} else if resp.StatusCode() == http.StatusInternalServerError {
-			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(utils.Stdout, mcpServerNameForStateChange+" MCP Server state changed successfully!")
 		} else {
-			// Neither 200 nor 500
-			fmt.Println("Error while changing MCP Server Status: ", resp.Status(), "\n", string(resp.Body()))
+			utils.HandleErrorResponseAndExit("Error while changing MCP Server Status", resp)
 		}
 	} else {
 		// Error changing the MCP Server status
-		fmt.Println("Error getting OAuth tokens while changing status of the MCP Server:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while changing status of the MCP Server", preCommandErr)
 	}
 }
 
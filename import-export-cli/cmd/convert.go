@@ -0,0 +1,95 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	convertFrom        string
+	convertFile        string
+	convertDestination string
+	convertVersion     string
+	convertForced      bool
+)
+
+const (
+	// Convert command related usage info
+	ConvertCmdLiteral   = "convert"
+	convertCmdShortDesc = "Convert the APIs exported from another gateway to API projects"
+	convertCmdLongDesc  = `Convert an offline export of another gateway specified by flag (--file, -f) to API projects in the directory
+specified by flag (--destination, -d). Kong decK declarative configurations (kong), Azure API Management ARM templates
+(azure) and Apigee proxy bundles (apigee) are supported. Rate limits are converted to advanced rate limiting policies
+and plugins are converted to operation policies where possible. The features that could not be converted are listed in
+` + impl.ConvertReportFileName + ` of the destination. The destination could be applied to an environment with the apply command.`
+)
+
+const convertCmdExamples = utils.ProjectName + ` ` + ConvertCmdLiteral + ` --from kong -f kong.yaml -d ./migrated
+` + utils.ProjectName + ` ` + ConvertCmdLiteral + ` --from azure -f apim-template.json -d ./migrated --version v1
+` + utils.ProjectName + ` ` + ConvertCmdLiteral + ` --from apigee -f orders-proxy.zip -d ./migrated --force
+NOTE: The flags (--from, --file (-f) and --destination (-d)) are mandatory`
+
+// ConvertCmd represents the convert command
+var ConvertCmd = &cobra.Command{
+	Use: ConvertCmdLiteral + " --from <" + strings.Join(impl.ConvertSources, "|") + "> --file <path-to-export> " +
+		"--destination <path-to-projects-dir>",
+	Short:   convertCmdShortDesc,
+	Long:    convertCmdLongDesc,
+	Example: convertCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ConvertCmdLiteral + " called")
+		report, err := impl.ConvertToAPIProjects(strings.ToLower(convertFrom), convertFile, convertDestination,
+			convertVersion, convertForced)
+		if err != nil {
+			utils.HandleErrorAndExit("Error converting "+convertFile, err)
+		}
+		unmapped := len(report.Unmapped)
+		for _, api := range report.APIs {
+			unmapped += len(api.Unmapped)
+		}
+		fmt.Printf("Converted %d APIs and %d rate limiting policies to %s\n", len(report.APIs),
+			len(report.RateLimitingPolicies), convertDestination)
+		if unmapped > 0 {
+			fmt.Printf("%d features could not be converted. See %s for the details\n", unmapped,
+				impl.ConvertReportFileName)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(ConvertCmd)
+	ConvertCmd.Flags().StringVar(&convertFrom, "from", "", "Gateway the export belongs to. One of "+
+		strings.Join(impl.ConvertSources, ", "))
+	ConvertCmd.Flags().StringVarP(&convertFile, "file", "f", "", "Path of the export. A decK configuration "+
+		"for kong, an ARM template for azure and a proxy bundle zip or directory for apigee")
+	ConvertCmd.Flags().StringVarP(&convertDestination, "destination", "d", "", "Path of the directory "+
+		"to write the projects to")
+	ConvertCmd.Flags().StringVarP(&convertVersion, "version", "v", "1.0.0", "Version of the APIs that "+
+		"do not have a version in the export")
+	ConvertCmd.Flags().BoolVar(&convertForced, "force", false, "Overwrite the existing projects")
+	_ = ConvertCmd.MarkFlagRequired("from")
+	_ = ConvertCmd.MarkFlagRequired("file")
+	_ = ConvertCmd.MarkFlagRequired("destination")
+}
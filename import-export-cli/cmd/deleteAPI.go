@@ -19,8 +19,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -49,6 +47,7 @@ var DeleteAPICmd = &cobra.Command{
 	Short:              deleteAPICmdShortDesc,
 	Long:               deleteAPICmdLongDesc,
 	Example:            deleteAPICmdExamplesDefault,
+	Annotations:        resultAnnotations("api", resultActionDeleted),
 	DisableFlagParsing: isK8sEnabled(),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + deleteAPICmdLiteral + " called")
@@ -78,7 +77,7 @@ func executeDeleteAPICmd(credential credentials.Credential) {
 		impl.PrintDeleteAPIResponse(resp, err)
 	} else {
 		// Error deleting API
-		fmt.Println("Error getting OAuth tokens while deleting API:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while deleting API", preCommandErr)
 	}
 }
 
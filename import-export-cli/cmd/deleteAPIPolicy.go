@@ -19,8 +19,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -43,9 +41,10 @@ const DeleteAPIPolicyCmdExamplesDefault = utils.ProjectName + ` ` + deleteCmdLit
 var DeleteAPIPolicyCmd = &cobra.Command{
 	Use: DeleteAPIPolicyCmdLiteral + " (--name <name-of-the-api-policy> --environment " +
 		"<environment-from-which-the-policy-should-be-deleted>)",
-	Short:   DeleteAPIPolicyCmdShortDesc,
-	Long:    DeleteAPIPolicyCmdLongDesc,
-	Example: DeleteAPIPolicyCmdExamplesDefault,
+	Short:       DeleteAPIPolicyCmdShortDesc,
+	Long:        DeleteAPIPolicyCmdLongDesc,
+	Example:     DeleteAPIPolicyCmdExamplesDefault,
+	Annotations: resultAnnotations("api-policy", resultActionDeleted),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + DeleteAPIPolicyCmdLiteral + " called")
 
@@ -70,7 +69,7 @@ func executeDeleteAPIPolicyCmd(credential credentials.Credential) {
 		impl.PrintDeleteAPIPolicyResponse(deleteAPIPolicyName, deleteAPIPolicyVersion, err)
 	} else {
 		// Error deleting API Policy
-		fmt.Println("Error getting OAuth tokens while deleting API Policy:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while deleting API Policy", preCommandErr)
 	}
 }
 
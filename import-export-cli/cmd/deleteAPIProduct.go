@@ -19,8 +19,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/spf13/cobra"
@@ -46,9 +44,10 @@ NOTE: Both the flags (--name (-n), --version (-v), and --environment (-e)) are m
 var DeleteAPIProductCmd = &cobra.Command{
 	Use: deleteAPIProductCmdLiteral + " (--name <name-of-the-api-product> --version <version-of-the-api-product> --provider <provider-of-the-api-product> --environment " +
 		"<environment-from-which-the-api-product-should-be-deleted>)",
-	Short:   deleteAPIProductCmdShortDesc,
-	Long:    deleteAPIProductCmdLongDesc,
-	Example: deleteAPIProductCmdExamples,
+	Short:       deleteAPIProductCmdShortDesc,
+	Long:        deleteAPIProductCmdLongDesc,
+	Example:     deleteAPIProductCmdExamples,
+	Annotations: resultAnnotations("api-product", resultActionDeleted),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + deleteAPIProductCmdLiteral + " called")
 		cred, err := GetCredentials(deleteAPIProductEnvironment)
@@ -70,7 +69,7 @@ func executeDeleteAPIProductCmd(credential credentials.Credential) {
 		impl.PrintDeleteAPIProductResponse(resp, err)
 	} else {
 		// Error deleting API Product
-		fmt.Println("Error getting OAuth tokens while deleting API Product:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while deleting API Product", preCommandErr)
 	}
 }
 
@@ -19,8 +19,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -45,9 +43,10 @@ NOTE: Both the flags (--name (-n), and --environment (-e)) are mandatory and the
 var DeleteAppCmd = &cobra.Command{
 	Use: deleteAppCmdLiteral + " (--name <name-of-the-application> --owner <owner-of-the-application> --environment " +
 		"<environment-from-which-the-application-should-be-deleted>)",
-	Short:   deleteAppCmdShortDesc,
-	Long:    deleteAppCmdLongDesc,
-	Example: deleteAppCmdExamples,
+	Short:       deleteAppCmdShortDesc,
+	Long:        deleteAppCmdLongDesc,
+	Example:     deleteAppCmdExamples,
+	Annotations: resultAnnotations("app", resultActionDeleted),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + deleteAppCmdLiteral + " called")
 		cred, err := GetCredentials(deleteAppEnvironment)
@@ -72,7 +71,7 @@ func executeDeleteAppCmd(credential credentials.Credential) {
 		impl.PrintDeleteAppResponse(resp, err)
 	} else {
 		// Error deleting Application
-		fmt.Println("Error getting OAuth tokens while deleting Application:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while deleting Application", preCommandErr)
 	}
 }
 
@@ -19,8 +19,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -49,6 +47,7 @@ var DeleteMCPServerCmd = &cobra.Command{
 	Short:              deleteMCPServerCmdShortDesc,
 	Long:               deleteMCPServerCmdLongDesc,
 	Example:            deleteMCPServerCmdExamplesDefault,
+	Annotations:        resultAnnotations("mcp-server", resultActionDeleted),
 	DisableFlagParsing: isK8sEnabled(),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + deleteMCPServerCmdLiteral + " called")
@@ -78,7 +77,7 @@ func executeDeleteMCPServerCmd(credential credentials.Credential) {
 		impl.PrintDeleteMCPServerResponse(resp, err)
 	} else {
 		// Error deleting MCP Server
-		fmt.Println("Error getting OAuth tokens while deleting MCP Server:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while deleting MCP Server", preCommandErr)
 	}
 }
 
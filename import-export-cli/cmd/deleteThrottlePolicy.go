@@ -19,8 +19,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -47,9 +45,10 @@ var DeleteThrottlingPolicyCmd = &cobra.Command{
 	Use: DeleteThrottlingPolicyCmdLiteral + " (--name <name-of-the-throttling-policy> --environment " +
 		"<environment-from-which-the-policy-should-be-deleted>)" +
 		"--type <type-of-the-throttling-policy>",
-	Short:   DeleteThrottlingPolicyCmdShortDesc,
-	Long:    DeleteThrottlingPolicyCmdLongDesc,
-	Example: DeleteThrottlingPolicyCmdExamplesDefault,
+	Short:       DeleteThrottlingPolicyCmdShortDesc,
+	Long:        DeleteThrottlingPolicyCmdLongDesc,
+	Example:     DeleteThrottlingPolicyCmdExamplesDefault,
+	Annotations: resultAnnotations("rate-limiting-policy", resultActionDeleted),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + DeleteThrottlingPolicyCmdLiteral + " called")
 
@@ -72,7 +71,7 @@ func executeDeleteThrottlingPolicyCmd(credential credentials.Credential) {
 		impl.PrintDeleteThrottlingPolicyResponse(deleteThrottlingPolicyName, deleteThrottlingPolicyType, err)
 	} else {
 		// Error deleting Throttling Policy
-		fmt.Println("Error getting OAuth tokens while deleting Throttling Policy:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens while deleting Throttling Policy", preCommandErr)
 	}
 }
 
@@ -0,0 +1,119 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Deploy command related usage Info
+const DeployRevisionCmdLiteral = "deploy"
+const deployRevisionCmdShortDesc = "Deploy an API/MCP Server/API Product revision to gateway environments"
+
+const deployRevisionCmdLongDesc = `Deploy an API/MCP Server/API Product revision available in the environment specified by flag (--environment, -e) to the gateways specified by flag (--gateway-env, -g). A revision deployed in a gateway environment can be promoted to other gateway environments using the flag (--from-gateway-env)`
+
+const deployRevisionCmdExamples = utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin --rev 1 -g Label1 -g Label2 -e dev
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPICmdLiteral + ` -n PizzaAPI -v 1.0.0 --from-gateway-env staging -g production --vhost api.pizza.com -e dev
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployMCPServerCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 --rev 1 -g Label1 -e dev
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 --rev 3 -g Label1 -e dev`
+
+// DeployRevisionCmd represents the deploy command
+var DeployRevisionCmd = &cobra.Command{
+	Use:     DeployRevisionCmdLiteral,
+	Short:   deployRevisionCmdShortDesc,
+	Long:    deployRevisionCmdLongDesc,
+	Example: deployRevisionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DeployRevisionCmdLiteral + " called")
+
+	},
+}
+
+// revisionDeployFlags holds the flags of the deploy commands of the artifacts having revisions
+type revisionDeployFlags struct {
+	name           string
+	version        string
+	provider       string
+	revisionNum    string
+	fromGatewayEnv string
+	gatewayEnvs    []string
+	vhost          string
+	environment    string
+}
+
+// addRevisionDeployFlags adds the flags of a deploy command of an artifact having revisions
+func addRevisionDeployFlags(cmd *cobra.Command, flags *revisionDeployFlags, artifactType string) {
+	cmd.Flags().StringVarP(&flags.name, "name", "n", "", "Name of the "+artifactType+" to be deployed")
+	cmd.Flags().StringVarP(&flags.version, "version", "v", "", "Version of the "+artifactType+" to be deployed")
+	cmd.Flags().StringVarP(&flags.provider, "provider", "r", "", "Provider of the "+artifactType)
+	cmd.Flags().StringVarP(&flags.revisionNum, "rev", "", "", "Revision number of the "+artifactType+" to deploy")
+	cmd.Flags().StringVarP(&flags.fromGatewayEnv, "from-gateway-env", "", "",
+		"Gateway environment of which the deployed revision has to be deployed")
+	cmd.Flags().StringSliceVarP(&flags.gatewayEnvs, "gateway-env", "g", []string{},
+		"Gateway environment which the revision has to be deployed")
+	cmd.Flags().StringVarP(&flags.vhost, "vhost", "", "",
+		"Virtual host of the gateway environments. The default vhost of each gateway environment is used if "+
+			"not specified")
+	cmd.Flags().StringVarP(&flags.environment, "environment", "e", "",
+		"Environment of which the "+artifactType+" should be deployed")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("version")
+	_ = cmd.MarkFlagRequired("gateway-env")
+	_ = cmd.MarkFlagRequired("environment")
+	cmd.MarkFlagsMutuallyExclusive("rev", "from-gateway-env")
+}
+
+func executeDeployRevisionCmd(flags *revisionDeployFlags, artifactType string) {
+	if flags.revisionNum == "" && flags.fromGatewayEnv == "" {
+		utils.HandleErrorAndExit("Either the revision number (--rev) or the gateway environment of the revision "+
+			"(--from-gateway-env) is required", nil)
+	}
+	cred, err := GetCredentials(flags.environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, flags.environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens to deploy the "+artifactType, err)
+	}
+
+	deployments := generateGatewayEnvsArray(flags.gatewayEnvs)
+	for i := range deployments {
+		deployments[i].Vhost = flags.vhost
+	}
+	revisionNum, err := impl.DeployRevision(accessToken, flags.environment, artifactType, flags.name, flags.version,
+		flags.provider, flags.revisionNum, flags.fromGatewayEnv, deployments)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while deploying the "+artifactType, err)
+	}
+	utils.GetCommandResult().Revision = revisionNum
+	fmt.Fprintln(utils.Stdout, "Revision "+revisionNum+" of "+artifactType+" "+flags.name+"_"+flags.version+
+		" successfully deployed to the gateway environments "+strings.Join(flags.gatewayEnvs, ", "))
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(DeployRevisionCmd)
+}
@@ -0,0 +1,60 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var deployAPIFlags revisionDeployFlags
+
+// DeployAPICmd command related usage info
+const DeployAPICmdLiteral = "api"
+const deployAPICmdShortDesc = "Deploy API"
+
+const deployAPICmdLongDesc = "Deploy an API revision to gateway environments"
+
+const deployAPICmdExamples = utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 --rev 2 -g Label1 -e dev
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r alice --rev 6 -g Label1 -g Label2 --vhost us.wso2.com -e production
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 --from-gateway-env staging -g production -e dev
+NOTE: The 4 flags (--name (-n), --version (-v), --gateway-env (-g), --environment (-e)) are mandatory. Either the flag (--rev) or (--from-gateway-env) is required.
+If the flag (--vhost) is not provided, the revision will be deployed to the default vhost of each gateway environment.`
+
+// DeployAPICmd represents the deploy api command
+var DeployAPICmd = &cobra.Command{
+	Use: DeployAPICmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> " +
+		"--provider <provider-of-the-api> --rev <revision-number-of-the-api> " +
+		"--gateway-env <gateway-environment> --environment <environment-to-which-the-api-should-be-deployed>)",
+	Short:       deployAPICmdShortDesc,
+	Long:        deployAPICmdLongDesc,
+	Example:     deployAPICmdExamples,
+	Annotations: resultAnnotations("api", resultActionDeployed),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DeployAPICmdLiteral + " called")
+		executeDeployRevisionCmd(&deployAPIFlags, impl.RevisionArtifactAPI)
+	},
+}
+
+// init using Cobra
+func init() {
+	DeployRevisionCmd.AddCommand(DeployAPICmd)
+	addRevisionDeployFlags(DeployAPICmd, &deployAPIFlags, impl.RevisionArtifactAPI)
+}
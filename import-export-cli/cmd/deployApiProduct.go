@@ -0,0 +1,60 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var deployAPIProductFlags revisionDeployFlags
+
+// DeployAPIProductCmd command related usage info
+const DeployAPIProductCmdLiteral = "api-product"
+const deployAPIProductCmdShortDesc = "Deploy API Product"
+
+const deployAPIProductCmdLongDesc = "Deploy an API Product revision to gateway environments"
+
+const deployAPIProductCmdExamples = utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 --rev 2 -g Label1 -e dev
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 -r alice --rev 6 -g Label1 -g Label2 --vhost us.wso2.com -e production
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 --from-gateway-env staging -g production -e dev
+NOTE: The 4 flags (--name (-n), --version (-v), --gateway-env (-g), --environment (-e)) are mandatory. Either the flag (--rev) or (--from-gateway-env) is required.
+If the flag (--vhost) is not provided, the revision will be deployed to the default vhost of each gateway environment.`
+
+// DeployAPIProductCmd represents the deploy api-product command
+var DeployAPIProductCmd = &cobra.Command{
+	Use: DeployAPIProductCmdLiteral + " (--name <name-of-the-api-product> --version <version-of-the-api-product> " +
+		"--provider <provider-of-the-api-product> --rev <revision-number-of-the-api-product> " +
+		"--gateway-env <gateway-environment> --environment <environment-to-which-the-api-product-should-be-deployed>)",
+	Short:       deployAPIProductCmdShortDesc,
+	Long:        deployAPIProductCmdLongDesc,
+	Example:     deployAPIProductCmdExamples,
+	Annotations: resultAnnotations("api-product", resultActionDeployed),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DeployAPIProductCmdLiteral + " called")
+		executeDeployRevisionCmd(&deployAPIProductFlags, impl.RevisionArtifactAPIProduct)
+	},
+}
+
+// init using Cobra
+func init() {
+	DeployRevisionCmd.AddCommand(DeployAPIProductCmd)
+	addRevisionDeployFlags(DeployAPIProductCmd, &deployAPIProductFlags, impl.RevisionArtifactAPIProduct)
+}
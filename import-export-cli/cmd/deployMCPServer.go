@@ -0,0 +1,60 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var deployMCPServerFlags revisionDeployFlags
+
+// DeployMCPServerCmd command related usage info
+const DeployMCPServerCmdLiteral = "mcp-server"
+const deployMCPServerCmdShortDesc = "Deploy MCP Server"
+
+const deployMCPServerCmdLongDesc = "Deploy an MCP Server revision to gateway environments"
+
+const deployMCPServerCmdExamples = utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployMCPServerCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 --rev 2 -g Label1 -e dev
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployMCPServerCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 -r alice --rev 6 -g Label1 -g Label2 --vhost us.wso2.com -e production
+` + utils.ProjectName + ` ` + DeployRevisionCmdLiteral + ` ` + DeployMCPServerCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 --from-gateway-env staging -g production -e dev
+NOTE: The 4 flags (--name (-n), --version (-v), --gateway-env (-g), --environment (-e)) are mandatory. Either the flag (--rev) or (--from-gateway-env) is required.
+If the flag (--vhost) is not provided, the revision will be deployed to the default vhost of each gateway environment.`
+
+// DeployMCPServerCmd represents the deploy mcp-server command
+var DeployMCPServerCmd = &cobra.Command{
+	Use: DeployMCPServerCmdLiteral + " (--name <name-of-the-mcp-server> --version <version-of-the-mcp-server> " +
+		"--provider <provider-of-the-mcp-server> --rev <revision-number-of-the-mcp-server> " +
+		"--gateway-env <gateway-environment> --environment <environment-to-which-the-mcp-server-should-be-deployed>)",
+	Short:       deployMCPServerCmdShortDesc,
+	Long:        deployMCPServerCmdLongDesc,
+	Example:     deployMCPServerCmdExamples,
+	Annotations: resultAnnotations("mcp-server", resultActionDeployed),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DeployMCPServerCmdLiteral + " called")
+		executeDeployRevisionCmd(&deployMCPServerFlags, impl.RevisionArtifactMCPServer)
+	},
+}
+
+// init using Cobra
+func init() {
+	DeployRevisionCmd.AddCommand(DeployMCPServerCmd)
+	addRevisionDeployFlags(DeployMCPServerCmd, &deployMCPServerFlags, impl.RevisionArtifactMCPServer)
+}
@@ -28,6 +28,7 @@ import (
 // Executes all deprecated child commands.
 // This is called by main.main(). It only needs to happen once.
 func Execute() {
+	cmd.AddPluginCmds(os.Args[1:])
 	if err := cmd.RootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(-1)
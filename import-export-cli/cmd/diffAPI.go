@@ -0,0 +1,87 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	diffAPIFile        string
+	diffAPIEnvironment string
+	diffAPIFormat      string
+)
+
+const (
+	// DiffAPI command related usage info
+	DiffAPICmdLiteral   = "api"
+	diffAPICmdShortDesc = "Compare an API project with the deployed API"
+	diffAPICmdLongDesc  = `Compare an API project with the API deployed in an environment. The deployed revision of the API
+(or the working copy if no revision is deployed) is exported and compared with the project field by field. The api.yaml
+fields, endpoint configuration, operations, policies and the API definition (paths and verbs) are compared ignoring
+field ordering and generated identifiers. Changes are shown relative to the environment, hence "added" means the
+project has something the deployed API does not have.`
+)
+
+const diffAPICmdExamples = utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -f ~/PizzaShackAPI -e dev
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -f qa/PizzaShackAPI.zip -e production --format json
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -f ~/PizzaShackAPI -e production --format diff
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+
+// DiffAPICmd represents the diff api command
+var DiffAPICmd = &cobra.Command{
+	Use:     DiffAPICmdLiteral + " --file <path-to-api> --environment <environment>",
+	Short:   diffAPICmdShortDesc,
+	Long:    diffAPICmdLongDesc,
+	Example: diffAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DiffCmdLiteral + " " + DiffAPICmdLiteral + " called")
+		cred, err := GetCredentials(diffAPIEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessOAuthToken, err := credentials.GetOAuthAccessToken(cred, diffAPIEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for comparing the API", err)
+		}
+		apiDiff, err := impl.DiffAPIWithEnv(accessOAuthToken, diffAPIEnvironment, diffAPIFile)
+		if err != nil {
+			utils.HandleErrorAndExit("Error comparing the API", err)
+		}
+		if err = impl.PrintAPIDiff(apiDiff, diffAPIFormat); err != nil {
+			utils.HandleErrorAndExit("Error printing the differences", err)
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	DiffCmd.AddCommand(DiffAPICmd)
+	DiffAPICmd.Flags().StringVarP(&diffAPIFile, "file", "f", "",
+		"Path to the API project directory or archive")
+	DiffAPICmd.Flags().StringVarP(&diffAPIEnvironment, "environment", "e", "",
+		"Environment of the deployed API to compare with")
+	DiffAPICmd.Flags().StringVarP(&diffAPIFormat, "format", "", "", "Output format of the differences. "+
+		"Supported formats: [table, json, diff]. If not provided, the default format is table.")
+	_ = DiffAPICmd.MarkFlagRequired("file")
+	_ = DiffAPICmd.MarkFlagRequired("environment")
+}
@@ -19,6 +19,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -35,10 +36,12 @@ const exportAPIsCmdLongDesc = "Export all the APIs of a tenant from one environm
 	"into another environment"
 const exportAPIsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --force
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --all --workers 8
 NOTE: The flag (--environment (-e)) is mandatory`
 
 var exportAPIsFormat string
 var exportAPIsAllRevisions bool
+var exportAPIsWorkers int
 
 //e.g. /home/samithac/.wso2apictl/exported/migration/production-2.5/wso2-dot-org
 var startFromBeginning bool
@@ -54,6 +57,9 @@ var ExportAPIsCmd = &cobra.Command{
 		utils.Logln(utils.LogPrefixInfo + ExportAPIsCmdLiteral + " called")
 		var artifactExportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedMigrationArtifactsDirName)
 
+		if exportAPIsWorkers < 1 {
+			utils.HandleErrorAndExit("Invalid number of workers", errors.New("--workers should be at least 1"))
+		}
 		cred, err := GetCredentials(CmdExportEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -88,7 +94,7 @@ func executeExportAPIsCmd(credential credentials.Credential, exportDirectory str
 
 	impl.ExportAPIs(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain, exportAPIsFormat,
 		CmdUsername, apiExportDir, exportAPIPreserveStatus, runningExportApiCommand, exportAPIsAllRevisions, false,
-		exportAPIPreserveCredentials)
+		exportAPIPreserveCredentials, exportAPIsWorkers)
 }
 
 func init() {
@@ -104,6 +110,8 @@ func init() {
         "Preserve endpoint credentials when exporting. Otherwise credentials will not be exported")
 	ExportAPIsCmd.Flags().BoolVarP(&exportAPIsAllRevisions, "all", "", false,
 		"Export working copy and all revisions for the APIs in the environments ")
+	ExportAPIsCmd.Flags().IntVarP(&exportAPIsWorkers, "workers", "", 1,
+		"Number of APIs exported concurrently")
 	ExportAPIsCmd.Flags().StringVarP(&exportAPIsFormat, "format", "", utils.DefaultExportFormat, "File format of exported archives(json or yaml)")
 	_ = ExportAPIsCmd.MarkFlagRequired("environment")
 }
@@ -19,6 +19,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -31,6 +32,7 @@ import (
 
 var exportAppsWithKeys bool
 var exportAppsFormat string
+var exportAppsWorkers int
 var startFromBeginningForApps bool
 var isProcessCompletedForApps bool
 
@@ -42,6 +44,7 @@ const exportAppsCmdLongDesc = "Export Applications of a given tenant from a spec
 
 const exportAppsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e dev --force
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e prod
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e prod --workers 8
 NOTE: The flag (--environment (-e)) is mandatory`
 
 // ExportAppsCmd represents the exportApps command
@@ -55,6 +58,9 @@ var ExportAppsCmd = &cobra.Command{
 		utils.Logln(utils.LogPrefixInfo + ExportAppsCmdLiteral + " called")
 		var appsExportDirectoryPath = filepath.Join(utils.ExportDirectory, utils.ExportedMigrationArtifactsDirName)
 
+		if exportAppsWorkers < 1 {
+			utils.HandleErrorAndExit("Invalid number of workers", errors.New("--workers should be at least 1"))
+		}
 		cred, err := GetCredentials(CmdExportEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -85,7 +91,7 @@ func executeExportAppsCmd(credential credentials.Credential, appsExportDirectory
     }
 
     impl.ExportApps(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain, exportAppsFormat,
-     CmdUsername, appExportDir, exportAppsWithKeys)
+     CmdUsername, appExportDir, exportAppsWithKeys, exportAppsWorkers)
 }
 
 // Init using Cobra
@@ -98,6 +104,8 @@ func init() {
 		"", "Environment from which the Applications should be exported")
 	ExportAppsCmd.Flags().BoolVarP(&exportAppsWithKeys, "with-keys", "",
 		false, "Export keys for the applications")
+	ExportAppsCmd.Flags().IntVarP(&exportAppsWorkers, "workers", "", 1,
+		"Number of Applications exported concurrently")
 	ExportAppsCmd.Flags().StringVarP(&exportAppsFormat, "format", "", utils.DefaultExportFormat, "File format of exported archive (json or yaml)")
 	_ = ExportAppCmd.MarkFlagRequired("environment")
 }
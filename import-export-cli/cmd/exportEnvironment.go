@@ -0,0 +1,104 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const ExportEnvironmentCmdLiteral = "environment"
+const exportEnvironmentCmdShortDesc = "Export a snapshot of an environment"
+
+const exportEnvironmentCmdLongDesc = "Export a versioned snapshot of an environment to a directory. The snapshot " +
+	"contains the APIs with all their revisions, API Products, MCP Servers, Applications, rate limiting policies of " +
+	"every type, API policies and the correlation and API logging settings, together with a manifest. An " +
+	"interrupted export is resumed from where it stopped when the command is run again."
+const exportEnvironmentCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportEnvironmentCmdLiteral + ` -e production
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportEnvironmentCmdLiteral + ` -e production --dir /home/user/snapshots/production --with-keys
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportEnvironmentCmdLiteral + ` -e production --force
+NOTE: The flag (--environment (-e)) is mandatory`
+
+var exportEnvironmentDir string
+var exportEnvironmentFormat string
+var exportEnvironmentWithKeys bool
+var exportEnvironmentPreserveStatus bool
+var exportEnvironmentPreserveCredentials bool
+var exportEnvironmentForce bool
+
+// ExportEnvironmentCmd represents the export environment command
+var ExportEnvironmentCmd = &cobra.Command{
+	Use: ExportEnvironmentCmdLiteral + " (--environment " +
+		"<environment-from-which-the-snapshot-should-be-exported> --dir <snapshot-directory>)",
+	Short:   exportEnvironmentCmdShortDesc,
+	Long:    exportEnvironmentCmdLongDesc,
+	Example: exportEnvironmentCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportEnvironmentCmdLiteral + " called")
+		cred, err := GetCredentials(CmdExportEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeExportEnvironmentCmd(cred)
+	},
+}
+
+func executeExportEnvironmentCmd(credential credentials.Credential) {
+	snapshotDir := exportEnvironmentDir
+	if snapshotDir == "" {
+		snapshotDir = impl.GetDefaultEnvironmentSnapshotDir(CmdExportEnvironment)
+	}
+	manifest, err := impl.ExportEnvironmentSnapshot(credential, CmdExportEnvironment, snapshotDir,
+		impl.EnvironmentSnapshotExportOptions{
+			Format:              exportEnvironmentFormat,
+			WithKeys:            exportEnvironmentWithKeys,
+			PreserveStatus:      exportEnvironmentPreserveStatus,
+			PreserveCredentials: exportEnvironmentPreserveCredentials,
+			Force:               exportEnvironmentForce,
+		})
+	if err != nil {
+		utils.HandleErrorAndExit("Error exporting environment "+CmdExportEnvironment, err)
+	}
+	fmt.Println("Successfully exported " + strconv.Itoa(len(manifest.Artifacts)) + " artifacts of " +
+		CmdExportEnvironment + " to " + snapshotDir)
+}
+
+func init() {
+	ExportCmd.AddCommand(ExportEnvironmentCmd)
+	ExportEnvironmentCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e",
+		"", "Environment of which the snapshot should be exported")
+	ExportEnvironmentCmd.Flags().StringVarP(&exportEnvironmentDir, "dir", "", "",
+		"Directory the snapshot should be exported to. Defaults to <export-directory>/snapshots/<environment>")
+	ExportEnvironmentCmd.Flags().StringVarP(&exportEnvironmentFormat, "format", "", utils.DefaultExportFormat,
+		"File format of exported archives (json or yaml)")
+	ExportEnvironmentCmd.Flags().BoolVarP(&exportEnvironmentWithKeys, "with-keys", "", false,
+		"Export the keys of the Applications")
+	ExportEnvironmentCmd.Flags().BoolVarP(&exportEnvironmentPreserveStatus, "preserve-status", "", true,
+		"Preserve the status of the APIs, API Products and MCP Servers when exporting")
+	ExportEnvironmentCmd.Flags().BoolVarP(&exportEnvironmentPreserveCredentials, "preserve-credentials", "", false,
+		"Preserve endpoint credentials when exporting. Otherwise credentials will not be exported")
+	ExportEnvironmentCmd.Flags().BoolVarP(&exportEnvironmentForce, "force", "", false,
+		"Clean the previously exported snapshot in the directory and export the environment from the beginning")
+	_ = ExportEnvironmentCmd.MarkFlagRequired("environment")
+}
@@ -19,6 +19,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -35,10 +36,12 @@ const exportMCPServersCmdLongDesc = "Export all the MCP Servers of a tenant from
 	"into another environment"
 const exportMCPServersCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportMCPServersCmdLiteral + ` -e production --force
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportMCPServersCmdLiteral + ` -e production
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportMCPServersCmdLiteral + ` -e production --all --workers 8
 NOTE: The flag (--environment (-e)) is mandatory`
 
 var exportMCPServersFormat string
 var exportMCPServersAllRevisions bool
+var exportMCPServersWorkers int
 
 var ExportMCPServersCmd = &cobra.Command{
 	Use: ExportMCPServersCmdLiteral + " (--environment " +
@@ -50,6 +53,9 @@ var ExportMCPServersCmd = &cobra.Command{
 		utils.Logln(utils.LogPrefixInfo + ExportMCPServersCmdLiteral + " called")
 		var artifactExportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedMigrationArtifactsDirName)
 
+		if exportMCPServersWorkers < 1 {
+			utils.HandleErrorAndExit("Invalid number of workers", errors.New("--workers should be at least 1"))
+		}
 		cred, err := GetCredentials(CmdExportEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -83,7 +89,8 @@ func executeExportMCPServersCmd(credential credentials.Credential, exportDirecto
 	}
 
 	impl.ExportMCPServers(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain, exportMCPServersFormat,
-		CmdUsername, mcpServerExportDir, exportMCPServerPreserveStatus, runningExportMCPServerCommand, exportMCPServersAllRevisions, false)
+		CmdUsername, mcpServerExportDir, exportMCPServerPreserveStatus, runningExportMCPServerCommand, exportMCPServersAllRevisions, false,
+		exportMCPServersWorkers)
 }
 
 func init() {
@@ -99,6 +106,8 @@ func init() {
 		"Preserve endpoint credentials when exporting. Otherwise credentials will not be exported")
 	ExportMCPServersCmd.Flags().BoolVarP(&exportMCPServersAllRevisions, "all", "", false,
 		"Export working copy and all revisions for the MCP Servers in the environments ")
+	ExportMCPServersCmd.Flags().IntVarP(&exportMCPServersWorkers, "workers", "", 1,
+		"Number of MCP Servers exported concurrently")
 	ExportMCPServersCmd.Flags().StringVarP(&exportMCPServersFormat, "format", "", utils.DefaultExportFormat, "File format of exported archives(json or yaml)")
 	_ = ExportMCPServersCmd.MarkFlagRequired("environment")
 }
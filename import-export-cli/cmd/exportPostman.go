@@ -0,0 +1,132 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	exportPostmanFile        string
+	exportPostmanAPIName     string
+	exportPostmanAPIVersion  string
+	exportPostmanProvider    string
+	exportPostmanEnvironment string
+	exportPostmanDestination string
+	exportPostmanFormat      string
+)
+
+// ExportPostman command related usage info
+const ExportPostmanCmdLiteral = "postman"
+const exportPostmanCmdShortDesc = "Export a Postman collection or an Insomnia export of an API"
+
+const exportPostmanCmdLongDesc = `Generate a Postman collection (v2.1) for an API project or for an API in an environment.
+The collection has a request for each operation of the API, grouped in folders by the tags of the operations, with
+the examples of the parameters and the request bodies. Requests are authenticated with an OAuth2 token of the client
+credentials grant from the token endpoint of the environment. Set the clientId and clientSecret variables of the
+collection to the keys of an application subscribed to the API. The baseUrl variable is set to the gateway URL of the
+vhosts the API is deployed to. Use --format insomnia to write the collection as an Insomnia export (v4), having the
+variables in the base environment.`
+
+const exportPostmanCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPostmanCmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r admin -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPostmanCmdLiteral + ` -f ~/PizzaShackAPI -e dev -d ./collections
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportPostmanCmdLiteral + ` -f ~/PizzaShackAPI -e dev --format insomnia
+NOTE: Either the flag (--file (-f)) or the flags (--name (-n), --version (-v) and --environment (-e)) are mandatory.`
+
+// ExportPostmanCmd represents the export postman command
+var ExportPostmanCmd = &cobra.Command{
+	Use: ExportPostmanCmdLiteral + " (--file <path-to-api-project> | --name <name-of-the-api> --version " +
+		"<version-of-the-api> --environment <environment-of-the-api>)",
+	Short:   exportPostmanCmdShortDesc,
+	Long:    exportPostmanCmdLongDesc,
+	Example: exportPostmanCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportCmdLiteral + " " + ExportPostmanCmdLiteral + " called")
+		if exportPostmanFormat != impl.CollectionFormatPostman && exportPostmanFormat != impl.CollectionFormatInsomnia {
+			utils.HandleErrorAndExit("Error exporting the Postman collection", fmt.Errorf("invalid format %s. "+
+				"It should be either %s or %s", exportPostmanFormat, impl.CollectionFormatPostman,
+				impl.CollectionFormatInsomnia))
+		}
+		destination := exportPostmanDestination
+		if destination == "" {
+			destination = filepath.Join(utils.ExportDirectory, utils.ExportedPostmanCollectionsDirName,
+				exportPostmanEnvironment)
+		}
+
+		var collection *impl.PostmanCollection
+		var err error
+		if exportPostmanFile != "" {
+			collection, err = impl.ExportPostmanCollectionFromProject(exportPostmanFile, exportPostmanEnvironment)
+		} else {
+			if exportPostmanAPIName == "" || exportPostmanAPIVersion == "" || exportPostmanEnvironment == "" {
+				utils.HandleErrorAndExit("Error exporting the Postman collection",
+					errors.New("either --file or all of --name, --version and --environment should be given"))
+			}
+			cred, credErr := GetCredentials(exportPostmanEnvironment)
+			if credErr != nil {
+				utils.HandleErrorAndExit("Error getting credentials", credErr)
+			}
+			accessToken, tokenErr := credentials.GetOAuthAccessToken(cred, exportPostmanEnvironment)
+			if tokenErr != nil {
+				utils.HandleErrorAndExit("Error getting OAuth tokens while exporting the Postman collection",
+					tokenErr)
+			}
+			collection, err = impl.ExportPostmanCollectionFromEnv(accessToken, exportPostmanEnvironment,
+				exportPostmanAPIName, exportPostmanAPIVersion, exportPostmanProvider)
+		}
+		if err != nil {
+			utils.HandleErrorAndExit("Error exporting the Postman collection", err)
+		}
+		var path string
+		if exportPostmanFormat == impl.CollectionFormatInsomnia {
+			path, err = impl.WriteInsomniaExport(collection, destination)
+		} else {
+			path, err = impl.WritePostmanCollection(collection, destination)
+		}
+		if err != nil {
+			utils.HandleErrorAndExit("Error writing the collection", err)
+		}
+		fmt.Println("Successfully exported the collection!")
+		fmt.Println("Find the exported collection at " + path)
+	},
+}
+
+// init using Cobra
+func init() {
+	ExportCmd.AddCommand(ExportPostmanCmd)
+	ExportPostmanCmd.Flags().StringVarP(&exportPostmanFile, "file", "f", "",
+		"Path to the API project directory or archive")
+	ExportPostmanCmd.Flags().StringVarP(&exportPostmanAPIName, "name", "n", "", "Name of the API")
+	ExportPostmanCmd.Flags().StringVarP(&exportPostmanAPIVersion, "version", "v", "", "Version of the API")
+	ExportPostmanCmd.Flags().StringVarP(&exportPostmanProvider, "provider", "r", "", "Provider of the API")
+	ExportPostmanCmd.Flags().StringVarP(&exportPostmanEnvironment, "environment", "e", "",
+		"Environment of the API, whose token endpoint is used by the collection")
+	ExportPostmanCmd.Flags().StringVarP(&exportPostmanDestination, "destination", "d", "",
+		"Directory to write the collection to")
+	ExportPostmanCmd.Flags().StringVarP(&exportPostmanFormat, "format", "", impl.CollectionFormatPostman,
+		"Format of the collection. Either "+impl.CollectionFormatPostman+" or "+impl.CollectionFormatInsomnia)
+	ExportPostmanCmd.MarkFlagsMutuallyExclusive("file", "name")
+}
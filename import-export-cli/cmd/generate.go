@@ -0,0 +1,49 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Generate command related usage Info
+const GenerateCmdLiteral = "generate"
+const generateCmdShortDesc = "Generate keys of an application"
+
+const generateCmdLongDesc = `Generate the keys of an application from a key manager of an environment`
+
+const generateCmdExamples = utils.ProjectName + ` ` + GenerateCmdLiteral + ` ` + GenerateKeysCmdLiteral + ` --app SampleApp --key-type PRODUCTION -e dev`
+
+// GenerateCmd represents the generate command
+var GenerateCmd = &cobra.Command{
+	Use:     GenerateCmdLiteral,
+	Short:   generateCmdShortDesc,
+	Long:    generateCmdLongDesc,
+	Example: generateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GenerateCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(GenerateCmd)
+}
@@ -0,0 +1,121 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var generateKeysCmdAppName string
+var generateKeysCmdKeyType string
+var generateKeysCmdKeyManager string
+var generateKeysCmdGrantTypes []string
+var generateKeysCmdCallbackURL string
+var generateKeysCmdScopes []string
+var generateKeysCmdEnvironment string
+
+// GenerateKeysCmd command related usage info
+const GenerateKeysCmdLiteral = "keys"
+const generateKeysCmdShortDesc = "Generate the keys of an application"
+
+const generateKeysCmdLongDesc = "Generate the consumer key and the consumer secret of an application of the user in the environment specified by flag (--environment, -e). " +
+	"The keys are generated from the key manager specified by flag (--key-manager), or from the default key manager if not specified"
+
+const generateKeysCmdExamples = utils.ProjectName + ` ` + GenerateCmdLiteral + ` ` + GenerateKeysCmdLiteral + ` --app SampleApp -e dev
+` + utils.ProjectName + ` ` + GenerateCmdLiteral + ` ` + GenerateKeysCmdLiteral + ` --app SampleApp --key-type SANDBOX --key-manager Keycloak -e dev
+` + utils.ProjectName + ` ` + GenerateCmdLiteral + ` ` + GenerateKeysCmdLiteral + ` --app SampleApp --grant-types client_credentials,authorization_code --callback-url https://localhost/callback -e prod
+NOTE: The 2 flags (--app, --environment (-e)) are mandatory`
+
+// GenerateKeysCmd represents the generate keys command
+var GenerateKeysCmd = &cobra.Command{
+	Use:         GenerateKeysCmdLiteral,
+	Short:       generateKeysCmdShortDesc,
+	Long:        generateKeysCmdLongDesc,
+	Example:     generateKeysCmdExamples,
+	Annotations: resultAnnotations("app", resultActionKeysGenerated),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GenerateCmdLiteral + " " + GenerateKeysCmdLiteral + " called")
+		validateKeyType(generateKeysCmdKeyType)
+		cred, err := GetCredentials(generateKeysCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, generateKeysCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to generate the keys", err)
+		}
+		keygenRequest := utils.KeygenRequest{
+			KeyType:                 generateKeysCmdKeyType,
+			KeyManager:              generateKeysCmdKeyManager,
+			GrantTypesToBeSupported: generateKeysCmdGrantTypes,
+			CallbackURL:             generateKeysCmdCallbackURL,
+			Scopes:                  generateKeysCmdScopes,
+			ValidityTime:            utils.DefaultTokenValidityPeriod,
+		}
+		keys, err := impl.GenerateKeys(accessToken, generateKeysCmdEnvironment, generateKeysCmdAppName,
+			keygenRequest)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while generating the keys of application "+generateKeysCmdAppName, err)
+		}
+		utils.GetCommandResult().ID = keys.KeyMappingID
+		utils.GetCommandResult().Keys = &formatter.ResultKeys{ConsumerKey: keys.ConsumerKey,
+			ConsumerSecret: keys.ConsumerSecret, KeyType: keys.KeyType, KeyManager: keys.KeyManager}
+		// The human readable output goes to stderr in json mode, which would leak the secret into logs. The keys are
+		// only written in the result then.
+		if !utils.IsJSONOutput() {
+			fmt.Fprintln(utils.Stdout, "Consumer Key: "+keys.ConsumerKey)
+			fmt.Fprintln(utils.Stdout, "Consumer Secret: "+keys.ConsumerSecret)
+		}
+	},
+}
+
+// validateKeyType exits if the key type is neither PRODUCTION nor SANDBOX
+func validateKeyType(keyType string) {
+	if keyType != utils.ProductionKeyType && keyType != utils.SandboxKeyType {
+		utils.HandleErrorAndExit("Invalid key type "+keyType, errors.New("supported key types: "+
+			utils.ProductionKeyType+", "+utils.SandboxKeyType))
+	}
+}
+
+// init using Cobra
+func init() {
+	GenerateCmd.AddCommand(GenerateKeysCmd)
+	GenerateKeysCmd.Flags().StringVarP(&generateKeysCmdAppName, "app", "", "", "Name of the application")
+	GenerateKeysCmd.Flags().StringVarP(&generateKeysCmdKeyType, "key-type", "", utils.ProductionKeyType,
+		"Type of the keys. PRODUCTION or SANDBOX")
+	GenerateKeysCmd.Flags().StringVarP(&generateKeysCmdKeyManager, "key-manager", "", "",
+		"Key manager to generate the keys from")
+	GenerateKeysCmd.Flags().StringSliceVarP(&generateKeysCmdGrantTypes, "grant-types", "",
+		utils.GrantTypesToBeSupported, "Grant types supported by the keys")
+	GenerateKeysCmd.Flags().StringVarP(&generateKeysCmdCallbackURL, "callback-url", "", "",
+		"Callback URL of the keys")
+	GenerateKeysCmd.Flags().StringSliceVarP(&generateKeysCmdScopes, "scopes", "", []string{},
+		"Scopes of the keys")
+	GenerateKeysCmd.Flags().StringVarP(&generateKeysCmdEnvironment, "environment", "e", "",
+		"Environment of the application")
+	_ = GenerateKeysCmd.MarkFlagRequired("app")
+	_ = GenerateKeysCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,85 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getSubscriptionsCmdAppName string
+var getSubscriptionsCmdEnvironment string
+var getSubscriptionsCmdFormat string
+var getSubscriptionsCmdLimit string
+var getSubscriptionsCmdArtifactFlags subscriptionArtifactFlags
+
+// GetSubscriptionsCmd related info
+const GetSubscriptionsCmdLiteral = "subscriptions"
+const getSubscriptionsCmdShortDesc = "Display a list of subscriptions of an application or an API, API Product or MCP Server"
+
+const getSubscriptionsCmdLongDesc = `Display a list of subscriptions in the environment specified by the flag --environment, -e. The subscriptions of an application of the user are listed if the flag (--app) is provided, and the subscriptions of an API, API Product or MCP Server are listed otherwise`
+
+const getSubscriptionsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSubscriptionsCmdLiteral + ` --app SampleApp -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSubscriptionsCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSubscriptionsCmdLiteral + ` --app SampleApp --api-product LeasingAPIProduct -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSubscriptionsCmdLiteral + ` --mcp-server WeatherMCPServer -v 1.0.0 -r admin -e prod -l 40
+NOTE: The flag (--environment (-e)) is mandatory. Either the flag (--app) or one of the flags (--api), (--api-product) or (--mcp-server) is required`
+
+// getSubscriptionsCmd represents the get subscriptions command
+var getSubscriptionsCmd = &cobra.Command{
+	Use:     GetSubscriptionsCmdLiteral,
+	Short:   getSubscriptionsCmdShortDesc,
+	Long:    getSubscriptionsCmdLongDesc,
+	Example: getSubscriptionsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetSubscriptionsCmdLiteral + " called")
+		artifact := getSubscriptionsCmdArtifactFlags.getArtifact(getSubscriptionsCmdAppName == "")
+		cred, err := GetCredentials(getSubscriptionsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, getSubscriptionsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error calling '"+GetSubscriptionsCmdLiteral+"'", err)
+		}
+		subscriptions, err := impl.GetSubscriptions(accessToken, getSubscriptionsCmdEnvironment,
+			getSubscriptionsCmdAppName, artifact, getSubscriptionsCmdLimit)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting the list of subscriptions.", err)
+		}
+		impl.PrintSubscriptions(subscriptions, getSubscriptionsCmdFormat)
+	},
+}
+
+func init() {
+	GetCmd.AddCommand(getSubscriptionsCmd)
+	addSubscriptionArtifactFlags(getSubscriptionsCmd, &getSubscriptionsCmdArtifactFlags)
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsCmdAppName, "app", "", "", "Name of the application")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsCmdEnvironment, "environment", "e",
+		"", "Environment to be searched")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsCmdLimit, "limit", "l",
+		strconv.Itoa(utils.DefaultAppsDisplayLimit), "Maximum number of subscriptions to return")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsCmdFormat, "format", "", "", "Pretty-print output"+
+		"using Go templates. Use \"{{jsonPretty .}}\" to list all fields")
+	_ = getSubscriptionsCmd.MarkFlagRequired("environment")
+}
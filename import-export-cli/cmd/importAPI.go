@@ -55,9 +55,10 @@ NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 var ImportAPICmd = &cobra.Command{
 	Use: ImportAPICmdLiteral + " --file <path-to-api> --environment " +
 		"<environment>",
-	Short:   importAPICmdShortDesc,
-	Long:    importAPICmdLongDesc,
-	Example: importAPICmdExamples,
+	Short:       importAPICmdShortDesc,
+	Long:        importAPICmdLongDesc,
+	Example:     importAPICmdExamples,
+	Annotations: resultAnnotations("api", resultActionImported),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ImportAPICmdLiteral + " called")
 		cred, err := GetCredentials(importEnvironment)
@@ -43,9 +43,10 @@ const importAPIPolicyCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral +
 var ImportAPIPolicyCmd = &cobra.Command{
 	Use: ImportAPIPolicyCmdLiteral + " --file <path-to-api> --environment " +
 		"<environment>",
-	Short:   importAPIPolicyCmdShortDesc,
-	Long:    importAPIPolicyCmdLongDesc,
-	Example: importAPIPolicyCmdExamples,
+	Short:       importAPIPolicyCmdShortDesc,
+	Long:        importAPIPolicyCmdLongDesc,
+	Example:     importAPIPolicyCmdExamples,
+	Annotations: resultAnnotations("api-policy", resultActionImported),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ImportAPIPolicyCmdLiteral + " called")
 		cred, err := GetCredentials(importEnvironment)
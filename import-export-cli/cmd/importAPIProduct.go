@@ -55,9 +55,10 @@ NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 var ImportAPIProductCmd = &cobra.Command{
 	Use: importAPIProductCmdLiteral + " (--file <path-to-api-product> --environment " +
 		"<environment-to-which-the-api-product-should-be-imported>)",
-	Short:   importAPIProductCmdShortDesc,
-	Long:    importAPIProductCmdLongDesc,
-	Example: importAPIProductCmdExamples,
+	Short:       importAPIProductCmdShortDesc,
+	Long:        importAPIProductCmdLongDesc,
+	Example:     importAPIProductCmdExamples,
+	Annotations: resultAnnotations("api-product", resultActionImported),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + importAPIProductCmdLiteral + " called")
 
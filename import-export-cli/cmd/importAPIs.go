@@ -0,0 +1,114 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importDirAPIsPath             string
+	importDirAPIsEnvironment      string
+	importDirAPIsPreserveProvider bool
+	importDirAPIsUpdate           bool
+	importDirAPIsParamsFile       string
+	importDirAPIsSkipCleanup      bool
+	importDirAPIsRotateRevision   bool
+	importDirAPIsSkipDeployments  bool
+	importDirAPIsWorkers          int
+)
+
+const (
+	// ImportAPIs command related usage info
+	ImportAPIsCmdLiteral   = "apis"
+	importAPIsCmdShortDesc = "Import APIs"
+	importAPIsCmdLongDesc  = "Import all the APIs in a directory to an environment. The directory can contain " +
+		"API archives and API projects, such as the APIs exported with the \"export apis\" command. Revisions of an " +
+		"API are imported in ascending order followed by the working copy."
+)
+
+const importAPIsCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --dir ~/.wso2apictl/exported/migration/production/tenant-default/apis -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --dir ~/apis -e production --update --rotate-revision --workers 8
+NOTE: Both the flags (--dir and --environment (-e)) are mandatory`
+
+// ImportAPIsCmd represents the import apis command
+var ImportAPIsCmd = &cobra.Command{
+	Use: ImportAPIsCmdLiteral + " --dir <path-to-directory> --environment " +
+		"<environment>",
+	Short:       importAPIsCmdShortDesc,
+	Long:        importAPIsCmdLongDesc,
+	Example:     importAPIsCmdExamples,
+	Annotations: resultAnnotations("apis", resultActionImported),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportAPIsCmdLiteral + " called")
+		if importDirAPIsWorkers < 1 {
+			utils.HandleErrorAndExit("Invalid number of workers", errors.New("--workers should be at least 1"))
+		}
+		cred, err := GetCredentials(importDirAPIsEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		results, err := impl.ImportAPIsFromDir(cred, importDirAPIsEnvironment, importDirAPIsPath, importDirAPIsParamsFile,
+			importDirAPIsUpdate, importDirAPIsPreserveProvider, importDirAPIsSkipCleanup, importDirAPIsRotateRevision,
+			importDirAPIsSkipDeployments, importDirAPIsWorkers)
+		if err != nil {
+			utils.HandleErrorAndExit("Error importing APIs", err)
+		}
+		for _, result := range results {
+			if result.Err != nil {
+				utils.AddCommandWarning("Failed to import " + result.Artifact + ": " + result.Err.Error())
+			}
+		}
+		if failed := impl.PrintBulkReport(results); failed > 0 {
+			utils.HandleErrorAndExit("Error importing APIs", errors.New(strconv.Itoa(failed)+
+				" APIs failed to import"))
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportAPIsCmd)
+	ImportAPIsCmd.Flags().StringVarP(&importDirAPIsPath, "dir", "", "",
+		"Directory containing the APIs to be imported")
+	ImportAPIsCmd.Flags().StringVarP(&importDirAPIsEnvironment, "environment", "e",
+		"", "Environment to which the APIs should be imported")
+	ImportAPIsCmd.Flags().BoolVar(&importDirAPIsPreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of the APIs after importing")
+	ImportAPIsCmd.Flags().BoolVar(&importDirAPIsUpdate, "update", false, "Update "+
+		"existing APIs or create new APIs")
+	ImportAPIsCmd.Flags().BoolVar(&importDirAPIsRotateRevision, "rotate-revision", false, "Rotate the "+
+		"revisions with each update")
+	ImportAPIsCmd.Flags().BoolVar(&importDirAPIsSkipDeployments, "skip-deployments", false, "Update only "+
+		"the working copies and skip deployment steps in import")
+	ImportAPIsCmd.Flags().StringVarP(&importDirAPIsParamsFile, "params", "", "", "Provide an API Manager params file "+
+		"or a directory generated using \"gen deployment-dir\" command")
+	ImportAPIsCmd.Flags().BoolVarP(&importDirAPIsSkipCleanup, "skip-cleanup", "", false, "Leave "+
+		"all temporary files created during import process")
+	ImportAPIsCmd.Flags().IntVarP(&importDirAPIsWorkers, "workers", "", 1,
+		"Number of APIs imported concurrently")
+	// Mark required flags
+	_ = ImportAPIsCmd.MarkFlagRequired("environment")
+	_ = ImportAPIsCmd.MarkFlagRequired("dir")
+}
@@ -49,9 +49,10 @@ NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 var ImportAppCmd = &cobra.Command{
 	Use: ImportAppCmdLiteral + " (--file <app-zip-file> --environment " +
 		"<environment-to-which-the-app-should-be-imported>)",
-	Short:   importAppCmdShortDesc,
-	Long:    importAppCmdLongDesc,
-	Example: importAppCmdExamples,
+	Short:       importAppCmdShortDesc,
+	Long:        importAppCmdLongDesc,
+	Example:     importAppCmdExamples,
+	Annotations: resultAnnotations("app", resultActionImported),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ImportAppCmdLiteral + " called")
 		cred, err := GetCredentials(importAppEnvironment)
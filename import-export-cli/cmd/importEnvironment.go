@@ -0,0 +1,96 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var importEnvironmentSnapshotDir string
+var importEnvironmentEnvironment string
+var importEnvironmentPreserveProvider bool
+var importEnvironmentSkipKeys bool
+var importEnvironmentForce bool
+
+// ImportEnvironment command related usage info
+const ImportEnvironmentCmdLiteral = "environment"
+const importEnvironmentCmdShortDesc = "Import a snapshot of an environment"
+
+const importEnvironmentCmdLongDesc = "Import a snapshot exported with the \"export environment\" command to an " +
+	"environment. The artifacts are imported in dependency order and an interrupted import is resumed from the " +
+	"artifact that failed when the command is run again."
+
+const importEnvironmentCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportEnvironmentCmdLiteral + ` -f /home/user/snapshots/production -e dev
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportEnvironmentCmdLiteral + ` -f /home/user/snapshots/production -e dev --preserve-provider=false
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportEnvironmentCmdLiteral + ` -f /home/user/snapshots/production -e dev --force
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
+
+// ImportEnvironmentCmd represents the import environment command
+var ImportEnvironmentCmd = &cobra.Command{
+	Use: ImportEnvironmentCmdLiteral + " (--file <snapshot-directory> --environment " +
+		"<environment-to-which-the-snapshot-should-be-imported>)",
+	Short:       importEnvironmentCmdShortDesc,
+	Long:        importEnvironmentCmdLongDesc,
+	Example:     importEnvironmentCmdExamples,
+	Annotations: resultAnnotations("environment", resultActionImported),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportEnvironmentCmdLiteral + " called")
+		cred, err := GetCredentials(importEnvironmentEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeImportEnvironmentCmd(cred)
+	},
+}
+
+func executeImportEnvironmentCmd(credential credentials.Credential) {
+	importedCount, err := impl.ImportEnvironmentSnapshot(credential, importEnvironmentEnvironment,
+		importEnvironmentSnapshotDir, impl.EnvironmentSnapshotImportOptions{
+			PreserveProvider: importEnvironmentPreserveProvider,
+			SkipKeys:         importEnvironmentSkipKeys,
+			Force:            importEnvironmentForce,
+		})
+	if err != nil {
+		utils.HandleErrorAndExit("Error importing the snapshot to "+importEnvironmentEnvironment, err)
+	}
+	fmt.Fprintln(utils.Stdout, "Successfully imported "+strconv.Itoa(importedCount)+" artifacts to "+
+		importEnvironmentEnvironment)
+}
+
+func init() {
+	ImportCmd.AddCommand(ImportEnvironmentCmd)
+	ImportEnvironmentCmd.Flags().StringVarP(&importEnvironmentSnapshotDir, "file", "f", "",
+		"Directory of the environment snapshot to be imported")
+	ImportEnvironmentCmd.Flags().StringVarP(&importEnvironmentEnvironment, "environment", "e",
+		"", "Environment to which the snapshot should be imported")
+	ImportEnvironmentCmd.Flags().BoolVarP(&importEnvironmentPreserveProvider, "preserve-provider", "", true,
+		"Preserve the providers of the APIs, API Products and MCP Servers")
+	ImportEnvironmentCmd.Flags().BoolVarP(&importEnvironmentSkipKeys, "skip-keys", "", false,
+		"Skip importing the keys of the Applications")
+	ImportEnvironmentCmd.Flags().BoolVarP(&importEnvironmentForce, "force", "", false,
+		"Ignore the progress of a previous import and import the snapshot from the beginning")
+	_ = ImportEnvironmentCmd.MarkFlagRequired("file")
+	_ = ImportEnvironmentCmd.MarkFlagRequired("environment")
+}
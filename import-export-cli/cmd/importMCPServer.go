@@ -55,9 +55,10 @@ NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 var ImportMCPServerCmd = &cobra.Command{
 	Use: ImportMCPServerCmdLiteral + " --file <path-to-mcp-server> --environment " +
 		"<environment>",
-	Short:   importMCPServerCmdShortDesc,
-	Long:    importMCPServerCmdLongDesc,
-	Example: importMCPServerCmdExamples,
+	Short:       importMCPServerCmdShortDesc,
+	Long:        importMCPServerCmdLongDesc,
+	Example:     importMCPServerCmdExamples,
+	Annotations: resultAnnotations("mcp-server", resultActionImported),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ImportMCPServerCmdLiteral + " called")
 		cred, err := GetCredentials(importMCPServerEnvironment)
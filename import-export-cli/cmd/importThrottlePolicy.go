@@ -46,9 +46,10 @@ NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 var ImportThrottlingPolicyCmd = &cobra.Command{
 	Use: ImportThrottlingPolicyCmdLiteral + " --file <path-to-api> --environment " +
 		"<environment>",
-	Short:   importThrottlingPolicyCmdShortDesc,
-	Long:    importThrottlingPolicyCmdLongDesc,
-	Example: importThrottlingPolicyCmdExamples,
+	Short:       importThrottlingPolicyCmdShortDesc,
+	Long:        importThrottlingPolicyCmdLongDesc,
+	Example:     importThrottlingPolicyCmdExamples,
+	Annotations: resultAnnotations("rate-limiting-policy", resultActionImported),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + ImportThrottlingPolicyCmdLiteral + " called")
 		cred, err := GetCredentials(importEnvironment)
@@ -33,20 +33,36 @@ import (
 var (
 	initCmdOutputDir         string
 	initCmdSwaggerPath       string
+	initCmdAsyncAPIPath      string
+	initCmdGraphQLPath       string
+	initCmdWSDLPath          string
+	initCmdPostmanPath       string
 	initCmdApiDefinitionPath string
 	initCmdInitialState      string
 	initCmdForced            bool
 )
 
+const initCmdLongDesc = `Initialize a new project in given path. If an OpenAPI (Swagger 2.0, OpenAPI 3.0 or 3.1), AsyncAPI,
+GraphQL SDL or WSDL definition is provided the API will be populated with details from it. The type, operations,
+topics and endpoints of the API are resolved from the definition. The definition is saved in the Definitions
+directory of the project, or in the WSDL directory in case of a WSDL. A Postman collection (v2.0 or v2.1) or an
+Insomnia export (v4) can be provided instead, from which an OpenAPI definition is inferred. Folders of the collection
+become the tags of the operations and the saved responses become the responses.`
+
 const initCmdExample = `apictl init myapi --oas petstore.yaml
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json
 apictl init Petstore --oas https://petstore.swagger.io/v2/swagger.json --initial-state=PUBLISHED
-apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml`
+apictl init MyAwesomeAPI --oas ./swagger.yaml -d definition.yaml
+apictl init StreetLights --asyncapi ./streetlights.yaml
+apictl init StarWars --graphql ./schema.graphql -d definition.yaml
+apictl init PhoneVerify --wsdl http://ws.cdyne.com/phoneverify/phoneverify.asmx?wsdl
+apictl init PizzaShack --from-postman ./PizzaShack.postman_collection.json
+apictl init PizzaShack --from-postman ./PizzaShack.insomnia.json`
 
 var InitCommand = &cobra.Command{
 	Use:     "init [project path]",
 	Short:   "Initialize a new project in given path",
-	Long:    "Initialize a new project in given path. If a OpenAPI specification provided API will be populated with details from it",
+	Long:    initCmdLongDesc,
 	Example: initCmdExample,
 	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -84,7 +100,20 @@ var InitCommand = &cobra.Command{
 			}
 		}
 
-		err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdApiDefinitionPath, false)
+		sourcePath, sourceType := initCmdSwaggerPath, impl.InitSourceOAS
+		switch {
+		case initCmdAsyncAPIPath != "":
+			sourcePath, sourceType = initCmdAsyncAPIPath, impl.InitSourceAsyncAPI
+		case initCmdGraphQLPath != "":
+			sourcePath, sourceType = initCmdGraphQLPath, impl.InitSourceGraphQL
+		case initCmdWSDLPath != "":
+			sourcePath, sourceType = initCmdWSDLPath, impl.InitSourceWSDL
+		case initCmdPostmanPath != "":
+			sourcePath, sourceType = initCmdPostmanPath, impl.InitSourcePostman
+		}
+
+		err := impl.InitAPIProject(initCmdOutputDir, initCmdInitialState, sourcePath, sourceType,
+			initCmdApiDefinitionPath, false)
 		if err != nil {
 			utils.HandleErrorAndContinue("Error initializing project", err)
 			// Remove the already created project with its content since it is partially created and wrong
@@ -107,7 +136,15 @@ func init() {
 		"YAML definition of API")
 	InitCommand.Flags().StringVarP(&initCmdSwaggerPath, "oas", "", "", "Provide an OpenAPI "+
 		"specification file for the API")
+	InitCommand.Flags().StringVar(&initCmdAsyncAPIPath, "asyncapi", "", "Provide an AsyncAPI "+
+		"specification file for the API")
+	InitCommand.Flags().StringVar(&initCmdGraphQLPath, "graphql", "", "Provide a GraphQL "+
+		"schema file for the API")
+	InitCommand.Flags().StringVar(&initCmdWSDLPath, "wsdl", "", "Provide a WSDL file for the API")
+	InitCommand.Flags().StringVar(&initCmdPostmanPath, "from-postman", "", "Provide a Postman collection or an "+
+		"Insomnia export to infer the OpenAPI definition of the API from")
 	InitCommand.Flags().StringVar(&initCmdInitialState, "initial-state", "", fmt.Sprintf("Provide the initial state "+
 		"of the API; Valid states: %v", utils.ValidInitialStates))
 	InitCommand.Flags().BoolVarP(&initCmdForced, "force", "f", false, "Force create project")
+	InitCommand.MarkFlagsMutuallyExclusive("oas", "asyncapi", "graphql", "wsdl", "from-postman")
 }
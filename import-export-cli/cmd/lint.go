@@ -0,0 +1,48 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Lint command related usage Info
+const LintCmdLiteral = "lint"
+const lintCmdShortDesc = "Validate a project without contacting API Manager"
+
+const lintCmdLongDesc = `Validate an API project offline against its schemas, its API definition and an optional rules file`
+
+const lintCmdExamples = utils.ProjectName + ` ` + LintCmdLiteral + ` ` + LintAPICmdLiteral + ` -f ~/PizzaShackAPI --rules ~/lint-rules.yaml`
+
+// LintCmd represents the lint command
+var LintCmd = &cobra.Command{
+	Use:     LintCmdLiteral,
+	Short:   lintCmdShortDesc,
+	Long:    lintCmdLongDesc,
+	Example: lintCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + LintCmdLiteral + " called")
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(LintCmd)
+}
@@ -0,0 +1,117 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	lintAPIFile       string
+	lintAPIParamsFile string
+	lintAPIRulesFile  string
+	lintAPIFormat     string
+)
+
+const (
+	// LintAPI command related usage info
+	LintAPICmdLiteral   = "api"
+	lintAPICmdShortDesc = "Validate an API project offline"
+	lintAPICmdLongDesc  = `Validate an API project without contacting API Manager. The api.yaml, api_meta.yaml and
+deployment_environments.yaml files are validated against their schemas and the Swagger or OpenAPI definition of the
+API is validated. If a rules file is provided with flag (--rules), the rules defined in it (naming conventions,
+mandatory security schemes, HTTPS endpoints in the production environments of the params file and the maximum number
+of resources) are evaluated as well. The command fails if any violation with the severity ERROR is found.
+A rules file has the following format. Rules that are not defined are not evaluated.
+
+name: Organization Rules
+rules:
+  naming:
+    severity: error
+    name: ^[A-Z][A-Za-z0-9]*$
+    context: ^/[a-z0-9/-]+$
+    version: ^v?[0-9]+\.[0-9]+\.[0-9]+$
+  securitySchemes:
+    severity: error
+    required: [oauth2]
+    allowUnsecuredOperations: false
+  productionEndpoints:
+    severity: error
+    environments: [production]
+    requireHttps: true
+  resources:
+    severity: warn
+    max: 50`
+)
+
+const lintAPICmdExamples = utils.ProjectName + ` ` + LintCmdLiteral + ` ` + LintAPICmdLiteral + ` -f ~/PizzaShackAPI
+` + utils.ProjectName + ` ` + LintCmdLiteral + ` ` + LintAPICmdLiteral + ` -f qa/PizzaShackAPI.zip --rules ~/lint-rules.yaml --format json
+` + utils.ProjectName + ` ` + LintCmdLiteral + ` ` + LintAPICmdLiteral + ` -f ~/PizzaShackAPI --rules ~/lint-rules.yaml --params ~/deployment/params.yaml
+NOTE: The flag (--file (-f)) is mandatory`
+
+// LintAPICmd represents the lint api command
+var LintAPICmd = &cobra.Command{
+	Use:     LintAPICmdLiteral + " --file <path-to-api>",
+	Short:   lintAPICmdShortDesc,
+	Long:    lintAPICmdLongDesc,
+	Example: lintAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + LintCmdLiteral + " " + LintAPICmdLiteral + " called")
+		var ruleSet *impl.LintRuleSet
+		if lintAPIRulesFile != "" {
+			var err error
+			ruleSet, err = impl.LoadLintRuleSet(lintAPIRulesFile)
+			if err != nil {
+				utils.HandleErrorAndExit("Error loading the lint rules", err)
+			}
+		}
+		violations, err := impl.LintAPIProject(lintAPIFile, lintAPIParamsFile, ruleSet)
+		if err != nil {
+			utils.HandleErrorAndExit("Error linting the API", err)
+		}
+		if len(violations) == 0 && lintAPIFormat != "json" {
+			fmt.Println("No violations found for the API")
+			return
+		}
+		impl.PrintViolations(violations, lintAPIFormat)
+		if errorCount := impl.CountLintViolations(violations, impl.LintSeverityError); errorCount > 0 {
+			utils.HandleErrorAndExit("Linting the API failed",
+				errors.New(strconv.Itoa(errorCount)+" violation(s) with the severity "+impl.LintSeverityError))
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	LintCmd.AddCommand(LintAPICmd)
+	LintAPICmd.Flags().StringVarP(&lintAPIFile, "file", "f", "",
+		"Path to the API project directory or archive")
+	LintAPICmd.Flags().StringVarP(&lintAPIRulesFile, "rules", "", "", "Path to the lint rules file")
+	LintAPICmd.Flags().StringVarP(&lintAPIParamsFile, "params", "", "", "Provide an API Manager params file "+
+		"or a directory generated using \"gen deployment-dir\" command to be checked by the rules")
+	LintAPICmd.Flags().StringVarP(&lintAPIFormat, "format", "", "", "Output format of violation results. "+
+		"Supported formats: [table, json, list]. If not provided, the default format is table.")
+	_ = LintAPICmd.MarkFlagRequired("file")
+}
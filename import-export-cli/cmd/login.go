@@ -20,6 +20,7 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -38,14 +39,37 @@ var loginPasswordStdin bool
 var clientId string
 var clientSecret string
 var personalAccessToken string
+var loginCredStore string
+var loginCredHelper string
+var loginGrantType string
+var loginClientId string
+var loginClientSecret string
 
 const loginCmdLiteral = "login [environment] [flags]"
 const loginCmdShortDesc = "Login to an API Manager"
-const loginCmdLongDesc = `Login to an API Manager using credentials or set token for authentication`
+const loginCmdLongDesc = `Login to an API Manager using credentials or set token for authentication.
+The credentials are kept in the credential store selected using --cred-store. The credentials of the current store
+are moved when a different store is selected. Supported stores:
+  json           : credentials are kept base64 encoded in keys.json (default)
+  encrypted-file : credentials are kept in keys.enc encrypted using a passphrase. The passphrase is read from the
+                   ` + credentials.CredStorePassphraseEnvVar + ` environment variable or prompted for
+  helper         : credentials are kept by the credential helper given by --cred-helper. A helper named <name> is
+                   the executable ` + credentials.CredentialHelperPrefix + `<name> in the PATH, which answers get, store, erase and list
+                   requests over stdin and stdout
+Login without a password is supported using --grant:
+  client_credentials : tokens are requested using --client-id and --client-secret of an OAuth application
+  device_code        : a verification URL is printed and the login completes once the user signs in from a
+                       browser. The refresh token is kept in the credential store to get new tokens silently
+The access token of an environment is cached in the credential store and reused by the commands until it is about to
+expire, after which it is refreshed. The cached token is revoked on logout`
 const loginCmdExamples = utils.ProjectName + " login dev -u admin -p admin\n" +
 	utils.ProjectName + " login dev -u admin\n" +
 	"cat ~/.mypassword | " + utils.ProjectName + " login dev -u admin\n" +
-	utils.ProjectName + " login dev --token e79bda48-3406-3178-acce-f6e4dbdcbb12"
+	utils.ProjectName + " login dev --token e79bda48-3406-3178-acce-f6e4dbdcbb12\n" +
+	utils.ProjectName + " login dev -u admin --cred-store encrypted-file\n" +
+	utils.ProjectName + " login dev -u admin --cred-store helper --cred-helper vault\n" +
+	utils.ProjectName + " login dev --grant client_credentials --client-id <client-id> --client-secret <client-secret>\n" +
+	utils.ProjectName + " login dev --grant device_code --client-id <client-id>"
 
 // loginCmd represents the login command
 var loginCmd = &cobra.Command{
@@ -56,12 +80,29 @@ var loginCmd = &cobra.Command{
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		environment := args[0]
-		store, err := credentials.GetDefaultCredentialStore()
+		if loginCredHelper != "" && loginCredStore != credentials.HelperCredStoreType {
+			fmt.Println("--cred-helper can only be used with --cred-store " + credentials.HelperCredStoreType)
+			os.Exit(1)
+		}
+		var store credentials.Store
+		var err error
+		if loginCredStore != "" {
+			store, err = credentials.SetDefaultCredentialStore(loginCredStore, loginCredHelper)
+		} else {
+			store, err = credentials.GetDefaultCredentialStore()
+		}
 		if err != nil {
 			fmt.Println("Error occurred while loading credential store : ", err)
 			os.Exit(1)
 		}
-		if personalAccessToken != "" {
+		if loginGrantType != "" && loginGrantType != utils.GrantTypePassword {
+			err = runLoginWithGrant(store, environment, loginGrantType, loginUsername, loginClientId,
+				loginClientSecret)
+			if err != nil {
+				fmt.Println("Error occurred while login using the "+loginGrantType+" grant : ", err)
+				os.Exit(1)
+			}
+		} else if personalAccessToken != "" {
 			err = runLogin(store, environment, loginUsername, loginPassword, personalAccessToken)
 			if err != nil {
 				fmt.Println("Error occurred while login using the token : ", err)
@@ -142,6 +183,82 @@ func runLogin(store credentials.Store, environment, username, password, personal
 	return nil
 }
 
+// runLoginWithGrant logs into an environment using a grant that does not need the password of the user
+// @param store : Credential store
+// @param environment : Environment to login to
+// @param grantType : client_credentials or device_code
+// @param username : Username recorded for the commands that default to the user. Could be blank
+// @param clientID : Client ID of the OAuth application
+// @param clientSecret : Client secret of the OAuth application. Could be blank for the device code grant
+func runLoginWithGrant(store credentials.Store, environment, grantType, username, clientID,
+	clientSecret string) error {
+	if !utils.APIMExistsInEnv(environment, utils.MainConfigFilePath) {
+		fmt.Println("APIM does not exists in", environment, "Add it using add env")
+		os.Exit(1)
+	}
+	if clientID == "" {
+		return errors.New("--client-id is required for the " + grantType + " grant")
+	}
+	tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(environment, utils.MainConfigFilePath)
+	var tokens map[string]string
+	var err error
+	switch grantType {
+	case utils.GrantTypeClientCredentials:
+		if clientSecret == "" {
+			fmt.Print("Client Secret:")
+			secret, err := terminal.ReadPassword(int(syscall.Stdin))
+			if err != nil {
+				return err
+			}
+			clientSecret = string(secret)
+			fmt.Println()
+		}
+		// The client credentials are verified before they are saved
+		tokens, err = utils.GetOAuthTokensWithClientCredentials(clientID, clientSecret, tokenEndpoint)
+		if err != nil {
+			return err
+		}
+	case utils.GrantTypeDeviceCode:
+		authorization, err := utils.RequestDeviceAuthorization(clientID,
+			utils.GetDeviceAuthorizationEndpoint(tokenEndpoint))
+		if err != nil {
+			return err
+		}
+		if authorization.VerificationURIComplete != "" {
+			fmt.Println("Open the following URL in a browser to login to " + environment + ":")
+			fmt.Println("  " + authorization.VerificationURIComplete)
+			fmt.Println("Verify that the code shown is " + authorization.UserCode)
+		} else {
+			fmt.Println("Open the following URL in a browser and enter the code " + authorization.UserCode +
+				" to login to " + environment + ":")
+			fmt.Println("  " + authorization.VerificationURI)
+		}
+		fmt.Println("Waiting for the login to complete...")
+		tokens, err = utils.PollDeviceAccessToken(authorization, clientID, clientSecret, tokenEndpoint)
+		if err != nil {
+			return err
+		}
+		if tokens["refresh_token"] == "" {
+			return errors.New("no refresh token was issued. Enable the refresh token grant for the client " +
+				clientID)
+		}
+	default:
+		return errors.New("unsupported grant type " + grantType + ". Supported grant types: " +
+			strings.Join([]string{utils.GrantTypePassword, utils.GrantTypeClientCredentials,
+				utils.GrantTypeDeviceCode}, ", "))
+	}
+
+	fmt.Println("Logged into APIM in ", environment, "environment")
+	if err := store.SetAPIMCredentials(environment, username, "", clientID, clientSecret, ""); err != nil {
+		return err
+	}
+	if err := store.SetAPIMGrant(environment, grantType, tokens["refresh_token"]); err != nil {
+		return err
+	}
+	// The token issued while logging in is used by the next commands
+	return store.SetAPIMToken(environment, credentials.NewAccessToken(tokens))
+}
+
 // GetCredentials function gets the credentials for the specified environment
 func GetCredentials(env string) (credentials.Credential, error) {
 	// get tokens or login
@@ -179,4 +296,16 @@ func init() {
 	loginCmd.Flags().StringVarP(&loginPassword, "password", "p", "", "Password for login")
 	loginCmd.Flags().BoolVarP(&loginPasswordStdin, "password-stdin", "", false, "Get password from stdin")
 	loginCmd.Flags().StringVarP(&personalAccessToken, "token", "", "", "Personal access token")
+	loginCmd.Flags().StringVarP(&loginGrantType, "grant", "", utils.GrantTypePassword, "Grant type used to "+
+		"login. Supported grant types: ["+utils.GrantTypePassword+", "+utils.GrantTypeClientCredentials+", "+
+		utils.GrantTypeDeviceCode+"]")
+	loginCmd.Flags().StringVarP(&loginClientId, "client-id", "", "", "Client ID of the OAuth application "+
+		"used with the client_credentials and device_code grants")
+	loginCmd.Flags().StringVarP(&loginClientSecret, "client-secret", "", "", "Client secret of the OAuth "+
+		"application used with the client_credentials and device_code grants")
+	loginCmd.Flags().StringVarP(&loginCredStore, "cred-store", "", "", "Credential store to keep the "+
+		"credentials of all the environments. Existing credentials are moved to the selected store. Supported "+
+		"stores: ["+strings.Join(credentials.CredStoreTypes, ", ")+"]")
+	loginCmd.Flags().StringVarP(&loginCredHelper, "cred-helper", "", "", "Name or path of the credential "+
+		"helper used by the helper credential store")
 }
@@ -21,6 +21,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -29,7 +30,7 @@ import (
 
 const logoutCmdLiteral = "logout [environment]"
 const logoutCmdShortDesc = "Logout to from an API Manager"
-const logoutCmdLongDesc = `Logout from an API Manager environment`
+const logoutCmdLongDesc = `Logout from an API Manager environment. The cached access token of the environment is revoked`
 const logoutCmdExamples = utils.ProjectName + " logout dev"
 
 // logoutCmd represents the logout command
@@ -50,12 +51,25 @@ var logoutCmd = &cobra.Command{
 
 func runLogout(environment string) error {
 	cred, err := GetCredentials(environment)
-	//Get current access token for
-	accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
-	error := credentials.RevokeAccessToken(cred, environment, accessToken)
-	if error != nil {
+	if err != nil {
 		return err
 	}
+	// Revoke the cached access token, or the refresh token if the access token has expired, so that they cannot be
+	// used after logging out. Revoking either of them revokes both in the key manager.
+	cachedToken := credentials.GetCachedAccessToken(environment)
+	token := cachedToken.AccessToken
+	if token == "" || time.Now().Unix() >= cachedToken.ExpiresAt {
+		token = cachedToken.RefreshToken
+	}
+	if token == "" {
+		token = cred.RefreshToken
+	}
+	if token != "" {
+		if err := credentials.RevokeAccessToken(cred, environment, token); err != nil {
+			fmt.Println("Unable to revoke the access token of", environment, ":", err.Error())
+		}
+	}
+	credentials.ForgetCachedAccessToken(environment)
 	store, err := credentials.GetDefaultCredentialStore()
 	if err != nil {
 		return err
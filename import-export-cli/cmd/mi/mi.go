@@ -36,6 +36,7 @@ import (
 var verbose bool
 var cfgFile string
 var insecure bool
+var trace bool
 
 const miCmdShortDesc = "Micro Integrator related commands"
 
@@ -71,6 +72,8 @@ func init() {
 		MICmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose mode")
 		MICmd.PersistentFlags().BoolVarP(&insecure, "insecure", "k", false,
 			"Allow connections to SSL endpoints without certs")
+		MICmd.PersistentFlags().BoolVar(&trace, "trace", false,
+			"Write the HTTP requests and responses to stderr with credentials and tokens redacted")
 		err := utils.SetConfigVars(utils.MainConfigFilePath)
 		if err != nil {
 			utils.HandleErrorAndExit("Error reading "+utils.MainConfigFilePath+".", err)
@@ -116,6 +119,9 @@ func initConfig() {
 	if insecure {
 		utils.Insecure = true
 	}
+	if trace {
+		utils.EnableHttpTrace()
+	}
 }
 
 // Execute adds all child commands to the root command sets flags appropriately.
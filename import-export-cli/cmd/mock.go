@@ -0,0 +1,48 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Mock command related usage Info
+const MockCmdLiteral = "mock"
+const mockCmdShortDesc = "Serve mock responses of a project locally"
+
+const mockCmdLongDesc = `Start a local server that responds to the requests of an API project using its API definition`
+
+const mockCmdExamples = utils.ProjectName + ` ` + MockCmdLiteral + ` ` + MockAPICmdLiteral + ` -f ~/PizzaShackAPI --port 8080`
+
+// MockCmd represents the mock command
+var MockCmd = &cobra.Command{
+	Use:     MockCmdLiteral,
+	Short:   mockCmdShortDesc,
+	Long:    mockCmdLongDesc,
+	Example: mockCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + MockCmdLiteral + " called")
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(MockCmd)
+}
@@ -0,0 +1,108 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	mockAPIFile           string
+	mockAPIHost           string
+	mockAPIPort           int
+	mockAPILatency        time.Duration
+	mockAPIJitter         time.Duration
+	mockAPIErrorRate      float64
+	mockAPIErrorStatus    int
+	mockAPISkipValidation bool
+)
+
+const (
+	// MockAPI command related usage info
+	MockAPICmdLiteral   = "api"
+	mockAPICmdShortDesc = "Serve mock responses of an API project"
+	mockAPICmdLongDesc  = `Start a local mock server for an API project. Every resource of the Swagger or OpenAPI
+definition in the Definitions directory is served with the example of its response, or with a response generated from
+its schema. Requests are validated against the definition unless the flag (--skip-validation) is given. GraphQL APIs
+are served with data generated from Definitions/schema.graphql for the fields selected by the query.
+The API is served at its context and version as in api.yaml, and also without them, so that
+http://<host>:<port> could be used as the production or sandbox endpoint of the API in a params file.
+Latency and failures could be simulated with the flags (--latency), (--jitter) and (--error-rate).`
+)
+
+const mockAPICmdExamples = utils.ProjectName + ` ` + MockCmdLiteral + ` ` + MockAPICmdLiteral + ` -f ~/PizzaShackAPI
+` + utils.ProjectName + ` ` + MockCmdLiteral + ` ` + MockAPICmdLiteral + ` -f ~/PizzaShackAPI --port 9090 --host 0.0.0.0
+` + utils.ProjectName + ` ` + MockCmdLiteral + ` ` + MockAPICmdLiteral + ` -f PizzaShackAPI.zip --latency 200ms --jitter 100ms --error-rate 0.1 --error-status 503
+NOTE: The flag (--file (-f)) is mandatory`
+
+// MockAPICmd represents the mock api command
+var MockAPICmd = &cobra.Command{
+	Use:     MockAPICmdLiteral + " --file <path-to-api>",
+	Short:   mockAPICmdShortDesc,
+	Long:    mockAPICmdLongDesc,
+	Example: mockAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + MockCmdLiteral + " " + MockAPICmdLiteral + " called")
+		if mockAPIErrorRate < 0 || mockAPIErrorRate > 1 {
+			utils.HandleErrorAndExit("Invalid error rate", errors.New("the error rate should be between 0 and 1"))
+		}
+		server, err := impl.NewMockServer(mockAPIFile, impl.MockOptions{
+			Host:           mockAPIHost,
+			Port:           mockAPIPort,
+			Latency:        mockAPILatency,
+			Jitter:         mockAPIJitter,
+			ErrorRate:      mockAPIErrorRate,
+			ErrorStatus:    mockAPIErrorStatus,
+			SkipValidation: mockAPISkipValidation,
+			AccessLog:      os.Stdout,
+		})
+		if err != nil {
+			utils.HandleErrorAndExit("Error loading the API project", err)
+		}
+		if err := server.ListenAndServe(); err != nil {
+			utils.HandleErrorAndExit("Error running the mock server", err)
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	MockCmd.AddCommand(MockAPICmd)
+	MockAPICmd.Flags().StringVarP(&mockAPIFile, "file", "f", "",
+		"Path to the API project directory or archive")
+	MockAPICmd.Flags().StringVarP(&mockAPIHost, "host", "", "localhost", "Host name or address to listen on")
+	MockAPICmd.Flags().IntVarP(&mockAPIPort, "port", "", 8080, "Port to listen on")
+	MockAPICmd.Flags().DurationVarP(&mockAPILatency, "latency", "", 0,
+		"Latency added to every response (eg: 200ms)")
+	MockAPICmd.Flags().DurationVarP(&mockAPIJitter, "jitter", "", 0,
+		"Maximum random latency added on top of the latency")
+	MockAPICmd.Flags().Float64VarP(&mockAPIErrorRate, "error-rate", "", 0,
+		"Fraction of the requests that fail, between 0 and 1")
+	MockAPICmd.Flags().IntVarP(&mockAPIErrorStatus, "error-status", "", 500,
+		"HTTP status of the simulated failures")
+	MockAPICmd.Flags().BoolVarP(&mockAPISkipValidation, "skip-validation", "", false,
+		"Do not validate the requests against the API definition")
+	_ = MockAPICmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,117 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const outputFlagName = "output"
+
+// Annotations of the commands that change an environment. The result of these commands reports the action, the
+// artifact type and the identity taken from the flags of the command.
+const (
+	resultActionAnnotation       = "resultAction"
+	resultArtifactTypeAnnotation = "resultArtifactType"
+)
+
+// Actions reported in the result of a command
+const (
+	resultActionImported      = "imported"
+	resultActionDeleted       = "deleted"
+	resultActionStatusChanged = "status-changed"
+	resultActionUndeployed    = "undeployed"
+	resultActionDeployed      = "deployed"
+	resultActionUpdated       = "updated"
+	resultActionApplied       = "applied"
+	resultActionRolledBack    = "rolled-back"
+	resultActionPruned        = "pruned"
+	resultActionSubscribed    = "subscribed"
+	resultActionUnsubscribed  = "unsubscribed"
+	resultActionBlocked       = "blocked"
+	resultActionUnblocked     = "unblocked"
+	resultActionKeysGenerated = "keys-generated"
+	resultActionSecretRegen   = "secret-regenerated"
+	resultActionTokenRevoked  = "token-revoked"
+)
+
+// resultAnnotations returns the annotations of a command that changes an environment
+// @param artifactType : Type of the artifact the command acts on. Eg: "api"
+// @param action : Action performed by the command on success
+func resultAnnotations(artifactType, action string) map[string]string {
+	return map[string]string{resultActionAnnotation: action, resultArtifactTypeAnnotation: artifactType}
+}
+
+// addOutputFlag adds the --output flag to the root command. The flag is only supported by the commands that change
+// an environment, which are the commands with the result annotations.
+func addOutputFlag(rootCmd *cobra.Command) {
+	rootCmd.PersistentFlags().StringVar(&utils.OutputFormat, outputFlagName, "", "Write the result of a command "+
+		"that changes an environment to stdout in the given format and exit with a code that indicates the category "+
+		"of a failure. Supported formats: [json]")
+}
+
+// prepareCommandOutput validates the --output flag and enables json output for the command
+func prepareCommandOutput(cmd *cobra.Command) {
+	if utils.OutputFormat == "" {
+		return
+	}
+	format := utils.OutputFormat
+	if format != formatter.JSONFormatKey {
+		utils.OutputFormat = ""
+		utils.HandleErrorAndExit("Invalid output format "+format, errors.New("supported formats: "+
+			formatter.JSONFormatKey))
+	}
+	if _, ok := cmd.Annotations[resultActionAnnotation]; !ok {
+		utils.OutputFormat = ""
+		utils.HandleErrorAndExit("Output format "+format+" is not supported by "+cmd.CommandPath(), nil)
+	}
+	artifact := &formatter.ResultArtifact{
+		Type:        cmd.Annotations[resultArtifactTypeAnnotation],
+		Name:        getStringFlagValue(cmd, "name"),
+		Version:     getStringFlagValue(cmd, "version"),
+		Provider:    getStringFlagValue(cmd, "provider"),
+		Owner:       getStringFlagValue(cmd, "owner"),
+		File:        getStringFlagValue(cmd, "file"),
+		Environment: getStringFlagValue(cmd, "environment"),
+	}
+	if artifact.File == "" {
+		artifact.File = getStringFlagValue(cmd, "dir")
+	}
+	if artifact.Name == "" && artifact.Type == "app" {
+		artifact.Name = getStringFlagValue(cmd, "app")
+	}
+	utils.EnableJSONOutput(strings.TrimPrefix(cmd.CommandPath(), utils.ProjectName+" "),
+		cmd.Annotations[resultActionAnnotation], artifact)
+	utils.GetCommandResult().Revision = getStringFlagValue(cmd, "rev")
+}
+
+// getStringFlagValue returns the value of a string flag of the command, or an empty string if the command does not
+// have the flag
+func getStringFlagValue(cmd *cobra.Command, name string) string {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil || flag.Value.Type() != "string" {
+		return ""
+	}
+	return flag.Value.String()
+}
@@ -0,0 +1,45 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const paramsCmdLiteral = "params"
+const paramsCmdShortDesc = "Work with params files"
+const paramsCmdLongDesc = `Work with the params files used to import APIs and API Products to environments`
+const paramsCmdExamples = utils.ProjectName + ` ` + paramsCmdLiteral + ` ` + paramsRenderCmdLiteral +
+	` -f api_params.yaml -e production`
+
+// ParamsCmd represents the params command
+var ParamsCmd = &cobra.Command{
+	Use:     paramsCmdLiteral,
+	Short:   paramsCmdShortDesc,
+	Long:    paramsCmdLongDesc,
+	Example: paramsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + paramsCmdLiteral + " called")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(ParamsCmd)
+}
@@ -0,0 +1,95 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var renderParamsFile string
+var renderParamsEnvironment string
+
+const paramsRenderCmdLiteral = "render"
+const paramsRenderCmdShortDesc = "Print the effective params of an environment"
+const paramsRenderCmdLongDesc = `Print the effective configs of an environment in a params file, after merging the
+configs of the environment on top of the base configs of the file. Use it to review the configs that will be applied
+when importing to the environment.
+
+A params file could have a base block with the configs shared by all the environments. Maps of an environment are
+deep merged with the base, a null value removes a key of the base and other values replace the values of the base.
+Lists are replaced unless a merge strategy is given for their path in the configs under mergeStrategies, either for
+all the environments or for a single environment. Supported strategies are replace, append, prepend, union and
+merge:<key>, which merges the items having the same value for the key.
+
+  base:
+    configs:
+      policies:
+        - Gold
+      certs:
+        - hostName: https://backend.example.com
+          alias: backend
+          path: backend.crt
+  mergeStrategies:
+    policies: union
+    certs: merge:alias
+  environments:
+    - name: production
+      configs:
+        policies:
+          - Unlimited
+        certs:
+          - alias: backend
+            path: backend-prod.crt
+    - name: dev
+      configs:
+        certs: null
+NOTE: All the flags (--file (-f) and --environment (-e)) are mandatory`
+const paramsRenderCmdExamples = utils.ProjectName + ` ` + paramsCmdLiteral + ` ` + paramsRenderCmdLiteral +
+	` -f api_params.yaml -e production
+` + utils.ProjectName + ` ` + paramsCmdLiteral + ` ` + paramsRenderCmdLiteral + ` -f ./deployment/PizzaShackAPI-1.0.0 -e dev`
+
+// ParamsRenderCmd represents the params render command
+var ParamsRenderCmd = &cobra.Command{
+	Use:     paramsRenderCmdLiteral + " (--file <path-to-params-file-or-deployment-directory> --environment <environment-in-params-file>)",
+	Short:   paramsRenderCmdShortDesc,
+	Long:    paramsRenderCmdLongDesc,
+	Example: paramsRenderCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + paramsRenderCmdLiteral + " called")
+		rendered, err := impl.RenderParams(renderParamsFile, renderParamsEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error rendering params", err)
+		}
+		fmt.Print(string(rendered))
+	},
+}
+
+func init() {
+	ParamsCmd.AddCommand(ParamsRenderCmd)
+	ParamsRenderCmd.Flags().StringVarP(&renderParamsFile, "file", "f", "",
+		"Params file or the deployment directory containing it")
+	ParamsRenderCmd.Flags().StringVarP(&renderParamsEnvironment, "environment", "e", "",
+		"Environment in the params file to render")
+	_ = ParamsRenderCmd.MarkFlagRequired("file")
+	_ = ParamsRenderCmd.MarkFlagRequired("environment")
+}
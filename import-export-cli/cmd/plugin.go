@@ -0,0 +1,221 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/plugin"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// PluginCmd related info
+const PluginCmdLiteral = "plugin"
+const pluginCmdShortDesc = "Manage the plugins of " + utils.ProjectName
+
+const pluginCmdLongDesc = `Manage the plugins of ` + utils.ProjectName + `.
+Any executable named ` + plugin.Prefix + `<name> in $HOME/` + utils.ConfigDirName + `/` + utils.PluginsDirName + ` or on the PATH is run as the command
+"` + utils.ProjectName + ` <name>" with all the arguments following the name. The environment given with --environment (-e)
+or the default environment and the config directory are passed to the plugin as the environment variables
+` + plugin.EnvEnvironment + ` and ` + plugin.EnvConfigDir + `. An access token of the environment is passed as ` + plugin.EnvAccessToken + `
+only to the plugins whose executable paths are listed in trusted_plugins of main_config.yaml. The token is issued
+separately from the token of ` + utils.ProjectName + ` for each run of a plugin, with the scopes listed in plugin_token_scopes
+of main_config.yaml or with read only scopes by default, and expires in 15 minutes. The credentials of ` + utils.ProjectName + ` are never passed to a plugin.`
+
+const pluginCmdExamples = utils.ProjectName + ` ` + PluginCmdLiteral + ` list`
+
+// PluginCmd represents the plugin command
+var PluginCmd = &cobra.Command{
+	Use:     PluginCmdLiteral,
+	Short:   pluginCmdShortDesc,
+	Long:    pluginCmdLongDesc,
+	Example: pluginCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PluginCmdLiteral + " called")
+		cmd.Help()
+	},
+}
+
+// PluginListCmd related info
+const pluginListCmdLiteral = "list"
+const pluginListCmdShortDesc = "List the plugins of " + utils.ProjectName
+const pluginListCmdLongDesc = `List the plugins in the plugins directory and on the PATH. A plugin having the name of a
+command or of an earlier plugin is listed with a warning and is not run.`
+
+const defaultPluginsTableFormat = "table {{.Name}}\t{{.Path}}\t{{.Warning}}"
+
+const pluginListCmdExamples = utils.ProjectName + ` ` + PluginCmdLiteral + ` ` + pluginListCmdLiteral + `
+` + utils.ProjectName + ` ` + PluginCmdLiteral + ` ` + pluginListCmdLiteral + ` --format "{{.Name}}"`
+
+var pluginListCmdFormat string
+
+// pluginListCmd represents the plugin list command
+var pluginListCmd = &cobra.Command{
+	Use:     pluginListCmdLiteral,
+	Short:   pluginListCmdShortDesc,
+	Long:    pluginListCmdLongDesc,
+	Example: pluginListCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PluginCmdLiteral + " " + pluginListCmdLiteral + " called")
+		format := pluginListCmdFormat
+		if format == "" {
+			format = defaultPluginsTableFormat
+		}
+		impl.PrintPlugins(impl.FindPlugins(getRootCmdNames()), format)
+	},
+}
+
+// pluginAnnotation marks the commands that run a plugin
+const pluginAnnotation = "plugin"
+
+// getRootCmdNames returns the names and aliases of the commands plugins cannot override
+func getRootCmdNames() []string {
+	names := []string{"help", "completion"}
+	for _, command := range RootCmd.Commands() {
+		if command.Annotations[pluginAnnotation] != "" {
+			continue
+		}
+		names = append(names, command.Name())
+		names = append(names, command.Aliases...)
+	}
+	return names
+}
+
+// AddPluginCmds adds the plugin a command line runs. Like kubectl, a plugin is only looked up when the first argument
+// is not a command of apictl, so that the PATH is not read on every run. All the plugins are added for the help and
+// for the shell completions.
+// @param args : Arguments of apictl
+func AddPluginCmds(args []string) {
+	name := getCommandName(args)
+	if name == "" || name == "help" || name == cobra.ShellCompRequestCmd || name == cobra.ShellCompNoDescRequestCmd {
+		for _, p := range impl.FindPlugins(getRootCmdNames()) {
+			if p.Warning != "" {
+				utils.Logln(utils.LogPrefixWarning + p.Path + " is " + p.Warning)
+				continue
+			}
+			addPluginCmd(p)
+		}
+		return
+	}
+	for _, command := range getRootCmdNames() {
+		if command == name {
+			return
+		}
+	}
+	if p, found := impl.FindPlugin(name); found {
+		addPluginCmd(p)
+	}
+}
+
+// getCommandName returns the first argument that is neither a flag of the root command nor the value of one
+func getCommandName(args []string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+		if strings.Contains(arg, "=") || (!strings.HasPrefix(arg, "--") && len(arg) != 2) {
+			continue
+		}
+		flag := RootCmd.PersistentFlags().Lookup(strings.TrimPrefix(arg, "--"))
+		if !strings.HasPrefix(arg, "--") {
+			flag = RootCmd.PersistentFlags().ShorthandLookup(strings.TrimPrefix(arg, "-"))
+		}
+		if flag != nil && flag.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return ""
+}
+
+// addPluginCmd adds a command running a plugin
+func addPluginCmd(p impl.Plugin) {
+	RootCmd.AddCommand(&cobra.Command{
+		Use:                p.Name,
+		Short:              "Run the plugin " + p.Path,
+		DisableFlagParsing: true,
+		Annotations:        map[string]string{pluginAnnotation: p.Path},
+		Run: func(cmd *cobra.Command, args []string) {
+			runPlugin(p, args)
+		},
+	})
+}
+
+// runPlugin runs a plugin and exits with the exit code of the plugin
+func runPlugin(p impl.Plugin, args []string) {
+	environment := impl.GetPluginEnvironment(args)
+	err := impl.RunPlugin(p, args, environment, getPluginAccessToken(p, environment))
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	}
+	if err != nil {
+		utils.HandleErrorAndExit("Error running the plugin "+p.Path, err)
+	}
+}
+
+// getPluginAccessToken returns a separate access token of an environment for a plugin. The token is only issued to
+// the plugins whose paths are trusted in the main config, with the plugin_token_scopes of the main config or the
+// default read only scopes, and expires after utils.PluginTokenValidityPeriod seconds. The plugin is run without a
+// token if it is not trusted or if a token cannot be issued with the credentials of the environment.
+func getPluginAccessToken(p impl.Plugin, environment string) string {
+	if environment == "" || !utils.APIMExistsInEnv(environment, utils.MainConfigFilePath) {
+		return ""
+	}
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	if !impl.IsPluginTrusted(p, mainConfig.Config.TrustedPlugins) {
+		utils.Logln(utils.LogPrefixInfo + p.Path + " is not a trusted plugin, running the plugin without a token")
+		return ""
+	}
+	store, err := credentials.GetDefaultCredentialStore()
+	if err != nil || !store.HasAPIM(environment) {
+		utils.Logln(utils.LogPrefixInfo + "Not logged in to " + environment + ", running the plugin without a token")
+		return ""
+	}
+	scopes := mainConfig.Config.PluginTokenScopes
+	if len(scopes) == 0 {
+		scopes = utils.DefaultPluginTokenScopes
+	}
+	cred, err := store.GetAPIMCredentials(environment)
+	if err == nil {
+		var accessToken string
+		if accessToken, err = credentials.GetPluginAccessToken(cred, environment, scopes); err == nil {
+			return accessToken
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Unable to get an access token of "+environment+", running the plugin without a token:", err)
+	return ""
+}
+
+func init() {
+	RootCmd.AddCommand(PluginCmd)
+	PluginCmd.AddCommand(pluginListCmd)
+	pluginListCmd.Flags().StringVarP(&pluginListCmdFormat, "format", "", "", "Pretty-print plugins "+
+		"using go templates")
+}
@@ -0,0 +1,112 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Prune command related usage Info
+const PruneCmdLiteral = "prune"
+const pruneCmdShortDesc = "Delete old revisions of an API/MCP Server/API Product"
+
+const pruneCmdLongDesc = `Delete the revisions of an API/MCP Server/API Product available in the environment specified by flag (--environment, -e) except the latest revisions specified by flag (--keep). Revisions deployed in a gateway environment are never deleted`
+
+const pruneCmdExamples = utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneAPIRevisionsCmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin --keep 2 -e dev
+` + utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneMCPServerRevisionsCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 --keep 1 -e dev
+` + utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneAPIProductRevisionsCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 --keep 3 --dry-run -e dev`
+
+// PruneCmd represents the prune command
+var PruneCmd = &cobra.Command{
+	Use:     PruneCmdLiteral,
+	Short:   pruneCmdShortDesc,
+	Long:    pruneCmdLongDesc,
+	Example: pruneCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PruneCmdLiteral + " called")
+
+	},
+}
+
+// revisionPruneFlags holds the flags of the prune commands of the artifacts having revisions
+type revisionPruneFlags struct {
+	name        string
+	version     string
+	provider    string
+	keep        int
+	dryRun      bool
+	environment string
+}
+
+// addRevisionPruneFlags adds the flags of a prune command of an artifact having revisions
+func addRevisionPruneFlags(cmd *cobra.Command, flags *revisionPruneFlags, artifactType string) {
+	cmd.Flags().StringVarP(&flags.name, "name", "n", "", "Name of the "+artifactType)
+	cmd.Flags().StringVarP(&flags.version, "version", "v", "", "Version of the "+artifactType)
+	cmd.Flags().StringVarP(&flags.provider, "provider", "r", "", "Provider of the "+artifactType)
+	cmd.Flags().IntVarP(&flags.keep, "keep", "", 0, "Number of the latest revisions to keep")
+	cmd.Flags().BoolVarP(&flags.dryRun, "dry-run", "", false,
+		"List the revisions to be deleted without deleting them")
+	cmd.Flags().StringVarP(&flags.environment, "environment", "e", "",
+		"Environment of which the revisions should be deleted")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("version")
+	_ = cmd.MarkFlagRequired("keep")
+	_ = cmd.MarkFlagRequired("environment")
+}
+
+func executePruneRevisionsCmd(flags *revisionPruneFlags, artifactType string) {
+	cred, err := GetCredentials(flags.environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, flags.environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens to delete the revisions of the "+artifactType, err)
+	}
+
+	pruned, err := impl.PruneRevisions(accessToken, flags.environment, artifactType, flags.name, flags.version,
+		flags.provider, flags.keep, flags.dryRun)
+	if err != nil {
+		if len(pruned) > 0 {
+			fmt.Fprintln(utils.Stdout, "Deleted revisions: "+strings.Join(pruned, ", "))
+		}
+		utils.HandleErrorAndExit("Error while deleting the revisions of the "+artifactType, err)
+	}
+	switch {
+	case len(pruned) == 0:
+		fmt.Fprintln(utils.Stdout, "No revisions of "+artifactType+" "+flags.name+"_"+flags.version+" to delete")
+	case flags.dryRun:
+		fmt.Fprintln(utils.Stdout, "Revisions of "+artifactType+" "+flags.name+"_"+flags.version+" to be deleted: "+
+			strings.Join(pruned, ", "))
+	default:
+		fmt.Fprintln(utils.Stdout, "Revisions "+strings.Join(pruned, ", ")+" of "+artifactType+" "+flags.name+"_"+
+			flags.version+" successfully deleted")
+	}
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(PruneCmd)
+}
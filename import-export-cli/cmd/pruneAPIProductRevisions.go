@@ -0,0 +1,59 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var pruneAPIProductRevisionsFlags revisionPruneFlags
+
+// PruneAPIProductRevisionsCmd command related usage info
+const PruneAPIProductRevisionsCmdLiteral = "api-product-revisions"
+const pruneAPIProductRevisionsCmdShortDesc = "Delete old revisions of an API Product"
+
+const pruneAPIProductRevisionsCmdLongDesc = "Delete the revisions of an API Product except the latest revisions and the deployed revisions"
+
+const pruneAPIProductRevisionsCmdExamples = utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneAPIProductRevisionsCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 --keep 2 -e dev
+` + utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneAPIProductRevisionsCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 -r alice --keep 0 -e production
+` + utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneAPIProductRevisionsCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 --keep 3 --dry-run -e production
+NOTE: All the 4 flags (--name (-n), --version (-v), --keep, --environment (-e)) are mandatory.`
+
+// PruneAPIProductRevisionsCmd represents the prune api-product-revisions command
+var PruneAPIProductRevisionsCmd = &cobra.Command{
+	Use: PruneAPIProductRevisionsCmdLiteral + " (--name <name-of-the-api-product> --version <version-of-the-api-product> " +
+		"--provider <provider-of-the-api-product> --keep <number-of-revisions-to-keep> " +
+		"--environment <environment-of-the-api-product>)",
+	Short:       pruneAPIProductRevisionsCmdShortDesc,
+	Long:        pruneAPIProductRevisionsCmdLongDesc,
+	Example:     pruneAPIProductRevisionsCmdExamples,
+	Annotations: resultAnnotations("api-product", resultActionPruned),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PruneAPIProductRevisionsCmdLiteral + " called")
+		executePruneRevisionsCmd(&pruneAPIProductRevisionsFlags, impl.RevisionArtifactAPIProduct)
+	},
+}
+
+// init using Cobra
+func init() {
+	PruneCmd.AddCommand(PruneAPIProductRevisionsCmd)
+	addRevisionPruneFlags(PruneAPIProductRevisionsCmd, &pruneAPIProductRevisionsFlags, impl.RevisionArtifactAPIProduct)
+}
@@ -0,0 +1,59 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var pruneAPIRevisionsFlags revisionPruneFlags
+
+// PruneAPIRevisionsCmd command related usage info
+const PruneAPIRevisionsCmdLiteral = "api-revisions"
+const pruneAPIRevisionsCmdShortDesc = "Delete old revisions of an API"
+
+const pruneAPIRevisionsCmdLongDesc = "Delete the revisions of an API except the latest revisions and the deployed revisions"
+
+const pruneAPIRevisionsCmdExamples = utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneAPIRevisionsCmdLiteral + ` -n TwitterAPI -v 1.0.0 --keep 2 -e dev
+` + utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneAPIRevisionsCmdLiteral + ` -n TwitterAPI -v 1.0.0 -r alice --keep 0 -e production
+` + utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneAPIRevisionsCmdLiteral + ` -n TwitterAPI -v 1.0.0 --keep 3 --dry-run -e production
+NOTE: All the 4 flags (--name (-n), --version (-v), --keep, --environment (-e)) are mandatory.`
+
+// PruneAPIRevisionsCmd represents the prune api-revisions command
+var PruneAPIRevisionsCmd = &cobra.Command{
+	Use: PruneAPIRevisionsCmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> " +
+		"--provider <provider-of-the-api> --keep <number-of-revisions-to-keep> " +
+		"--environment <environment-of-the-api>)",
+	Short:       pruneAPIRevisionsCmdShortDesc,
+	Long:        pruneAPIRevisionsCmdLongDesc,
+	Example:     pruneAPIRevisionsCmdExamples,
+	Annotations: resultAnnotations("api", resultActionPruned),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PruneAPIRevisionsCmdLiteral + " called")
+		executePruneRevisionsCmd(&pruneAPIRevisionsFlags, impl.RevisionArtifactAPI)
+	},
+}
+
+// init using Cobra
+func init() {
+	PruneCmd.AddCommand(PruneAPIRevisionsCmd)
+	addRevisionPruneFlags(PruneAPIRevisionsCmd, &pruneAPIRevisionsFlags, impl.RevisionArtifactAPI)
+}
@@ -0,0 +1,59 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var pruneMCPServerRevisionsFlags revisionPruneFlags
+
+// PruneMCPServerRevisionsCmd command related usage info
+const PruneMCPServerRevisionsCmdLiteral = "mcp-server-revisions"
+const pruneMCPServerRevisionsCmdShortDesc = "Delete old revisions of an MCP Server"
+
+const pruneMCPServerRevisionsCmdLongDesc = "Delete the revisions of an MCP Server except the latest revisions and the deployed revisions"
+
+const pruneMCPServerRevisionsCmdExamples = utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneMCPServerRevisionsCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 --keep 2 -e dev
+` + utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneMCPServerRevisionsCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 -r alice --keep 0 -e production
+` + utils.ProjectName + ` ` + PruneCmdLiteral + ` ` + PruneMCPServerRevisionsCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 --keep 3 --dry-run -e production
+NOTE: All the 4 flags (--name (-n), --version (-v), --keep, --environment (-e)) are mandatory.`
+
+// PruneMCPServerRevisionsCmd represents the prune mcp-server-revisions command
+var PruneMCPServerRevisionsCmd = &cobra.Command{
+	Use: PruneMCPServerRevisionsCmdLiteral + " (--name <name-of-the-mcp-server> --version <version-of-the-mcp-server> " +
+		"--provider <provider-of-the-mcp-server> --keep <number-of-revisions-to-keep> " +
+		"--environment <environment-of-the-mcp-server>)",
+	Short:       pruneMCPServerRevisionsCmdShortDesc,
+	Long:        pruneMCPServerRevisionsCmdLongDesc,
+	Example:     pruneMCPServerRevisionsCmdExamples,
+	Annotations: resultAnnotations("mcp-server", resultActionPruned),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PruneMCPServerRevisionsCmdLiteral + " called")
+		executePruneRevisionsCmd(&pruneMCPServerRevisionsFlags, impl.RevisionArtifactMCPServer)
+	},
+}
+
+// init using Cobra
+func init() {
+	PruneCmd.AddCommand(PruneMCPServerRevisionsCmd)
+	addRevisionPruneFlags(PruneMCPServerRevisionsCmd, &pruneMCPServerRevisionsFlags, impl.RevisionArtifactMCPServer)
+}
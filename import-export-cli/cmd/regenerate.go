@@ -0,0 +1,49 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Regenerate command related usage Info
+const RegenerateCmdLiteral = "regenerate"
+const regenerateCmdShortDesc = "Regenerate the consumer secret of an application"
+
+const regenerateCmdLongDesc = `Regenerate the consumer secret of the keys of an application in an environment`
+
+const regenerateCmdExamples = utils.ProjectName + ` ` + RegenerateCmdLiteral + ` ` + RegenerateSecretCmdLiteral + ` --app SampleApp --key-type PRODUCTION -e dev`
+
+// RegenerateCmd represents the regenerate command
+var RegenerateCmd = &cobra.Command{
+	Use:     RegenerateCmdLiteral,
+	Short:   regenerateCmdShortDesc,
+	Long:    regenerateCmdLongDesc,
+	Example: regenerateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RegenerateCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(RegenerateCmd)
+}
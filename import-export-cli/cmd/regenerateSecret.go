@@ -0,0 +1,95 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var regenerateSecretCmdAppName string
+var regenerateSecretCmdKeyType string
+var regenerateSecretCmdKeyManager string
+var regenerateSecretCmdEnvironment string
+
+// RegenerateSecretCmd command related usage info
+const RegenerateSecretCmdLiteral = "secret"
+const regenerateSecretCmdShortDesc = "Regenerate the consumer secret of an application"
+
+const regenerateSecretCmdLongDesc = "Regenerate the consumer secret of the keys of an application of the user in the environment specified by flag (--environment, -e). " +
+	"The flag (--key-manager) is required if the application has keys of multiple key managers"
+
+const regenerateSecretCmdExamples = utils.ProjectName + ` ` + RegenerateCmdLiteral + ` ` + RegenerateSecretCmdLiteral + ` --app SampleApp -e dev
+` + utils.ProjectName + ` ` + RegenerateCmdLiteral + ` ` + RegenerateSecretCmdLiteral + ` --app SampleApp --key-type SANDBOX --key-manager Keycloak -e dev
+NOTE: The 2 flags (--app, --environment (-e)) are mandatory`
+
+// RegenerateSecretCmd represents the regenerate secret command
+var RegenerateSecretCmd = &cobra.Command{
+	Use:         RegenerateSecretCmdLiteral,
+	Short:       regenerateSecretCmdShortDesc,
+	Long:        regenerateSecretCmdLongDesc,
+	Example:     regenerateSecretCmdExamples,
+	Annotations: resultAnnotations("app", resultActionSecretRegen),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RegenerateCmdLiteral + " " + RegenerateSecretCmdLiteral + " called")
+		validateKeyType(regenerateSecretCmdKeyType)
+		cred, err := GetCredentials(regenerateSecretCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, regenerateSecretCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to regenerate the consumer secret", err)
+		}
+		keys, err := impl.RegenerateSecret(accessToken, regenerateSecretCmdEnvironment, regenerateSecretCmdAppName,
+			regenerateSecretCmdKeyType, regenerateSecretCmdKeyManager)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while regenerating the consumer secret of application "+
+				regenerateSecretCmdAppName, err)
+		}
+		utils.GetCommandResult().Keys = &formatter.ResultKeys{ConsumerKey: keys.ConsumerKey,
+			ConsumerSecret: keys.ConsumerSecret, KeyType: regenerateSecretCmdKeyType,
+			KeyManager: regenerateSecretCmdKeyManager}
+		// The human readable output goes to stderr in json mode, which would leak the secret into logs. The keys are
+		// only written in the result then.
+		if !utils.IsJSONOutput() {
+			fmt.Fprintln(utils.Stdout, "Consumer Key: "+keys.ConsumerKey)
+			fmt.Fprintln(utils.Stdout, "Consumer Secret: "+keys.ConsumerSecret)
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	RegenerateCmd.AddCommand(RegenerateSecretCmd)
+	RegenerateSecretCmd.Flags().StringVarP(&regenerateSecretCmdAppName, "app", "", "", "Name of the application")
+	RegenerateSecretCmd.Flags().StringVarP(&regenerateSecretCmdKeyType, "key-type", "", utils.ProductionKeyType,
+		"Type of the keys. PRODUCTION or SANDBOX")
+	RegenerateSecretCmd.Flags().StringVarP(&regenerateSecretCmdKeyManager, "key-manager", "", "",
+		"Key manager of the keys")
+	RegenerateSecretCmd.Flags().StringVarP(&regenerateSecretCmdEnvironment, "environment", "e", "",
+		"Environment of the application")
+	_ = RegenerateSecretCmd.MarkFlagRequired("app")
+	_ = RegenerateSecretCmd.MarkFlagRequired("environment")
+}
@@ -0,0 +1,49 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Revoke command related usage Info
+const RevokeCmdLiteral = "revoke"
+const revokeCmdShortDesc = "Revoke an access token of an application"
+
+const revokeCmdLongDesc = `Revoke an access token issued to an application in an environment`
+
+const revokeCmdExamples = utils.ProjectName + ` ` + RevokeCmdLiteral + ` ` + RevokeTokenCmdLiteral + ` --app SampleApp --token <access-token> -e dev`
+
+// RevokeCmd represents the revoke command
+var RevokeCmd = &cobra.Command{
+	Use:     RevokeCmdLiteral,
+	Short:   revokeCmdShortDesc,
+	Long:    revokeCmdLongDesc,
+	Example: revokeCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RevokeCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(RevokeCmd)
+}
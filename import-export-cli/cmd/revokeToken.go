@@ -0,0 +1,93 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var revokeTokenCmdAppName string
+var revokeTokenCmdToken string
+var revokeTokenCmdKeyType string
+var revokeTokenCmdKeyManager string
+var revokeTokenCmdConsumerSecret string
+var revokeTokenCmdEnvironment string
+
+// RevokeTokenCmd command related usage info
+const RevokeTokenCmdLiteral = "token"
+const revokeTokenCmdShortDesc = "Revoke an access token of an application"
+
+const revokeTokenCmdLongDesc = "Revoke an access token issued to an application of the user in the environment specified by flag (--environment, -e). " +
+	"The token is revoked using the keys of the application at the revoke endpoint of the key manager of the keys. The flag (--consumer-secret) is required if the consumer secrets are hashed in the environment"
+
+const revokeTokenCmdExamples = utils.ProjectName + ` ` + RevokeCmdLiteral + ` ` + RevokeTokenCmdLiteral + ` --app SampleApp --token <access-token> -e dev
+` + utils.ProjectName + ` ` + RevokeCmdLiteral + ` ` + RevokeTokenCmdLiteral + ` --app SampleApp --token <access-token> --key-type SANDBOX -e dev
+` + utils.ProjectName + ` ` + RevokeCmdLiteral + ` ` + RevokeTokenCmdLiteral + ` --app SampleApp --token <access-token> --key-manager Keycloak -e dev
+` + utils.ProjectName + ` ` + RevokeCmdLiteral + ` ` + RevokeTokenCmdLiteral + ` --app SampleApp --token <access-token> --consumer-secret <consumer-secret> -e prod
+NOTE: The 3 flags (--app, --token, --environment (-e)) are mandatory`
+
+// RevokeTokenCmd represents the revoke token command
+var RevokeTokenCmd = &cobra.Command{
+	Use:         RevokeTokenCmdLiteral,
+	Short:       revokeTokenCmdShortDesc,
+	Long:        revokeTokenCmdLongDesc,
+	Example:     revokeTokenCmdExamples,
+	Annotations: resultAnnotations("app", resultActionTokenRevoked),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RevokeCmdLiteral + " " + RevokeTokenCmdLiteral + " called")
+		validateKeyType(revokeTokenCmdKeyType)
+		cred, err := GetCredentials(revokeTokenCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, revokeTokenCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to revoke the token", err)
+		}
+		err = impl.RevokeToken(accessToken, revokeTokenCmdEnvironment, revokeTokenCmdAppName, revokeTokenCmdKeyType,
+			revokeTokenCmdKeyManager, revokeTokenCmdConsumerSecret, revokeTokenCmdToken)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while revoking the token of application "+revokeTokenCmdAppName, err)
+		}
+		fmt.Fprintln(utils.Stdout, "Access token of application "+revokeTokenCmdAppName+" revoked successfully")
+	},
+}
+
+// init using Cobra
+func init() {
+	RevokeCmd.AddCommand(RevokeTokenCmd)
+	RevokeTokenCmd.Flags().StringVarP(&revokeTokenCmdAppName, "app", "", "", "Name of the application")
+	RevokeTokenCmd.Flags().StringVarP(&revokeTokenCmdToken, "token", "", "", "Access token to be revoked")
+	RevokeTokenCmd.Flags().StringVarP(&revokeTokenCmdKeyType, "key-type", "", utils.ProductionKeyType,
+		"Type of the keys the token is issued for. PRODUCTION or SANDBOX")
+	RevokeTokenCmd.Flags().StringVarP(&revokeTokenCmdKeyManager, "key-manager", "", "",
+		"Key manager of the keys. The token is revoked at the revoke endpoint of the key manager")
+	RevokeTokenCmd.Flags().StringVarP(&revokeTokenCmdConsumerSecret, "consumer-secret", "", "",
+		"Consumer secret of the keys")
+	RevokeTokenCmd.Flags().StringVarP(&revokeTokenCmdEnvironment, "environment", "e", "",
+		"Environment of the application")
+	_ = RevokeTokenCmd.MarkFlagRequired("app")
+	_ = RevokeTokenCmd.MarkFlagRequired("token")
+	_ = RevokeTokenCmd.MarkFlagRequired("environment")
+}
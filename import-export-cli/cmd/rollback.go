@@ -0,0 +1,107 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Rollback command related usage Info
+const RollbackCmdLiteral = "rollback"
+const rollbackCmdShortDesc = "Roll back an API/MCP Server/API Product to a previous revision"
+
+const rollbackCmdLongDesc = `Roll back the gateway environments of an API/MCP Server/API Product available in the environment specified by flag (--environment, -e) to the revision deployed before the deployed revision, keeping its visibility on the devportal, or to the revision specified by flag (--rev). The previous deployments are read from the deployment history recorded by apictl in ` + utils.DeploymentHistoryFileName + ` next to the main config. If no previous deployment is recorded, such as for revisions deployed from the publisher or from another machine, the latest older revision deployed in another gateway environment, or else the latest older revision, is deployed`
+
+const rollbackCmdExamples = utils.ProjectName + ` ` + RollbackCmdLiteral + ` ` + RollbackAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
+` + utils.ProjectName + ` ` + RollbackCmdLiteral + ` ` + RollbackAPICmdLiteral + ` -n PizzaAPI -v 1.0.0 --rev 2 -g Label1 -e dev
+` + utils.ProjectName + ` ` + RollbackCmdLiteral + ` ` + RollbackMCPServerCmdLiteral + ` -n WeatherMCPServer -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + RollbackCmdLiteral + ` ` + RollbackAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 -g Label1 -e dev`
+
+// RollbackCmd represents the rollback command
+var RollbackCmd = &cobra.Command{
+	Use:     RollbackCmdLiteral,
+	Short:   rollbackCmdShortDesc,
+	Long:    rollbackCmdLongDesc,
+	Example: rollbackCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RollbackCmdLiteral + " called")
+
+	},
+}
+
+// revisionRollbackFlags holds the flags of the rollback commands of the artifacts having revisions
+type revisionRollbackFlags struct {
+	name        string
+	version     string
+	provider    string
+	revisionNum string
+	gatewayEnvs []string
+	environment string
+}
+
+// addRevisionRollbackFlags adds the flags of a rollback command of an artifact having revisions
+func addRevisionRollbackFlags(cmd *cobra.Command, flags *revisionRollbackFlags, artifactType string) {
+	cmd.Flags().StringVarP(&flags.name, "name", "n", "", "Name of the "+artifactType+" to be rolled back")
+	cmd.Flags().StringVarP(&flags.version, "version", "v", "", "Version of the "+artifactType+" to be rolled back")
+	cmd.Flags().StringVarP(&flags.provider, "provider", "r", "", "Provider of the "+artifactType)
+	cmd.Flags().StringVarP(&flags.revisionNum, "rev", "", "",
+		"Revision number to roll back to. The previously deployed revision recorded in the deployment history, or else the latest older revision, is used if not specified")
+	cmd.Flags().StringSliceVarP(&flags.gatewayEnvs, "gateway-env", "g", []string{},
+		"Gateway environment which has to be rolled back")
+	cmd.Flags().StringVarP(&flags.environment, "environment", "e", "",
+		"Environment of which the "+artifactType+" should be rolled back")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("version")
+	_ = cmd.MarkFlagRequired("environment")
+}
+
+func executeRollbackRevisionCmd(flags *revisionRollbackFlags, artifactType string) {
+	cred, err := GetCredentials(flags.environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, flags.environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens to roll back the "+artifactType, err)
+	}
+
+	rollbacks, err := impl.RollbackRevision(accessToken, flags.environment, artifactType, flags.name, flags.version,
+		flags.provider, flags.revisionNum, flags.gatewayEnvs)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while rolling back the "+artifactType, err)
+	}
+	for _, rollback := range rollbacks {
+		fmt.Fprintln(utils.Stdout, "Gateway environment "+rollback.GatewayEnv+" of "+artifactType+" "+flags.name+"_"+
+			flags.version+" rolled back from revision "+rollback.FromRevision+" to revision "+
+			rollback.ToRevision)
+	}
+	if len(rollbacks) == 1 {
+		utils.GetCommandResult().Revision = rollbacks[0].ToRevision
+	}
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(RollbackCmd)
+}
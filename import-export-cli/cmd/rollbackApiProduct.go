@@ -0,0 +1,59 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var rollbackAPIProductFlags revisionRollbackFlags
+
+// RollbackAPIProductCmd command related usage info
+const RollbackAPIProductCmdLiteral = "api-product"
+const rollbackAPIProductCmdShortDesc = "Roll back API Product"
+
+const rollbackAPIProductCmdLongDesc = "Roll back the gateway environments of an API Product to the previous revision"
+
+const rollbackAPIProductCmdExamples = utils.ProjectName + ` ` + RollbackCmdLiteral + ` ` + RollbackAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + RollbackCmdLiteral + ` ` + RollbackAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 -r alice -g Label1 -g Label2 -e production
+` + utils.ProjectName + ` ` + RollbackCmdLiteral + ` ` + RollbackAPIProductCmdLiteral + ` -n LeasingAPIProduct -v 1.0.0 --rev 3 -g Label1 -e production
+NOTE: All the 3 flags (--name (-n), --version (-v), --environment (-e)) are mandatory.
+If the flag (--gateway-env (-g)) is not provided, all the gateway environments having a deployed revision will be rolled back.`
+
+// RollbackAPIProductCmd represents the rollback api-product command
+var RollbackAPIProductCmd = &cobra.Command{
+	Use: RollbackAPIProductCmdLiteral + " (--name <name-of-the-api-product> --version <version-of-the-api-product> " +
+		"--provider <provider-of-the-api-product> --environment <environment-of-the-api-product>)",
+	Short:       rollbackAPIProductCmdShortDesc,
+	Long:        rollbackAPIProductCmdLongDesc,
+	Example:     rollbackAPIProductCmdExamples,
+	Annotations: resultAnnotations("api-product", resultActionRolledBack),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RollbackAPIProductCmdLiteral + " called")
+		executeRollbackRevisionCmd(&rollbackAPIProductFlags, impl.RevisionArtifactAPIProduct)
+	},
+}
+
+// init using Cobra
+func init() {
+	RollbackCmd.AddCommand(RollbackAPIProductCmd)
+	addRevisionRollbackFlags(RollbackAPIProductCmd, &rollbackAPIProductFlags, impl.RevisionArtifactAPIProduct)
+}
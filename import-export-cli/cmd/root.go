@@ -45,6 +45,7 @@ import (
 var verbose bool
 var cfgFile string
 var insecure bool
+var trace bool
 var cmdPassword string
 var CmdUsername string
 var CmdExportEnvironment string
@@ -73,6 +74,12 @@ var RootCmd = &cobra.Command{
 	DisableFlagParsing: isK8sEnabled(),
 	Short:              rootCmdShortDesc,
 	Long:               rootCmdLongDesc,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		prepareCommandOutput(cmd)
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		utils.PrintCommandSuccess()
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if isK8sEnabled() {
 			ExecuteKubernetes(args...)
@@ -85,6 +92,8 @@ var RootCmd = &cobra.Command{
 // Execute adds all child commands to the root command sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	// Plugins are added here instead of init so that the generated docs do not have the local plugins
+	AddPluginCmds(os.Args[1:])
 	if err := RootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(-1)
@@ -101,6 +110,9 @@ func init() {
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose mode")
 	RootCmd.PersistentFlags().BoolVarP(&insecure, "insecure", "k", false,
 		"Allow connections to SSL endpoints without certs")
+	RootCmd.PersistentFlags().BoolVar(&trace, "trace", false,
+		"Write the HTTP requests and responses to stderr with credentials and tokens redacted")
+	addOutputFlag(RootCmd)
 	//RootCmd.PersistentFlags().StringP("author", "a", "", "WSO2")
 
 	//viper.BindPFlag("author", RootCmd.PersistentFlags().Lookup("author"))
@@ -146,6 +158,7 @@ func createConfigFiles() {
 	if !utils.IsFileExist(utils.MainConfigFilePath) {
 		var mainConfig = new(utils.MainConfig)
 		mainConfig.Config = utils.Config{HttpRequestTimeout: utils.DefaultHttpRequestTimeout,
+			HttpRetryCount:       utils.DefaultHttpRetryCount,
 			ExportDirectory:      utils.DefaultExportDirPath,
 			KubernetesMode:       k8sUtils.DefaultKubernetesMode,
 			TokenType:            utils.DefaultTokenType,
@@ -188,6 +201,9 @@ func initConfig() {
 	if insecure {
 		utils.Insecure = true
 	}
+	if trace {
+		utils.EnableHttpTrace()
+	}
 
 	/*
 		if cfgFile != "" { // enable ability to specify config file via flag
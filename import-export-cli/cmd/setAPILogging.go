@@ -41,10 +41,11 @@ var setApiLoggingCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + ` ` + S
 ` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetApiLoggingCmdLiteral + ` --api-id bf36ca3a-0332-49ba-abce-e9992228ae06 --log-level off -e dev --tenant-domain carbon.super`
 
 var setApiLoggingCmd = &cobra.Command{
-	Use:     SetApiLoggingCmdLiteral,
-	Short:   setApiLoggingCmdShortDesc,
-	Long:    setApiLoggingCmdLongDesc,
-	Example: setApiLoggingCmdExamples,
+	Use:         SetApiLoggingCmdLiteral,
+	Short:       setApiLoggingCmdShortDesc,
+	Long:        setApiLoggingCmdLongDesc,
+	Example:     setApiLoggingCmdExamples,
+	Annotations: resultAnnotations("api-logging", resultActionUpdated),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetApiLoggingCmdLiteral + " called")
 		cred, err := GetCredentials(setApiLoggingEnvironment)
@@ -65,9 +66,9 @@ func executeSetApiLoggingCmd(credential credentials.Credential) {
 	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 	if resp.StatusCode() == http.StatusOK {
 		// 200 OK
-		fmt.Println("Log level " + setApiLoggingLogLevel + " is successfully set to the API.")
+		fmt.Fprintln(utils.Stdout, "Log level "+setApiLoggingLogLevel+" is successfully set to the API.")
 	} else {
-		fmt.Println("Setting the log level of the API: ", resp.Status(), "\n", string(resp.Body()))
+		utils.HandleErrorResponseAndExit("Error while setting the log level of the API", resp)
 	}
 }
 
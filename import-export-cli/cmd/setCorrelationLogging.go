@@ -43,10 +43,11 @@ var setCorrelationLoggingCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral +
 ` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetCorrelationLoggingCmdLiteral + ` --component-name jdbc --enable true --denied-threads MessageDeliveryTaskThreadPool,HumanTaskServer,BPELServer -e dev`
 
 var setCorrelationLoggingCmd = &cobra.Command{
-	Use:     SetCorrelationLoggingCmdLiteral,
-	Short:   setCorrelationLoggingCmdShortDesc,
-	Long:    setCorrelationLoggingCmdLongDesc,
-	Example: setCorrelationLoggingCmdExamples,
+	Use:         SetCorrelationLoggingCmdLiteral,
+	Short:       setCorrelationLoggingCmdShortDesc,
+	Long:        setCorrelationLoggingCmdLongDesc,
+	Example:     setCorrelationLoggingCmdExamples,
+	Annotations: resultAnnotations("correlation-logging", resultActionUpdated),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetCorrelationLoggingCmdLiteral + " called")
 		cred, err := GetCredentials(setCorrelationLoggingEnvironment)
@@ -69,12 +70,12 @@ func executeSetCorrelationLoggingCmd(credential credentials.Credential) {
 	if resp.StatusCode() == http.StatusOK {
 		// 200 OK
 		if strings.ToLower(setCorrelationLoggingEnabled) == "true" {
-			fmt.Println("Correlation component " + setCorrelationLoggingComponentName + " is successfully enabled.")
+			fmt.Fprintln(utils.Stdout, "Correlation component "+setCorrelationLoggingComponentName+" is successfully enabled.")
 		} else {
-			fmt.Println("Correlation component " + setCorrelationLoggingComponentName + " is successfully disabled.")
+			fmt.Fprintln(utils.Stdout, "Correlation component "+setCorrelationLoggingComponentName+" is successfully disabled.")
 		}
 	} else {
-		fmt.Println("Setting the correlation components : ", resp.Status(), "\n", string(resp.Body()))
+		utils.HandleErrorResponseAndExit("Error while setting the correlation components", resp)
 	}
 }
 
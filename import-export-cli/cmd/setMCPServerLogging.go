@@ -41,10 +41,11 @@ var setMCPServerLoggingCmdExamples = utils.ProjectName + ` ` + SetCmdLiteral + `
 ` + utils.ProjectName + ` ` + SetCmdLiteral + ` ` + SetMCPServerLoggingCmdLiteral + ` --mcp-server-id bf36ca3a-0332-49ba-abce-e9992228ae06 --log-level off -e dev --tenant-domain carbon.super`
 
 var setMCPServerLoggingCmd = &cobra.Command{
-	Use:     SetMCPServerLoggingCmdLiteral,
-	Short:   setMCPServerLoggingCmdShortDesc,
-	Long:    setMCPServerLoggingCmdLongDesc,
-	Example: setMCPServerLoggingCmdExamples,
+	Use:         SetMCPServerLoggingCmdLiteral,
+	Short:       setMCPServerLoggingCmdShortDesc,
+	Long:        setMCPServerLoggingCmdLongDesc,
+	Example:     setMCPServerLoggingCmdExamples,
+	Annotations: resultAnnotations("mcp-server-logging", resultActionUpdated),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + SetCmdLiteral + " " + SetMCPServerLoggingCmdLiteral + " called")
 		cred, err := GetCredentials(setMCPServerLoggingEnvironment)
@@ -65,9 +66,9 @@ func executeSetMCPServerLoggingCmd(credential credentials.Credential) {
 	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 	if resp.StatusCode() == http.StatusOK {
 		// 200 OK
-		fmt.Println("Log level " + setMCPServerLoggingLogLevel + " is successfully set to the MCP Server.")
+		fmt.Fprintln(utils.Stdout, "Log level "+setMCPServerLoggingLogLevel+" is successfully set to the MCP Server.")
 	} else {
-		fmt.Println("Setting the log level of the MCP Server: ", resp.Status(), "\n", string(resp.Body()))
+		utils.HandleErrorResponseAndExit("Error while setting the log level of the MCP Server", resp)
 	}
 }
 
@@ -0,0 +1,129 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var subscribeCmdAppName string
+var subscribeCmdPolicy string
+var subscribeCmdEnvironment string
+var subscribeCmdArtifactFlags subscriptionArtifactFlags
+
+// Subscribe command related usage Info
+const SubscribeCmdLiteral = "subscribe"
+const subscribeCmdShortDesc = "Subscribe an application to an API, API Product or MCP Server"
+
+const subscribeCmdLongDesc = `Subscribe an application of the user to an API, API Product or MCP Server in the environment specified by flag (--environment, -e). The first subscription throttling policy of the API, API Product or MCP Server is used if the flag (--policy) is not provided`
+
+const subscribeCmdExamples = utils.ProjectName + ` ` + SubscribeCmdLiteral + ` --app SampleApp --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + SubscribeCmdLiteral + ` --app SampleApp --api PizzaShackAPI -v 1.0.0 -r admin --policy Gold -e dev
+` + utils.ProjectName + ` ` + SubscribeCmdLiteral + ` --app SampleApp --api-product LeasingAPIProduct -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + SubscribeCmdLiteral + ` --app SampleApp --mcp-server WeatherMCPServer -v 1.0.0 -e dev
+NOTE: The 3 flags (--app, --version (-v), --environment (-e)) and one of the flags (--api), (--api-product) or (--mcp-server) are mandatory`
+
+// subscribeCmd represents the subscribe command
+var subscribeCmd = &cobra.Command{
+	Use:         SubscribeCmdLiteral,
+	Short:       subscribeCmdShortDesc,
+	Long:        subscribeCmdLongDesc,
+	Example:     subscribeCmdExamples,
+	Annotations: resultAnnotations("subscription", resultActionSubscribed),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + SubscribeCmdLiteral + " called")
+		artifact := subscribeCmdArtifactFlags.getArtifact(true)
+		cred, err := GetCredentials(subscribeCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, subscribeCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to subscribe the application", err)
+		}
+		subscriptionId, err := impl.Subscribe(accessToken, subscribeCmdEnvironment, subscribeCmdAppName, artifact,
+			subscribeCmdPolicy)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while subscribing the application "+subscribeCmdAppName, err)
+		}
+		utils.GetCommandResult().ID = subscriptionId
+		fmt.Fprintln(utils.Stdout, "Application "+subscribeCmdAppName+" subscribed to the "+artifact.Type+" "+
+			artifact.Name+" "+artifact.Version+" successfully. Subscription ID: "+subscriptionId)
+	},
+}
+
+// subscriptionArtifactFlags holds the flags which identify the API, API Product or MCP Server of a subscription
+type subscriptionArtifactFlags struct {
+	api        string
+	apiProduct string
+	mcpServer  string
+	version    string
+	provider   string
+}
+
+// addSubscriptionArtifactFlags adds the flags which identify the API, API Product or MCP Server of a subscription
+func addSubscriptionArtifactFlags(cmd *cobra.Command, flags *subscriptionArtifactFlags) {
+	cmd.Flags().StringVarP(&flags.api, "api", "", "", "Name of the API")
+	cmd.Flags().StringVarP(&flags.apiProduct, "api-product", "", "", "Name of the API Product")
+	cmd.Flags().StringVarP(&flags.mcpServer, "mcp-server", "", "", "Name of the MCP Server")
+	cmd.Flags().StringVarP(&flags.version, "version", "v", "", "Version of the API, API Product or MCP Server")
+	cmd.Flags().StringVarP(&flags.provider, "provider", "r", "", "Provider of the API, API Product or MCP Server")
+	cmd.MarkFlagsMutuallyExclusive("api", "api-product", "mcp-server")
+}
+
+// getArtifact returns the API, API Product or MCP Server given by the flags. Exits if the artifact is required but
+// not given.
+func (flags *subscriptionArtifactFlags) getArtifact(required bool) impl.SubscriptionArtifact {
+	artifact := impl.SubscriptionArtifact{Version: flags.version, Provider: flags.provider}
+	switch {
+	case flags.api != "":
+		artifact.Type, artifact.Name = utils.ProjectTypeApi, flags.api
+	case flags.apiProduct != "":
+		artifact.Type, artifact.Name = utils.ProjectTypeApiProduct, flags.apiProduct
+	case flags.mcpServer != "":
+		artifact.Type, artifact.Name = utils.ProjectTypeMcpServer, flags.mcpServer
+	default:
+		if required {
+			utils.HandleErrorAndExit("One of the flags (--api), (--api-product) or (--mcp-server) is required", nil)
+		}
+		return artifact
+	}
+	if artifact.Version == "" {
+		utils.HandleErrorAndExit("The version (--version, -v) of the "+artifact.Type+" is required", nil)
+	}
+	return artifact
+}
+
+func init() {
+	RootCmd.AddCommand(subscribeCmd)
+	addSubscriptionArtifactFlags(subscribeCmd, &subscribeCmdArtifactFlags)
+	subscribeCmd.Flags().StringVarP(&subscribeCmdAppName, "app", "", "", "Name of the application")
+	subscribeCmd.Flags().StringVarP(&subscribeCmdPolicy, "policy", "", "",
+		"Subscription throttling policy of the subscription")
+	subscribeCmd.Flags().StringVarP(&subscribeCmdEnvironment, "environment", "e", "",
+		"Environment of the application and the API, API Product or MCP Server")
+	_ = subscribeCmd.MarkFlagRequired("app")
+	_ = subscribeCmd.MarkFlagRequired("version")
+	_ = subscribeCmd.MarkFlagRequired("environment")
+}
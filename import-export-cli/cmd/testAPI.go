@@ -0,0 +1,150 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	testAPIName          string
+	testAPIVersion       string
+	testAPIProvider      string
+	testAPIEnv           string
+	testAPITokenEndpoint string
+	testAPIDataFile      string
+	testAPIGatewayURL    string
+	testAPIGatewayEnv    string
+	testAPIFormat        string
+)
+
+const (
+	// TestAPI command related usage info
+	TestAPICmdLiteral   = "api"
+	testAPICmdShortDesc = "Run contract tests against a deployed API"
+	testAPICmdLongDesc  = `Invoke every operation of an API or API Product deployed in an environment through the gateway
+and validate the status codes and the response bodies against the API definition. A token is generated by subscribing
+to the default application, the same way as the command "get keys". Path, query and header parameters and request
+bodies are taken from the examples of the definition, or generated from the schemas. A test data file provided with
+the flag (--data) overrides them. Operations are identified by their operation IDs or by the method and the path.
+
+headers:
+  X-Tenant: acme
+operations:
+  getMenu:
+    queryParams:
+      category: pizza
+  GET /order/{orderId}:
+    pathParams:
+      orderId: 12
+  POST /order:
+    body:
+      name: Margherita
+    expectedStatus: 201
+  DELETE /order/{orderId}:
+    skip: true
+
+The report is printed as a table, or in the JSON or JUnit XML format with the flag (--format).
+The command fails if any test fails.`
+)
+
+const testAPICmdExamples = utils.ProjectName + ` ` + TestCmdLiteral + ` ` + TestAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + TestCmdLiteral + ` ` + TestAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r admin -e dev --data pizza-test-data.yaml --format junit > report.xml
+` + utils.ProjectName + ` ` + TestCmdLiteral + ` ` + TestAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -e dev --gateway-env Default --gateway-url https://localhost:8243
+NOTE: Both the flags (--name (-n) and --environment (-e)) are mandatory.`
+
+// TestAPICmd represents the test api command
+var TestAPICmd = &cobra.Command{
+	Use:     TestAPICmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --environment <environment>)",
+	Short:   testAPICmdShortDesc,
+	Long:    testAPICmdLongDesc,
+	Example: testAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + TestCmdLiteral + " " + TestAPICmdLiteral + " called")
+		if !isValidTestAPIFormat(testAPIFormat) {
+			utils.HandleErrorAndExit("Invalid format", errors.New("supported formats: "+
+				strings.Join(impl.APITestFormats, ", ")))
+		}
+		options := impl.APITestOptions{
+			TokenEndpoint:      testAPITokenEndpoint,
+			GatewayEnvironment: testAPIGatewayEnv,
+			GatewayURL:         testAPIGatewayURL,
+		}
+		if testAPIDataFile != "" {
+			data, err := impl.LoadAPITestData(testAPIDataFile)
+			if err != nil {
+				utils.HandleErrorAndExit("Error loading the test data", err)
+			}
+			options.Data = data
+		}
+		cred, err := GetCredentials(testAPIEnv)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		cred.ClientId, cred.ClientSecret, err = impl.CallDCREndpoint(cred, testAPIEnv)
+		if err != nil {
+			utils.HandleErrorAndExit("Internal error occurred", err)
+		}
+		report, err := impl.RunAPITests(cred, testAPIEnv, testAPIName, testAPIVersion, testAPIProvider, options)
+		if err != nil {
+			utils.HandleErrorAndExit("Error testing the API", err)
+		}
+		if err := impl.PrintAPITestReport(report, testAPIFormat); err != nil {
+			utils.HandleErrorAndExit("Error printing the test report", err)
+		}
+		if report.Failed > 0 {
+			utils.HandleErrorAndExit("Testing the API failed",
+				errors.New(strconv.Itoa(report.Failed)+" of "+strconv.Itoa(report.Tests)+" test(s) failed"))
+		}
+	},
+}
+
+func isValidTestAPIFormat(format string) bool {
+	for _, supported := range impl.APITestFormats {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// init using Cobra
+func init() {
+	TestCmd.AddCommand(TestAPICmd)
+	TestAPICmd.Flags().StringVarP(&testAPIName, "name", "n", "", "Name of the API or API Product to be tested")
+	TestAPICmd.Flags().StringVarP(&testAPIVersion, "version", "v", "", "Version of the API or API Product")
+	TestAPICmd.Flags().StringVarP(&testAPIProvider, "provider", "r", "", "Provider of the API or API Product")
+	TestAPICmd.Flags().StringVarP(&testAPIEnv, "environment", "e", "", "Environment the API is deployed in")
+	TestAPICmd.Flags().StringVarP(&testAPITokenEndpoint, "token", "t", "", "Token endpoint URL of Environment")
+	TestAPICmd.Flags().StringVarP(&testAPIDataFile, "data", "", "", "Path to the test data file")
+	TestAPICmd.Flags().StringVarP(&testAPIGatewayEnv, "gateway-env", "", "",
+		"Gateway environment whose URLs are used to invoke the API")
+	TestAPICmd.Flags().StringVarP(&testAPIGatewayURL, "gateway-url", "", "",
+		"Scheme, host and port of the gateway, overriding the URL of the gateway environment")
+	TestAPICmd.Flags().StringVarP(&testAPIFormat, "format", "", impl.APITestFormatTable,
+		"Format of the test report. Supported formats: [table, json, junit]")
+	_ = TestAPICmd.MarkFlagRequired("name")
+	_ = TestAPICmd.MarkFlagRequired("environment")
+}
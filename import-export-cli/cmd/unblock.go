@@ -0,0 +1,50 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Unblock command related usage Info
+const UnblockCmdLiteral = "unblock"
+const unblockCmdShortDesc = "Unblock a subscription"
+
+const unblockCmdLongDesc = `Unblock a blocked subscription of an application to an API, API Product or MCP Server in an environment`
+
+const unblockCmdExamples = utils.ProjectName + ` ` + UnblockCmdLiteral + ` ` + UnblockSubscriptionCmdLiteral + ` --app SampleApp --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + UnblockCmdLiteral + ` ` + UnblockSubscriptionCmdLiteral + ` --app SampleApp --owner alice --api PizzaShackAPI -v 1.0.0 -e dev`
+
+// UnblockCmd represents the unblock command
+var UnblockCmd = &cobra.Command{
+	Use:     UnblockCmdLiteral,
+	Short:   unblockCmdShortDesc,
+	Long:    unblockCmdLongDesc,
+	Example: unblockCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + UnblockCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(UnblockCmd)
+}
@@ -0,0 +1,88 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var unblockSubscriptionCmdAppName string
+var unblockSubscriptionCmdAppOwner string
+var unblockSubscriptionCmdEnvironment string
+var unblockSubscriptionCmdArtifactFlags subscriptionArtifactFlags
+
+// UnblockSubscriptionCmd command related usage info
+const UnblockSubscriptionCmdLiteral = "subscription"
+const unblockSubscriptionCmdShortDesc = "Unblock a subscription of an application"
+
+const unblockSubscriptionCmdLongDesc = "Unblock the blocked subscription of an application to an API, API Product or MCP Server in the environment specified by flag (--environment, -e)"
+
+const unblockSubscriptionCmdExamples = utils.ProjectName + ` ` + UnblockCmdLiteral + ` ` + UnblockSubscriptionCmdLiteral + ` --app SampleApp --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + UnblockCmdLiteral + ` ` + UnblockSubscriptionCmdLiteral + ` --app SampleApp --owner alice --api-product LeasingAPIProduct -v 1.0.0 -e dev
+NOTE: The 3 flags (--app, --version (-v), --environment (-e)) and one of the flags (--api), (--api-product) or (--mcp-server) are mandatory.
+The flag (--owner) is required if applications of multiple owners having the same name are subscribed.`
+
+// UnblockSubscriptionCmd represents the unblock subscription command
+var UnblockSubscriptionCmd = &cobra.Command{
+	Use:         UnblockSubscriptionCmdLiteral,
+	Short:       unblockSubscriptionCmdShortDesc,
+	Long:        unblockSubscriptionCmdLongDesc,
+	Example:     unblockSubscriptionCmdExamples,
+	Annotations: resultAnnotations("subscription", resultActionUnblocked),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + UnblockCmdLiteral + " " + UnblockSubscriptionCmdLiteral + " called")
+		artifact := unblockSubscriptionCmdArtifactFlags.getArtifact(true)
+		cred, err := GetCredentials(unblockSubscriptionCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, unblockSubscriptionCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to unblock the subscription", err)
+		}
+		subscriptionId, err := impl.UnblockSubscription(accessToken, unblockSubscriptionCmdEnvironment,
+			unblockSubscriptionCmdAppName, unblockSubscriptionCmdAppOwner, artifact)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while unblocking the subscription of "+unblockSubscriptionCmdAppName, err)
+		}
+		utils.GetCommandResult().ID = subscriptionId
+		fmt.Fprintln(utils.Stdout, "Subscription of application "+unblockSubscriptionCmdAppName+" to the "+artifact.Type+
+			" "+artifact.Name+" "+artifact.Version+" unblocked successfully")
+	},
+}
+
+// init using Cobra
+func init() {
+	UnblockCmd.AddCommand(UnblockSubscriptionCmd)
+	addSubscriptionArtifactFlags(UnblockSubscriptionCmd, &unblockSubscriptionCmdArtifactFlags)
+	UnblockSubscriptionCmd.Flags().StringVarP(&unblockSubscriptionCmdAppName, "app", "", "",
+		"Name of the application")
+	UnblockSubscriptionCmd.Flags().StringVarP(&unblockSubscriptionCmdAppOwner, "owner", "", "",
+		"Owner of the application")
+	UnblockSubscriptionCmd.Flags().StringVarP(&unblockSubscriptionCmdEnvironment, "environment", "e", "",
+		"Environment of the subscription")
+	_ = UnblockSubscriptionCmd.MarkFlagRequired("app")
+	_ = UnblockSubscriptionCmd.MarkFlagRequired("version")
+	_ = UnblockSubscriptionCmd.MarkFlagRequired("environment")
+}
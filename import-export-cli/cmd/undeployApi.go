@@ -55,9 +55,10 @@ var UndeployAPICmd = &cobra.Command{
 	Use: UndeployAPICmdLiteral + " (--name <name-of-the-api> --version <version-of-the-api> --provider <provider-of-the-api> " +
 		"--rev <revision-number-of-the-api> --gateway-env <gateway-environment> " +
 		"--environment <environment-from-which-the-api-should-be-undeployed>)",
-	Short:   undeployAPICmdShortDesc,
-	Long:    undeployAPICmdLongDesc,
-	Example: undeployAPICmdExamples,
+	Short:       undeployAPICmdShortDesc,
+	Long:        undeployAPICmdLongDesc,
+	Example:     undeployAPICmdExamples,
+	Annotations: resultAnnotations("api", resultActionUndeployed),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + UndeployAPICmdLiteral + " called")
 		if len(undeployAPICmdAPIGatewayEnvs) > 0 {
@@ -85,13 +86,13 @@ func executeUndeployAPICmd(credential credentials.Credential, deployments []util
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusCreated {
-			fmt.Println("Revision " + undeployRevisionNum + " of API " + undeployAPIName + "_" + undeployAPIVersion +
+			fmt.Fprintln(utils.Stdout, "Revision "+undeployRevisionNum+" of API "+undeployAPIName+"_"+undeployAPIVersion+
 				" successfully undeployed from the specified gateways environments")
 		} else {
-			fmt.Println("Error while undeploying the API: ", resp.Status(), "\n", string(resp.Body()))
+			utils.HandleErrorResponseAndExit("Error while undeploying the API", resp)
 		}
 	} else {
-		fmt.Println("Error getting OAuth tokens to undeploy the API:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens to undeploy the API", preCommandErr)
 	}
 }
 
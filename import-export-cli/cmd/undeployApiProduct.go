@@ -56,9 +56,10 @@ var UndeployAPIProductCmd = &cobra.Command{
 	Use: UndeployAPIProductCmdLiteral + " (--name <name-of-the-api-product> " +
 		"--version <version-of-the-api-product> --rev<revision-number-of-the-api-product> --gateway-env <gateway-environment> " +
 		"--environment <environment-from-which-the-api-product-should-be-undeployed>)",
-	Short:   undeployAPIProductCmdShortDesc,
-	Long:    undeployAPIProductmdLongDesc,
-	Example: undeployAPIProductCmdExamples,
+	Short:       undeployAPIProductCmdShortDesc,
+	Long:        undeployAPIProductmdLongDesc,
+	Example:     undeployAPIProductCmdExamples,
+	Annotations: resultAnnotations("api-product", resultActionUndeployed),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + UndeployAPIProductCmdLiteral + " called")
 
@@ -87,13 +88,13 @@ func executeUndeployAPIProductCmd(credential credentials.Credential, deployments
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusCreated {
-			fmt.Println("Revision " + undeployAPIProductRevisionNum + " of API Product " + undeployAPIProductName +
+			fmt.Fprintln(utils.Stdout, "Revision "+undeployAPIProductRevisionNum+" of API Product "+undeployAPIProductName+
 				" successfully undeployed from the specified gateway environments")
 		} else {
-			fmt.Println("Error while undeploying the  APIProduct: ", resp.Status(), "\n", string(resp.Body()))
+			utils.HandleErrorResponseAndExit("Error while undeploying the APIProduct", resp)
 		}
 	} else {
-		fmt.Println("Error getting OAuth tokens to undeploy the API Product:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens to undeploy the API Product", preCommandErr)
 	}
 }
 
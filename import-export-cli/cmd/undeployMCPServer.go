@@ -50,9 +50,10 @@ var UndeployMCPServerCmd = &cobra.Command{
 	Use: UndeployMCPServerCmdLiteral + " (--name <name-of-the-mcpserver> --version <version-of-the-mcpserver> --provider <provider-of-the-mcpserver> " +
 		"--rev <revision-number-of-the-mcpserver> --gateway-env <gateway-environment> " +
 		"--environment <environment-from-which-the-mcpserver-should-be-undeployed>)",
-	Short:   undeployMCPServerCmdShortDesc,
-	Long:    undeployMCPServerCmdLongDesc,
-	Example: undeployMCPServerCmdExamples,
+	Short:       undeployMCPServerCmdShortDesc,
+	Long:        undeployMCPServerCmdLongDesc,
+	Example:     undeployMCPServerCmdExamples,
+	Annotations: resultAnnotations("mcp-server", resultActionUndeployed),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + UndeployMCPServerCmdLiteral + " called")
 		if len(undeployMCPServerCmdGatewayEnvs) > 0 {
@@ -79,13 +80,13 @@ func executeUndeployMCPServerCmd(credential credentials.Credential, deployments
 		}
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusCreated {
-			fmt.Println("Revision " + undeployMCPServerRevisionNum + " of MCP Server " + undeployMCPServerName + "_" + undeployMCPServerVersion +
+			fmt.Fprintln(utils.Stdout, "Revision "+undeployMCPServerRevisionNum+" of MCP Server "+undeployMCPServerName+"_"+undeployMCPServerVersion+
 				" successfully undeployed from the specified gateways environments")
 		} else {
-			fmt.Println("Error while undeploying the MCP Server: ", resp.Status(), "\n", string(resp.Body()))
+			utils.HandleErrorResponseAndExit("Error while undeploying the MCP Server", resp)
 		}
 	} else {
-		fmt.Println("Error getting OAuth tokens to undeploy the MCP Server:" + preCommandErr.Error())
+		utils.HandleErrorAndExit("Error getting OAuth tokens to undeploy the MCP Server", preCommandErr)
 	}
 }
 
@@ -0,0 +1,83 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var unsubscribeCmdAppName string
+var unsubscribeCmdEnvironment string
+var unsubscribeCmdArtifactFlags subscriptionArtifactFlags
+
+// Unsubscribe command related usage Info
+const UnsubscribeCmdLiteral = "unsubscribe"
+const unsubscribeCmdShortDesc = "Unsubscribe an application from an API, API Product or MCP Server"
+
+const unsubscribeCmdLongDesc = `Remove the subscription of an application of the user to an API, API Product or MCP Server in the environment specified by flag (--environment, -e)`
+
+const unsubscribeCmdExamples = utils.ProjectName + ` ` + UnsubscribeCmdLiteral + ` --app SampleApp --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + UnsubscribeCmdLiteral + ` --app SampleApp --api-product LeasingAPIProduct -v 1.0.0 -r admin -e dev
+` + utils.ProjectName + ` ` + UnsubscribeCmdLiteral + ` --app SampleApp --mcp-server WeatherMCPServer -v 1.0.0 -e dev
+NOTE: The 3 flags (--app, --version (-v), --environment (-e)) and one of the flags (--api), (--api-product) or (--mcp-server) are mandatory`
+
+// unsubscribeCmd represents the unsubscribe command
+var unsubscribeCmd = &cobra.Command{
+	Use:         UnsubscribeCmdLiteral,
+	Short:       unsubscribeCmdShortDesc,
+	Long:        unsubscribeCmdLongDesc,
+	Example:     unsubscribeCmdExamples,
+	Annotations: resultAnnotations("subscription", resultActionUnsubscribed),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + UnsubscribeCmdLiteral + " called")
+		artifact := unsubscribeCmdArtifactFlags.getArtifact(true)
+		cred, err := GetCredentials(unsubscribeCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, unsubscribeCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting OAuth tokens to unsubscribe the application", err)
+		}
+		subscriptionId, err := impl.Unsubscribe(accessToken, unsubscribeCmdEnvironment, unsubscribeCmdAppName,
+			artifact)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while unsubscribing the application "+unsubscribeCmdAppName, err)
+		}
+		utils.GetCommandResult().ID = subscriptionId
+		fmt.Fprintln(utils.Stdout, "Application "+unsubscribeCmdAppName+" unsubscribed from the "+artifact.Type+" "+
+			artifact.Name+" "+artifact.Version+" successfully")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(unsubscribeCmd)
+	addSubscriptionArtifactFlags(unsubscribeCmd, &unsubscribeCmdArtifactFlags)
+	unsubscribeCmd.Flags().StringVarP(&unsubscribeCmdAppName, "app", "", "", "Name of the application")
+	unsubscribeCmd.Flags().StringVarP(&unsubscribeCmdEnvironment, "environment", "e", "",
+		"Environment of the application and the API, API Product or MCP Server")
+	_ = unsubscribeCmd.MarkFlagRequired("app")
+	_ = unsubscribeCmd.MarkFlagRequired("version")
+	_ = unsubscribeCmd.MarkFlagRequired("environment")
+}
@@ -20,7 +20,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -45,20 +44,19 @@ const deployCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deploy
 
 // deployCmd represents the deploy command
 var DeployCmd = &cobra.Command{
-	Use:     deployCmdLiteral,
-	Short:   deployCmdShortDesc,
-	Long:    deployCmdLongDesc,
-	Example: deployCmdExamples,
+	Use:         deployCmdLiteral,
+	Short:       deployCmdShortDesc,
+	Long:        deployCmdLongDesc,
+	Example:     deployCmdExamples,
+	Annotations: resultAnnotations("project", resultActionDeployed),
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + deployCmdLiteral + " called")
 		if !utils.EnvExistsInMainConfigFile(flagVCSDeployEnvName, utils.MainConfigFilePath) {
-			fmt.Println(flagVCSDeployEnvName, "does not exists. Add it using add env")
-			os.Exit(1)
+			utils.HandleErrorAndExit(flagVCSDeployEnvName+" does not exists. Add it using add env", nil)
 		}
 		mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 		if mainConfig.Config.VCSSourceRepoPath == "" {
-			fmt.Println("VCS source repo path cannot be empty. Set it using apictl set command.")
-			os.Exit(1)
+			utils.HandleErrorAndExit("VCS source repo path cannot be empty. Set it using apictl set command.", nil)
 		}
 		credential, err := GetCredentials(flagVCSDeployEnvName)
 		if err != nil {
@@ -69,8 +67,13 @@ var DeployCmd = &cobra.Command{
 			utils.HandleErrorAndExit("Error while getting an access token for deploying the project(s)", err)
 		}
 		failedProjects := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName)
+		for projectType, projects := range failedProjects {
+			for _, project := range projects {
+				utils.AddCommandWarning("Failed to deploy " + projectType + " project " + project.RelativePath)
+			}
+		}
 		if failedProjects != nil && len(failedProjects) > 0 && flagVCSDeploySkipRollback == false {
-			fmt.Println("\nRolling back to the last successful revision as there are failures..")
+			fmt.Fprintln(utils.Stdout, "\nRolling back to the last successful revision as there are failures..")
 			err = git.Rollback(accessOAuthToken, flagVCSDeployEnvName)
 			if err != nil {
 				utils.HandleErrorAndExit("There are project deployment failures. Failed to rollback.", err)
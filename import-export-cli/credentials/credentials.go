@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"path/filepath"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -43,6 +44,10 @@ type Credential struct {
 	ClientSecret string `json:"clientSecret"`
 	// PersonalAccessToken of API Manager
 	PersonalAccessToken string `json:"accessToken"`
+	// GrantType used to login. Empty for the password grant
+	GrantType string `json:"grantType,omitempty"`
+	// RefreshToken of the device code grant
+	RefreshToken string `json:"refreshToken,omitempty"`
 }
 
 // Credentials of cli
@@ -53,6 +58,8 @@ type Credentials struct {
 	MgwAdapterEnvs map[string]MgAdapterEnv `json:"mgw-clusters"`
 	// CredStore represent type of store to be used
 	CredStore string `json:"credStore,omitempty"`
+	// CredHelper is the name or path of the credential helper used by the helper store
+	CredHelper string `json:"credHelper,omitempty"`
 }
 
 // DefaultAppKey stores consumer key/secret for the default CLI app
@@ -66,6 +73,8 @@ type Environment struct {
 	APIM       Credential    `json:"apim"`
 	MI         MiCredential  `json:"mi"`
 	DefaultApp *DefaultAppKey `json:"defaultApp,omitempty"`
+	// APIMToken is the cached access token of apim
+	APIMToken *AccessToken `json:"apimToken,omitempty"`
 }
 
 type MgAdapterEnv struct {
@@ -74,7 +83,7 @@ type MgAdapterEnv struct {
 }
 
 // GetCredentialStore from file
-// Note to set a different store please use credStore variable
+// Note to set a different store please use SetCredentialStore
 func GetCredentialStore(f string) (Store, error) {
 	// load as a json store first
 	js := NewJsonStore(f)
@@ -82,31 +91,118 @@ func GetCredentialStore(f string) (Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	return js, nil
+	if !js.IsKeychainEnabled() {
+		return js, nil
+	}
+	return newCredentialStore(f, js.credentials.CredStore, js.credentials.CredHelper)
 }
 
 // GetDefaultCredentialStore returns store from default path
 func GetDefaultCredentialStore() (Store, error) {
-	return GetCredentialStore(filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile))
+	return GetCredentialStore(getDefaultCredentialsFilePath())
+}
+
+// SetDefaultCredentialStore changes the store of the default credentials file and moves the existing credentials
+func SetDefaultCredentialStore(storeType, helper string) (Store, error) {
+	return SetCredentialStore(getDefaultCredentialsFilePath(), storeType, helper)
+}
+
+func getDefaultCredentialsFilePath() string {
+	return filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile)
 }
 
-// GetOAuthAccessToken generates an accesstoken for CLI
+// GetOAuthAccessToken returns an accesstoken for CLI. A cached access token of the environment is reused until it
+// is about to expire, after which it is refreshed using its refresh token or a new token is requested.
 func GetOAuthAccessToken(credential Credential, env string) (string, error) {
 	if credential.PersonalAccessToken != "" {
 		return credential.PersonalAccessToken, nil
-	} else {
-		tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(env, utils.MainConfigFilePath)
-		data, err := utils.GetOAuthTokens(credential.Username, credential.Password,
-			Base64Encode(credential.ClientId+":"+credential.ClientSecret),
-			tokenEndpoint)
-		if err != nil {
-			return "", err
+	}
+	accessTokensLock.Lock()
+	defer accessTokensLock.Unlock()
+	cached := getCachedAccessToken(env)
+	if cached.isUsable() {
+		utils.Logln(utils.LogPrefixInfo + "Using the cached access token of " + env)
+		return cached.AccessToken, nil
+	}
+
+	tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(env, utils.MainConfigFilePath)
+	data, err := getOAuthTokens(credential, env, cached.RefreshToken, tokenEndpoint)
+	if err != nil {
+		return "", err
+	}
+	token := NewAccessToken(data)
+	if token.AccessToken == "" {
+		return "", errors.New("access_token not found")
+	}
+	if token.RefreshToken == "" && credential.GrantType == utils.GrantTypeDeviceCode {
+		// The refresh token is kept if it was not rotated
+		token.RefreshToken = cached.RefreshToken
+	}
+	cacheAccessToken(env, token)
+	return token.AccessToken, nil
+}
+
+// getOAuthTokens requests new tokens of an environment. The refresh token of the cached access token is used if
+// there is one. The tokens are requested using the grant used to login if the refresh token cannot be used, except
+// for the device code grant which needs the user to login again.
+func getOAuthTokens(credential Credential, env, refreshToken, tokenEndpoint string) (map[string]string, error) {
+	if refreshToken == "" && credential.GrantType == utils.GrantTypeDeviceCode {
+		refreshToken = credential.RefreshToken
+	}
+	if refreshToken != "" {
+		data, err := utils.GetOAuthTokensWithRefreshToken(refreshToken, credential.ClientId,
+			credential.ClientSecret, tokenEndpoint)
+		if err == nil {
+			return data, nil
 		}
-		if accessToken, ok := data["access_token"]; ok {
-			return accessToken, nil
+		if credential.GrantType == utils.GrantTypeDeviceCode {
+			return nil, fmt.Errorf("unable to refresh the session of %s, login again using --grant %s: %v", env,
+				utils.GrantTypeDeviceCode, err)
 		}
+		utils.Logln(utils.LogPrefixWarning+"Unable to refresh the access token of", env, ":", err)
+	}
+	switch credential.GrantType {
+	case utils.GrantTypeClientCredentials:
+		return utils.GetOAuthTokensWithClientCredentials(credential.ClientId, credential.ClientSecret,
+			tokenEndpoint)
+	case utils.GrantTypeDeviceCode:
+		return nil, fmt.Errorf("the session of %s has ended, login again using --grant %s", env,
+			utils.GrantTypeDeviceCode)
+	default:
+		return utils.GetOAuthTokens(credential.Username, credential.Password,
+			Base64Encode(credential.ClientId+":"+credential.ClientSecret), tokenEndpoint)
+	}
+}
+
+// GetPluginAccessToken requests a separate access token of an environment for a plugin, with the given scopes and a
+// validity period of utils.PluginTokenValidityPeriod. The token is not cached, so the access token of apictl is
+// neither reused nor refreshed. A token cannot be requested if apictl is logged in with a personal access token or
+// with the device code grant, since there are no credentials to request a token with.
+func GetPluginAccessToken(credential Credential, env string, scopes []string) (string, error) {
+	if credential.PersonalAccessToken != "" {
+		return "", errors.New("a token cannot be issued for the plugin when logged in with a personal access token")
+	}
+	tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(env, utils.MainConfigFilePath)
+	var data map[string]string
+	var err error
+	switch credential.GrantType {
+	case utils.GrantTypeDeviceCode:
+		return "", fmt.Errorf("a token cannot be issued for the plugin when logged in using --grant %s",
+			utils.GrantTypeDeviceCode)
+	case utils.GrantTypeClientCredentials:
+		data, err = utils.GetPluginOAuthTokens("", "", credential.ClientId, credential.ClientSecret, scopes,
+			tokenEndpoint)
+	default:
+		data, err = utils.GetPluginOAuthTokens(credential.Username, credential.Password, credential.ClientId,
+			credential.ClientSecret, scopes, tokenEndpoint)
+	}
+	if err != nil {
+		return "", err
+	}
+	if data["access_token"] == "" {
+		return "", errors.New("access_token not found")
 	}
-	return "", errors.New("access_token not found")
+	return data["access_token"], nil
 }
 
 // GetBasicAuth returns basic auth username:password encoded in base64
@@ -116,21 +212,30 @@ func GetBasicAuth(credential Credential) string {
 
 // Revoke access Token when user is logging out from environment
 func RevokeAccessToken(credential Credential, env string, token string) error {
+	return RevokeAccessTokenOfEndpoint(credential, utils.GetTokenRevokeEndpoint(env, utils.MainConfigFilePath), token)
+}
+
+// RevokeAccessTokenOfEndpoint revokes an access token using the given revoke endpoint of a key manager
+func RevokeAccessTokenOfEndpoint(credential Credential, tokenRevokeEndpoint string, token string) error {
 	if credential.PersonalAccessToken != "" {
 		return nil
 	} else {
-		//get revoke endpoint
-		tokenRevokeEndpoint := utils.GetTokenRevokeEndpoint(env, utils.MainConfigFilePath)
-		//Encoding client secret and client Id
-		var b64EncodedClientIDClientSecret = utils.GetBase64EncodedCredentials(credential.ClientId, credential.ClientSecret)
 		// set headers to request
 		headers := make(map[string]string)
 		headers[utils.HeaderContentType] = utils.HeaderValueXWWWFormUrlEncoded
-		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBasicPrefix + " " + b64EncodedClientIDClientSecret
 
 		//Create body for the request
 		body := utils.HeaderToken + token + utils.TokenTypeForRevocation
 
+		if credential.ClientSecret != "" {
+			//Encoding client secret and client Id
+			var b64EncodedClientIDClientSecret = utils.GetBase64EncodedCredentials(credential.ClientId, credential.ClientSecret)
+			headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBasicPrefix + " " + b64EncodedClientIDClientSecret
+		} else {
+			// Public clients of the device code grant send the client ID in the body
+			body += "&client_id=" + url.QueryEscape(credential.ClientId)
+		}
+
 		utils.Logln(utils.LogPrefixInfo + "connecting to " + tokenRevokeEndpoint)
 		resp, err := utils.InvokePOSTRequest(tokenRevokeEndpoint, headers, body)
 
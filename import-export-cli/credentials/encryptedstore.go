@@ -0,0 +1,187 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// DefaultEncryptedConfigFile is the name of the file of the encrypted file store
+var DefaultEncryptedConfigFile = "keys.enc"
+
+// CredStorePassphraseEnvVar is the environment variable with the passphrase of the encrypted file store. The
+// passphrase is prompted for if it is not set.
+const CredStorePassphraseEnvVar = "APICTL_CRED_STORE_PASSPHRASE"
+
+// Parameters used to derive the AES-256 key from the passphrase
+const (
+	encryptedStoreVersion  = 1
+	encryptedStoreSaltSize = 16
+	scryptCost             = 1 << 15
+	scryptBlockSize        = 8
+	scryptParallelism      = 1
+)
+
+// The passphrase and the keys derived from it are cached for the lifetime of the process, since the credential store
+// is loaded several times by a single command and deriving a key takes a noticeable amount of time
+var (
+	credStoreCacheLock         sync.Mutex
+	cachedCredStorePassphrase  string
+	cachedCredStoreDerivedKeys = make(map[string][]byte)
+)
+
+// EncryptedFileStore keeps the credentials in a file encrypted with AES-256-GCM using a key derived from a
+// passphrase. The content of the file is the same as the json store once decrypted.
+type EncryptedFileStore struct {
+	*JsonStore
+}
+
+// encryptedFile is the content of the file of the encrypted file store
+type encryptedFile struct {
+	Version int    `json:"version"`
+	Salt    string `json:"salt"`
+	Data    string `json:"data"`
+}
+
+// encryptedFileCodec encrypts and decrypts the content of the encrypted file store
+type encryptedFileCodec struct {
+	passphrase string
+	salt       []byte
+	key        []byte
+}
+
+// NewEncryptedFileStore creates a new encrypted file store
+func NewEncryptedFileStore(path, passphrase string) *EncryptedFileStore {
+	return &EncryptedFileStore{JsonStore: &JsonStore{
+		Path:     path,
+		codec:    &encryptedFileCodec{passphrase: passphrase},
+		fileMode: 0600,
+	}}
+}
+
+// purge removes the file of the store
+func (s *EncryptedFileStore) purge() error {
+	if err := os.Remove(s.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.JsonStore.Load()
+}
+
+func (c *encryptedFileCodec) encode(data []byte) ([]byte, error) {
+	if c.key == nil {
+		c.salt = make([]byte, encryptedStoreSaltSize)
+		if _, err := io.ReadFull(rand.Reader, c.salt); err != nil {
+			return nil, err
+		}
+		if err := c.deriveKey(); err != nil {
+			return nil, err
+		}
+	}
+	encrypted, err := utils.EncryptAES256(c.key, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedFile{
+		Version: encryptedStoreVersion,
+		Salt:    base64.StdEncoding.EncodeToString(c.salt),
+		Data:    encrypted,
+	}, "", "  ")
+}
+
+func (c *encryptedFileCodec) decode(data []byte) ([]byte, error) {
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if file.Version != encryptedStoreVersion {
+		return nil, fmt.Errorf("unsupported version %d of the encrypted credential store", file.Version)
+	}
+	salt, err := base64.StdEncoding.DecodeString(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	c.salt = salt
+	if err := c.deriveKey(); err != nil {
+		return nil, err
+	}
+	decrypted, err := utils.DecryptAES256(c.key, file.Data)
+	if err != nil {
+		return nil, errors.New("incorrect passphrase for the encrypted credential store")
+	}
+	return []byte(decrypted), nil
+}
+
+// deriveKey derives the AES-256 key from the passphrase and the salt, reusing a key derived earlier in the process
+func (c *encryptedFileCodec) deriveKey() error {
+	cacheKey := c.passphrase + "\x00" + base64.StdEncoding.EncodeToString(c.salt)
+	credStoreCacheLock.Lock()
+	defer credStoreCacheLock.Unlock()
+	if key, ok := cachedCredStoreDerivedKeys[cacheKey]; ok {
+		c.key = key
+		return nil
+	}
+	key, err := scrypt.Key([]byte(c.passphrase), c.salt, scryptCost, scryptBlockSize, scryptParallelism,
+		utils.AES256KeySize)
+	if err != nil {
+		return err
+	}
+	cachedCredStoreDerivedKeys[cacheKey] = key
+	c.key = key
+	return nil
+}
+
+// getCredStorePassphrase returns the passphrase of the encrypted file store from the environment or the terminal.
+// The passphrase is prompted for only once in a process.
+func getCredStorePassphrase() (string, error) {
+	if passphrase := os.Getenv(CredStorePassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	credStoreCacheLock.Lock()
+	defer credStoreCacheLock.Unlock()
+	if cachedCredStorePassphrase != "" {
+		return cachedCredStorePassphrase, nil
+	}
+	if !terminal.IsTerminal(int(syscall.Stdin)) {
+		return "", errors.New("the passphrase of the encrypted credential store is required. Set it using " +
+			CredStorePassphraseEnvVar)
+	}
+	fmt.Fprint(os.Stderr, "Credential store passphrase:")
+	passphrase, err := terminal.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if len(passphrase) == 0 {
+		return "", errors.New("the passphrase of the encrypted credential store cannot be empty")
+	}
+	cachedCredStorePassphrase = string(passphrase)
+	return cachedCredStorePassphrase, nil
+}
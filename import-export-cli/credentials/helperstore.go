@@ -0,0 +1,426 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// CredentialHelperPrefix is the prefix of the executables of credential helpers referred to by name. Eg: the helper
+// "vault" is the executable apictl-credential-vault in the PATH.
+const CredentialHelperPrefix = "apictl-credential-"
+
+// Actions of the credential helper protocol. The action is passed as the only argument of the helper.
+//
+//	get   : reads a key from stdin and writes its secret to stdout. Exits with a non-zero status and writes
+//	        HelperCredentialsNotFound to stdout if there is no secret for the key
+//	store : reads {"key": "<key>", "secret": "<secret>"} from stdin and stores the secret
+//	erase : reads a key from stdin and removes its secret
+//	list  : writes a json array of all the stored keys to stdout
+const (
+	helperActionGet   = "get"
+	helperActionStore = "store"
+	helperActionErase = "erase"
+	helperActionList  = "list"
+)
+
+// HelperCredentialsNotFound is written by a credential helper when there is no secret for a key
+const HelperCredentialsNotFound = "credentials not found"
+
+var errHelperCredentialsNotFound = errors.New(HelperCredentialsNotFound)
+
+// Prefixes of the keys given to the credential helper. A key is the prefix followed by the environment.
+// Eg: apim/dev
+const (
+	helperKeyPrefixAPIM       = "apim/"
+	helperKeyPrefixAPIMToken  = "apim-token/"
+	helperKeyPrefixMI         = "mi/"
+	helperKeyPrefixMG         = "mg/"
+	helperKeyPrefixDefaultApp = "default-app/"
+)
+
+// HelperStore delegates the credentials to an external credential helper, so that they can be kept in a secret
+// manager such as Vault. Each credential is kept by the helper as a json secret.
+type HelperStore struct {
+	// Helper is the name or path of the credential helper
+	Helper string
+
+	// internal usage
+	program string
+	secrets map[string]string
+}
+
+// helperStoreRequest is written to the credential helper to store a secret
+type helperStoreRequest struct {
+	Key    string `json:"key"`
+	Secret string `json:"secret"`
+}
+
+// NewHelperStore creates a new store backed by a credential helper
+func NewHelperStore(helper string) *HelperStore {
+	return &HelperStore{Helper: helper}
+}
+
+// Load resolves the executable of the credential helper
+func (s *HelperStore) Load() error {
+	program := s.Helper
+	if !strings.ContainsRune(program, filepath.Separator) && !strings.ContainsRune(program, '/') {
+		program = CredentialHelperPrefix + program
+	}
+	path, err := exec.LookPath(program)
+	if err != nil {
+		return fmt.Errorf("credential helper %s not found: %v", s.Helper, err)
+	}
+	s.program = path
+	s.secrets = make(map[string]string)
+	return nil
+}
+
+// execute runs the credential helper with an action and returns its output
+func (s *HelperStore) execute(action, input string) (string, error) {
+	utils.Logln(utils.LogPrefixInfo+"Running credential helper", s.program, action)
+	cmd := exec.Command(s.program, action)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	output := strings.TrimSpace(stdout.String())
+	if err != nil {
+		if action == helperActionGet && output == HelperCredentialsNotFound {
+			return "", errHelperCredentialsNotFound
+		}
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = output
+		}
+		return "", fmt.Errorf("credential helper %s failed to %s: %v %s", s.Helper, action, err, message)
+	}
+	return output, nil
+}
+
+// get returns the secret of a key, or false if the helper has no secret for the key
+func (s *HelperStore) get(key string) (string, bool, error) {
+	if secret, ok := s.secrets[key]; ok {
+		return secret, secret != "", nil
+	}
+	secret, err := s.execute(helperActionGet, key)
+	if err == errHelperCredentialsNotFound {
+		s.secrets[key] = ""
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	s.secrets[key] = secret
+	return secret, true, nil
+}
+
+// getJSON decodes the secret of a key into value and returns false if the helper has no secret for the key
+func (s *HelperStore) getJSON(key string, value interface{}) (bool, error) {
+	secret, ok, err := s.get(key)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(secret), value); err != nil {
+		return false, fmt.Errorf("invalid secret of %s returned by credential helper %s: %v", key, s.Helper, err)
+	}
+	return true, nil
+}
+
+// setJSON stores value as the secret of a key
+func (s *HelperStore) setJSON(key string, value interface{}) error {
+	secret, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	request, err := json.Marshal(helperStoreRequest{Key: key, Secret: string(secret)})
+	if err != nil {
+		return err
+	}
+	if _, err := s.execute(helperActionStore, string(request)); err != nil {
+		return err
+	}
+	s.secrets[key] = string(secret)
+	return nil
+}
+
+// erase removes the secret of a key
+func (s *HelperStore) erase(key string) error {
+	if _, ok, err := s.get(key); err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("%s was not found", strings.SplitN(key, "/", 2)[1])
+	}
+	if _, err := s.execute(helperActionErase, key); err != nil {
+		return err
+	}
+	s.secrets[key] = ""
+	return nil
+}
+
+// GetAPIMCredentials returns credentials for apim from the store or an error
+func (s *HelperStore) GetAPIMCredentials(env string) (Credential, error) {
+	var credential Credential
+	if ok, err := s.getJSON(helperKeyPrefixAPIM+env, &credential); err != nil {
+		return Credential{}, err
+	} else if !ok {
+		return Credential{}, fmt.Errorf("credentials not found for APIM in %s, use login", env)
+	}
+	return credential, nil
+}
+
+// SetAPIMCredentials sets credentials for apim using username, password, clientID, client secret and access token
+func (s *HelperStore) SetAPIMCredentials(env, username, password, clientId, clientSecret,
+	personalAccessToken string) error {
+	err := s.setJSON(helperKeyPrefixAPIM+env, Credential{
+		Username:            username,
+		Password:            password,
+		ClientId:            clientId,
+		ClientSecret:        clientSecret,
+		PersonalAccessToken: personalAccessToken,
+	})
+	if err != nil {
+		return err
+	}
+	// The cached access token belongs to the previous credentials
+	return s.eraseIfExists(helperKeyPrefixAPIMToken + env)
+}
+
+// SetAPIMGrant sets the grant type used to login to apim and the refresh token of the grant
+func (s *HelperStore) SetAPIMGrant(env, grantType, refreshToken string) error {
+	credential, err := s.GetAPIMCredentials(env)
+	if err != nil {
+		return err
+	}
+	credential.GrantType = grantType
+	credential.RefreshToken = refreshToken
+	return s.setJSON(helperKeyPrefixAPIM+env, credential)
+}
+
+// GetAPIMToken returns the cached access token of apim for a given environment, or a blank token if there is none
+func (s *HelperStore) GetAPIMToken(env string) (AccessToken, error) {
+	var token AccessToken
+	if _, err := s.getJSON(helperKeyPrefixAPIMToken+env, &token); err != nil {
+		return AccessToken{}, err
+	}
+	return token, nil
+}
+
+// SetAPIMToken caches an access token of apim for a given environment
+func (s *HelperStore) SetAPIMToken(env string, token AccessToken) error {
+	return s.setJSON(helperKeyPrefixAPIMToken+env, token)
+}
+
+// GetMICredentials returns credentials for micro integrator from the store or an error
+func (s *HelperStore) GetMICredentials(env string) (MiCredential, error) {
+	var credential MiCredential
+	if ok, err := s.getJSON(helperKeyPrefixMI+env, &credential); err != nil {
+		return MiCredential{}, err
+	} else if !ok {
+		return MiCredential{}, fmt.Errorf("credentials not found for Mi in %s, use login", env)
+	}
+	return credential, nil
+}
+
+// SetMICredentials set credentials for mi using username, password, accessToken
+func (s *HelperStore) SetMICredentials(env, username, password, accessToken string) error {
+	return s.setJSON(helperKeyPrefixMI+env, MiCredential{
+		Username:    username,
+		Password:    password,
+		AccessToken: accessToken,
+	})
+}
+
+// GetMGToken returns token for microgateway adapter from the store or an error
+func (s *HelperStore) GetMGToken(env string) (MgAdapterEnv, error) {
+	var mgwAdapterEnv MgAdapterEnv
+	if ok, err := s.getJSON(helperKeyPrefixMG+env, &mgwAdapterEnv); err != nil {
+		return MgAdapterEnv{}, err
+	} else if !ok {
+		return MgAdapterEnv{}, fmt.Errorf(
+			"Tokens not found for Mgw in %s. Log in with `apictl mg login [env]`", env)
+	}
+	return mgwAdapterEnv, nil
+}
+
+// SetMGToken set token for microgateway adapter
+func (s *HelperStore) SetMGToken(env, accessToken string) error {
+	return s.setJSON(helperKeyPrefixMG+env, MgAdapterEnv{AccessToken: accessToken})
+}
+
+// GetDefaultAppKeys returns the consumer key and secret for the default CLI app in a given env
+func (s *HelperStore) GetDefaultAppKeys(env string) (string, string, error) {
+	var app DefaultAppKey
+	if ok, err := s.getJSON(helperKeyPrefixDefaultApp+env, &app); err != nil {
+		return "", "", err
+	} else if !ok {
+		return "", "", fmt.Errorf("default app keys not found for env %s", env)
+	}
+	return app.ConsumerKey, app.ConsumerSecret, nil
+}
+
+// SetDefaultAppKeys stores the consumer key and secret for the default CLI app in a given env
+func (s *HelperStore) SetDefaultAppKeys(env, consumerKey, consumerSecret string) error {
+	return s.setJSON(helperKeyPrefixDefaultApp+env, DefaultAppKey{
+		ConsumerKey:    consumerKey,
+		ConsumerSecret: consumerSecret,
+	})
+}
+
+// EraseAPIM remove apim credentials from the store. The default app keys of the environment are removed as well,
+// similar to the json store.
+func (s *HelperStore) EraseAPIM(env string) error {
+	if err := s.erase(helperKeyPrefixAPIM + env); err != nil {
+		return err
+	}
+	if err := s.eraseIfExists(helperKeyPrefixAPIMToken + env); err != nil {
+		return err
+	}
+	return s.eraseIfExists(helperKeyPrefixDefaultApp + env)
+}
+
+// eraseIfExists removes the secret of a key if the helper has a secret for the key
+func (s *HelperStore) eraseIfExists(key string) error {
+	if _, ok, err := s.get(key); err != nil || !ok {
+		return err
+	}
+	return s.erase(key)
+}
+
+// EraseMI remove mi credentials from the store
+func (s *HelperStore) EraseMI(env string) error {
+	return s.erase(helperKeyPrefixMI + env)
+}
+
+// EraseMG remove mg tokens from the store
+func (s *HelperStore) EraseMG(env string) error {
+	return s.erase(helperKeyPrefixMG + env)
+}
+
+// HasAPIM return the existance of apim credentials in the store for a given environment
+func (s *HelperStore) HasAPIM(env string) bool {
+	credential, err := s.GetAPIMCredentials(env)
+	return err == nil && apimCredentialsExists(credential)
+}
+
+// HasMI return the existance of mi credentials in the store for a given environment
+func (s *HelperStore) HasMI(env string) bool {
+	credential, err := s.GetMICredentials(env)
+	return err == nil && miCredentialsExists(credential)
+}
+
+// HasMG return the existance of mg tokens in the store for a given mgw adapter environment
+func (s *HelperStore) HasMG(env string) bool {
+	mgwAdapterEnv, err := s.GetMGToken(env)
+	return err == nil && mgTokenExists(mgwAdapterEnv)
+}
+
+// listKeys returns the keys of all the secrets kept by the credential helper for apictl
+func (s *HelperStore) listKeys() ([]string, error) {
+	output, err := s.execute(helperActionList, "")
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	if output != "" {
+		if err := json.Unmarshal([]byte(output), &keys); err != nil {
+			return nil, fmt.Errorf("invalid keys returned by credential helper %s: %v", s.Helper, err)
+		}
+	}
+	var apictlKeys []string
+	for _, key := range keys {
+		for _, prefix := range []string{helperKeyPrefixAPIM, helperKeyPrefixAPIMToken, helperKeyPrefixMI,
+			helperKeyPrefixMG, helperKeyPrefixDefaultApp} {
+			if strings.HasPrefix(key, prefix) {
+				apictlKeys = append(apictlKeys, key)
+				break
+			}
+		}
+	}
+	return apictlKeys, nil
+}
+
+// export returns all the credentials kept by the credential helper
+func (s *HelperStore) export() (Credentials, error) {
+	exported := Credentials{
+		Environments:   make(map[string]Environment),
+		MgwAdapterEnvs: make(map[string]MgAdapterEnv),
+	}
+	keys, err := s.listKeys()
+	if err != nil {
+		return Credentials{}, err
+	}
+	for _, key := range keys {
+		prefix, env := splitHelperKey(key)
+		environment := exported.Environments[env]
+		switch prefix {
+		case helperKeyPrefixAPIM:
+			environment.APIM, err = s.GetAPIMCredentials(env)
+		case helperKeyPrefixAPIMToken:
+			environment.APIMToken = &AccessToken{}
+			*environment.APIMToken, err = s.GetAPIMToken(env)
+		case helperKeyPrefixMI:
+			environment.MI, err = s.GetMICredentials(env)
+		case helperKeyPrefixDefaultApp:
+			environment.DefaultApp = &DefaultAppKey{}
+			environment.DefaultApp.ConsumerKey, environment.DefaultApp.ConsumerSecret, err =
+				s.GetDefaultAppKeys(env)
+		case helperKeyPrefixMG:
+			exported.MgwAdapterEnvs[env], err = s.GetMGToken(env)
+			if err != nil {
+				return Credentials{}, err
+			}
+			continue
+		}
+		if err != nil {
+			return Credentials{}, err
+		}
+		exported.Environments[env] = environment
+	}
+	return exported, nil
+}
+
+// purge removes all the credentials kept by the credential helper for apictl
+func (s *HelperStore) purge() error {
+	keys, err := s.listKeys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := s.execute(helperActionErase, key); err != nil {
+			return err
+		}
+		s.secrets[key] = ""
+	}
+	return nil
+}
+
+// splitHelperKey splits a key of the credential helper into its prefix and the environment
+func splitHelperKey(key string) (string, string) {
+	parts := strings.SplitN(key, "/", 2)
+	return parts[0] + "/", parts[1]
+}
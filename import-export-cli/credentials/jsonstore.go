@@ -35,11 +35,21 @@ type JsonStore struct {
 
 	// internal usage
 	credentials Credentials
+	// codec transforms the content of the file, nil if the file is plain json
+	codec fileCodec
+	// fileMode of the file when it is created
+	fileMode os.FileMode
+}
+
+// fileCodec transforms the json content of a store before it is written to and after it is read from the file
+type fileCodec interface {
+	encode(data []byte) ([]byte, error)
+	decode(data []byte) ([]byte, error)
 }
 
 // NewJsonStore creates a new store
 func NewJsonStore(path string) *JsonStore {
-	return &JsonStore{Path: path}
+	return &JsonStore{Path: path, fileMode: os.ModePerm}
 }
 
 // Load json store
@@ -49,6 +59,12 @@ func (s *JsonStore) Load() error {
 		if err != nil {
 			return err
 		}
+		if s.codec != nil {
+			data, err = s.codec.decode(data)
+			if err != nil {
+				return fmt.Errorf("unable to read %s: %v", s.Path, err)
+			}
+		}
 
 		var cred Credentials
 		err = json.Unmarshal(data, &cred)
@@ -75,13 +91,26 @@ func (s *JsonStore) persist() error {
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(s.Path, data, os.ModePerm)
+	if s.codec != nil {
+		data, err = s.codec.encode(data)
+		if err != nil {
+			return err
+		}
+	}
+	err = ioutil.WriteFile(s.Path, data, s.fileMode)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// warnPlainText warns that the credentials are readable by anyone with access to the file
+func (s *JsonStore) warnPlainText() {
+	if s.codec == nil {
+		fmt.Printf(PlainTextWarnMessage, s.Path)
+	}
+}
+
 // GetAPIMCredentials returns credentials for apim from the store or an error
 func (s *JsonStore) GetAPIMCredentials(env string) (Credential, error) {
 	if environment, ok := s.credentials.Environments[env]; ok {
@@ -105,8 +134,13 @@ func (s *JsonStore) GetAPIMCredentials(env string) (Credential, error) {
 		if err != nil {
 			return Credential{}, err
 		}
+		refreshToken, err := Base64Decode(environment.APIM.RefreshToken)
+		if err != nil {
+			return Credential{}, err
+		}
 		credential := Credential{
-			username, password, clientID, clientSecret, personalAccessToken,
+			username, password, clientID, clientSecret, personalAccessToken, environment.APIM.GrantType,
+			refreshToken,
 		}
 		return credential, nil
 	}
@@ -123,15 +157,62 @@ func (s *JsonStore) SetAPIMCredentials(env, username, password, clientId, client
 		ClientSecret:        Base64Encode(clientSecret),
 		PersonalAccessToken: Base64Encode(personalAccessToken),
 	}
+	// The cached access token belongs to the previous credentials
+	environment.APIMToken = nil
 	s.credentials.Environments[env] = environment
 	err := s.persist()
 	if err != nil {
 		return err
 	}
-	fmt.Printf(PlainTextWarnMessage, s.Path)
+	s.warnPlainText()
 	return nil
 }
 
+// SetAPIMGrant sets the grant type used to login to apim and the refresh token of the grant
+func (s *JsonStore) SetAPIMGrant(env, grantType, refreshToken string) error {
+	environment, ok := s.credentials.Environments[env]
+	if !ok {
+		return fmt.Errorf("credentials not found for APIM in %s, use login", env)
+	}
+	environment.APIM.GrantType = grantType
+	environment.APIM.RefreshToken = ""
+	if refreshToken != "" {
+		environment.APIM.RefreshToken = Base64Encode(refreshToken)
+	}
+	s.credentials.Environments[env] = environment
+	return s.persist()
+}
+
+// GetAPIMToken returns the cached access token of apim for a given environment, or a blank token if there is none
+func (s *JsonStore) GetAPIMToken(env string) (AccessToken, error) {
+	environment, ok := s.credentials.Environments[env]
+	if !ok || environment.APIMToken == nil {
+		return AccessToken{}, nil
+	}
+	token := *environment.APIMToken
+	var err error
+	if token.AccessToken, err = Base64Decode(token.AccessToken); err != nil {
+		return AccessToken{}, err
+	}
+	if token.RefreshToken, err = Base64Decode(token.RefreshToken); err != nil {
+		return AccessToken{}, err
+	}
+	return token, nil
+}
+
+// SetAPIMToken caches an access token of apim for a given environment
+func (s *JsonStore) SetAPIMToken(env string, token AccessToken) error {
+	environment, ok := s.credentials.Environments[env]
+	if !ok {
+		return fmt.Errorf("credentials not found for APIM in %s, use login", env)
+	}
+	token.AccessToken = Base64Encode(token.AccessToken)
+	token.RefreshToken = Base64Encode(token.RefreshToken)
+	environment.APIMToken = &token
+	s.credentials.Environments[env] = environment
+	return s.persist()
+}
+
 // GetMICredentials returns credentials for micro integrator from the store or an error
 func (s *JsonStore) GetMICredentials(env string) (MiCredential, error) {
 	if environment, ok := s.credentials.Environments[env]; ok {
@@ -168,7 +249,7 @@ func (s *JsonStore) SetMICredentials(env, username, password, accessToken string
 	if err != nil {
 		return err
 	}
-	fmt.Printf(PlainTextWarnMessage, s.Path)
+	s.warnPlainText()
 	return nil
 }
 
@@ -221,7 +302,7 @@ func (s *JsonStore) SetDefaultAppKeys(env, consumerKey, consumerSecret string) e
 	if err := s.persist(); err != nil {
 		return err
 	}
-	fmt.Printf(PlainTextWarnMessage, s.Path)
+	s.warnPlainText()
 	return nil
 }
 
@@ -237,6 +318,7 @@ func (s *JsonStore) EraseAPIM(env string) error {
 	} else {
 		// remove only apim credentials
 		environment.APIM = Credential{}
+		environment.APIMToken = nil
 		s.credentials.Environments[env] = environment
 	}
 	return s.persist()
@@ -276,6 +358,64 @@ func (s *JsonStore) IsKeychainEnabled() bool {
 	return s.credentials.CredStore != ""
 }
 
+// setCredStore records the store to be used for the credentials. The default json store is recorded as empty.
+func (s *JsonStore) setCredStore(storeType, helper string) error {
+	if storeType == JsonCredStoreType {
+		storeType = ""
+	}
+	s.credentials.CredStore = storeType
+	s.credentials.CredHelper = helper
+	return s.persist()
+}
+
+// export returns the decoded credentials of the store
+func (s *JsonStore) export() (Credentials, error) {
+	exported := Credentials{
+		Environments:   make(map[string]Environment),
+		MgwAdapterEnvs: make(map[string]MgAdapterEnv),
+	}
+	for env, environment := range s.credentials.Environments {
+		var exportedEnvironment Environment
+		var err error
+		if apimCredentialsExists(environment.APIM) {
+			if exportedEnvironment.APIM, err = s.GetAPIMCredentials(env); err != nil {
+				return Credentials{}, err
+			}
+			if environment.APIMToken != nil {
+				token, err := s.GetAPIMToken(env)
+				if err != nil {
+					return Credentials{}, err
+				}
+				exportedEnvironment.APIMToken = &token
+			}
+		}
+		if miCredentialsExists(environment.MI) {
+			if exportedEnvironment.MI, err = s.GetMICredentials(env); err != nil {
+				return Credentials{}, err
+			}
+		}
+		if defaultAppExists(environment.DefaultApp) {
+			key, secret, err := s.GetDefaultAppKeys(env)
+			if err != nil {
+				return Credentials{}, err
+			}
+			exportedEnvironment.DefaultApp = &DefaultAppKey{ConsumerKey: key, ConsumerSecret: secret}
+		}
+		exported.Environments[env] = exportedEnvironment
+	}
+	for env, mgwAdapterEnv := range s.credentials.MgwAdapterEnvs {
+		exported.MgwAdapterEnvs[env] = mgwAdapterEnv
+	}
+	return exported, nil
+}
+
+// purge removes all the credentials from the store
+func (s *JsonStore) purge() error {
+	s.credentials.Environments = make(map[string]Environment)
+	s.credentials.MgwAdapterEnvs = make(map[string]MgAdapterEnv)
+	return s.persist()
+}
+
 // HasAPIM return the existance of apim credentials in the store for a given environment
 func (s *JsonStore) HasAPIM(env string) bool {
 	if environment, ok := s.credentials.Environments[env]; ok {
@@ -309,6 +449,8 @@ func apimCredentialsExists(apimCred Credential) bool {
 		return true
 	} else if apimCred.PersonalAccessToken != "" {
 		return true
+	} else if apimCred.GrantType != "" && apimCred.ClientId != "" {
+		return true
 	}
 	return false
 }
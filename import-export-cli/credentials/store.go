@@ -18,6 +18,13 @@
 
 package credentials
 
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
 type Store interface {
 	// Has return the existance of apim credentials in the store for a given environment
 	HasAPIM(env string) bool
@@ -33,6 +40,12 @@ type Store interface {
 	GetMGToken(env string) (MgAdapterEnv, error)
 	// SetAPIMCredentials sets credentials for micro integrator using username, password, clientID and client secret
 	SetAPIMCredentials(env, username, password, clientID, clientSecret, accessToken string) error
+	// SetAPIMGrant sets the grant type used to login to apim and the refresh token of the grant, if any
+	SetAPIMGrant(env, grantType, refreshToken string) error
+	// GetAPIMToken returns the cached access token of apim for a given environment, or a blank token if there is none
+	GetAPIMToken(env string) (AccessToken, error)
+	// SetAPIMToken caches an access token of apim for a given environment
+	SetAPIMToken(env string, token AccessToken) error
 	// SetMICredentials sets credentials for micro integrator using username, password and access token
 	SetMICredentials(env, username, password, accessToken string) error
 	// SetMGToken sets the Access Token for a Microgateway Adapter env
@@ -50,3 +63,147 @@ type Store interface {
 	// Load store
 	Load() error
 }
+
+// Types of the credential stores
+const (
+	// JsonCredStoreType keeps the credentials base64 encoded in keys.json
+	JsonCredStoreType = "json"
+	// EncryptedFileCredStoreType keeps the credentials in keys.enc encrypted with a passphrase
+	EncryptedFileCredStoreType = "encrypted-file"
+	// HelperCredStoreType delegates the credentials to an external credential helper
+	HelperCredStoreType = "helper"
+)
+
+// CredStoreTypes are the supported types of credential stores
+var CredStoreTypes = []string{JsonCredStoreType, EncryptedFileCredStoreType, HelperCredStoreType}
+
+// migratableStore is a store whose credentials can be moved to another store
+type migratableStore interface {
+	Store
+	// export returns all the credentials of the store decoded
+	export() (Credentials, error)
+	// purge removes all the credentials from the store
+	purge() error
+}
+
+// newCredentialStore creates and loads a store of the given type for the credentials file f
+func newCredentialStore(f, storeType, helper string) (migratableStore, error) {
+	var store migratableStore
+	switch storeType {
+	case "", JsonCredStoreType:
+		store = NewJsonStore(f)
+	case EncryptedFileCredStoreType:
+		passphrase, err := getCredStorePassphrase()
+		if err != nil {
+			return nil, err
+		}
+		store = NewEncryptedFileStore(filepath.Join(filepath.Dir(f), DefaultEncryptedConfigFile), passphrase)
+	case HelperCredStoreType:
+		if helper == "" {
+			return nil, errors.New("a credential helper is required for the helper credential store")
+		}
+		store = NewHelperStore(helper)
+	default:
+		return nil, fmt.Errorf("unsupported credential store %s. Supported stores: %s", storeType,
+			strings.Join(CredStoreTypes, ", "))
+	}
+	if err := store.Load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// SetCredentialStore makes the store of storeType the credential store recorded in the credentials file f. The
+// credentials of the current store are moved to the new store.
+// @param f : Path to the credentials file
+// @param storeType : Type of the new store
+// @param helper : Name or path of the credential helper if storeType is helper
+// @return the new store
+// @return error
+func SetCredentialStore(f, storeType, helper string) (Store, error) {
+	js := NewJsonStore(f)
+	if err := js.Load(); err != nil {
+		return nil, err
+	}
+	currentType, currentHelper := js.credentials.CredStore, js.credentials.CredHelper
+	if currentType == "" {
+		currentType = JsonCredStoreType
+	}
+	if storeType != HelperCredStoreType {
+		helper = ""
+	}
+	if currentType == storeType && currentHelper == helper {
+		return newCredentialStore(f, storeType, helper)
+	}
+
+	current, err := newCredentialStore(f, currentType, currentHelper)
+	if err != nil {
+		return nil, err
+	}
+	target, err := newCredentialStore(f, storeType, helper)
+	if err != nil {
+		return nil, err
+	}
+	exported, err := current.export()
+	if err != nil {
+		return nil, err
+	}
+	if err := importCredentials(target, exported); err != nil {
+		return nil, err
+	}
+	if err := current.purge(); err != nil {
+		return nil, err
+	}
+	// The json store is reloaded since it may have been changed while moving the credentials
+	if err := js.Load(); err != nil {
+		return nil, err
+	}
+	if err := js.setCredStore(storeType, helper); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// importCredentials adds the decoded credentials to a store
+func importCredentials(store Store, creds Credentials) error {
+	for env, environment := range creds.Environments {
+		if apimCredentialsExists(environment.APIM) {
+			if err := store.SetAPIMCredentials(env, environment.APIM.Username, environment.APIM.Password,
+				environment.APIM.ClientId, environment.APIM.ClientSecret,
+				environment.APIM.PersonalAccessToken); err != nil {
+				return err
+			}
+			if environment.APIM.GrantType != "" {
+				if err := store.SetAPIMGrant(env, environment.APIM.GrantType,
+					environment.APIM.RefreshToken); err != nil {
+					return err
+				}
+			}
+			if environment.APIMToken != nil {
+				if err := store.SetAPIMToken(env, *environment.APIMToken); err != nil {
+					return err
+				}
+			}
+		}
+		if miCredentialsExists(environment.MI) {
+			if err := store.SetMICredentials(env, environment.MI.Username, environment.MI.Password,
+				environment.MI.AccessToken); err != nil {
+				return err
+			}
+		}
+		if defaultAppExists(environment.DefaultApp) {
+			if err := store.SetDefaultAppKeys(env, environment.DefaultApp.ConsumerKey,
+				environment.DefaultApp.ConsumerSecret); err != nil {
+				return err
+			}
+		}
+	}
+	for env, mgwAdapterEnv := range creds.MgwAdapterEnvs {
+		if mgTokenExists(mgwAdapterEnv) {
+			if err := store.SetMGToken(env, mgwAdapterEnv.AccessToken); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
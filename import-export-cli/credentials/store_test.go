@@ -0,0 +1,227 @@
+package credentials
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestEncryptedFileStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultEncryptedConfigFile)
+	store := NewEncryptedFileStore(path, "passphrase")
+	assert.Nil(t, store.Load())
+	assert.Nil(t, store.SetAPIMCredentials("dev", "admin", "secret-password", "id", "client-secret", ""))
+
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), "admin")
+	assert.NotContains(t, string(content), Base64Encode("secret-password"))
+
+	reloaded := NewEncryptedFileStore(path, "passphrase")
+	assert.Nil(t, reloaded.Load())
+	assert.True(t, reloaded.HasAPIM("dev"))
+	credential, err := reloaded.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret-password", credential.Password)
+
+	assert.Error(t, NewEncryptedFileStore(path, "wrong").Load(), "Wrong passphrase should be rejected")
+}
+
+func TestEncryptedFileStoreReusesDerivedKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultEncryptedConfigFile)
+	store := NewEncryptedFileStore(path, "cached-passphrase")
+	assert.Nil(t, store.Load())
+	assert.Nil(t, store.SetMGToken("mg-dev", "mg-token"))
+	key := store.codec.(*encryptedFileCodec).key
+
+	cachedKeys := len(cachedCredStoreDerivedKeys)
+	reloaded := NewEncryptedFileStore(path, "cached-passphrase")
+	assert.Nil(t, reloaded.Load())
+	assert.Equal(t, cachedKeys, len(cachedCredStoreDerivedKeys), "The key should be derived only once")
+	assert.Equal(t, key, reloaded.codec.(*encryptedFileCodec).key)
+	assert.True(t, reloaded.HasMG("mg-dev"))
+}
+
+func TestSetCredentialStoreMovesCredentials(t *testing.T) {
+	t.Setenv(CredStorePassphraseEnvVar, "passphrase")
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	js := NewJsonStore(path)
+	assert.Nil(t, js.Load())
+	assert.Nil(t, js.SetAPIMCredentials("dev", "admin", "admin", "id", "secret", ""))
+	assert.Nil(t, js.SetMICredentials("dev", "mi", "mi", "token"))
+	assert.Nil(t, js.SetMGToken("mg-dev", "mg-token"))
+	assert.Nil(t, js.SetAPIMToken("dev", AccessToken{AccessToken: "token", ExpiresAt: 1}))
+
+	store, err := SetCredentialStore(path, EncryptedFileCredStoreType, "")
+	assert.Nil(t, err)
+	assert.IsType(t, &EncryptedFileStore{}, store)
+	content, _ := ioutil.ReadFile(path)
+	assert.NotContains(t, string(content), Base64Encode("admin"), "Credentials should be removed from keys.json")
+
+	store, err = GetCredentialStore(path)
+	assert.Nil(t, err)
+	assert.True(t, store.HasAPIM("dev"))
+	assert.True(t, store.HasMI("dev"))
+	assert.True(t, store.HasMG("mg-dev"))
+	token, err := store.GetAPIMToken("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "token", token.AccessToken)
+
+	store, err = SetCredentialStore(path, JsonCredStoreType, "")
+	assert.Nil(t, err)
+	assert.IsType(t, &JsonStore{}, store)
+	_, err = os.Stat(filepath.Join(filepath.Dir(path), DefaultEncryptedConfigFile))
+	assert.True(t, os.IsNotExist(err), "Encrypted file should be removed")
+	store, err = GetCredentialStore(path)
+	assert.Nil(t, err)
+	credential, err := store.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "admin", credential.Username)
+}
+
+// helperScript is a credential helper keeping each secret in a file of its directory
+const helperScript = `#!/bin/sh
+dir=$(dirname "$0")/secrets
+mkdir -p "$dir"
+case "$1" in
+get)
+	key=$(cat | tr '/' '_')
+	if [ -f "$dir/$key" ]; then cat "$dir/$key"; else echo "credentials not found"; exit 1; fi ;;
+store)
+	request=$(cat)
+	key=$(echo "$request" | sed 's/^{"key":"\([^"]*\)".*/\1/' | tr '/' '_')
+	echo "$request" | sed 's/^{"key":"[^"]*","secret":"\(.*\)"}$/\1/' | sed 's/\\"/"/g' > "$dir/$key" ;;
+erase)
+	key=$(cat | tr '/' '_')
+	rm -f "$dir/$key" ;;
+list)
+	printf '['; sep=''
+	for f in $(ls "$dir"); do printf '%s"%s"' "$sep" "$(echo "$f" | tr '_' '/')"; sep=','; done
+	printf ']' ;;
+esac
+`
+
+func TestHelperStore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test credential helper is a shell script")
+	}
+	dir := t.TempDir()
+	helper := filepath.Join(dir, CredentialHelperPrefix+"test")
+	assert.Nil(t, ioutil.WriteFile(helper, []byte(helperScript), 0700))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	store := NewHelperStore("test")
+	assert.Nil(t, store.Load())
+	assert.False(t, store.HasAPIM("dev"))
+	assert.Nil(t, store.SetAPIMCredentials("dev", "admin", "admin", "id", "secret", ""))
+	assert.Nil(t, store.SetDefaultAppKeys("dev", "key", "key-secret"))
+	assert.Nil(t, store.SetAPIMToken("dev", AccessToken{AccessToken: "token", ExpiresAt: 1}))
+
+	reloaded := NewHelperStore("test")
+	assert.Nil(t, reloaded.Load())
+	credential, err := reloaded.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", credential.ClientSecret)
+	exported, err := reloaded.export()
+	assert.Nil(t, err)
+	assert.Equal(t, "key", exported.Environments["dev"].DefaultApp.ConsumerKey)
+	assert.Equal(t, "token", exported.Environments["dev"].APIMToken.AccessToken)
+
+	assert.Nil(t, reloaded.EraseAPIM("dev"))
+	assert.False(t, reloaded.HasAPIM("dev"))
+	files, _ := ioutil.ReadDir(filepath.Join(dir, "secrets"))
+	assert.Equal(t, 0, len(files), "Erasing APIM credentials should erase the access token and default app keys")
+
+	err = NewHelperStore("missing").Load()
+	assert.True(t, err != nil && strings.Contains(err.Error(), "not found"))
+}
+
+func TestJsonStoreCachesAPIMToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	store := NewJsonStore(path)
+	assert.Nil(t, store.Load())
+	assert.Error(t, store.SetAPIMToken("dev", AccessToken{AccessToken: "token"}), "Token requires a login")
+	assert.Nil(t, store.SetAPIMCredentials("dev", "admin", "admin", "id", "secret", ""))
+	assert.Nil(t, store.SetMICredentials("dev", "mi", "mi", "mi-token"))
+	token := AccessToken{AccessToken: "token", RefreshToken: "refresh", Scopes: "apim:api_view", ExpiresAt: 100}
+	assert.Nil(t, store.SetAPIMToken("dev", token))
+
+	content, _ := ioutil.ReadFile(path)
+	assert.NotContains(t, string(content), `"token"`)
+	reloaded := NewJsonStore(path)
+	assert.Nil(t, reloaded.Load())
+	cached, err := reloaded.GetAPIMToken("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, token, cached)
+
+	assert.Nil(t, reloaded.SetAPIMCredentials("dev", "other", "other", "id", "secret", ""))
+	cached, _ = reloaded.GetAPIMToken("dev")
+	assert.Equal(t, AccessToken{}, cached, "Login should discard the token of the previous user")
+
+	assert.Nil(t, reloaded.SetAPIMToken("dev", token))
+	assert.Nil(t, reloaded.EraseAPIM("dev"))
+	assert.True(t, reloaded.HasMI("dev"))
+	cached, _ = reloaded.GetAPIMToken("dev")
+	assert.Equal(t, AccessToken{}, cached, "Logout should remove the token")
+}
+
+func TestAccessTokenIsUsable(t *testing.T) {
+	token := NewAccessToken(map[string]string{"access_token": "token", "refresh_token": "refresh",
+		"expires_in": "3600"})
+	assert.True(t, token.isUsable())
+	assert.Equal(t, "refresh", token.RefreshToken)
+
+	token.ExpiresAt = time.Now().Add(30 * time.Second).Unix()
+	assert.False(t, token.isUsable(), "Token about to expire should not be used")
+
+	token = NewAccessToken(map[string]string{"access_token": "token", "expires_in": "3600"})
+	token.Scopes = "apim:api_view"
+	assert.False(t, token.isUsable(), "Token requested with other scopes should not be used")
+
+	assert.False(t, NewAccessToken(map[string]string{"access_token": "token"}).isUsable(),
+		"Token without an expiry should not be cached")
+}
+
+func TestGetOAuthTokensRefreshesCachedToken(t *testing.T) {
+	var grants []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		grants = append(grants, r.PostForm.Get("grant_type"))
+		if r.PostForm.Get("refresh_token") == "revoked" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"new-token","refresh_token":"new-refresh","expires_in":3600}`))
+	}))
+	defer server.Close()
+	password := Credential{Username: "admin", Password: "admin", ClientId: "id", ClientSecret: "secret"}
+
+	tokens, err := getOAuthTokens(password, "dev", "refresh", server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "new-token", tokens["access_token"])
+	assert.Equal(t, []string{utils.GrantTypeRefreshToken}, grants)
+
+	grants = nil
+	_, err = getOAuthTokens(password, "dev", "revoked", server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{utils.GrantTypeRefreshToken, utils.GrantTypePassword}, grants,
+		"Password grant should be used if the refresh token is revoked")
+
+	grants = nil
+	deviceCode := Credential{ClientId: "id", GrantType: utils.GrantTypeDeviceCode, RefreshToken: "revoked"}
+	_, err = getOAuthTokens(deviceCode, "dev", "", server.URL)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "login again")
+	}
+	assert.Equal(t, []string{utils.GrantTypeRefreshToken}, grants)
+}
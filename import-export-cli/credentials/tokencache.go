@@ -0,0 +1,114 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// accessTokenExpirySkew is the time before the expiry of a cached access token from which it is no longer used, so
+// that the token does not expire while a command is running
+const accessTokenExpirySkew = 60 * time.Second
+
+// AccessToken is an access token of apim cached in the credential store, so that it is reused by the commands until
+// it is about to expire
+type AccessToken struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	// Scopes requested for the token. A token requested with different scopes is not reused.
+	Scopes string `json:"scopes"`
+	// ExpiresAt is the unix time in seconds at which the access token expires
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// accessTokens caches the access token of each environment for the lifetime of the process, since the commands
+// that work on several artifacts get a token for each of them
+var accessTokens = make(map[string]AccessToken)
+var accessTokensLock sync.Mutex
+
+// NewAccessToken creates an access token to be cached from the response of the token endpoint
+func NewAccessToken(tokens map[string]string) AccessToken {
+	token := AccessToken{
+		AccessToken:  tokens["access_token"],
+		RefreshToken: tokens["refresh_token"],
+		Scopes:       utils.OAuthTokenScopes,
+	}
+	if expiresIn, err := strconv.ParseInt(tokens["expires_in"], 10, 64); err == nil && expiresIn > 0 {
+		token.ExpiresAt = time.Now().Unix() + expiresIn
+	}
+	return token
+}
+
+// isUsable returns true if the access token can be used without getting a new one
+func (t AccessToken) isUsable() bool {
+	return t.AccessToken != "" && t.Scopes == utils.OAuthTokenScopes &&
+		time.Now().Add(accessTokenExpirySkew).Unix() < t.ExpiresAt
+}
+
+// GetCachedAccessToken returns the cached access token of apim for an environment, or a blank token if there is none.
+// The token could have expired.
+func GetCachedAccessToken(env string) AccessToken {
+	accessTokensLock.Lock()
+	defer accessTokensLock.Unlock()
+	return getCachedAccessToken(env)
+}
+
+// getCachedAccessToken returns the cached access token of an environment. The caller must hold accessTokensLock.
+func getCachedAccessToken(env string) AccessToken {
+	if token, ok := accessTokens[env]; ok {
+		return token
+	}
+	store, err := GetDefaultCredentialStore()
+	if err != nil {
+		utils.Logln(utils.LogPrefixWarning+"Unable to read the cached access token of", env, ":", err)
+		return AccessToken{}
+	}
+	token, err := store.GetAPIMToken(env)
+	if err != nil {
+		utils.Logln(utils.LogPrefixWarning+"Unable to read the cached access token of", env, ":", err)
+		return AccessToken{}
+	}
+	accessTokens[env] = token
+	return token
+}
+
+// cacheAccessToken keeps an access token of an environment for the next commands. The caller must hold
+// accessTokensLock. The command does not fail if the token cannot be saved, since it only has to get a new token.
+func cacheAccessToken(env string, token AccessToken) {
+	accessTokens[env] = token
+	store, err := GetDefaultCredentialStore()
+	if err == nil {
+		err = store.SetAPIMToken(env, token)
+	}
+	if err != nil {
+		utils.Logln(utils.LogPrefixWarning+"Unable to cache the access token of", env, ":", err)
+	}
+}
+
+// ForgetCachedAccessToken removes the access token of an environment cached by this process. The token in the
+// credential store is removed with the apim credentials.
+func ForgetCachedAccessToken(env string) {
+	accessTokensLock.Lock()
+	defer accessTokensLock.Unlock()
+	delete(accessTokens, env)
+}
@@ -0,0 +1,88 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONFormatKey is the identifier used for machine readable json output
+const JSONFormatKey = "json"
+
+// Statuses of a command result
+const (
+	ResultStatusSuccess = "success"
+	ResultStatusFailure = "failure"
+)
+
+// CommandResult is the machine readable outcome of a command that changes an environment
+type CommandResult struct {
+	Command  string          `json:"command"`
+	Status   string          `json:"status"`
+	Action   string          `json:"action,omitempty"`
+	Artifact *ResultArtifact `json:"artifact,omitempty"`
+	// ID is the UUID of the artifact in the environment, if known
+	ID       string       `json:"id,omitempty"`
+	Revision string       `json:"revision,omitempty"`
+	Keys     *ResultKeys  `json:"keys,omitempty"`
+	Warnings []string     `json:"warnings,omitempty"`
+	Error    *ResultError `json:"error,omitempty"`
+	ExitCode int          `json:"exitCode"`
+}
+
+// ResultArtifact identifies the artifact a command acted on
+type ResultArtifact struct {
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Provider    string `json:"provider,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	File        string `json:"file,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+// ResultKeys are the keys of an application generated or regenerated by a command
+type ResultKeys struct {
+	ConsumerKey    string `json:"consumerKey"`
+	ConsumerSecret string `json:"consumerSecret"`
+	KeyType        string `json:"keyType,omitempty"`
+	KeyManager     string `json:"keyManager,omitempty"`
+}
+
+// ResultError describes the failure of a command. The response fields are filled from the error body returned by
+// API Manager when it is available.
+type ResultError struct {
+	Message     string `json:"message"`
+	Reason      string `json:"reason,omitempty"`
+	HTTPStatus  int    `json:"httpStatus,omitempty"`
+	Code        int    `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	MoreInfo    string `json:"moreInfo,omitempty"`
+}
+
+// Write writes the result to the output as an indented json object
+func (r *CommandResult) Write(output io.Writer) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = output.Write(append(content, '\n'))
+	return err
+}
@@ -314,9 +314,9 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 	// Deleting Application projects
 	applicationProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApplication]
 	if len(applicationProjectsToDelete) != 0 {
-		fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjectsToDelete)) + ") ...")
+		fmt.Fprintln(utils.Stdout, "\nApplications ("+strconv.Itoa(len(applicationProjectsToDelete))+") ...")
 		for i, projectParam := range applicationProjectsToDelete {
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
+			fmt.Fprintln(utils.Stdout, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
 			appInfo, _, err := impl.GetApplicationDefinition(projectParam.AbsolutePath)
 			if handleIfError(err, failedProjects, projectParam) {
 				continue
@@ -333,9 +333,9 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 	// Deleting API Product projects
 	apiProductProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApiProduct]
 	if len(apiProductProjectsToDelete) != 0 {
-		fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjectsToDelete)) + ") ...")
+		fmt.Fprintln(utils.Stdout, "\nAPI Products ("+strconv.Itoa(len(apiProductProjectsToDelete))+") ...")
 		for i, projectParam := range apiProductProjectsToDelete {
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
+			fmt.Fprintln(utils.Stdout, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
 			apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParam.AbsolutePath)
 			if handleIfError(err, failedProjects, projectParam) {
 				continue
@@ -351,9 +351,9 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 	// Deleting API projects
 	apiProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApi]
 	if len(apiProjectsToDelete) != 0 {
-		fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjectsToDelete)) + ") ...")
+		fmt.Fprintln(utils.Stdout, "\nAPIs ("+strconv.Itoa(len(apiProjectsToDelete))+") ...")
 		for i, projectParam := range apiProjectsToDelete {
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
+			fmt.Fprintln(utils.Stdout, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
 			apiInfo, _, err := impl.GetAPIDefinition(projectParam.AbsolutePath)
 			if handleIfError(err, failedProjects, projectParam) {
 				continue
@@ -372,7 +372,7 @@ func deployProjectDeletions(accessToken, environment string, deletedProjectsPerT
 // Logs the error and appends the failed project given from projectParam into the failedProjects map.
 func handleIfError(err error, failedProjects map[string][]*params.ProjectParams, projectParam *params.ProjectParams) bool {
 	if err != nil {
-		fmt.Println("Error... ", err)
+		fmt.Fprintln(utils.Stdout, "Error... ", err)
 		failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
 	}
 	return err != nil
@@ -398,11 +398,11 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 	updatedProjectsPerType map[string][]*params.ProjectParams) (bool, map[string][]*params.ProjectParams,
 	map[string][]*params.ProjectParams) {
 	if totalProjectsToUpdate == 0 {
-		fmt.Println("Everything is up-to-date")
+		fmt.Fprintln(utils.Stdout, "Everything is up-to-date")
 		return false, nil, nil
 	}
 
-	fmt.Println("Deploying Projects (" + strconv.Itoa(totalProjectsToUpdate) + ")...")
+	fmt.Fprintln(utils.Stdout, "Deploying Projects ("+strconv.Itoa(totalProjectsToUpdate)+")...")
 
 	var failedProjects = make(map[string][]*params.ProjectParams)
 	var hasDeletedProjects bool
@@ -412,7 +412,7 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 	// deploying API projects
 	apiProjects := updatedProjectsPerType[utils.ProjectTypeApi]
 	if len(apiProjects) != 0 {
-		fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjects)) + ") ...")
+		fmt.Fprintln(utils.Stdout, "\nAPIs ("+strconv.Itoa(len(apiProjects))+") ...")
 		for i, projectParam := range apiProjects {
 			// if the project is a deleted one, we do it later. So keep it for now.
 			if projectParam.Deleted {
@@ -428,16 +428,16 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 				err := resolveProjectParamsMetaDataDeployConfig(&projectParam.MetaData.DeployConfig,
 					projectDeploymentParamsDirLocation+string(os.PathSeparator)+utils.MetaFileAPI)
 				if err != nil {
-					fmt.Println("Error... ", err)
+					fmt.Fprintln(utils.Stdout, "Error... ", err)
 					failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
 				}
 			}
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
+			fmt.Fprintln(utils.Stdout, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
 			err := impl.ImportAPIToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
 				projectDeploymentParamsDirLocation, importParams.Update, importParams.PreserveProvider, false, importParams.RotateRevision, false, false, "")
 			if err != nil {
-				fmt.Println("Error... ", err)
+				fmt.Fprintln(utils.Stdout, "Error... ", err)
 				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
 			}
 		}
@@ -446,7 +446,7 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 	// deploying API product projects
 	apiProductProjects := updatedProjectsPerType[utils.ProjectTypeApiProduct]
 	if len(apiProductProjects) != 0 {
-		fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjects)) + ") ...")
+		fmt.Fprintln(utils.Stdout, "\nAPI Products ("+strconv.Itoa(len(apiProductProjects))+") ...")
 		for i, projectParam := range apiProductProjects {
 			// if the project is a deleted one, we do it later. So keep it for now.
 			if projectParam.Deleted {
@@ -462,17 +462,17 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 				err := resolveProjectParamsMetaDataDeployConfig(&projectParam.MetaData.DeployConfig,
 					projectDeploymentParamsDirLocation+string(os.PathSeparator)+utils.MetaFileAPIProduct)
 				if err != nil {
-					fmt.Println("Error... ", err)
+					fmt.Fprintln(utils.Stdout, "Error... ", err)
 					failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
 				}
 			}
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
+			fmt.Fprintln(utils.Stdout, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
 			err := impl.ImportAPIProductToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
 				projectDeploymentParamsDirLocation, importParams.ImportAPIs, importParams.UpdateAPIs, importParams.UpdateAPIProduct,
 				importParams.PreserveProvider, false, importParams.RotateRevision, false)
 			if err != nil {
-				fmt.Println("\terror... ", err)
+				fmt.Fprintln(utils.Stdout, "\terror... ", err)
 				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
 			}
 		}
@@ -481,7 +481,7 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 	// deploying Application projects
 	applicationProjects := updatedProjectsPerType[utils.ProjectTypeApplication]
 	if len(applicationProjects) != 0 {
-		fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjects)) + ") ...")
+		fmt.Fprintln(utils.Stdout, "\nApplications ("+strconv.Itoa(len(applicationProjects))+") ...")
 		for i, projectParam := range applicationProjects {
 			// if the project is a deleted one, we do it later. So keep it for now.
 			if projectParam.Deleted {
@@ -490,11 +490,11 @@ func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environm
 				continue
 			}
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
+			fmt.Fprintln(utils.Stdout, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
 			_, err := impl.ImportApplicationToEnv(accessToken, environment, projectParam.AbsolutePath, projectParam.MetaData.Owner,
 				importParams.Update, importParams.PreserveOwner, importParams.SkipSubscriptions, importParams.SkipKeys, false)
 			if err != nil {
-				fmt.Println("\terror... ", err)
+				fmt.Fprintln(utils.Stdout, "\terror... ", err)
 				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
 			}
 		}
@@ -564,7 +564,7 @@ func updateVCSConfig(repoId, environment string, failedProjects map[string][]*pa
 // projectParam is the project to be deleted
 // deletedProjectsPerType is the map of project type -> projects which are keeping the projects to delete
 func handleProjectDeletion(i int, projectParam *params.ProjectParams, deletedProjectsPerType map[string][]*params.ProjectParams) {
-	fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ") awaiting deletion..")
+	fmt.Fprintln(utils.Stdout, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+") awaiting deletion..")
 	if deletedProjectsPerType[projectParam.Type] == nil {
 		deletedProjectsPerType[projectParam.Type] = []*params.ProjectParams{}
 	}
@@ -619,7 +619,7 @@ func DeployChangedFiles(accessToken, environment string) map[string][]*params.Pr
 		lastSuccessfulRev := envVCSConfig.LastSuccessfulRev[0]
 		tmpBranchName := "tmp-" + lastSuccessfulRev[0:8]
 
-		fmt.Println("\nDeleting projects ..")
+		fmt.Fprintln(utils.Stdout, "\nDeleting projects ..")
 		checkoutNewBranchFromRevision(tmpBranchName, lastSuccessfulRev)
 		failedProjects = deployProjectDeletions(accessToken, environment, deletedProjectsPerType, failedProjects)
 		checkoutBranch(currentBranch)
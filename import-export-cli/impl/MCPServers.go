@@ -53,7 +53,7 @@ func GetMCPServerId(accessToken, environment, mcpServerName, mcpServerVersion, m
 		err = json.Unmarshal(data, &mcpServerData)
 		if mcpServerData.Count != 0 {
 			mcpServerId := mcpServerData.List[0].ID
-			fmt.Printf("MCP Server ID: %s\n", mcpServerId)
+			fmt.Fprintf(utils.Stdout, "MCP Server ID: %s\n", mcpServerId)
 			return mcpServerId, err
 		}
 		if mcpServerProvider != "" {
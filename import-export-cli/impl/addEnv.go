@@ -19,6 +19,7 @@
 package impl
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 
@@ -49,7 +50,7 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 		if envEndpoints.PublisherEndpoint != "" && !isDefaultTokenEndpointSet {
 			envEndpoints.TokenEndpoint = utils.GetTokenEndPointFromPublisherEndpoint(envEndpoints.PublisherEndpoint)
 		}
-		fmt.Printf("Default token endpoint '%s' is added as the token endpoint \n", envEndpoints.TokenEndpoint)
+		fmt.Fprintf(utils.Stdout, "Default token endpoint '%s' is added as the token endpoint \n", envEndpoints.TokenEndpoint)
 	}
 
 	if envEndpoints.ApiManagerEndpoint == "" {
@@ -68,6 +69,10 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
         }
 	}
 
+	if err := validateEnvNetworkSettings(envEndpoints); err != nil {
+		return err
+	}
+
 	if utils.EnvExistsInMainConfigFile(envName, mainConfigFilePath) {
 		// environment already exists
 		return errors.New("Environment '" + envName + "' already exists in " + mainConfigFilePath)
@@ -76,7 +81,11 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 	mainConfig := utils.GetMainConfigFromFile(mainConfigFilePath)
 
 	var validatedEnvEndpoints = utils.EnvEndpoints{
-		TokenEndpoint: envEndpoints.TokenEndpoint,
+		TokenEndpoint:     envEndpoints.TokenEndpoint,
+		Proxy:             envEndpoints.Proxy,
+		NoProxy:           envEndpoints.NoProxy,
+		ClientCertificate: envEndpoints.ClientCertificate,
+		ClientKey:         envEndpoints.ClientKey,
 	}
 
 	if envEndpoints.ApiManagerEndpoint != "" {
@@ -118,7 +127,7 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 	mainConfig.Environments[envName] = validatedEnvEndpoints
 	utils.WriteConfigFile(mainConfig, mainConfigFilePath)
 
-	fmt.Printf("Successfully added environment '%s'\n", envName)
+	fmt.Fprintf(utils.Stdout, "Successfully added environment '%s'\n", envName)
 
 	return nil
 }
@@ -148,7 +157,26 @@ func AddMIEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePa
 	mainConfig.Environments[envName] = validatedEnvEndpoints
 	utils.WriteConfigFile(mainConfig, mainConfigFilePath)
 
-	fmt.Printf("Successfully added environment '%s'\n", envName)
+	fmt.Fprintf(utils.Stdout, "Successfully added environment '%s'\n", envName)
+
+	return nil
+}
 
+// validateEnvNetworkSettings validates the proxy and the client certificate of an environment
+func validateEnvNetworkSettings(envEndpoints *utils.EnvEndpoints) error {
+	if envEndpoints.Proxy != "" && !utils.IsValidUrl(envEndpoints.Proxy) {
+		return errors.New("Invalid proxy " + envEndpoints.Proxy)
+	}
+	if envEndpoints.NoProxy != "" && envEndpoints.Proxy == "" {
+		return errors.New("--no-proxy requires --proxy")
+	}
+	if (envEndpoints.ClientCertificate == "") != (envEndpoints.ClientKey == "") {
+		return errors.New("Both the client certificate and its key are required for mutual TLS")
+	}
+	if envEndpoints.ClientCertificate != "" {
+		if _, err := tls.LoadX509KeyPair(envEndpoints.ClientCertificate, envEndpoints.ClientKey); err != nil {
+			return errors.New("Invalid client certificate: " + err.Error())
+		}
+	}
 	return nil
 }
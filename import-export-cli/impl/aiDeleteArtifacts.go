@@ -56,7 +56,7 @@ func AIDeleteAPIs(credential credentials.Credential, CmdPurgeEnvironment, aiToke
 		Endpoint = utils.GetAIServiceEndpointOfEnv(CmdPurgeEnvironment, utils.MainConfigFilePath)
 	}
 
-	fmt.Println("Removing existing APIs and API Products from vector database for tenant:", tenant)
+	fmt.Fprintln(utils.Stdout, "Removing existing APIs and API Products from vector database for tenant:", tenant)
 
 	headers["TENANT-DOMAIN"] = tenant
 	headers["User-Agent"] = "WSO2-API-Controller"
@@ -68,14 +68,14 @@ func AIDeleteAPIs(credential credentials.Credential, CmdPurgeEnvironment, aiToke
 	for attempt := 1; attempt <= 2; attempt++ {
 		resp, deleteErr = utils.InvokeDELETERequest(Endpoint+"/ai/spec-populator/bulk-remove", headers)
 		if deleteErr != nil {
-			fmt.Printf("Error removing existing APIs and API Products (attempt %d): %v\n", attempt, deleteErr)
+			fmt.Fprintf(utils.Stdout, "Error removing existing APIs and API Products (attempt %d): %v\n", attempt, deleteErr)
 			continue
 		}
 
 		if resp.StatusCode() != 200 {
-			fmt.Printf("Removing existing APIs and API Products failed with status %d %s (attempt %d)\n", resp.StatusCode(), resp.Body(), attempt)
+			fmt.Fprintf(utils.Stdout, "Removing existing APIs and API Products failed with status %d %s (attempt %d)\n", resp.StatusCode(), resp.Body(), attempt)
 			if attempt == 2 {
-				fmt.Println("Removing existing APIs and API Products failed.")
+				fmt.Fprintln(utils.Stdout, "Removing existing APIs and API Products failed.")
 				os.Exit(1)
 			}
 			continue
@@ -90,7 +90,7 @@ func AIDeleteAPIs(credential credentials.Credential, CmdPurgeEnvironment, aiToke
 			continue
 		}
 
-		fmt.Printf("Removed %d APIs and API Products successfully from vector database for tenant: %s (attempt %d)\n", jsonResp["message"]["delete_count"], tenant, attempt)
+		fmt.Fprintf(utils.Stdout, "Removed %d APIs and API Products successfully from vector database for tenant: %s (attempt %d)\n", jsonResp["message"]["delete_count"], tenant, attempt)
 		return
 	}
 
@@ -19,7 +19,7 @@ var apiProducts []utils.APIProduct
 
 func AddAPIProductsToQueue(accessToken string, apiListQueue chan<- []map[string]interface{}) {
 	if count == 0 {
-		fmt.Println("No API Products available to be exported..!")
+		fmt.Fprintln(utils.Stdout, "No API Products available to be exported..!")
 		return
 	}
 	for count > 0 {
@@ -84,7 +84,7 @@ func GetAPIPayload(apiOrProduct interface{}, accessToken, cmdUploadEnvironment s
 		return apiPayload
 	}
 
-	fmt.Println("Error exporting API: " + name + " Status: " + resp.Status())
+	fmt.Fprintln(utils.Stdout, "Error exporting API: "+name+" Status: "+resp.Status())
 	return nil
 }
 
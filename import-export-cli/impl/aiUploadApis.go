@@ -69,7 +69,7 @@ func AIUploadAPIs(credential credentials.Credential, cmdUploadEnvironment, aiTok
 
 	wg.Wait()
 
-	fmt.Printf("\nTotal number of public APIs present in the API Manager: %d\nTotal number of APIs successfully uploaded: %d\n\n", totalAPIs, uploadedAPIs)
+	fmt.Fprintf(utils.Stdout, "\nTotal number of public APIs present in the API Manager: %d\nTotal number of APIs successfully uploaded: %d\n\n", totalAPIs, uploadedAPIs)
 }
 
 func ProduceAPIPayloads(accessToken string, apiListQueue chan<- []map[string]interface{}) {
@@ -77,7 +77,7 @@ func ProduceAPIPayloads(accessToken string, apiListQueue chan<- []map[string]int
 	startingApiIndexFromList = 0
 	if UploadAll {
 		count, apis = getAPIList(Credential, CmdUploadEnvironment, "")
-		ExportAPIs(Credential, "", CmdUploadEnvironment, Tenant, "json", "", "", true, true, false, true, false)
+		ExportAPIs(Credential, "", CmdUploadEnvironment, Tenant, "json", "", "", true, true, false, true, false, 1)
 		apiListOffset = 0
 		count, apiProducts, _ = GetAPIProductListFromEnv(accessToken, CmdUploadEnvironment, "", strconv.Itoa(utils.MaxAPIsToExportOnce)+"&offset="+strconv.Itoa(apiListOffset))
 		AddAPIProductsToQueue(accessToken, apiListQueue)
@@ -86,7 +86,7 @@ func ProduceAPIPayloads(accessToken string, apiListQueue chan<- []map[string]int
 		AddAPIProductsToQueue(accessToken, apiListQueue)
 	} else {
 		count, apis = getAPIList(Credential, CmdUploadEnvironment, "")
-		ExportAPIs(Credential, "", CmdUploadEnvironment, Tenant, "json", "", "", true, true, false, true, false)
+		ExportAPIs(Credential, "", CmdUploadEnvironment, Tenant, "json", "", "", true, true, false, true, false, 1)
 	}
 	close(apiListQueue)
 }
@@ -100,7 +100,7 @@ func ConsumeAPIPayloads(headers map[string]string, apiListQueue <-chan []map[str
 }
 
 func InvokePOSTRequest(headers map[string]string, apiList []map[string]interface{}) {
-	fmt.Printf("Uploading %d APIs for tenant: %s\n", len(apiList), apiList[0]["tenant_domain"])
+	fmt.Fprintf(utils.Stdout, "Uploading %d APIs for tenant: %s\n", len(apiList), apiList[0]["tenant_domain"])
 	payload, err := json.Marshal(map[string]interface{}{"apis": apiList})
 	if err != nil {
 		utils.HandleErrorAndContinue("Error in marshalling payload:", err)
@@ -113,12 +113,12 @@ func InvokePOSTRequest(headers map[string]string, apiList []map[string]interface
 	for attempt := 1; attempt <= 2; attempt++ {
 		resp, uploadErr = utils.InvokePOSTRequest(Endpoint+"/ai/spec-populator/bulk-upload", headers, payload)
 		if uploadErr != nil {
-			fmt.Printf("API upload failed (attempt %d). Reason: %v\n", attempt, uploadErr)
+			fmt.Fprintf(utils.Stdout, "API upload failed (attempt %d). Reason: %v\n", attempt, uploadErr)
 			continue
 		}
 
 		if resp.StatusCode() != 200 {
-			fmt.Printf("Failed to upload %d APIs for tenant %s with status %d %s (attempt %d).\n", len(apiList), apiList[0]["tenant_domain"], resp.StatusCode(), resp.Body(), attempt)
+			fmt.Fprintf(utils.Stdout, "Failed to upload %d APIs for tenant %s with status %d %s (attempt %d).\n", len(apiList), apiList[0]["tenant_domain"], resp.StatusCode(), resp.Body(), attempt)
 			continue
 		}
 
@@ -131,7 +131,7 @@ func InvokePOSTRequest(headers map[string]string, apiList []map[string]interface
 			continue
 		}
 
-		fmt.Printf("%d APIs uploaded successfully for tenant: %s (attempt %d)\n", len(apiList), apiList[0]["tenant_domain"], attempt)
+		fmt.Fprintf(utils.Stdout, "%d APIs uploaded successfully for tenant: %s (attempt %d)\n", len(apiList), apiList[0]["tenant_domain"], attempt)
 		atomic.AddInt32(&uploadedAPIs, jsonResp["message"]["upsert_count"])
 		break
 	}
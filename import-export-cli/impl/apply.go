@@ -0,0 +1,825 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Actions that can be taken on a project while applying a directory to an environment
+const (
+	ApplyActionCreate    = "create"
+	ApplyActionUpdate    = "update"
+	ApplyActionUnchanged = "unchanged"
+	ApplyActionPrune     = "prune"
+	ApplyActionSkip      = "skip"
+)
+
+const (
+	applyTypeHeader    = "TYPE"
+	applyNameHeader    = "NAME"
+	applyVersionHeader = "VERSION"
+	applyActionHeader  = "ACTION"
+	applyPathHeader    = "PATH"
+
+	defaultApplyPlanTableFormat = "table {{.Type}}\t{{.Name}}\t{{.Version}}\t{{.Action}}\t{{.Path}}"
+
+	applyArtifactTypeThrottlingPolicy = "throttling policy"
+	applyArtifactTypeOperationPolicy  = "operation_policy_specification"
+)
+
+// applyProjectOrder is the order in which the project types are applied to an environment. Rate limiting policies
+// and operation policies are referred by APIs, APIs are referred by API Products and API Products are subscribed by
+// Applications. Pruning happens in the reverse order.
+var applyProjectOrder = []string{
+	utils.ProjectTypePolicy,
+	utils.ProjectTypeAPIPolicy,
+	utils.ProjectTypeApi,
+	utils.ProjectTypeApiProduct,
+	utils.ProjectTypeApplication,
+}
+
+// ApplyProject holds the details of a project discovered while walking a directory to apply, or of an artifact in
+// the environment that needs to be pruned
+type ApplyProject struct {
+	Type         string
+	Name         string
+	Version      string
+	Owner        string
+	PolicyType   string
+	AbsolutePath string
+	RelativePath string
+	Action       string
+	MetaData     *utils.MetaData
+	// ID is the id of an artifact in the environment that needs to be pruned
+	ID string
+	// Reason is the reason an artifact that is not available in the directory is skipped instead of being pruned
+	Reason string
+	// Error is the error occurred while applying the project
+	Error error
+}
+
+// ApplyOptions holds the user provided options of an apply operation
+type ApplyOptions struct {
+	ParamsDir string
+	Prune     bool
+	DryRun    bool
+	// PreserveProvider is nil if --preserve-provider is not given, in which case the preserveProvider of the meta
+	// file of each project is used
+	PreserveProvider *bool
+	RotateRevision   bool
+	SkipCleanup      bool
+}
+
+// applyArtifactHeader is used to identify the type of standalone policy artifacts
+type applyArtifactHeader struct {
+	Type    string        `yaml:"type"`
+	Subtype string        `yaml:"subtype"`
+	Data    yaml.MapSlice `yaml:"data"`
+}
+
+// DiscoverApplyProjects walks rootDir and returns the API, API Product, Application, operation policy and rate
+// limiting policy projects found in it, sorted in the order they should be applied
+func DiscoverApplyProjects(rootDir string) ([]*ApplyProject, error) {
+	rootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	var projects []*ApplyProject
+	err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, _ := filepath.Rel(rootDir, path)
+		if info.IsDir() {
+			// Skip hidden directories such as .git
+			if path != rootDir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			project, err := getApplyProjectOfDir(path)
+			if err != nil {
+				return err
+			}
+			if project == nil {
+				return nil
+			}
+			project.AbsolutePath = path
+			project.RelativePath = relativePath
+			projects = append(projects, project)
+			// Files inside a project (ie: APIs of an API Product) are not projects on their own
+			return filepath.SkipDir
+		}
+		project, err := getApplyProjectOfFile(path)
+		if err != nil {
+			return err
+		}
+		if project != nil {
+			project.AbsolutePath = path
+			project.RelativePath = relativePath
+			projects = append(projects, project)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortApplyProjects(projects)
+	return projects, nil
+}
+
+// getApplyProjectOfDir returns the project represented by the directory dirPath, or nil if it is not a project
+func getApplyProjectOfDir(dirPath string) (*ApplyProject, error) {
+	switch {
+	case isFileExistInDir(dirPath, utils.APIDefinitionFileYaml, utils.APIDefinitionFileJson):
+		apiDefinition, _, err := GetAPIDefinition(dirPath)
+		if err != nil {
+			return nil, errors.New("Error while reading API project " + dirPath + ": " + err.Error())
+		}
+		return &ApplyProject{
+			Type:     utils.ProjectTypeApi,
+			Name:     apiDefinition.Data.Name,
+			Version:  apiDefinition.Data.Version,
+			Owner:    apiDefinition.Data.Provider,
+			MetaData: loadApplyMetaData(filepath.Join(dirPath, utils.MetaFileAPI)),
+		}, nil
+	case isFileExistInDir(dirPath, utils.APIProductDefinitionFileYaml, utils.APIProductDefinitionFileJson):
+		apiProductDefinition, _, err := GetAPIProductDefinition(dirPath)
+		if err != nil {
+			return nil, errors.New("Error while reading API Product project " + dirPath + ": " + err.Error())
+		}
+		return &ApplyProject{
+			Type:     utils.ProjectTypeApiProduct,
+			Name:     apiProductDefinition.Data.Name,
+			Version:  apiProductDefinition.Data.Version,
+			Owner:    apiProductDefinition.Data.Provider,
+			MetaData: loadApplyMetaData(filepath.Join(dirPath, utils.MetaFileAPIProduct)),
+		}, nil
+	case isFileExistInDir(dirPath, utils.ApplicationDefinitionFileYaml, utils.ApplicationDefinitionFileJson):
+		appDefinition, _, err := GetApplicationDefinition(dirPath)
+		if err != nil {
+			return nil, errors.New("Error while reading Application project " + dirPath + ": " + err.Error())
+		}
+		return &ApplyProject{
+			Type:     utils.ProjectTypeApplication,
+			Name:     appDefinition.Data.Applicationinfo.Name,
+			Owner:    appDefinition.Data.Applicationinfo.Owner,
+			MetaData: loadApplyMetaData(filepath.Join(dirPath, utils.MetaFileApplication)),
+		}, nil
+	}
+
+	// An operation policy project is a directory containing a specification file with the same name as the directory
+	policyName := filepath.Base(dirPath)
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		header, err := readApplyArtifactHeader(filepath.Join(dirPath, policyName+ext))
+		if err != nil {
+			return nil, err
+		}
+		if header != nil && header.Type == applyArtifactTypeOperationPolicy {
+			return &ApplyProject{
+				Type:    utils.ProjectTypeAPIPolicy,
+				Name:    getMapSliceValue(header.Data, "name"),
+				Version: getMapSliceValue(header.Data, "version"),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// getApplyProjectOfFile returns the rate limiting policy represented by the file filePath, or nil if it is not one
+func getApplyProjectOfFile(filePath string) (*ApplyProject, error) {
+	header, err := readApplyArtifactHeader(filePath)
+	if err != nil || header == nil || header.Type != applyArtifactTypeThrottlingPolicy {
+		return nil, err
+	}
+	policyType := getCmdThrottlingPolicyType(header.Subtype)
+	if policyType == "" {
+		return nil, errors.New("Unsupported rate limiting policy type '" + header.Subtype + "' in " + filePath)
+	}
+	return &ApplyProject{
+		Type:       utils.ProjectTypePolicy,
+		Name:       getMapSliceValue(header.Data, "policyName"),
+		PolicyType: policyType,
+	}, nil
+}
+
+// readApplyArtifactHeader reads the type information of a yaml or json artifact file. Returns nil if the file does
+// not exist or is not a yaml or json file.
+func readApplyArtifactHeader(filePath string) (*applyArtifactHeader, error) {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		return nil, nil
+	}
+	if !utils.IsFileExist(filePath) {
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	header := &applyArtifactHeader{}
+	if err := yaml.Unmarshal(content, header); err != nil {
+		// Files that are not artifacts (ie: Swagger definitions) are not required to be valid
+		utils.Logln(utils.LogPrefixWarning+"Skipping unreadable file "+filePath+":", err)
+		return nil, nil
+	}
+	return header, nil
+}
+
+// getCmdThrottlingPolicyType maps the subtype of an exported rate limiting policy to the type used by the commands
+func getCmdThrottlingPolicyType(subtype string) string {
+	switch subtype {
+	case ExportPolicyTypeSubscription:
+		return CmdPolicyTypeSubscription
+	case ExportPolicyTypeApplication:
+		return CmdPolicyTypeApplication
+	case ExportPolicyTypeAdvanced:
+		return CmdPolicyTypeAdvanced
+	case ExportPolicyTypeCustom:
+		return CmdPolicyTypeCustom
+	}
+	return ""
+}
+
+// getThrottlingPolicyQueryType maps the type used by the commands to the type used in search queries
+func getThrottlingPolicyQueryType(policyType string) string {
+	switch policyType {
+	case CmdPolicyTypeSubscription:
+		return QueryPolicyTypeSubscription
+	case CmdPolicyTypeApplication:
+		return QueryPolicyTypeApplication
+	case CmdPolicyTypeAdvanced:
+		return QueryPolicyTypeAdvanced
+	case CmdPolicyTypeCustom:
+		return QueryCmdPolicyTypeCustom
+	}
+	return ""
+}
+
+func getMapSliceValue(mapSlice yaml.MapSlice, key string) string {
+	for _, item := range mapSlice {
+		if fmt.Sprint(item.Key) == key {
+			return fmt.Sprint(item.Value)
+		}
+	}
+	return ""
+}
+
+func isFileExistInDir(dirPath string, fileNames ...string) bool {
+	for _, fileName := range fileNames {
+		if utils.IsFileExist(filepath.Join(dirPath, fileName)) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadApplyMetaData loads the meta file of a project if it is available
+func loadApplyMetaData(metaFilePath string) *utils.MetaData {
+	if !utils.IsFileExist(metaFilePath) {
+		return &utils.MetaData{}
+	}
+	metaData, err := LoadMetaInfoFromFile(metaFilePath)
+	if err != nil {
+		utils.Logln(utils.LogPrefixWarning+"Unable to read "+metaFilePath+":", err)
+		return &utils.MetaData{}
+	}
+	return metaData
+}
+
+// sortApplyProjects sorts the projects by the order they need to be applied, keeping the walk order within a type
+func sortApplyProjects(projects []*ApplyProject) {
+	sort.SliceStable(projects, func(i, j int) bool {
+		return getApplyProjectOrder(projects[i].Type) < getApplyProjectOrder(projects[j].Type)
+	})
+}
+
+func getApplyProjectOrder(projectType string) int {
+	for i, t := range applyProjectOrder {
+		if t == projectType {
+			return i
+		}
+	}
+	return len(applyProjectOrder)
+}
+
+// PlanApply compares the projects with the artifacts available in the environment and sets the action to be taken
+// for each of them. Existing projects are compared with the artifacts exported from the environment, so that only the
+// changed ones are updated. When options.Prune is set, the artifacts in the environment that are not available in
+// projects are returned as well, in the order they should be removed.
+func PlanApply(accessToken, environment string, projects []*ApplyProject, options ApplyOptions) ([]*ApplyProject,
+	error) {
+	envThrottlingPolicies, err := getAllThrottlingPoliciesFromEnv(accessToken, environment)
+	if err != nil {
+		return nil, err
+	}
+	envAPIPolicies, err := getAllAPIPoliciesFromEnv(accessToken, environment)
+	if err != nil {
+		return nil, err
+	}
+	envAPIs, err := getAllAPIsFromEnv(accessToken, environment)
+	if err != nil {
+		return nil, err
+	}
+	envAPIProducts, err := getAllAPIProductsFromEnv(accessToken, environment)
+	if err != nil {
+		return nil, err
+	}
+	envApps, err := getAllApplicationsFromEnv(accessToken, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		exists := false
+		switch project.Type {
+		case utils.ProjectTypePolicy:
+			for _, policy := range envThrottlingPolicies {
+				if policy.PolicyName == project.Name && getEnvThrottlingPolicyType(policy) == project.PolicyType {
+					exists = true
+					break
+				}
+			}
+		case utils.ProjectTypeAPIPolicy:
+			for _, policy := range envAPIPolicies {
+				if policy.Name == project.Name && policy.Version == project.Version {
+					exists = true
+					break
+				}
+			}
+		case utils.ProjectTypeApi:
+			for _, api := range envAPIs {
+				if api.Name == project.Name && api.Version == project.Version {
+					exists = true
+					break
+				}
+			}
+		case utils.ProjectTypeApiProduct:
+			for _, apiProduct := range envAPIProducts {
+				if apiProduct.Name == project.Name && apiProduct.Version == project.Version {
+					exists = true
+					break
+				}
+			}
+		case utils.ProjectTypeApplication:
+			for _, app := range envApps {
+				if app.Name == project.Name && app.Owner == project.Owner {
+					exists = true
+					break
+				}
+			}
+		}
+		project.Action = getApplyAction(project.Type, exists)
+		if project.Action == ApplyActionUpdate && isApplyProjectUnchanged(accessToken, environment, project, options) {
+			project.Action = ApplyActionUnchanged
+		}
+	}
+
+	if !options.Prune {
+		return projects, nil
+	}
+
+	var pruned []*ApplyProject
+	for _, app := range envApps {
+		if app.Name == utils.DefaultCliApp || hasApplyApplication(projects, app.Name, app.Owner) {
+			continue
+		}
+		pruned = append(pruned, &ApplyProject{Type: utils.ProjectTypeApplication, ID: app.ID, Name: app.Name,
+			Owner: app.Owner, Action: ApplyActionPrune})
+	}
+	for _, apiProduct := range envAPIProducts {
+		if !hasApplyProject(projects, utils.ProjectTypeApiProduct, apiProduct.Name, apiProduct.Version) {
+			pruned = append(pruned, &ApplyProject{Type: utils.ProjectTypeApiProduct, ID: apiProduct.ID,
+				Name: apiProduct.Name, Version: apiProduct.Version, Owner: apiProduct.Provider, Action: ApplyActionPrune})
+		}
+	}
+	for _, api := range envAPIs {
+		if !hasApplyProject(projects, utils.ProjectTypeApi, api.Name, api.Version) {
+			pruned = append(pruned, &ApplyProject{Type: utils.ProjectTypeApi, ID: api.ID, Name: api.Name,
+				Version: api.Version, Owner: api.Provider, Action: ApplyActionPrune})
+		}
+	}
+	if err := skipPruningUsedAPIs(accessToken, environment, envAPIProducts, pruned); err != nil {
+		return nil, err
+	}
+	for _, policy := range envAPIPolicies {
+		// The environment does not mark the common operation policies shipped with API Manager, hence only the other
+		// versions of the policies in the directory are pruned, which leaves the shipped policies as they are
+		if hasApplyAPIPolicyName(projects, policy.Name) &&
+			!hasApplyProject(projects, utils.ProjectTypeAPIPolicy, policy.Name, policy.Version) {
+			pruned = append(pruned, &ApplyProject{Type: utils.ProjectTypeAPIPolicy, ID: policy.Id, Name: policy.Name,
+				Version: policy.Version, Action: ApplyActionPrune})
+		}
+	}
+	for _, policy := range envThrottlingPolicies {
+		policyType := getEnvThrottlingPolicyType(policy)
+		// Policies of unknown types are left as they are, since they cannot be deleted by type. The policies shipped
+		// with API Manager are never pruned.
+		if policyType == "" || isDefaultThrottlingPolicy(policy.PolicyName, policyType) ||
+			hasApplyThrottlingPolicy(projects, policy.PolicyName, policyType) {
+			continue
+		}
+		pruned = append(pruned, &ApplyProject{Type: utils.ProjectTypePolicy, ID: policy.Uuid, Name: policy.PolicyName,
+			PolicyType: policyType, Action: ApplyActionPrune})
+	}
+	return append(projects, pruned...), nil
+}
+
+// defaultThrottlingPolicies are the rate limiting policies shipped with API Manager by the type used by the commands
+var defaultThrottlingPolicies = map[string]map[string]bool{
+	CmdPolicyTypeSubscription: {"Bronze": true, "Silver": true, "Gold": true, "Unlimited": true,
+		"Unauthenticated": true, "AsyncBronze": true, "AsyncSilver": true, "AsyncGold": true,
+		"AsyncUnlimited": true, "AsyncWHBronze": true, "AsyncWHSilver": true, "AsyncWHGold": true,
+		"AsyncWHUnlimited": true, "DefaultSubscriptionless": true},
+	CmdPolicyTypeApplication: {"10PerMin": true, "20PerMin": true, "50PerMin": true, "Unlimited": true},
+	CmdPolicyTypeAdvanced:    {"10KPerMin": true, "20KPerMin": true, "50KPerMin": true, "Unlimited": true},
+}
+
+// getApplyAction returns the action to be taken on a project based on whether it already exists in the environment.
+// Operation policies cannot be overwritten, hence a new version needs to be added to change an existing one.
+func getApplyAction(projectType string, exists bool) string {
+	if !exists {
+		return ApplyActionCreate
+	}
+	if projectType == utils.ProjectTypeAPIPolicy {
+		return ApplyActionUnchanged
+	}
+	return ApplyActionUpdate
+}
+
+func hasApplyProject(projects []*ApplyProject, projectType, name, version string) bool {
+	for _, project := range projects {
+		if project.Type == projectType && project.Name == name && project.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func hasApplyAPIPolicyName(projects []*ApplyProject, name string) bool {
+	for _, project := range projects {
+		if project.Type == utils.ProjectTypeAPIPolicy && project.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// skipPruningUsedAPIs skips pruning the APIs that are used by the API Products kept in the environment, since
+// API Manager does not delete an API used by an API Product
+func skipPruningUsedAPIs(accessToken, environment string, envAPIProducts []utils.APIProduct, pruned []*ApplyProject) error {
+	prunedAPIs := make(map[string]*ApplyProject)
+	prunedAPIProducts := make(map[string]bool)
+	for _, project := range pruned {
+		if project.Type == utils.ProjectTypeApi {
+			prunedAPIs[project.ID] = project
+		} else if project.Type == utils.ProjectTypeApiProduct {
+			prunedAPIProducts[project.ID] = true
+		}
+	}
+	if len(prunedAPIs) == 0 {
+		return nil
+	}
+	for _, apiProduct := range envAPIProducts {
+		if prunedAPIProducts[apiProduct.ID] {
+			continue
+		}
+		apiIds, err := getAPIProductAPIIds(accessToken, environment, apiProduct.ID)
+		if err != nil {
+			return fmt.Errorf("unable to get the APIs of the API Product %s %s: %w", apiProduct.Name,
+				apiProduct.Version, err)
+		}
+		for _, apiId := range apiIds {
+			if project, ok := prunedAPIs[apiId]; ok && project.Action == ApplyActionPrune {
+				project.Action = ApplyActionSkip
+				project.Reason = "used by the API Product " + apiProduct.Name + " " + apiProduct.Version
+			}
+		}
+	}
+	return nil
+}
+
+// getAPIProductAPIIds returns the ids of the APIs used by an API Product of the environment
+func getAPIProductAPIIds(accessToken, environment, apiProductId string) ([]string, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(utils.GetApiProductListEndpointOfEnv(environment,
+		utils.MainConfigFilePath)+"/"+apiProductId, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, utils.NewHttpResponseError(resp)
+	}
+	var apiProduct struct {
+		APIs []struct {
+			APIId string `json:"apiId"`
+		} `json:"apis"`
+	}
+	if err := json.Unmarshal(resp.Body(), &apiProduct); err != nil {
+		return nil, err
+	}
+	var apiIds []string
+	for _, api := range apiProduct.APIs {
+		apiIds = append(apiIds, api.APIId)
+	}
+	return apiIds, nil
+}
+
+func hasApplyApplication(projects []*ApplyProject, name, owner string) bool {
+	for _, project := range projects {
+		if project.Type == utils.ProjectTypeApplication && project.Name == name && project.Owner == owner {
+			return true
+		}
+	}
+	return false
+}
+
+func hasApplyThrottlingPolicy(projects []*ApplyProject, name, policyType string) bool {
+	for _, project := range projects {
+		if project.Type == utils.ProjectTypePolicy && project.Name == name && project.PolicyType == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// getEnvThrottlingPolicyType maps the type of a rate limiting policy listed from the environment (ie: "Subscription
+// Policy") to the type used by the commands
+// isDefaultThrottlingPolicy checks whether a policy is one of the policies shipped with API Manager. The environment
+// does not mark them, since it reports every deployed policy as deployed, hence they are identified by name.
+func isDefaultThrottlingPolicy(name, policyType string) bool {
+	return defaultThrottlingPolicies[policyType][name]
+}
+
+func getEnvThrottlingPolicyType(policy utils.ThrottlingPolicyDetails) string {
+	return getCmdThrottlingPolicyType(strings.ToLower(policy.Type))
+}
+
+func getAllAPIsFromEnv(accessToken, environment string) ([]utils.API, error) {
+	var allAPIs []utils.API
+	for offset := 0; ; offset += utils.MaxAPIsToExportOnce {
+		_, apiList, err := GetAPIListFromEnv(accessToken, environment, "",
+			strconv.Itoa(utils.MaxAPIsToExportOnce)+"&offset="+strconv.Itoa(offset))
+		if err != nil {
+			return nil, err
+		}
+		allAPIs = append(allAPIs, apiList...)
+		if len(apiList) < utils.MaxAPIsToExportOnce {
+			return allAPIs, nil
+		}
+	}
+}
+
+func getAllAPIProductsFromEnv(accessToken, environment string) ([]utils.APIProduct, error) {
+	var allAPIProducts []utils.APIProduct
+	for offset := 0; ; offset += utils.MaxAPIsToExportOnce {
+		_, apiProductList, err := GetAPIProductListFromEnv(accessToken, environment, "",
+			strconv.Itoa(utils.MaxAPIsToExportOnce)+"&offset="+strconv.Itoa(offset))
+		if err != nil {
+			return nil, err
+		}
+		allAPIProducts = append(allAPIProducts, apiProductList...)
+		if len(apiProductList) < utils.MaxAPIsToExportOnce {
+			return allAPIProducts, nil
+		}
+	}
+}
+
+func getAllApplicationsFromEnv(accessToken, environment string) ([]utils.Application, error) {
+	var allApps []utils.Application
+	for offset := 0; ; offset += utils.MaxAppsToExportOnce {
+		_, appList, err := GetApplicationListFromEnv(accessToken, environment, "",
+			strconv.Itoa(utils.MaxAppsToExportOnce)+"&offset="+strconv.Itoa(offset))
+		if err != nil {
+			return nil, errors.New("Error while getting the Applications: " + err.Error())
+		}
+		allApps = append(allApps, appList...)
+		if len(appList) < utils.MaxAppsToExportOnce {
+			return allApps, nil
+		}
+	}
+}
+
+func getAllThrottlingPoliciesFromEnv(accessToken, environment string) ([]utils.ThrottlingPolicyDetails, error) {
+	resp, err := GetThrottlePolicyListFromEnv(accessToken, environment, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, errors.New("Request didn't respond 200 OK for getting Rate Limiting Policies. Status: " +
+			resp.Status())
+	}
+	var policyList utils.ThrottlingPoliciesDetailsList
+	if err := json.Unmarshal(resp.Body(), &policyList); err != nil {
+		return nil, err
+	}
+	return policyList.List, nil
+}
+
+func getAllAPIPoliciesFromEnv(accessToken, environment string) ([]utils.APIPolicy, error) {
+	resp, err := GetAPIPolicyListFromEnv(accessToken, environment, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, errors.New("Request didn't respond 200 OK for getting API Policies. Status: " + resp.Status())
+	}
+	var apiPolicyList utils.APIPoliciesList
+	if err := json.Unmarshal(resp.Body(), &apiPolicyList); err != nil {
+		return nil, err
+	}
+	return apiPolicyList.List, nil
+}
+
+// PrintApplyPlan prints the actions that will be taken on each project
+func PrintApplyPlan(projects []*ApplyProject) {
+	planContext := formatter.NewContext(utils.Stdout, defaultApplyPlanTableFormat)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, project := range projects {
+			if err := t.Execute(w, project); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	planTableHeaders := map[string]string{
+		"Type":    applyTypeHeader,
+		"Name":    applyNameHeader,
+		"Version": applyVersionHeader,
+		"Action":  applyActionHeader,
+		"Path":    applyPathHeader,
+	}
+	if err := planContext.Write(renderer, planTableHeaders); err != nil {
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
+	}
+	for _, project := range projects {
+		if project.Action == ApplyActionSkip {
+			fmt.Fprintln(utils.Stdout, "Not pruning the "+project.Type+" "+project.Name+" "+project.Version+
+				" as it is "+project.Reason)
+		}
+	}
+}
+
+// Path returns the relative path of a project for printing
+func (p ApplyProject) Path() string {
+	if p.RelativePath == "" {
+		return "-"
+	}
+	return p.RelativePath
+}
+
+// ApplyProjectsToEnv reconciles the projects in rootDir against the environment by creating, updating and
+// optionally pruning the artifacts. Returns the projects that failed to be applied.
+func ApplyProjectsToEnv(accessToken, environment, rootDir string, options ApplyOptions) ([]*ApplyProject, error) {
+	projects, err := DiscoverApplyProjects(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 && !options.Prune {
+		fmt.Fprintln(utils.Stdout, "No projects found in "+rootDir)
+		return nil, nil
+	}
+	projects, err = PlanApply(accessToken, environment, projects, options)
+	if err != nil {
+		return nil, err
+	}
+
+	PrintApplyPlan(projects)
+	if options.DryRun {
+		return nil, nil
+	}
+
+	var failedProjects []*ApplyProject
+	for i, project := range projects {
+		if project.Action == ApplyActionUnchanged {
+			continue
+		}
+		if project.Action == ApplyActionSkip {
+			utils.AddCommandWarning(project.Type + " " + project.Name + " " + project.Version + " is not pruned as it is " +
+				project.Reason)
+			continue
+		}
+		fmt.Fprintln(utils.Stdout, "\n"+strconv.Itoa(i+1)+": "+project.Action+" "+project.Type+" "+project.Name+
+			" "+project.Version)
+		if err := applyProject(accessToken, environment, project, options); err != nil {
+			fmt.Fprintln(utils.Stdout, "Error... ", err)
+			project.Error = err
+			failedProjects = append(failedProjects, project)
+		}
+	}
+	return failedProjects, nil
+}
+
+// applyProject takes the planned action on a single project
+func applyProject(accessToken, environment string, project *ApplyProject, options ApplyOptions) error {
+	if project.Action == ApplyActionPrune {
+		return pruneApplyProject(accessToken, environment, project)
+	}
+	update := project.Action == ApplyActionUpdate
+	switch project.Type {
+	case utils.ProjectTypePolicy:
+		return ImportThrottlingPolicyToEnv(accessToken, environment, project.AbsolutePath, update)
+	case utils.ProjectTypeAPIPolicy:
+		return ImportAPIPolicyToEnv(accessToken, environment, project.AbsolutePath)
+	case utils.ProjectTypeApi:
+		importParams := project.MetaData.DeployConfig.Import
+		return ImportAPIToEnv(accessToken, environment, project.AbsolutePath, getApplyParamsPath(project, options),
+			update, options.preserveProvider(importParams), options.SkipCleanup,
+			options.RotateRevision || importParams.RotateRevision, false, false, "")
+	case utils.ProjectTypeApiProduct:
+		importParams := project.MetaData.DeployConfig.Import
+		// Dependent APIs are applied as projects on their own, hence they are not imported with the API Product
+		return ImportAPIProductToEnv(accessToken, environment, project.AbsolutePath, getApplyParamsPath(project, options),
+			false, false, update, options.preserveProvider(importParams), options.SkipCleanup,
+			options.RotateRevision || importParams.RotateRevision, false)
+	case utils.ProjectTypeApplication:
+		importParams := project.MetaData.DeployConfig.Import
+		_, err := ImportApplicationToEnv(accessToken, environment, project.AbsolutePath, project.Owner, update,
+			importParams.PreserveOwner, importParams.SkipSubscriptions, importParams.SkipKeys, options.SkipCleanup)
+		return err
+	}
+	return errors.New("Unsupported project type " + project.Type)
+}
+
+// preserveProvider returns whether the provider of an API or an API Product is preserved. The --preserve-provider
+// flag overrides the preserveProvider of the meta file of the project, the same way as vcs deploy uses the meta file.
+func (options ApplyOptions) preserveProvider(importParams utils.ImportConfig) bool {
+	if options.PreserveProvider != nil {
+		return *options.PreserveProvider
+	}
+	return importParams.PreserveProvider
+}
+
+// pruneApplyProject deletes an artifact that is not available in the directory from the environment by its id, so
+// that a failure is reported against the artifact instead of stopping the apply
+func pruneApplyProject(accessToken, environment string, project *ApplyProject) error {
+	var err error
+	switch project.Type {
+	case utils.ProjectTypePolicy:
+		_, err = deleteThrottlingPolicyById(accessToken, environment, project.PolicyType, project.ID)
+		PrintDeleteThrottlingPolicyResponse(project.Name, project.PolicyType, err)
+	case utils.ProjectTypeAPIPolicy:
+		_, err = deleteAPIPolicyById(accessToken, environment, project.ID)
+		PrintDeleteAPIPolicyResponse(project.Name, project.Version, err)
+	case utils.ProjectTypeApi:
+		var resp *resty.Response
+		resp, err = deleteAPIById(accessToken, environment, project.ID)
+		PrintDeleteAPIResponse(resp, err)
+	case utils.ProjectTypeApiProduct:
+		var resp *resty.Response
+		resp, err = deleteAPIProductById(accessToken, environment, project.ID)
+		PrintDeleteAPIProductResponse(resp, err)
+	case utils.ProjectTypeApplication:
+		var resp *resty.Response
+		resp, err = deleteApplicationById(accessToken, environment, project.ID)
+		PrintDeleteAppResponse(resp, err)
+	default:
+		err = errors.New("Unsupported project type " + project.Type)
+	}
+	return err
+}
+
+// getApplyParamsPath returns the deployment directory of the project inside the params directory if available. The
+// deployment directory is expected in the same relative path as the project, as generated by "gen deployment-dir".
+func getApplyParamsPath(project *ApplyProject, options ApplyOptions) string {
+	if options.ParamsDir == "" {
+		return ""
+	}
+	paramsPath := filepath.Join(options.ParamsDir, project.RelativePath)
+	if exists, _ := utils.IsDirExists(paramsPath); exists {
+		return paramsPath
+	}
+	return ""
+}
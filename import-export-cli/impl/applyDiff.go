@@ -0,0 +1,247 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const applyExportFormatJSON = "JSON"
+
+// applyVolatileFields are assigned by the environment when an artifact is created or updated, hence they are
+// ignored when comparing a project with the artifact deployed in the environment
+var applyVolatileFields = map[string]bool{
+	"id":                   true,
+	"uuid":                 true,
+	"policyId":             true,
+	"applicationId":        true,
+	"createdTime":          true,
+	"lastUpdatedTime":      true,
+	"lastUpdatedTimestamp": true,
+}
+
+// applyIgnoredFiles are the files of a project that are only used by the tool and are never part of an export
+var applyIgnoredFiles = map[string]bool{
+	utils.MetaFileAPI:            true,
+	utils.MetaFileAPIProduct:     true,
+	utils.MetaFileApplication:    true,
+	utils.ParamFile:              true,
+	utils.ParamsIntermediateFile: true,
+}
+
+// isApplyProjectUnchanged exports the artifact of an existing project from the environment and compares it with the
+// project. Returns false when the artifact cannot be compared, so that the project is updated.
+func isApplyProjectUnchanged(accessToken, environment string, project *ApplyProject, options ApplyOptions) bool {
+	// The environment specific params are applied while importing, hence the project itself cannot be compared
+	if getApplyParamsPath(project, options) != "" {
+		return false
+	}
+	resp, err := exportApplyProject(accessToken, environment, project)
+	if err == nil && resp.StatusCode() != http.StatusOK {
+		err = errors.New(resp.Status())
+	}
+	if err == nil {
+		if project.Type == utils.ProjectTypePolicy {
+			err = compareApplyPolicy(project.AbsolutePath, resp.Body())
+		} else {
+			err = compareApplyProject(project.AbsolutePath, resp.Body())
+		}
+		if err == nil {
+			return true
+		}
+	}
+	utils.Logln(utils.LogPrefixInfo+"Planning an update for "+project.Type+" "+project.Name+" "+project.Version+":",
+		err)
+	return false
+}
+
+// exportApplyProject exports the artifact of a project from the environment in the format of the project
+func exportApplyProject(accessToken, environment string, project *ApplyProject) (*resty.Response, error) {
+	format := utils.DefaultExportFormat
+	switch project.Type {
+	case utils.ProjectTypePolicy:
+		if strings.ToLower(filepath.Ext(project.AbsolutePath)) == ".json" {
+			format = applyExportFormatJSON
+		}
+		return ExportThrottlingPolicyFromEnv(accessToken, environment, project.Name, project.PolicyType, format)
+	case utils.ProjectTypeApi:
+		if isFileExistInDir(project.AbsolutePath, utils.APIDefinitionFileJson) {
+			format = applyExportFormatJSON
+		}
+		return ExportAPIFromEnv(accessToken, project.Name, project.Version, "", project.Owner, format, environment,
+			true, false, false)
+	case utils.ProjectTypeApiProduct:
+		if isFileExistInDir(project.AbsolutePath, utils.APIProductDefinitionFileJson) {
+			format = applyExportFormatJSON
+		}
+		return ExportAPIProductFromEnv(accessToken, project.Name, project.Version, "", project.Owner, format,
+			environment, false, true)
+	case utils.ProjectTypeApplication:
+		if isFileExistInDir(project.AbsolutePath, utils.ApplicationDefinitionFileJson) {
+			format = applyExportFormatJSON
+		}
+		return ExportAppFromEnv(accessToken, project.Name, project.Owner, format, environment, false)
+	}
+	return nil, errors.New("Unsupported project type " + project.Type)
+}
+
+// compareApplyPolicy compares a rate limiting policy file with the exported policy
+func compareApplyPolicy(filePath string, exported []byte) error {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	equal, err := isApplyContentEqual(filePath, content, exported)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		return errors.New("the policy differs from the environment")
+	}
+	return nil
+}
+
+// compareApplyProject compares the files of a project directory with the files of the exported archive. The archive
+// holds the project inside a single root directory.
+func compareApplyProject(projectDir string, archive []byte) error {
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return err
+	}
+	exportedFiles := make(map[string]*zip.File)
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		parts := strings.SplitN(filepath.ToSlash(file.Name), "/", 2)
+		if len(parts) == 2 {
+			exportedFiles[parts[1]] = file
+		}
+	}
+
+	localFiles := make(map[string]string)
+	err = filepath.Walk(projectDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() || applyIgnoredFiles[info.Name()] {
+			return nil
+		}
+		relativePath, _ := filepath.Rel(projectDir, path)
+		localFiles[filepath.ToSlash(relativePath)] = path
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for name := range exportedFiles {
+		if _, ok := localFiles[name]; !ok {
+			return errors.New(name + " is not available in the project")
+		}
+	}
+	for name, path := range localFiles {
+		exportedFile, ok := exportedFiles[name]
+		if !ok {
+			return errors.New(name + " is not available in the environment")
+		}
+		local, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		exported, err := readApplyZipFile(exportedFile)
+		if err != nil {
+			return err
+		}
+		equal, err := isApplyContentEqual(name, local, exported)
+		if err != nil {
+			return err
+		}
+		if !equal {
+			return errors.New(name + " differs from the environment")
+		}
+	}
+	return nil
+}
+
+func readApplyZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// isApplyContentEqual compares yaml and json files by their content ignoring the formatting and the volatile fields,
+// and the other files byte by byte
+func isApplyContentEqual(fileName string, local, exported []byte) (bool, error) {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		return bytes.Equal(local, exported), nil
+	}
+	var localContent, exportedContent interface{}
+	if err := yaml.Unmarshal(local, &localContent); err != nil {
+		return false, fmt.Errorf("unable to read %s: %v", fileName, err)
+	}
+	if err := yaml.Unmarshal(exported, &exportedContent); err != nil {
+		return false, fmt.Errorf("unable to read the exported %s: %v", fileName, err)
+	}
+	return reflect.DeepEqual(removeApplyVolatileFields(localContent), removeApplyVolatileFields(exportedContent)), nil
+}
+
+// removeApplyVolatileFields returns the content without the fields assigned by the environment
+func removeApplyVolatileFields(content interface{}) interface{} {
+	switch value := content.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[interface{}]interface{}, len(value))
+		for key, item := range value {
+			if !applyVolatileFields[fmt.Sprint(key)] {
+				result[key] = removeApplyVolatileFields(item)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = removeApplyVolatileFields(item)
+		}
+		return result
+	}
+	return content
+}
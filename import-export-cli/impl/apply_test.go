@@ -0,0 +1,303 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func writeApplyTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscoverApplyProjectsOrdersByDependency(t *testing.T) {
+	root := t.TempDir()
+	writeApplyTestFile(t, filepath.Join(root, "apps", "SampleApp", utils.ApplicationDefinitionFileYaml),
+		"type: application\ndata:\n  applicationInfo:\n    name: SampleApp\n    owner: admin\n")
+	writeApplyTestFile(t, filepath.Join(root, "products", "Leasing", utils.APIProductDefinitionFileYaml),
+		"type: api_product\ndata:\n  name: Leasing\n  version: 1.0.0\n  provider: admin\n")
+	writeApplyTestFile(t, filepath.Join(root, "products", "Leasing", "APIs", "Inner", utils.APIDefinitionFileYaml),
+		"type: api\ndata:\n  name: Inner\n  version: 1.0.0\n")
+	writeApplyTestFile(t, filepath.Join(root, "apis", "PizzaShack", utils.APIDefinitionFileYaml),
+		"type: api\ndata:\n  name: PizzaShack\n  version: 2.0.0\n  provider: admin\n")
+	writeApplyTestFile(t, filepath.Join(root, "policies", "addHeader_v1", "addHeader_v1.yaml"),
+		"type: operation_policy_specification\ndata:\n  name: addHeader\n  version: v1\n")
+	writeApplyTestFile(t, filepath.Join(root, "policies", "Subscription-Gold.yaml"),
+		"type: throttling policy\nsubtype: subscription policy\ndata:\n  policyId: 1\n  policyName: Gold\n")
+	writeApplyTestFile(t, filepath.Join(root, ".git", "api.yaml"), "type: api\n")
+	writeApplyTestFile(t, filepath.Join(root, "README.md"), "not a project")
+
+	projects, err := DiscoverApplyProjects(root)
+	assert.Nil(t, err, "Should return nil error for a valid directory")
+	if !assert.Len(t, projects, 5, "Projects inside other projects and hidden directories should be skipped") {
+		return
+	}
+
+	assert.Equal(t, utils.ProjectTypePolicy, projects[0].Type)
+	assert.Equal(t, "Gold", projects[0].Name)
+	assert.Equal(t, CmdPolicyTypeSubscription, projects[0].PolicyType)
+	assert.Equal(t, utils.ProjectTypeAPIPolicy, projects[1].Type)
+	assert.Equal(t, "addHeader", projects[1].Name)
+	assert.Equal(t, "v1", projects[1].Version)
+	assert.Equal(t, utils.ProjectTypeApi, projects[2].Type)
+	assert.Equal(t, "PizzaShack", projects[2].Name)
+	assert.Equal(t, "2.0.0", projects[2].Version)
+	assert.Equal(t, utils.ProjectTypeApiProduct, projects[3].Type)
+	assert.Equal(t, "Leasing", projects[3].Name)
+	assert.Equal(t, utils.ProjectTypeApplication, projects[4].Type)
+	assert.Equal(t, "SampleApp", projects[4].Name)
+	assert.Equal(t, "admin", projects[4].Owner)
+	assert.Equal(t, filepath.Join("apps", "SampleApp"), projects[4].RelativePath)
+}
+
+func TestDiscoverApplyProjectsWithUnsupportedPolicyType(t *testing.T) {
+	root := t.TempDir()
+	writeApplyTestFile(t, filepath.Join(root, "Unknown-Policy.yaml"),
+		"type: throttling policy\nsubtype: unknown policy\ndata:\n  policyName: Policy\n")
+
+	projects, err := DiscoverApplyProjects(root)
+	assert.Error(t, err, "Should return an error for unsupported rate limiting policy types")
+	assert.Nil(t, projects)
+}
+
+func TestGetApplyAction(t *testing.T) {
+	assert.Equal(t, ApplyActionCreate, getApplyAction(utils.ProjectTypeApi, false))
+	assert.Equal(t, ApplyActionUpdate, getApplyAction(utils.ProjectTypeApi, true))
+	assert.Equal(t, ApplyActionUpdate, getApplyAction(utils.ProjectTypePolicy, true))
+	assert.Equal(t, ApplyActionUnchanged, getApplyAction(utils.ProjectTypeAPIPolicy, true))
+}
+
+// applyTestArchive returns a zip archive holding the files inside the root directory, as exported by the environment
+func applyTestArchive(t *testing.T, root string, files map[string]string) []byte {
+	t.Helper()
+	buffer := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buffer)
+	for name, content := range files {
+		fileWriter, err := zipWriter.Create(root + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fileWriter.Write([]byte(content))
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// newApplyTestServer serves the artifacts of an environment used by the apply tests. Deleting the API with the id
+// "failing-id" fails. The API Product Leasing uses the API with the id "used-id".
+func newApplyTestServer(t *testing.T, deleted *[]string) *httptest.Server {
+	pizzaShackAPI := "type: api\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n  provider: admin\n  context: /pizza\n"
+	changedAPI := "type: api\ndata:\n  name: ChangedAPI\n  version: 1.0.0\n  provider: admin\n  context: /changed\n"
+	sampleApp := "type: application\ndata:\n  applicationInfo:\n    name: SampleApp\n    owner: admin\n"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/admin/v4/throttling/policies/search":
+			_, _ = w.Write([]byte(`{"count":5,"list":[
+				{"uuid":"gold-id","policyName":"Gold","type":"Subscription Policy","isDeployed":true},
+				{"uuid":"10k-id","policyName":"10KPerMin","type":"Advanced Policy","isDeployed":true},
+				{"uuid":"20-id","policyName":"20PerMin","type":"Application Policy"},
+				{"uuid":"platinum-id","policyName":"Platinum","type":"Subscription Policy"},
+				{"uuid":"5k-id","policyName":"5KPerMin","type":"Advanced Policy","isDeployed":true}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/operation-policies":
+			_, _ = w.Write([]byte(`{"count":3,"list":[{"id":"add-header-id","name":"addHeader","version":"v1"},
+				{"id":"rewrite-id","name":"rewritePath","version":"v1"},
+				{"id":"custom-id","name":"customPolicy","version":"v1"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/search" &&
+			strings.Contains(r.URL.Query().Get("query"), "APIProduct"):
+			_, _ = w.Write([]byte(`{"count":1,"list":[
+				{"id":"leasing-id","name":"Leasing","version":"1.0.0","provider":"admin"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/api-products/leasing-id":
+			_, _ = w.Write([]byte(`{"id":"leasing-id","apis":[{"apiId":"used-id","name":"UsedAPI","version":"1.0.0"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/api-products/export":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/apis":
+			_, _ = w.Write([]byte(`{"count":4,"list":[
+				{"id":"pizza-id","name":"PizzaShackAPI","version":"1.0.0","provider":"admin"},
+				{"id":"changed-id","name":"ChangedAPI","version":"1.0.0","provider":"admin"},
+				{"id":"used-id","name":"UsedAPI","version":"1.0.0","provider":"admin"},
+				{"id":"failing-id","name":"OrphanAPI","version":"1.0.0","provider":"admin"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/admin/v4/applications":
+			assert.Empty(t, r.URL.Query().Get("user"), "Applications of all the owners should be listed")
+			_, _ = w.Write([]byte(`{"count":3,"list":[
+				{"applicationId":"sample-id","name":"SampleApp","owner":"admin"},
+				{"applicationId":"other-id","name":"OtherApp","owner":"bob"},
+				{"applicationId":"cli-id","name":"` + utils.DefaultCliApp + `","owner":"admin"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/apis/export":
+			w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationZip)
+			switch r.URL.Query().Get("name") {
+			case "PizzaShackAPI":
+				// Fields assigned by the environment are not considered as changes
+				_, _ = w.Write(applyTestArchive(t, "PizzaShackAPI-1.0.0", map[string]string{
+					utils.APIDefinitionFileYaml: pizzaShackAPI + "  id: pizza-id\n  lastUpdatedTime: 1700000000\n",
+				}))
+			case "ChangedAPI":
+				_, _ = w.Write(applyTestArchive(t, "ChangedAPI-1.0.0", map[string]string{
+					utils.APIDefinitionFileYaml: strings.Replace(changedAPI, "/changed", "/old", 1),
+				}))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/devportal/v3/applications/export":
+			w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationZip)
+			_, _ = w.Write(applyTestArchive(t, "admin_SampleApp", map[string]string{
+				utils.ApplicationDefinitionFileYaml: sampleApp,
+			}))
+		case r.Method == http.MethodDelete:
+			if strings.HasSuffix(r.URL.Path, "/failing-id") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			*deleted = append(*deleted, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+}
+
+// writeApplyTestProjects writes the projects used by the apply tests, where PizzaShackAPI and SampleApp are the same
+// as the environment and ChangedAPI differs from the environment
+func writeApplyTestProjects(t *testing.T, root string) {
+	writeApplyTestFile(t, filepath.Join(root, "apis", "PizzaShackAPI", utils.APIDefinitionFileYaml),
+		"type: api\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n  provider: admin\n  context: /pizza\n")
+	writeApplyTestFile(t, filepath.Join(root, "apis", "PizzaShackAPI", utils.MetaFileAPI), "name: PizzaShackAPI\n")
+	writeApplyTestFile(t, filepath.Join(root, "apis", "ChangedAPI", utils.APIDefinitionFileYaml),
+		"type: api\ndata:\n  name: ChangedAPI\n  version: 1.0.0\n  provider: admin\n  context: /changed\n")
+	writeApplyTestFile(t, filepath.Join(root, "apps", "SampleApp", utils.ApplicationDefinitionFileYaml),
+		"type: application\ndata:\n  applicationInfo:\n    name: SampleApp\n    owner: admin\n")
+}
+
+func TestPlanApplyComparesWithEnvironmentAndPrunes(t *testing.T) {
+	var deleted []string
+	server := newApplyTestServer(t, &deleted)
+	defer server.Close()
+	useTestEnvironment(t, "dev", server.URL)
+	root := t.TempDir()
+	writeApplyTestProjects(t, root)
+	writeApplyTestFile(t, filepath.Join(root, "products", "Leasing", utils.APIProductDefinitionFileYaml),
+		"type: api_product\ndata:\n  name: Leasing\n  version: 1.0.0\n  provider: admin\n")
+	writeApplyTestFile(t, filepath.Join(root, "policies", "customPolicy_v2", "customPolicy_v2.yaml"),
+		"type: operation_policy_specification\ndata:\n  name: customPolicy\n  version: v2\n")
+
+	projects, err := DiscoverApplyProjects(root)
+	assert.Nil(t, err)
+	projects, err = PlanApply("access-token", "dev", projects, ApplyOptions{Prune: true})
+	assert.Nil(t, err)
+
+	var plan []string
+	for _, project := range projects {
+		plan = append(plan, project.Action+" "+project.Type+" "+project.Name+" "+project.ID)
+	}
+	assert.Equal(t, []string{
+		"create " + utils.ProjectTypeAPIPolicy + " customPolicy ",
+		"update " + utils.ProjectTypeApi + " ChangedAPI ",
+		"unchanged " + utils.ProjectTypeApi + " PizzaShackAPI ",
+		"update " + utils.ProjectTypeApiProduct + " Leasing ",
+		"unchanged " + utils.ProjectTypeApplication + " SampleApp ",
+		"prune " + utils.ProjectTypeApplication + " OtherApp other-id",
+		"skip " + utils.ProjectTypeApi + " UsedAPI used-id",
+		"prune " + utils.ProjectTypeApi + " OrphanAPI failing-id",
+		"prune " + utils.ProjectTypeAPIPolicy + " customPolicy custom-id",
+		"prune " + utils.ProjectTypePolicy + " Platinum platinum-id",
+		"prune " + utils.ProjectTypePolicy + " 5KPerMin 5k-id",
+	}, plan, "The policies shipped with API Manager, the operation policies not in the directory and the "+
+		"apictl application should not be pruned")
+	assert.Equal(t, "used by the API Product Leasing 1.0.0", projects[6].Reason,
+		"An API used by a kept API Product should be skipped with the reason")
+	assert.Equal(t, CmdPolicyTypeSubscription, projects[len(projects)-2].PolicyType)
+	assert.Equal(t, CmdPolicyTypeAdvanced, projects[len(projects)-1].PolicyType,
+		"A deployed custom policy should be pruned")
+	assert.Empty(t, deleted, "Planning should not delete anything")
+}
+
+func TestPlanApplyWithUnavailableApplications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		if r.URL.Path == "/api/am/admin/v4/applications" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"count":0,"list":[]}`))
+	}))
+	defer server.Close()
+	useTestEnvironment(t, "dev", server.URL)
+
+	projects, err := PlanApply("access-token", "dev", []*ApplyProject{{Type: utils.ProjectTypeApplication,
+		Name: "SampleApp", Owner: "admin"}}, ApplyOptions{})
+	assert.Error(t, err, "Should not plan a create when the Applications cannot be listed")
+	assert.Nil(t, projects)
+}
+
+func TestApplyProjectsToEnvReportsPruneFailures(t *testing.T) {
+	var deleted []string
+	server := newApplyTestServer(t, &deleted)
+	defer server.Close()
+	useTestEnvironment(t, "dev", server.URL)
+	root := t.TempDir()
+	writeApplyTestProjects(t, root)
+	// Only the prune actions are taken by removing the project that needs to be imported, which prunes its API
+	assert.Nil(t, os.RemoveAll(filepath.Join(root, "apis", "ChangedAPI")))
+
+	failedProjects, err := ApplyProjectsToEnv("access-token", "dev", root, ApplyOptions{Prune: true})
+	assert.Nil(t, err)
+	if assert.Len(t, failedProjects, 1) {
+		assert.Equal(t, "OrphanAPI", failedProjects[0].Name)
+		assert.Equal(t, ApplyActionPrune, failedProjects[0].Action)
+		assert.Error(t, failedProjects[0].Error)
+	}
+	assert.Equal(t, []string{
+		"/api/am/admin/v4/applications/other-id",
+		"/api/am/publisher/v4/api-products/leasing-id",
+		"/api/am/publisher/v4/apis/changed-id",
+		"/api/am/publisher/v4/apis/used-id",
+		"/api/am/admin/v4/throttling/policies/subscription/platinum-id",
+		"/api/am/admin/v4/throttling/policies/advanced/5k-id",
+	}, deleted, "The other artifacts should be pruned after a failure")
+}
+
+func TestApplyPreserveProvider(t *testing.T) {
+	preserve, overwrite := true, false
+	metaPreserve := utils.ImportConfig{PreserveProvider: true}
+	metaOverwrite := utils.ImportConfig{PreserveProvider: false}
+
+	assert.True(t, ApplyOptions{}.preserveProvider(metaPreserve), "The meta file should be used without the flag")
+	assert.False(t, ApplyOptions{}.preserveProvider(metaOverwrite), "The meta file should be used without the flag")
+	assert.True(t, ApplyOptions{PreserveProvider: &preserve}.preserveProvider(metaOverwrite))
+	assert.False(t, ApplyOptions{PreserveProvider: &overwrite}.preserveProvider(metaPreserve))
+}
@@ -0,0 +1,183 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Retry configuration of the tasks of bulk exports. Declared as variables so that tests can shorten the backoff.
+var (
+	bulkTaskMaxAttempts    = 3
+	bulkTaskInitialBackoff = 2 * time.Second
+	bulkTaskMaxBackoff     = 30 * time.Second
+)
+
+// BulkArtifactResult is the outcome of exporting or importing a single artifact in a bulk operation
+type BulkArtifactResult struct {
+	Artifact string
+	Err      error
+}
+
+// bulkTask is a unit of work of a bulk operation. run of an idempotent task is called again when it fails with a
+// retryable error, hence such a task made of several requests keeps track of its progress and resumes from the
+// request that failed.
+type bulkTask struct {
+	artifact   string
+	idempotent bool
+	run        func() error
+}
+
+// runBulkTasks runs the tasks with at most workers tasks in progress at a time. An idempotent task failed due to a
+// server error is retried by the worker running it. onComplete is called for each task as soon as it finishes, and
+// the calls are serialized so that the callback can update shared state such as the
+// resumption files without further locking.
+// @param tasks : Tasks to be run
+// @param workers : Maximum number of tasks run concurrently
+// @param onComplete : Callback invoked with the index and the result of a finished task
+// @return results of the tasks in the order of tasks
+func runBulkTasks(tasks []bulkTask, workers int, onComplete func(index int, result *BulkArtifactResult)) []*BulkArtifactResult {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]*BulkArtifactResult, len(tasks))
+	indexes := make(chan int)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(tasks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := &BulkArtifactResult{Artifact: tasks[i].artifact, Err: runBulkTask(tasks[i])}
+				mutex.Lock()
+				results[i] = result
+				if onComplete != nil {
+					onComplete(i, result)
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	for i := range tasks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// runBulkTask runs a task, retrying an idempotent task until it succeeds, fails with an error that is not retryable
+// or bulkTaskMaxAttempts attempts are made. The HTTP client already retries a request rejected with 429 or 503, and
+// an idempotent request failed with a network error, 502 or 504. Hence only a 500 response, which the server may
+// return for a transient failure, is retried here, so that a request is not retried both by the client and the task.
+// Imports are not idempotent, since importing again can create another revision, and are only retried by the client.
+// @param task : Task to be run
+// @return error of the last attempt
+func runBulkTask(task bulkTask) error {
+	backoff := bulkTaskInitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := task.run()
+		if !task.idempotent || !isRetryableBulkError(err) || attempt >= bulkTaskMaxAttempts {
+			return err
+		}
+		utils.Logln(utils.LogPrefixWarning+task.artifact+" failed with "+err.Error()+". Retrying in", backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > bulkTaskMaxBackoff {
+			backoff = bulkTaskMaxBackoff
+		}
+	}
+}
+
+// isRetryableBulkError checks whether the error is caused by a 500 response, which is not retried by the HTTP client
+func isRetryableBulkError(err error) bool {
+	return utils.GetHttpStatusCodeFromError(err) == http.StatusInternalServerError
+}
+
+// bulkResumptionMarker tracks the finished tasks of a batch of a bulk operation. Tasks finish out of order when they
+// run concurrently, while the resumption files only record the last succeeded artifact. Hence the marker only
+// advances over a contiguous prefix of succeeded tasks, so that a resumed operation never skips an artifact.
+type bulkResumptionMarker struct {
+	succeeded []bool
+	next      int
+}
+
+func newBulkResumptionMarker(size int) *bulkResumptionMarker {
+	return &bulkResumptionMarker{succeeded: make([]bool, size)}
+}
+
+// markSucceeded records the task as succeeded and returns the index of the last task of the contiguous prefix of
+// succeeded tasks if the prefix grew, or -1 otherwise
+func (m *bulkResumptionMarker) markSucceeded(index int) int {
+	m.succeeded[index] = true
+	last := -1
+	for m.next < len(m.succeeded) && m.succeeded[m.next] {
+		last = m.next
+		m.next++
+	}
+	return last
+}
+
+// PrintBulkReport prints the outcome of each artifact of a bulk operation followed by a summary
+// @param results : Results of the artifacts processed
+// @return number of failed artifacts
+func PrintBulkReport(results []*BulkArtifactResult) int {
+	fmt.Fprintln(utils.Stdout, "\nSTATUS\t\tARTIFACT")
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if result.Err != nil {
+			fmt.Fprintln(utils.Stdout, "FAILED\t\t"+result.Artifact+": "+result.Err.Error())
+		} else {
+			fmt.Fprintln(utils.Stdout, "SUCCEEDED\t"+result.Artifact)
+		}
+	}
+	failed := countFailedBulkResults(results)
+	fmt.Fprintln(utils.Stdout, "\nSucceeded: "+strconv.Itoa(countBulkResults(results)-failed)+", Failed: "+
+		strconv.Itoa(failed))
+	return failed
+}
+
+func countFailedBulkResults(results []*BulkArtifactResult) int {
+	failed := 0
+	for _, result := range results {
+		if result != nil && result.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+func countBulkResults(results []*BulkArtifactResult) int {
+	count := 0
+	for _, result := range results {
+		if result != nil {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,182 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestRunBulkTasksBoundsConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	tasks := make([]bulkTask, 10)
+	for i := range tasks {
+		index := i
+		tasks[i] = bulkTask{
+			artifact: "API" + strconv.Itoa(i),
+			run: func() error {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				if index == 3 {
+					return errors.New("404 Not Found")
+				}
+				return nil
+			},
+		}
+	}
+
+	var completed int
+	results := runBulkTasks(tasks, 3, func(index int, result *BulkArtifactResult) {
+		completed++
+	})
+	assert.Equal(t, 10, completed, "Should notify the completion of every task")
+	assert.LessOrEqual(t, maxRunning, int32(3), "Should not run more tasks than the number of workers")
+	assert.Greater(t, maxRunning, int32(1), "Should run tasks concurrently")
+	if assert.Len(t, results, 10) {
+		assert.Equal(t, "API3", results[3].Artifact, "Results should be in the order of the tasks")
+		assert.Error(t, results[3].Err)
+		assert.Equal(t, 1, countFailedBulkResults(results))
+	}
+}
+
+// shortenBulkTaskBackoff shortens the backoff of the retried bulk tasks for the duration of the test
+func shortenBulkTaskBackoff(t *testing.T) {
+	initialBackoff, maxBackoff := bulkTaskInitialBackoff, bulkTaskMaxBackoff
+	bulkTaskInitialBackoff, bulkTaskMaxBackoff = time.Millisecond, 2*time.Millisecond
+	t.Cleanup(func() {
+		bulkTaskInitialBackoff, bulkTaskMaxBackoff = initialBackoff, maxBackoff
+	})
+}
+
+// failingBulkTask returns a task that fails with the given errors in order and succeeds afterwards
+func failingBulkTask(idempotent bool, attempts *int, errs ...error) bulkTask {
+	return bulkTask{
+		artifact:   "PizzaShackAPI 1.0.0 (admin)",
+		idempotent: idempotent,
+		run: func() error {
+			*attempts++
+			if *attempts <= len(errs) {
+				return errs[*attempts-1]
+			}
+			return nil
+		},
+	}
+}
+
+func TestRunBulkTasksRetriesServerErrorsOfIdempotentTasks(t *testing.T) {
+	shortenBulkTaskBackoff(t)
+	attempts := 0
+	results := runBulkTasks([]bulkTask{failingBulkTask(true, &attempts,
+		&utils.HttpResponseError{StatusCode: http.StatusInternalServerError, Status: "500 Internal Server Error"},
+		fmt.Errorf("Error getting the revisions list: %w",
+			&utils.HttpResponseError{StatusCode: http.StatusInternalServerError, Status: "500 Internal Server Error"}),
+	)}, 1, nil)
+	assert.Equal(t, 3, attempts, "Should retry the task until it succeeds")
+	assert.NoError(t, results[0].Err)
+}
+
+func TestRunBulkTasksGivesUpAfterMaxAttempts(t *testing.T) {
+	shortenBulkTaskBackoff(t)
+	serverErr := errors.New("500 Internal Server Error")
+	attempts := 0
+	results := runBulkTasks([]bulkTask{failingBulkTask(true, &attempts, serverErr, serverErr, serverErr,
+		serverErr)}, 1, nil)
+	assert.Equal(t, bulkTaskMaxAttempts, attempts, "Should stop retrying after the maximum number of attempts")
+	assert.Equal(t, serverErr, results[0].Err, "Should report the error of the last attempt")
+}
+
+func TestRunBulkTasksDoesNotRetryErrorsRetriedByTheHttpClient(t *testing.T) {
+	shortenBulkTaskBackoff(t)
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout} {
+		err := &utils.HttpResponseError{StatusCode: status, Status: http.StatusText(status)}
+		attempts := 0
+		results := runBulkTasks([]bulkTask{failingBulkTask(true, &attempts, err)}, 1, nil)
+		assert.Equal(t, 1, attempts, "Should leave retrying "+strconv.Itoa(status)+" to the HTTP client")
+		assert.Equal(t, err, results[0].Err)
+	}
+}
+
+func TestRunBulkTasksDoesNotRetryClientErrors(t *testing.T) {
+	shortenBulkTaskBackoff(t)
+	for _, err := range []error{
+		&utils.HttpResponseError{StatusCode: http.StatusConflict, Status: "409 Conflict"},
+		errors.New("404 Not Found"),
+		errors.New("open PizzaShackAPI-1.0.0.zip: no such file or directory"),
+	} {
+		attempts := 0
+		results := runBulkTasks([]bulkTask{failingBulkTask(true, &attempts, err)}, 1, nil)
+		assert.Equal(t, 1, attempts, "Should not retry "+err.Error())
+		assert.Equal(t, err, results[0].Err)
+	}
+}
+
+func TestRunBulkTasksDoesNotRetryImports(t *testing.T) {
+	shortenBulkTaskBackoff(t)
+	serverErr := fmt.Errorf("PizzaShackAPI-1.0.0.zip: %w",
+		&utils.HttpResponseError{StatusCode: http.StatusInternalServerError, Status: "500 Internal Server Error"})
+	attempts := 0
+	results := runBulkTasks([]bulkTask{failingBulkTask(false, &attempts, serverErr)}, 1, nil)
+	assert.Equal(t, 1, attempts, "Should not import again, since an import can create another revision")
+	assert.Equal(t, serverErr, results[0].Err)
+}
+
+func TestBulkResumptionMarkerAdvancesOverContiguousSuccesses(t *testing.T) {
+	marker := newBulkResumptionMarker(4)
+	assert.Equal(t, -1, marker.markSucceeded(1), "Should not advance while an earlier task is in progress")
+	assert.Equal(t, -1, marker.markSucceeded(3))
+	assert.Equal(t, 1, marker.markSucceeded(0), "Should advance over all the contiguous succeeded tasks")
+	assert.Equal(t, 3, marker.markSucceeded(2))
+}
+
+func TestGetImportAPIArtifactGroupsOrdersRevisions(t *testing.T) {
+	importDir := t.TempDir()
+	for _, name := range []string{"PizzaShackAPI_1.0.0.zip", "PizzaShackAPI_1.0.0_Revision-10.zip",
+		"PizzaShackAPI_1.0.0_Revision-2.zip", "SwaggerPetstore_1.0.0.zip", "notes.txt"} {
+		writeApplyTestFile(t, filepath.Join(importDir, name), "")
+	}
+	writeApplyTestFile(t, filepath.Join(importDir, "MyAPI", "api.yaml"), "")
+
+	groups, err := getImportAPIArtifactGroups(importDir)
+	if !assert.Nil(t, err) || !assert.Len(t, groups, 3, "Should group the archives of the same API") {
+		return
+	}
+	var pizzaShack []string
+	for _, artifact := range groups[1] {
+		pizzaShack = append(pizzaShack, filepath.Base(artifact.path))
+	}
+	assert.Equal(t, []string{"PizzaShackAPI_1.0.0_Revision-2.zip", "PizzaShackAPI_1.0.0_Revision-10.zip",
+		"PizzaShackAPI_1.0.0.zip"}, pizzaShack, "Revisions should be imported in order before the working copy")
+}
@@ -0,0 +1,598 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+// Sources of the gateway exports that can be converted to API projects
+const (
+	ConvertSourceKong   = "kong"
+	ConvertSourceAzure  = "azure"
+	ConvertSourceApigee = "apigee"
+)
+
+// ConvertSources are the gateways whose exports can be converted to API projects
+var ConvertSources = []string{ConvertSourceKong, ConvertSourceAzure, ConvertSourceApigee}
+
+const (
+	// ConvertReportFileName is the name of the report written to the output directory of a conversion
+	ConvertReportFileName = "conversion_report.yaml"
+	// convertPoliciesDir is the directory of the output directory the rate limiting policies are written to
+	convertPoliciesDir = "RateLimitingPolicies"
+)
+
+// Built-in operation policies of API Manager the plugins and policies of the other gateways are mapped to
+const (
+	convertPolicyVersion          = "v1"
+	convertPolicyAddHeader        = "addHeader"
+	convertPolicyRemoveHeader     = "removeHeader"
+	convertPolicyAddQueryParam    = "addQueryParam"
+	convertPolicyRemoveQueryParam = "removeQueryParam"
+)
+
+// Security schemes of API Manager the authentication plugins and policies of the other gateways are mapped to
+const (
+	convertSecurityOAuth2    = "oauth2"
+	convertSecurityAPIKey    = "api_key"
+	convertSecurityBasicAuth = "basic_auth"
+	convertSecurityMandatory = "oauth_basic_auth_api_key_mandatory"
+)
+
+// convertDefaultVerbs are the operations created for a path that is not restricted to a set of HTTP methods
+var convertDefaultVerbs = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// ConversionReport lists the APIs converted from an export and the features that could not be mapped
+type ConversionReport struct {
+	Source               string               `yaml:"source"`
+	Input                string               `yaml:"input"`
+	APIs                 []ConvertedAPIReport `yaml:"apis"`
+	RateLimitingPolicies []string             `yaml:"rateLimitingPolicies,omitempty"`
+	Unmapped             []string             `yaml:"unmapped,omitempty"`
+}
+
+// ConvertedAPIReport lists the features of an API that could not be mapped to the API project
+type ConvertedAPIReport struct {
+	Name     string   `yaml:"name"`
+	Version  string   `yaml:"version"`
+	Project  string   `yaml:"project"`
+	Unmapped []string `yaml:"unmapped,omitempty"`
+}
+
+// convertedFlow holds the rate limit and the operation policies of an API or of an operation
+type convertedFlow struct {
+	RateLimit *convertedRateLimit
+	Policies  v2.OperationPolicies
+}
+
+// convertedOperation is an operation of a converted API
+type convertedOperation struct {
+	convertedFlow
+	Target string
+	Verb   string
+}
+
+// convertedAPI is the gateway independent model of an API read from an export
+type convertedAPI struct {
+	convertedFlow
+	Name            string
+	Version         string
+	Description     string
+	Context         string
+	Tags            []string
+	EndpointType    string
+	EndpointUrls    []string
+	Operations      []*convertedOperation
+	SecuritySchemes []string
+	APIKeyHeader    string
+	Cors            *v2.CorsConfiguration
+	Unmapped        []string
+}
+
+// convertedRateLimit is a request count limit. It is written as an advanced rate limiting policy.
+type convertedRateLimit struct {
+	RequestCount int
+	UnitTime     int
+	TimeUnit     string
+}
+
+// convertTimeUnits are the time units of the rate limiting policies with their length in seconds, longest first
+var convertTimeUnits = []struct {
+	name    string
+	seconds int64
+}{
+	{"day", 24 * 60 * 60},
+	{"hour", 60 * 60},
+	{"min", 60},
+}
+
+// newConvertedRateLimit creates a rate limit allowing count requests per the given number of seconds. API Manager
+// does not support limits per second, hence shorter periods are approximated as a limit per minute. The second
+// return value is true if the limit was approximated.
+func newConvertedRateLimit(count int, seconds int64) (*convertedRateLimit, bool) {
+	if count <= 0 || seconds <= 0 {
+		return nil, false
+	}
+	for _, unit := range convertTimeUnits {
+		if seconds%unit.seconds == 0 {
+			return &convertedRateLimit{RequestCount: count, UnitTime: int(seconds / unit.seconds),
+				TimeUnit: unit.name}, false
+		}
+	}
+	perMinute := int((int64(count)*60 + seconds - 1) / seconds)
+	return &convertedRateLimit{RequestCount: perMinute, UnitTime: 1, TimeUnit: "min"}, true
+}
+
+// PolicyName returns the name of the advanced rate limiting policy of the limit. Eg: 100PerMin, 5000Per6Hour
+func (l *convertedRateLimit) PolicyName() string {
+	unit := strings.Title(l.TimeUnit)
+	if l.UnitTime == 1 {
+		return fmt.Sprintf("%dPer%s", l.RequestCount, unit)
+	}
+	return fmt.Sprintf("%dPer%d%s", l.RequestCount, l.UnitTime, unit)
+}
+
+// setRateLimit sets the rate limit of the flows. Only a single limit is supported per flow, hence the rest are
+// reported.
+func (a *convertedAPI) setRateLimit(flows []*convertedFlow, source string, count int, seconds int64) {
+	limit, approximated := newConvertedRateLimit(count, seconds)
+	if limit == nil {
+		a.unmapped("%s: invalid rate limit of %d requests per %d seconds", source, count, seconds)
+		return
+	}
+	conflict := false
+	for _, flow := range flows {
+		if flow.RateLimit != nil {
+			conflict = true
+			continue
+		}
+		flow.RateLimit = limit
+	}
+	if conflict {
+		a.unmapped("%s: only a single rate limit is supported, %d requests per %d seconds is not mapped",
+			source, count, seconds)
+	} else if approximated {
+		a.unmapped("%s: %d requests per %d seconds is approximated as %s", source, count, seconds,
+			limit.PolicyName())
+	}
+}
+
+// addPolicy attaches a built-in policy to the request flow, or to the response flow if response is true
+func (f *convertedFlow) addPolicy(response bool, name string, parameters map[string]interface{}) {
+	policy := v2.OperationPolicy{PolicyName: name, PolicyVersion: convertPolicyVersion, Parameters: parameters}
+	if response {
+		f.Policies.Response = append(f.Policies.Response, policy)
+	} else {
+		f.Policies.Request = append(f.Policies.Request, policy)
+	}
+}
+
+// addHeader attaches a policy setting a header of the request or the response
+func (f *convertedFlow) addHeader(response bool, name, value string) {
+	f.addPolicy(response, convertPolicyAddHeader, map[string]interface{}{"headerName": name, "headerValue": value})
+}
+
+// removeHeader attaches a policy removing a header of the request or the response
+func (f *convertedFlow) removeHeader(response bool, name string) {
+	f.addPolicy(response, convertPolicyRemoveHeader, map[string]interface{}{"headerName": name})
+}
+
+// addQueryParam attaches a policy setting a query parameter of the request
+func (f *convertedFlow) addQueryParam(name, value string) {
+	f.addPolicy(false, convertPolicyAddQueryParam, map[string]interface{}{"paramKey": name, "paramValue": value})
+}
+
+// removeQueryParam attaches a policy removing a query parameter of the request
+func (f *convertedFlow) removeQueryParam(name string) {
+	f.addPolicy(false, convertPolicyRemoveQueryParam, map[string]interface{}{"paramKey": name})
+}
+
+// unmapped records a feature of the API that could not be mapped to the project
+func (a *convertedAPI) unmapped(format string, args ...interface{}) {
+	a.Unmapped = append(a.Unmapped, fmt.Sprintf(format, args...))
+}
+
+// addSecurityScheme adds a security scheme to the API if it is not already added
+func (a *convertedAPI) addSecurityScheme(scheme string) {
+	for _, s := range a.SecuritySchemes {
+		if s == scheme {
+			return
+		}
+	}
+	a.SecuritySchemes = append(a.SecuritySchemes, scheme)
+}
+
+// addOperation adds an operation to the API for each verb, or for each of the default verbs if none is given.
+// Operations that already exist are returned as they are.
+func (a *convertedAPI) addOperation(target string, verbs ...string) []*convertedOperation {
+	if len(verbs) == 0 {
+		verbs = convertDefaultVerbs
+	}
+	var operations []*convertedOperation
+	for _, verb := range verbs {
+		verb = strings.ToUpper(verb)
+		var operation *convertedOperation
+		for _, op := range a.Operations {
+			if op.Target == target && op.Verb == verb {
+				operation = op
+				break
+			}
+		}
+		if operation == nil {
+			operation = &convertedOperation{Target: target, Verb: verb}
+			a.Operations = append(a.Operations, operation)
+		}
+		operations = append(operations, operation)
+	}
+	return operations
+}
+
+// ConvertToAPIProjects converts the APIs of an export of another gateway to API projects in outDir. Each API is
+// written to a directory named <name>-<version>, the rate limits are written as advanced rate limiting policies to
+// the RateLimitingPolicies directory and the features that could not be mapped are listed in conversion_report.yaml,
+// so that the output directory could be applied to an environment with apictl apply.
+// @param source : Gateway the export belongs to. One of kong, azure or apigee
+// @param exportPath : Path of the export
+// @param outDir : Directory to write the projects to
+// @param version : Version of the APIs the export does not define a version for
+// @param force : Whether to overwrite the existing projects
+// @return report of the conversion
+// @return error
+func ConvertToAPIProjects(source, exportPath, outDir, version string, force bool) (*ConversionReport, error) {
+	report := &ConversionReport{Source: source, Input: exportPath}
+	var apis []*convertedAPI
+	var err error
+	switch source {
+	case ConvertSourceKong:
+		apis, report.Unmapped, err = readKongExport(exportPath)
+	case ConvertSourceAzure:
+		apis, report.Unmapped, err = readAzureExport(exportPath)
+	case ConvertSourceApigee:
+		apis, report.Unmapped, err = readApigeeExport(exportPath)
+	default:
+		return nil, fmt.Errorf("unsupported source '%s'. Supported sources are %s", source,
+			strings.Join(ConvertSources, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(apis) == 0 {
+		return nil, errors.New("no APIs found in " + exportPath)
+	}
+
+	rateLimits := make(map[string]*convertedRateLimit)
+	for _, api := range apis {
+		if api.Version == "" {
+			api.Version = version
+		}
+		projectName := api.Name + "-" + api.Version
+		projectDir := filepath.Join(outDir, projectName)
+		if exists, _ := utils.IsDirExists(projectDir); exists && !force {
+			return nil, fmt.Errorf("%s already exists. Run with --force to overwrite the projects", projectDir)
+		}
+		if err := writeConvertedAPIProject(api, projectDir); err != nil {
+			return nil, fmt.Errorf("error writing the project of %s: %w", api.Name, err)
+		}
+		for _, limit := range api.rateLimits() {
+			rateLimits[limit.PolicyName()] = limit
+		}
+		report.APIs = append(report.APIs, ConvertedAPIReport{Name: api.Name, Version: api.Version,
+			Project: projectName, Unmapped: api.Unmapped})
+	}
+
+	defaultSpec, err := loadDefaultSpec()
+	if err != nil {
+		return nil, err
+	}
+	for name := range rateLimits {
+		report.RateLimitingPolicies = append(report.RateLimitingPolicies, name)
+	}
+	sort.Strings(report.RateLimitingPolicies)
+	for _, name := range report.RateLimitingPolicies {
+		if err := writeConvertedRateLimitingPolicy(rateLimits[name], defaultSpec.ApimVersion,
+			filepath.Join(outDir, convertPoliciesDir)); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := yaml2.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	reportPath := filepath.Join(outDir, ConvertReportFileName)
+	utils.Logln(utils.LogPrefixInfo + "Writing " + reportPath)
+	if err := ioutil.WriteFile(reportPath, content, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// rateLimits returns the rate limits of the API and its operations
+func (a *convertedAPI) rateLimits() []*convertedRateLimit {
+	var limits []*convertedRateLimit
+	if a.RateLimit != nil {
+		limits = append(limits, a.RateLimit)
+	}
+	for _, operation := range a.Operations {
+		if operation.RateLimit != nil {
+			limits = append(limits, operation.RateLimit)
+		}
+	}
+	return limits
+}
+
+// writeConvertedAPIProject initializes a project from an OpenAPI definition generated for the API and updates the
+// api.yaml of the project with the endpoints, rate limits, policies and security of the API
+func writeConvertedAPIProject(api *convertedAPI, projectDir string) error {
+	definition, err := buildConvertedOpenAPI(api)
+	if err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile("", "apictl-convert-*.yaml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.Write(definition)
+	tmpFile.Close()
+	if err != nil {
+		return err
+	}
+	if err := InitAPIProject(projectDir, "", tmpFile.Name(), InitSourceOAS, "", false); err != nil {
+		return err
+	}
+
+	apiYamlPath := filepath.Join(projectDir, filepath.FromSlash(utils.APIDefinitionFileYaml))
+	content, err := ioutil.ReadFile(apiYamlPath)
+	if err != nil {
+		return err
+	}
+	definitionFile := &v2.APIDefinitionFile{}
+	if err := yaml2.Unmarshal(content, definitionFile); err != nil {
+		return err
+	}
+	if err := applyConvertedAPI(&definitionFile.Data, api); err != nil {
+		return err
+	}
+	content, err = yaml2.Marshal(definitionFile)
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Writing " + apiYamlPath)
+	return ioutil.WriteFile(apiYamlPath, content, os.ModePerm)
+}
+
+// applyConvertedAPI sets the details of the API that are not carried by the OpenAPI definition
+func applyConvertedAPI(def *v2.APIDTODefinition, api *convertedAPI) error {
+	def.Name = api.Name
+	def.Version = api.Version
+	def.Context = api.Context
+	if len(api.Tags) > 0 {
+		def.Tags = api.Tags
+	}
+	if len(api.EndpointUrls) > 0 {
+		endpoints := &v2.Endpoints{Type: api.EndpointType, Urls: api.EndpointUrls}
+		endpointConfig, err := v2.BuildAPIMEndpoints(endpoints, endpoints)
+		if err != nil {
+			return err
+		}
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(endpointConfig), &config); err != nil {
+			return err
+		}
+		def.EndpointConfig = config
+	}
+	if api.RateLimit != nil {
+		def.APIThrottlingPolicy = api.RateLimit.PolicyName()
+	}
+	if !api.Policies.IsEmpty() {
+		policies := api.Policies
+		def.APIPolicies = &policies
+	}
+	if len(api.SecuritySchemes) > 0 {
+		def.SecurityScheme = append(api.SecuritySchemes, convertSecurityMandatory)
+	}
+	if api.APIKeyHeader != "" {
+		def.ApiKeyHeader = api.APIKeyHeader
+	}
+	if api.Cors != nil {
+		def.CorsConfiguration = api.Cors
+	}
+	def.Operations = nil
+	for _, op := range api.Operations {
+		operation := v2.NewAPIOperation(op.Target, op.Verb)
+		if op.RateLimit != nil {
+			operation.ThrottlingPolicy = op.RateLimit.PolicyName()
+		}
+		if !op.Policies.IsEmpty() {
+			policies := op.Policies
+			operation.OperationPolicies = &policies
+		}
+		def.Operations = append(def.Operations, operation)
+	}
+	return nil
+}
+
+// convertPathParamRegex matches the templated parameters of a path. Eg: {petId}
+var convertPathParamRegex = regexp.MustCompile(`{([^}]+)}`)
+
+// buildConvertedOpenAPI generates an OpenAPI 3.0 definition with the operations of the API
+func buildConvertedOpenAPI(api *convertedAPI) ([]byte, error) {
+	paths := make(map[string]map[string]interface{})
+	for _, op := range api.Operations {
+		if paths[op.Target] == nil {
+			paths[op.Target] = make(map[string]interface{})
+		}
+		operation := map[string]interface{}{
+			"responses": map[string]interface{}{
+				"default": map[string]interface{}{"description": "Default response"},
+			},
+		}
+		var parameters []interface{}
+		for _, match := range convertPathParamRegex.FindAllStringSubmatch(op.Target, -1) {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     match[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+		paths[op.Target][strings.ToLower(op.Verb)] = operation
+	}
+	info := map[string]interface{}{"title": api.Name, "version": api.Version}
+	if api.Description != "" {
+		info["description"] = api.Description
+	}
+	definition := map[string]interface{}{
+		"openapi":         "3.0.1",
+		"info":            info,
+		"paths":           paths,
+		"x-wso2-basePath": api.Context,
+	}
+	return yaml.Marshal(definition)
+}
+
+// writeConvertedRateLimitingPolicy writes a rate limit as an advanced rate limiting policy in the format exported by
+// apictl export policy rate-limiting
+func writeConvertedRateLimitingPolicy(limit *convertedRateLimit, apimVersion, dir string) error {
+	name := limit.PolicyName()
+	policy := utils.ExportThrottlePolicy{
+		Type:    applyArtifactTypeThrottlingPolicy,
+		Subtype: ExportPolicyTypeAdvanced,
+		Version: apimVersion,
+		Data: yaml2.MapSlice{
+			{Key: "policyName", Value: name},
+			{Key: "displayName", Value: name},
+			{Key: "description", Value: fmt.Sprintf("Allows %d requests per %d %s", limit.RequestCount,
+				limit.UnitTime, limit.TimeUnit)},
+			{Key: "isDeployed", Value: true},
+			{Key: "type", Value: "AdvancedThrottlePolicy"},
+			{Key: "defaultLimit", Value: yaml2.MapSlice{
+				{Key: "type", Value: "REQUESTCOUNTLIMIT"},
+				{Key: "requestCount", Value: yaml2.MapSlice{
+					{Key: "timeUnit", Value: limit.TimeUnit},
+					{Key: "unitTime", Value: limit.UnitTime},
+					{Key: "requestCount", Value: limit.RequestCount},
+				}},
+			}},
+		},
+	}
+	content, err := yaml2.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	policyPath := filepath.Join(dir, name+".yaml")
+	utils.Logln(utils.LogPrefixInfo + "Writing " + policyPath)
+	return ioutil.WriteFile(policyPath, content, os.ModePerm)
+}
+
+// convertXMLNode is a generic XML element used to read the policies of Azure API Management and Apigee
+type convertXMLNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr       `xml:",any,attr"`
+	Content string           `xml:",chardata"`
+	Nodes   []convertXMLNode `xml:",any"`
+}
+
+// parseConvertXML parses an XML document to its root element
+func parseConvertXML(content []byte) (*convertXMLNode, error) {
+	root := &convertXMLNode{}
+	if err := xml.Unmarshal(content, root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// name returns the local name of the element
+func (n *convertXMLNode) name() string {
+	return n.XMLName.Local
+}
+
+// text returns the trimmed text content of the element
+func (n *convertXMLNode) text() string {
+	return strings.TrimSpace(n.Content)
+}
+
+// attr returns the value of an attribute of the element
+func (n *convertXMLNode) attr(name string) string {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// child returns the first child element with the given name, or an empty element if there is no such child
+func (n *convertXMLNode) child(name string) *convertXMLNode {
+	for i := range n.Nodes {
+		if n.Nodes[i].name() == name {
+			return &n.Nodes[i]
+		}
+	}
+	return &convertXMLNode{}
+}
+
+// children returns the child elements with the given name
+func (n *convertXMLNode) children(name string) []*convertXMLNode {
+	var nodes []*convertXMLNode
+	for i := range n.Nodes {
+		if n.Nodes[i].name() == name {
+			nodes = append(nodes, &n.Nodes[i])
+		}
+	}
+	return nodes
+}
+
+// convertAPIName removes the characters API Manager does not allow in API names
+func convertAPIName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(" ~!@#;:%^*()+={}|\\<>\"',&$[]/", r) {
+			return -1
+		}
+		return r
+	}, name)
+}
+
+// convertContext returns the context of an API from a base path
+func convertContext(basePath string) string {
+	basePath = strings.Trim(strings.TrimSpace(basePath), "/")
+	return "/" + basePath
+}
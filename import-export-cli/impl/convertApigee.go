@@ -0,0 +1,387 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// apigeeBundleDir is the directory of a proxy bundle holding the proxy configuration
+const apigeeBundleDir = "apiproxy"
+
+// apigeeTimeUnits are the time units of the Quota policy with their length in seconds
+var apigeeTimeUnits = map[string]int64{
+	"minute": 60,
+	"hour":   60 * 60,
+	"day":    24 * 60 * 60,
+	"week":   7 * 24 * 60 * 60,
+	"month":  30 * 24 * 60 * 60,
+}
+
+// Conditions of the flows of a proxy endpoint
+var (
+	apigeePathConditionRegex = regexp.MustCompile(`proxy\.pathsuffix\s+(?:MatchesPath|Matches|~|=|==|Equals)\s+"([^"]*)"`)
+	apigeeVerbConditionRegex = regexp.MustCompile(`request\.verb\s+(?:=|==|Equals)\s+"([^"]*)"`)
+	apigeeSpikeArrestRegex   = regexp.MustCompile(`^(\d+)(ps|pm)$`)
+)
+
+// apigeeBundle is an extracted proxy bundle
+type apigeeBundle struct {
+	dir      string
+	policies map[string]*convertXMLNode
+}
+
+// readApigeeExport reads an Apigee proxy bundle, either a zip file or a directory containing the apiproxy directory.
+// The proxy is converted to an API and the conditional flows of its proxy endpoint are converted to operations.
+func readApigeeExport(exportPath string) ([]*convertedAPI, []string, error) {
+	dir := exportPath
+	if info, err := os.Stat(exportPath); err != nil {
+		return nil, nil, err
+	} else if !info.IsDir() {
+		tmpDir, err := ioutil.TempDir("", "apictl-apigee")
+		if err != nil {
+			return nil, nil, err
+		}
+		defer os.RemoveAll(tmpDir)
+		utils.Logln(utils.LogPrefixInfo+"Extracting", exportPath, "to", tmpDir)
+		if _, err := utils.Unzip(exportPath, tmpDir); err != nil {
+			return nil, nil, fmt.Errorf("invalid proxy bundle %s: %w", exportPath, err)
+		}
+		dir = tmpDir
+	}
+	if filepath.Base(dir) != apigeeBundleDir {
+		dir = filepath.Join(dir, apigeeBundleDir)
+	}
+	if exists, _ := utils.IsDirExists(dir); !exists {
+		return nil, nil, fmt.Errorf("%s directory is not found in the proxy bundle %s", apigeeBundleDir, exportPath)
+	}
+
+	bundle := &apigeeBundle{dir: dir, policies: make(map[string]*convertXMLNode)}
+	proxy, err := bundle.readProxy()
+	if err != nil {
+		return nil, nil, err
+	}
+	policyFiles, _ := filepath.Glob(filepath.Join(dir, "policies", "*.xml"))
+	for _, policyFile := range policyFiles {
+		policy, err := readApigeeXML(policyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		name := policy.attr("name")
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(policyFile), ".xml")
+		}
+		bundle.policies[name] = policy
+	}
+
+	var unmapped []string
+	for _, resource := range []string{"resources", "sharedflows"} {
+		if exists, _ := utils.IsDirExists(filepath.Join(dir, resource)); exists {
+			unmapped = append(unmapped, fmt.Sprintf("%s of the proxy bundle are not converted", resource))
+		}
+	}
+	api, err := bundle.convertProxy(proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []*convertedAPI{api}, unmapped, nil
+}
+
+// readApigeeXML reads and parses an XML file of a bundle
+func readApigeeXML(path string) (*convertXMLNode, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	node, err := parseConvertXML(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XML file %s: %w", path, err)
+	}
+	return node, nil
+}
+
+// readProxy reads the proxy descriptor, which is the only XML file in the root of the apiproxy directory
+func (b *apigeeBundle) readProxy() (*convertXMLNode, error) {
+	files, _ := filepath.Glob(filepath.Join(b.dir, "*.xml"))
+	if len(files) == 0 {
+		return nil, fmt.Errorf("proxy descriptor is not found in %s", b.dir)
+	}
+	return readApigeeXML(files[0])
+}
+
+// convertProxy converts the proxy descriptor with its proxy and target endpoints to an API
+func (b *apigeeBundle) convertProxy(proxy *convertXMLNode) (*convertedAPI, error) {
+	api := &convertedAPI{
+		Name:        convertAPIName(proxy.attr("name")),
+		Description: proxy.child("Description").text(),
+	}
+	if displayName := convertAPIName(proxy.child("DisplayName").text()); displayName != "" {
+		api.Name = displayName
+	}
+
+	proxyFiles, _ := filepath.Glob(filepath.Join(b.dir, "proxies", "*.xml"))
+	sort.Strings(proxyFiles)
+	if len(proxyFiles) == 0 {
+		return nil, fmt.Errorf("proxy endpoint is not found in %s", filepath.Join(b.dir, "proxies"))
+	}
+	for _, proxyFile := range proxyFiles[1:] {
+		api.unmapped("proxy endpoint %s is not converted, only a single proxy endpoint is supported",
+			filepath.Base(proxyFile))
+	}
+	endpoint, err := readApigeeXML(proxyFiles[0])
+	if err != nil {
+		return nil, err
+	}
+	basePath := endpoint.child("HTTPProxyConnection").child("BasePath").text()
+	if basePath == "" {
+		basePath = proxy.child("Basepaths").text()
+	}
+	if basePath == "" {
+		basePath = strings.ToLower(api.Name)
+	}
+	api.Context = convertContext(basePath)
+
+	for _, flowName := range []string{"PreFlow", "PostFlow"} {
+		b.applySteps(api, []*convertedFlow{&api.convertedFlow}, endpoint.child(flowName), flowName)
+	}
+	for _, flow := range endpoint.child("Flows").children("Flow") {
+		condition := flow.child("Condition").text()
+		target := "/*"
+		if match := apigeePathConditionRegex.FindStringSubmatch(condition); match != nil {
+			target = apigeeResourcePath(match[1])
+		}
+		var verbs []string
+		if match := apigeeVerbConditionRegex.FindStringSubmatch(condition); match != nil {
+			verbs = []string{match[1]}
+		}
+		var flows []*convertedFlow
+		for _, operation := range api.addOperation(target, verbs...) {
+			flows = append(flows, &operation.convertedFlow)
+		}
+		b.applySteps(api, flows, flow, "flow "+flow.attr("name"))
+	}
+	if len(api.Operations) == 0 {
+		api.addOperation("/*")
+	}
+	if faultRules := endpoint.child("FaultRules").children("FaultRule"); len(faultRules) > 0 {
+		api.unmapped("%d fault rules of the proxy endpoint are not mapped", len(faultRules))
+	}
+
+	if err := b.convertTarget(api, endpoint); err != nil {
+		return nil, err
+	}
+	return api, nil
+}
+
+// apigeeResourcePath converts the path of a flow condition to a resource path. Wildcards in the middle of the path
+// are converted to path parameters and a trailing wildcard matches the rest of the path.
+func apigeeResourcePath(pattern string) string {
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	params := 0
+	for i, segment := range segments {
+		if segment != "*" && segment != "**" {
+			continue
+		}
+		if i == len(segments)-1 {
+			segments[i] = "*"
+			continue
+		}
+		params++
+		segments[i] = "{param" + strconv.Itoa(params) + "}"
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// convertTarget sets the endpoint of the API from the target endpoint the proxy endpoint routes to
+func (b *apigeeBundle) convertTarget(api *convertedAPI, endpoint *convertXMLNode) error {
+	routeRules := endpoint.children("RouteRule")
+	targetName := ""
+	for _, rule := range routeRules {
+		if rule.child("Condition").text() != "" {
+			api.unmapped("conditional route rule %s is not mapped", rule.attr("name"))
+			continue
+		}
+		if url := rule.child("URL").text(); url != "" {
+			api.EndpointUrls = []string{url}
+			return nil
+		}
+		targetName = rule.child("TargetEndpoint").text()
+	}
+	if targetName == "" {
+		if len(routeRules) > 0 {
+			// a route rule without a target is a proxy without a backend
+			api.unmapped("the proxy does not route to a target endpoint")
+			return nil
+		}
+		targetName = "default"
+	}
+	targetPath := filepath.Join(b.dir, "targets", targetName+".xml")
+	if !utils.IsFileExist(targetPath) {
+		api.unmapped("target endpoint %s is not found", targetName)
+		return nil
+	}
+	target, err := readApigeeXML(targetPath)
+	if err != nil {
+		return err
+	}
+	connection := target.child("HTTPTargetConnection")
+	if url := connection.child("URL").text(); url != "" {
+		api.EndpointUrls = []string{url}
+	}
+	if servers := connection.child("LoadBalancer").children("Server"); len(servers) > 0 {
+		var names []string
+		for _, server := range servers {
+			names = append(names, server.attr("name"))
+		}
+		api.unmapped("target servers %s of the load balancer are not mapped. Set the endpoints of the API",
+			strings.Join(names, ", "))
+	}
+	for _, flowName := range []string{"PreFlow", "PostFlow"} {
+		b.applySteps(api, []*convertedFlow{&api.convertedFlow}, target.child(flowName),
+			"target endpoint "+flowName)
+	}
+	if flows := target.child("Flows").children("Flow"); len(flows) > 0 {
+		api.unmapped("%d conditional flows of the target endpoint are not mapped", len(flows))
+	}
+	return nil
+}
+
+// applySteps maps the policies of the request and response steps of a flow
+func (b *apigeeBundle) applySteps(api *convertedAPI, flows []*convertedFlow, flow *convertXMLNode, owner string) {
+	for _, direction := range []string{"Request", "Response"} {
+		for _, step := range flow.child(direction).children("Step") {
+			name := step.child("Name").text()
+			source := fmt.Sprintf("%s: policy %s", owner, name)
+			if step.child("Condition").text() != "" {
+				api.unmapped("%s: the condition of the step is not mapped", source)
+			}
+			policy, ok := b.policies[name]
+			if !ok {
+				api.unmapped("%s is not found in the bundle", source)
+				continue
+			}
+			if !applyApigeePolicy(api, flows, policy, direction == "Response", source) {
+				api.unmapped("%s of type %s is not mapped", source, policy.name())
+			}
+		}
+	}
+}
+
+// applyApigeePolicy maps a policy to the rate limits, policies or security of the API or its operations. Returns
+// false if the policy is not supported.
+func applyApigeePolicy(api *convertedAPI, flows []*convertedFlow, policy *convertXMLNode, response bool,
+	source string) bool {
+	apiLevel := len(flows) == 1 && flows[0] == &api.convertedFlow
+	switch policy.name() {
+	case "Quota":
+		count, _ := strconv.Atoi(policy.child("Allow").attr("count"))
+		interval, _ := strconv.Atoi(policy.child("Interval").text())
+		if interval == 0 {
+			interval = 1
+		}
+		unitSeconds, ok := apigeeTimeUnits[policy.child("TimeUnit").text()]
+		if !ok || count == 0 {
+			api.unmapped("%s: the quota is not mapped since it is not a fixed request count", source)
+			return true
+		}
+		api.setRateLimit(flows, source, count, int64(interval)*unitSeconds)
+	case "SpikeArrest":
+		match := apigeeSpikeArrestRegex.FindStringSubmatch(policy.child("Rate").text())
+		if match == nil {
+			api.unmapped("%s: rate %s is not mapped", source, policy.child("Rate").text())
+			return true
+		}
+		count, _ := strconv.Atoi(match[1])
+		seconds := int64(60)
+		if match[2] == "ps" {
+			seconds = 1
+		}
+		api.setRateLimit(flows, source, count, seconds)
+	case "AssignMessage":
+		if assignTo := policy.child("AssignTo"); assignTo.text() != "" || assignTo.attr("createNew") == "true" {
+			api.unmapped("%s: assigning to a new message is not mapped", source)
+			return true
+		}
+		for _, flow := range flows {
+			applyApigeeAssignMessage(flow, policy, response)
+		}
+		// the unsupported elements are reported once for all the flows
+		for _, element := range policy.Nodes {
+			switch element.name() {
+			case "Add", "Set", "Remove":
+				for _, item := range element.Nodes {
+					if item.name() != "Headers" && (item.name() != "QueryParams" || response) {
+						api.unmapped("%s: %s of %s is not mapped", source, strings.ToLower(element.name()),
+							item.name())
+					}
+				}
+			case "AssignTo", "IgnoreUnresolvedVariables", "DisplayName":
+			default:
+				api.unmapped("%s: %s is not mapped", source, element.name())
+			}
+		}
+	case "VerifyAPIKey":
+		if !apiLevel {
+			return false
+		}
+		api.addSecurityScheme(convertSecurityAPIKey)
+		if ref := policy.child("APIKey").attr("ref"); strings.HasPrefix(ref, "request.header.") {
+			api.APIKeyHeader = strings.TrimPrefix(ref, "request.header.")
+		}
+	case "OAuthV2":
+		if !apiLevel || policy.child("Operation").text() != "VerifyAccessToken" {
+			return false
+		}
+		api.addSecurityScheme(convertSecurityOAuth2)
+	default:
+		return false
+	}
+	return true
+}
+
+// applyApigeeAssignMessage maps the headers and the query parameters added, set or removed by an AssignMessage policy
+func applyApigeeAssignMessage(flow *convertedFlow, policy *convertXMLNode, response bool) {
+	for _, action := range []string{"Add", "Set"} {
+		for _, header := range policy.child(action).child("Headers").children("Header") {
+			flow.addHeader(response, header.attr("name"), header.text())
+		}
+		for _, param := range policy.child(action).child("QueryParams").children("QueryParam") {
+			if !response {
+				flow.addQueryParam(param.attr("name"), param.text())
+			}
+		}
+	}
+	for _, header := range policy.child("Remove").child("Headers").children("Header") {
+		flow.removeHeader(response, header.attr("name"))
+	}
+	for _, param := range policy.child("Remove").child("QueryParams").children("QueryParam") {
+		if !response {
+			flow.removeQueryParam(param.attr("name"))
+		}
+	}
+}
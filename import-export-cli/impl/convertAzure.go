@@ -0,0 +1,366 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+)
+
+// Types of the resources of an Azure API Management ARM template
+const (
+	azureResourceAPI             = "Microsoft.ApiManagement/service/apis"
+	azureResourceOperation       = "Microsoft.ApiManagement/service/apis/operations"
+	azureResourceAPIPolicy       = "Microsoft.ApiManagement/service/apis/policies"
+	azureResourceOperationPolicy = "Microsoft.ApiManagement/service/apis/operations/policies"
+)
+
+// azureTemplate is an ARM template exported from Azure API Management
+type azureTemplate struct {
+	Resources []azureResource `json:"resources"`
+}
+
+// azureResource is a resource of an ARM template. The properties depend on the type of the resource.
+type azureResource struct {
+	Type       string          `json:"type"`
+	Name       string          `json:"name"`
+	Properties json.RawMessage `json:"properties"`
+}
+
+// azureAPIProperties are the properties of an API resource
+type azureAPIProperties struct {
+	DisplayName                   string `json:"displayName"`
+	Description                   string `json:"description"`
+	Path                          string `json:"path"`
+	ServiceURL                    string `json:"serviceUrl"`
+	APIVersion                    string `json:"apiVersion"`
+	Type                          string `json:"type"`
+	SubscriptionRequired          *bool  `json:"subscriptionRequired"`
+	SubscriptionKeyParameterNames struct {
+		Header string `json:"header"`
+	} `json:"subscriptionKeyParameterNames"`
+	IsCurrent *bool `json:"isCurrent"`
+}
+
+// azureOperationProperties are the properties of an operation resource
+type azureOperationProperties struct {
+	Method      string `json:"method"`
+	URLTemplate string `json:"urlTemplate"`
+}
+
+// azurePolicyProperties are the properties of a policy resource
+type azurePolicyProperties struct {
+	Format string `json:"format"`
+	Value  string `json:"value"`
+}
+
+// azureAPI holds an API resource with the resources that belong to it
+type azureAPI struct {
+	api        *convertedAPI
+	operations map[string][]*convertedOperation
+}
+
+// azureSecondsRegex matches the renewal periods of the rate limit policies
+var azureSecondsRegex = regexp.MustCompile(`^\d+$`)
+
+// readAzureExport reads an ARM template exported from Azure API Management. The API resources are converted to APIs
+// and the operation resources are converted to their operations. Bicep files need to be compiled to ARM templates.
+func readAzureExport(exportPath string) ([]*convertedAPI, []string, error) {
+	if strings.EqualFold(filepath.Ext(exportPath), ".bicep") {
+		return nil, nil, fmt.Errorf("bicep files are not supported. Compile %s to an ARM template with "+
+			"'az bicep build --file %s' and convert the generated JSON file", exportPath, exportPath)
+	}
+	content, err := ioutil.ReadFile(exportPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &azureTemplate{}
+	if err := json.Unmarshal(content, template); err != nil {
+		return nil, nil, fmt.Errorf("invalid ARM template %s: %w", exportPath, err)
+	}
+
+	var unmapped []string
+	apis := make(map[string]*azureAPI)
+	var names []string
+	// APIs are read first since the resources of a template are not ordered
+	for _, resource := range template.Resources {
+		if resource.Type != azureResourceAPI {
+			continue
+		}
+		segments := azureResourceNames(resource.Name)
+		if len(segments) < 1 {
+			continue
+		}
+		properties := azureAPIProperties{}
+		if err := json.Unmarshal(resource.Properties, &properties); err != nil {
+			return nil, nil, fmt.Errorf("invalid properties of the API %s: %w", resource.Name, err)
+		}
+		// Revisions other than the current one are named as <api>;rev=<revision>
+		name := segments[len(segments)-1]
+		if strings.Contains(name, ";rev=") {
+			unmapped = append(unmapped, fmt.Sprintf("revision %s is not converted", name))
+			continue
+		}
+		apis[name] = &azureAPI{api: convertAzureAPI(name, properties),
+			operations: make(map[string][]*convertedOperation)}
+		names = append(names, name)
+	}
+
+	for _, resource := range template.Resources {
+		segments := azureResourceNames(resource.Name)
+		switch resource.Type {
+		case azureResourceAPI:
+		case azureResourceOperation:
+			if len(segments) < 2 || apis[segments[len(segments)-2]] == nil {
+				continue
+			}
+			properties := azureOperationProperties{}
+			if err := json.Unmarshal(resource.Properties, &properties); err != nil {
+				return nil, nil, fmt.Errorf("invalid properties of the operation %s: %w", resource.Name, err)
+			}
+			api := apis[segments[len(segments)-2]]
+			target := strings.SplitN(properties.URLTemplate, "?", 2)[0]
+			if !strings.HasPrefix(target, "/") {
+				target = "/" + target
+			}
+			operationName := segments[len(segments)-1]
+			api.operations[operationName] = append(api.operations[operationName],
+				api.api.addOperation(target, properties.Method)...)
+		case azureResourceAPIPolicy, azureResourceOperationPolicy:
+		default:
+			if strings.HasPrefix(resource.Type, azureResourceAPI+"/") {
+				unmapped = append(unmapped, fmt.Sprintf("resource %s of type %s is not converted",
+					strings.Join(segments, "/"), resource.Type))
+			}
+		}
+	}
+
+	// Policies are applied once the operations are known
+	for _, resource := range template.Resources {
+		if resource.Type != azureResourceAPIPolicy && resource.Type != azureResourceOperationPolicy {
+			continue
+		}
+		segments := azureResourceNames(resource.Name)
+		properties := azurePolicyProperties{}
+		if err := json.Unmarshal(resource.Properties, &properties); err != nil {
+			return nil, nil, fmt.Errorf("invalid properties of the policy %s: %w", resource.Name, err)
+		}
+		if resource.Type == azureResourceAPIPolicy {
+			if len(segments) < 2 || apis[segments[len(segments)-2]] == nil {
+				continue
+			}
+			api := apis[segments[len(segments)-2]].api
+			applyAzurePolicy(api, []*convertedFlow{&api.convertedFlow}, properties, "API policy")
+			continue
+		}
+		if len(segments) < 3 || apis[segments[len(segments)-3]] == nil {
+			continue
+		}
+		api := apis[segments[len(segments)-3]]
+		operationName := segments[len(segments)-2]
+		var flows []*convertedFlow
+		for _, operation := range api.operations[operationName] {
+			flows = append(flows, &operation.convertedFlow)
+		}
+		applyAzurePolicy(api.api, flows, properties, "operation "+operationName)
+	}
+
+	sort.Strings(names)
+	var converted []*convertedAPI
+	for _, name := range names {
+		api := apis[name].api
+		if len(api.Operations) == 0 {
+			api.addOperation("/*")
+		}
+		converted = append(converted, api)
+	}
+	return converted, unmapped, nil
+}
+
+// Parts of the ARM template expressions of the resource names
+var (
+	azureNameFunctionRegex = regexp.MustCompile(`(parameters|variables)\('[^']*'\)`)
+	azureNameLiteralRegex  = regexp.MustCompile(`'([^']*)'`)
+)
+
+// azureResourceNames returns the names of the resource and its parents without the name of the service. The names
+// of exported resources are expressions such as [concat(parameters('service_name'), '/petstore/get-pets')].
+func azureResourceNames(name string) []string {
+	var path string
+	if strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]") {
+		name = azureNameFunctionRegex.ReplaceAllString(name, "")
+		for _, match := range azureNameLiteralRegex.FindAllStringSubmatch(name, -1) {
+			path += match[1]
+		}
+	} else {
+		// drop the name of the service
+		segments := strings.SplitN(name, "/", 2)
+		if len(segments) < 2 {
+			return nil
+		}
+		path = segments[1]
+	}
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// convertAzureAPI converts the properties of an API resource to an API
+func convertAzureAPI(name string, properties azureAPIProperties) *convertedAPI {
+	api := &convertedAPI{
+		Name:        convertAPIName(name),
+		Version:     properties.APIVersion,
+		Description: properties.Description,
+		Context:     convertContext(properties.Path),
+	}
+	if displayName := convertAPIName(properties.DisplayName); displayName != "" {
+		api.Name = displayName
+	}
+	if properties.Path == "" {
+		api.Context = convertContext(strings.ToLower(api.Name))
+	}
+	if properties.ServiceURL != "" {
+		api.EndpointUrls = []string{properties.ServiceURL}
+	}
+	if properties.Type != "" && properties.Type != "http" {
+		api.unmapped("API type %s is converted as an HTTP API", properties.Type)
+	}
+	if properties.SubscriptionRequired == nil || *properties.SubscriptionRequired {
+		api.addSecurityScheme(convertSecurityAPIKey)
+		api.APIKeyHeader = properties.SubscriptionKeyParameterNames.Header
+	}
+	return api
+}
+
+// applyAzurePolicy maps the elements of a policy document to the rate limits, policies, security, CORS
+// configuration and endpoints of the API or its operations
+func applyAzurePolicy(api *convertedAPI, flows []*convertedFlow, properties azurePolicyProperties, owner string) {
+	if properties.Format != "" && properties.Format != "xml" && properties.Format != "rawxml" {
+		api.unmapped("%s: policy of format %s is not mapped", owner, properties.Format)
+		return
+	}
+	root, err := parseConvertXML([]byte(properties.Value))
+	if err != nil {
+		api.unmapped("%s: invalid policy document: %v", owner, err)
+		return
+	}
+	apiLevel := len(flows) == 1 && flows[0] == &api.convertedFlow
+	for _, section := range root.Nodes {
+		for i := range section.Nodes {
+			element := &section.Nodes[i]
+			source := fmt.Sprintf("%s: %s policy %s", owner, section.name(), element.name())
+			if element.name() == "base" || (section.name() == "backend" && element.name() == "forward-request") {
+				continue
+			}
+			if section.name() != "inbound" && section.name() != "outbound" {
+				api.unmapped("%s is not mapped", source)
+				continue
+			}
+			response := section.name() == "outbound"
+			if !applyAzurePolicyElement(api, flows, element, response, apiLevel, source) {
+				api.unmapped("%s is not mapped", source)
+			}
+		}
+	}
+}
+
+// applyAzurePolicyElement maps a single policy element. Returns false if the element is not supported.
+func applyAzurePolicyElement(api *convertedAPI, flows []*convertedFlow, element *convertXMLNode, response,
+	apiLevel bool, source string) bool {
+	switch element.name() {
+	case "rate-limit", "quota":
+		if response {
+			return false
+		}
+		count, _ := strconv.Atoi(element.attr("calls"))
+		period := element.attr("renewal-period")
+		if !azureSecondsRegex.MatchString(period) {
+			api.unmapped("%s: renewal period %s is not mapped", source, period)
+			return true
+		}
+		seconds, _ := strconv.ParseInt(period, 10, 64)
+		if len(element.Nodes) > 0 {
+			api.unmapped("%s: limits of the individual operations are not mapped", source)
+		}
+		api.setRateLimit(flows, source, count, seconds)
+	case "set-header", "set-query-parameter":
+		name := element.attr("name")
+		value := element.child("value").text()
+		if strings.Contains(value, "@(") || strings.Contains(value, "{{") {
+			api.unmapped("%s: expression or named value %s of %s is not mapped", source, value, name)
+			return true
+		}
+		remove := element.attr("exists-action") == "delete"
+		for _, flow := range flows {
+			switch {
+			case element.name() == "set-header" && remove:
+				flow.removeHeader(response, name)
+			case element.name() == "set-header":
+				flow.addHeader(response, name, value)
+			case response:
+				return false
+			case remove:
+				flow.removeQueryParam(name)
+			default:
+				flow.addQueryParam(name, value)
+			}
+		}
+	case "cors":
+		if !apiLevel || response {
+			return false
+		}
+		cors := &v2.CorsConfiguration{CorsConfigurationEnabled: true}
+		for _, origin := range element.child("allowed-origins").children("origin") {
+			cors.AccessControlAllowOrigins = append(cors.AccessControlAllowOrigins, origin.text())
+		}
+		for _, method := range element.child("allowed-methods").children("method") {
+			cors.AccessControlAllowMethods = append(cors.AccessControlAllowMethods, method.text())
+		}
+		for _, header := range element.child("allowed-headers").children("header") {
+			cors.AccessControlAllowHeaders = append(cors.AccessControlAllowHeaders, header.text())
+		}
+		cors.AccessControlAllowCredentials = element.attr("allow-credentials") == "true"
+		api.Cors = cors
+	case "set-backend-service":
+		baseURL := element.attr("base-url")
+		if !apiLevel || response || baseURL == "" || strings.Contains(baseURL, "{{") {
+			return false
+		}
+		api.EndpointUrls = []string{baseURL}
+	case "validate-jwt", "validate-azure-ad-token":
+		if !apiLevel || response {
+			return false
+		}
+		api.addSecurityScheme(convertSecurityOAuth2)
+		api.unmapped("%s: the issuers and keys of the tokens are not mapped. Configure a key manager", source)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,441 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+)
+
+// kongDefaultTargetPort is the port of the targets of an upstream that do not specify a port
+const kongDefaultTargetPort = 8000
+
+// kongConfig is a declarative configuration of Kong as exported by decK
+type kongConfig struct {
+	Services  []kongService  `json:"services"`
+	Routes    []kongRoute    `json:"routes"`
+	Upstreams []kongUpstream `json:"upstreams"`
+	Plugins   []kongPlugin   `json:"plugins"`
+	Consumers []interface{}  `json:"consumers"`
+}
+
+// kongService is a service of Kong. A service is converted to an API.
+type kongService struct {
+	Name     string       `json:"name"`
+	URL      string       `json:"url"`
+	Protocol string       `json:"protocol"`
+	Host     string       `json:"host"`
+	Port     int          `json:"port"`
+	Path     string       `json:"path"`
+	Tags     []string     `json:"tags"`
+	Routes   []kongRoute  `json:"routes"`
+	Plugins  []kongPlugin `json:"plugins"`
+}
+
+// kongRoute is a route of a service. The paths and methods of the routes are converted to operations.
+type kongRoute struct {
+	Name      string       `json:"name"`
+	Service   interface{}  `json:"service"`
+	Paths     []string     `json:"paths"`
+	Methods   []string     `json:"methods"`
+	Hosts     []string     `json:"hosts"`
+	StripPath *bool        `json:"strip_path"`
+	Plugins   []kongPlugin `json:"plugins"`
+}
+
+// kongUpstream is a load balanced upstream of Kong referred by the host of services
+type kongUpstream struct {
+	Name    string `json:"name"`
+	Targets []struct {
+		Target string `json:"target"`
+		Weight *int   `json:"weight"`
+	} `json:"targets"`
+}
+
+// kongPlugin is a plugin applied globally, to a service or to a route
+type kongPlugin struct {
+	Name    string                 `json:"name"`
+	Enabled *bool                  `json:"enabled"`
+	Service interface{}            `json:"service"`
+	Route   interface{}            `json:"route"`
+	Config  map[string]interface{} `json:"config"`
+}
+
+// kongRateLimitPeriods are the periods of the rate-limiting plugin with their length in seconds
+var kongRateLimitPeriods = []struct {
+	name    string
+	seconds int64
+}{
+	{"second", 1},
+	{"minute", 60},
+	{"hour", 60 * 60},
+	{"day", 24 * 60 * 60},
+	{"month", 30 * 24 * 60 * 60},
+	{"year", 365 * 24 * 60 * 60},
+}
+
+// readKongExport reads a decK declarative configuration in YAML or JSON. Each service is converted to an API and
+// the routes of the service are converted to its operations.
+func readKongExport(exportPath string) ([]*convertedAPI, []string, error) {
+	content, err := ioutil.ReadFile(exportPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	config := &kongConfig{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, nil, fmt.Errorf("invalid Kong declarative configuration %s: %w", exportPath, err)
+	}
+
+	var unmapped []string
+	if len(config.Consumers) > 0 {
+		unmapped = append(unmapped, fmt.Sprintf("%d consumers are not converted. Create applications and "+
+			"subscriptions for them", len(config.Consumers)))
+	}
+	upstreams := make(map[string]kongUpstream)
+	for _, upstream := range config.Upstreams {
+		upstreams[upstream.Name] = upstream
+	}
+
+	var apis []*convertedAPI
+	for i := range config.Services {
+		service := &config.Services[i]
+		// routes and plugins could be declared at the top level referring the service
+		for _, route := range config.Routes {
+			if kongReference(route.Service) == service.Name {
+				service.Routes = append(service.Routes, route)
+			}
+		}
+		var routePlugins []kongPlugin
+		for _, plugin := range config.Plugins {
+			switch {
+			case plugin.Route != nil:
+				routePlugins = append(routePlugins, plugin)
+			case kongReference(plugin.Service) == service.Name:
+				service.Plugins = append(service.Plugins, plugin)
+			}
+		}
+		for j := range service.Routes {
+			for _, plugin := range routePlugins {
+				if kongReference(plugin.Route) == service.Routes[j].Name {
+					service.Routes[j].Plugins = append(service.Routes[j].Plugins, plugin)
+				}
+			}
+		}
+		apis = append(apis, convertKongService(service, upstreams))
+	}
+
+	for _, plugin := range config.Plugins {
+		if plugin.Service == nil && plugin.Route == nil && kongPluginEnabled(plugin) {
+			unmapped = append(unmapped, fmt.Sprintf("global plugin %s is not converted. Apply it to the APIs "+
+				"or configure it in the gateway", plugin.Name))
+		}
+	}
+	return apis, unmapped, nil
+}
+
+// kongReference returns the name or the id of a service or a route referred by a route or a plugin
+func kongReference(reference interface{}) string {
+	switch ref := reference.(type) {
+	case string:
+		return ref
+	case map[string]interface{}:
+		for _, key := range []string{"name", "id"} {
+			if value, ok := ref[key].(string); ok {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// kongPluginEnabled returns false if the plugin is explicitly disabled
+func kongPluginEnabled(plugin kongPlugin) bool {
+	return plugin.Enabled == nil || *plugin.Enabled
+}
+
+// convertKongService converts a service with its routes and plugins to an API
+func convertKongService(service *kongService, upstreams map[string]kongUpstream) *convertedAPI {
+	api := &convertedAPI{Name: convertAPIName(service.Name), Tags: service.Tags}
+
+	protocol, host, port, path := service.Protocol, service.Host, service.Port, service.Path
+	if service.URL != "" {
+		protocol, host, port, path = kongParseURL(service.URL)
+	}
+	if protocol == "" {
+		protocol = "http"
+	}
+	if upstream, ok := upstreams[host]; ok {
+		for _, target := range upstream.Targets {
+			if target.Weight != nil && *target.Weight == 0 {
+				continue
+			}
+			address := target.Target
+			if _, _, err := net.SplitHostPort(address); err != nil {
+				address = net.JoinHostPort(address, strconv.Itoa(kongDefaultTargetPort))
+			}
+			api.EndpointUrls = append(api.EndpointUrls, protocol+"://"+address+path)
+		}
+		if len(api.EndpointUrls) > 1 {
+			api.EndpointType = v2.EpLoadbalance
+			api.unmapped("upstream %s: target weights and health checks are not mapped, the targets are load "+
+				"balanced in round robin", host)
+		}
+	} else if host != "" {
+		address := host
+		if port != 0 && !(protocol == "http" && port == 80) && !(protocol == "https" && port == 443) {
+			address = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+		api.EndpointUrls = []string{protocol + "://" + address + path}
+	}
+
+	// A single route that strips its path is the context of the API, since Kong forwards only the rest of the path
+	// to the service as API Manager does. Otherwise the routes are resources of an API named after the service.
+	api.Context = convertContext(api.Name)
+	routeContext := len(service.Routes) == 1 && len(service.Routes[0].Paths) == 1 &&
+		kongStripPath(service.Routes[0]) && !strings.HasPrefix(service.Routes[0].Paths[0], "~")
+	if routeContext {
+		api.Context = convertContext(service.Routes[0].Paths[0])
+	}
+	for _, route := range service.Routes {
+		if len(route.Hosts) > 0 {
+			api.unmapped("route %s: host based routing to %s is not mapped", route.Name,
+				strings.Join(route.Hosts, ", "))
+		}
+		paths := route.Paths
+		if len(paths) == 0 {
+			paths = []string{"/"}
+		}
+		var operations []*convertedOperation
+		for _, path := range paths {
+			if strings.HasPrefix(path, "~") {
+				api.unmapped("route %s: regex path %s is not mapped", route.Name, path)
+				continue
+			}
+			target := "/*"
+			if !routeContext {
+				if kongStripPath(route) {
+					api.unmapped("route %s: the path %s is stripped by Kong but is part of the resource path in "+
+						"API Manager", route.Name, path)
+				}
+				target = strings.TrimSuffix(path, "/") + "/*"
+			}
+			operations = append(operations, api.addOperation(target, route.Methods...)...)
+		}
+		var flows []*convertedFlow
+		for _, operation := range operations {
+			flows = append(flows, &operation.convertedFlow)
+		}
+		for _, plugin := range route.Plugins {
+			if kongPluginEnabled(plugin) && len(flows) > 0 {
+				applyKongPlugin(api, flows, plugin, "route "+route.Name)
+			}
+		}
+	}
+	if len(api.Operations) == 0 {
+		api.addOperation("/*")
+	}
+	for _, plugin := range service.Plugins {
+		if kongPluginEnabled(plugin) {
+			applyKongPlugin(api, []*convertedFlow{&api.convertedFlow}, plugin, "service "+service.Name)
+		}
+	}
+	return api
+}
+
+// kongStripPath returns whether Kong strips the matching path of the route before forwarding to the service
+func kongStripPath(route kongRoute) bool {
+	return route.StripPath == nil || *route.StripPath
+}
+
+// kongParseURL splits the url of a service to the protocol, host, port and path
+func kongParseURL(serviceURL string) (string, string, int, string) {
+	protocol, rest := "http", serviceURL
+	if index := strings.Index(serviceURL, "://"); index >= 0 {
+		protocol, rest = serviceURL[:index], serviceURL[index+3:]
+	}
+	path := ""
+	if index := strings.Index(rest, "/"); index >= 0 {
+		rest, path = rest[:index], rest[index:]
+	}
+	host, port := rest, 0
+	if h, p, err := net.SplitHostPort(rest); err == nil {
+		host = h
+		port, _ = strconv.Atoi(p)
+	}
+	return protocol, host, port, path
+}
+
+// applyKongPlugin maps a plugin to the rate limit, policies, security or CORS configuration of the API or an
+// the operations of a route. Authentication and CORS plugins are only supported for services.
+func applyKongPlugin(api *convertedAPI, flows []*convertedFlow, plugin kongPlugin, owner string) {
+	source := fmt.Sprintf("%s: plugin %s", owner, plugin.Name)
+	apiLevel := len(flows) == 1 && flows[0] == &api.convertedFlow
+	switch plugin.Name {
+	case "rate-limiting":
+		var periods []string
+		for _, period := range kongRateLimitPeriods {
+			if count, ok := kongNumber(plugin.Config[period.name]); ok {
+				api.setRateLimit(flows, source, count, period.seconds)
+				periods = append(periods, period.name)
+			}
+		}
+		if len(periods) == 0 {
+			api.unmapped("%s: no limit is configured", source)
+		}
+	case "rate-limiting-advanced":
+		limits, _ := plugin.Config["limit"].([]interface{})
+		windows, _ := plugin.Config["window_size"].([]interface{})
+		for i, limit := range limits {
+			count, ok := kongNumber(limit)
+			if !ok || i >= len(windows) {
+				continue
+			}
+			if window, ok := kongNumber(windows[i]); ok {
+				api.setRateLimit(flows, source, count, int64(window))
+			}
+		}
+	case "request-transformer", "response-transformer":
+		response := plugin.Name == "response-transformer"
+		for _, action := range []string{"add", "append"} {
+			actionConfig, _ := plugin.Config[action].(map[string]interface{})
+			for _, header := range kongStrings(actionConfig["headers"]) {
+				name, value := kongSplitPair(header)
+				for _, flow := range flows {
+					flow.addHeader(response, name, value)
+				}
+			}
+			for _, param := range kongStrings(actionConfig["querystring"]) {
+				name, value := kongSplitPair(param)
+				if response {
+					api.unmapped("%s: query parameter %s is not mapped", source, name)
+					continue
+				}
+				for _, flow := range flows {
+					flow.addQueryParam(name, value)
+				}
+			}
+			if len(kongStrings(actionConfig["body"])) > 0 || len(kongStrings(actionConfig["json"])) > 0 {
+				api.unmapped("%s: %s of body fields is not mapped", source, action)
+			}
+		}
+		removeConfig, _ := plugin.Config["remove"].(map[string]interface{})
+		for _, header := range kongStrings(removeConfig["headers"]) {
+			for _, flow := range flows {
+				flow.removeHeader(response, header)
+			}
+		}
+		for _, param := range kongStrings(removeConfig["querystring"]) {
+			if response {
+				api.unmapped("%s: query parameter %s is not mapped", source, param)
+				continue
+			}
+			for _, flow := range flows {
+				flow.removeQueryParam(param)
+			}
+		}
+		if len(kongStrings(removeConfig["body"])) > 0 || len(kongStrings(removeConfig["json"])) > 0 {
+			api.unmapped("%s: remove of body fields is not mapped", source)
+		}
+		for _, action := range []string{"rename", "replace"} {
+			if actionConfig, _ := plugin.Config[action].(map[string]interface{}); kongHasValues(actionConfig) {
+				api.unmapped("%s: %s is not mapped", source, action)
+			}
+		}
+	case "cors":
+		if !apiLevel {
+			api.unmapped("%s: CORS is only supported for the whole API", source)
+			return
+		}
+		cors := &v2.CorsConfiguration{
+			CorsConfigurationEnabled:  true,
+			AccessControlAllowOrigins: kongStrings(plugin.Config["origins"]),
+			AccessControlAllowHeaders: kongStrings(plugin.Config["headers"]),
+			AccessControlAllowMethods: kongStrings(plugin.Config["methods"]),
+		}
+		cors.AccessControlAllowCredentials, _ = plugin.Config["credentials"].(bool)
+		if len(cors.AccessControlAllowOrigins) == 0 {
+			cors.AccessControlAllowOrigins = []string{"*"}
+		}
+		api.Cors = cors
+	case "key-auth", "basic-auth", "jwt", "oauth2", "openid-connect":
+		if !apiLevel {
+			api.unmapped("%s: authentication is only supported for the whole API", source)
+			return
+		}
+		switch plugin.Name {
+		case "key-auth":
+			api.addSecurityScheme(convertSecurityAPIKey)
+			if names := kongStrings(plugin.Config["key_names"]); len(names) > 0 {
+				api.APIKeyHeader = names[0]
+			}
+		case "basic-auth":
+			api.addSecurityScheme(convertSecurityBasicAuth)
+		default:
+			api.addSecurityScheme(convertSecurityOAuth2)
+			api.unmapped("%s: the issuers and keys of the tokens are not mapped. Configure a key manager", source)
+		}
+	default:
+		api.unmapped("%s is not mapped", source)
+	}
+}
+
+// kongNumber returns the value of a numeric config
+func kongNumber(value interface{}) (int, bool) {
+	if number, ok := value.(float64); ok && number > 0 {
+		return int(number), true
+	}
+	return 0, false
+}
+
+// kongStrings returns the value of a config holding a list of strings
+func kongStrings(value interface{}) []string {
+	values, _ := value.([]interface{})
+	var result []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// kongSplitPair splits a name:value pair of the transformer plugins
+func kongSplitPair(pair string) (string, string) {
+	index := strings.Index(pair, ":")
+	if index < 0 {
+		return strings.TrimSpace(pair), ""
+	}
+	return strings.TrimSpace(pair[:index]), strings.TrimSpace(pair[index+1:])
+}
+
+// kongHasValues returns true if any of the lists of a transformer action is not empty
+func kongHasValues(config map[string]interface{}) bool {
+	for _, value := range config {
+		if len(kongStrings(value)) > 0 {
+			return true
+		}
+	}
+	return false
+}
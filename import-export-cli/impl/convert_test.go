@@ -0,0 +1,255 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+const convertTestKongConfig = `_format_version: "3.0"
+services:
+- name: orders
+  url: http://orders-upstream:8080/api
+  routes:
+  - name: orders-route
+    paths: [/orders]
+    methods: [GET, POST]
+    plugins:
+    - name: request-transformer
+      config:
+        add:
+          headers: ["x-source:kong"]
+        remove:
+          querystring: [debug]
+  plugins:
+  - name: rate-limiting
+    config: {minute: 100}
+  - name: key-auth
+    config: {key_names: [x-api-key]}
+  - name: prometheus
+upstreams:
+- name: orders-upstream
+  targets:
+  - target: 10.0.0.1:8080
+  - target: 10.0.0.2
+consumers:
+- username: alice
+`
+
+const convertTestAzureTemplate = `{
+  "resources": [
+    {"type": "Microsoft.ApiManagement/service/apis/operations",
+     "name": "[concat(parameters('service_name'), '/petstore/create-pet')]",
+     "properties": {"method": "POST", "urlTemplate": "/pets"}},
+    {"type": "Microsoft.ApiManagement/service/apis", "name": "[concat(parameters('service_name'), '/petstore')]",
+     "properties": {"displayName": "Pet Store", "path": "petstore", "serviceUrl": "https://pets.example.com",
+       "apiVersion": "v1", "subscriptionRequired": false}},
+    {"type": "Microsoft.ApiManagement/service/apis/operations/policies",
+     "name": "[concat(parameters('service_name'), '/petstore/create-pet/policy')]",
+     "properties": {"format": "rawxml", "value": "<policies><inbound><base /><rate-limit calls=\"5\" renewal-period=\"3600\" /><ip-filter action=\"allow\" /></inbound><outbound><set-header name=\"X-Powered-By\" exists-action=\"delete\" /></outbound></policies>"}}
+  ]
+}`
+
+func readConvertedAPI(t *testing.T, projectDir string) *v2.APIDefinitionFile {
+	t.Helper()
+	content, err := ioutil.ReadFile(filepath.Join(projectDir, utils.APIDefinitionFileYaml))
+	if err != nil {
+		t.Fatal(err)
+	}
+	definition := &v2.APIDefinitionFile{}
+	if err := yaml2.Unmarshal(content, definition); err != nil {
+		t.Fatal(err)
+	}
+	return definition
+}
+
+func TestConvertKongExport(t *testing.T) {
+	root := t.TempDir()
+	exportPath := filepath.Join(root, "kong.yaml")
+	writeApplyTestFile(t, exportPath, convertTestKongConfig)
+	outDir := filepath.Join(root, "out")
+
+	report, err := ConvertToAPIProjects(ConvertSourceKong, exportPath, outDir, "1.0.0", false)
+	if !assert.Nil(t, err, "Should convert a valid decK configuration") {
+		return
+	}
+	assert.Equal(t, []string{"100PerMin"}, report.RateLimitingPolicies)
+	assert.Len(t, report.Unmapped, 1, "Consumers should be reported")
+	if assert.Len(t, report.APIs, 1) {
+		assert.Contains(t, report.APIs[0].Unmapped, "service orders: plugin prometheus is not mapped")
+	}
+
+	api := readConvertedAPI(t, filepath.Join(outDir, "orders-1.0.0")).Data
+	assert.Equal(t, "/orders", api.Context, "The stripped path of a single route should be the context")
+	assert.Equal(t, "100PerMin", api.APIThrottlingPolicy)
+	assert.Equal(t, "x-api-key", api.ApiKeyHeader)
+	assert.Equal(t, []string{convertSecurityAPIKey, convertSecurityMandatory}, api.SecurityScheme)
+	endpointConfig, _ := api.EndpointConfig.(map[interface{}]interface{})
+	assert.Equal(t, v2.EpLoadbalance, endpointConfig["endpoint_type"], "Upstream targets should be load balanced")
+	assert.Len(t, endpointConfig["production_endpoints"], 2)
+	assert.Len(t, api.Operations, 2)
+	for _, op := range api.Operations {
+		operation, _ := op.(map[interface{}]interface{})
+		assert.Equal(t, "/*", operation["target"])
+		policies, _ := operation["operationPolicies"].(map[interface{}]interface{})
+		assert.Len(t, policies["request"], 2, "Transformer plugins of the route should be operation policies")
+	}
+
+	projects, err := DiscoverApplyProjects(outDir)
+	assert.Nil(t, err)
+	if assert.Len(t, projects, 2, "Output directory should be applicable") {
+		assert.Equal(t, utils.ProjectTypePolicy, projects[0].Type)
+		assert.Equal(t, "100PerMin", projects[0].Name)
+		assert.Equal(t, CmdPolicyTypeAdvanced, projects[0].PolicyType)
+		assert.Equal(t, utils.ProjectTypeApi, projects[1].Type)
+		assert.Equal(t, "orders", projects[1].Name)
+	}
+
+	_, err = ConvertToAPIProjects(ConvertSourceKong, exportPath, outDir, "1.0.0", false)
+	assert.Error(t, err, "Should not overwrite existing projects unless forced")
+	_, err = ConvertToAPIProjects(ConvertSourceKong, exportPath, outDir, "1.0.0", true)
+	assert.Nil(t, err, "Should overwrite existing projects when forced")
+}
+
+func TestConvertAzureExport(t *testing.T) {
+	root := t.TempDir()
+	exportPath := filepath.Join(root, "template.json")
+	writeApplyTestFile(t, exportPath, convertTestAzureTemplate)
+	outDir := filepath.Join(root, "out")
+
+	report, err := ConvertToAPIProjects(ConvertSourceAzure, exportPath, outDir, "1.0.0", false)
+	if !assert.Nil(t, err, "Should convert a valid ARM template") {
+		return
+	}
+	assert.Equal(t, []string{"5PerHour"}, report.RateLimitingPolicies)
+	if assert.Len(t, report.APIs, 1) {
+		assert.Equal(t, "PetStore-v1", report.APIs[0].Project, "The API version of the export should be used")
+		assert.Equal(t, []string{"operation create-pet: inbound policy ip-filter is not mapped"},
+			report.APIs[0].Unmapped)
+	}
+
+	api := readConvertedAPI(t, filepath.Join(outDir, "PetStore-v1")).Data
+	assert.Equal(t, "/petstore", api.Context)
+	assert.Empty(t, api.APIThrottlingPolicy)
+	endpointConfig, _ := api.EndpointConfig.(map[interface{}]interface{})
+	assert.Equal(t, map[interface{}]interface{}{"url": "https://pets.example.com"},
+		endpointConfig["production_endpoints"])
+	if assert.Len(t, api.Operations, 1) {
+		operation, _ := api.Operations[0].(map[interface{}]interface{})
+		assert.Equal(t, "/pets", operation["target"])
+		assert.Equal(t, "POST", operation["verb"])
+		assert.Equal(t, "5PerHour", operation["throttlingPolicy"])
+		policies, _ := operation["operationPolicies"].(map[interface{}]interface{})
+		assert.Len(t, policies["response"], 1, "Outbound policies should be response policies")
+	}
+}
+
+func TestConvertAzureBicepExport(t *testing.T) {
+	root := t.TempDir()
+	exportPath := filepath.Join(root, "apim.bicep")
+	writeApplyTestFile(t, exportPath, "resource api 'Microsoft.ApiManagement/service/apis@2022-08-01' = {}")
+
+	_, err := ConvertToAPIProjects(ConvertSourceAzure, exportPath, filepath.Join(root, "out"), "1.0.0", false)
+	if assert.Error(t, err, "Bicep files should be compiled to ARM templates first") {
+		assert.Contains(t, err.Error(), "az bicep build")
+	}
+}
+
+func TestConvertApigeeBundle(t *testing.T) {
+	root := t.TempDir()
+	bundleDir := filepath.Join(root, "bundle", apigeeBundleDir)
+	writeApplyTestFile(t, filepath.Join(bundleDir, "orders.xml"),
+		`<APIProxy revision="3" name="orders"><Basepaths>/orders</Basepaths></APIProxy>`)
+	writeApplyTestFile(t, filepath.Join(bundleDir, "proxies", "default.xml"), `<ProxyEndpoint name="default">
+  <PreFlow><Request><Step><Name>Verify-API-Key</Name></Step></Request></PreFlow>
+  <Flows>
+    <Flow name="getItem">
+      <Request><Step><Name>Quota-1</Name></Step><Step><Name>JS-Transform</Name></Step></Request>
+      <Condition>(proxy.pathsuffix MatchesPath "/orders/*/items/*") and (request.verb = "GET")</Condition>
+    </Flow>
+  </Flows>
+  <HTTPProxyConnection><BasePath>/orders/v1</BasePath></HTTPProxyConnection>
+  <RouteRule name="default"><TargetEndpoint>default</TargetEndpoint></RouteRule>
+</ProxyEndpoint>`)
+	writeApplyTestFile(t, filepath.Join(bundleDir, "targets", "default.xml"),
+		`<TargetEndpoint name="default"><HTTPTargetConnection><URL>https://orders.example.com</URL></HTTPTargetConnection></TargetEndpoint>`)
+	writeApplyTestFile(t, filepath.Join(bundleDir, "policies", "Verify-API-Key.xml"),
+		`<VerifyAPIKey name="Verify-API-Key"><APIKey ref="request.header.x-apikey"/></VerifyAPIKey>`)
+	writeApplyTestFile(t, filepath.Join(bundleDir, "policies", "Quota-1.xml"),
+		`<Quota name="Quota-1"><Allow count="1000"/><Interval>1</Interval><TimeUnit>hour</TimeUnit></Quota>`)
+	writeApplyTestFile(t, filepath.Join(bundleDir, "policies", "JS-Transform.xml"),
+		`<Javascript name="JS-Transform"><ResourceURL>jsc://transform.js</ResourceURL></Javascript>`)
+	outDir := filepath.Join(root, "out")
+
+	report, err := ConvertToAPIProjects(ConvertSourceApigee, filepath.Join(root, "bundle"), outDir, "2.0.0", false)
+	if !assert.Nil(t, err, "Should convert a valid proxy bundle") {
+		return
+	}
+	assert.Equal(t, []string{"1000PerHour"}, report.RateLimitingPolicies)
+	if assert.Len(t, report.APIs, 1) {
+		assert.Equal(t, []string{"flow getItem: policy JS-Transform of type Javascript is not mapped"},
+			report.APIs[0].Unmapped)
+	}
+
+	api := readConvertedAPI(t, filepath.Join(outDir, "orders-2.0.0")).Data
+	assert.Equal(t, "/orders/v1", api.Context, "The base path of the proxy endpoint should be the context")
+	assert.Equal(t, "x-apikey", api.ApiKeyHeader)
+	if assert.Len(t, api.Operations, 1) {
+		operation, _ := api.Operations[0].(map[interface{}]interface{})
+		assert.Equal(t, "/orders/{param1}/items/*", operation["target"])
+		assert.Equal(t, "GET", operation["verb"])
+		assert.Equal(t, "1000PerHour", operation["throttlingPolicy"])
+	}
+}
+
+func TestConvertUnsupportedSource(t *testing.T) {
+	_, err := ConvertToAPIProjects("mulesoft", "export.yaml", t.TempDir(), "1.0.0", false)
+	assert.Error(t, err, "Should return an error for unsupported sources")
+}
+
+func TestNewConvertedRateLimit(t *testing.T) {
+	tests := []struct {
+		count        int
+		seconds      int64
+		policyName   string
+		approximated bool
+	}{
+		{100, 60, "100PerMin", false},
+		{5000, 3600, "5000PerHour", false},
+		{10, 2 * 24 * 60 * 60, "10Per2Day", false},
+		{5, 1, "300PerMin", true},
+		{20, 90, "14PerMin", true},
+	}
+	for _, test := range tests {
+		limit, approximated := newConvertedRateLimit(test.count, test.seconds)
+		if assert.NotNil(t, limit) {
+			assert.Equal(t, test.policyName, limit.PolicyName())
+		}
+		assert.Equal(t, test.approximated, approximated)
+	}
+	limit, _ := newConvertedRateLimit(0, 60)
+	assert.Nil(t, limit, "Should not create a limit without requests")
+}
@@ -36,12 +36,17 @@ import (
 // @param deleteAPIProvider : Provider of API
 // @return response Response in the form of *resty.Response
 func DeleteAPI(accessToken, environment, deleteAPIName, deleteAPIVersion, deleteAPIProvider string) (*resty.Response, error) {
-	deleteEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
-	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	apiId, err := GetAPIId(accessToken, environment, deleteAPIName, deleteAPIVersion, deleteAPIProvider)
 	if err != nil {
 		utils.HandleErrorAndExit("Error while getting API Id for deletion ", err)
 	}
+	return deleteAPIById(accessToken, environment, apiId)
+}
+
+// deleteAPIById deletes the API with the given id
+func deleteAPIById(accessToken, environment, apiId string) (*resty.Response, error) {
+	deleteEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	url := deleteEndpoint + apiId
 	utils.Logln(utils.LogPrefixInfo+"DeleteAPI: URL:", url)
 	headers := make(map[string]string)
@@ -60,8 +65,8 @@ func DeleteAPI(accessToken, environment, deleteAPIName, deleteAPIVersion, delete
 
 func PrintDeleteAPIResponse(resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting API:", err)
+		fmt.Fprintln(utils.Stdout, "Error deleting API:", err)
 	} else {
-		fmt.Println("API deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(utils.Stdout, "API deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -36,14 +36,18 @@ import (
 // @param environment : Environment where API Policy should be deleted
 // @return response Response in the form of *resty.Response
 func DeleteAPIPolicy(accessToken, policyName, policyVersion, environment string) (*resty.Response, error) {
-	deleteEndpoint := utils.GetAPIPolicyListEndpointOfEnv(environment, utils.MainConfigFilePath)
-	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
-
 	policyId, err := GetAPIPolicyId(accessToken, environment, policyName, policyVersion)
 
 	if err != nil {
 		utils.HandleErrorAndExit("Error while getting API Policy Id for deletion ", err)
 	}
+	return deleteAPIPolicyById(accessToken, environment, policyId)
+}
+
+// deleteAPIPolicyById deletes the API Policy with the given id
+func deleteAPIPolicyById(accessToken, environment, policyId string) (*resty.Response, error) {
+	deleteEndpoint := utils.GetAPIPolicyListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	url := deleteEndpoint + policyId
 	utils.Logln(utils.LogPrefixInfo+"DeleteAPIPolicy: URL:", url)
 	headers := make(map[string]string)
@@ -72,8 +76,8 @@ func DeleteAPIPolicy(accessToken, policyName, policyVersion, environment string)
 
 func PrintDeleteAPIPolicyResponse(policyName, policyVersion string, err error) {
 	if err != nil {
-		fmt.Println("Error deleting API Policy:", err)
+		fmt.Fprintln(utils.Stdout, "Error deleting API Policy:", err)
 	} else {
-		fmt.Println(policyName + " API Policy with the version " + policyVersion + " deleted successfully!")
+		fmt.Fprintln(utils.Stdout, policyName+" API Policy with the version "+policyVersion+" deleted successfully!")
 	}
 }
@@ -37,12 +37,17 @@ import (
 // @param apiProductProvider : Provider of the API Product
 // @return response Response in the form of *resty.Response
 func DeleteAPIProduct(accessToken, environment, apiProductName, apiProductVersion, apiProductProvider string) (*resty.Response, error) {
-	deleteEndpoint := utils.GetApiProductListEndpointOfEnv(environment, utils.MainConfigFilePath)
-	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	apiProductId, err := GetAPIProductId(accessToken, environment, apiProductName, apiProductVersion, apiProductProvider)
 	if err != nil {
 		utils.HandleErrorAndExit("Error while getting API Product Id for deletion ", err)
 	}
+	return deleteAPIProductById(accessToken, environment, apiProductId)
+}
+
+// deleteAPIProductById deletes the API Product with the given id
+func deleteAPIProductById(accessToken, environment, apiProductId string) (*resty.Response, error) {
+	deleteEndpoint := utils.GetApiProductListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	url := deleteEndpoint + apiProductId
 	utils.Logln(utils.LogPrefixInfo+"DeleteAPIProduct: URL:", url)
 	headers := make(map[string]string)
@@ -61,8 +66,8 @@ func DeleteAPIProduct(accessToken, environment, apiProductName, apiProductVersio
 
 func PrintDeleteAPIProductResponse(resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting API Product:", err)
+		fmt.Fprintln(utils.Stdout, "Error deleting API Product:", err)
 	} else {
-		fmt.Println("API Product deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(utils.Stdout, "API Product deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -0,0 +1,72 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// useTestEnvironment adds an environment for the API Manager at apimEndpoint to a main config used by the test
+func useTestEnvironment(t *testing.T, env, apimEndpoint string) {
+	path := filepath.Join(t.TempDir(), utils.MainConfigFileName)
+	utils.WriteConfigFile(&utils.MainConfig{
+		Config: utils.Config{ExportDirectory: t.TempDir()},
+		Environments: map[string]utils.EnvEndpoints{
+			env: {ApiManagerEndpoint: apimEndpoint, TokenEndpoint: apimEndpoint + "/oauth2/token"},
+		},
+	}, path)
+	originalPath := utils.MainConfigFilePath
+	utils.MainConfigFilePath = path
+	t.Cleanup(func() { utils.MainConfigFilePath = originalPath })
+}
+
+func TestDeleteAPIRetriesUnavailablePublisher(t *testing.T) {
+	var deletes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access-token", r.Header.Get(utils.HeaderAuthorization))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/search":
+			assert.Equal(t, `name:"PizzaShackAPI" version:"1.0.0" provider:"admin"`, r.URL.Query().Get("query"))
+			w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"id":"pizza-id","name":"PizzaShackAPI"}]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/am/publisher/v4/apis/pizza-id":
+			if atomic.AddInt32(&deletes, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	useTestEnvironment(t, "dev", server.URL)
+
+	resp, err := DeleteAPI("access-token", "dev", "PizzaShackAPI", "1.0.0", "admin")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, int32(2), deletes, "Delete should be retried while the publisher is unavailable")
+}
@@ -33,8 +33,6 @@ import (
 // @param accessToken : Access Token for the resource
 // @return response Response in the form of *resty.Response
 func DeleteApplication(accessToken, environment, deleteAppName, deleteAppOwner string) (*resty.Response, error) {
-	deleteEndpoint := utils.GetAdminApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
-	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	appId, err := GetAppId(accessToken, environment, deleteAppName, deleteAppOwner)
 	if err != nil {
 		utils.HandleErrorAndExit("Error while getting App Id for deletion ", err)
@@ -42,6 +40,13 @@ func DeleteApplication(accessToken, environment, deleteAppName, deleteAppOwner s
 	if appId == "" {
 		utils.HandleErrorAndExit("Cannot find the application: "+deleteAppName+" for owner: "+deleteAppOwner, err)
 	}
+	return deleteApplicationById(accessToken, environment, appId)
+}
+
+// deleteApplicationById deletes the Application with the given id
+func deleteApplicationById(accessToken, environment, appId string) (*resty.Response, error) {
+	deleteEndpoint := utils.GetAdminApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	url := deleteEndpoint + appId
 	utils.Logln(utils.LogPrefixInfo+"DeleteApplication: URL:", url)
 	headers := make(map[string]string)
@@ -60,8 +65,8 @@ func DeleteApplication(accessToken, environment, deleteAppName, deleteAppOwner s
 
 func PrintDeleteAppResponse(resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting Application:", err)
+		fmt.Fprintln(utils.Stdout, "Error deleting Application:", err)
 	} else {
-		fmt.Println("Application deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(utils.Stdout, "Application deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -60,8 +60,8 @@ func DeleteMCPServer(accessToken, environment, deleteMCPServerName, deleteMCPSer
 
 func PrintDeleteMCPServerResponse(resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting MCP Server:", err)
+		fmt.Fprintln(utils.Stdout, "Error deleting MCP Server:", err)
 	} else {
-		fmt.Println("MCP Server deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(utils.Stdout, "MCP Server deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -69,8 +69,13 @@ func DeleteThrottlingPolicy(accessToken, policyName, policyType, environment str
 	if err != nil {
 		utils.HandleErrorAndExit("Error while getting Throttling Policy Id for deletion ", err)
 	}
+	return deleteThrottlingPolicyById(accessToken, environment, policyType, policyId)
+}
 
-	resource = "throttling/policies/"
+// deleteThrottlingPolicyById deletes the Throttling Policy of the given type with the given id
+func deleteThrottlingPolicyById(accessToken, environment, policyType, policyId string) (*resty.Response, error) {
+	endpoint := utils.AppendSlashToString(utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath))
+	resource := "throttling/policies/"
 
 	switch policyType {
 	case CmdPolicyTypeSubscription:
@@ -84,7 +89,7 @@ func DeleteThrottlingPolicy(accessToken, policyName, policyType, environment str
 	}
 
 	resource = utils.AppendSlashToString(resource) + policyId
-	url = endpoint + resource
+	url := endpoint + resource
 	utils.Logln(utils.LogPrefixInfo+"DeleteThrottlingPolicy: URL:", url)
 	headers := make(map[string]string)
 	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
@@ -125,8 +130,8 @@ func getThrottlingPolicyId(accessToken, environment, url, queryParamString, poli
 
 func PrintDeleteThrottlingPolicyResponse(policyName, policyType string, err error) {
 	if err != nil {
-		fmt.Println("Error deleting Throttling Policy:", err)
+		fmt.Fprintln(utils.Stdout, "Error deleting Throttling Policy:", err)
 	} else {
-		fmt.Println(policyName + " Throttling Policy with type " + policyType + " deleted successfully!")
+		fmt.Fprintln(utils.Stdout, policyName+" Throttling Policy with type "+policyType+" deleted successfully!")
 	}
 }
@@ -0,0 +1,663 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Sections of an API project compared by the diff command
+const (
+	DiffSectionAPI        = "api"
+	DiffSectionEndpoints  = "endpoints"
+	DiffSectionOperations = "operations"
+	DiffSectionPolicies   = "policies"
+	DiffSectionDefinition = "definition"
+)
+
+// Kinds of changes reported by the diff command. Changes are relative to the environment, hence "added" means the
+// local project has something the deployed API does not have.
+const (
+	DiffChangeAdded   = "added"
+	DiffChangeRemoved = "removed"
+	DiffChangeChanged = "changed"
+)
+
+// Output formats supported by the diff command
+const (
+	DiffFormatTable   = "table"
+	DiffFormatJSON    = "json"
+	DiffFormatUnified = "diff"
+)
+
+const (
+	diffSectionHeader = "SECTION"
+	diffChangeHeader  = "CHANGE"
+	diffPathHeader    = "PATH"
+	diffLocalHeader   = "LOCAL"
+	diffRemoteHeader  = "ENVIRONMENT"
+
+	defaultDiffTableFormat = "table {{.Section}}\t{{.Change}}\t{{.Path}}\t{{.LocalValue}}\t{{.RemoteValue}}"
+
+	diffTableValueMaxLength = 40
+	diffWorkingCopy         = "working copy"
+)
+
+// diffIgnoredAPIFields are api.yaml fields that are generated by API Manager or compared in a separate section
+var diffIgnoredAPIFields = map[string]bool{
+	"id":                   true,
+	"createdTime":          true,
+	"lastUpdatedTimestamp": true,
+	"lastUpdatedTime":      true,
+	"isRevision":           true,
+	"revisionId":           true,
+	"workflowStatus":       true,
+	"hasThumbnail":         true,
+	"endpointConfig":       true,
+	"operations":           true,
+	"mediationPolicies":    true,
+	"apiPolicies":          true,
+}
+
+// diffGeneratedKeys are keys of generated identifiers inside operations and policies
+var diffGeneratedKeys = map[string]bool{
+	"id":           true,
+	"uuid":         true,
+	"policyId":     true,
+	"uriMappingId": true,
+}
+
+var diffSwaggerVerbs = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// APIDiffEntry represents a single difference between a local API project and the deployed API
+type APIDiffEntry struct {
+	Section string      `json:"section"`
+	Change  string      `json:"change"`
+	Path    string      `json:"path"`
+	Local   interface{} `json:"local,omitempty"`
+	Remote  interface{} `json:"environment,omitempty"`
+}
+
+// LocalValue returns the local value of an entry for printing
+func (e APIDiffEntry) LocalValue() string {
+	return getDiffTableValue(e.Local)
+}
+
+// RemoteValue returns the value of an entry in the environment for printing
+func (e APIDiffEntry) RemoteValue() string {
+	return getDiffTableValue(e.Remote)
+}
+
+// APIDiff holds the differences between a local API project and the API deployed in an environment
+type APIDiff struct {
+	Name        string         `json:"name"`
+	Version     string         `json:"version"`
+	Provider    string         `json:"provider"`
+	Environment string         `json:"environment"`
+	Revision    string         `json:"revision"`
+	Entries     []APIDiffEntry `json:"differences"`
+
+	sections []apiDiffSection
+}
+
+// apiDiffSection keeps the normalized content of a section of both the projects for unified diffs
+type apiDiffSection struct {
+	name   string
+	files  bool
+	local  interface{}
+	remote interface{}
+}
+
+// apiDiffProject holds the comparable content of an API project
+type apiDiffProject struct {
+	data        map[string]interface{}
+	definition  map[string]interface{}
+	definitions map[string][]byte
+	policies    map[string][]byte
+}
+
+// DiffAPIWithEnv compares the API project in projectPath with the API deployed in the environment. The deployed
+// revision is exported from the environment, or the working copy if no revision is deployed.
+func DiffAPIWithEnv(accessToken, environment, projectPath string) (*APIDiff, error) {
+	localPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(localPath))
+	local, err := loadAPIDiffProject(localPath)
+	if err != nil {
+		return nil, errors.New("Error while reading the local API project: " + err.Error())
+	}
+
+	name := fmt.Sprint(local.data["name"])
+	version := fmt.Sprint(local.data["version"])
+	provider, _ := local.data["provider"].(string)
+	if _, err := GetAPIId(accessToken, environment, name, version, provider); err != nil {
+		return nil, err
+	}
+
+	revisionNum := ""
+	_, revisions, err := GetRevisionListFromEnv(accessToken, environment, name, version, provider, "deployed:true")
+	if err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		num := utils.GetRevisionNumFromRevisionName(revision.RevisionNumber)
+		if revisionNum == "" || compareRevisionNumbers(num, revisionNum) > 0 {
+			revisionNum = num
+		}
+	}
+
+	resp, err := ExportAPIFromEnv(accessToken, name, version, revisionNum, provider, utils.DefaultExportFormat,
+		environment, true, false, false)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New("Error while exporting the API from " + environment + ". Status: " + resp.Status())
+	}
+	zipFile, err := utils.WriteResponseToTempZip(name+"_"+version+".zip", resp)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(zipFile))
+	remotePath, err := utils.GetTempCloneFromDirOrZip(zipFile)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(remotePath))
+	remote, err := loadAPIDiffProject(remotePath)
+	if err != nil {
+		return nil, errors.New("Error while reading the API exported from " + environment + ": " + err.Error())
+	}
+
+	apiDiff := diffAPIProjects(local, remote)
+	apiDiff.Name = name
+	apiDiff.Version = version
+	apiDiff.Provider = provider
+	apiDiff.Environment = environment
+	apiDiff.Revision = diffWorkingCopy
+	if revisionNum != "" {
+		apiDiff.Revision = "Revision " + revisionNum
+	}
+	return apiDiff, nil
+}
+
+func compareRevisionNumbers(a, b string) int {
+	numA, errA := strconv.Atoi(a)
+	numB, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return numA - numB
+}
+
+// loadAPIDiffProject reads the content of the API project in projectPath
+func loadAPIDiffProject(projectPath string) (*apiDiffProject, error) {
+	_, content, err := resolveYamlOrJSON(filepath.Join(projectPath, "api"))
+	if err != nil {
+		return nil, err
+	}
+	var apiFile struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(content, &apiFile); err != nil {
+		return nil, err
+	}
+	if apiFile.Data == nil {
+		return nil, errors.New("data section is missing in the API definition")
+	}
+	project := &apiDiffProject{data: apiFile.Data}
+
+	project.definitions, err = readAPIDiffFiles(filepath.Join(projectPath, utils.InitProjectDefinitions))
+	if err != nil {
+		return nil, err
+	}
+	project.policies, err = readAPIDiffFiles(filepath.Join(projectPath, utils.InitProjectSequences))
+	if err != nil {
+		return nil, err
+	}
+	for _, swaggerFile := range []string{"swagger.yaml", "swagger.json"} {
+		if swagger, ok := project.definitions[swaggerFile]; ok {
+			swaggerJSON, err := utils.YamlToJson(swagger)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(swaggerJSON, &project.definition); err != nil {
+				return nil, err
+			}
+			delete(project.definitions, swaggerFile)
+			break
+		}
+	}
+	return project, nil
+}
+
+// readAPIDiffFiles reads all the files in dirPath keyed by their paths relative to dirPath
+func readAPIDiffFiles(dirPath string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	if exists, _ := utils.IsDirExists(dirPath); !exists {
+		return files, nil
+	}
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relativePath, _ := filepath.Rel(dirPath, path)
+		files[filepath.ToSlash(relativePath)] = content
+		return nil
+	})
+	return files, err
+}
+
+// diffAPIProjects compares the local API project with the API project exported from the environment
+func diffAPIProjects(local, remote *apiDiffProject) *APIDiff {
+	apiDiff := &APIDiff{}
+
+	// api.yaml fields
+	localFields, remoteFields := make(map[string]interface{}), make(map[string]interface{})
+	for _, field := range getAPIDTODefinitionFields() {
+		if diffIgnoredAPIFields[field] {
+			continue
+		}
+		if value, ok := local.data[field]; ok && !isEmptyDiffValue(value) {
+			localFields[field] = normalizeDiffValue(value, false)
+		}
+		if value, ok := remote.data[field]; ok && !isEmptyDiffValue(value) {
+			remoteFields[field] = normalizeDiffValue(value, false)
+		}
+	}
+	apiDiff.addSection(DiffSectionAPI, localFields, remoteFields)
+	apiDiff.compareMaps(DiffSectionAPI, "", localFields, remoteFields)
+
+	// endpoint configuration
+	localEndpoints := flattenDiffValue("", normalizeDiffValue(local.data["endpointConfig"], false))
+	remoteEndpoints := flattenDiffValue("", normalizeDiffValue(remote.data["endpointConfig"], false))
+	apiDiff.addSection(DiffSectionEndpoints, local.data["endpointConfig"], remote.data["endpointConfig"])
+	apiDiff.compareMaps(DiffSectionEndpoints, "", localEndpoints, remoteEndpoints)
+
+	// operations keyed by the verb and the target
+	localOperations := getDiffOperations(local.data["operations"])
+	remoteOperations := getDiffOperations(remote.data["operations"])
+	apiDiff.addSection(DiffSectionOperations, localOperations, remoteOperations)
+	for _, key := range getSortedDiffKeys(localOperations, remoteOperations) {
+		localOperation, inLocal := localOperations[key]
+		remoteOperation, inRemote := remoteOperations[key]
+		if !inRemote {
+			apiDiff.add(DiffSectionOperations, DiffChangeAdded, key, localOperation, nil)
+		} else if !inLocal {
+			apiDiff.add(DiffSectionOperations, DiffChangeRemoved, key, nil, remoteOperation)
+		} else {
+			apiDiff.compareMaps(DiffSectionOperations, key+".", flattenDiffValue("", localOperation),
+				flattenDiffValue("", remoteOperation))
+		}
+	}
+
+	// API level policies and the policy files
+	localPolicies := map[string]interface{}{
+		"apiPolicies":       normalizeDiffValue(local.data["apiPolicies"], true),
+		"mediationPolicies": normalizeDiffValue(local.data["mediationPolicies"], true),
+	}
+	remotePolicies := map[string]interface{}{
+		"apiPolicies":       normalizeDiffValue(remote.data["apiPolicies"], true),
+		"mediationPolicies": normalizeDiffValue(remote.data["mediationPolicies"], true),
+	}
+	apiDiff.addSection(DiffSectionPolicies, localPolicies, remotePolicies)
+	apiDiff.compareMaps(DiffSectionPolicies, "", flattenDiffValue("", localPolicies),
+		flattenDiffValue("", remotePolicies))
+	apiDiff.compareFiles(DiffSectionPolicies, utils.InitProjectSequences+"/", local.policies, remote.policies)
+
+	// Swagger/OAS definition and the other definition files
+	apiDiff.addSection(DiffSectionDefinition, local.definition, remote.definition)
+	apiDiff.compareDefinitions(local.definition, remote.definition)
+	apiDiff.compareFiles(DiffSectionDefinition, utils.InitProjectDefinitions+"/", local.definitions,
+		remote.definitions)
+
+	return apiDiff
+}
+
+// getAPIDTODefinitionFields returns the json field names of v2.APIDTODefinition
+func getAPIDTODefinitionFields() []string {
+	var fields []string
+	apiType := reflect.TypeOf(v2.APIDTODefinition{})
+	for i := 0; i < apiType.NumField(); i++ {
+		tag := strings.Split(apiType.Field(i).Tag.Get("json"), ",")[0]
+		if tag != "" && tag != "-" {
+			fields = append(fields, tag)
+		}
+	}
+	return fields
+}
+
+// getDiffOperations returns the operations keyed by "VERB target" with the generated identifiers removed
+func getDiffOperations(value interface{}) map[string]interface{} {
+	operations := make(map[string]interface{})
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		operation, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := strings.ToUpper(fmt.Sprint(operation["verb"])) + " " + fmt.Sprint(operation["target"])
+		operations[key] = normalizeDiffValue(operation, true)
+	}
+	return operations
+}
+
+// compareDefinitions compares the paths and verbs of the Swagger/OAS definitions
+func (d *APIDiff) compareDefinitions(local, remote map[string]interface{}) {
+	for _, key := range getSortedDiffKeys(local, remote) {
+		if key == "paths" {
+			continue
+		}
+		d.compareValues(DiffSectionDefinition, key, local[key], remote[key])
+	}
+	localPaths, _ := local["paths"].(map[string]interface{})
+	remotePaths, _ := remote["paths"].(map[string]interface{})
+	for _, path := range getSortedDiffKeys(localPaths, remotePaths) {
+		localPath, _ := localPaths[path].(map[string]interface{})
+		remotePath, _ := remotePaths[path].(map[string]interface{})
+		for _, verb := range diffSwaggerVerbs {
+			d.compareValues(DiffSectionDefinition, strings.ToUpper(verb)+" "+path, localPath[verb], remotePath[verb])
+		}
+	}
+}
+
+// compareFiles compares the content of the files of a section
+func (d *APIDiff) compareFiles(section, prefix string, local, remote map[string][]byte) {
+	localFiles, remoteFiles := make(map[string]interface{}), make(map[string]interface{})
+	for name, content := range local {
+		localFiles[name] = string(content)
+	}
+	for name, content := range remote {
+		remoteFiles[name] = string(content)
+	}
+	for _, name := range getSortedDiffKeys(localFiles, remoteFiles) {
+		localContent, inLocal := local[name]
+		remoteContent, inRemote := remote[name]
+		if !inRemote {
+			d.add(section, DiffChangeAdded, prefix+name, nil, nil)
+		} else if !inLocal {
+			d.add(section, DiffChangeRemoved, prefix+name, nil, nil)
+		} else if strings.TrimSpace(string(localContent)) != strings.TrimSpace(string(remoteContent)) {
+			d.add(section, DiffChangeChanged, prefix+name, nil, nil)
+		}
+	}
+	if len(localFiles) > 0 || len(remoteFiles) > 0 {
+		d.sections = append(d.sections, apiDiffSection{name: prefix, files: true, local: localFiles,
+			remote: remoteFiles})
+	}
+}
+
+// compareMaps compares two flat maps and adds an entry for each differing key
+func (d *APIDiff) compareMaps(section, prefix string, local, remote map[string]interface{}) {
+	for _, key := range getSortedDiffKeys(local, remote) {
+		d.compareValues(section, prefix+key, local[key], remote[key])
+	}
+}
+
+// compareValues adds an entry if the local and the remote values are different
+func (d *APIDiff) compareValues(section, path string, local, remote interface{}) {
+	local, remote = normalizeDiffValue(local, false), normalizeDiffValue(remote, false)
+	switch {
+	case isEmptyDiffValue(local) && isEmptyDiffValue(remote):
+		return
+	case isEmptyDiffValue(remote):
+		d.add(section, DiffChangeAdded, path, local, nil)
+	case isEmptyDiffValue(local):
+		d.add(section, DiffChangeRemoved, path, nil, remote)
+	case !reflect.DeepEqual(local, remote):
+		d.add(section, DiffChangeChanged, path, local, remote)
+	}
+}
+
+func (d *APIDiff) add(section, change, path string, local, remote interface{}) {
+	d.Entries = append(d.Entries, APIDiffEntry{Section: section, Change: change, Path: path, Local: local,
+		Remote: remote})
+}
+
+func (d *APIDiff) addSection(name string, local, remote interface{}) {
+	d.sections = append(d.sections, apiDiffSection{name: name, local: local, remote: remote})
+}
+
+// normalizeDiffValue converts a value to a form that can be compared regardless of the order of scalar lists and
+// number types. Generated identifiers are removed when removeGenerated is set.
+func normalizeDiffValue(value interface{}, removeGenerated bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{})
+		for key, item := range v {
+			if removeGenerated && diffGeneratedKeys[key] {
+				continue
+			}
+			normalized[key] = normalizeDiffValue(item, removeGenerated)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		scalars := true
+		for i, item := range v {
+			normalized[i] = normalizeDiffValue(item, removeGenerated)
+			switch normalized[i].(type) {
+			case map[string]interface{}, []interface{}:
+				scalars = false
+			}
+		}
+		if scalars {
+			sort.SliceStable(normalized, func(i, j int) bool {
+				return fmt.Sprint(normalized[i]) < fmt.Sprint(normalized[j])
+			})
+		}
+		return normalized
+	case int, int32, int64, float32:
+		f, _ := strconv.ParseFloat(fmt.Sprint(v), 64)
+		return f
+	}
+	return value
+}
+
+func isEmptyDiffValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// flattenDiffValue flattens nested maps into a single map keyed by the dotted paths of the leaf values. Lists are
+// kept as leaf values.
+func flattenDiffValue(prefix string, value interface{}) map[string]interface{} {
+	flattened := make(map[string]interface{})
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		if !isEmptyDiffValue(value) && prefix != "" {
+			flattened[prefix] = value
+		}
+		return flattened
+	}
+	for key, item := range m {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		for k, v := range flattenDiffValue(path, item) {
+			flattened[k] = v
+		}
+	}
+	return flattened
+}
+
+func getSortedDiffKeys(maps ...map[string]interface{}) []string {
+	keySet := make(map[string]bool)
+	for _, m := range maps {
+		for key := range m {
+			keySet[key] = true
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func getDiffTableValue(value interface{}) string {
+	if value == nil {
+		return "-"
+	}
+	str, ok := value.(string)
+	if !ok {
+		content, _ := json.Marshal(value)
+		str = string(content)
+	}
+	str = strings.ReplaceAll(str, "\n", " ")
+	if len(str) > diffTableValueMaxLength {
+		str = str[:diffTableValueMaxLength-3] + "..."
+	}
+	return str
+}
+
+// UnifiedDiff returns the differences of each section as a unified diff of the normalized content
+func (d *APIDiff) UnifiedDiff() (string, error) {
+	var builder strings.Builder
+	for _, section := range d.sections {
+		remoteContent, err := getUnifiedDiffContent(section.remote, section.files)
+		if err != nil {
+			return "", err
+		}
+		localContent, err := getUnifiedDiffContent(section.local, section.files)
+		if err != nil {
+			return "", err
+		}
+		sectionDiff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(remoteContent),
+			B:        difflib.SplitLines(localContent),
+			FromFile: d.Environment + "/" + section.name,
+			ToFile:   "local/" + section.name,
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(sectionDiff)
+	}
+	return builder.String(), nil
+}
+
+// getUnifiedDiffContent renders a section as yaml with sorted keys. File sections are rendered as the file contents.
+func getUnifiedDiffContent(value interface{}, files bool) (string, error) {
+	if fileContents, ok := value.(map[string]interface{}); ok && files {
+		var builder strings.Builder
+		for _, name := range getSortedDiffKeys(fileContents) {
+			builder.WriteString("# " + name + "\n" + fmt.Sprint(fileContents[name]) + "\n")
+		}
+		return builder.String(), nil
+	}
+	if isEmptyDiffValue(value) {
+		return "", nil
+	}
+	content, err := json.Marshal(normalizeDiffValue(value, false))
+	if err != nil {
+		return "", err
+	}
+	content, err = utils.JsonToYaml(content)
+	return string(content), err
+}
+
+// PrintAPIDiff prints the differences in the given format
+func PrintAPIDiff(apiDiff *APIDiff, format string) error {
+	if format == DiffFormatUnified {
+		unifiedDiff, err := apiDiff.UnifiedDiff()
+		if err != nil {
+			return err
+		}
+		return writeDiffWithFormat("{{.}}", unifiedDiff)
+	}
+	if format == DiffFormatJSON {
+		if apiDiff.Entries == nil {
+			apiDiff.Entries = []APIDiffEntry{}
+		}
+		return writeDiffWithFormat("{{jsonPretty .}}\n", apiDiff)
+	}
+	if format != "" && format != DiffFormatTable {
+		return errors.New("Unsupported format " + format + ". Supported formats: [table, json, diff]")
+	}
+
+	fmt.Fprintln(utils.Stdout, "Comparing "+apiDiff.Name+" "+apiDiff.Version+" with the "+apiDiff.Revision+" in "+
+		apiDiff.Environment)
+	if len(apiDiff.Entries) == 0 {
+		fmt.Fprintln(utils.Stdout, "No differences found.")
+		return nil
+	}
+	diffContext := formatter.NewContext(os.Stdout, defaultDiffTableFormat)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, entry := range apiDiff.Entries {
+			if err := t.Execute(w, entry); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	diffTableHeaders := map[string]string{
+		"Section":     diffSectionHeader,
+		"Change":      diffChangeHeader,
+		"Path":        diffPathHeader,
+		"LocalValue":  diffLocalHeader,
+		"RemoteValue": diffRemoteHeader,
+	}
+	return diffContext.Write(renderer, diffTableHeaders)
+}
+
+func writeDiffWithFormat(format string, data interface{}) error {
+	diffContext := formatter.NewContext(os.Stdout, format)
+	renderer := func(w io.Writer, t *template.Template) error {
+		return t.Execute(w, data)
+	}
+	return diffContext.Write(renderer, nil)
+}
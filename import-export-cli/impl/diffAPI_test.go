@@ -0,0 +1,164 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const diffTestSwagger = `openapi: 3.0.1
+info:
+  title: PizzaShackAPI
+  version: 1.0.0
+paths:
+  /menu:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+
+func writeDiffTestProject(t *testing.T, apiYaml, swagger string) string {
+	t.Helper()
+	projectDir := filepath.Join(t.TempDir(), "PizzaShackAPI-1.0.0")
+	writeApplyTestFile(t, filepath.Join(projectDir, utils.APIDefinitionFileYaml), apiYaml)
+	writeApplyTestFile(t, filepath.Join(projectDir, utils.InitProjectDefinitions, "swagger.yaml"), swagger)
+	return projectDir
+}
+
+func TestDiffAPIProjectsIgnoresOrderingAndGeneratedFields(t *testing.T) {
+	local, err := loadAPIDiffProject(writeDiffTestProject(t, `type: api
+data:
+  id: 1111
+  name: PizzaShackAPI
+  version: 1.0.0
+  tags: [pizza, food]
+  operations:
+    - id: a
+      target: /menu
+      verb: GET
+      authType: Application & Application User
+`, diffTestSwagger))
+	assert.Nil(t, err)
+	remote, err := loadAPIDiffProject(writeDiffTestProject(t, `type: api
+data:
+  id: 2222
+  name: PizzaShackAPI
+  version: 1.0.0
+  lastUpdatedTimestamp: 1617625320
+  tags: [food, pizza]
+  operations:
+    - id: b
+      target: /menu
+      verb: GET
+      authType: Application & Application User
+`, diffTestSwagger))
+	assert.Nil(t, err)
+
+	apiDiff := diffAPIProjects(local, remote)
+	assert.Empty(t, apiDiff.Entries, "Should not report ordering and generated field differences")
+}
+
+func TestDiffAPIProjectsIgnoresGeneratedAPIPolicyIDs(t *testing.T) {
+	apiYaml := `type: api
+data:
+  name: PizzaShackAPI
+  version: 1.0.0
+  apiPolicies:
+    request:
+      - policyName: addHeader
+        policyVersion: v1
+        policyId: %s
+        uuid: %s
+        parameters:
+          headerName: x-trace
+          headerValue: "true"
+`
+	local, err := loadAPIDiffProject(writeDiffTestProject(t, fmt.Sprintf(apiYaml, "1111", "aaaa"), diffTestSwagger))
+	assert.Nil(t, err)
+	remote, err := loadAPIDiffProject(writeDiffTestProject(t, fmt.Sprintf(apiYaml, "2222", "bbbb"), diffTestSwagger))
+	assert.Nil(t, err)
+
+	apiDiff := diffAPIProjects(local, remote)
+	assert.Empty(t, apiDiff.Entries, "Should not report generated API policy ID differences")
+}
+
+func TestDiffAPIProjectsReportsChanges(t *testing.T) {
+	local, err := loadAPIDiffProject(writeDiffTestProject(t, `type: api
+data:
+  name: PizzaShackAPI
+  version: 1.0.0
+  context: /pizza
+  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: https://localhost:9443/v2
+  operations:
+    - target: /menu
+      verb: GET
+      throttlingPolicy: Unlimited
+    - target: /order
+      verb: POST
+`, diffTestSwagger+`  /order:
+    post:
+      responses:
+        "201":
+          description: Created
+`))
+	assert.Nil(t, err)
+	remote, err := loadAPIDiffProject(writeDiffTestProject(t, `type: api
+data:
+  name: PizzaShackAPI
+  version: 1.0.0
+  context: /pizzashack
+  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: https://localhost:9443/v1
+  operations:
+    - target: /menu
+      verb: GET
+      throttlingPolicy: 10KPerMin
+`, diffTestSwagger))
+	assert.Nil(t, err)
+
+	apiDiff := diffAPIProjects(local, remote)
+	assert.Equal(t, []APIDiffEntry{
+		{Section: DiffSectionAPI, Change: DiffChangeChanged, Path: "context", Local: "/pizza", Remote: "/pizzashack"},
+		{Section: DiffSectionEndpoints, Change: DiffChangeChanged, Path: "production_endpoints.url",
+			Local: "https://localhost:9443/v2", Remote: "https://localhost:9443/v1"},
+		{Section: DiffSectionOperations, Change: DiffChangeChanged, Path: "GET /menu.throttlingPolicy",
+			Local: "Unlimited", Remote: "10KPerMin"},
+		{Section: DiffSectionOperations, Change: DiffChangeAdded, Path: "POST /order",
+			Local: map[string]interface{}{"target": "/order", "verb": "POST"}},
+		{Section: DiffSectionDefinition, Change: DiffChangeAdded, Path: "POST /order",
+			Local: map[string]interface{}{"responses": map[string]interface{}{
+				"201": map[string]interface{}{"description": "Created"}}}},
+	}, apiDiff.Entries)
+
+	unifiedDiff, err := apiDiff.UnifiedDiff()
+	assert.Nil(t, err)
+	assert.Contains(t, unifiedDiff, "-context: /pizzashack")
+	assert.Contains(t, unifiedDiff, "+context: /pizza")
+}
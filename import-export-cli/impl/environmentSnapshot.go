@@ -0,0 +1,247 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// EnvironmentSnapshotType is the type of the manifest of an environment snapshot
+	EnvironmentSnapshotType = "environment_snapshot"
+	// EnvironmentSnapshotVersion is the version of the snapshot layout written by this version of the tool
+	EnvironmentSnapshotVersion = "v1"
+
+	EnvironmentSnapshotManifestFileName = "manifest.yaml"
+	environmentSnapshotsDirName         = "snapshots"
+	environmentSnapshotLoggingDirName   = "logging"
+	snapshotImportProgressFilePrefix    = "import-progress-"
+)
+
+// Types of the artifacts in an environment snapshot
+const (
+	SnapshotArtifactRateLimitingPolicy = "rate_limiting_policy"
+	SnapshotArtifactAPIPolicy          = "api_policy"
+	SnapshotArtifactAPI                = "api"
+	SnapshotArtifactAPIProduct         = "api_product"
+	SnapshotArtifactMCPServer          = "mcp_server"
+	SnapshotArtifactApplication        = "application"
+	SnapshotArtifactCorrelationLogging = "correlation_logging"
+	SnapshotArtifactAPILogging         = "api_logging"
+)
+
+// snapshotArtifactOrder is the order in which the artifacts of a snapshot are imported. Policies are referred by
+// APIs, APIs are referred by API Products, APIs and API Products are subscribed by Applications and the logging
+// settings refer to the imported APIs.
+var snapshotArtifactOrder = []string{
+	SnapshotArtifactRateLimitingPolicy,
+	SnapshotArtifactAPIPolicy,
+	SnapshotArtifactAPI,
+	SnapshotArtifactAPIProduct,
+	SnapshotArtifactMCPServer,
+	SnapshotArtifactApplication,
+	SnapshotArtifactCorrelationLogging,
+	SnapshotArtifactAPILogging,
+}
+
+// snapshotArtifactDirs are the directories of the snapshot the artifacts are written to
+var snapshotArtifactDirs = map[string]string{
+	SnapshotArtifactRateLimitingPolicy: filepath.Join(utils.ExportedPoliciesDirName, utils.ExportedThrottlePoliciesDirName),
+	SnapshotArtifactAPIPolicy:          filepath.Join(utils.ExportedPoliciesDirName, utils.ExportedAPIPoliciesDirName),
+	SnapshotArtifactAPI:                utils.ExportedApisDirName,
+	SnapshotArtifactAPIProduct:         utils.ExportedApiProductsDirName,
+	SnapshotArtifactMCPServer:          utils.ExportedMCPServersDirName,
+	SnapshotArtifactApplication:        utils.ExportedAppsDirName,
+	SnapshotArtifactCorrelationLogging: environmentSnapshotLoggingDirName,
+	SnapshotArtifactAPILogging:         environmentSnapshotLoggingDirName,
+}
+
+// EnvironmentSnapshotManifest describes the content of an environment snapshot. Artifacts are added to the
+// manifest as soon as they are written, hence an interrupted export can be resumed from the manifest.
+type EnvironmentSnapshotManifest struct {
+	Type        string              `yaml:"type"`
+	Version     string              `yaml:"version"`
+	Environment string              `yaml:"environment"`
+	CreatedTime string              `yaml:"createdTime"`
+	Completed   bool                `yaml:"completed"`
+	WithKeys    bool                `yaml:"withKeys"`
+	Artifacts   []*SnapshotArtifact `yaml:"artifacts"`
+}
+
+// SnapshotArtifact is an artifact exported to an environment snapshot
+type SnapshotArtifact struct {
+	Type       string `yaml:"type"`
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version,omitempty"`
+	Owner      string `yaml:"owner,omitempty"`
+	PolicyType string `yaml:"policyType,omitempty"`
+	// Revision of an API, API Product or MCP Server. Empty for the working copy.
+	Revision string `yaml:"revision,omitempty"`
+	// File is the path of the artifact relative to the snapshot directory
+	File string `yaml:"file"`
+}
+
+// snapshotImportProgress records the artifacts of a snapshot already imported to an environment
+type snapshotImportProgress struct {
+	Environment string   `yaml:"environment"`
+	Imported    []string `yaml:"imported"`
+}
+
+// snapshotAPILogging is the log level of an API, identified in an environment independent way
+type snapshotAPILogging struct {
+	Name     string `yaml:"name"`
+	Version  string `yaml:"version"`
+	Provider string `yaml:"provider"`
+	LogLevel string `yaml:"logLevel"`
+}
+
+// Key uniquely identifies the artifact within a snapshot
+func (a SnapshotArtifact) Key() string {
+	return strings.Join([]string{a.Type, a.PolicyType, a.Owner, a.Name, a.Version, a.Revision}, ":")
+}
+
+// String returns a readable identifier of the artifact
+func (a SnapshotArtifact) String() string {
+	str := a.Type + " " + a.Name
+	if a.Version != "" {
+		str += " " + a.Version
+	}
+	if a.Owner != "" {
+		str += " (" + a.Owner + ")"
+	}
+	if a.Revision != "" {
+		str += " Revision " + a.Revision
+	}
+	return str
+}
+
+// GetDefaultEnvironmentSnapshotDir returns the directory a snapshot of an environment is exported to by default
+func GetDefaultEnvironmentSnapshotDir(environment string) string {
+	return filepath.Join(utils.ExportDirectory, environmentSnapshotsDirName, environment)
+}
+
+// LoadEnvironmentSnapshotManifest reads the manifest of the snapshot in snapshotDir
+func LoadEnvironmentSnapshotManifest(snapshotDir string) (*EnvironmentSnapshotManifest, error) {
+	content, err := ioutil.ReadFile(filepath.Join(snapshotDir, EnvironmentSnapshotManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &EnvironmentSnapshotManifest{}
+	if err := yaml.Unmarshal(content, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Type != EnvironmentSnapshotType {
+		return nil, errors.New(snapshotDir + " is not an environment snapshot")
+	}
+	if manifest.Version != EnvironmentSnapshotVersion {
+		return nil, errors.New("Unsupported environment snapshot version " + manifest.Version +
+			". Supported version is " + EnvironmentSnapshotVersion)
+	}
+	return manifest, nil
+}
+
+// writeEnvironmentSnapshotManifest writes the manifest to the snapshot directory
+func writeEnvironmentSnapshotManifest(snapshotDir string, manifest *EnvironmentSnapshotManifest) error {
+	return writeSnapshotYamlFile(filepath.Join(snapshotDir, EnvironmentSnapshotManifestFileName), manifest)
+}
+
+// writeSnapshotYamlFile writes the content as a yaml file, replacing the file only after the content is written
+// completely so that an interrupted write does not corrupt the resumption data
+func writeSnapshotYamlFile(filePath string, content interface{}) error {
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return err
+	}
+	tmpFilePath := filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpFilePath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+// getSnapshotImportProgressFilePath returns the path of the file recording the import progress of the snapshot to
+// the environment
+func getSnapshotImportProgressFilePath(snapshotDir, environment string) string {
+	return filepath.Join(snapshotDir, snapshotImportProgressFilePrefix+environment+".yaml")
+}
+
+// loadSnapshotImportProgress reads the import progress of the snapshot to the environment. An empty progress is
+// returned if the snapshot has not been imported to the environment before.
+func loadSnapshotImportProgress(snapshotDir, environment string) (*snapshotImportProgress, error) {
+	progress := &snapshotImportProgress{Environment: environment}
+	content, err := ioutil.ReadFile(getSnapshotImportProgressFilePath(snapshotDir, environment))
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(content, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// getPendingSnapshotArtifacts returns the artifacts of the manifest that are not imported yet, in the order they
+// should be imported. The order of the artifacts of the same type is kept, hence revisions are imported in the order
+// they were exported.
+func getPendingSnapshotArtifacts(manifest *EnvironmentSnapshotManifest,
+	progress *snapshotImportProgress) []*SnapshotArtifact {
+	imported := make(map[string]bool, len(progress.Imported))
+	for _, key := range progress.Imported {
+		imported[key] = true
+	}
+	var pending []*SnapshotArtifact
+	for _, artifact := range manifest.Artifacts {
+		if !imported[artifact.Key()] {
+			pending = append(pending, artifact)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return getSnapshotArtifactOrder(pending[i].Type) < getSnapshotArtifactOrder(pending[j].Type)
+	})
+	return pending
+}
+
+func getSnapshotArtifactOrder(artifactType string) int {
+	for i, t := range snapshotArtifactOrder {
+		if t == artifactType {
+			return i
+		}
+	}
+	return len(snapshotArtifactOrder)
+}
+
+// getSnapshotArtifactFileName returns a file name for an API, API Product or MCP Server artifact following the
+// naming used by the other export commands. Eg: PizzaShackAPI_1.0.0_Revision-1.zip
+func getSnapshotArtifactFileName(name, version, revision string) string {
+	fileName := name + "_" + version
+	if revision != "" {
+		fileName += "_" + utils.GetRevisionNamFromRevisionNum(revision)
+	}
+	return fileName + ".zip"
+}
@@ -0,0 +1,121 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestEnvironmentSnapshotManifestRoundTrip(t *testing.T) {
+	snapshotDir := t.TempDir()
+	manifest := &EnvironmentSnapshotManifest{
+		Type:        EnvironmentSnapshotType,
+		Version:     EnvironmentSnapshotVersion,
+		Environment: "production",
+		Completed:   true,
+		Artifacts: []*SnapshotArtifact{
+			{Type: SnapshotArtifactAPI, Name: "PizzaShackAPI", Version: "1.0.0", Revision: "1",
+				File: "apis/PizzaShackAPI_1.0.0_Revision-1.zip"},
+		},
+	}
+	if !assert.Nil(t, writeEnvironmentSnapshotManifest(snapshotDir, manifest)) {
+		return
+	}
+
+	loaded, err := LoadEnvironmentSnapshotManifest(snapshotDir)
+	assert.Nil(t, err, "Should load a manifest written by the export")
+	assert.Equal(t, manifest, loaded)
+
+	writeApplyTestFile(t, filepath.Join(snapshotDir, EnvironmentSnapshotManifestFileName),
+		"type: environment_snapshot\nversion: v0\n")
+	_, err = LoadEnvironmentSnapshotManifest(snapshotDir)
+	assert.Error(t, err, "Should return an error for an unsupported snapshot version")
+
+	writeApplyTestFile(t, filepath.Join(snapshotDir, EnvironmentSnapshotManifestFileName), "type: api\n")
+	_, err = LoadEnvironmentSnapshotManifest(snapshotDir)
+	assert.Error(t, err, "Should return an error for a file which is not a snapshot manifest")
+}
+
+func TestGetPendingSnapshotArtifactsOrdersByDependency(t *testing.T) {
+	app := &SnapshotArtifact{Type: SnapshotArtifactApplication, Name: "SampleApp", Owner: "admin"}
+	revision1 := &SnapshotArtifact{Type: SnapshotArtifactAPI, Name: "PizzaShackAPI", Version: "1.0.0", Revision: "1"}
+	revision2 := &SnapshotArtifact{Type: SnapshotArtifactAPI, Name: "PizzaShackAPI", Version: "1.0.0", Revision: "2"}
+	workingCopy := &SnapshotArtifact{Type: SnapshotArtifactAPI, Name: "PizzaShackAPI", Version: "1.0.0"}
+	policy := &SnapshotArtifact{Type: SnapshotArtifactRateLimitingPolicy, Name: "Gold",
+		PolicyType: CmdPolicyTypeSubscription}
+	manifest := &EnvironmentSnapshotManifest{
+		Artifacts: []*SnapshotArtifact{app, revision1, revision2, workingCopy, policy},
+	}
+
+	pending := getPendingSnapshotArtifacts(manifest, &snapshotImportProgress{})
+	assert.Equal(t, []*SnapshotArtifact{policy, revision1, revision2, workingCopy, app}, pending,
+		"Policies should be imported first and revisions should keep their order")
+
+	progress := &snapshotImportProgress{Imported: []string{policy.Key(), revision1.Key()}}
+	pending = getPendingSnapshotArtifacts(manifest, progress)
+	assert.Equal(t, []*SnapshotArtifact{revision2, workingCopy, app}, pending,
+		"Imported artifacts should be skipped when resuming")
+}
+
+func TestSnapshotImportProgressRoundTrip(t *testing.T) {
+	snapshotDir := t.TempDir()
+	progress, err := loadSnapshotImportProgress(snapshotDir, "dev")
+	assert.Nil(t, err, "Should return an empty progress if the snapshot was not imported before")
+	assert.Empty(t, progress.Imported)
+
+	progress.Imported = []string{"api::::PizzaShackAPI:1.0.0:1"}
+	if !assert.Nil(t, writeSnapshotYamlFile(getSnapshotImportProgressFilePath(snapshotDir, "dev"), progress)) {
+		return
+	}
+	loaded, err := loadSnapshotImportProgress(snapshotDir, "dev")
+	assert.Nil(t, err)
+	assert.Equal(t, progress, loaded)
+
+	other, err := loadSnapshotImportProgress(snapshotDir, "prod")
+	assert.Nil(t, err)
+	assert.Empty(t, other.Imported, "Progress should be tracked per environment")
+}
+
+func TestGetSnapshotRevisionNumbers(t *testing.T) {
+	revisions := []utils.Revisions{
+		{RevisionNumber: "Revision 10"}, {RevisionNumber: "Revision 2"}, {RevisionNumber: "Revision 1"},
+	}
+	assert.Equal(t, []string{"1", "2", "10", ""}, getSnapshotRevisionNumbers(revisions),
+		"Revisions should be sorted numerically and followed by the working copy")
+	assert.Equal(t, "PizzaShackAPI_1.0.0_Revision-10.zip", getSnapshotArtifactFileName("PizzaShackAPI", "1.0.0", "10"))
+	assert.Equal(t, "PizzaShackAPI_1.0.0.zip", getSnapshotArtifactFileName("PizzaShackAPI", "1.0.0", ""))
+}
+
+func TestExportEnvironmentSnapshotDoesNotCleanOtherDirectories(t *testing.T) {
+	snapshotDir := t.TempDir()
+	writeApplyTestFile(t, filepath.Join(snapshotDir, "notes.txt"), "keep")
+
+	exporter := &environmentSnapshotExporter{
+		environment: "production",
+		snapshotDir: snapshotDir,
+		options:     EnvironmentSnapshotExportOptions{Force: true},
+		exported:    make(map[string]bool),
+	}
+	assert.Error(t, exporter.prepare(), "Should not export to a non empty directory which is not a snapshot")
+	assert.True(t, utils.IsFileExist(filepath.Join(snapshotDir, "notes.txt")))
+}
@@ -120,7 +120,7 @@ func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLoc
 
 	// Output the final zip file location.
 	if runningExportApiCommand {
-		fmt.Println("Successfully exported API!")
-		fmt.Println("Find the exported API at " + exportedFinalZip)
+		fmt.Fprintln(utils.Stdout, "Successfully exported API!")
+		fmt.Fprintln(utils.Stdout, "Find the exported API at "+exportedFinalZip)
 	}
 }
@@ -70,7 +70,7 @@ func WriteAPIPolicyToFile(exportLocationPath string, resp *resty.Response, expor
 		utils.HandleErrorAndExit("Error creating the temporary zip file to store the exported API", err)
 	}
 
-	fmt.Println("Successfully exported API Policy!")
-	fmt.Println("Find the exported API Policy at " +
-		utils.AppendSlashToString(exportLocationPath) + zipFileName)
+	fmt.Fprintln(utils.Stdout, "Successfully exported API Policy!")
+	fmt.Fprintln(utils.Stdout, "Find the exported API Policy at "+
+		utils.AppendSlashToString(exportLocationPath)+zipFileName)
 }
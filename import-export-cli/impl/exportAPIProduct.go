@@ -111,7 +111,7 @@ func WriteAPIProductToZip(exportAPIProductName, exportAPIProductVersion, zipLoca
 	}
 
 	if runningExportAPIProductCommand {
-		fmt.Println("Successfully exported API Product!")
-		fmt.Println("Find the exported API Product at " + exportedFinalZip)
+		fmt.Fprintln(utils.Stdout, "Successfully exported API Product!")
+		fmt.Fprintln(utils.Stdout, "Find the exported API Product at "+exportedFinalZip)
 	}
 }
@@ -19,6 +19,7 @@
 package impl
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -67,7 +68,7 @@ func PrepareResumption(credential credentials.Credential, exportRelatedFilesPath
 			utils.WriteMigrationApisExportMetadataFile(apis, cmdResourceTenantDomain, cmdUsername,
 				exportRelatedFilesPath, apiListOffset)
 		} else {
-			fmt.Println("Command: export apis execution completed !")
+			fmt.Fprintln(utils.Stdout, "Command: export apis execution completed !")
 		}
 	}
 }
@@ -75,7 +76,7 @@ func PrepareResumption(credential credentials.Credential, exportRelatedFilesPath
 // Delete directories where the APIs are exported, reset the indexes, get first API list and write the
 // migration-apis-export-metadata.yaml file
 func PrepareStartFromBeginning(credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	fmt.Println("Cleaning all the previously exported APIs of the given target tenant, in the given environment if " +
+	fmt.Fprintln(utils.Stdout, "Cleaning all the previously exported APIs of the given target tenant, in the given environment if "+
 		"any, and prepare to export APIs from beginning")
 	//cleaning existing old files (if exists) related to exportation
 	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportRelatedFilesPath, utils.ExportedApisDirName)); err != nil {
@@ -146,61 +147,49 @@ func getRevisionsListForAPI(accessToken, cmdExportEnvironment string, api utils.
 // Do the API exportation
 func ExportAPIs(credential credentials.Credential, exportRelatedFilesPath, cmdExportEnvironment, cmdResourceTenantDomain,
 	exportAPIsFormat, cmdUsername, apiExportDir string, exportAPIPreserveStatus, runningExportApiCommand,
-	exportAllRevisions, exportForAI, exportAPIPreserveCredentials bool) {
+	exportAllRevisions, exportForAI, exportAPIPreserveCredentials bool, workers int) {
 	if count == 0 {
-		fmt.Println("No APIs available to be exported..!")
+		fmt.Fprintln(utils.Stdout, "No APIs available to be exported..!")
 	} else {
-		var counterSuceededAPIs = 0
+		var counterSuceededAPIs int32 = 0
+		var results []*BulkArtifactResult
 		for count > 0 {
 			utils.Logln(utils.LogPrefixInfo+"Found ", count, "of APIs to be exported in the iteration beginning with the offset #"+
 				strconv.Itoa(apiListOffset)+". Maximum limit of APIs exported in single iteration is "+
 				strconv.Itoa(utils.MaxAPIsToExportOnce))
 			accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
 			if preCommandErr == nil {
-				apiList := []map[string]interface{}{}
-				for i := startingApiIndexFromList; i < len(apis); i++ {
-					if exportForAI {
+				if exportForAI {
+					apiList := []map[string]interface{}{}
+					for i := startingApiIndexFromList; i < len(apis); i++ {
 						apiPayload := GetAPIPayload(apis[i], accessToken, CmdUploadEnvironment, false)
 						if apiPayload != nil {
 							apiList = append(apiList, apiPayload)
 						}
-						continue
-					} else {
-						if exportAllRevisions {
-							//Export the working copy of the api
-							exportAPIandWriteToZip(apis[i], "", accessToken, cmdExportEnvironment, apiExportDir,
-								exportRelatedFilesPath, exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand,
-								exportAPIPreserveCredentials)
-							counterSuceededAPIs++
-						}
-						revisionCount, revisions, err := getRevisionsListForAPI(accessToken, cmdExportEnvironment, apis[i],
-							exportAllRevisions)
-						if err != nil {
-							fmt.Println("An error occurred while getting the revisions list for API "+apis[i].Version+
-								"_"+apis[i].Version, err)
-						} else if revisionCount > 0 {
-							for j := 0; j < len(revisions); j++ {
-								exportApiRevision := utils.GetRevisionNumFromRevisionName(revisions[j].RevisionNumber)
-								exportAPIandWriteToZip(apis[i], exportApiRevision, accessToken, cmdExportEnvironment,
-									apiExportDir, exportRelatedFilesPath, exportAPIsFormat, exportAPIPreserveStatus,
-									runningExportApiCommand, exportAPIPreserveCredentials)
-								counterSuceededAPIs++
-							}
-						}
 					}
-				}
-				if exportForAI {
 					atomic.AddInt32(&totalAPIs, int32(len(apiList)))
 					if len(apiList) > 0 {
 						apiListQueue <- apiList
 					}
+				} else {
+					batchResults := exportAPIsBatch(apis[startingApiIndexFromList:], workers, accessToken,
+						cmdExportEnvironment, apiExportDir, exportRelatedFilesPath, exportAPIsFormat,
+						exportAPIPreserveStatus, runningExportApiCommand, exportAllRevisions,
+						exportAPIPreserveCredentials, &counterSuceededAPIs)
+					results = append(results, batchResults...)
+					if failed := countFailedBulkResults(batchResults); failed > 0 {
+						PrintBulkReport(results)
+						// Moving to the next batch would overwrite the list of APIs to resume from
+						utils.HandleErrorAndExit("Error exporting APIs", errors.New(strconv.Itoa(failed)+
+							" APIs failed to export. Run the command again to resume from the first failed API"))
+					}
 				}
 			} else {
 				// error getting OAuth tokens
-				fmt.Println("Error getting OAuth Tokens : " + preCommandErr.Error())
+				fmt.Fprintln(utils.Stdout, "Error getting OAuth Tokens : "+preCommandErr.Error())
 			}
 			if !exportForAI {
-				fmt.Println("Batch of " + cast.ToString(count) + " APIs exported successfully..!")
+				fmt.Fprintln(utils.Stdout, "Batch of "+cast.ToString(count)+" APIs exported successfully..!")
 			}
 
 			apiListOffset += utils.MaxAPIsToExportOnce
@@ -214,17 +203,77 @@ func ExportAPIs(credential credentials.Credential, exportRelatedFilesPath, cmdEx
 			}
 		}
 		if !exportForAI {
-			fmt.Println("\nTotal number of APIs exported: " + cast.ToString(counterSuceededAPIs))
-			fmt.Println("API export path: " + apiExportDir)
-			fmt.Println("\nCommand: export-apis execution completed !")
+			PrintBulkReport(results)
+			fmt.Fprintln(utils.Stdout, "\nTotal number of APIs exported: "+cast.ToString(counterSuceededAPIs))
+			fmt.Fprintln(utils.Stdout, "API export path: "+apiExportDir)
+			fmt.Fprintln(utils.Stdout, "\nCommand: export-apis execution completed !")
+		}
+	}
+}
+
+// Export a batch of APIs concurrently using the given number of workers. The last succeeded API is recorded only
+// when all the APIs before it in the batch are exported, so that a resumed export does not skip any API.
+func exportAPIsBatch(batch []utils.API, workers int, accessToken, cmdExportEnvironment, apiExportDir,
+	exportRelatedFilesPath, exportAPIsFormat string, exportAPIPreserveStatus, runningExportApiCommand,
+	exportAllRevisions, exportAPIPreserveCredentials bool, counterSuceededAPIs *int32) []*BulkArtifactResult {
+	tasks := make([]bulkTask, len(batch))
+	for i := range batch {
+		api := batch[i]
+		// A retried task resumes from the export that failed, so that the exported revisions are not counted again
+		workingCopyExported := false
+		var revisions []utils.Revisions
+		var revisionCount int32
+		nextRevision := 0
+		tasks[i] = bulkTask{
+			artifact:   api.Name + " " + api.Version + " (" + api.Provider + ")",
+			idempotent: true,
+			run: func() error {
+				if exportAllRevisions && !workingCopyExported {
+					//Export the working copy of the api
+					if err := exportAPIandWriteToZip(api, "", accessToken, cmdExportEnvironment, apiExportDir,
+						exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand,
+						exportAPIPreserveCredentials); err != nil {
+						return err
+					}
+					atomic.AddInt32(counterSuceededAPIs, 1)
+					workingCopyExported = true
+				}
+				if revisions == nil {
+					count, list, err := getRevisionsListForAPI(accessToken, cmdExportEnvironment, api,
+						exportAllRevisions)
+					if err != nil {
+						return fmt.Errorf("Error getting the revisions list: %w", err)
+					}
+					revisionCount, revisions = count, list
+				}
+				for ; nextRevision < int(revisionCount) && nextRevision < len(revisions); nextRevision++ {
+					exportApiRevision := utils.GetRevisionNumFromRevisionName(revisions[nextRevision].RevisionNumber)
+					if err := exportAPIandWriteToZip(api, exportApiRevision, accessToken, cmdExportEnvironment,
+						apiExportDir, exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand,
+						exportAPIPreserveCredentials); err != nil {
+						return err
+					}
+					atomic.AddInt32(counterSuceededAPIs, 1)
+				}
+				return nil
+			},
 		}
 	}
+	marker := newBulkResumptionMarker(len(batch))
+	return runBulkTasks(tasks, workers, func(index int, result *BulkArtifactResult) {
+		if result.Err != nil {
+			return
+		}
+		if last := marker.markSucceeded(index); last >= 0 {
+			//write on last-succeeded-api.log
+			utils.WriteLastSuceededAPIFileData(exportRelatedFilesPath, batch[last])
+		}
+	})
 }
 
 // Export the API and archive to zip format
 func exportAPIandWriteToZip(api utils.API, revisionNumber, accessToken, cmdExportEnvironment, apiExportDir,
-	exportRelatedFilesPath, exportAPIsFormat string, exportAPIPreserveStatus, runningExportApiCommand,
-	exportAPIPreserveCredentials bool) {
+	exportAPIsFormat string, exportAPIPreserveStatus, runningExportApiCommand, exportAPIPreserveCredentials bool) error {
 
 	exportAPIName := api.Name
 	exportAPIVersion := api.Version
@@ -237,18 +286,14 @@ func exportAPIandWriteToZip(api utils.API, revisionNumber, accessToken, cmdExpor
 		exportApiProvider, exportAPIsFormat, cmdExportEnvironment, exportAPIPreserveStatus, false,
 		exportAPIPreserveCredentials)
 	if err != nil {
-		utils.HandleErrorAndExit("Error exporting", err)
+		return err
 	}
-
-	if resp.StatusCode() == http.StatusOK {
-		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		WriteToZip(exportAPIName, exportAPIVersion, exportApiRevision, apiExportDir, runningExportApiCommand, resp)
-		//write on last-succeeded-api.log
-		utils.WriteLastSuceededAPIFileData(exportRelatedFilesPath, api)
-	} else {
-		fmt.Println("Error exporting API:", exportAPIName, "-", exportAPIVersion, " of Provider:", exportApiProvider)
-		utils.PrintErrorResponseAndExit(resp)
+	if resp.StatusCode() != http.StatusOK {
+		return utils.NewHttpResponseError(resp)
 	}
+	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+	WriteToZip(exportAPIName, exportAPIVersion, exportApiRevision, apiExportDir, runningExportApiCommand, resp)
+	return nil
 }
 
 // Create the required directory structure to save the exported APIs
@@ -108,8 +108,8 @@ func WriteApplicationToZip(exportAppName, exportAppOwner, zipLocationPath string
 		utils.HandleErrorAndExit("Error creating the final zip archive with application_meta.yaml file", err)
 	}
 
-	fmt.Println("Successfully exported Application!")
-	fmt.Println("Find the exported Application at " + exportedFinalZip)
+	fmt.Fprintln(utils.Stdout, "Successfully exported Application!")
+	fmt.Fprintln(utils.Stdout, "Find the exported Application at "+exportedFinalZip)
 }
 
 // The Application owner name is used to construct a unique name for the app export zip.
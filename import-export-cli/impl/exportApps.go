@@ -19,6 +19,7 @@
 package impl
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -64,7 +65,7 @@ func PrepareResumptionForApps(credential credentials.Credential, exportAppsRelat
 			utils.WriteMigrationAppsExportMetadataFile(apps, cmdResourceTenantDomain, cmdUsername,
 				exportAppsRelatedFilesPath, appListOffset)
 		} else {
-			fmt.Println("Command: export apps execution completed!")
+			fmt.Fprintln(utils.Stdout, "Command: export apps execution completed!")
 		}
 	}
 }
@@ -72,7 +73,7 @@ func PrepareResumptionForApps(credential credentials.Credential, exportAppsRelat
 // Delete directories where the Apps are exported, reset the indexes, get first App list and write the
 // migration-apps-export-metadata.yaml file
 func PrepareStartAppsFromBeginning(credential credentials.Credential, exportAppsRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	fmt.Println("Cleaning all the previously exported Apps of the given target tenant, in the given environment if " +
+	fmt.Fprintln(utils.Stdout, "Cleaning all the previously exported Apps of the given target tenant, in the given environment if "+
 		"any, and prepare to export Apps from beginning")
 	// Cleaning existing old files (if exists) related to exportation
 	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportAppsRelatedFilesPath, utils.ExportedAppsDirName)); err != nil {
@@ -131,27 +132,34 @@ func getAppList(credential credentials.Credential, cmdExportEnvironment, cmdReso
 
 // Do the App exportation
 func ExportApps(credential credentials.Credential, exportAppsRelatedFilesPath, cmdExportEnvironment, cmdResourceTenantDomain,
-	exportAppsFormat, cmdUsername, appExportDir string, exportAppsWithKeys bool) {
+	exportAppsFormat, cmdUsername, appExportDir string, exportAppsWithKeys bool, workers int) {
 	if appCount == 0 {
-		fmt.Println("No Apps available to be exported..!")
+		fmt.Fprintln(utils.Stdout, "No Apps available to be exported..!")
 	} else {
 		var counterSuceededApps = 0
+		var results []*BulkArtifactResult
 		for appCount > 0 {
 			utils.Logln(utils.LogPrefixInfo+"Found ", appCount, "of Apps to be exported in the iteration beginning with the offset #"+
 				strconv.Itoa(appListOffset)+". Maximum limit of Apps exported in single iteration is "+
 				strconv.Itoa(utils.MaxAppsToExportOnce))
 			accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
 			if preCommandErr == nil {
-				for i := startingAppIndexFromList; i < len(apps); i++ {
-                    exportAppAndWriteToZip(apps[i], accessToken, cmdExportEnvironment, appExportDir,
-                        exportAppsRelatedFilesPath, exportAppsFormat, exportAppsWithKeys)
-                    counterSuceededApps++
+				batchResults := exportAppsBatch(apps[startingAppIndexFromList:], workers, accessToken,
+					cmdExportEnvironment, appExportDir, exportAppsRelatedFilesPath, exportAppsFormat, exportAppsWithKeys)
+				results = append(results, batchResults...)
+				failed := countFailedBulkResults(batchResults)
+				counterSuceededApps += len(batchResults) - failed
+				if failed > 0 {
+					PrintBulkReport(results)
+					// Moving to the next batch would overwrite the list of Apps to resume from
+					utils.HandleErrorAndExit("Error exporting Apps", errors.New(strconv.Itoa(failed)+
+						" Apps failed to export. Run the command again to resume from the first failed App"))
 				}
 			} else {
 				// Error getting OAuth tokens
-				fmt.Println("Error getting OAuth Tokens : " + preCommandErr.Error())
+				fmt.Fprintln(utils.Stdout, "Error getting OAuth Tokens : "+preCommandErr.Error())
 			}
-			fmt.Println("Batch of " + cast.ToString(appCount) + " Apps exported successfully..!")
+			fmt.Fprintln(utils.Stdout, "Batch of "+cast.ToString(appCount)+" Apps exported successfully..!")
 
 			appListOffset += utils.MaxAppsToExportOnce
 			appCount, apps = getAppList(credential, cmdExportEnvironment, cmdResourceTenantDomain)
@@ -161,33 +169,58 @@ func ExportApps(credential credentials.Credential, exportAppsRelatedFilesPath, c
 					exportAppsRelatedFilesPath, appListOffset)
 			}
 		}
-		fmt.Println("\nTotal number of Apps exported: " + cast.ToString(counterSuceededApps))
-		fmt.Println("App export path: " + appExportDir)
-		fmt.Println("\nCommand: export-apps execution completed !")
+		PrintBulkReport(results)
+		fmt.Fprintln(utils.Stdout, "\nTotal number of Apps exported: "+cast.ToString(counterSuceededApps))
+		fmt.Fprintln(utils.Stdout, "App export path: "+appExportDir)
+		fmt.Fprintln(utils.Stdout, "\nCommand: export-apps execution completed !")
 	}
 }
 
+// Export a batch of Apps concurrently using the given number of workers. The last succeeded App is recorded only
+// when all the Apps before it in the batch are exported, so that a resumed export does not skip any App.
+func exportAppsBatch(batch []utils.Application, workers int, accessToken, cmdExportEnvironment, appExportDir,
+	exportAppsRelatedFilesPath, exportAppsFormat string, exportAppsWithKeys bool) []*BulkArtifactResult {
+	tasks := make([]bulkTask, len(batch))
+	for i := range batch {
+		app := batch[i]
+		tasks[i] = bulkTask{
+			artifact:   app.Name + " (" + app.Owner + ")",
+			idempotent: true,
+			run: func() error {
+				return exportAppAndWriteToZip(app, accessToken, cmdExportEnvironment, appExportDir, exportAppsFormat,
+					exportAppsWithKeys)
+			},
+		}
+	}
+	marker := newBulkResumptionMarker(len(batch))
+	return runBulkTasks(tasks, workers, func(index int, result *BulkArtifactResult) {
+		if result.Err != nil {
+			return
+		}
+		if last := marker.markSucceeded(index); last >= 0 {
+			// write on last-succeeded-app.log
+			utils.WriteLastSuceededAppFileData(exportAppsRelatedFilesPath, batch[last])
+		}
+	})
+}
+
 // Export the App and archive to zip format
 func exportAppAndWriteToZip(app utils.Application, accessToken, cmdExportEnvironment, appExportDir,
-	exportAppsRelatedFilesPath, exportAppsFormat string, exportAppsWithKeys bool) {
+	exportAppsFormat string, exportAppsWithKeys bool) error {
 
 	exportAppName := app.Name
 	exportAppOwner := app.Owner
 	resp, err := ExportAppFromEnv(accessToken, exportAppName, exportAppOwner, exportAppsFormat, cmdExportEnvironment,
-	 exportAppsWithKeys)
+		exportAppsWithKeys)
 	if err != nil {
-		utils.HandleErrorAndExit("Error exporting", err)
+		return err
 	}
-
-	if resp.StatusCode() == http.StatusOK {
-		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		WriteApplicationToZip(exportAppName, exportAppOwner, appExportDir, resp)
-		// write on last-succeeded-app.log
-		utils.WriteLastSuceededAppFileData(exportAppsRelatedFilesPath, app)
-	} else {
-		fmt.Println("Error exporting App:", exportAppName, " of Owner:", exportAppOwner)
-		utils.PrintErrorResponseAndExit(resp)
+	if resp.StatusCode() != http.StatusOK {
+		return utils.NewHttpResponseError(resp)
 	}
+	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+	WriteApplicationToZip(exportAppName, exportAppOwner, appExportDir, resp)
+	return nil
 }
 
 // Create the required directory structure to save the exported Apps
@@ -0,0 +1,483 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Types of the rate limiting policies returned by the policy search
+const (
+	searchPolicyTypeSubscription = "SubscriptionThrottlePolicy"
+	searchPolicyTypeApplication  = "ApplicationThrottlePolicy"
+	searchPolicyTypeAdvanced     = "AdvancedThrottlePolicy"
+	searchPolicyTypeCustom       = "GlobalThrottlePolicy"
+)
+
+// EnvironmentSnapshotExportOptions holds the user provided options of an environment export
+type EnvironmentSnapshotExportOptions struct {
+	Format              string
+	WithKeys            bool
+	PreserveStatus      bool
+	PreserveCredentials bool
+	Force               bool
+}
+
+// environmentSnapshotExporter exports the artifacts of an environment to a snapshot directory, skipping the
+// artifacts already available in the manifest of a previous halted export
+type environmentSnapshotExporter struct {
+	credential  credentials.Credential
+	environment string
+	snapshotDir string
+	options     EnvironmentSnapshotExportOptions
+	manifest    *EnvironmentSnapshotManifest
+	exported    map[string]bool
+	accessToken string
+	apis        []utils.API
+}
+
+// ExportEnvironmentSnapshot exports the rate limiting policies, API policies, APIs, API Products, MCP Servers,
+// Applications and logging settings of an environment to snapshotDir along with a manifest describing them.
+// If a previous export to snapshotDir was halted, the export is resumed unless options.Force is set.
+// @param credential : Credential of the environment
+// @param environment : Environment to be exported
+// @param snapshotDir : Directory the snapshot is written to
+// @param options : Options of the export
+// @return manifest of the snapshot
+// @return error
+func ExportEnvironmentSnapshot(credential credentials.Credential, environment, snapshotDir string,
+	options EnvironmentSnapshotExportOptions) (*EnvironmentSnapshotManifest, error) {
+	exporter := &environmentSnapshotExporter{
+		credential:  credential,
+		environment: environment,
+		snapshotDir: snapshotDir,
+		options:     options,
+		exported:    make(map[string]bool),
+	}
+	if err := exporter.prepare(); err != nil {
+		return nil, err
+	}
+	if exporter.manifest.Completed {
+		fmt.Fprintln(utils.Stdout, "Snapshot of "+environment+" is already available at "+snapshotDir+". Use --force to "+
+			"export it again")
+		return exporter.manifest, nil
+	}
+
+	stages := []struct {
+		name   string
+		export func() error
+	}{
+		{"rate limiting policies", exporter.exportRateLimitingPolicies},
+		{"API policies", exporter.exportAPIPolicies},
+		{"APIs", exporter.exportAPIs},
+		{"API Products", exporter.exportAPIProducts},
+		{"MCP Servers", exporter.exportMCPServers},
+		{"Applications", exporter.exportApplications},
+		{"logging settings", exporter.exportLoggingSettings},
+	}
+	for _, stage := range stages {
+		fmt.Fprintln(utils.Stdout, "Exporting "+stage.name+"...")
+		accessToken, err := credentials.GetOAuthAccessToken(credential, environment)
+		if err != nil {
+			return nil, err
+		}
+		exporter.accessToken = accessToken
+		if err := stage.export(); err != nil {
+			return nil, errors.New("Error exporting " + stage.name + ": " + err.Error() +
+				". Run the command again to resume the export")
+		}
+	}
+
+	exporter.manifest.Completed = true
+	if err := writeEnvironmentSnapshotManifest(snapshotDir, exporter.manifest); err != nil {
+		return nil, err
+	}
+	return exporter.manifest, nil
+}
+
+// prepare loads the manifest of a previous export to resume it, or cleans the snapshot directory and creates a new
+// manifest
+func (e *environmentSnapshotExporter) prepare() error {
+	manifestPath := filepath.Join(e.snapshotDir, EnvironmentSnapshotManifestFileName)
+	if utils.IsFileExist(manifestPath) && !e.options.Force {
+		manifest, err := LoadEnvironmentSnapshotManifest(e.snapshotDir)
+		if err != nil {
+			return err
+		}
+		if manifest.Environment != e.environment {
+			return errors.New(e.snapshotDir + " contains a snapshot of the environment " + manifest.Environment +
+				". Use --force to replace it")
+		}
+		if !manifest.Completed {
+			fmt.Fprintln(utils.Stdout, "Resuming the export of "+e.environment+" to "+e.snapshotDir+" ("+
+				strconv.Itoa(len(manifest.Artifacts))+" artifacts already exported)")
+		}
+		for _, artifact := range manifest.Artifacts {
+			e.exported[artifact.Key()] = true
+		}
+		e.manifest = manifest
+		return nil
+	}
+
+	if utils.IsFileExist(manifestPath) {
+		fmt.Fprintln(utils.Stdout, "Cleaning the previous snapshot in "+e.snapshotDir+" and exporting from the beginning")
+		if err := utils.RemoveDirectoryIfExists(e.snapshotDir); err != nil {
+			return err
+		}
+	} else if entries, err := ioutil.ReadDir(e.snapshotDir); err == nil && len(entries) > 0 {
+		// Never clean a directory that is not a snapshot
+		return errors.New(e.snapshotDir + " is not empty and does not contain an environment snapshot")
+	}
+	if err := os.MkdirAll(e.snapshotDir, os.ModePerm); err != nil {
+		return err
+	}
+	e.manifest = &EnvironmentSnapshotManifest{
+		Type:        EnvironmentSnapshotType,
+		Version:     EnvironmentSnapshotVersion,
+		Environment: e.environment,
+		CreatedTime: time.Now().UTC().Format(time.RFC3339),
+		WithKeys:    e.options.WithKeys,
+	}
+	return writeEnvironmentSnapshotManifest(e.snapshotDir, e.manifest)
+}
+
+// addArtifact writes the content of an artifact to the snapshot and records it in the manifest
+func (e *environmentSnapshotExporter) addArtifact(artifact *SnapshotArtifact, fileName string, content []byte) error {
+	dir := snapshotArtifactDirs[artifact.Type]
+	if err := utils.CreateDirIfNotExist(filepath.Join(e.snapshotDir, dir)); err != nil {
+		return err
+	}
+	artifact.File = filepath.ToSlash(filepath.Join(dir, fileName))
+	if err := ioutil.WriteFile(filepath.Join(e.snapshotDir, dir, fileName), content, 0644); err != nil {
+		return err
+	}
+	e.manifest.Artifacts = append(e.manifest.Artifacts, artifact)
+	e.exported[artifact.Key()] = true
+	utils.Logln(utils.LogPrefixInfo + "Exported " + artifact.String())
+	return writeEnvironmentSnapshotManifest(e.snapshotDir, e.manifest)
+}
+
+// checkSnapshotExportResponse returns an error if exporting the artifact was not successful
+func checkSnapshotExportResponse(artifact *SnapshotArtifact, resp *resty.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New("Exporting " + artifact.String() + " failed with status " + resp.Status() + ": " +
+			string(resp.Body()))
+	}
+	return nil
+}
+
+func (e *environmentSnapshotExporter) exportRateLimitingPolicies() error {
+	resp, err := GetThrottlePolicyListFromEnv(e.accessToken, e.environment, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New("Request didn't respond 200 OK for getting rate limiting policies. Status: " + resp.Status())
+	}
+	var policyList utils.ThrottlingPoliciesDetailsList
+	if err := json.Unmarshal(resp.Body(), &policyList); err != nil {
+		return err
+	}
+	for _, policy := range policyList.List {
+		policyType := getCmdPolicyTypeOfSearchType(policy.Type)
+		if policyType == "" {
+			utils.Logln(utils.LogPrefixWarning + "Skipping the policy " + policy.PolicyName + " of unsupported type " +
+				policy.Type)
+			continue
+		}
+		artifact := &SnapshotArtifact{Type: SnapshotArtifactRateLimitingPolicy, Name: policy.PolicyName,
+			PolicyType: policyType}
+		if e.exported[artifact.Key()] {
+			continue
+		}
+		resp, err := ExportThrottlingPolicyFromEnv(e.accessToken, e.environment, policy.PolicyName, policyType,
+			e.options.Format)
+		if err := checkSnapshotExportResponse(artifact, resp, err); err != nil {
+			return err
+		}
+		fileName, content := resolveThrottlePolicy(e.options.Format, resp)
+		if err := e.addArtifact(artifact, fileName, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCmdPolicyTypeOfSearchType maps the type of a rate limiting policy in the search results to the type used by
+// the commands
+func getCmdPolicyTypeOfSearchType(searchType string) string {
+	switch searchType {
+	case searchPolicyTypeSubscription:
+		return CmdPolicyTypeSubscription
+	case searchPolicyTypeApplication:
+		return CmdPolicyTypeApplication
+	case searchPolicyTypeAdvanced:
+		return CmdPolicyTypeAdvanced
+	case searchPolicyTypeCustom:
+		return CmdPolicyTypeCustom
+	}
+	return ""
+}
+
+func (e *environmentSnapshotExporter) exportAPIPolicies() error {
+	policies, err := getAllAPIPoliciesFromEnv(e.accessToken, e.environment)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		artifact := &SnapshotArtifact{Type: SnapshotArtifactAPIPolicy, Name: policy.Name, Version: policy.Version}
+		if e.exported[artifact.Key()] {
+			continue
+		}
+		resp, err := ExportAPIPolicyFromEnv(e.accessToken, e.environment, policy.Name,
+			policy.Version, e.options.Format)
+		if err := checkSnapshotExportResponse(artifact, resp, err); err != nil {
+			return err
+		}
+		if err := e.addArtifact(artifact, policy.Name+"_"+policy.Version+".zip", resp.Body()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *environmentSnapshotExporter) exportAPIs() error {
+	apis, err := getAllAPIsFromEnv(e.accessToken, e.environment)
+	if err != nil {
+		return err
+	}
+	e.apis = apis
+	revisionEndpoint := utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(e.environment,
+		utils.MainConfigFilePath))
+	for _, api := range apis {
+		_, revisions, err := GetRevisionsList(e.accessToken, revisionEndpoint+api.ID+"/revisions")
+		if err != nil {
+			return err
+		}
+		for _, revision := range getSnapshotRevisionNumbers(revisions) {
+			artifact := &SnapshotArtifact{Type: SnapshotArtifactAPI, Name: api.Name, Version: api.Version,
+				Owner: api.Provider, Revision: revision}
+			if e.exported[artifact.Key()] {
+				continue
+			}
+			resp, err := ExportAPIFromEnv(e.accessToken, api.Name, api.Version, revision,
+				api.Provider, e.options.Format, e.environment, e.options.PreserveStatus, false,
+				e.options.PreserveCredentials)
+			if err := checkSnapshotExportResponse(artifact, resp, err); err != nil {
+				return err
+			}
+			if err := e.addArtifact(artifact, getSnapshotArtifactFileName(api.Name, api.Version, revision),
+				resp.Body()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *environmentSnapshotExporter) exportAPIProducts() error {
+	apiProducts, err := getAllAPIProductsFromEnv(e.accessToken, e.environment)
+	if err != nil {
+		return err
+	}
+	revisionEndpoint := utils.AppendSlashToString(utils.GetApiProductListEndpointOfEnv(e.environment,
+		utils.MainConfigFilePath))
+	for _, apiProduct := range apiProducts {
+		_, revisions, err := GetAPIProductRevisionsList(e.accessToken, revisionEndpoint+apiProduct.ID+"/revisions")
+		if err != nil {
+			return err
+		}
+		for _, revision := range getSnapshotRevisionNumbers(revisions) {
+			artifact := &SnapshotArtifact{Type: SnapshotArtifactAPIProduct, Name: apiProduct.Name,
+				Version: apiProduct.Version, Owner: apiProduct.Provider, Revision: revision}
+			if e.exported[artifact.Key()] {
+				continue
+			}
+			resp, err := ExportAPIProductFromEnv(e.accessToken, apiProduct.Name,
+				apiProduct.Version, revision, apiProduct.Provider, e.options.Format, e.environment, false,
+				e.options.PreserveStatus)
+			if err := checkSnapshotExportResponse(artifact, resp, err); err != nil {
+				return err
+			}
+			if err := e.addArtifact(artifact, getSnapshotArtifactFileName(apiProduct.Name, apiProduct.Version,
+				revision), resp.Body()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *environmentSnapshotExporter) exportMCPServers() error {
+	var mcpServers []utils.MCPServer
+	for offset := 0; ; offset += utils.MaxAPIsToExportOnce {
+		_, mcpServerList, err := GetMCPServerListFromEnv(e.accessToken, e.environment, "",
+			strconv.Itoa(utils.MaxAPIsToExportOnce)+"&offset="+strconv.Itoa(offset))
+		if err != nil {
+			return err
+		}
+		mcpServers = append(mcpServers, mcpServerList...)
+		if len(mcpServerList) < utils.MaxAPIsToExportOnce {
+			break
+		}
+	}
+	revisionEndpoint := utils.AppendSlashToString(utils.GetMcpServerListEndpointOfEnv(e.environment,
+		utils.MainConfigFilePath))
+	for _, mcpServer := range mcpServers {
+		_, revisions, err := GetMCPServerRevisionsList(e.accessToken, revisionEndpoint+mcpServer.ID+"/revisions")
+		if err != nil {
+			return err
+		}
+		for _, revision := range getSnapshotRevisionNumbers(revisions) {
+			artifact := &SnapshotArtifact{Type: SnapshotArtifactMCPServer, Name: mcpServer.Name,
+				Version: mcpServer.Version, Owner: mcpServer.Provider, Revision: revision}
+			if e.exported[artifact.Key()] {
+				continue
+			}
+			resp, err := ExportMCPServerFromEnv(e.accessToken, mcpServer.Name,
+				mcpServer.Version, revision, mcpServer.Provider, e.options.Format, e.environment,
+				e.options.PreserveStatus, false, e.options.PreserveCredentials)
+			if err := checkSnapshotExportResponse(artifact, resp, err); err != nil {
+				return err
+			}
+			if err := e.addArtifact(artifact, getSnapshotArtifactFileName(mcpServer.Name, mcpServer.Version,
+				revision), resp.Body()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *environmentSnapshotExporter) exportApplications() error {
+	var apps []utils.Application
+	appListEndpoint := utils.GetAdminApplicationListEndpointOfEnv(e.environment, utils.MainConfigFilePath)
+	for offset := 0; ; offset += utils.MaxAppsToExportOnce {
+		_, appList, err := GetApplicationList(e.accessToken, appListEndpoint+"?limit="+
+			strconv.Itoa(utils.MaxAppsToExportOnce)+"&offset="+strconv.Itoa(offset), "", "")
+		if err != nil {
+			return err
+		}
+		apps = append(apps, appList...)
+		if len(appList) < utils.MaxAppsToExportOnce {
+			break
+		}
+	}
+	for _, app := range apps {
+		if app.Name == utils.DefaultCliApp {
+			continue
+		}
+		artifact := &SnapshotArtifact{Type: SnapshotArtifactApplication, Name: app.Name, Owner: app.Owner}
+		if e.exported[artifact.Key()] {
+			continue
+		}
+		resp, err := ExportAppFromEnv(e.accessToken, app.Name, app.Owner, e.options.Format,
+			e.environment, e.options.WithKeys)
+		if err := checkSnapshotExportResponse(artifact, resp, err); err != nil {
+			return err
+		}
+		if err := e.addArtifact(artifact, app.Owner+"_"+app.Name+".zip", resp.Body()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportLoggingSettings exports the correlation logging components and the log levels of the APIs. The APIs are
+// identified by the name, version and provider since the ids differ between environments.
+func (e *environmentSnapshotExporter) exportLoggingSettings() error {
+	correlationArtifact := &SnapshotArtifact{Type: SnapshotArtifactCorrelationLogging, Name: "correlation-logging"}
+	if !e.exported[correlationArtifact.Key()] {
+		components, err := GetCorrelationLogComponentListFromEnv(e.credential, e.environment)
+		if err != nil {
+			return err
+		}
+		content, err := yaml.Marshal(components)
+		if err != nil {
+			return err
+		}
+		if err := e.addArtifact(correlationArtifact, correlationArtifact.Name+".yaml", content); err != nil {
+			return err
+		}
+	}
+
+	apiLoggingArtifact := &SnapshotArtifact{Type: SnapshotArtifactAPILogging, Name: "api-logging"}
+	if e.exported[apiLoggingArtifact.Key()] {
+		return nil
+	}
+	if e.apis == nil {
+		apis, err := getAllAPIsFromEnv(e.accessToken, e.environment)
+		if err != nil {
+			return err
+		}
+		e.apis = apis
+	}
+	loggers, err := GetPerAPILoggingListFromEnv(e.credential, e.environment, "")
+	if err != nil {
+		return err
+	}
+	apiLogging := []snapshotAPILogging{}
+	for _, logger := range loggers {
+		if logger.LogLevel == "" || logger.LogLevel == "OFF" {
+			continue
+		}
+		for _, api := range e.apis {
+			if api.ID == logger.ID {
+				apiLogging = append(apiLogging, snapshotAPILogging{Name: api.Name, Version: api.Version,
+					Provider: api.Provider, LogLevel: logger.LogLevel})
+				break
+			}
+		}
+	}
+	content, err := yaml.Marshal(apiLogging)
+	if err != nil {
+		return err
+	}
+	return e.addArtifact(apiLoggingArtifact, apiLoggingArtifact.Name+".yaml", content)
+}
+
+// getSnapshotRevisionNumbers returns the numbers of the revisions in ascending order followed by an empty string for
+// the working copy, which is the order they are imported to recreate the revision history
+func getSnapshotRevisionNumbers(revisions []utils.Revisions) []string {
+	var revisionNumbers []string
+	for _, revision := range revisions {
+		revisionNumbers = append(revisionNumbers, utils.GetRevisionNumFromRevisionName(revision.RevisionNumber))
+	}
+	sort.SliceStable(revisionNumbers, func(i, j int) bool {
+		return compareRevisionNumbers(revisionNumbers[i], revisionNumbers[j]) < 0
+	})
+	return append(revisionNumbers, "")
+}
@@ -120,7 +120,7 @@ func WriteMCPServerToZip(exportMCPServerName, exportMCPServerVersion, exportMCPS
 
 	// Output the final zip file location.
 	if runningExportMCPServerCommand {
-		fmt.Println("Successfully exported MCP Server!")
-		fmt.Println("Find the exported MCP Server at " + exportedFinalZip)
+		fmt.Fprintln(utils.Stdout, "Successfully exported MCP Server!")
+		fmt.Fprintln(utils.Stdout, "Find the exported MCP Server at "+exportedFinalZip)
 	}
 }
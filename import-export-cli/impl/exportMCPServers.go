@@ -19,10 +19,12 @@
 package impl
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/spf13/cast"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -62,7 +64,7 @@ func PrepareMCPServerResumption(credential credentials.Credential, exportRelated
 			utils.WriteMigrationMCPServersExportMetadataFile(mcpServers, cmdResourceTenantDomain, cmdUsername,
 				exportRelatedFilesPath, mcpServerListOffset)
 		} else {
-			fmt.Println("Command: export mcp-servers execution completed !")
+			fmt.Fprintln(utils.Stdout, "Command: export mcp-servers execution completed !")
 		}
 	}
 }
@@ -70,7 +72,7 @@ func PrepareMCPServerResumption(credential credentials.Credential, exportRelated
 // Delete directories where the MCP Servers are exported, reset the indexes, get first MCP Server list and write the
 // migration-mcp-servers-export-metadata.yaml file
 func PrepareMCPServerStartFromBeginning(credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	fmt.Println("Cleaning all the previously exported MCP Servers of the given target tenant, in the given environment if " +
+	fmt.Fprintln(utils.Stdout, "Cleaning all the previously exported MCP Servers of the given target tenant, in the given environment if "+
 		"any, and prepare to export MCP Servers from beginning")
 	//cleaning existing old files (if exists) related to exportation
 	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportRelatedFilesPath, utils.ExportedMCPServersDirName)); err != nil {
@@ -89,7 +91,7 @@ func PrepareMCPServerStartFromBeginning(credential credentials.Credential, expor
 	mcpServerListOffset = 0
 	startingMCPServerIndexFromList = 0
 	mcpServerCount, mcpServers = getMCPServerList(credential, cmdExportEnvironment, cmdResourceTenantDomain)
-	fmt.Printf("Found %d MCP Servers to be exported in the environment: %s, tenant: %s\n", mcpServerCount, cmdExportEnvironment, cmdResourceTenantDomain)
+	fmt.Fprintf(utils.Stdout, "Found %d MCP Servers to be exported in the environment: %s, tenant: %s\n", mcpServerCount, cmdExportEnvironment, cmdResourceTenantDomain)
 	//write migration-mcp-servers-export-metadata.yaml file
 	utils.WriteMigrationMCPServersExportMetadataFile(mcpServers, cmdResourceTenantDomain, cmdUsername, exportRelatedFilesPath,
 		mcpServerListOffset)
@@ -142,46 +144,34 @@ func getRevisionsListForMCPServer(accessToken, environment string, mcpServer uti
 // Do the MCP Server exportation
 func ExportMCPServers(credential credentials.Credential, exportRelatedFilesPath, environment, tenantDomain, format,
 	username, mcpServerExportDir string, preserveStatus, runningExportMCPServerCommand, allRevisions,
-	preserveCredentials bool) {
+	preserveCredentials bool, workers int) {
 
 	if mcpServerCount == 0 {
-		fmt.Println("No MCP Servers available to be exported..!")
+		fmt.Fprintln(utils.Stdout, "No MCP Servers available to be exported..!")
 	} else {
-		var counterSucceededMCPServers = 0
+		var counterSucceededMCPServers int32 = 0
+		var results []*BulkArtifactResult
 		for mcpServerCount > 0 {
 			utils.Logln(utils.LogPrefixInfo+"Found ", mcpServerCount, "of MCP Servers to be exported in the iteration beginning with the offset #"+
 				strconv.Itoa(mcpServerListOffset)+". Maximum limit of MCP Servers exported in single iteration is "+
 				strconv.Itoa(utils.MaxMCPServersToExportOnce))
 			accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, environment)
 			if preCommandErr == nil {
-				for i := startingMCPServerIndexFromList; i < len(mcpServers); i++ {
-					if allRevisions {
-						// Export the working copy of the MCP server
-						exportMCPServerAndWriteToZip(mcpServers[i], "", accessToken, environment, mcpServerExportDir,
-							exportRelatedFilesPath, format, preserveStatus, runningExportMCPServerCommand,
-							preserveCredentials)
-						counterSucceededMCPServers++
-					}
-					revisionCount, revisions, err := getRevisionsListForMCPServer(accessToken, environment, mcpServers[i],
-						allRevisions)
-					if err != nil {
-						fmt.Printf("An error occurred while getting the revisions list for MCP Server %s_%s: %v\n",
-							mcpServers[i].Name, mcpServers[i].Version, err)
-					} else if revisionCount > 0 {
-						for j := 0; j < len(revisions); j++ {
-							exportMCPServerRevision := utils.GetRevisionNumFromRevisionName(revisions[j].RevisionNumber)
-							exportMCPServerAndWriteToZip(mcpServers[i], exportMCPServerRevision, accessToken, environment,
-								mcpServerExportDir, exportRelatedFilesPath, format, preserveStatus, runningExportMCPServerCommand,
-								preserveCredentials)
-							counterSucceededMCPServers++
-						}
-					}
+				batchResults := exportMCPServersBatch(mcpServers[startingMCPServerIndexFromList:], workers,
+					accessToken, environment, mcpServerExportDir, exportRelatedFilesPath, format, preserveStatus,
+					runningExportMCPServerCommand, allRevisions, preserveCredentials, &counterSucceededMCPServers)
+				results = append(results, batchResults...)
+				if failed := countFailedBulkResults(batchResults); failed > 0 {
+					PrintBulkReport(results)
+					// Moving to the next batch would overwrite the list of MCP Servers to resume from
+					utils.HandleErrorAndExit("Error exporting MCP Servers", errors.New(strconv.Itoa(failed)+
+						" MCP Servers failed to export. Run the command again to resume from the first failed MCP Server"))
 				}
 			} else {
 				// error getting OAuth tokens
-				fmt.Println("Error getting OAuth Tokens : " + preCommandErr.Error())
+				fmt.Fprintln(utils.Stdout, "Error getting OAuth Tokens : "+preCommandErr.Error())
 			}
-			fmt.Println("Batch of " + cast.ToString(mcpServerCount) + " MCP Servers exported successfully..!")
+			fmt.Fprintln(utils.Stdout, "Batch of "+cast.ToString(mcpServerCount)+" MCP Servers exported successfully..!")
 
 			mcpServerListOffset += utils.MaxMCPServersToExportOnce
 			mcpServerCount, mcpServers = getMCPServerList(credential, environment, tenantDomain)
@@ -191,16 +181,76 @@ func ExportMCPServers(credential credentials.Credential, exportRelatedFilesPath,
 					exportRelatedFilesPath, mcpServerListOffset)
 			}
 		}
-		fmt.Println("\nTotal number of MCP Servers exported: " + cast.ToString(counterSucceededMCPServers))
-		fmt.Println("MCP Server export path: " + mcpServerExportDir)
-		fmt.Println("\nCommand: export-mcp-servers execution completed !")
+		PrintBulkReport(results)
+		fmt.Fprintln(utils.Stdout, "\nTotal number of MCP Servers exported: "+cast.ToString(counterSucceededMCPServers))
+		fmt.Fprintln(utils.Stdout, "MCP Server export path: "+mcpServerExportDir)
+		fmt.Fprintln(utils.Stdout, "\nCommand: export-mcp-servers execution completed !")
+	}
+}
+
+// Export a batch of MCP Servers concurrently using the given number of workers. The last succeeded MCP Server is
+// recorded only when all the MCP Servers before it in the batch are exported, so that a resumed export does not
+// skip any MCP Server.
+func exportMCPServersBatch(batch []utils.MCPServer, workers int, accessToken, environment, mcpServerExportDir,
+	exportRelatedFilesPath, format string, preserveStatus, runningExportMCPServerCommand, allRevisions,
+	preserveCredentials bool, counterSucceededMCPServers *int32) []*BulkArtifactResult {
+	tasks := make([]bulkTask, len(batch))
+	for i := range batch {
+		mcpServer := batch[i]
+		// A retried task resumes from the export that failed, so that the exported revisions are not counted again
+		workingCopyExported := false
+		var revisions []utils.Revisions
+		var revisionCount int32
+		nextRevision := 0
+		tasks[i] = bulkTask{
+			artifact:   mcpServer.Name + " " + mcpServer.Version + " (" + mcpServer.Provider + ")",
+			idempotent: true,
+			run: func() error {
+				if allRevisions && !workingCopyExported {
+					// Export the working copy of the MCP server
+					if err := exportMCPServerAndWriteToZip(mcpServer, "", accessToken, environment, mcpServerExportDir,
+						format, preserveStatus, runningExportMCPServerCommand, preserveCredentials); err != nil {
+						return err
+					}
+					atomic.AddInt32(counterSucceededMCPServers, 1)
+					workingCopyExported = true
+				}
+				if revisions == nil {
+					count, list, err := getRevisionsListForMCPServer(accessToken, environment, mcpServer,
+						allRevisions)
+					if err != nil {
+						return fmt.Errorf("Error getting the revisions list: %w", err)
+					}
+					revisionCount, revisions = count, list
+				}
+				for ; nextRevision < int(revisionCount) && nextRevision < len(revisions); nextRevision++ {
+					exportMCPServerRevision := utils.GetRevisionNumFromRevisionName(revisions[nextRevision].RevisionNumber)
+					if err := exportMCPServerAndWriteToZip(mcpServer, exportMCPServerRevision, accessToken,
+						environment, mcpServerExportDir, format, preserveStatus, runningExportMCPServerCommand,
+						preserveCredentials); err != nil {
+						return err
+					}
+					atomic.AddInt32(counterSucceededMCPServers, 1)
+				}
+				return nil
+			},
+		}
 	}
+	marker := newBulkResumptionMarker(len(batch))
+	return runBulkTasks(tasks, workers, func(index int, result *BulkArtifactResult) {
+		if result.Err != nil {
+			return
+		}
+		if last := marker.markSucceeded(index); last >= 0 {
+			//write on last-succeeded-mcp-server.log
+			utils.WriteLastSucceededMCPServerFileData(exportRelatedFilesPath, batch[last])
+		}
+	})
 }
 
 // Export the MCP Server and archive to zip format
-func exportMCPServerAndWriteToZip(mcpServer utils.MCPServer, revisionNumber, accessToken, environment, mcpServerExportDir,
-	exportRelatedFilesPath, format string, preserveStatus, runningExportMCPServerCommand,
-	preserveCredentials bool) {
+func exportMCPServerAndWriteToZip(mcpServer utils.MCPServer, revisionNumber, accessToken, environment,
+	mcpServerExportDir, format string, preserveStatus, runningExportMCPServerCommand, preserveCredentials bool) error {
 
 	exportMCPServerName := mcpServer.Name
 	exportMCPServerVersion := mcpServer.Version
@@ -210,21 +260,19 @@ func exportMCPServerAndWriteToZip(mcpServer utils.MCPServer, revisionNumber, acc
 		exportMCPServerRevision = utils.GetRevisionNumFromRevisionName(revisionNumber)
 	}
 
-	resp, err := ExportMCPServerFromEnv(accessToken, exportMCPServerName, exportMCPServerVersion, exportMCPServerRevision,
-		exportMCPServerProvider, format, environment, preserveStatus, false, preserveCredentials)
+	resp, err := ExportMCPServerFromEnv(accessToken, exportMCPServerName, exportMCPServerVersion,
+		exportMCPServerRevision, exportMCPServerProvider, format, environment, preserveStatus, false,
+		preserveCredentials)
 	if err != nil {
-		utils.HandleErrorAndExit("Error exporting MCP Server", err)
+		return err
 	}
-
-	if resp.StatusCode() == http.StatusOK {
-		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		WriteMCPServerToZip(exportMCPServerName, exportMCPServerVersion, exportMCPServerRevision, mcpServerExportDir, runningExportMCPServerCommand, resp)
-		//write on last-succeeded-mcp-server.log
-		utils.WriteLastSucceededMCPServerFileData(exportRelatedFilesPath, mcpServer)
-	} else {
-		fmt.Printf("Error exporting MCP Server: %s - %s of Provider: %s\n", exportMCPServerName, exportMCPServerVersion, exportMCPServerProvider)
-		utils.PrintErrorResponseAndExit(resp)
+	if resp.StatusCode() != http.StatusOK {
+		return utils.NewHttpResponseError(resp)
 	}
+	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+	WriteMCPServerToZip(exportMCPServerName, exportMCPServerVersion, exportMCPServerRevision, mcpServerExportDir,
+		runningExportMCPServerCommand, resp)
+	return nil
 }
 
 // Create the required directory structure to save the exported MCP Servers
@@ -0,0 +1,413 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+// postmanDefaultGatewayURL is the URL of the default vhost of a gateway, used when the deployments are not known
+const postmanDefaultGatewayURL = "https://localhost:8243"
+
+// postmanDefaultHttpsPort is the HTTPS port of the gateway, used when only the vhost of a deployment is known
+const postmanDefaultHttpsPort = 8243
+
+// PostmanGateway is the URL an API is invoked with through a gateway environment
+type PostmanGateway struct {
+	Name string
+	URL  string
+}
+
+// ExportPostmanCollectionFromProject generates a Postman collection for an API project. The API is invoked through
+// the vhosts in deployment_environments.yaml with the default HTTPS port of the gateway. The token endpoint of the
+// environment is used if an environment is given.
+// @param projectPath : Path to the API project directory or archive
+// @param environment : Environment whose token endpoint is used, if any
+// @return collection, error
+func ExportPostmanCollectionFromProject(projectPath, environment string) (*PostmanCollection, error) {
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(tmpPath))
+
+	content, err := ioutil.ReadFile(filepath.Join(tmpPath, utils.APIDefinitionFileYaml))
+	if err != nil {
+		return nil, fmt.Errorf("error reading the API definition of %s: %w", projectPath, err)
+	}
+	definition := &v2.APIDefinitionFile{}
+	if err := yaml2.Unmarshal(content, definition); err != nil {
+		return nil, fmt.Errorf("invalid API definition in %s: %w", projectPath, err)
+	}
+	swaggerPath := filepath.Join(tmpPath, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
+	content, err = ioutil.ReadFile(swaggerPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s of %s: %w", utils.InitProjectDefinitionsSwagger, projectPath, err)
+	}
+	doc, err := loadOpenAPIDefinition(content, swaggerPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API definition %s: %w", utils.InitProjectDefinitionsSwagger, err)
+	}
+
+	basePath := getMockBasePaths(definition.Data.Context, definition.Data.Version)[0]
+	var gateways []PostmanGateway
+	deploymentEnvsPath := filepath.Join(tmpPath, utils.DeploymentEnvFile)
+	if utils.IsFileExist(deploymentEnvsPath) {
+		content, err := ioutil.ReadFile(deploymentEnvsPath)
+		if err != nil {
+			return nil, err
+		}
+		deploymentEnvs := &v2.DeploymentEnvironmentsFile{}
+		if err := yaml2.Unmarshal(content, deploymentEnvs); err != nil {
+			return nil, fmt.Errorf("invalid %s in %s: %w", utils.DeploymentEnvFile, projectPath, err)
+		}
+		for _, deployment := range deploymentEnvs.Data {
+			if deployment.DeploymentVhost != "" {
+				gateways = append(gateways, PostmanGateway{Name: deployment.DeploymentEnvironment,
+					URL: getPostmanGatewayURL(deployment.DeploymentVhost, postmanDefaultHttpsPort, "", basePath)})
+			}
+		}
+	}
+	if len(gateways) == 0 {
+		gateways = append(gateways, PostmanGateway{URL: postmanDefaultGatewayURL + basePath})
+	}
+	tokenEndpoint := ""
+	if environment != "" {
+		tokenEndpoint = utils.GetTokenEndpointOfEnv(environment, utils.MainConfigFilePath)
+	}
+	return GeneratePostmanCollection(doc, definition.Data.Name, definition.Data.Version, gateways, tokenEndpoint), nil
+}
+
+// postmanPublisherAPI is the part of an API of the publisher used to generate a collection
+type postmanPublisherAPI struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Context string `json:"context"`
+}
+
+// ExportPostmanCollectionFromEnv generates a Postman collection for an API in an environment. The API is invoked
+// through the vhosts its revisions are deployed to.
+// @param accessToken : Access token to call the publisher REST API
+// @param environment : Environment of the API
+// @param name : Name of the API
+// @param version : Version of the API
+// @param provider : Provider of the API
+// @return collection, error
+func ExportPostmanCollectionFromEnv(accessToken, environment, name, version,
+	provider string) (*PostmanCollection, error) {
+	artifact, err := getRevisionedArtifact(accessToken, environment, RevisionArtifactAPI, name, version, provider)
+	if err != nil {
+		return nil, err
+	}
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	resp, err := utils.InvokeGETRequest(artifact.endpoint+artifact.id, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, utils.NewHttpResponseError(resp)
+	}
+	api := &postmanPublisherAPI{}
+	if err := json.Unmarshal(resp.Body(), api); err != nil {
+		return nil, err
+	}
+	resp, err = utils.InvokeGETRequest(artifact.endpoint+artifact.id+"/swagger", headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, utils.NewHttpResponseError(resp)
+	}
+	doc, err := loadOpenAPIDefinition(resp.Body(), artifact.endpoint+artifact.id+"/swagger")
+	if err != nil {
+		return nil, fmt.Errorf("invalid API definition of %s %s: %w", name, version, err)
+	}
+
+	revisions, err := getSortedRevisions(accessToken, artifact)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := getPublisherSettings(accessToken, environment)
+	if err != nil {
+		return nil, err
+	}
+	gateways := getPostmanDeployedGateways(revisions, settings, getMockBasePaths(api.Context, api.Version)[0])
+	if len(gateways) == 0 {
+		fmt.Fprintln(utils.Stdout, "The API is not deployed in any gateway environment. Update the baseUrl variable of the "+
+			"collection once the API is deployed")
+		gateways = append(gateways, PostmanGateway{URL: postmanDefaultGatewayURL +
+			getMockBasePaths(api.Context, api.Version)[0]})
+	}
+	tokenEndpoint := utils.GetTokenEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return GeneratePostmanCollection(doc, api.Name, api.Version, gateways, tokenEndpoint), nil
+}
+
+// getPostmanDeployedGateways returns the URLs of the vhosts the revisions of an API are deployed to
+func getPostmanDeployedGateways(revisions []utils.Revisions, settings *publisherSettings,
+	basePath string) []PostmanGateway {
+	var gateways []PostmanGateway
+	for _, revision := range revisions {
+		for _, deployment := range revision.Deployments {
+			port, httpContext := postmanDefaultHttpsPort, ""
+			for _, gatewayEnv := range settings.Environment {
+				if gatewayEnv.Name != deployment.Name {
+					continue
+				}
+				for _, vhost := range gatewayEnv.Vhosts {
+					if vhost.Host == deployment.Vhost {
+						port, httpContext = vhost.HttpsPort, vhost.HttpContext
+					}
+				}
+			}
+			gateways = append(gateways, PostmanGateway{Name: deployment.Name,
+				URL: getPostmanGatewayURL(deployment.Vhost, port, httpContext, basePath)})
+		}
+	}
+	sort.SliceStable(gateways, func(i, j int) bool {
+		return gateways[i].Name < gateways[j].Name
+	})
+	return gateways
+}
+
+// getPostmanGatewayURL returns the HTTPS URL of an API deployed to a vhost
+func getPostmanGatewayURL(host string, port int, httpContext, basePath string) string {
+	gatewayURL := "https://" + host
+	if port != 0 && port != 443 {
+		gatewayURL += ":" + strconv.Itoa(port)
+	}
+	if httpContext = strings.Trim(httpContext, "/"); httpContext != "" {
+		gatewayURL += "/" + httpContext
+	}
+	return gatewayURL + basePath
+}
+
+// GeneratePostmanCollection generates a Postman collection having a request for each operation of an API. Requests
+// are grouped in folders by the first tag of the operations. The collection is authenticated with an OAuth2 token
+// of the client credentials grant. The gateway URLs, the token endpoint and the client credentials are the
+// variables of the collection.
+func GeneratePostmanCollection(doc *openapi3.T, name, version string, gateways []PostmanGateway,
+	tokenEndpoint string) *PostmanCollection {
+	collection := &PostmanCollection{
+		Info: PostmanInfo{Name: name + " " + version, Schema: PostmanCollectionSchema},
+		Auth: &PostmanAuth{Type: "oauth2", OAuth2: []PostmanKeyValue{
+			{Key: "grant_type", Value: "client_credentials", Type: "string"},
+			{Key: "accessTokenUrl", Value: "{{tokenUrl}}", Type: "string"},
+			{Key: "clientId", Value: "{{clientId}}", Type: "string"},
+			{Key: "clientSecret", Value: "{{clientSecret}}", Type: "string"},
+			{Key: "client_authentication", Value: "header", Type: "string"},
+			{Key: "tokenName", Value: PostmanText(name), Type: "string"},
+			{Key: "addTokenTo", Value: "header", Type: "string"},
+		}},
+	}
+	if doc.Info != nil && doc.Info.Description != "" {
+		collection.Info.Description = PostmanText(doc.Info.Description)
+	}
+	for i, gateway := range gateways {
+		if i == 0 {
+			collection.Variable = append(collection.Variable, PostmanKeyValue{Key: "baseUrl",
+				Value: PostmanText(gateway.URL), Type: "string"})
+		}
+		if len(gateways) > 1 && gateway.Name != "" {
+			collection.Variable = append(collection.Variable, PostmanKeyValue{Key: "baseUrl_" + gateway.Name,
+				Value: PostmanText(gateway.URL), Type: "string"})
+		}
+	}
+	collection.Variable = append(collection.Variable,
+		PostmanKeyValue{Key: "tokenUrl", Value: PostmanText(tokenEndpoint), Type: "string"},
+		PostmanKeyValue{Key: "clientId", Value: "", Type: "string"},
+		PostmanKeyValue{Key: "clientSecret", Value: "", Type: "string"})
+
+	folders := make(map[string]*PostmanItem)
+	for _, operation := range getAPITestOperations(doc) {
+		item := buildPostmanItem(operation)
+		if len(operation.operation.Tags) == 0 {
+			collection.Item = append(collection.Item, item)
+			continue
+		}
+		tag := operation.operation.Tags[0]
+		folder, ok := folders[tag]
+		if !ok {
+			folder = &PostmanItem{Name: tag}
+			if doc.Tags != nil {
+				if t := doc.Tags.Get(tag); t != nil {
+					folder.Description = PostmanText(t.Description)
+				}
+			}
+			folders[tag] = folder
+			collection.Item = append(collection.Item, folder)
+		}
+		folder.Item = append(folder.Item, item)
+	}
+	return collection
+}
+
+// buildPostmanItem builds the request of an operation with the examples of the parameters and the request body
+func buildPostmanItem(operation apiTestOperation) *PostmanItem {
+	item := &PostmanItem{Name: operation.operation.Summary, Description: PostmanText(operation.operation.Description)}
+	if item.Name == "" {
+		item.Name = operation.operation.OperationID
+	}
+	if item.Name == "" {
+		item.Name = operation.name()
+	}
+	request := &PostmanRequest{Method: operation.method, Header: []PostmanKeyValue{}}
+
+	path := strings.TrimSuffix(operation.path, "/*")
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment != "" {
+			segments = append(segments, convertPathParamRegex.ReplaceAllString(segment, ":$1"))
+		}
+	}
+	request.URL = PostmanURL{Host: []string{"{{baseUrl}}"}, Path: segments}
+
+	parameters := append(openapi3.Parameters{}, operation.pathItem.Parameters...)
+	parameters = append(parameters, operation.operation.Parameters...)
+	var query []string
+	for _, parameterRef := range parameters {
+		parameter := parameterRef.Value
+		if parameter == nil {
+			continue
+		}
+		value := PostmanKeyValue{Key: parameter.Name, Value: PostmanText(formatAPITestValue(
+			getAPITestParameterValue(parameter))), Description: PostmanText(parameter.Description)}
+		switch parameter.In {
+		case openapi3.ParameterInPath:
+			request.URL.Variable = append(request.URL.Variable, value)
+		case openapi3.ParameterInQuery:
+			value.Disabled = !parameter.Required
+			request.URL.Query = append(request.URL.Query, value)
+			if parameter.Required {
+				query = append(query, parameter.Name+"="+string(value.Value))
+			}
+		case openapi3.ParameterInHeader:
+			if strings.EqualFold(parameter.Name, utils.HeaderAuthorization) {
+				continue
+			}
+			value.Disabled = !parameter.Required
+			request.Header = append(request.Header, value)
+		}
+	}
+	request.URL.Raw = "{{baseUrl}}"
+	if len(segments) > 0 {
+		request.URL.Raw += "/" + strings.Join(segments, "/")
+	}
+	if len(query) > 0 {
+		request.URL.Raw += "?" + strings.Join(query, "&")
+	}
+
+	if requestBody := operation.operation.RequestBody; requestBody != nil && requestBody.Value != nil &&
+		len(requestBody.Value.Content) > 0 {
+		contentType := getMockMediaType(requestBody.Value.Content)
+		value := getMockMediaValue(requestBody.Value.Content[contentType])
+		request.Header = append(request.Header, PostmanKeyValue{Key: utils.HeaderContentType,
+			Value: PostmanText(contentType)})
+		request.Body = buildPostmanBody(contentType, value)
+	}
+
+	if isPostmanUnsecuredOperation(operation.operation) {
+		request.Auth = &PostmanAuth{Type: "noauth"}
+	}
+	item.Request = request
+	return item
+}
+
+// buildPostmanBody builds the body of a request from the example of the request body
+func buildPostmanBody(contentType string, value interface{}) *PostmanBody {
+	object, isObject := value.(map[string]interface{})
+	switch {
+	case contentType == utils.HeaderValueXWWWFormUrlEncoded && isObject:
+		body := &PostmanBody{Mode: "urlencoded"}
+		for _, name := range getSortedKeys(object) {
+			body.URLEncoded = append(body.URLEncoded, PostmanKeyValue{Key: name,
+				Value: PostmanText(formatAPITestValue(object[name]))})
+		}
+		return body
+	case strings.HasPrefix(contentType, "multipart/") && isObject:
+		body := &PostmanBody{Mode: "formdata"}
+		for _, name := range getSortedKeys(object) {
+			body.FormData = append(body.FormData, PostmanKeyValue{Key: name,
+				Value: PostmanText(formatAPITestValue(object[name])), Type: "text"})
+		}
+		return body
+	}
+	body := &PostmanBody{Mode: "raw"}
+	if text, ok := value.(string); ok && !strings.Contains(contentType, "json") {
+		body.Raw = text
+	} else {
+		content, _ := json.MarshalIndent(value, "", "  ")
+		body.Raw = string(content)
+		body.Options = &PostmanBodyOptions{}
+		body.Options.Raw.Language = "json"
+	}
+	if strings.Contains(contentType, "xml") {
+		body.Options = &PostmanBodyOptions{}
+		body.Options.Raw.Language = "xml"
+	}
+	return body
+}
+
+// isPostmanUnsecuredOperation returns whether an operation does not require a token, either having no security
+// requirements or having the auth type None of API Manager
+func isPostmanUnsecuredOperation(operation *openapi3.Operation) bool {
+	if operation.Security != nil && len(*operation.Security) == 0 {
+		return true
+	}
+	authType, _ := operation.Extensions["x-auth-type"].(string)
+	return strings.EqualFold(authType, "None")
+}
+
+func getSortedKeys(object map[string]interface{}) []string {
+	var keys []string
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WritePostmanCollection writes a collection to the destination directory as <name>_<version>.postman_collection.json
+// and returns the path of the file
+func WritePostmanCollection(collection *PostmanCollection, destination string) (string, error) {
+	if err := utils.CreateDirIfNotExist(destination); err != nil {
+		return "", err
+	}
+	content, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(destination, strings.ReplaceAll(collection.Info.Name, " ", "_")+".postman_collection.json")
+	utils.Logln(utils.LogPrefixInfo + "Writing " + path)
+	return path, ioutil.WriteFile(path, content, os.ModePerm)
+}
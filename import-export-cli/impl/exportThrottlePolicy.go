@@ -97,9 +97,9 @@ func WriteThrottlePolicyToFile(ExportLocationPath string, resp *resty.Response,
 	fileName, marshaledData := resolveThrottlePolicy(ExportFormat, resp)
 	_, _ = throttlingPolicyWrite(ExportLocationPath, fileName, marshaledData)
 	if runningExportThrottlePolicyCommand {
-		fmt.Println("Successfully exported Throttling Policy!")
-		fmt.Println("Find the exported Throttling Policies at " +
-			utils.AppendSlashToString(ExportLocationPath) + fileName)
+		fmt.Fprintln(utils.Stdout, "Successfully exported Throttling Policy!")
+		fmt.Fprintln(utils.Stdout, "Find the exported Throttling Policies at "+
+			utils.AppendSlashToString(ExportLocationPath)+fileName)
 	}
 }
 
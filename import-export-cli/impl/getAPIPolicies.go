@@ -152,7 +152,7 @@ func PrintAPIPolicies(resp *resty.Response, format string) {
 		}
 		// execute context
 		if err := policyContext.Write(renderer, apiPolicyTableHeaders); err != nil {
-			fmt.Println("Error executing template:", err.Error())
+			fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
 		}
 	} else if format == utils.JsonArrayFormatType {
 		utils.ListArtifactsInJsonArrayFormat(policies, utils.ProjectTypeAPIPolicy)
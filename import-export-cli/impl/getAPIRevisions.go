@@ -138,6 +138,6 @@ func PrintRevisions(revisions []utils.Revisions, format string) {
 
 	// execute context
 	if err := revisionContext.Write(renderer, revisionTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
 	}
 }
@@ -132,7 +132,7 @@ func PrintAPIProducts(apiProducts []utils.APIProduct, format string) {
 
 	// execute context
 	if err := apiProductContext.Write(renderer, apiProductTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
 	}
 }
 
@@ -140,6 +140,6 @@ func PrintAPIs(apis []utils.API, format string) {
 
 	// execute context
 	if err := apiContext.Write(renderer, apiTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
 	}
 }
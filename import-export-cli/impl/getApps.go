@@ -143,6 +143,6 @@ func PrintApps(apps []utils.Application, format string) {
 
 	// execute context
 	if err := appContext.Write(renderer, appTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
 	}
 }
@@ -143,6 +143,6 @@ func PrintEnvs(envData map[string]utils.EnvEndpoints, format, defaulEnvsTableFor
 
 	// execute context
 	if err := envsContext.Write(renderer, envsTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
 	}
 }
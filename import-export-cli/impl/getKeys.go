@@ -42,6 +42,18 @@ var keyGenTokenEndpoint string
 
 // Subscribe the given API, API Product or MCP Server to the default application and generate an access token
 func GetKeys(cred credentials.Credential, envName, name, version, provider, tokenEndpoint string) {
+	token, err := GenerateAccessToken(cred, envName, name, version, provider, tokenEndpoint)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while generating token:", err)
+	}
+	// Access Token generated successfully.
+	fmt.Fprintln(utils.Stdout, token)
+}
+
+// GenerateAccessToken subscribes the given API, API Product or MCP Server to the default application and returns
+// an access token of the application
+func GenerateAccessToken(cred credentials.Credential, envName, name, version, provider,
+	tokenEndpoint string) (string, error) {
 	keyGenEnv = envName
 	apiName = name
 	apiVersion = version
@@ -51,30 +63,31 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 	//generating access token for the env based on the credentials
 	accessToken, err := credentials.GetOAuthAccessToken(cred, keyGenEnv)
 	if err != nil {
-		utils.HandleErrorAndExit("Internal error occurred", err)
+		return "", err
 	}
 	utils.Logln(utils.LogPrefixInfo + "Generated a token to access the Publisher and DevPortal REST APIs.")
 	//retrieving subscription tiers
 	tiers, err := getAvailableAPITiers(accessToken)
-
-	if tiers != nil && err == nil {
-		utils.Logln(utils.LogPrefixInfo+"Retrieved available subscription tiers of the API, API Product or MCP Server: ", tiers)
-		// Needs an available subscription tier when subscribing to the particular API, API Product or MCP Server using the application
-		subscriptionThrottlingTier = tiers[0]
-	} else {
-		utils.HandleErrorAndExit("Internal error occurred", err)
+	if err != nil {
+		return "", err
 	}
+	if len(tiers) == 0 {
+		return "", errors.New("no subscription tiers are available for " + apiName + " " + apiVersion)
+	}
+	utils.Logln(utils.LogPrefixInfo+"Retrieved available subscription tiers of the API, API Product or MCP Server: ", tiers)
+	// Needs an available subscription tier when subscribing to the particular API, API Product or MCP Server using the application
+	subscriptionThrottlingTier = tiers[0]
 	// Retrieving application throttling policy
 	applicationThrottlingPolicy, err := getApplicationThrottlingPolicy(accessToken)
-	// If the application throttling policy call fails, exit with the error
+	// If the application throttling policy call fails, return the error
 	if err != nil {
-		utils.HandleErrorAndExit("Internal error occurred", err)
+		return "", err
 	}
 	utils.Logln(utils.LogPrefixInfo+"Retrieved application throttling policy successfully: ", applicationThrottlingPolicy)
 	//search if the default cli application already exists
-	appId, err := searchApplication(utils.DefaultCliApp, accessToken)
+	appId, err := searchApplication(keyGenEnv, utils.DefaultCliApp, accessToken)
 	if err != nil {
-		utils.HandleErrorAndExit("Internal error occurred", err)
+		return "", err
 	}
 	utils.Logln(utils.LogPrefixInfo + "Searched if application exists.")
 	//if the application exists
@@ -84,103 +97,86 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 		subId, err := subscribe(appId, accessToken)
 		// If subscription fails
 		if subId == "" && err != nil {
-			utils.HandleErrorAndExit("Error occurred while subscribing.", err)
+			return "", fmt.Errorf("error occurred while subscribing: %w", err)
 		}
 
-		scopes, err := getScopes(appId, accessToken)
+		scopes, _ := getScopes(appId, accessToken)
 		//retrieve application specific details
 		appDetails, err := getApplicationDetails(appId, accessToken)
-		if appDetails != nil {
-			//Reading configuration to check if the application needs to be updated
-			configVars := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
-			tokenType = configVars.Config.TokenType
-
-			//retrieve keys of application to see if there are already generated keys
-			appKeys, keysErr := getApplicationKeys(appId, accessToken)
-			if keysErr != nil {
-				utils.HandleErrorAndExit("Error occurred while getting CLI application keys.", keysErr)
-			}
+		if appDetails == nil {
+			return "", fmt.Errorf("error while retrieving the CLI application: %v", err)
+		}
+		//Reading configuration to check if the application needs to be updated
+		configVars := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+		tokenType = configVars.Config.TokenType
 
-			//if keys have been already generated before, then update the consumer key and secret
-			if appKeys.Count != 0 {
-				// Use stored consumer key/secret
-				appKey := &appKeys.List[0]
-				if store, storeErr := credentials.GetDefaultCredentialStore(); storeErr == nil {
-					if storedKey, storedSecret, loadErr := store.GetDefaultAppKeys(keyGenEnv); loadErr == nil {
-						appKey.ConsumerKey = storedKey
-						appKey.ConsumerSecret = storedSecret
-					}
-				}
-				//If the keys have not been generated and the application is updated
-				token, err := getNewToken(appKey, scopes)
-				//Assert token endpoint related fails and errors
-				if err != nil {
-					utils.HandleErrorAndExit("Error while generating token. ", err)
-				}
+		//retrieve keys of application to see if there are already generated keys
+		appKeys, err := getApplicationKeys(appId, accessToken)
+		if err != nil {
+			return "", fmt.Errorf("error occurred while getting CLI application keys: %w", err)
+		}
 
-				if accessToken != "" {
-					// Access Token generated successfully.
-					fmt.Println(token)
-				} else {
-					utils.HandleErrorAndExit("Error while generating token: ", err)
-				}
-			} else {
-				//If the application is already created but the keys have not generated in the first time
-				keygenResponse, err := generateApplicationKeys(appId, accessToken)
-				if keygenResponse == nil && err != nil {
-					utils.HandleErrorAndExit("Error occurred while generating CLI application keys.", err)
-				}
-				if store, storeErr := credentials.GetDefaultCredentialStore(); storeErr == nil {
-					store.SetDefaultAppKeys(keyGenEnv, keygenResponse.ConsumerKey, keygenResponse.ConsumerSecret)
+		//if keys have been already generated before, then update the consumer key and secret
+		if appKeys.Count != 0 {
+			// Use stored consumer key/secret
+			appKey := &appKeys.List[0]
+			if store, storeErr := credentials.GetDefaultCredentialStore(); storeErr == nil {
+				if storedKey, storedSecret, loadErr := store.GetDefaultAppKeys(keyGenEnv); loadErr == nil {
+					appKey.ConsumerKey = storedKey
+					appKey.ConsumerSecret = storedSecret
 				}
-				// Access Token generated successfully.
-				fmt.Println(keygenResponse.Token.AccessToken)
 			}
-		} else {
-			utils.HandleErrorAndExit("Error while retrieving the CLI application:", err)
-		}
-	} else {
-		//If the default cli appId does not exist in the environment
-		//Create the application
-		createdAppId, appName, err := createApplication(accessToken, applicationThrottlingPolicy)
-		appId = createdAppId
-		if createdAppId != "" || appName != "" {
-			utils.Logln(utils.LogPrefixInfo+"Created CLI application: ", appName)
-		} else {
-			//if error occurred while creating the application, then
-			utils.HandleErrorAndExit("Error while creating the CLI application:", err)
+			//If the keys have not been generated and the application is updated
+			return getNewToken(appKey, scopes)
 		}
-		//Search if the given API, API Product or MCP Server is present to subscribe
-		subId, err := subscribe(appId, accessToken)
-		//If subscription failed
-		if subId == "" && err != nil {
-			utils.HandleErrorAndExit("Error occurred while subscribing.", err)
-		}
-		scopes, err := getScopes(appId, accessToken)
-		//If errors occurred while retrieving scopes
-		if scopes == nil && err != nil {
-			utils.HandleErrorAndExit("Error while retrieving scopes ", err)
-		}
-		//Generate the tokens
-		keygenResponse, err := generateApplicationKeys(appId, accessToken)
-		if err != nil {
-			utils.HandleErrorAndExit("Error while generating CLI application keys", err)
+		//If the application is already created but the keys have not generated in the first time
+		keygenResponse, err := generateApplicationKeys(keyGenEnv, appId, accessToken, newDefaultKeygenRequest())
+		if keygenResponse == nil {
+			return "", fmt.Errorf("error occurred while generating CLI application keys: %v", err)
 		}
-		// Save consumer key/secret so it can be reloaded as APIM masks it in GET responses
 		if store, storeErr := credentials.GetDefaultCredentialStore(); storeErr == nil {
 			store.SetDefaultAppKeys(keyGenEnv, keygenResponse.ConsumerKey, keygenResponse.ConsumerSecret)
 		}
-		appKey := &utils.ApplicationKey{}
-		appKey.ConsumerKey = keygenResponse.ConsumerKey
-		appKey.ConsumerSecret = keygenResponse.ConsumerSecret
-		token, err := getNewToken(appKey, scopes)
-		if token != "" {
-			// Access Token generated successfully.
-			fmt.Println(token)
-		} else {
-			utils.HandleErrorAndExit("Error while generating token: ", err)
-		}
+		return keygenResponse.Token.AccessToken, nil
+	}
+
+	//If the default cli appId does not exist in the environment
+	//Create the application
+	createdAppId, appName, err := createApplication(accessToken, applicationThrottlingPolicy)
+	appId = createdAppId
+	if createdAppId == "" && appName == "" {
+		//if error occurred while creating the application, then
+		return "", fmt.Errorf("error while creating the CLI application: %v", err)
+	}
+	utils.Logln(utils.LogPrefixInfo+"Created CLI application: ", appName)
+	//Search if the given API, API Product or MCP Server is present to subscribe
+	subId, err := subscribe(appId, accessToken)
+	//If subscription failed
+	if subId == "" && err != nil {
+		return "", fmt.Errorf("error occurred while subscribing: %w", err)
+	}
+	scopes, err := getScopes(appId, accessToken)
+	//If errors occurred while retrieving scopes
+	if scopes == nil && err != nil {
+		return "", fmt.Errorf("error while retrieving scopes: %w", err)
+	}
+	//Generate the tokens
+	keygenResponse, err := generateApplicationKeys(keyGenEnv, appId, accessToken, newDefaultKeygenRequest())
+	if err != nil {
+		return "", fmt.Errorf("error while generating CLI application keys: %w", err)
 	}
+	// Save consumer key/secret so it can be reloaded as APIM masks it in GET responses
+	if store, storeErr := credentials.GetDefaultCredentialStore(); storeErr == nil {
+		store.SetDefaultAppKeys(keyGenEnv, keygenResponse.ConsumerKey, keygenResponse.ConsumerSecret)
+	}
+	appKey := &utils.ApplicationKey{}
+	appKey.ConsumerKey = keygenResponse.ConsumerKey
+	appKey.ConsumerSecret = keygenResponse.ConsumerSecret
+	token, err := getNewToken(appKey, scopes)
+	if token == "" {
+		return "", fmt.Errorf("error while generating token: %v", err)
+	}
+	return token, nil
 }
 
 // Retrieve an available throttling tiers of the API, API Product or MCP Server
@@ -279,27 +275,33 @@ func CallDCREndpoint(credential credentials.Credential, keyGenEnv string) (strin
 }
 
 // Search if the application exists with the name
+// @param environment : Environment of the application
 // @param appName : Name of the application
 // @param accessToken : Access token to authenticate the devportal REST API
 // @return appId, error
-func searchApplication(appName string, accessToken string) (string, error) {
+func searchApplication(environment, appName string, accessToken string) (string, error) {
 	//Application REST API endpoint of the environment from the config file
-	applicationEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(keyGenEnv, utils.MainConfigFilePath)
+	applicationEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
 	//Prepping headers
 	headers := make(map[string]string)
 	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
 	resp, err := utils.InvokeGETRequestWithQueryParam("query", appName, applicationEndpoint, headers)
+	if err != nil {
+		return "", err
+	}
 
 	if resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusCreated {
 		// 200 OK or 201 Created
 		appData := &utils.AppList{}
 		data := []byte(resp.Body())
 		err = json.Unmarshal(data, &appData)
-		if appData.Count != 0 {
-			appId := appData.List[0].ApplicationID
-			return appId, err
+		// The query matches the applications having the name as a part of their names
+		for _, app := range appData.List {
+			if app.Name == appName {
+				return app.ApplicationID, err
+			}
 		}
-		return "", nil
+		return "", err
 
 	} else {
 		utils.Logf("Error: %s\n", resp.Error())
@@ -428,7 +430,7 @@ func subscribe(appId string, accessToken string) (string, error) {
 	if apiId != "" && err == nil {
 		//If the API, API Product or MCP Server is present, perform application subscription
 		utils.Logln(utils.LogPrefixInfo+"API, API Product or MCP Server name: ", apiName, "& version: ", apiVersion, "exists")
-		subId, err := subscribeApiOrProduct(apiId, appId, accessToken)
+		subId, err := subscribeApiOrProduct(keyGenEnv, apiId, appId, subscriptionThrottlingTier, accessToken)
 		if subId != "" {
 			utils.Logln(utils.LogPrefixInfo+"API, API Product or MCP Server", apiName, ":", apiVersion, "subscribed successfully.")
 		} else {
@@ -494,14 +496,14 @@ func getApiOrProduct(apiId string, accessToken string) (*utils.APIData, error) {
 }
 
 // Subscribe the API, API Product or MCP Server to a given Application
+// @param environment : Environment of the API, API Product or MCP Server and the application
 // @param apiId : API, API Product or MCP Server ID to be subscribed
 // @param appId : Application ID to be subscribed
+// @param throttlingPolicy : Subscription throttling policy of the subscription
 // @param accessToken : Access token to call the REST API
 // @return subscriptionId, error
-func subscribeApiOrProduct(apiId string, appId string, accessToken string) (string, error) {
-	//todo: subscription endpoint to be included in conf
-	subEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(keyGenEnv, utils.MainConfigFilePath)
-	subEndpoint = strings.Replace(subEndpoint, "applications", "subscriptions", -1)
+func subscribeApiOrProduct(environment, apiId, appId, throttlingPolicy, accessToken string) (string, error) {
+	subEndpoint := utils.GetDevPortalSubscriptionsEndpointOfEnv(environment, utils.MainConfigFilePath)
 	//prepping the headers
 	headers := make(map[string]string)
 	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
@@ -511,6 +513,9 @@ func subscribeApiOrProduct(apiId string, appId string, accessToken string) (stri
 		utils.ApiId: apiId}
 	//Checking if there is a subscription of given API to the give application
 	subResp, subErr := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, subEndpoint, headers)
+	if subErr != nil {
+		return "", subErr
+	}
 
 	if subResp.StatusCode() == http.StatusOK || subResp.StatusCode() == http.StatusCreated {
 		// 200 OK or 201 Created
@@ -529,7 +534,7 @@ func subscribeApiOrProduct(apiId string, appId string, accessToken string) (stri
 		subscriptionReq := &utils.SubscriptionCreateRequest{
 			APIID:            apiId,
 			ApplicationID:    appId,
-			ThrottlingPolicy: subscriptionThrottlingTier,
+			ThrottlingPolicy: throttlingPolicy,
 		}
 		//If there is no subscription, make a subscription
 		body, err := json.Marshal(subscriptionReq)
@@ -537,6 +542,9 @@ func subscribeApiOrProduct(apiId string, appId string, accessToken string) (stri
 			utils.HandleErrorAndExit("Error occurred while creating CLI application subscription request.", err)
 		}
 		resp, err := utils.InvokePOSTRequest(subEndpoint, headers, string(body))
+		if err != nil {
+			return "", err
+		}
 		if resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusCreated {
 			// 200 OK or 201 Created
 			subscription := &utils.Subscription{}
@@ -767,28 +775,38 @@ func getScopes(appId string, accessToken string) ([]string, error) {
 	}
 }
 
+// newDefaultKeygenRequest returns the key generation request of the production keys of the CLI application
+func newDefaultKeygenRequest() utils.KeygenRequest {
+	return utils.KeygenRequest{
+		KeyType:                 utils.ProductionKeyType,
+		GrantTypesToBeSupported: utils.GrantTypesToBeSupported,
+		ValidityTime:            utils.DefaultTokenValidityPeriod,
+	}
+}
+
 // Generate client credentials for the application first time and generate access token
+// @param environment : Environment of the application
 // @param appId : Application ID of the app to be generated keys
 // @param token : Token to invoke the devportal REST API
+// @param generateKeyReq : Key type, key manager and grant types of the keys to be generated
 // @return client_id, client_secret, error
-func generateApplicationKeys(appId string, token string) (*utils.KeygenResponse, error) {
+func generateApplicationKeys(environment, appId string, token string,
+	generateKeyReq utils.KeygenRequest) (*utils.KeygenResponse, error) {
 
-	applicationEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(keyGenEnv, utils.MainConfigFilePath) +
+	applicationEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath) +
 		"/" + appId + "/generate-keys"
 	headers := make(map[string]string)
 	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + token
 	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
-	generateKeyReq := utils.KeygenRequest{
-		KeyType:                 utils.ProductionKeyType,
-		GrantTypesToBeSupported: utils.GrantTypesToBeSupported,
-		ValidityTime:            utils.DefaultTokenValidityPeriod,
-	}
 	body, err := json.Marshal(generateKeyReq)
-	if body == nil && err != nil {
-		utils.HandleErrorAndExit("Error occurred while creating CLI application key generation request.", err)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := utils.InvokePOSTRequest(applicationEndpoint, headers, string(body))
+	if err != nil {
+		return nil, err
+	}
 	if resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusCreated {
 		// 200 OK or 201 Created
 		keygenResponse := &utils.KeygenResponse{}
@@ -800,7 +818,7 @@ func generateApplicationKeys(appId string, token string) (*utils.KeygenResponse,
 		utils.Logf("Body: %s\n", resp.Body())
 		if resp.StatusCode() == http.StatusUnauthorized {
 			// 401 Unauthorized
-			return nil, fmt.Errorf("authorization failed while generating keys of the CLI application: " + appId)
+			return nil, fmt.Errorf("authorization failed while generating keys of the application: " + appId)
 		}
 		return nil, errors.New("Request didn't respond 200 OK for application key generation. Status: " + resp.Status())
 	}
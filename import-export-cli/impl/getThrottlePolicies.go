@@ -119,6 +119,6 @@ func PrintThrottlePolicies(resp *resty.Response, format string) {
 	}
 	// execute context
 	if err := policyContext.Write(renderer, ThrottlePolicyTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
 	}
 }
@@ -125,8 +125,17 @@ func resolveYamlOrJSON(filename string) (string, []byte, error) {
 	return "", nil, fmt.Errorf("%s was not found as a YAML or JSON", filename)
 }
 
-// Substitutes environment variables in the project files.
-func replaceEnvVariables(apiFilePath string) error {
+// getProjectBaseDir returns the directory that the relative paths referenced in a project are resolved against. It is
+// the project directory, or the directory having the project archive.
+func getProjectBaseDir(projectPath string) string {
+	if info, err := os.Stat(projectPath); err == nil && info.IsDir() {
+		return projectPath
+	}
+	return filepath.Dir(projectPath)
+}
+
+// Substitutes environment variables in the project files. Relative paths are resolved against baseDir.
+func replaceEnvVariables(apiFilePath, baseDir string) error {
 	for _, replacePath := range utils.EnvReplaceFilePaths {
 		absFile := filepath.Join(apiFilePath, replacePath)
 		// check if the path exists. If exists, proceed with processing. Otherwise, continue with the next items
@@ -139,13 +148,13 @@ func replaceEnvVariables(apiFilePath string) error {
 			case mode.IsDir():
 				utils.Logln(utils.LogPrefixInfo+"Substituting env variables of files in folder path: ", absFile)
 				if strings.EqualFold(replacePath, utils.InitProjectSequences) {
-					err = utils.EnvSubstituteInFolder(absFile, utils.EnvReplacePoliciesFileExtensions)
+					err = utils.EnvSubstituteInFolder(absFile, baseDir, utils.EnvReplacePoliciesFileExtensions)
 				} else {
-					err = utils.EnvSubstituteInFolder(absFile, nil)
+					err = utils.EnvSubstituteInFolder(absFile, baseDir, nil)
 				}
 			case mode.IsRegular():
 				utils.Logln(utils.LogPrefixInfo+"Substituting env of file: ", absFile)
-				err = utils.EnvSubstituteInFile(absFile, nil)
+				err = utils.EnvSubstituteInFile(absFile, baseDir, nil)
 			}
 			if err != nil {
 				return err
@@ -172,30 +181,31 @@ func importAPI(endpoint, filePath, accessToken string, extraParams map[string]st
 			err := json.Unmarshal([]byte(resp.String()), &data)
 			if err != nil {
 				utils.Logln(utils.LogPrefixError, err)
-				fmt.Println("Error occurred while validating API")
-				return errors.New(resp.Status())
+				fmt.Fprintln(utils.Stdout, "Error occurred while validating API")
+				return utils.NewHttpResponseError(resp)
 			}
 			if data.ComplianceCheck.Result == "fail" {
 				PrintViolations(data.ComplianceCheck.Violations, apiLoggingCmdFormat)
 			} else if resp.StatusCode() == http.StatusOK {
-				fmt.Printf("No violations found for the API")
+				fmt.Fprintf(utils.Stdout, "No violations found for the API")
 			}
 		} else {
 			// We have an HTTP error
 			utils.Logln(utils.LogPrefixError, err)
-			fmt.Println("Error occurred while validating API")
-			return errors.New(resp.Status())
+			fmt.Fprintln(utils.Stdout, "Error occurred while validating API")
+			return utils.NewHttpResponseError(resp)
 		}
 	} else {
 		if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 			// 201 Created or 200 OK
-			fmt.Println("Successfully imported API.")
+			fmt.Fprintln(utils.Stdout, "Successfully imported API.")
+			utils.RecordArtifactIdFromResponse("api", resp.Body())
 		} else {
 			// We have an HTTP error
 			utils.Logln(utils.LogPrefixError, err)
-			fmt.Println("Status: " + resp.Status())
-			fmt.Println("Response:", resp)
-			return errors.New(resp.Status())
+			fmt.Fprintln(utils.Stdout, "Status: "+resp.Status())
+			fmt.Fprintln(utils.Stdout, "Response:", resp)
+			return utils.NewHttpResponseError(resp)
 		}
 	}
 	return nil
@@ -240,7 +250,7 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 	apiFilePath := tmpPath
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in API files...")
-	err = replaceEnvVariables(apiFilePath)
+	err = replaceEnvVariables(apiFilePath, getProjectBaseDir(resolvedAPIFilePath))
 	if err != nil {
 		return err
 	}
@@ -300,7 +310,10 @@ func envParamsFileProcess(importPath, paramsPath, importEnvironment string) erro
 		return err
 	}
 	// check whether import environment is included in params configuration
-	envParams := apiParams.GetEnv(importEnvironment)
+	envParams, err := apiParams.GetEffectiveEnv(importEnvironment)
+	if err != nil {
+		return err
+	}
 	if envParams == nil {
 		return errors.New("Environment '" + importEnvironment + "' does not exist in " + paramsPath)
 	} else {
@@ -337,7 +350,10 @@ func envParamsDirectoryProcess(importPath, paramsPath, importEnvironment string)
 		return err
 	}
 	// check whether import environment is included in api params configuration
-	envParams := apiParams.GetEnv(importEnvironment)
+	envParams, err := apiParams.GetEffectiveEnv(importEnvironment)
+	if err != nil {
+		return err
+	}
 	if envParams == nil {
 		return errors.New("Environment '" + importEnvironment + "' does not exist in " + paramsPath)
 	} else {
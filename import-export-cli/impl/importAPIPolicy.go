@@ -94,7 +94,7 @@ func importAPIPolicy(endpoint string, importPath string, accessToken string, isO
 	}
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in API Policy files...")
-	err = replaceEnvVariablesInPolicies(tmpPath)
+	err = replaceEnvVariablesInPolicies(tmpPath, getProjectBaseDir(resolvedPolicyFilePath))
 	if err != nil {
 		return err
 	}
@@ -120,7 +120,7 @@ func importAPIPolicy(endpoint string, importPath string, accessToken string, isO
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully Imported API Policy.")
+		fmt.Fprintln(utils.Stdout, "Successfully Imported API Policy.")
 		return nil
 	} else if resp.StatusCode() == http.StatusConflict {
 
@@ -130,8 +130,8 @@ func importAPIPolicy(endpoint string, importPath string, accessToken string, isO
 			return err
 		}
 
-		fmt.Println("Error importing API Policy due to: ", errorResponse.Description)
-		fmt.Println("Please change the Policy name and re-import")
+		fmt.Fprintln(utils.Stdout, "Error importing API Policy due to: ", errorResponse.Description)
+		fmt.Fprintln(utils.Stdout, "Please change the Policy name and re-import")
 
 		if err != nil {
 			return err
@@ -139,9 +139,9 @@ func importAPIPolicy(endpoint string, importPath string, accessToken string, isO
 
 		return errors.New(errorResponse.Status)
 	} else {
-		fmt.Println("Error importing API Policy.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp.IsSuccess())
+		fmt.Fprintln(utils.Stdout, "Error importing API Policy.")
+		fmt.Fprintln(utils.Stdout, "Status: "+resp.Status())
+		fmt.Fprintln(utils.Stdout, "Response:", resp.IsSuccess())
 
 		err := json.Unmarshal(resp.Body(), &errorResponse)
 
@@ -188,8 +188,8 @@ func resolvePolicyImportFilePath(file, defaultExportDirectory string) (string, e
 	return absPath, nil
 }
 
-// Substitutes environment variables in the project files.
-func replaceEnvVariablesInPolicies(policyFilePath string) error {
+// Substitutes environment variables in the project files. Relative paths are resolved against baseDir.
+func replaceEnvVariablesInPolicies(policyFilePath, baseDir string) error {
 	for _, replacePath := range utils.EnvReplaceFilePaths {
 		absFile := filepath.Join(policyFilePath, replacePath)
 		// check if the path exists. If exists, proceed with processing. Otherwise, continue with the next items
@@ -202,13 +202,13 @@ func replaceEnvVariablesInPolicies(policyFilePath string) error {
 			case mode.IsDir():
 				utils.Logln(utils.LogPrefixInfo+"Substituting env variables of files in folder path: ", absFile)
 				if strings.EqualFold(replacePath, utils.InitProjectSequences) {
-					err = utils.EnvSubstituteInFolder(absFile, utils.EnvReplacePoliciesFileExtensions)
+					err = utils.EnvSubstituteInFolder(absFile, baseDir, utils.EnvReplacePoliciesFileExtensions)
 				} else {
-					err = utils.EnvSubstituteInFolder(absFile, nil)
+					err = utils.EnvSubstituteInFolder(absFile, baseDir, nil)
 				}
 			case mode.IsRegular():
 				utils.Logln(utils.LogPrefixInfo+"Substituting env of file: ", absFile)
-				err = utils.EnvSubstituteInFile(absFile, nil)
+				err = utils.EnvSubstituteInFile(absFile, baseDir, nil)
 			}
 			if err != nil {
 				return err
@@ -19,7 +19,6 @@
 package impl
 
 import (
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -66,14 +65,15 @@ func importAPIProduct(endpoint, filePath, accessToken string, extraParams map[st
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported API Product.")
+		fmt.Fprintln(utils.Stdout, "Successfully imported API Product.")
+		utils.RecordArtifactIdFromResponse("api-product", resp.Body())
 		return nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing API Product.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return errors.New(resp.Status())
+		fmt.Fprintln(utils.Stdout, "Error importing API Product.")
+		fmt.Fprintln(utils.Stdout, "Status: "+resp.Status())
+		fmt.Fprintln(utils.Stdout, "Response:", resp)
+		return utils.NewHttpResponseError(resp)
 	}
 }
 
@@ -118,13 +118,14 @@ func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, im
 	apiProductFilePath := tmpPath
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in API Product files...")
-	err = replaceEnvVariables(apiProductFilePath)
+	baseDir := getProjectBaseDir(resolvedAPIProductFilePath)
+	err = replaceEnvVariables(apiProductFilePath, baseDir)
 	if err != nil {
 		return err
 	}
 
 	// Replace the environment variables inside the dependent API directories
-	err = replaceEnvVariablesInDependentAPIs(apiProductFilePath)
+	err = replaceEnvVariablesInDependentAPIs(apiProductFilePath, baseDir)
 	if err != nil {
 		return err
 	}
@@ -186,8 +187,9 @@ func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, im
 	return err
 }
 
-// replaceEnvVariablesInDependentAPIs replaces the environment variables inside the dependent APIs
-func replaceEnvVariablesInDependentAPIs(apiProductFilePath string) error {
+// replaceEnvVariablesInDependentAPIs replaces the environment variables inside the dependent APIs. Relative paths are
+// resolved against baseDir, the API Product project directory.
+func replaceEnvVariablesInDependentAPIs(apiProductFilePath, baseDir string) error {
 	// Check whether the APIs directory exists
 	apisDirectoryPath := apiProductFilePath + string(os.PathSeparator) + "APIs"
 	_, err := os.Stat(apisDirectoryPath)
@@ -204,7 +206,7 @@ func replaceEnvVariablesInDependentAPIs(apiProductFilePath string) error {
 
 		utils.Logln(utils.LogPrefixInfo + "Replacing env variables in" + apiDirectoryPath)
 		// Substitutes environment variables in the project files
-		err = replaceEnvVariables(apiDirectoryPath)
+		err = replaceEnvVariables(apiDirectoryPath, baseDir)
 		if err != nil {
 			return err
 		}
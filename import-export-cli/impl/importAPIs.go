@@ -0,0 +1,136 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+)
+
+// revisionArchiveRegex matches the revision suffix of the archives written by the export commands.
+// Eg: PizzaShackAPI_1.0.0_Revision-2.zip
+var revisionArchiveRegex = regexp.MustCompile(`^(.+)_Revision-([0-9]+)$`)
+
+// importAPIArtifact is an archive or a project directory of an API to be imported
+type importAPIArtifact struct {
+	path     string
+	revision string
+}
+
+// ImportAPIsFromDir imports all the API archives and project directories in a directory to an environment using
+// the given number of workers. Revisions of the same API are imported one after the other in ascending order
+// followed by the working copy, while different APIs are imported concurrently.
+// @param credential : Credential of the environment
+// @param environment : Environment the APIs are imported to
+// @param importDir : Directory containing the APIs. Eg: the directory written by "export apis"
+// @param workers : Maximum number of APIs imported concurrently
+// @return results of the imported APIs
+// @return error
+func ImportAPIsFromDir(credential credentials.Credential, environment, importDir, apiParamsPath string,
+	importAPIUpdate, preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool,
+	workers int) ([]*BulkArtifactResult, error) {
+	groups, err := getImportAPIArtifactGroups(importDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, errors.New("No APIs found in " + importDir)
+	}
+
+	tasks := make([]bulkTask, len(groups))
+	for i := range groups {
+		group := groups[i]
+		names := make([]string, len(group))
+		for j, artifact := range group {
+			names[j] = filepath.Base(artifact.path)
+		}
+		tasks[i] = bulkTask{
+			artifact: strings.Join(names, ", "),
+			run: func() error {
+				for _, artifact := range group {
+					if err := importAPIArtifactToEnv(credential, environment, artifact.path, apiParamsPath,
+						importAPIUpdate, preserveProvider, importAPISkipCleanup, importAPIRotateRevision,
+						importAPISkipDeployments); err != nil {
+						return fmt.Errorf("%s: %w", filepath.Base(artifact.path), err)
+					}
+				}
+				return nil
+			},
+		}
+	}
+	return runBulkTasks(tasks, workers, nil), nil
+}
+
+// importAPIArtifactToEnv imports a single API archive or project. Throttled and unavailable requests are retried by
+// the HTTP client. A token is requested for each import since importing a large directory can outlive a token.
+func importAPIArtifactToEnv(credential credentials.Credential, environment, artifactPath, apiParamsPath string,
+	importAPIUpdate, preserveProvider, importAPISkipCleanup, importAPIRotateRevision,
+	importAPISkipDeployments bool) error {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, environment)
+	if err != nil {
+		return err
+	}
+	return ImportAPIToEnv(accessToken, environment, artifactPath, apiParamsPath, importAPIUpdate,
+		preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments, false, "")
+}
+
+// getImportAPIArtifactGroups lists the API archives and project directories in importDir grouped by API. The
+// artifacts of a group are ordered by their revision, and the working copy is placed last.
+func getImportAPIArtifactGroups(importDir string) ([][]importAPIArtifact, error) {
+	entries, err := ioutil.ReadDir(importDir)
+	if err != nil {
+		return nil, err
+	}
+	groupIndexes := make(map[string]int)
+	var groups [][]importAPIArtifact
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || (!entry.IsDir() && !strings.HasSuffix(name, ".zip")) {
+			continue
+		}
+		key := strings.TrimSuffix(name, ".zip")
+		revision := ""
+		if match := revisionArchiveRegex.FindStringSubmatch(key); match != nil {
+			key, revision = match[1], match[2]
+		}
+		i, ok := groupIndexes[key]
+		if !ok {
+			i = len(groups)
+			groupIndexes[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], importAPIArtifact{path: filepath.Join(importDir, name), revision: revision})
+	}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].revision == "" || group[j].revision == "" {
+				return group[j].revision == "" && group[i].revision != ""
+			}
+			return compareRevisionNumbers(group[i].revision, group[j].revision) < 0
+		})
+	}
+	return groups, nil
+}
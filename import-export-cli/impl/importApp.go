@@ -20,7 +20,6 @@ package impl
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -99,14 +98,15 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported Application.")
+		fmt.Fprintln(utils.Stdout, "Successfully imported Application.")
+		utils.RecordArtifactIdFromResponse("app", resp.Body())
 		return nil, nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing Application.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return nil, errors.New(resp.Status())
+		fmt.Fprintln(utils.Stdout, "Error importing Application.")
+		fmt.Fprintln(utils.Stdout, "Status: "+resp.Status())
+		fmt.Fprintln(utils.Stdout, "Response:", resp)
+		return nil, utils.NewHttpResponseError(resp)
 	}
 }
 
@@ -0,0 +1,206 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// EnvironmentSnapshotImportOptions holds the user provided options of an environment import
+type EnvironmentSnapshotImportOptions struct {
+	PreserveProvider bool
+	SkipKeys         bool
+	Force            bool
+}
+
+// environmentSnapshotImporter replays the artifacts of a snapshot to an environment
+type environmentSnapshotImporter struct {
+	credential          credentials.Credential
+	environment         string
+	snapshotDir         string
+	options             EnvironmentSnapshotImportOptions
+	accessToken         string
+	existingAPIPolicies []utils.APIPolicy
+}
+
+// ImportEnvironmentSnapshot imports the artifacts of the snapshot in snapshotDir to an environment in dependency
+// order. The imported artifacts are recorded in the snapshot directory, hence a halted import is resumed from the
+// artifact that failed unless options.Force is set.
+// @param credential : Credential of the environment
+// @param environment : Environment the snapshot is imported to
+// @param snapshotDir : Directory of the snapshot
+// @param options : Options of the import
+// @return number of artifacts imported
+// @return error
+func ImportEnvironmentSnapshot(credential credentials.Credential, environment, snapshotDir string,
+	options EnvironmentSnapshotImportOptions) (int, error) {
+	manifest, err := LoadEnvironmentSnapshotManifest(snapshotDir)
+	if err != nil {
+		return 0, err
+	}
+	if !manifest.Completed {
+		return 0, errors.New("The snapshot in " + snapshotDir + " is incomplete. Run \"export environment\" " +
+			"again to resume exporting " + manifest.Environment)
+	}
+
+	progress := &snapshotImportProgress{Environment: environment}
+	if !options.Force {
+		progress, err = loadSnapshotImportProgress(snapshotDir, environment)
+		if err != nil {
+			return 0, err
+		}
+		if len(progress.Imported) > 0 {
+			fmt.Fprintln(utils.Stdout, "Resuming the import of the snapshot to "+environment+" ("+
+				strconv.Itoa(len(progress.Imported))+" artifacts already imported)")
+		}
+	}
+
+	importer := &environmentSnapshotImporter{
+		credential:  credential,
+		environment: environment,
+		snapshotDir: snapshotDir,
+		options:     options,
+	}
+	importedCount := 0
+	for _, artifact := range getPendingSnapshotArtifacts(manifest, progress) {
+		// Tokens are requested for each artifact since importing a large snapshot can outlive a token
+		importer.accessToken, err = credentials.GetOAuthAccessToken(credential, environment)
+		if err != nil {
+			return importedCount, err
+		}
+		fmt.Fprintln(utils.Stdout, "Importing "+artifact.String()+"...")
+		if err := importer.importArtifact(artifact); err != nil {
+			return importedCount, errors.New("Error importing " + artifact.String() + ": " + err.Error() +
+				". Run the command again to resume the import")
+		}
+		progress.Imported = append(progress.Imported, artifact.Key())
+		if err := writeSnapshotYamlFile(getSnapshotImportProgressFilePath(snapshotDir, environment),
+			progress); err != nil {
+			return importedCount, err
+		}
+		importedCount++
+	}
+	return importedCount, nil
+}
+
+// importArtifact imports a single artifact of the snapshot. Revisions are imported as updates that create a new
+// revision, while working copies are imported without deployments so that only the working copy is updated.
+func (i *environmentSnapshotImporter) importArtifact(artifact *SnapshotArtifact) error {
+	artifactPath := filepath.Join(i.snapshotDir, filepath.FromSlash(artifact.File))
+	workingCopy := artifact.Revision == ""
+	switch artifact.Type {
+	case SnapshotArtifactRateLimitingPolicy:
+		return ImportThrottlingPolicyToEnv(i.accessToken, i.environment, artifactPath, true)
+	case SnapshotArtifactAPIPolicy:
+		exists, err := i.isAPIPolicyInEnv(artifact.Name, artifact.Version)
+		if err != nil || exists {
+			// Operation policies cannot be updated, hence an existing policy is left as it is
+			return err
+		}
+		return ImportAPIPolicyToEnv(i.accessToken, i.environment, artifactPath)
+	case SnapshotArtifactAPI:
+		return ImportAPIToEnv(i.accessToken, i.environment, artifactPath, "", true, i.options.PreserveProvider,
+			false, true, workingCopy, false, "")
+	case SnapshotArtifactAPIProduct:
+		return ImportAPIProductToEnv(i.accessToken, i.environment, artifactPath, "", false, false, true,
+			i.options.PreserveProvider, false, true, workingCopy)
+	case SnapshotArtifactMCPServer:
+		return ImportMCPServerToEnv(i.accessToken, i.environment, artifactPath, "", true,
+			i.options.PreserveProvider, false, true, workingCopy, false, "")
+	case SnapshotArtifactApplication:
+		_, err := ImportApplicationToEnv(i.accessToken, i.environment, artifactPath, "", true, true, false,
+			i.options.SkipKeys, false)
+		return err
+	case SnapshotArtifactCorrelationLogging:
+		return i.importCorrelationLogging(artifactPath)
+	case SnapshotArtifactAPILogging:
+		return i.importAPILogging(artifactPath)
+	}
+	return errors.New("Unsupported artifact type " + artifact.Type)
+}
+
+func (i *environmentSnapshotImporter) isAPIPolicyInEnv(name, version string) (bool, error) {
+	if i.existingAPIPolicies == nil {
+		policies, err := getAllAPIPoliciesFromEnv(i.accessToken, i.environment)
+		if err != nil {
+			return false, err
+		}
+		i.existingAPIPolicies = policies
+	}
+	for _, policy := range i.existingAPIPolicies {
+		if policy.Name == name && policy.Version == version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (i *environmentSnapshotImporter) importCorrelationLogging(filePath string) error {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	var components []utils.CorrelationComponent
+	if err := yaml.Unmarshal(content, &components); err != nil {
+		return err
+	}
+	for _, component := range components {
+		deniedThreads := ""
+		for _, property := range component.Properties {
+			if property.Name == "deniedThreads" {
+				deniedThreads = strings.Join(property.Value, ",")
+			}
+		}
+		if _, err := SetCorrelationLoggingComponent(i.credential, i.environment, component.Name, component.Enabled,
+			deniedThreads); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *environmentSnapshotImporter) importAPILogging(filePath string) error {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	var apiLogging []snapshotAPILogging
+	if err := yaml.Unmarshal(content, &apiLogging); err != nil {
+		return err
+	}
+	for _, logging := range apiLogging {
+		apiId, err := GetAPIId(i.accessToken, i.environment, logging.Name, logging.Version, logging.Provider)
+		if err != nil {
+			return err
+		}
+		if _, err := SetAPILoggingLevel(i.credential, i.environment, apiId, "", logging.LogLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
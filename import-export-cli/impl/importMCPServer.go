@@ -20,7 +20,6 @@ package impl
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -47,30 +46,31 @@ func importMCPServer(endpoint, filePath, accessToken string, extraParams map[str
 			err := json.Unmarshal([]byte(resp.String()), &data)
 			if err != nil {
 				utils.Logln(utils.LogPrefixError, err)
-				fmt.Println("Error occurred while validating MCP Server")
-				return errors.New(resp.Status())
+				fmt.Fprintln(utils.Stdout, "Error occurred while validating MCP Server")
+				return utils.NewHttpResponseError(resp)
 			}
 			if data.ComplianceCheck.Result == "fail" {
 				PrintViolations(data.ComplianceCheck.Violations, mcpServerLoggingCmdFormat)
 			} else if resp.StatusCode() == http.StatusOK {
-				fmt.Printf("No violations found for the MCP Server")
+				fmt.Fprintf(utils.Stdout, "No violations found for the MCP Server")
 			}
 		} else {
 			// We have an HTTP error
 			utils.Logln(utils.LogPrefixError, err)
-			fmt.Println("Error occurred while validating MCP Server")
-			return errors.New(resp.Status())
+			fmt.Fprintln(utils.Stdout, "Error occurred while validating MCP Server")
+			return utils.NewHttpResponseError(resp)
 		}
 	} else {
 		if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 			// 201 Created or 200 OK
-			fmt.Println("Successfully imported MCP Server.")
+			fmt.Fprintln(utils.Stdout, "Successfully imported MCP Server.")
+			utils.RecordArtifactIdFromResponse("mcp-server", resp.Body())
 		} else {
 			// We have an HTTP error
 			utils.Logln(utils.LogPrefixError, err)
-			fmt.Println("Status: " + resp.Status())
-			fmt.Println("Response:", resp)
-			return errors.New(resp.Status())
+			fmt.Fprintln(utils.Stdout, "Status: "+resp.Status())
+			fmt.Fprintln(utils.Stdout, "Response:", resp)
+			return utils.NewHttpResponseError(resp)
 		}
 	}
 	return nil
@@ -115,7 +115,7 @@ func ImportMCPServer(accessOAuthToken, publisherEndpoint, importEnvironment, imp
 	mcpServerFilePath := tmpPath
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in MCP Server files...")
-	err = replaceEnvVariables(mcpServerFilePath)
+	err = replaceEnvVariables(mcpServerFilePath, getProjectBaseDir(resolvedMCPServerFilePath))
 	if err != nil {
 		return err
 	}
@@ -19,7 +19,6 @@
 package impl
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -59,18 +58,18 @@ func importThrottlingPolicy(endpoint string, importPath string, accessToken stri
 	}
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println(resp.String())
+		fmt.Fprintln(utils.Stdout, resp.String())
 		return nil
 	} else {
 		// We have an HTTP error
 		if resp.StatusCode() == http.StatusConflict && ThrottlePolicyUpdate {
-			fmt.Println("Cannot Update")
+			fmt.Fprintln(utils.Stdout, "Cannot Update")
 		}
-		fmt.Println("Error importing Throttling Policy.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp.IsSuccess())
+		fmt.Fprintln(utils.Stdout, "Error importing Throttling Policy.")
+		fmt.Fprintln(utils.Stdout, "Status: "+resp.Status())
+		fmt.Fprintln(utils.Stdout, "Response:", resp.IsSuccess())
 
-		return errors.New(resp.Status())
+		return utils.NewHttpResponseError(resp)
 	}
 }
 
@@ -23,11 +23,13 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/Jeffail/gabs"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-openapi/loads"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/wso2/product-apim-tooling/import-export-cli/box"
@@ -49,10 +51,26 @@ var dirs = []string{
 	utils.InitProjectLibs,
 }
 
+// Types of the definitions an API project could be initialized from
+const (
+	InitSourceOAS      = "oas"
+	InitSourceAsyncAPI = "asyncapi"
+	InitSourceGraphQL  = "graphql"
+	InitSourceWSDL     = "wsdl"
+	InitSourcePostman  = "postman"
+)
+
 // InitAPIProject function is used to initlialize an API Project
-func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, initCmdApiDefinitionPath string, isAdvertiseOnly bool) error {
+// @param initCmdOutputDir : Path of the project
+// @param initCmdInitialState : Initial lifecycle state of the API
+// @param initCmdSourcePath : Path or URL of the definition to populate the API from, if any
+// @param initCmdSourceType : Type of the definition. One of oas, asyncapi, graphql, wsdl or postman
+// @param initCmdApiDefinitionPath : Path of the api.yaml to merge with the populated definition
+// @param isAdvertiseOnly : Whether the API is an advertise only API
+// @return error
+func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSourcePath, initCmdSourceType,
+	initCmdApiDefinitionPath string, isAdvertiseOnly bool) error {
 	var dir string
-	swaggerSavePath := filepath.Join(initCmdOutputDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
 
 	if initCmdOutputDir != "" {
 		err := os.MkdirAll(initCmdOutputDir, os.ModePerm)
@@ -71,7 +89,7 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		}
 		dir = pwd
 	}
-	fmt.Println("Initializing a new WSO2 API Manager project in", dir)
+	fmt.Fprintln(utils.Stdout, "Initializing a new WSO2 API Manager project in", dir)
 
 	definitionFile, err := loadDefaultSpec()
 
@@ -91,38 +109,25 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		return err
 	}
 
-	// Use the swagger definition to populate the API definition and save the swagger file separately inside the project
-	if initCmdSwaggerPath != "" {
-		// Load the swagger file from the provided path
-		doc, err := loadSwagger(initCmdSwaggerPath)
-		if err != nil {
-			return err
-		}
-		// We use swagger2 loader. It works fine for now
-		// Since we don't use 3.0 specific details its ok
-		// otherwise please use v2.openAPI3 loaders
-		err = v2.Swagger2Populate(def, doc)
-		if err != nil {
-			return err
-		}
-
-		// Convert and write the swagger definition as yaml
-		yamlSwagger, err := utils.JsonToYaml(doc.Raw())
-		if err != nil {
-			return err
-		}
-		err = ioutil.WriteFile(swaggerSavePath, yamlSwagger, os.ModePerm)
-		if err != nil {
-			return err
-		}
-	} else {
-		// Create an empty swagger definition
-		utils.Logln(utils.LogPrefixInfo + "Writing " + swaggerSavePath)
-		swaggerDoc, _ := box.Get("/init/swagger-default.yaml")
-		err = ioutil.WriteFile(swaggerSavePath, swaggerDoc, os.ModePerm)
-		if err != nil {
-			return err
-		}
+	// Use the source definition to populate the API definition and save it separately inside the project. The WSDL
+	// is named after the API, hence it is saved once the API definition is complete.
+	var wsdl []byte
+	switch {
+	case initCmdSourcePath == "":
+		err = writeDefaultSwagger(initCmdOutputDir)
+	case initCmdSourceType == InitSourceAsyncAPI:
+		err = initFromAsyncAPI(def, initCmdOutputDir, initCmdSourcePath)
+	case initCmdSourceType == InitSourceGraphQL:
+		err = initFromGraphQL(def, initCmdOutputDir, initCmdSourcePath)
+	case initCmdSourceType == InitSourceWSDL:
+		wsdl, err = initFromWSDL(def, initCmdOutputDir, initCmdSourcePath)
+	case initCmdSourceType == InitSourcePostman:
+		err = initFromPostman(def, initCmdOutputDir, initCmdSourcePath)
+	default:
+		err = initFromOpenAPI(def, initCmdOutputDir, initCmdSourcePath)
+	}
+	if err != nil {
+		return err
 	}
 
 	// Use the API definition if provided
@@ -176,6 +181,16 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		definitionFile.Data.Context = "/" + strings.ToLower(filepath.Base(initCmdOutputDir))
 	}
 
+	if wsdl != nil {
+		wsdlSavePath := filepath.Join(initCmdOutputDir, utils.InitProjectWSDL,
+			definitionFile.Data.Name+"-"+definitionFile.Data.Version+".wsdl")
+		utils.Logln(utils.LogPrefixInfo + "Writing " + wsdlSavePath)
+		err = ioutil.WriteFile(wsdlSavePath, wsdl, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+
 	apiData, err := yaml2.Marshal(definitionFile)
 	if err != nil {
 		return err
@@ -239,8 +254,8 @@ func InitAPIProject(initCmdOutputDir, initCmdInitialState, initCmdSwaggerPath, i
 		return err
 	}
 
-	fmt.Println("Project initialized")
-	fmt.Println("Open README file to learn more")
+	fmt.Fprintln(utils.Stdout, "Project initialized")
+	fmt.Fprintln(utils.Stdout, "Open README file to learn more")
 	return nil
 }
 
@@ -271,8 +286,175 @@ func createDirectories(name string) error {
 	return nil
 }
 
+// writeDefaultSwagger writes an empty swagger definition to the project
+func writeDefaultSwagger(projectDir string) error {
+	swaggerSavePath := filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + swaggerSavePath)
+	swaggerDoc, _ := box.Get("/init/swagger-default.yaml")
+	return ioutil.WriteFile(swaggerSavePath, swaggerDoc, os.ModePerm)
+}
+
+// initFromOpenAPI populates the API definition from a Swagger 2.0 or an OpenAPI 3.x definition and writes the
+// definition as Definitions/swagger.yaml
+func initFromOpenAPI(def *v2.APIDTODefinition, projectDir, swaggerPath string) error {
+	content, err := readInitSource(swaggerPath)
+	if err != nil {
+		return err
+	}
+	return initFromOpenAPIContent(def, projectDir, content, swaggerPath)
+}
+
+// initFromPostman populates the API definition from an OpenAPI definition inferred from a Postman collection or an
+// Insomnia export and writes the inferred definition as Definitions/swagger.yaml
+func initFromPostman(def *v2.APIDTODefinition, projectDir, collectionPath string) error {
+	content, err := readInitSource(collectionPath)
+	if err != nil {
+		return err
+	}
+	collection, err := ParseRequestCollection(content)
+	if err != nil {
+		return err
+	}
+	definition, err := BuildPostmanOpenAPI(collection)
+	if err != nil {
+		return err
+	}
+	return initFromOpenAPIContent(def, projectDir, definition, collectionPath)
+}
+
+// initFromOpenAPIContent populates the API definition from the content of a Swagger 2.0 or an OpenAPI 3.x definition
+// read from swaggerPath and writes the definition as Definitions/swagger.yaml
+func initFromOpenAPIContent(def *v2.APIDTODefinition, projectDir string, content []byte, swaggerPath string) error {
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return err
+	}
+	var version struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(jsonContent, &version); err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(version.OpenAPI, "3.") {
+		doc, err := loadOpenAPI3(jsonContent, swaggerPath)
+		if err != nil {
+			return err
+		}
+		err = v2.Oai3Populate(def, doc)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Load the swagger file from the provided path
+		doc, err := loadSwagger(swaggerPath)
+		if err != nil {
+			return err
+		}
+		err = v2.Swagger2Populate(def, doc)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Convert and write the swagger definition as yaml
+	yamlSwagger, err := utils.JsonToYaml(jsonContent)
+	if err != nil {
+		return err
+	}
+	swaggerSavePath := filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + swaggerSavePath)
+	return ioutil.WriteFile(swaggerSavePath, yamlSwagger, os.ModePerm)
+}
+
+// initFromAsyncAPI populates the API definition from an AsyncAPI definition and writes the definition as
+// Definitions/asyncapi.yaml. Async APIs do not have a swagger definition.
+func initFromAsyncAPI(def *v2.APIDTODefinition, projectDir, asyncAPIPath string) error {
+	content, err := readInitSource(asyncAPIPath)
+	if err != nil {
+		return err
+	}
+	err = v2.AsyncAPIPopulate(def, content)
+	if err != nil {
+		return err
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return err
+	}
+	yamlContent, err := utils.JsonToYaml(jsonContent)
+	if err != nil {
+		return err
+	}
+	asyncAPISavePath := filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectDefinitionsAsyncAPI))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + asyncAPISavePath)
+	return ioutil.WriteFile(asyncAPISavePath, yamlContent, os.ModePerm)
+}
+
+// initFromGraphQL populates the API definition from a GraphQL schema and writes the schema as
+// Definitions/schema.graphql
+func initFromGraphQL(def *v2.APIDTODefinition, projectDir, schemaPath string) error {
+	content, err := readInitSource(schemaPath)
+	if err != nil {
+		return err
+	}
+	err = v2.GraphQLPopulate(def, content)
+	if err != nil {
+		return err
+	}
+	schemaSavePath := filepath.Join(projectDir, filepath.FromSlash(utils.InitProjectDefinitionsGraphQLSchema))
+	utils.Logln(utils.LogPrefixInfo + "Writing " + schemaSavePath)
+	return ioutil.WriteFile(schemaSavePath, content, os.ModePerm)
+}
+
+// initFromWSDL populates the API definition from a WSDL and returns the WSDL to be written to the WSDL directory.
+// SOAP APIs are exposed through the default swagger definition.
+func initFromWSDL(def *v2.APIDTODefinition, projectDir, wsdlPath string) ([]byte, error) {
+	content, err := readInitSource(wsdlPath)
+	if err != nil {
+		return nil, err
+	}
+	err = v2.WsdlPopulate(def, content)
+	if err != nil {
+		return nil, err
+	}
+	wsdlDir := filepath.Join(projectDir, utils.InitProjectWSDL)
+	utils.Logln(utils.LogPrefixInfo + "Creating directory " + wsdlDir)
+	err = os.MkdirAll(wsdlDir, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+	return content, writeDefaultSwagger(projectDir)
+}
+
+// readInitSource reads a definition from a file or a URL
+func readInitSource(path string) ([]byte, error) {
+	utils.Logln(utils.LogPrefixInfo + "Reading definition from " + path)
+	if utils.IsValidUrl(path) {
+		return utils.ReadFromUrl(path)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// loadOpenAPI3 loads an OpenAPI 3.0 or 3.1 definition. References are resolved relative to the location of the
+// definition.
+func loadOpenAPI3(content []byte, path string) (*openapi3.T, error) {
+	utils.Logln(utils.LogPrefixInfo + "Loading OpenAPI definition from " + path)
+	location, err := url.Parse(path)
+	if err != nil || !utils.IsValidUrl(path) {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		location = &url.URL{Path: filepath.ToSlash(absPath)}
+	}
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	return loader.LoadFromDataWithPath(content, location)
+}
+
 // loadSwagger will Load the swagger definition from swaggerDoc
-// Swagger2.0/OpenAPI3.0 specs are supported
+// Swagger2.0 specs are supported
 func loadSwagger(swaggerDoc string) (*loads.Document, error) {
 	utils.Logln(utils.LogPrefixInfo + "Loading swagger from " + swaggerDoc)
 	return loads.Spec(swaggerDoc)
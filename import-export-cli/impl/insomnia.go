@@ -0,0 +1,421 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Formats of the collections generated by "apictl export postman"
+const (
+	CollectionFormatPostman  = "postman"
+	CollectionFormatInsomnia = "insomnia"
+)
+
+// Types of the resources of an Insomnia export
+const (
+	insomniaExportType       = "export"
+	insomniaExportFormat     = 4
+	insomniaWorkspaceType    = "workspace"
+	insomniaEnvironmentType  = "environment"
+	insomniaRequestGroupType = "request_group"
+	insomniaRequestType      = "request"
+)
+
+// InsomniaExport is an export of Insomnia of the format v4 having a workspace with its requests, request groups and
+// environments
+type InsomniaExport struct {
+	Type         string              `json:"_type"`
+	ExportFormat int                 `json:"__export_format"`
+	ExportSource string              `json:"__export_source,omitempty"`
+	Resources    []*InsomniaResource `json:"resources"`
+}
+
+// InsomniaResource is a workspace, an environment, a request group or a request of an export. The fields not used by
+// a type of resource are left empty.
+type InsomniaResource struct {
+	ID             string                 `json:"_id"`
+	Type           string                 `json:"_type"`
+	ParentID       *string                `json:"parentId"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description,omitempty"`
+	Scope          string                 `json:"scope,omitempty"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+	Method         string                 `json:"method,omitempty"`
+	URL            string                 `json:"url,omitempty"`
+	Body           *InsomniaBody          `json:"body,omitempty"`
+	Parameters     []InsomniaKeyValue     `json:"parameters,omitempty"`
+	PathParameters []InsomniaKeyValue     `json:"pathParameters,omitempty"`
+	Headers        []InsomniaKeyValue     `json:"headers,omitempty"`
+	Authentication map[string]interface{} `json:"authentication,omitempty"`
+}
+
+// InsomniaBody is the body of a request. Raw bodies have the text and form bodies have the params.
+type InsomniaBody struct {
+	MimeType string             `json:"mimeType,omitempty"`
+	Text     string             `json:"text,omitempty"`
+	Params   []InsomniaKeyValue `json:"params,omitempty"`
+}
+
+// InsomniaKeyValue is a header, a parameter or a field of a form body
+type InsomniaKeyValue struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// insomniaVariableRegex matches the variables of the base environment as referred in Insomnia, eg: {{ _.baseUrl }}
+var insomniaVariableRegex = regexp.MustCompile(`{{\s*_\.([^{}\s]+)\s*}}`)
+
+// ParseRequestCollection reads a Postman collection of the format v2.0 or v2.1, or an Insomnia export of the format
+// v4 in JSON or YAML, as a Postman collection
+func ParseRequestCollection(content []byte) (*PostmanCollection, error) {
+	var export struct {
+		Type string `json:"_type"`
+	}
+	if jsonContent, err := utils.YamlToJson(content); err == nil && json.Unmarshal(jsonContent, &export) == nil &&
+		export.Type == insomniaExportType {
+		return ParseInsomniaExport(jsonContent)
+	}
+	return ParsePostmanCollection(content)
+}
+
+// ParseInsomniaExport reads the first workspace of an Insomnia export of the format v4 as a Postman collection. The
+// request groups become folders and the variables of the base environment become the variables of the collection.
+func ParseInsomniaExport(content []byte) (*PostmanCollection, error) {
+	export := &InsomniaExport{}
+	if err := json.Unmarshal(content, export); err != nil {
+		return nil, fmt.Errorf("invalid Insomnia export: %w", err)
+	}
+	if export.Type != insomniaExportType || export.ExportFormat != insomniaExportFormat {
+		return nil, errors.New("unsupported Insomnia export. Export the collection in the format Insomnia v4")
+	}
+
+	var workspace *InsomniaResource
+	children := make(map[string][]*InsomniaResource)
+	for _, resource := range export.Resources {
+		if resource.Type == insomniaWorkspaceType && workspace == nil {
+			workspace = resource
+		}
+		if resource.ParentID != nil {
+			children[*resource.ParentID] = append(children[*resource.ParentID], resource)
+		}
+	}
+	if workspace == nil {
+		return nil, errors.New("the Insomnia export does not have a workspace")
+	}
+
+	collection := &PostmanCollection{Info: PostmanInfo{Name: workspace.Name,
+		Description: PostmanText(workspace.Description), Schema: PostmanCollectionSchema}}
+	for _, resource := range children[workspace.ID] {
+		if resource.Type != insomniaEnvironmentType {
+			continue
+		}
+		for _, key := range getSortedKeys(resource.Data) {
+			collection.Variable = append(collection.Variable, PostmanKeyValue{Key: key,
+				Value: PostmanText(fmt.Sprint(resource.Data[key]))})
+		}
+	}
+	collection.Item = buildInsomniaPostmanItems(workspace.ID, children)
+	return collection, nil
+}
+
+// buildInsomniaPostmanItems builds the items of the request groups and the requests under a parent recursively
+func buildInsomniaPostmanItems(parentID string, children map[string][]*InsomniaResource) []*PostmanItem {
+	var items []*PostmanItem
+	for _, resource := range children[parentID] {
+		switch resource.Type {
+		case insomniaRequestGroupType:
+			items = append(items, &PostmanItem{Name: resource.Name, Description: PostmanText(resource.Description),
+				Auth: getInsomniaPostmanAuth(resource.Authentication),
+				Item: buildInsomniaPostmanItems(resource.ID, children)})
+		case insomniaRequestType:
+			items = append(items, &PostmanItem{Name: resource.Name, Request: buildInsomniaPostmanRequest(resource)})
+		}
+	}
+	return items
+}
+
+// buildInsomniaPostmanRequest builds the request of a collection from a request of an export
+func buildInsomniaPostmanRequest(resource *InsomniaResource) *PostmanRequest {
+	request := &PostmanRequest{Method: resource.Method, Header: []PostmanKeyValue{},
+		Description: PostmanText(resource.Description), Auth: getInsomniaPostmanAuth(resource.Authentication)}
+	request.URL.Raw = toPostmanVariables(resource.URL)
+	for _, parameter := range resource.Parameters {
+		request.URL.Query = append(request.URL.Query, toPostmanKeyValue(parameter))
+	}
+	for _, parameter := range resource.PathParameters {
+		request.URL.Variable = append(request.URL.Variable, toPostmanKeyValue(parameter))
+	}
+	hasContentType := false
+	for _, header := range resource.Headers {
+		hasContentType = hasContentType || strings.EqualFold(header.Name, utils.HeaderContentType)
+		request.Header = append(request.Header, toPostmanKeyValue(header))
+	}
+
+	body := resource.Body
+	if body == nil || (body.Text == "" && len(body.Params) == 0) {
+		return request
+	}
+	if body.MimeType != "" && !hasContentType {
+		request.Header = append(request.Header, PostmanKeyValue{Key: utils.HeaderContentType,
+			Value: PostmanText(body.MimeType)})
+	}
+	switch {
+	case body.MimeType == utils.HeaderValueXWWWFormUrlEncoded:
+		request.Body = &PostmanBody{Mode: "urlencoded"}
+		for _, param := range body.Params {
+			request.Body.URLEncoded = append(request.Body.URLEncoded, toPostmanKeyValue(param))
+		}
+	case strings.HasPrefix(body.MimeType, "multipart/"):
+		request.Body = &PostmanBody{Mode: "formdata"}
+		for _, param := range body.Params {
+			request.Body.FormData = append(request.Body.FormData, toPostmanKeyValue(param))
+		}
+	default:
+		request.Body = &PostmanBody{Mode: "raw", Raw: toPostmanVariables(body.Text)}
+	}
+	return request
+}
+
+// getInsomniaPostmanAuth converts the authentication of a request or a request group. Nil is returned if the
+// authentication is inherited.
+func getInsomniaPostmanAuth(authentication map[string]interface{}) *PostmanAuth {
+	attribute := func(name string) PostmanKeyValue {
+		value, _ := authentication[name].(string)
+		return PostmanKeyValue{Key: name, Value: PostmanText(toPostmanVariables(value)), Type: "string"}
+	}
+	authType, _ := authentication["type"].(string)
+	switch authType {
+	case "oauth2":
+		return &PostmanAuth{Type: "oauth2", OAuth2: []PostmanKeyValue{attribute("accessTokenUrl"),
+			attribute("clientId"), attribute("clientSecret"), attribute("scope")}}
+	case "bearer":
+		return &PostmanAuth{Type: "bearer", Bearer: []PostmanKeyValue{attribute("token")}}
+	case "basic":
+		return &PostmanAuth{Type: "basic", Basic: []PostmanKeyValue{attribute("username"), attribute("password")}}
+	case "apikey":
+		in := PostmanKeyValue{Key: "in", Value: "header", Type: "string"}
+		if addTo, _ := authentication["addTo"].(string); addTo == "queryParams" {
+			in.Value = "query"
+		}
+		return &PostmanAuth{Type: "apikey", APIKey: []PostmanKeyValue{attribute("key"), attribute("value"), in}}
+	case "none":
+		return &PostmanAuth{Type: "noauth"}
+	}
+	return nil
+}
+
+// ConvertPostmanToInsomnia converts a Postman collection to an Insomnia export of the format v4. The variables of
+// the collection become the base environment and the folders become request groups. Requests are given the
+// authentication they inherit from the collection and their folders.
+func ConvertPostmanToInsomnia(collection *PostmanCollection) *InsomniaExport {
+	workspaceID := getInsomniaID("wrk", collection.Info.Name)
+	export := &InsomniaExport{Type: insomniaExportType, ExportFormat: insomniaExportFormat,
+		ExportSource: utils.ProjectName}
+	export.Resources = append(export.Resources, &InsomniaResource{ID: workspaceID, Type: insomniaWorkspaceType,
+		Name: collection.Info.Name, Description: string(collection.Info.Description), Scope: "collection"})
+
+	data := make(map[string]interface{})
+	for _, variable := range collection.Variable {
+		data[variable.Key] = string(variable.Value)
+	}
+	export.Resources = append(export.Resources, &InsomniaResource{ID: getInsomniaID("env", collection.Info.Name),
+		Type: insomniaEnvironmentType, ParentID: &workspaceID, Name: "Base Environment", Data: data})
+	addInsomniaResources(export, collection.Item, workspaceID, collection.Auth)
+	return export
+}
+
+// addInsomniaResources adds the request groups and the requests of the items under a parent recursively
+func addInsomniaResources(export *InsomniaExport, items []*PostmanItem, parentID string, auth *PostmanAuth) {
+	for _, item := range items {
+		itemAuth := auth
+		if item.Auth != nil {
+			itemAuth = item.Auth
+		}
+		id := getInsomniaID("req", parentID, item.Name, fmt.Sprint(len(export.Resources)))
+		if item.Request == nil {
+			id = getInsomniaID("fld", parentID, item.Name, fmt.Sprint(len(export.Resources)))
+			export.Resources = append(export.Resources, &InsomniaResource{ID: id, Type: insomniaRequestGroupType,
+				ParentID: &parentID, Name: item.Name, Description: string(item.Description)})
+			addInsomniaResources(export, item.Item, id, itemAuth)
+			continue
+		}
+		if item.Request.Auth != nil {
+			itemAuth = item.Request.Auth
+		}
+		resource := buildInsomniaRequest(item.Request, itemAuth)
+		resource.ID, resource.ParentID, resource.Name = id, &parentID, item.Name
+		if resource.Description == "" {
+			resource.Description = string(item.Description)
+		}
+		export.Resources = append(export.Resources, resource)
+	}
+}
+
+// buildInsomniaRequest builds a request of an export from a request of a collection
+func buildInsomniaRequest(request *PostmanRequest, auth *PostmanAuth) *InsomniaResource {
+	resource := &InsomniaResource{Type: insomniaRequestType, Method: request.Method,
+		Description: string(request.Description), Parameters: []InsomniaKeyValue{}, Headers: []InsomniaKeyValue{}}
+	resource.URL = request.URL.Raw
+	if index := strings.IndexAny(resource.URL, "?#"); index >= 0 {
+		resource.URL = resource.URL[:index]
+	}
+	resource.URL = toInsomniaVariables(resource.URL)
+	for _, query := range getPostmanQuery(request.URL) {
+		resource.Parameters = append(resource.Parameters, toInsomniaKeyValue(query))
+	}
+	for _, variable := range request.URL.Variable {
+		resource.PathParameters = append(resource.PathParameters, toInsomniaKeyValue(variable))
+	}
+	contentType := ""
+	for _, header := range request.Header {
+		if strings.EqualFold(header.Key, utils.HeaderContentType) && !header.Disabled {
+			contentType = string(header.Value)
+		}
+		resource.Headers = append(resource.Headers, toInsomniaKeyValue(header))
+	}
+
+	if body := request.Body; body != nil {
+		switch body.Mode {
+		case "raw":
+			if contentType == "" {
+				contentType = "text/plain"
+				if body.Options != nil && body.Options.Raw.Language == "json" {
+					contentType = utils.HeaderValueApplicationJSON
+				} else if body.Options != nil && body.Options.Raw.Language == "xml" {
+					contentType = "application/xml"
+				}
+			}
+			resource.Body = &InsomniaBody{MimeType: contentType, Text: toInsomniaVariables(body.Raw)}
+		case "urlencoded":
+			resource.Body = &InsomniaBody{MimeType: utils.HeaderValueXWWWFormUrlEncoded}
+			for _, field := range body.URLEncoded {
+				resource.Body.Params = append(resource.Body.Params, toInsomniaKeyValue(field))
+			}
+		case "formdata":
+			resource.Body = &InsomniaBody{MimeType: "multipart/form-data"}
+			for _, field := range body.FormData {
+				resource.Body.Params = append(resource.Body.Params, toInsomniaKeyValue(field))
+			}
+		}
+	}
+	resource.Authentication = getInsomniaAuthentication(auth)
+	return resource
+}
+
+// getInsomniaAuthentication converts the authentication of a collection. The authentications not supported by
+// Insomnia are left empty.
+func getInsomniaAuthentication(auth *PostmanAuth) map[string]interface{} {
+	if auth == nil {
+		return nil
+	}
+	attribute := func(name string) string {
+		return toInsomniaVariables(auth.attribute(name))
+	}
+	switch auth.Type {
+	case "oauth2":
+		authentication := map[string]interface{}{"type": "oauth2", "grantType": "client_credentials",
+			"accessTokenUrl": attribute("accessTokenUrl"), "clientId": attribute("clientId"),
+			"clientSecret": attribute("clientSecret"), "scope": attribute("scope"),
+			"credentialsInBody": auth.attribute("client_authentication") == "body"}
+		if grantType := auth.attribute("grant_type"); grantType != "" {
+			authentication["grantType"] = grantType
+		}
+		return authentication
+	case "bearer":
+		return map[string]interface{}{"type": "bearer", "token": attribute("token")}
+	case "basic":
+		return map[string]interface{}{"type": "basic", "username": attribute("username"),
+			"password": attribute("password")}
+	case "apikey":
+		addTo := "header"
+		if auth.attribute("in") == "query" {
+			addTo = "queryParams"
+		}
+		return map[string]interface{}{"type": "apikey", "key": attribute("key"), "value": attribute("value"),
+			"addTo": addTo}
+	case "noauth":
+		return map[string]interface{}{"type": "none"}
+	}
+	return nil
+}
+
+// getInsomniaID returns an identifier of a resource derived from its parts, so that exporting an API again updates
+// the resources imported to Insomnia instead of duplicating them
+func getInsomniaID(prefix string, parts ...string) string {
+	hash := sha1.Sum([]byte(strings.Join(parts, "/")))
+	return prefix + "_" + hex.EncodeToString(hash[:16])
+}
+
+// toInsomniaVariables replaces the variables of a collection with the variables of the base environment
+func toInsomniaVariables(value string) string {
+	return postmanVariableRegex.ReplaceAllString(value, "{{ _.$1 }}")
+}
+
+// toPostmanVariables replaces the variables of the base environment with the variables of a collection
+func toPostmanVariables(value string) string {
+	return insomniaVariableRegex.ReplaceAllString(value, "{{$1}}")
+}
+
+func toInsomniaKeyValue(value PostmanKeyValue) InsomniaKeyValue {
+	keyValue := InsomniaKeyValue{Name: value.Key, Value: toInsomniaVariables(string(value.Value)),
+		Description: string(value.Description), Disabled: value.Disabled}
+	if value.Type == "file" {
+		keyValue.Type = "file"
+	}
+	return keyValue
+}
+
+func toPostmanKeyValue(value InsomniaKeyValue) PostmanKeyValue {
+	keyValue := PostmanKeyValue{Key: value.Name, Value: PostmanText(toPostmanVariables(value.Value)),
+		Description: PostmanText(value.Description), Disabled: value.Disabled}
+	if value.Type == "file" {
+		keyValue.Type = "file"
+	}
+	return keyValue
+}
+
+// WriteInsomniaExport writes a collection as an Insomnia export to the destination directory as
+// <name>_<version>.insomnia.json and returns the path of the file
+func WriteInsomniaExport(collection *PostmanCollection, destination string) (string, error) {
+	if err := utils.CreateDirIfNotExist(destination); err != nil {
+		return "", err
+	}
+	content, err := json.MarshalIndent(ConvertPostmanToInsomnia(collection), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(destination, strings.ReplaceAll(collection.Info.Name, " ", "_")+".insomnia.json")
+	utils.Logln(utils.LogPrefixInfo + "Writing " + path)
+	return path, ioutil.WriteFile(path, content, os.ModePerm)
+}
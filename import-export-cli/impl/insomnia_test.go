@@ -0,0 +1,106 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertPostmanToInsomnia(t *testing.T) {
+	collection, err := ParsePostmanCollection([]byte(postmanTestCollection))
+	assert.Nil(t, err, "Should parse the collection")
+	export := ConvertPostmanToInsomnia(collection)
+	assert.Equal(t, "export", export.Type)
+	assert.Equal(t, 4, export.ExportFormat)
+
+	resources := make(map[string]*InsomniaResource)
+	for _, resource := range export.Resources {
+		resources[resource.Type+"/"+resource.Name] = resource
+	}
+	workspace := resources["workspace/PizzaShack"]
+	assert.NotNil(t, workspace)
+	environment := resources["environment/Base Environment"]
+	assert.Equal(t, workspace.ID, *environment.ParentID)
+	assert.Equal(t, "https://pizza.example.com/api", environment.Data["baseUrl"],
+		"Should add the variables to the base environment")
+
+	menu := resources["request/List menu"]
+	assert.Equal(t, resources["request_group/Menu"].ID, *menu.ParentID, "Should convert the folders to request groups")
+	assert.Equal(t, "{{ _.baseUrl }}/menu", menu.URL)
+	assert.Equal(t, []InsomniaKeyValue{{Name: "limit", Value: "10"}}, menu.Parameters)
+	assert.Equal(t, "oauth2", menu.Authentication["type"], "Should inherit the authentication of the collection")
+	assert.Equal(t, "{{ _.tokenUrl }}", menu.Authentication["accessTokenUrl"])
+
+	order := resources["request/Get order"]
+	assert.Equal(t, "none", order.Authentication["type"])
+	assert.Equal(t, []InsomniaKeyValue{{Name: "orderId", Value: "12"}}, order.PathParameters)
+
+	create := resources["request/Create order"]
+	assert.Equal(t, "application/json", create.Body.MimeType)
+}
+
+func TestParseInsomniaExport(t *testing.T) {
+	collection, err := ParsePostmanCollection([]byte(postmanTestCollection))
+	assert.Nil(t, err, "Should parse the collection")
+	path, err := WriteInsomniaExport(collection, t.TempDir())
+	assert.Nil(t, err, "Should write the export")
+	assert.Equal(t, "PizzaShack.insomnia.json", filepath.Base(path))
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+
+	parsed, err := ParseRequestCollection(content)
+	assert.Nil(t, err, "Should parse the Insomnia export")
+	definition, err := BuildPostmanOpenAPI(parsed)
+	assert.Nil(t, err, "Should infer the OpenAPI definition")
+	doc, err := loadOpenAPI3(definition, "PizzaShack.insomnia.json")
+	assert.Nil(t, err, "Should infer a valid OpenAPI definition")
+	assert.Nil(t, doc.Validate(context.Background()), "Should infer a valid OpenAPI definition")
+
+	assert.Equal(t, "https://pizza.example.com", doc.Servers[0].URL)
+	menu := doc.Paths.Find("/api/menu").Get
+	assert.Equal(t, []string{"Menu"}, menu.Tags, "Should tag the operations by the request groups")
+	assert.NotNil(t, menu.Parameters.GetByInAndName("query", "limit"))
+	assert.NotNil(t, doc.Components.SecuritySchemes["default"])
+	order := doc.Paths.Find("/api/order/{orderId}").Get
+	assert.NotNil(t, order.Parameters.GetByInAndName("path", "orderId"))
+	assert.Equal(t, 0, len(*order.Security))
+	create := doc.Paths.Find("/api/order").Post
+	assert.NotNil(t, create.RequestBody.Value.Content.Get("application/json"))
+
+	_, err = ParseInsomniaExport([]byte(`{"_type": "export", "__export_format": 3, "resources": []}`))
+	assert.Error(t, err, "Should not parse the exports of the other formats")
+}
+
+func TestInitAPIProjectFromInsomnia(t *testing.T) {
+	dir := t.TempDir()
+	collection, err := ParsePostmanCollection([]byte(postmanTestCollection))
+	assert.Nil(t, err, "Should parse the collection")
+	exportPath, err := WriteInsomniaExport(collection, dir)
+	assert.Nil(t, err, "Should write the export")
+	projectPath := filepath.Join(dir, "PizzaShack")
+
+	err = InitAPIProject(projectPath, "", exportPath, InitSourcePostman, "", false)
+	assert.Nil(t, err, "Should initialize the project from the Insomnia export")
+	assert.FileExists(t, filepath.Join(projectPath, "Definitions", "swagger.yaml"))
+}
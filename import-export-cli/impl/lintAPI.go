@@ -0,0 +1,617 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/loads"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Severities of the lint violations
+const (
+	LintSeverityError = "ERROR"
+	LintSeverityWarn  = "WARN"
+	LintSeverityInfo  = "INFO"
+)
+
+const (
+	lintSchemaPolicy          = "Schema Validation"
+	lintDefinitionPolicy      = "API Definition Validation"
+	lintDefaultRulesPolicy    = "Lint Rules"
+	lintRulesetTypeMetadata   = "API_METADATA"
+	lintRulesetTypeDefinition = "API_DEFINITION"
+	lintAPIType               = "api"
+	lintDeploymentEnvsType    = "deployment_environments"
+)
+
+// LintRuleSet represents the configurable rules read from a lint rules file. A rule is only evaluated when it is
+// defined in the file.
+type LintRuleSet struct {
+	// Name is shown as the policy of the violations reported by the rules
+	Name  string    `yaml:"name"`
+	Rules LintRules `yaml:"rules"`
+}
+
+// LintRules contains the supported lint rules
+type LintRules struct {
+	Naming              *LintNamingRule              `yaml:"naming"`
+	SecuritySchemes     *LintSecuritySchemesRule     `yaml:"securitySchemes"`
+	ProductionEndpoints *LintProductionEndpointsRule `yaml:"productionEndpoints"`
+	Resources           *LintResourcesRule           `yaml:"resources"`
+}
+
+// LintNamingRule checks the name, context and version of the API against regular expressions
+type LintNamingRule struct {
+	Severity string `yaml:"severity"`
+	Name     string `yaml:"name"`
+	Context  string `yaml:"context"`
+	Version  string `yaml:"version"`
+}
+
+// LintSecuritySchemesRule checks the security schemes of the API and its operations
+type LintSecuritySchemesRule struct {
+	Severity                 string   `yaml:"severity"`
+	Required                 []string `yaml:"required"`
+	AllowUnsecuredOperations bool     `yaml:"allowUnsecuredOperations"`
+}
+
+// LintProductionEndpointsRule checks the endpoints of the production environments in the params file
+type LintProductionEndpointsRule struct {
+	Severity string `yaml:"severity"`
+	// Environments considered as production. All the environments in the params file are checked if empty.
+	Environments []string `yaml:"environments"`
+	RequireHTTPS bool     `yaml:"requireHttps"`
+}
+
+// LintResourcesRule checks the number of resources of the API
+type LintResourcesRule struct {
+	Severity string `yaml:"severity"`
+	Max      int    `yaml:"max"`
+}
+
+// apiLintProject holds the content of an API project required by the lint rules
+type apiLintProject struct {
+	definition          *v2.APIDefinitionFile
+	operations          []map[interface{}]interface{}
+	paramsEnvs          []*params.Environment
+	definitionResources int
+}
+
+// apiLintRule is a configurable rule evaluated against a loaded API project
+type apiLintRule struct {
+	name    string
+	rsType  string
+	check   func(project *apiLintProject, rules *LintRules) []RuleViolation
+	enabled func(rules *LintRules) bool
+}
+
+// apiLintRules contains the configurable rules in the order they are evaluated
+var apiLintRules = []apiLintRule{
+	{
+		name:    "naming",
+		rsType:  lintRulesetTypeMetadata,
+		check:   lintNaming,
+		enabled: func(rules *LintRules) bool { return rules.Naming != nil },
+	},
+	{
+		name:    "securitySchemes",
+		rsType:  lintRulesetTypeMetadata,
+		check:   lintSecuritySchemes,
+		enabled: func(rules *LintRules) bool { return rules.SecuritySchemes != nil },
+	},
+	{
+		name:    "productionEndpoints",
+		rsType:  lintRulesetTypeMetadata,
+		check:   lintProductionEndpoints,
+		enabled: func(rules *LintRules) bool { return rules.ProductionEndpoints != nil },
+	},
+	{
+		name:    "resources",
+		rsType:  lintRulesetTypeDefinition,
+		check:   lintResources,
+		enabled: func(rules *LintRules) bool { return rules.Resources != nil },
+	},
+}
+
+// LoadLintRuleSet loads and validates the lint rules file in the given path
+// @param rulesFilePath : Path to the lint rules file
+// @return lint rule set
+// @return error
+func LoadLintRuleSet(rulesFilePath string) (*LintRuleSet, error) {
+	content, err := ioutil.ReadFile(rulesFilePath)
+	if err != nil {
+		return nil, err
+	}
+	ruleSet := &LintRuleSet{}
+	if err := yaml.UnmarshalStrict(content, ruleSet); err != nil {
+		return nil, errors.New("Invalid lint rules file " + rulesFilePath + ": " + err.Error())
+	}
+	if ruleSet.Name == "" {
+		ruleSet.Name = lintDefaultRulesPolicy
+	}
+
+	rules := &ruleSet.Rules
+	severities := map[string]*string{}
+	if rules.Naming != nil {
+		for _, expr := range []string{rules.Naming.Name, rules.Naming.Context, rules.Naming.Version} {
+			if _, err := regexp.Compile(expr); err != nil {
+				return nil, errors.New("Invalid naming rule expression " + expr + ": " + err.Error())
+			}
+		}
+		severities["naming"] = &rules.Naming.Severity
+	}
+	if rules.SecuritySchemes != nil {
+		severities["securitySchemes"] = &rules.SecuritySchemes.Severity
+	}
+	if rules.ProductionEndpoints != nil {
+		severities["productionEndpoints"] = &rules.ProductionEndpoints.Severity
+	}
+	if rules.Resources != nil {
+		if rules.Resources.Max <= 0 {
+			return nil, errors.New("The max value of the resources rule should be a positive number")
+		}
+		severities["resources"] = &rules.Resources.Severity
+	}
+	for rule, severity := range severities {
+		normalized, err := normalizeLintSeverity(*severity)
+		if err != nil {
+			return nil, errors.New("Invalid severity of the " + rule + " rule: " + err.Error())
+		}
+		*severity = normalized
+	}
+	return ruleSet, nil
+}
+
+// normalizeLintSeverity converts the severity given in the rules file to the severity used in violations.
+// ERROR is used if the severity is not given.
+func normalizeLintSeverity(severity string) (string, error) {
+	switch strings.ToUpper(severity) {
+	case "", LintSeverityError:
+		return LintSeverityError, nil
+	case LintSeverityWarn, "WARNING":
+		return LintSeverityWarn, nil
+	case LintSeverityInfo:
+		return LintSeverityInfo, nil
+	}
+	return "", errors.New("unsupported severity " + severity)
+}
+
+// LintAPIProject validates an API project without contacting API Manager
+// @param projectPath : Path to the API project directory or archive
+// @param paramsPath : Path to the params file or deployment directory of the API (optional)
+// @param ruleSet : Configurable rules to be evaluated (optional)
+// @return violations of the project grouped by the policies
+// @return error
+func LintAPIProject(projectPath, paramsPath string, ruleSet *LintRuleSet) ([]Violation, error) {
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(tmpPath))
+
+	violations := []Violation{}
+	project := &apiLintProject{}
+
+	schemaRulesets, err := lintAPISchemas(tmpPath, project)
+	if err != nil {
+		return nil, err
+	}
+	violations = appendLintViolation(violations, lintSchemaPolicy, schemaRulesets)
+
+	definitionRulesets, err := lintAPIDefinition(tmpPath, project)
+	if err != nil {
+		return nil, err
+	}
+	violations = appendLintViolation(violations, lintDefinitionPolicy, definitionRulesets)
+
+	if ruleSet == nil {
+		return violations, nil
+	}
+	if paramsPath != "" {
+		var apiParams *params.ApiParams
+		if strings.Contains(paramsPath, ".yaml") {
+			apiParams, err = params.LoadApiParamsFromFile(paramsPath)
+		} else {
+			apiParams, err = params.LoadApiParamsFromDirectory(paramsPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the environments are linted with the configs inherited from the base configs
+		for _, env := range apiParams.Environments {
+			effectiveEnv, err := apiParams.GetEffectiveEnv(env.Name)
+			if err != nil {
+				return nil, err
+			}
+			project.paramsEnvs = append(project.paramsEnvs, effectiveEnv)
+		}
+	}
+	var ruleRulesets []Ruleset
+	for _, rule := range apiLintRules {
+		if !rule.enabled(&ruleSet.Rules) {
+			continue
+		}
+		utils.Logln(utils.LogPrefixInfo + "Evaluating lint rule " + rule.name)
+		if ruleViolations := rule.check(project, &ruleSet.Rules); len(ruleViolations) > 0 {
+			ruleRulesets = append(ruleRulesets, Ruleset{Ruleset: rule.name, Type: rule.rsType,
+				RuleViolations: ruleViolations})
+		}
+	}
+	return appendLintViolation(violations, ruleSet.Name, ruleRulesets), nil
+}
+
+// appendLintViolation adds a violation for the policy if the policy has rulesets with violations
+func appendLintViolation(violations []Violation, policy string, rulesets []Ruleset) []Violation {
+	if len(rulesets) == 0 {
+		return violations
+	}
+	return append(violations, Violation{Policy: policy, Rulesets: rulesets})
+}
+
+// CountLintViolations returns the number of rule violations with the given severity
+func CountLintViolations(violations []Violation, severity string) int {
+	count := 0
+	for _, violation := range violations {
+		for _, ruleset := range violation.Rulesets {
+			for _, ruleViolation := range ruleset.RuleViolations {
+				if ruleViolation.Severity == severity {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// lintAPISchemas validates the api.yaml, api_meta.yaml and deployment_environments.yaml files of the project
+// against their types and loads the content required by the rules to the project
+func lintAPISchemas(apiPath string, project *apiLintProject) ([]Ruleset, error) {
+	var rulesets []Ruleset
+
+	apiFilePath, _, err := resolveYamlOrJSON(filepath.Join(apiPath, "api"))
+	if err != nil {
+		return nil, err
+	}
+	apiFile := filepath.Base(apiFilePath)
+	content, err := ioutil.ReadFile(apiFilePath)
+	if err != nil {
+		return nil, err
+	}
+	project.definition = &v2.APIDefinitionFile{}
+	violations := unmarshalLintFile(apiFile, content, project.definition)
+	if project.definition.Type != "" && project.definition.Type != lintAPIType {
+		violations = append(violations, newLintViolation("type", LintSeverityError,
+			"Type should be "+lintAPIType+" but found "+project.definition.Type))
+	}
+	data := project.definition.Data
+	for _, field := range []struct{ path, value string }{
+		{"data.name", data.Name},
+		{"data.version", data.Version},
+		{"data.context", data.Context},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			violations = append(violations, newLintViolation(field.path, LintSeverityError, field.path+" is required"))
+		}
+	}
+	if data.Context != "" && !strings.HasPrefix(data.Context, "/") {
+		violations = append(violations, newLintViolation("data.context", LintSeverityError,
+			"Context should start with /"))
+	}
+	project.operations = getLintOperations(content)
+	for i, operation := range project.operations {
+		if operation["target"] == nil || operation["verb"] == nil {
+			violations = append(violations, newLintViolation("data.operations["+strconv.Itoa(i)+"]",
+				LintSeverityError, "Operation should have a target and a verb"))
+		}
+	}
+	rulesets = appendLintRuleset(rulesets, apiFile, lintRulesetTypeMetadata, violations)
+
+	metaFilePath := filepath.Join(apiPath, utils.MetaFileAPI)
+	if utils.IsFileExist(metaFilePath) {
+		content, err := ioutil.ReadFile(metaFilePath)
+		if err != nil {
+			return nil, err
+		}
+		metaData := &utils.MetaData{}
+		violations := unmarshalLintFile(utils.MetaFileAPI, content, metaData)
+		if metaData.Name != "" && metaData.Name != data.Name {
+			violations = append(violations, newLintViolation("name", LintSeverityWarn,
+				"Name "+metaData.Name+" does not match the API name "+data.Name))
+		}
+		if metaData.Version != "" && metaData.Version != data.Version {
+			violations = append(violations, newLintViolation("version", LintSeverityWarn,
+				"Version "+metaData.Version+" does not match the API version "+data.Version))
+		}
+		rulesets = appendLintRuleset(rulesets, utils.MetaFileAPI, lintRulesetTypeMetadata, violations)
+	}
+
+	deploymentEnvsFilePath := filepath.Join(apiPath, utils.DeploymentEnvFile)
+	if utils.IsFileExist(deploymentEnvsFilePath) {
+		content, err := ioutil.ReadFile(deploymentEnvsFilePath)
+		if err != nil {
+			return nil, err
+		}
+		deploymentEnvs := &v2.DeploymentEnvironmentsFile{}
+		violations := unmarshalLintFile(utils.DeploymentEnvFile, content, deploymentEnvs)
+		if deploymentEnvs.Type != "" && deploymentEnvs.Type != lintDeploymentEnvsType {
+			violations = append(violations, newLintViolation("type", LintSeverityError,
+				"Type should be "+lintDeploymentEnvsType+" but found "+deploymentEnvs.Type))
+		}
+		for i, env := range deploymentEnvs.Data {
+			if env.DeploymentEnvironment == "" {
+				violations = append(violations, newLintViolation("data["+strconv.Itoa(i)+"].deploymentEnvironment",
+					LintSeverityError, "deploymentEnvironment is required"))
+			}
+		}
+		rulesets = appendLintRuleset(rulesets, utils.DeploymentEnvFile, lintRulesetTypeMetadata, violations)
+	}
+	return rulesets, nil
+}
+
+// unmarshalLintFile unmarshals the file content to the given type and returns the type mismatches as violations
+func unmarshalLintFile(file string, content []byte, out interface{}) []RuleViolation {
+	err := yaml.Unmarshal(content, out)
+	if err == nil {
+		return nil
+	}
+	var violations []RuleViolation
+	if typeError, ok := err.(*yaml.TypeError); ok {
+		for _, message := range typeError.Errors {
+			violations = append(violations, newLintViolation(file, LintSeverityError, message))
+		}
+		return violations
+	}
+	return append(violations, newLintViolation(file, LintSeverityError, err.Error()))
+}
+
+// getLintOperations returns the operations in the api.yaml content
+func getLintOperations(content []byte) []map[interface{}]interface{} {
+	apiFile := struct {
+		Data struct {
+			Operations []map[interface{}]interface{} `yaml:"operations"`
+		} `yaml:"data"`
+	}{}
+	if err := yaml.Unmarshal(content, &apiFile); err != nil {
+		return nil
+	}
+	return apiFile.Data.Operations
+}
+
+// lintAPIDefinition validates the Swagger 2 or OpenAPI 3 definition of the project
+func lintAPIDefinition(apiPath string, project *apiLintProject) ([]Ruleset, error) {
+	definitionPath, content, err := resolveYamlOrJSON(filepath.Join(apiPath, utils.InitProjectDefinitions, "swagger"))
+	if err != nil {
+		apiType := strings.ToUpper(project.definition.Data.Type)
+		if apiType == "" || apiType == "HTTP" {
+			return []Ruleset{{Ruleset: utils.InitProjectDefinitions, Type: lintRulesetTypeDefinition,
+				RuleViolations: []RuleViolation{newLintViolation(utils.InitProjectDefinitionsSwagger,
+					LintSeverityError, "API definition is not available in the project")}}}, nil
+		}
+		// Definitions of the other API types are not OpenAPI definitions
+		return nil, nil
+	}
+	definitionFile := filepath.ToSlash(filepath.Join(utils.InitProjectDefinitions, filepath.Base(definitionPath)))
+
+	var version struct {
+		Swagger string `json:"swagger"`
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(content, &version); err != nil {
+		return nil, err
+	}
+
+	var doc *openapi3.T
+	var violations []RuleViolation
+	switch {
+	case version.Swagger != "":
+		if _, err := loads.Analyzed(json.RawMessage(content), version.Swagger); err != nil {
+			violations = append(violations, newLintViolation(definitionFile, LintSeverityError, err.Error()))
+			break
+		}
+		var swaggerDoc openapi2.T
+		if err := json.Unmarshal(content, &swaggerDoc); err != nil {
+			violations = append(violations, newLintViolation(definitionFile, LintSeverityError, err.Error()))
+			break
+		}
+		if doc, err = openapi2conv.ToV3(&swaggerDoc); err != nil {
+			violations = append(violations, newLintViolation(definitionFile, LintSeverityError, err.Error()))
+		}
+	case version.OpenAPI != "":
+		loader := openapi3.NewLoader()
+		if doc, err = loader.LoadFromData(content); err != nil {
+			violations = append(violations, newLintViolation(definitionFile, LintSeverityError, err.Error()))
+		}
+	default:
+		violations = append(violations, newLintViolation(definitionFile, LintSeverityError,
+			"Definition should be either a Swagger 2 or an OpenAPI 3 definition"))
+	}
+
+	if doc != nil {
+		if err := doc.Validate(context.Background()); err != nil {
+			violations = append(violations, newLintViolation(definitionFile, LintSeverityError, err.Error()))
+		}
+		if doc.Paths != nil {
+			for _, pathItem := range doc.Paths.Map() {
+				project.definitionResources += len(pathItem.Operations())
+			}
+		}
+	}
+	return appendLintRuleset(nil, definitionFile, lintRulesetTypeDefinition, violations), nil
+}
+
+// appendLintRuleset adds a ruleset for the file if there are violations
+func appendLintRuleset(rulesets []Ruleset, file, rulesetType string, violations []RuleViolation) []Ruleset {
+	if len(violations) == 0 {
+		return rulesets
+	}
+	return append(rulesets, Ruleset{Ruleset: file, Type: rulesetType, RuleViolations: violations})
+}
+
+func newLintViolation(path, severity, message string) RuleViolation {
+	return RuleViolation{Path: path, Message: message, Severity: severity}
+}
+
+// lintNaming checks the name, context and version of the API against the naming rule
+func lintNaming(project *apiLintProject, rules *LintRules) []RuleViolation {
+	rule := rules.Naming
+	data := project.definition.Data
+	var violations []RuleViolation
+	for _, check := range []struct{ field, value, expr string }{
+		{"data.name", data.Name, rule.Name},
+		{"data.context", data.Context, rule.Context},
+		{"data.version", data.Version, rule.Version},
+	} {
+		if check.expr == "" {
+			continue
+		}
+		if !regexp.MustCompile(check.expr).MatchString(check.value) {
+			violations = append(violations, newLintViolation(check.field, rule.Severity,
+				fmt.Sprintf("%q does not match the pattern %s", check.value, check.expr)))
+		}
+	}
+	return violations
+}
+
+// lintSecuritySchemes checks whether the required security schemes are enabled and the operations are secured
+func lintSecuritySchemes(project *apiLintProject, rules *LintRules) []RuleViolation {
+	rule := rules.SecuritySchemes
+	var violations []RuleViolation
+	for _, required := range rule.Required {
+		found := false
+		for _, scheme := range project.definition.Data.SecurityScheme {
+			if strings.EqualFold(scheme, required) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violations = append(violations, newLintViolation("data.securityScheme", rule.Severity,
+				"Security scheme "+required+" is required"))
+		}
+	}
+	if !rule.AllowUnsecuredOperations {
+		for i, operation := range project.operations {
+			if authType, ok := operation["authType"].(string); ok && strings.EqualFold(authType, "None") {
+				violations = append(violations, newLintViolation("data.operations["+strconv.Itoa(i)+"].authType",
+					rule.Severity, fmt.Sprintf("Operation %v %v is not secured", operation["verb"],
+						operation["target"])))
+			}
+		}
+	}
+	return violations
+}
+
+// lintProductionEndpoints checks the endpoints of the production environments in the params file
+func lintProductionEndpoints(project *apiLintProject, rules *LintRules) []RuleViolation {
+	rule := rules.ProductionEndpoints
+	if len(project.paramsEnvs) == 0 || !rule.RequireHTTPS {
+		return nil
+	}
+	var violations []RuleViolation
+	for _, env := range project.paramsEnvs {
+		if len(rule.Environments) > 0 && !isLintProductionEnv(rule.Environments, env.Name) {
+			continue
+		}
+		endpoints, ok := env.Config["endpoints"]
+		if !ok {
+			continue
+		}
+		urls := getLintEndpointURLs("environments."+env.Name+".configs.endpoints", endpoints)
+		paths := make([]string, 0, len(urls))
+		for path := range urls {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			if url := urls[path]; !strings.HasPrefix(strings.ToLower(url), "https://") {
+				violations = append(violations, newLintViolation(path, rule.Severity,
+					"Endpoint "+url+" of a production environment should use HTTPS"))
+			}
+		}
+	}
+	return violations
+}
+
+func isLintProductionEnv(environments []string, name string) bool {
+	for _, env := range environments {
+		if env == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getLintEndpointURLs returns the urls in the endpoints of the params file with their paths
+func getLintEndpointURLs(path string, value interface{}) map[string]string {
+	urls := map[string]string{}
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		for key, child := range v {
+			keyStr := fmt.Sprint(key)
+			if url, ok := child.(string); ok && keyStr == "url" {
+				if url != "" {
+					urls[path+"."+keyStr] = url
+				}
+				continue
+			}
+			for childPath, url := range getLintEndpointURLs(path+"."+keyStr, child) {
+				urls[childPath] = url
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			for childPath, url := range getLintEndpointURLs(path+"["+strconv.Itoa(i)+"]", child) {
+				urls[childPath] = url
+			}
+		}
+	}
+	return urls
+}
+
+// lintResources checks the number of resources of the API against the maximum allowed
+func lintResources(project *apiLintProject, rules *LintRules) []RuleViolation {
+	rule := rules.Resources
+	count := len(project.operations)
+	if count == 0 {
+		count = project.definitionResources
+	}
+	if count <= rule.Max {
+		return nil
+	}
+	return []RuleViolation{newLintViolation("data.operations", rule.Severity,
+		fmt.Sprintf("API has %d resources but only %d are allowed", count, rule.Max))}
+}
@@ -0,0 +1,155 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const lintTestSwagger = `openapi: 3.0.1
+info:
+  title: PizzaShackAPI
+  version: 1.0.0
+paths:
+  /menu:
+    get:
+      responses:
+        "200":
+          description: OK
+  /order:
+    post:
+      responses:
+        "201":
+          description: Created
+`
+
+func writeLintTestProject(t *testing.T, apiYaml, swagger string) string {
+	t.Helper()
+	projectPath := filepath.Join(t.TempDir(), "PizzaShackAPI")
+	writeApplyTestFile(t, filepath.Join(projectPath, utils.APIDefinitionFileYaml), apiYaml)
+	writeApplyTestFile(t, filepath.Join(projectPath, utils.InitProjectDefinitionsSwagger), swagger)
+	return projectPath
+}
+
+func getLintRuleViolations(violations []Violation, policy, ruleset string) []RuleViolation {
+	for _, violation := range violations {
+		if violation.Policy != policy {
+			continue
+		}
+		for _, rs := range violation.Rulesets {
+			if rs.Ruleset == ruleset {
+				return rs.RuleViolations
+			}
+		}
+	}
+	return nil
+}
+
+func TestLintAPIProjectWithValidProject(t *testing.T) {
+	projectPath := writeLintTestProject(t, "type: api\nversion: v4.7.0\ndata:\n  name: PizzaShackAPI\n"+
+		"  context: /pizzashack\n  version: 1.0.0\n", lintTestSwagger)
+	writeApplyTestFile(t, filepath.Join(projectPath, utils.DeploymentEnvFile),
+		"type: deployment_environments\ndata:\n  - displayOnDevportal: true\n    deploymentEnvironment: Default\n")
+
+	violations, err := LintAPIProject(projectPath, "", nil)
+	assert.Nil(t, err, "Should return nil error for a valid project")
+	assert.Empty(t, violations, "Should not return violations for a valid project")
+}
+
+func TestLintAPIProjectSchemaAndDefinitionViolations(t *testing.T) {
+	projectPath := writeLintTestProject(t, "type: api\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n"+
+		"  transport: https\n", "openapi: 3.0.1\ninfo:\n  title: PizzaShackAPI\npaths: {}\n")
+	writeApplyTestFile(t, filepath.Join(projectPath, utils.DeploymentEnvFile),
+		"type: deployment_environments\ndata:\n  - displayOnDevportal: true\n")
+
+	violations, err := LintAPIProject(projectPath, "", nil)
+	assert.Nil(t, err, "Should return nil error for a project with violations")
+
+	apiViolations := getLintRuleViolations(violations, lintSchemaPolicy, utils.APIDefinitionFileYaml)
+	if assert.Len(t, apiViolations, 2, "Should report the type mismatch and the missing context") {
+		assert.Equal(t, utils.APIDefinitionFileYaml, apiViolations[0].Path)
+		assert.Equal(t, "data.context", apiViolations[1].Path)
+	}
+	deploymentViolations := getLintRuleViolations(violations, lintSchemaPolicy, utils.DeploymentEnvFile)
+	if assert.Len(t, deploymentViolations, 1) {
+		assert.Equal(t, "data[0].deploymentEnvironment", deploymentViolations[0].Path)
+	}
+	definitionViolations := getLintRuleViolations(violations, lintDefinitionPolicy, "Definitions/swagger.yaml")
+	assert.Len(t, definitionViolations, 1, "Should report the missing info.version of the definition")
+	assert.Equal(t, 4, CountLintViolations(violations, LintSeverityError))
+}
+
+func TestLintAPIProjectWithRules(t *testing.T) {
+	projectPath := writeLintTestProject(t, "type: api\ndata:\n  name: pizzaShack\n  context: /PizzaShack\n"+
+		"  version: 1.0.0\n  securityScheme:\n    - api_key\n  operations:\n    - target: /menu\n      verb: GET\n"+
+		"      authType: None\n    - target: /order\n      verb: POST\n      authType: Application & Application User\n",
+		lintTestSwagger)
+	paramsPath := filepath.Join(t.TempDir(), "params.yaml")
+	writeApplyTestFile(t, paramsPath, "environments:\n  - name: production\n    configs:\n      endpoints:\n"+
+		"        production:\n          url: http://prod.example.com\n        sandbox:\n"+
+		"          url: https://sandbox.example.com\n  - name: dev\n    configs:\n      endpoints:\n"+
+		"        production:\n          url: http://dev.example.com\n")
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	writeApplyTestFile(t, rulesPath, "name: Org Rules\nrules:\n  naming:\n    name: ^[A-Z][A-Za-z]*$\n"+
+		"    context: ^/[a-z]+$\n  securitySchemes:\n    severity: warn\n    required: [oauth2]\n"+
+		"  productionEndpoints:\n    environments: [production]\n    requireHttps: true\n"+
+		"  resources:\n    severity: info\n    max: 1\n")
+
+	ruleSet, err := LoadLintRuleSet(rulesPath)
+	if !assert.Nil(t, err, "Should load a valid rules file") {
+		return
+	}
+	violations, err := LintAPIProject(projectPath, paramsPath, ruleSet)
+	assert.Nil(t, err, "Should return nil error for a project with rule violations")
+
+	assert.Len(t, getLintRuleViolations(violations, "Org Rules", "naming"), 2)
+	securityViolations := getLintRuleViolations(violations, "Org Rules", "securitySchemes")
+	if assert.Len(t, securityViolations, 2, "Should report the missing scheme and the unsecured operation") {
+		assert.Equal(t, LintSeverityWarn, securityViolations[0].Severity)
+		assert.Equal(t, "data.operations[0].authType", securityViolations[1].Path)
+	}
+	endpointViolations := getLintRuleViolations(violations, "Org Rules", "productionEndpoints")
+	if assert.Len(t, endpointViolations, 1, "Only the http endpoints of production environments should be reported") {
+		assert.Equal(t, "environments.production.configs.endpoints.production.url", endpointViolations[0].Path)
+	}
+	resourceViolations := getLintRuleViolations(violations, "Org Rules", "resources")
+	if assert.Len(t, resourceViolations, 1) {
+		assert.Equal(t, LintSeverityInfo, resourceViolations[0].Severity)
+	}
+	assert.Equal(t, 3, CountLintViolations(violations, LintSeverityError))
+}
+
+func TestLoadLintRuleSetWithInvalidRules(t *testing.T) {
+	rulesDir := t.TempDir()
+	for name, content := range map[string]string{
+		"unknown.yaml":  "rules:\n  unknown:\n    severity: error\n",
+		"severity.yaml": "rules:\n  resources:\n    severity: fatal\n    max: 10\n",
+		"regexp.yaml":   "rules:\n  naming:\n    name: ^[A-Z\n",
+		"max.yaml":      "rules:\n  resources:\n    max: 0\n",
+	} {
+		rulesPath := filepath.Join(rulesDir, name)
+		writeApplyTestFile(t, rulesPath, content)
+		_, err := LoadLintRuleSet(rulesPath)
+		assert.Error(t, err, "Should return an error for the invalid rules file "+name)
+	}
+}
@@ -201,7 +201,7 @@ func PrintAPILoggers(apis []utils.APILogger, format string) {
 
 	// Execute context
 	if err := apiContext.Write(renderer, apiLoggerTableHeaders); err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
 	}
 }
 
@@ -324,7 +324,7 @@ func PrintCorrelationLoggers(components []utils.CorrelationComponent, format str
 	}
 
 	if err := formatContext.Write(renderer, correlationTableHeaders); err != nil {
-		fmt.Println("Error executing template", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template", err.Error())
 	}
 }
 func SetCorrelationLoggingComponent(credential credentials.Credential, environment, componentName, enabled, deniedThreads string) (*resty.Response, error) {
@@ -521,6 +521,6 @@ func PrintMCPServerLoggers(mcpServers []utils.MCPServerLogger, format string) {
 
 	// Execute context
 	if err := mcpServerContext.Write(renderer, mcpServerLoggerTableHeaders); err != nil {
-		fmt.Println("Error executing template: ", err.Error())
+		fmt.Fprintln(utils.Stdout, "Error executing template: ", err.Error())
 	}
 }
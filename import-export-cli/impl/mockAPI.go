@@ -0,0 +1,675 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+// maxMockSchemaDepth limits the nesting of the responses generated from recursive schemas
+const maxMockSchemaDepth = 6
+
+// MockOptions holds the user provided options of a mock server
+type MockOptions struct {
+	Host string
+	Port int
+	// Latency is added to every response. A random delay of up to Jitter is added on top of it.
+	Latency time.Duration
+	Jitter  time.Duration
+	// ErrorRate is the fraction of the requests that fail with ErrorStatus, between 0 and 1
+	ErrorRate   float64
+	ErrorStatus int
+	// SkipValidation disables validating the requests against the definition of the API
+	SkipValidation bool
+	// AccessLog receives a line for each request served, if set
+	AccessLog io.Writer
+}
+
+// MockServer serves responses generated from the Swagger, OpenAPI or GraphQL definition of an API project
+type MockServer struct {
+	Name      string
+	Version   string
+	Context   string
+	basePaths []string
+	options   MockOptions
+	doc       *openapi3.T
+	routes    []*mockRoute
+	graphQL   *v2.GraphQLSchema
+	random    *rand.Rand
+	mutex     sync.Mutex
+}
+
+// mockRoute matches the request paths of a resource of the API
+type mockRoute struct {
+	path     string
+	pattern  *regexp.Regexp
+	params   []string
+	pathItem *openapi3.PathItem
+}
+
+// mockError is the body of the errors returned by the mock server, in the format of the errors of the gateway
+type mockError struct {
+	Code        int    `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewMockServer creates a mock server for the API project or archive at projectPath. GraphQL APIs are served from
+// Definitions/schema.graphql and the others from Definitions/swagger.yaml.
+func NewMockServer(projectPath string, options MockOptions) (*MockServer, error) {
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(filepath.Dir(tmpPath))
+
+	content, err := ioutil.ReadFile(filepath.Join(tmpPath, utils.APIDefinitionFileYaml))
+	if err != nil {
+		return nil, fmt.Errorf("error reading the API definition of %s: %w", projectPath, err)
+	}
+	definition := &v2.APIDefinitionFile{}
+	if err := yaml2.Unmarshal(content, definition); err != nil {
+		return nil, fmt.Errorf("invalid API definition in %s: %w", projectPath, err)
+	}
+	if options.ErrorStatus == 0 {
+		options.ErrorStatus = http.StatusInternalServerError
+	}
+	server := &MockServer{
+		Name:      definition.Data.Name,
+		Version:   definition.Data.Version,
+		Context:   definition.Data.Context,
+		basePaths: getMockBasePaths(definition.Data.Context, definition.Data.Version),
+		options:   options,
+		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	schemaPath := filepath.Join(tmpPath, filepath.FromSlash(utils.InitProjectDefinitionsGraphQLSchema))
+	swaggerPath := filepath.Join(tmpPath, filepath.FromSlash(utils.InitProjectDefinitionsSwagger))
+	switch {
+	case definition.Data.Type == v2.APITypeGraphQL || utils.IsFileExist(schemaPath):
+		schema, err := ioutil.ReadFile(schemaPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the GraphQL schema of %s: %w", projectPath, err)
+		}
+		server.graphQL = v2.ParseGraphQLSchema(schema)
+	case utils.IsFileExist(swaggerPath):
+		content, err := ioutil.ReadFile(swaggerPath)
+		if err != nil {
+			return nil, err
+		}
+		if server.doc, err = loadOpenAPIDefinition(content, swaggerPath); err != nil {
+			return nil, fmt.Errorf("invalid API definition %s: %w", utils.InitProjectDefinitionsSwagger, err)
+		}
+		if server.routes, err = getMockRoutes(server.doc); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("a Swagger, OpenAPI or GraphQL definition is not found in the " +
+			utils.InitProjectDefinitions + " directory of " + projectPath)
+	}
+	return server, nil
+}
+
+// loadOpenAPIDefinition loads a Swagger 2.0 definition as an OpenAPI 3 definition, or an OpenAPI 3.x definition as it is
+func loadOpenAPIDefinition(content []byte, path string) (*openapi3.T, error) {
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return nil, err
+	}
+	var version struct {
+		Swagger string `json:"swagger"`
+	}
+	if err := json.Unmarshal(jsonContent, &version); err != nil {
+		return nil, err
+	}
+	if version.Swagger == "" {
+		return loadOpenAPI3(jsonContent, path)
+	}
+	swagger := &openapi2.T{}
+	if err := json.Unmarshal(jsonContent, swagger); err != nil {
+		return nil, err
+	}
+	return openapi2conv.ToV3(swagger)
+}
+
+// getMockBasePaths returns the prefixes of the request paths the API is served with. The gateway serves an API at
+// its context followed by the version, unless the context has the {version} template. The context is accepted
+// without the version as well, and paths without the context are served as they are, so that the mock server could
+// be used as the endpoint of the API.
+func getMockBasePaths(apiContext, version string) []string {
+	apiContext = "/" + strings.Trim(apiContext, "/")
+	var basePaths []string
+	if strings.Contains(apiContext, "{version}") {
+		basePaths = append(basePaths, strings.ReplaceAll(apiContext, "{version}", version),
+			strings.ReplaceAll(strings.ReplaceAll(apiContext, "/{version}", ""), "{version}", ""))
+	} else {
+		basePaths = append(basePaths, apiContext+"/"+version, apiContext)
+	}
+	var unique []string
+	for _, basePath := range basePaths {
+		if basePath != "/" && basePath != "" && !containsMockBasePath(unique, basePath) {
+			unique = append(unique, basePath)
+		}
+	}
+	return unique
+}
+
+func containsMockBasePath(basePaths []string, basePath string) bool {
+	for _, p := range basePaths {
+		if p == basePath {
+			return true
+		}
+	}
+	return false
+}
+
+// stripBasePath removes the context of the API from the path of a request
+func (s *MockServer) stripBasePath(path string) string {
+	for _, basePath := range s.basePaths {
+		if path == basePath {
+			return "/"
+		}
+		if strings.HasPrefix(path, basePath+"/") {
+			return path[len(basePath):]
+		}
+	}
+	return path
+}
+
+// mockPathParamRegex matches the templated parameters of a resource path
+var mockPathParamRegex = regexp.MustCompile(`{([^}]+)}`)
+
+// getMockRoutes compiles the resource paths of the definition to regular expressions. Paths ending with /* match
+// any path under them as the resources of API Manager do.
+func getMockRoutes(doc *openapi3.T) ([]*mockRoute, error) {
+	var routes []*mockRoute
+	if doc.Paths == nil {
+		return routes, nil
+	}
+	for _, path := range doc.Paths.InMatchingOrder() {
+		route := &mockRoute{path: path, pathItem: doc.Paths.Value(path)}
+		pattern := path
+		suffix := "/?"
+		if strings.HasSuffix(pattern, "/*") {
+			pattern = strings.TrimSuffix(pattern, "/*")
+			suffix = "(?:/.*)?"
+		}
+		var expression strings.Builder
+		last := 0
+		for _, match := range mockPathParamRegex.FindAllStringSubmatchIndex(pattern, -1) {
+			expression.WriteString(regexp.QuoteMeta(pattern[last:match[0]]))
+			expression.WriteString("([^/]+)")
+			route.params = append(route.params, pattern[match[2]:match[3]])
+			last = match[1]
+		}
+		expression.WriteString(regexp.QuoteMeta(pattern[last:]))
+		compiled, err := regexp.Compile("^" + expression.String() + suffix + "$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource path %s: %w", path, err)
+		}
+		route.pattern = compiled
+		routes = append(routes, route)
+	}
+	// resources matching any path are matched last
+	sort.SliceStable(routes, func(i, j int) bool {
+		return !strings.HasSuffix(routes[i].path, "/*") && strings.HasSuffix(routes[j].path, "/*")
+	})
+	return routes, nil
+}
+
+// ServeHTTP serves a request with a mock response after the simulated latency, or with the simulated error
+func (s *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &mockResponseRecorder{ResponseWriter: w, status: http.StatusOK}
+	start := time.Now()
+	s.serve(recorder, r)
+	if s.options.AccessLog != nil {
+		fmt.Fprintf(s.options.AccessLog, "%s %s %s %d %dms\n", time.Now().Format(time.RFC3339), r.Method,
+			r.URL.RequestURI(), recorder.status, time.Since(start).Milliseconds())
+	}
+}
+
+func (s *MockServer) serve(w http.ResponseWriter, r *http.Request) {
+	// the mock server is usually called from the browser by the frontend under development
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS")
+		if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	delay, fail := s.simulate()
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	if fail {
+		writeMockJSON(w, s.options.ErrorStatus, mockError{Code: s.options.ErrorStatus,
+			Message: "Simulated error", Description: "The error is simulated by the mock server"})
+		return
+	}
+	path := s.stripBasePath(r.URL.Path)
+	if s.graphQL != nil {
+		s.serveGraphQL(w, r)
+		return
+	}
+	s.serveREST(w, r, path)
+}
+
+// simulate returns the latency of a request and whether the request should fail
+func (s *MockServer) simulate() (time.Duration, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delay := s.options.Latency
+	if s.options.Jitter > 0 {
+		delay += time.Duration(s.random.Int63n(int64(s.options.Jitter)))
+	}
+	return delay, s.options.ErrorRate > 0 && s.random.Float64() < s.options.ErrorRate
+}
+
+// serveREST validates the request against the matching operation of the definition and writes its response
+func (s *MockServer) serveREST(w http.ResponseWriter, r *http.Request, path string) {
+	var route *mockRoute
+	var matches []string
+	for _, candidate := range s.routes {
+		if matches = candidate.pattern.FindStringSubmatch(path); matches != nil {
+			route = candidate
+			break
+		}
+	}
+	if route == nil {
+		writeMockJSON(w, http.StatusNotFound, mockError{Code: http.StatusNotFound, Message: "Resource not found",
+			Description: "No matching resource found for " + r.Method + " " + path})
+		return
+	}
+	operation := route.pathItem.GetOperation(r.Method)
+	if operation == nil {
+		writeMockJSON(w, http.StatusMethodNotAllowed, mockError{Code: http.StatusMethodNotAllowed,
+			Message: "Method not allowed", Description: "Method " + r.Method + " is not allowed for " + route.path})
+		return
+	}
+
+	if !s.options.SkipValidation {
+		pathParams := make(map[string]string)
+		for i, name := range route.params {
+			pathParams[name] = matches[i+1]
+		}
+		request := r.Clone(r.Context())
+		request.URL.Path = path
+		input := &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route: &routers.Route{Spec: s.doc, Path: route.path, PathItem: route.pathItem, Method: r.Method,
+				Operation: operation},
+			Options: &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		}
+		if err := openapi3filter.ValidateRequest(context.Background(), input); err != nil {
+			writeMockJSON(w, http.StatusBadRequest, mockError{Code: http.StatusBadRequest,
+				Message: "Request validation failed", Description: err.Error()})
+			return
+		}
+	}
+
+	status, contentType, body := getMockResponse(operation)
+	if contentType == "" {
+		w.WriteHeader(status)
+		return
+	}
+	if text, ok := body.(string); ok && !strings.Contains(contentType, "json") {
+		w.Header().Set(utils.HeaderContentType, contentType)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(text))
+		return
+	}
+	w.Header().Set(utils.HeaderContentType, contentType)
+	writeMockJSON(w, status, body)
+}
+
+// getMockResponse returns the status, content type and body of the response of an operation. The lowest 2xx
+// response is used if the operation has one, or else the default response. The body is the example of the response,
+// or is generated from its schema.
+func getMockResponse(operation *openapi3.Operation) (int, string, interface{}) {
+	if operation.Responses == nil {
+		return http.StatusOK, "", nil
+	}
+	responses := operation.Responses.Map()
+	status, code := http.StatusOK, ""
+	var codes []string
+	for key := range responses {
+		codes = append(codes, key)
+	}
+	sort.Strings(codes)
+	for _, key := range codes {
+		if value, err := strconv.Atoi(key); err == nil && value >= 200 && value < 300 {
+			status, code = value, key
+			break
+		}
+	}
+	if code == "" {
+		if _, ok := responses["default"]; !ok {
+			return http.StatusOK, "", nil
+		}
+		code = "default"
+	}
+	response := responses[code].Value
+	if response == nil || len(response.Content) == 0 {
+		return status, "", nil
+	}
+
+	contentType := getMockMediaType(response.Content)
+	return status, contentType, getMockMediaValue(response.Content[contentType])
+}
+
+// getMockMediaType returns the media type of a content to be mocked, preferring application/json and the other JSON
+// media types
+func getMockMediaType(content openapi3.Content) string {
+	contentType := ""
+	var contentTypes []string
+	for key := range content {
+		contentTypes = append(contentTypes, key)
+	}
+	if len(contentTypes) == 0 {
+		return ""
+	}
+	sort.Strings(contentTypes)
+	for _, key := range contentTypes {
+		if strings.Contains(key, "json") && (contentType == "" || key == "application/json") {
+			contentType = key
+		}
+	}
+	if contentType == "" {
+		contentType = contentTypes[0]
+	}
+	return contentType
+}
+
+// getMockMediaValue returns the example of a media type, or a value generated from its schema
+func getMockMediaValue(media *openapi3.MediaType) interface{} {
+	if media == nil {
+		return nil
+	}
+	if media.Example != nil {
+		return media.Example
+	}
+	if len(media.Examples) > 0 {
+		var names []string
+		for name := range media.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if example := media.Examples[names[0]]; example != nil && example.Value != nil {
+			return example.Value.Value
+		}
+	}
+	return getMockSchemaValue(media.Schema, 0)
+}
+
+// getMockSchemaValue generates a value of a schema. The example, default or first enum value of the schema is used if
+// it has one.
+func getMockSchemaValue(schemaRef *openapi3.SchemaRef, depth int) interface{} {
+	if schemaRef == nil || schemaRef.Value == nil || depth > maxMockSchemaDepth {
+		return nil
+	}
+	schema := schemaRef.Value
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case len(schema.AllOf) > 0:
+		merged := make(map[string]interface{})
+		for _, part := range schema.AllOf {
+			if value, ok := getMockSchemaValue(part, depth).(map[string]interface{}); ok {
+				for key, v := range value {
+					merged[key] = v
+				}
+			}
+		}
+		return merged
+	case len(schema.OneOf) > 0:
+		return getMockSchemaValue(schema.OneOf[0], depth)
+	case len(schema.AnyOf) > 0:
+		return getMockSchemaValue(schema.AnyOf[0], depth)
+	}
+
+	switch {
+	case schema.Type.Is(openapi3.TypeString):
+		return getMockString(schema.Format)
+	case schema.Type.Is(openapi3.TypeInteger):
+		if schema.Min != nil {
+			return int64(*schema.Min)
+		}
+		return 0
+	case schema.Type.Is(openapi3.TypeNumber):
+		if schema.Min != nil {
+			return *schema.Min
+		}
+		return 0.0
+	case schema.Type.Is(openapi3.TypeBoolean):
+		return true
+	case schema.Type.Is(openapi3.TypeArray) || schema.Items != nil:
+		if item := getMockSchemaValue(schema.Items, depth+1); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	case schema.Type.Is(openapi3.TypeObject) || len(schema.Properties) > 0:
+		object := make(map[string]interface{})
+		for name, property := range schema.Properties {
+			if value := getMockSchemaValue(property, depth+1); value != nil {
+				object[name] = value
+			}
+		}
+		return object
+	}
+	return nil
+}
+
+// getMockString returns a sample string of a format
+func getMockString(format string) string {
+	switch format {
+	case "date":
+		return "2024-01-01"
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "uri", "url":
+		return "https://example.com"
+	case "byte":
+		return "c3RyaW5n"
+	case "ipv4":
+		return "127.0.0.1"
+	}
+	return "string"
+}
+
+// mockGraphQLRequest is the body of a GraphQL request
+type mockGraphQLRequest struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName"`
+}
+
+// serveGraphQL writes data generated from the schema for the fields selected by a query or mutation
+func (s *MockServer) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	request := mockGraphQLRequest{}
+	switch r.Method {
+	case http.MethodGet:
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeMockGraphQLErrors(w, http.StatusBadRequest, "invalid GraphQL request: "+err.Error())
+			return
+		}
+	default:
+		writeMockJSON(w, http.StatusMethodNotAllowed, mockError{Code: http.StatusMethodNotAllowed,
+			Message: "Method not allowed", Description: "GraphQL requests should be sent with GET or POST"})
+		return
+	}
+	operation, err := v2.ParseGraphQLQuery(request.Query, request.OperationName)
+	if err != nil {
+		writeMockGraphQLErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if operation.Verb == "SUBSCRIPTION" {
+		writeMockGraphQLErrors(w, http.StatusBadRequest, "subscriptions are not supported by the mock server")
+		return
+	}
+	var errs []string
+	data := s.getMockGraphQLObject(s.graphQL.Roots[operation.Verb], operation.Selections, &errs)
+	if len(errs) > 0 && !s.options.SkipValidation {
+		writeMockGraphQLErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+// getMockGraphQLObject generates an object of a type with the selected fields. Fields that are not defined in the
+// schema are reported in errs.
+func (s *MockServer) getMockGraphQLObject(typeName string, selections []v2.GraphQLSelection,
+	errs *[]string) map[string]interface{} {
+	object := make(map[string]interface{})
+	for _, selection := range selections {
+		if selection.Name == "" {
+			condition := selection.TypeCondition
+			if condition == "" {
+				condition = typeName
+			}
+			for key, value := range s.getMockGraphQLObject(condition, selection.Selections, errs) {
+				object[key] = value
+			}
+			continue
+		}
+		if selection.Name == "__typename" {
+			object[selection.Key()] = typeName
+			continue
+		}
+		field := s.graphQL.Field(typeName, selection.Name)
+		if field == nil {
+			*errs = append(*errs, fmt.Sprintf("Cannot query field \"%s\" on type \"%s\"", selection.Name, typeName))
+			object[selection.Key()] = nil
+			continue
+		}
+		value := s.getMockGraphQLValue(field.Type, selection.Selections, errs)
+		if field.List {
+			object[selection.Key()] = []interface{}{value}
+		} else {
+			object[selection.Key()] = value
+		}
+	}
+	return object
+}
+
+// getMockGraphQLValue generates a value of a scalar, enum or object type
+func (s *MockServer) getMockGraphQLValue(typeName string, selections []v2.GraphQLSelection,
+	errs *[]string) interface{} {
+	switch typeName {
+	case "Int":
+		return 1
+	case "Float":
+		return 1.5
+	case "Boolean":
+		return true
+	case "ID":
+		return "1"
+	case "String":
+		return "string"
+	}
+	if values := s.graphQL.Enums[typeName]; len(values) > 0 {
+		return values[0]
+	}
+	if _, ok := s.graphQL.Types[typeName]; ok || len(selections) > 0 {
+		return s.getMockGraphQLObject(typeName, selections, errs)
+	}
+	// custom scalars
+	return "string"
+}
+
+// writeMockGraphQLErrors writes the errors of a GraphQL request
+func writeMockGraphQLErrors(w http.ResponseWriter, status int, messages ...string) {
+	var errs []map[string]string
+	for _, message := range messages {
+		errs = append(errs, map[string]string{"message": message})
+	}
+	writeMockJSON(w, status, map[string]interface{}{"errors": errs})
+}
+
+// writeMockJSON writes a value as a JSON response
+func writeMockJSON(w http.ResponseWriter, status int, body interface{}) {
+	if w.Header().Get(utils.HeaderContentType) == "" {
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// mockResponseRecorder records the status of a response for the access log
+type mockResponseRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *mockResponseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Address returns the address the mock server listens on
+func (s *MockServer) Address() string {
+	return net.JoinHostPort(s.options.Host, strconv.Itoa(s.options.Port))
+}
+
+// ListenAndServe starts serving the mock responses until the server fails
+func (s *MockServer) ListenAndServe() error {
+	fmt.Fprintf(utils.Stdout, "Mock server of %s %s is listening on http://%s%s\n", s.Name, s.Version, s.Address(),
+		s.basePaths[0])
+	fmt.Fprintf(utils.Stdout, "Requests without the context are served as well. Use http://%s as the endpoint of the API to route "+
+		"it to the mock server\n", s.Address())
+	return http.ListenAndServe(s.Address(), s)
+}
@@ -0,0 +1,192 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const mockTestAPIYaml = "type: api\nversion: v4.7.0\ndata:\n  name: PizzaShackAPI\n  context: /pizzashack\n" +
+	"  version: 1.0.0\n  type: HTTP\n"
+
+const mockTestSwagger = `openapi: 3.0.1
+info:
+  title: PizzaShackAPI
+  version: 1.0.0
+paths:
+  /menu:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              example:
+                - name: Chicken Parmesan
+                  price: 10.99
+  /order/{orderId}:
+    get:
+      parameters:
+        - name: orderId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Order'
+  /order:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order'
+      responses:
+        "201":
+          description: Created
+components:
+  schemas:
+    Order:
+      type: object
+      required: [orderId, items]
+      properties:
+        orderId:
+          type: integer
+        status:
+          type: string
+          enum: [PENDING, DELIVERED]
+        createdTime:
+          type: string
+          format: date-time
+        items:
+          type: array
+          items:
+            type: string
+`
+
+const mockTestGraphQLSchema = `type Query {
+  hero(episode: Episode): Character
+}
+
+enum Episode { NEWHOPE EMPIRE JEDI }
+
+type Character {
+  id: ID!
+  name: String
+  appearsIn: [Episode]
+  friends: [Character]
+}
+`
+
+func newMockTestServer(t *testing.T, definitionPath, definition string, options MockOptions) *MockServer {
+	t.Helper()
+	projectPath := filepath.Join(t.TempDir(), "PizzaShackAPI")
+	writeApplyTestFile(t, filepath.Join(projectPath, utils.APIDefinitionFileYaml), mockTestAPIYaml)
+	writeApplyTestFile(t, filepath.Join(projectPath, definitionPath), definition)
+	server, err := NewMockServer(projectPath, options)
+	assert.Nil(t, err, "Should load the API project")
+	return server
+}
+
+func serveMockTestRequest(server *MockServer, method, path, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		request.Header.Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+	}
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestMockServerServesExamplesAndSchemas(t *testing.T) {
+	server := newMockTestServer(t, utils.InitProjectDefinitionsSwagger, mockTestSwagger, MockOptions{})
+
+	for _, path := range []string{"/pizzashack/1.0.0/menu", "/pizzashack/menu", "/menu"} {
+		response := serveMockTestRequest(server, http.MethodGet, path, "")
+		assert.Equal(t, http.StatusOK, response.Code, "Should serve "+path)
+		assert.JSONEq(t, `[{"name":"Chicken Parmesan","price":10.99}]`, response.Body.String(),
+			"Should respond with the example")
+	}
+
+	response := serveMockTestRequest(server, http.MethodGet, "/pizzashack/1.0.0/order/12", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"orderId":0,"status":"PENDING","createdTime":"2024-01-01T00:00:00Z","items":["string"]}`,
+		response.Body.String(), "Should respond with a value generated from the schema")
+
+	response = serveMockTestRequest(server, http.MethodPost, "/pizzashack/1.0.0/order",
+		`{"orderId":1,"items":["pizza"]}`)
+	assert.Equal(t, http.StatusCreated, response.Code, "Should respond with the 2xx status of the operation")
+
+	response = serveMockTestRequest(server, http.MethodGet, "/pizzashack/1.0.0/customers", "")
+	assert.Equal(t, http.StatusNotFound, response.Code, "Should not serve unknown resources")
+	response = serveMockTestRequest(server, http.MethodDelete, "/pizzashack/1.0.0/menu", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code, "Should not serve unknown methods")
+}
+
+func TestMockServerValidatesRequests(t *testing.T) {
+	server := newMockTestServer(t, utils.InitProjectDefinitionsSwagger, mockTestSwagger, MockOptions{})
+
+	response := serveMockTestRequest(server, http.MethodGet, "/pizzashack/1.0.0/order/abc", "")
+	assert.Equal(t, http.StatusBadRequest, response.Code, "Should reject an invalid path parameter")
+	response = serveMockTestRequest(server, http.MethodPost, "/pizzashack/1.0.0/order", `{"status":"PENDING"}`)
+	assert.Equal(t, http.StatusBadRequest, response.Code, "Should reject a body without the required properties")
+
+	server.options.SkipValidation = true
+	response = serveMockTestRequest(server, http.MethodPost, "/pizzashack/1.0.0/order", `{"status":"PENDING"}`)
+	assert.Equal(t, http.StatusCreated, response.Code, "Should not validate requests when the validation is skipped")
+}
+
+func TestMockServerSimulatesErrors(t *testing.T) {
+	server := newMockTestServer(t, utils.InitProjectDefinitionsSwagger, mockTestSwagger,
+		MockOptions{ErrorRate: 1, ErrorStatus: http.StatusServiceUnavailable})
+
+	response := serveMockTestRequest(server, http.MethodGet, "/pizzashack/1.0.0/menu", "")
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code, "Should fail with the error status")
+}
+
+func TestMockServerServesGraphQL(t *testing.T) {
+	server := newMockTestServer(t, utils.InitProjectDefinitionsGraphQLSchema, mockTestGraphQLSchema, MockOptions{})
+
+	query, _ := json.Marshal(map[string]string{
+		"query": `query { hero(episode: JEDI) { id heroName: name appearsIn friends { name __typename } } }`})
+	response := serveMockTestRequest(server, http.MethodPost, "/pizzashack/1.0.0", string(query))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"data":{"hero":{"id":"1","heroName":"string","appearsIn":["NEWHOPE"],`+
+		`"friends":[{"name":"string","__typename":"Character"}]}}}`, response.Body.String(),
+		"Should respond with data generated from the schema")
+
+	query, _ = json.Marshal(map[string]string{"query": `{ hero { age } }`})
+	response = serveMockTestRequest(server, http.MethodPost, "/pizzashack/1.0.0", string(query))
+	assert.Equal(t, http.StatusBadRequest, response.Code, "Should reject fields not in the schema")
+	assert.Contains(t, response.Body.String(), `Cannot query field \"age\" on type \"Character\"`)
+}
@@ -0,0 +1,235 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/plugin"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	pluginNameHeader    = "NAME"
+	pluginPathHeader    = "PATH"
+	pluginWarningHeader = "WARNING"
+)
+
+// Plugin is an executable run as a command of apictl
+type Plugin struct {
+	// Name of the command the plugin is run as
+	Name string
+	// Path of the executable
+	Path string
+	// Warning is set if the plugin cannot be run as it has the name of a command or of another plugin
+	Warning string
+}
+
+// GetPluginsDirPath returns the directory plugins are installed to
+func GetPluginsDirPath() string {
+	return filepath.Join(filepath.Dir(utils.MainConfigFilePath), utils.PluginsDirName)
+}
+
+// FindPlugins returns the plugins in the plugins directory and in the directories of the PATH, in that order. A
+// plugin is not run if there is a command or an earlier plugin with the same name.
+// @param commands : Names of the commands of apictl
+// @return plugins
+func FindPlugins(commands []string) []Plugin {
+	reserved := make(map[string]bool)
+	for _, command := range commands {
+		reserved[command] = true
+	}
+	found := make(map[string]string)
+	var plugins []Plugin
+	for _, dir := range getPluginDirs() {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			name, ok := getPluginName(file)
+			if !ok {
+				continue
+			}
+			p := Plugin{Name: name, Path: filepath.Join(dir, file.Name())}
+			if reserved[name] {
+				p.Warning = "shadowed by the command " + utils.ProjectName + " " + name
+			} else if path, exists := found[name]; exists {
+				p.Warning = "shadowed by " + path
+			} else {
+				found[name] = p.Path
+			}
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins
+}
+
+// FindPlugin returns the plugin run as a command, looking for an executable named apictl-<name> in the plugins
+// directory and in the directories of the PATH, in that order. Unlike FindPlugins the directories are not read.
+// @param name : Name of the command
+// @return plugin, true if the plugin is found
+func FindPlugin(name string) (Plugin, bool) {
+	fileNames := []string{plugin.Prefix + name}
+	if runtime.GOOS == "windows" {
+		fileNames = []string{plugin.Prefix + name + ".exe", plugin.Prefix + name + ".bat", plugin.Prefix + name + ".cmd"}
+	}
+	for _, dir := range getPluginDirs() {
+		for _, fileName := range fileNames {
+			file, err := os.Stat(filepath.Join(dir, fileName))
+			if err != nil {
+				continue
+			}
+			if pluginName, ok := getPluginName(file); ok && pluginName == name {
+				return Plugin{Name: name, Path: filepath.Join(dir, fileName)}, true
+			}
+		}
+	}
+	return Plugin{}, false
+}
+
+// getPluginDirs returns the plugins directory and the directories of the PATH without duplicates
+func getPluginDirs() []string {
+	var dirs []string
+	visited := make(map[string]bool)
+	for _, dir := range append([]string{GetPluginsDirPath()}, filepath.SplitList(os.Getenv("PATH"))...) {
+		if dir == "" || visited[dir] {
+			continue
+		}
+		visited[dir] = true
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// getPluginName returns the name of the command a file is run as if it is an executable named apictl-<name>. The
+// credential helpers, named apictl-credential-<name>, are not plugins.
+func getPluginName(file os.FileInfo) (string, bool) {
+	if file.IsDir() || !strings.HasPrefix(file.Name(), plugin.Prefix) ||
+		strings.HasPrefix(file.Name(), credentials.CredentialHelperPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file.Name(), plugin.Prefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	} else if file.Mode()&0111 == 0 {
+		return "", false
+	}
+	return name, name != ""
+}
+
+// RunPlugin runs a plugin with the arguments following its name. The environment, the config directory and the
+// access token of the environment, if the plugin is trusted, are passed to the plugin as environment variables.
+// @param p : Plugin to run
+// @param args : Arguments of the plugin
+// @param environment : Environment the plugin is run against, if any
+// @param accessToken : Access token of the environment, empty if the plugin is not trusted
+// @return error
+func RunPlugin(p Plugin, args []string, environment, accessToken string) error {
+	command := exec.Command(p.Path, args...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	command.Env = append(os.Environ(),
+		plugin.EnvName+"="+p.Name,
+		plugin.EnvEnvironment+"="+environment,
+		plugin.EnvConfigDir+"="+utils.HomeDirectory,
+		plugin.EnvAccessToken+"="+accessToken)
+	utils.Logln(utils.LogPrefixInfo + "Running the plugin " + p.Path)
+	return command.Run()
+}
+
+// IsPluginTrusted returns true if the executable of the plugin is one of the trusted plugins. The trusted plugins are
+// the paths of the executables, compared after resolving the symbolic links, so that another executable with the same
+// name earlier on the PATH is not trusted. Only the trusted plugins are issued an access token of an environment.
+func IsPluginTrusted(p Plugin, trustedPlugins []string) bool {
+	path := resolvePluginPath(p.Path)
+	for _, trustedPath := range trustedPlugins {
+		if filepath.IsAbs(trustedPath) && resolvePluginPath(trustedPath) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePluginPath returns the absolute path of an executable with the symbolic links resolved
+func resolvePluginPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return filepath.Clean(path)
+}
+
+// GetPluginEnvironment returns the environment given to a plugin with --environment (-e). The default environment is
+// returned if no environment is given.
+func GetPluginEnvironment(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		for _, flag := range []string{"-e", "--environment"} {
+			if arg == flag && i+1 < len(args) {
+				return args[i+1]
+			}
+			if strings.HasPrefix(arg, flag+"=") {
+				return strings.TrimPrefix(arg, flag+"=")
+			}
+		}
+	}
+	return utils.GetDefaultEnvironment(utils.MainConfigFilePath)
+}
+
+// PrintPlugins prints the plugins using a go template
+func PrintPlugins(plugins []Plugin, format string) {
+	pluginsContext := formatter.NewContext(os.Stdout, format)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, p := range plugins {
+			if err := t.Execute(w, p); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	pluginsTableHeaders := map[string]string{
+		"Name":    pluginNameHeader,
+		"Path":    pluginPathHeader,
+		"Warning": pluginWarningHeader,
+	}
+	if err := pluginsContext.Write(renderer, pluginsTableHeaders); err != nil {
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
+	}
+}
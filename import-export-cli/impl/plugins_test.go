@@ -0,0 +1,118 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writePluginTestFile(t *testing.T, dir, name, content string, mode os.FileMode) string {
+	path := filepath.Join(dir, name)
+	assert.Nil(t, ioutil.WriteFile(path, []byte(content), mode))
+	return path
+}
+
+func TestFindPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugins are discovered by the file extension on Windows")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	hello := writePluginTestFile(t, first, "apictl-hello", "#!/bin/sh\n", 0755)
+	shadowedHello := writePluginTestFile(t, second, "apictl-hello", "#!/bin/sh\n", 0755)
+	login := writePluginTestFile(t, second, "apictl-login", "#!/bin/sh\n", 0755)
+	writePluginTestFile(t, second, "apictl-notes", "not executable", 0644)
+	writePluginTestFile(t, second, "kubectl-hello", "#!/bin/sh\n", 0755)
+	writePluginTestFile(t, second, "apictl-credential-pass", "#!/bin/sh\n", 0755)
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second+string(os.PathListSeparator)+first)
+
+	plugins := FindPlugins([]string{"login"})
+
+	assert.Equal(t, []Plugin{
+		{Name: "hello", Path: hello},
+		{Name: "hello", Path: shadowedHello, Warning: "shadowed by " + hello},
+		{Name: "login", Path: login, Warning: "shadowed by the command apictl login"},
+	}, plugins)
+}
+
+func TestFindPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugins are discovered by the file extension on Windows")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	writePluginTestFile(t, first, "apictl-hello", "not executable", 0644)
+	hello := writePluginTestFile(t, second, "apictl-hello", "#!/bin/sh\n", 0755)
+	writePluginTestFile(t, second, "apictl-credential-pass", "#!/bin/sh\n", 0755)
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	p, found := FindPlugin("hello")
+	assert.True(t, found)
+	assert.Equal(t, Plugin{Name: "hello", Path: hello}, p)
+
+	_, found = FindPlugin("credential-pass")
+	assert.False(t, found, "Credential helpers should not be run as plugins")
+	_, found = FindPlugin("missing")
+	assert.False(t, found)
+}
+
+func TestGetPluginEnvironment(t *testing.T) {
+	assert.Equal(t, "dev", GetPluginEnvironment([]string{"check", "-e", "dev"}))
+	assert.Equal(t, "prod", GetPluginEnvironment([]string{"--environment=prod", "-n", "PizzaShackAPI"}))
+	assert.Equal(t, "dev", GetPluginEnvironment([]string{"--environment", "dev", "--", "-e", "prod"}))
+}
+
+func TestRunPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test plugin is a shell script")
+	}
+	dir := t.TempDir()
+	output := filepath.Join(dir, "output")
+	path := writePluginTestFile(t, dir, "apictl-hello", `#!/bin/sh
+echo "$* $APICTL_PLUGIN_NAME $APICTL_ENVIRONMENT $APICTL_ACCESS_TOKEN" > "`+output+`"
+exit 3
+`, 0755)
+
+	err := RunPlugin(Plugin{Name: "hello", Path: path}, []string{"-e", "dev", "--fix"}, "dev", "token")
+
+	assert.NotNil(t, err, "Should return the exit status of the plugin")
+	content, _ := ioutil.ReadFile(output)
+	assert.Equal(t, "-e dev --fix hello dev token\n", string(content))
+}
+
+func TestIsPluginTrusted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test plugin is linked with a symbolic link")
+	}
+	dir := t.TempDir()
+	path := writePluginTestFile(t, dir, "apictl-check-naming", "#!/bin/sh\n", 0755)
+	link := filepath.Join(t.TempDir(), "apictl-check-naming")
+	assert.Nil(t, os.Symlink(path, link))
+	p := Plugin{Name: "check-naming", Path: link}
+
+	assert.True(t, IsPluginTrusted(p, []string{"/plugins/apictl-lint", path}))
+	assert.True(t, IsPluginTrusted(Plugin{Name: "check-naming", Path: path}, []string{link}))
+	assert.False(t, IsPluginTrusted(p, []string{"check-naming"}), "Plugins should not be trusted by the name")
+	assert.False(t, IsPluginTrusted(p, []string{filepath.Join(dir, "apictl-lint")}))
+	assert.False(t, IsPluginTrusted(p, nil), "Plugins should not be trusted by default")
+}
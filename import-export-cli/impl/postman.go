@@ -0,0 +1,690 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// PostmanCollectionSchema is the schema of the Postman collections generated and read by apictl
+const PostmanCollectionSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// PostmanCollection is a Postman collection of the format v2.1. Collections of the format v2.0 can be read as well.
+type PostmanCollection struct {
+	Info     PostmanInfo       `json:"info"`
+	Item     []*PostmanItem    `json:"item"`
+	Auth     *PostmanAuth      `json:"auth,omitempty"`
+	Variable []PostmanKeyValue `json:"variable,omitempty"`
+}
+
+// PostmanInfo holds the name and the description of a collection
+type PostmanInfo struct {
+	PostmanID   string      `json:"_postman_id,omitempty"`
+	Name        string      `json:"name"`
+	Description PostmanText `json:"description,omitempty"`
+	Schema      string      `json:"schema"`
+}
+
+// PostmanItem is either a folder having items or a request
+type PostmanItem struct {
+	Name        string            `json:"name"`
+	Description PostmanText       `json:"description,omitempty"`
+	Item        []*PostmanItem    `json:"item,omitempty"`
+	Auth        *PostmanAuth      `json:"auth,omitempty"`
+	Request     *PostmanRequest   `json:"request,omitempty"`
+	Response    []PostmanResponse `json:"response,omitempty"`
+}
+
+// PostmanRequest is a request of a collection
+type PostmanRequest struct {
+	Method      string            `json:"method"`
+	Header      []PostmanKeyValue `json:"header"`
+	URL         PostmanURL        `json:"url"`
+	Body        *PostmanBody      `json:"body,omitempty"`
+	Auth        *PostmanAuth      `json:"auth,omitempty"`
+	Description PostmanText       `json:"description,omitempty"`
+}
+
+// PostmanURL is the URL of a request. Collections could have the URL as a string or as an object.
+type PostmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host,omitempty"`
+	Path     []string          `json:"path,omitempty"`
+	Query    []PostmanKeyValue `json:"query,omitempty"`
+	Variable []PostmanKeyValue `json:"variable,omitempty"`
+}
+
+// PostmanBody is the body of a request
+type PostmanBody struct {
+	Mode       string              `json:"mode"`
+	Raw        string              `json:"raw,omitempty"`
+	URLEncoded []PostmanKeyValue   `json:"urlencoded,omitempty"`
+	FormData   []PostmanKeyValue   `json:"formdata,omitempty"`
+	Options    *PostmanBodyOptions `json:"options,omitempty"`
+}
+
+// PostmanBodyOptions holds the language of a raw body
+type PostmanBodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+// PostmanAuth is the authentication of a collection, a folder or a request. The attributes are under the name of the
+// type, eg: oauth2
+type PostmanAuth struct {
+	Type   string            `json:"type"`
+	OAuth2 []PostmanKeyValue `json:"oauth2,omitempty"`
+	APIKey []PostmanKeyValue `json:"apikey,omitempty"`
+	Bearer []PostmanKeyValue `json:"bearer,omitempty"`
+	Basic  []PostmanKeyValue `json:"basic,omitempty"`
+}
+
+// PostmanResponse is a saved response of a request
+type PostmanResponse struct {
+	Name   string            `json:"name"`
+	Code   int               `json:"code"`
+	Header []PostmanKeyValue `json:"header,omitempty"`
+	Body   string            `json:"body,omitempty"`
+}
+
+// PostmanKeyValue is a header, a parameter, a variable or an attribute of an authentication
+type PostmanKeyValue struct {
+	Key         string      `json:"key"`
+	Value       PostmanText `json:"value"`
+	Type        string      `json:"type,omitempty"`
+	Disabled    bool        `json:"disabled,omitempty"`
+	Description PostmanText `json:"description,omitempty"`
+}
+
+// PostmanText is a string of a collection. Values could be numbers or booleans and descriptions could be objects
+// having the content, which are read as strings.
+type PostmanText string
+
+// UnmarshalJSON reads a string, a number, a boolean or an object having the content as a string
+func (t *PostmanText) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		*t = ""
+	case string:
+		*t = PostmanText(v)
+	case map[string]interface{}:
+		content, _ := v["content"].(string)
+		*t = PostmanText(content)
+	default:
+		*t = PostmanText(fmt.Sprint(v))
+	}
+	return nil
+}
+
+// UnmarshalJSON reads the URL given as a string or as an object. Hosts and paths could be strings or arrays, and the
+// segments of paths could be objects having the values.
+func (u *PostmanURL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		u.Raw = raw
+		return nil
+	}
+	var object struct {
+		Raw      string            `json:"raw"`
+		Protocol string            `json:"protocol"`
+		Host     interface{}       `json:"host"`
+		Port     string            `json:"port"`
+		Path     interface{}       `json:"path"`
+		Query    []PostmanKeyValue `json:"query"`
+		Variable []PostmanKeyValue `json:"variable"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	u.Raw = object.Raw
+	u.Host = getPostmanStrings(object.Host, ".")
+	u.Path = getPostmanStrings(object.Path, "/")
+	u.Query = object.Query
+	u.Variable = object.Variable
+	if u.Raw == "" {
+		u.Raw = strings.Join(u.Host, ".")
+		if object.Protocol != "" {
+			u.Raw = object.Protocol + "://" + u.Raw
+		}
+		if object.Port != "" {
+			u.Raw += ":" + object.Port
+		}
+		if len(u.Path) > 0 {
+			u.Raw += "/" + strings.Join(u.Path, "/")
+		}
+	}
+	return nil
+}
+
+// getPostmanStrings returns the segments of a host or a path given as a string or an array
+func getPostmanStrings(value interface{}, separator string) []string {
+	var segments []string
+	switch v := value.(type) {
+	case string:
+		for _, segment := range strings.Split(strings.Trim(v, separator), separator) {
+			if segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			switch segment := item.(type) {
+			case string:
+				segments = append(segments, segment)
+			case map[string]interface{}:
+				segments = append(segments, fmt.Sprint(segment["value"]))
+			}
+		}
+	}
+	return segments
+}
+
+// attribute returns an attribute of an authentication of the given type
+func (a *PostmanAuth) attribute(key string) string {
+	var attributes []PostmanKeyValue
+	switch a.Type {
+	case "oauth2":
+		attributes = a.OAuth2
+	case "apikey":
+		attributes = a.APIKey
+	case "bearer":
+		attributes = a.Bearer
+	case "basic":
+		attributes = a.Basic
+	}
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return string(attribute.Value)
+		}
+	}
+	return ""
+}
+
+// ParsePostmanCollection reads a Postman collection of the format v2.0 or v2.1
+func ParsePostmanCollection(content []byte) (*PostmanCollection, error) {
+	collection := &PostmanCollection{}
+	if err := json.Unmarshal(content, collection); err != nil {
+		return nil, fmt.Errorf("invalid Postman collection: %w", err)
+	}
+	if !strings.Contains(collection.Info.Schema, "/collection/v2") {
+		return nil, errors.New("unsupported Postman collection. Export the collection in the format v2.1")
+	}
+	return collection, nil
+}
+
+// postmanVariableRegex matches the variables of a collection, eg: {{baseUrl}}
+var postmanVariableRegex = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+
+// postmanIgnoredHeaders are the headers not added to the API definition as parameters
+var postmanIgnoredHeaders = map[string]bool{"authorization": true, "content-type": true, "accept": true,
+	"content-length": true, "host": true, "user-agent": true, "cookie": true}
+
+// postmanOperation is a request of a collection inferred as an operation of the API
+type postmanOperation struct {
+	tag     string
+	auth    *PostmanAuth
+	item    *PostmanItem
+	path    string
+	origin  string
+	request *PostmanRequest
+}
+
+// BuildPostmanOpenAPI infers an OpenAPI 3.0 definition from the requests of a Postman collection. Folders become the
+// tags of the operations, the origin of the requests becomes the server, and parameters, request bodies, responses
+// and security schemes are inferred from the requests and their saved responses. Variables of the collection are
+// substituted and the variables remaining in paths become path parameters.
+func BuildPostmanOpenAPI(collection *PostmanCollection) ([]byte, error) {
+	variables := make(map[string]string)
+	for _, variable := range collection.Variable {
+		variables[variable.Key] = string(variable.Value)
+	}
+	var operations []postmanOperation
+	collectPostmanOperations(collection.Item, "", collection.Auth, variables, &operations)
+	if len(operations) == 0 {
+		return nil, errors.New("the Postman collection does not have any requests")
+	}
+
+	paths := make(map[string]map[string]interface{})
+	securitySchemes := make(map[string]interface{})
+	var servers []interface{}
+	var tags []interface{}
+	seenServers := make(map[string]bool)
+	seenTags := make(map[string]bool)
+	for _, op := range operations {
+		method := strings.ToLower(op.request.Method)
+		if method == "" {
+			method = "get"
+		}
+		if paths[op.path] == nil {
+			paths[op.path] = make(map[string]interface{})
+		}
+		if _, ok := paths[op.path][method]; ok {
+			utils.Logln(utils.LogPrefixWarning + "Skipping the request " + op.item.Name + " as " +
+				strings.ToUpper(method) + " " + op.path + " is already defined")
+			continue
+		}
+		if op.origin != "" && !seenServers[op.origin] {
+			seenServers[op.origin] = true
+			servers = append(servers, map[string]interface{}{"url": op.origin})
+		}
+		if op.tag != "" && !seenTags[op.tag] {
+			seenTags[op.tag] = true
+			tags = append(tags, map[string]interface{}{"name": op.tag})
+		}
+		paths[op.path][method] = buildPostmanOperation(op, variables, securitySchemes)
+	}
+
+	info := map[string]interface{}{"title": collection.Info.Name, "version": utils.DefaultApiProductVersion}
+	if collection.Info.Description != "" {
+		info["description"] = string(collection.Info.Description)
+	}
+	definition := map[string]interface{}{
+		"openapi": "3.0.1",
+		"info":    info,
+		"paths":   paths,
+	}
+	if len(servers) > 0 {
+		definition["servers"] = servers
+	}
+	if len(tags) > 0 {
+		definition["tags"] = tags
+	}
+	if len(securitySchemes) > 0 {
+		definition["components"] = map[string]interface{}{"securitySchemes": securitySchemes}
+	}
+	return json.MarshalIndent(definition, "", "  ")
+}
+
+// collectPostmanOperations collects the requests of the items of a collection recursively. The requests inherit the
+// authentication of their folders and are tagged by the top level folder.
+func collectPostmanOperations(items []*PostmanItem, tag string, auth *PostmanAuth, variables map[string]string,
+	operations *[]postmanOperation) {
+	for _, item := range items {
+		itemAuth := auth
+		if item.Auth != nil {
+			itemAuth = item.Auth
+		}
+		if item.Request == nil {
+			itemTag := tag
+			if itemTag == "" {
+				itemTag = item.Name
+			}
+			collectPostmanOperations(item.Item, itemTag, itemAuth, variables, operations)
+			continue
+		}
+		if item.Request.Auth != nil {
+			itemAuth = item.Request.Auth
+		}
+		origin, path := getPostmanOriginAndPath(item.Request.URL.Raw, variables)
+		*operations = append(*operations, postmanOperation{tag: tag, auth: itemAuth, item: item, path: path,
+			origin: origin, request: item.Request})
+	}
+}
+
+// getPostmanOriginAndPath splits the URL of a request to the origin and the templated path. Variables of the
+// collection are substituted, and the variables that could not be substituted and the segments such as :id
+// become path parameters. The origin is empty if it could not be resolved.
+func getPostmanOriginAndPath(raw string, variables map[string]string) (string, string) {
+	if index := strings.IndexAny(raw, "?#"); index >= 0 {
+		raw = raw[:index]
+	}
+	for i := 0; i < 5 && postmanVariableRegex.MatchString(raw); i++ {
+		raw = postmanVariableRegex.ReplaceAllStringFunc(raw, func(match string) string {
+			name := postmanVariableRegex.FindStringSubmatch(match)[1]
+			if value, ok := variables[name]; ok && value != "" {
+				return value
+			}
+			return match
+		})
+	}
+
+	origin := ""
+	rest := raw
+	if index := strings.Index(rest, "://"); index >= 0 {
+		end := strings.Index(rest[index+3:], "/")
+		if end < 0 {
+			origin, rest = rest, ""
+		} else {
+			origin, rest = rest[:index+3+end], rest[index+3+end:]
+		}
+	} else if strings.HasPrefix(rest, "{{") {
+		// the base URL is a variable without a value
+		end := strings.Index(rest, "}}")
+		rest = rest[end+2:]
+	} else if !strings.HasPrefix(rest, "/") {
+		end := strings.Index(rest, "/")
+		if end < 0 {
+			origin, rest = rest, ""
+		} else {
+			origin, rest = rest[:end], rest[end:]
+		}
+		if origin != "" {
+			origin = "http://" + origin
+		}
+	}
+	if postmanVariableRegex.MatchString(origin) {
+		origin = ""
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(rest, "/") {
+		if segment == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segment = "{" + segment[1:] + "}"
+		case postmanVariableRegex.MatchString(segment):
+			segment = postmanVariableRegex.ReplaceAllString(segment, "{$1}")
+		}
+		segments = append(segments, segment)
+	}
+	return strings.TrimSuffix(origin, "/"), "/" + strings.Join(segments, "/")
+}
+
+// buildPostmanOperation infers an operation from a request
+func buildPostmanOperation(op postmanOperation, variables map[string]string,
+	securitySchemes map[string]interface{}) map[string]interface{} {
+	operation := map[string]interface{}{}
+	if op.item.Name != "" {
+		operation["summary"] = op.item.Name
+	}
+	description := op.request.Description
+	if description == "" {
+		description = op.item.Description
+	}
+	if description != "" {
+		operation["description"] = string(description)
+	}
+	if op.tag != "" {
+		operation["tags"] = []string{op.tag}
+	}
+
+	var parameters []interface{}
+	pathValues := make(map[string]string)
+	for _, variable := range op.request.URL.Variable {
+		pathValues[variable.Key] = resolvePostmanVariables(string(variable.Value), variables)
+	}
+	for _, match := range convertPathParamRegex.FindAllStringSubmatch(op.path, -1) {
+		parameters = append(parameters, buildPostmanParameter(match[1], "path", pathValues[match[1]], true))
+	}
+	for _, query := range getPostmanQuery(op.request.URL) {
+		parameters = append(parameters, buildPostmanParameter(query.Key, "query",
+			resolvePostmanVariables(string(query.Value), variables), false))
+	}
+	for _, header := range op.request.Header {
+		if header.Disabled || postmanIgnoredHeaders[strings.ToLower(header.Key)] {
+			continue
+		}
+		parameters = append(parameters, buildPostmanParameter(header.Key, "header",
+			resolvePostmanVariables(string(header.Value), variables), false))
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if body := buildPostmanRequestBody(op.request, variables); body != nil {
+		operation["requestBody"] = body
+	}
+	operation["responses"] = buildPostmanResponses(op.item.Response)
+
+	if op.auth != nil {
+		if op.auth.Type == "noauth" {
+			operation["security"] = []interface{}{}
+		} else if name, scheme := buildPostmanSecurityScheme(op.auth, variables); scheme != nil {
+			securitySchemes[name] = scheme
+			operation["security"] = []interface{}{map[string]interface{}{name: []string{}}}
+		}
+	}
+	return operation
+}
+
+// getPostmanQuery returns the query parameters of a URL, read from the raw URL if they are not given separately
+func getPostmanQuery(u PostmanURL) []PostmanKeyValue {
+	if len(u.Query) > 0 {
+		return u.Query
+	}
+	var query []PostmanKeyValue
+	if index := strings.Index(u.Raw, "?"); index >= 0 {
+		for _, pair := range strings.Split(u.Raw[index+1:], "&") {
+			if pair == "" {
+				continue
+			}
+			key, value := pair, ""
+			if i := strings.Index(pair, "="); i >= 0 {
+				key, value = pair[:i], pair[i+1:]
+			}
+			if unescaped, err := url.QueryUnescape(value); err == nil {
+				value = unescaped
+			}
+			query = append(query, PostmanKeyValue{Key: key, Value: PostmanText(value)})
+		}
+	}
+	return query
+}
+
+// resolvePostmanVariables substitutes the variables of a collection in a value
+func resolvePostmanVariables(value string, variables map[string]string) string {
+	return postmanVariableRegex.ReplaceAllStringFunc(value, func(match string) string {
+		if resolved, ok := variables[postmanVariableRegex.FindStringSubmatch(match)[1]]; ok {
+			return resolved
+		}
+		return match
+	})
+}
+
+// buildPostmanParameter infers a parameter with the type of its example
+func buildPostmanParameter(name, in, example string, required bool) map[string]interface{} {
+	schema := map[string]interface{}{"type": "string"}
+	parameter := map[string]interface{}{"name": name, "in": in, "required": required, "schema": schema}
+	if example == "" || postmanVariableRegex.MatchString(example) {
+		return parameter
+	}
+	if i, err := strconv.ParseInt(example, 10, 64); err == nil {
+		schema["type"] = "integer"
+		parameter["example"] = i
+	} else if f, err := strconv.ParseFloat(example, 64); err == nil {
+		schema["type"] = "number"
+		parameter["example"] = f
+	} else if b, err := strconv.ParseBool(example); err == nil && (example == "true" || example == "false") {
+		schema["type"] = "boolean"
+		parameter["example"] = b
+	} else {
+		parameter["example"] = example
+	}
+	return parameter
+}
+
+// buildPostmanRequestBody infers the request body of a request from its raw, url encoded or form data body
+func buildPostmanRequestBody(request *PostmanRequest, variables map[string]string) map[string]interface{} {
+	body := request.Body
+	if body == nil {
+		return nil
+	}
+	contentType := ""
+	for _, header := range request.Header {
+		if strings.EqualFold(header.Key, utils.HeaderContentType) && !header.Disabled {
+			contentType = strings.TrimSpace(strings.Split(string(header.Value), ";")[0])
+		}
+	}
+	var media map[string]interface{}
+	switch body.Mode {
+	case "raw":
+		raw := resolvePostmanVariables(body.Raw, variables)
+		if strings.TrimSpace(raw) == "" {
+			return nil
+		}
+		var example interface{}
+		if err := json.Unmarshal([]byte(raw), &example); err == nil {
+			if contentType == "" {
+				contentType = utils.HeaderValueApplicationJSON
+			}
+			media = map[string]interface{}{"schema": inferPostmanSchema(example), "example": example}
+		} else {
+			if contentType == "" {
+				contentType = "text/plain"
+				if body.Options != nil && body.Options.Raw.Language == "xml" {
+					contentType = "application/xml"
+				}
+			}
+			media = map[string]interface{}{"schema": map[string]interface{}{"type": "string"}, "example": raw}
+		}
+	case "urlencoded", "formdata":
+		fields := body.URLEncoded
+		contentType = utils.HeaderValueXWWWFormUrlEncoded
+		if body.Mode == "formdata" {
+			fields = body.FormData
+			contentType = "multipart/form-data"
+		}
+		properties := make(map[string]interface{})
+		for _, field := range fields {
+			if field.Disabled {
+				continue
+			}
+			property := map[string]interface{}{"type": "string"}
+			if field.Type == "file" {
+				property["format"] = "binary"
+			}
+			properties[field.Key] = property
+		}
+		media = map[string]interface{}{"schema": map[string]interface{}{"type": "object", "properties": properties}}
+	default:
+		return nil
+	}
+	return map[string]interface{}{"content": map[string]interface{}{contentType: media}}
+}
+
+// buildPostmanResponses infers the responses of an operation from the saved responses of a request
+func buildPostmanResponses(responses []PostmanResponse) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, response := range responses {
+		code := "default"
+		if response.Code != 0 {
+			code = strconv.Itoa(response.Code)
+		}
+		if _, ok := result[code]; ok {
+			continue
+		}
+		description := response.Name
+		if description == "" {
+			description = "Response"
+		}
+		entry := map[string]interface{}{"description": description}
+		var example interface{}
+		if response.Body != "" && json.Unmarshal([]byte(response.Body), &example) == nil {
+			entry["content"] = map[string]interface{}{utils.HeaderValueApplicationJSON: map[string]interface{}{
+				"schema": inferPostmanSchema(example), "example": example}}
+		} else if response.Body != "" {
+			contentType := "text/plain"
+			for _, header := range response.Header {
+				if strings.EqualFold(header.Key, utils.HeaderContentType) {
+					contentType = strings.TrimSpace(strings.Split(string(header.Value), ";")[0])
+				}
+			}
+			entry["content"] = map[string]interface{}{contentType: map[string]interface{}{
+				"schema": map[string]interface{}{"type": "string"}, "example": response.Body}}
+		}
+		result[code] = entry
+	}
+	if len(result) == 0 {
+		result["default"] = map[string]interface{}{"description": "Default response"}
+	}
+	return result
+}
+
+// inferPostmanSchema infers the schema of a JSON value
+func inferPostmanSchema(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties := make(map[string]interface{})
+		for name, property := range v {
+			properties[name] = inferPostmanSchema(property)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	case []interface{}:
+		items := map[string]interface{}{}
+		if len(v) > 0 {
+			items = inferPostmanSchema(v[0])
+		}
+		return map[string]interface{}{"type": "array", "items": items}
+	case string:
+		return map[string]interface{}{"type": "string"}
+	case float64:
+		if v == float64(int64(v)) {
+			return map[string]interface{}{"type": "integer"}
+		}
+		return map[string]interface{}{"type": "number"}
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+	}
+	return map[string]interface{}{}
+}
+
+// buildPostmanSecurityScheme infers a security scheme from an authentication. Nil is returned for the
+// authentications that do not have an equivalent security scheme.
+func buildPostmanSecurityScheme(auth *PostmanAuth, variables map[string]string) (string, map[string]interface{}) {
+	switch auth.Type {
+	case "oauth2":
+		tokenURL := resolvePostmanVariables(auth.attribute("accessTokenUrl"), variables)
+		if tokenURL == "" || postmanVariableRegex.MatchString(tokenURL) {
+			tokenURL = "https://localhost:9443/oauth2/token"
+		}
+		flows := map[string]interface{}{"clientCredentials": map[string]interface{}{"tokenUrl": tokenURL,
+			"scopes": map[string]interface{}{}}}
+		if scope := auth.attribute("scope"); scope != "" {
+			scopes := make(map[string]interface{})
+			fields := strings.Fields(scope)
+			sort.Strings(fields)
+			for _, name := range fields {
+				scopes[name] = name
+			}
+			flows["clientCredentials"].(map[string]interface{})["scopes"] = scopes
+		}
+		return "default", map[string]interface{}{"type": "oauth2", "flows": flows}
+	case "bearer":
+		return "bearer", map[string]interface{}{"type": "http", "scheme": "bearer"}
+	case "basic":
+		return "basic", map[string]interface{}{"type": "http", "scheme": "basic"}
+	case "apikey":
+		name := auth.attribute("key")
+		if name == "" {
+			name = "apikey"
+		}
+		in := auth.attribute("in")
+		if in == "" {
+			in = "header"
+		}
+		return "api_key", map[string]interface{}{"type": "apiKey", "name": name, "in": in}
+	}
+	return "", nil
+}
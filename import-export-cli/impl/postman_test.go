@@ -0,0 +1,237 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const postmanTestCollection = `{
+  "info": {
+    "name": "PizzaShack",
+    "description": {"content": "Pizza ordering API"},
+    "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+  },
+  "auth": {
+    "type": "oauth2",
+    "oauth2": [{"key": "accessTokenUrl", "value": "{{tokenUrl}}"}, {"key": "scope", "value": "order menu"}]
+  },
+  "variable": [
+    {"key": "baseUrl", "value": "https://pizza.example.com/api"},
+    {"key": "tokenUrl", "value": "https://idp.example.com/token"}
+  ],
+  "item": [
+    {
+      "name": "Menu",
+      "item": [
+        {
+          "name": "List menu",
+          "request": {
+            "method": "GET",
+            "header": [{"key": "X-Tenant", "value": "acme"}, {"key": "Accept", "value": "application/json"}],
+            "url": {
+              "raw": "{{baseUrl}}/menu?limit=10",
+              "host": ["{{baseUrl}}"],
+              "path": ["menu"],
+              "query": [{"key": "limit", "value": "10"}]
+            }
+          },
+          "response": [
+            {"name": "OK", "code": 200, "body": "[{\"name\": \"Margherita\", \"price\": 10.5}]"}
+          ]
+        }
+      ]
+    },
+    {
+      "name": "Get order",
+      "auth": {"type": "noauth"},
+      "request": {
+        "method": "GET",
+        "url": {
+          "raw": "{{baseUrl}}/order/:orderId",
+          "variable": [{"key": "orderId", "value": "12"}]
+        }
+      }
+    },
+    {
+      "name": "Create order",
+      "request": {
+        "method": "POST",
+        "header": [{"key": "Content-Type", "value": "application/json"}],
+        "body": {"mode": "raw", "raw": "{\"name\": \"Margherita\", \"quantity\": 2}"},
+        "url": "{{baseUrl}}/order"
+      }
+    }
+  ]
+}`
+
+func TestBuildPostmanOpenAPI(t *testing.T) {
+	collection, err := ParsePostmanCollection([]byte(postmanTestCollection))
+	assert.Nil(t, err, "Should parse the collection")
+	definition, err := BuildPostmanOpenAPI(collection)
+	assert.Nil(t, err, "Should infer the OpenAPI definition")
+	doc, err := loadOpenAPI3(definition, "collection.json")
+	assert.Nil(t, err, "Should infer a valid OpenAPI definition")
+	assert.Nil(t, doc.Validate(context.Background()), "Should infer a valid OpenAPI definition")
+
+	assert.Equal(t, "PizzaShack", doc.Info.Title)
+	assert.Equal(t, "Pizza ordering API", doc.Info.Description)
+	assert.Equal(t, "https://pizza.example.com", doc.Servers[0].URL, "Should use the origin of the requests as the server")
+
+	menu := doc.Paths.Find("/api/menu").Get
+	assert.Equal(t, []string{"Menu"}, menu.Tags, "Should tag the operations by the folders")
+	assert.Equal(t, "List menu", menu.Summary)
+	assert.NotNil(t, menu.Parameters.GetByInAndName("query", "limit"))
+	assert.True(t, menu.Parameters.GetByInAndName("query", "limit").Schema.Value.Type.Is("integer"),
+		"Should infer the type of a parameter from its value")
+	assert.NotNil(t, menu.Parameters.GetByInAndName("header", "X-Tenant"))
+	assert.Nil(t, menu.Parameters.GetByInAndName("header", "Accept"), "Should not add the standard headers")
+	items := menu.Responses.Status(200).Value.Content.Get("application/json").Schema.Value.Items.Value
+	assert.True(t, items.Properties["price"].Value.Type.Is("number"), "Should infer the schema of the responses")
+	assert.NotNil(t, doc.Components.SecuritySchemes["default"],
+		"Should use the OAuth2 authentication of the collection as the security scheme")
+
+	order := doc.Paths.Find("/api/order/{orderId}").Get
+	assert.NotNil(t, order.Parameters.GetByInAndName("path", "orderId"), "Should convert the path variables")
+	assert.Equal(t, 0, len(*order.Security), "Should not secure the requests without authentication")
+
+	create := doc.Paths.Find("/api/order").Post
+	body := create.RequestBody.Value.Content.Get("application/json")
+	assert.True(t, body.Schema.Value.Properties["quantity"].Value.Type.Is("integer"),
+		"Should infer the schema of the request body")
+}
+
+func TestInitAPIProjectFromPostman(t *testing.T) {
+	dir := t.TempDir()
+	collectionPath := filepath.Join(dir, "PizzaShack.postman_collection.json")
+	writeApplyTestFile(t, collectionPath, postmanTestCollection)
+	projectPath := filepath.Join(dir, "PizzaShack")
+
+	err := InitAPIProject(projectPath, "", collectionPath, InitSourcePostman, "", false)
+	assert.Nil(t, err, "Should initialize the project from the collection")
+	assert.FileExists(t, filepath.Join(projectPath, "api.yaml"))
+	assert.FileExists(t, filepath.Join(projectPath, "Definitions", "swagger.yaml"))
+}
+
+func TestExportPostmanCollectionFromProject(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "PizzaShackAPI")
+	writeApplyTestFile(t, filepath.Join(projectPath, "api.yaml"), `type: api
+version: v4.7.0
+data:
+  name: PizzaShackAPI
+  context: /pizzashack
+  version: 1.0.0
+`)
+	writeApplyTestFile(t, filepath.Join(projectPath, "Definitions", "swagger.yaml"), `openapi: 3.0.1
+info:
+  title: PizzaShackAPI
+  version: 1.0.0
+tags:
+  - name: Order
+    description: Pizza orders
+paths:
+  /menu:
+    get:
+      summary: List menu
+      security: []
+      responses:
+        "200":
+          description: OK
+  /order/{orderId}:
+    get:
+      tags: [Order]
+      operationId: getOrder
+      parameters:
+        - name: orderId
+          in: path
+          required: true
+          schema:
+            type: string
+            example: "12"
+      responses:
+        "200":
+          description: OK
+    put:
+      tags: [Order]
+      parameters:
+        - name: orderId
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            example:
+              quantity: 2
+      responses:
+        "200":
+          description: OK
+`)
+	writeApplyTestFile(t, filepath.Join(projectPath, "deployment_environments.yaml"), `type: deployment_environments
+version: v4.7.0
+data:
+  - displayOnDevportal: true
+    deploymentEnvironment: Default
+    deploymentVhost: gw.example.com
+  - displayOnDevportal: true
+    deploymentEnvironment: Internal
+    deploymentVhost: internal.example.com
+`)
+
+	collection, err := ExportPostmanCollectionFromProject(projectPath, "")
+	assert.Nil(t, err, "Should generate the collection")
+	assert.Equal(t, "PizzaShackAPI 1.0.0", collection.Info.Name)
+	assert.Equal(t, "oauth2", collection.Auth.Type, "Should authenticate the collection with OAuth2")
+	assert.Equal(t, "{{tokenUrl}}", collection.Auth.attribute("accessTokenUrl"))
+
+	variables := make(map[string]string)
+	for _, variable := range collection.Variable {
+		variables[variable.Key] = string(variable.Value)
+	}
+	assert.Equal(t, "https://gw.example.com:8243/pizzashack/1.0.0", variables["baseUrl"])
+	assert.Equal(t, "https://internal.example.com:8243/pizzashack/1.0.0", variables["baseUrl_Internal"])
+
+	assert.Equal(t, 2, len(collection.Item))
+	menu := collection.Item[0]
+	assert.Equal(t, "List menu", menu.Name)
+	assert.Equal(t, "noauth", menu.Request.Auth.Type, "Should not authenticate the unsecured operations")
+	order := collection.Item[1]
+	assert.Equal(t, "Order", order.Name, "Should group the operations by the tags")
+	assert.Equal(t, "Pizza orders", string(order.Description))
+	assert.Equal(t, 2, len(order.Item))
+	getOrder := order.Item[0].Request
+	assert.Equal(t, "{{baseUrl}}/order/:orderId", getOrder.URL.Raw)
+	assert.Equal(t, "12", string(getOrder.URL.Variable[0].Value))
+
+	putOrder := order.Item[1].Request
+	assert.Equal(t, "PUT", putOrder.Method)
+	body := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal([]byte(putOrder.Body.Raw), &body))
+	assert.Equal(t, float64(2), body["quantity"])
+
+	path, err := WritePostmanCollection(collection, t.TempDir())
+	assert.Nil(t, err, "Should write the collection")
+	assert.Equal(t, "PizzaShackAPI_1.0.0.postman_collection.json", filepath.Base(path))
+}
@@ -21,9 +21,9 @@ package impl
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 // Violation represents a violation
@@ -58,21 +58,21 @@ func PrintViolations(violations []Violation, apiLoggingCmdFormat string) {
 	if apiLoggingCmdFormat == "json" {
 		violationsJSON, err := json.MarshalIndent(violations, "", "  ")
 		if err != nil {
-			fmt.Println("Error marshaling violations to JSON:", err)
+			fmt.Fprintln(utils.Stdout, "Error marshaling violations to JSON:", err)
 			return
 		}
-		fmt.Println(string(violationsJSON))
+		fmt.Fprintln(utils.Stdout, string(violationsJSON))
 		return
 
 	} else if apiLoggingCmdFormat == "list" {
 		// Print violations in list format
-		fmt.Println("\nViolations:")
+		fmt.Fprintln(utils.Stdout, "\nViolations:")
 		for _, violation := range violations {
-			fmt.Println("- Policy:", violation.Policy)
+			fmt.Fprintln(utils.Stdout, "- Policy:", violation.Policy)
 			for _, ruleset := range violation.Rulesets {
-				fmt.Println("  Ruleset:", ruleset.Ruleset)
+				fmt.Fprintln(utils.Stdout, "  Ruleset:", ruleset.Ruleset)
 				for _, rule := range ruleset.RuleViolations {
-					fmt.Printf("    - Path: %s | Message: %s | Severity: %s\n", rule.Path, rule.Message, rule.Severity)
+					fmt.Fprintf(utils.Stdout, "    - Path: %s | Message: %s | Severity: %s\n", rule.Path, rule.Message, rule.Severity)
 				}
 			}
 		}
@@ -81,9 +81,9 @@ func PrintViolations(violations []Violation, apiLoggingCmdFormat string) {
 		for _, violation := range violations {
 
 			for _, ruleset := range violation.Rulesets {
-				fmt.Printf("\nPolicy: %s\nRuleset: %s\n\n", violation.Policy, ruleset.Ruleset)
+				fmt.Fprintf(utils.Stdout, "\nPolicy: %s\nRuleset: %s\n\n", violation.Policy, ruleset.Ruleset)
 				// Create table for each ruleset
-				table := tablewriter.NewWriter(os.Stdout)
+				table := tablewriter.NewWriter(utils.Stdout)
 				table.SetHeader([]string{"Path", "Message", "Severity"})
 				// Append violations to the table
 				for _, violation := range ruleset.RuleViolations {
@@ -0,0 +1,47 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"gopkg.in/yaml.v2"
+)
+
+// RenderParams returns the effective configs of an environment in a params file as YAML. The configs of the
+// environment are merged on top of the base configs of the file.
+// @param paramsPath : Path to the params file or the deployment directory containing it
+// @param environment : Name of the environment in the params file
+// @return the name and the configs of the environment as YAML
+// @return error
+func RenderParams(paramsPath, environment string) ([]byte, error) {
+	envParams, err := params.LoadEnvironmentParams(paramsPath)
+	if err != nil {
+		return nil, err
+	}
+	env, err := envParams.GetEffectiveEnv(environment)
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		return nil, errors.New("Environment '" + environment + "' does not exist in " + paramsPath)
+	}
+	return yaml.Marshal(env)
+}
@@ -0,0 +1,172 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// revisionDeploymentRecord is a deployment of a revision to a gateway environment. The environment only has the
+// current deployments, hence the deployments done by apictl are recorded in the deployment history to be able to roll
+// back to the previous ones.
+type revisionDeploymentRecord struct {
+	GatewayEnv         string `yaml:"gatewayEnv"`
+	Vhost              string `yaml:"vhost,omitempty"`
+	DisplayOnDevportal bool   `yaml:"displayOnDevportal"`
+	Revision           string `yaml:"revision"`
+	DeployedTime       string `yaml:"deployedTime,omitempty"`
+}
+
+// revisionHistory has the deployments of the artifacts, from the oldest to the latest, by the URL of the artifact
+type revisionHistory map[string][]revisionDeploymentRecord
+
+// getRevisionHistoryFilePath returns the file of the deployment history, which is kept with the main config
+func getRevisionHistoryFilePath() string {
+	return filepath.Join(filepath.Dir(utils.MainConfigFilePath), utils.DeploymentHistoryFileName)
+}
+
+// loadRevisionHistory reads the deployment history. The history is empty if nothing has been deployed yet.
+func loadRevisionHistory() (revisionHistory, error) {
+	history := make(revisionHistory)
+	data, err := ioutil.ReadFile(getRevisionHistoryFilePath())
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// saveRevisionHistory writes the deployment history
+func saveRevisionHistory(history revisionHistory) error {
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(getRevisionHistoryFilePath(), data, 0644)
+}
+
+// getRevisionHistoryKey returns the key of the deployments of an artifact in the deployment history
+func getRevisionHistoryKey(artifact *revisionedArtifact) string {
+	return artifact.endpoint + artifact.id
+}
+
+// appendRevisionDeployment records the deployment of a revision to a gateway environment. The revision deployed in
+// the gateway environment before is recorded first if it was not deployed by apictl, so that it can be rolled back to.
+func appendRevisionDeployment(records []revisionDeploymentRecord, revisions []utils.Revisions,
+	deployment utils.Deployment, revisionNum string) []revisionDeploymentRecord {
+	if deployed, current := getRevisionDeployedIn(revisions, deployment.Name); deployed != nil {
+		deployedNum := utils.GetRevisionNumFromRevisionName(deployed.RevisionNumber)
+		if last := getLastRevisionDeployment(records, deployment.Name); last == nil || last.Revision != deployedNum {
+			records = append(records, revisionDeploymentRecord{GatewayEnv: current.Name, Vhost: current.Vhost,
+				DisplayOnDevportal: current.DisplayOnDevportal, Revision: deployedNum})
+		}
+	}
+	return append(records, revisionDeploymentRecord{GatewayEnv: deployment.Name, Vhost: deployment.Vhost,
+		DisplayOnDevportal: deployment.DisplayOnDevportal, Revision: revisionNum,
+		DeployedTime: time.Now().UTC().Format(time.RFC3339)})
+}
+
+// getLastRevisionDeployment returns the latest recorded deployment of a gateway environment, or nil if there is none
+func getLastRevisionDeployment(records []revisionDeploymentRecord, gatewayEnv string) *revisionDeploymentRecord {
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].GatewayEnv == gatewayEnv {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+// getPreviousRevisionDeployment returns the index of the latest recorded deployment of a gateway environment having
+// a revision other than the deployed revision that still exists, or -1 if there is none
+func getPreviousRevisionDeployment(records []revisionDeploymentRecord, revisions []utils.Revisions, gatewayEnv,
+	deployedNum string) int {
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].GatewayEnv == gatewayEnv && records[i].Revision != deployedNum &&
+			getRevisionByNumber(revisions, records[i].Revision) != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// recordRevisionDeployments records the deployments of a revision of an artifact in the deployment history. The
+// revisions are the ones before the deployment.
+func recordRevisionDeployments(artifact *revisionedArtifact, revisions []utils.Revisions, revisionNum string,
+	deployments []utils.Deployment) {
+	updateRevisionHistory(artifact, func(records []revisionDeploymentRecord) []revisionDeploymentRecord {
+		for _, deployment := range deployments {
+			records = appendRevisionDeployment(records, revisions, deployment, revisionNum)
+		}
+		return records
+	})
+}
+
+// recordRevisionRollbacks records the rollbacks of an artifact in the deployment history. Rolling back to the
+// previous deployment removes the deployments recorded after it, so that the next rollback goes further back, while
+// rolling back to a given revision is recorded as a deployment.
+func recordRevisionRollbacks(artifact *revisionedArtifact, revisions []utils.Revisions, rollbacks []RevisionRollback) {
+	updateRevisionHistory(artifact, func(records []revisionDeploymentRecord) []revisionDeploymentRecord {
+		previous := make(map[string]int)
+		for _, rollback := range rollbacks {
+			if rollback.historyIndex >= 0 {
+				previous[rollback.GatewayEnv] = rollback.historyIndex
+			}
+		}
+		var updated []revisionDeploymentRecord
+		for i, record := range records {
+			if index, ok := previous[record.GatewayEnv]; !ok || i <= index {
+				updated = append(updated, record)
+			}
+		}
+		for _, rollback := range rollbacks {
+			if rollback.historyIndex < 0 {
+				updated = appendRevisionDeployment(updated, revisions, utils.Deployment{Name: rollback.GatewayEnv,
+					Vhost: rollback.Vhost, DisplayOnDevportal: rollback.DisplayOnDevportal}, rollback.ToRevision)
+			}
+		}
+		return updated
+	})
+}
+
+// updateRevisionHistory updates the recorded deployments of an artifact. Failing to record is not an error of the
+// deployment, which is already done, hence it is only reported.
+func updateRevisionHistory(artifact *revisionedArtifact,
+	update func([]revisionDeploymentRecord) []revisionDeploymentRecord) {
+	history, err := loadRevisionHistory()
+	if err == nil {
+		key := getRevisionHistoryKey(artifact)
+		history[key] = update(history[key])
+		err = saveRevisionHistory(history)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to record the deployment history in "+getRevisionHistoryFilePath()+":", err)
+	}
+}
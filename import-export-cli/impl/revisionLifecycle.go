@@ -0,0 +1,453 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Types of the artifacts having revisions
+const (
+	RevisionArtifactAPI        = "API"
+	RevisionArtifactAPIProduct = "API Product"
+	RevisionArtifactMCPServer  = "MCP Server"
+)
+
+// RevisionRollback is the rollback of a gateway environment from a revision to an older revision
+type RevisionRollback struct {
+	GatewayEnv         string
+	Vhost              string
+	FromRevision       string
+	ToRevision         string
+	DisplayOnDevportal bool
+
+	// historyIndex is the index of the recorded deployment rolled back to, or -1 if a revision was given
+	historyIndex int
+}
+
+// revisionedArtifact is an API, API Product or MCP Server having revisions in an environment
+type revisionedArtifact struct {
+	// endpoint is the publisher resource of the type of the artifact. Eg: https://localhost:9443/api/am/publisher/v4/apis
+	endpoint string
+	id       string
+}
+
+// publisherSettings is the part of the publisher settings having the gateway environments
+type publisherSettings struct {
+	Environment []struct {
+		Name   string `json:"name"`
+		Vhosts []struct {
+			Host        string `json:"host"`
+			HttpContext string `json:"httpContext"`
+			HttpsPort   int    `json:"httpsPort"`
+		} `json:"vhosts"`
+	} `json:"environment"`
+}
+
+// DeployRevision deploys a revision of an API, API Product or MCP Server to gateway environments. The revision is
+// either given by the revision number or is the revision deployed in another gateway environment, so that it can be
+// promoted. The default vhost of a gateway environment is used if the vhost of a deployment is not given.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the artifact
+// @param artifactType : One of RevisionArtifactAPI, RevisionArtifactAPIProduct or RevisionArtifactMCPServer
+// @param name : Name of the artifact
+// @param version : Version of the artifact
+// @param provider : Provider of the artifact
+// @param revisionNum : Revision number to deploy
+// @param fromGatewayEnv : Gateway environment of the revision to deploy, if the revision number is not given
+// @param deployments : Gateway environments to deploy the revision
+// @return the deployed revision number
+// @return error
+func DeployRevision(accessToken, environment, artifactType, name, version, provider, revisionNum,
+	fromGatewayEnv string, deployments []utils.Deployment) (string, error) {
+	artifact, err := getRevisionedArtifact(accessToken, environment, artifactType, name, version, provider)
+	if err != nil {
+		return "", err
+	}
+	revisions, err := getSortedRevisions(accessToken, artifact)
+	if err != nil {
+		return "", err
+	}
+	var revision *utils.Revisions
+	if fromGatewayEnv != "" {
+		revision, _ = getRevisionDeployedIn(revisions, fromGatewayEnv)
+		if revision == nil {
+			return "", fmt.Errorf("no revision of the %s is deployed in the gateway environment %s", artifactType,
+				fromGatewayEnv)
+		}
+	} else if revision = getRevisionByNumber(revisions, revisionNum); revision == nil {
+		return "", fmt.Errorf("revision %s of the %s is not found", revisionNum, artifactType)
+	}
+
+	if err := resolveDefaultVhosts(accessToken, environment, deployments); err != nil {
+		return "", err
+	}
+	if err := deployRevision(accessToken, artifact, revision.ID, deployments); err != nil {
+		return "", err
+	}
+	revisionNum = utils.GetRevisionNumFromRevisionName(revision.RevisionNumber)
+	recordRevisionDeployments(artifact, revisions, revisionNum, deployments)
+	return revisionNum, nil
+}
+
+// RollbackRevision deploys the revision deployed before the current revision in each gateway environment of an API,
+// API Product or MCP Server, keeping the vhosts of the deployments. The previous revision and whether it was displayed
+// on the devportal are read from the deployment history recorded by the deploy and rollback commands. If no prior
+// deployment is recorded, the latest older revision deployed in another gateway environment, or else the latest older
+// revision, is rolled back to. A revision number can be given to roll back to a specific revision instead.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the artifact
+// @param artifactType : One of RevisionArtifactAPI, RevisionArtifactAPIProduct or RevisionArtifactMCPServer
+// @param name : Name of the artifact
+// @param version : Version of the artifact
+// @param provider : Provider of the artifact
+// @param revisionNum : Revision number to roll back to. The previously deployed revision is used if it is empty
+// @param gatewayEnvs : Gateway environments to roll back. All the gateway environments are rolled back if empty
+// @return rollbacks of the gateway environments
+// @return error
+func RollbackRevision(accessToken, environment, artifactType, name, version, provider, revisionNum string,
+	gatewayEnvs []string) ([]RevisionRollback, error) {
+	artifact, err := getRevisionedArtifact(accessToken, environment, artifactType, name, version, provider)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := getSortedRevisions(accessToken, artifact)
+	if err != nil {
+		return nil, err
+	}
+	history, err := loadRevisionHistory()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the deployment history: %w", err)
+	}
+	rollbacks, err := getRevisionRollbacks(revisions, history[getRevisionHistoryKey(artifact)], revisionNum,
+		gatewayEnvs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The deployments of each target revision are deployed together
+	deployments := make(map[string][]utils.Deployment)
+	var targets []string
+	for _, rollback := range rollbacks {
+		if _, ok := deployments[rollback.ToRevision]; !ok {
+			targets = append(targets, rollback.ToRevision)
+		}
+		deployments[rollback.ToRevision] = append(deployments[rollback.ToRevision],
+			utils.Deployment{Name: rollback.GatewayEnv, Vhost: rollback.Vhost,
+				DisplayOnDevportal: rollback.DisplayOnDevportal})
+	}
+	for _, target := range targets {
+		revision := getRevisionByNumber(revisions, target)
+		if err := deployRevision(accessToken, artifact, revision.ID, deployments[target]); err != nil {
+			return nil, err
+		}
+	}
+	recordRevisionRollbacks(artifact, revisions, rollbacks)
+	return rollbacks, nil
+}
+
+// PruneRevisions deletes the oldest revisions of an API, API Product or MCP Server, keeping the given number of the
+// latest revisions. Revisions deployed in a gateway environment are never deleted.
+// @param accessToken : Access Token for the environment
+// @param environment : Environment of the artifact
+// @param artifactType : One of RevisionArtifactAPI, RevisionArtifactAPIProduct or RevisionArtifactMCPServer
+// @param name : Name of the artifact
+// @param version : Version of the artifact
+// @param provider : Provider of the artifact
+// @param keep : Number of the latest revisions to keep
+// @param dryRun : Only return the revisions that would be deleted
+// @return the deleted revision numbers
+// @return error
+func PruneRevisions(accessToken, environment, artifactType, name, version, provider string, keep int,
+	dryRun bool) ([]string, error) {
+	if keep < 0 {
+		return nil, errors.New("the number of revisions to keep cannot be negative")
+	}
+	artifact, err := getRevisionedArtifact(accessToken, environment, artifactType, name, version, provider)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := getSortedRevisions(accessToken, artifact)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []string
+	for _, revision := range getPrunableRevisions(revisions, keep) {
+		revisionNum := utils.GetRevisionNumFromRevisionName(revision.RevisionNumber)
+		if !dryRun {
+			if err := deleteRevision(accessToken, artifact, revision.ID); err != nil {
+				return pruned, err
+			}
+		}
+		pruned = append(pruned, revisionNum)
+	}
+	return pruned, nil
+}
+
+// getRevisionedArtifact resolves the publisher resource and the id of an artifact having revisions
+func getRevisionedArtifact(accessToken, environment, artifactType, name, version,
+	provider string) (*revisionedArtifact, error) {
+	var endpoint, id string
+	var err error
+	switch artifactType {
+	case RevisionArtifactAPI:
+		endpoint = utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+		id, err = GetAPIId(accessToken, environment, name, version, provider)
+	case RevisionArtifactAPIProduct:
+		endpoint = utils.GetApiProductListEndpointOfEnv(environment, utils.MainConfigFilePath)
+		id, err = GetAPIProductId(accessToken, environment, name, version, provider)
+	case RevisionArtifactMCPServer:
+		endpoint = utils.GetMcpServerListEndpointOfEnv(environment, utils.MainConfigFilePath)
+		id, err = GetMCPServerId(accessToken, environment, name, version, provider)
+	default:
+		return nil, errors.New("revisions are not supported for " + artifactType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revisionedArtifact{endpoint: utils.AppendSlashToString(endpoint), id: id}, nil
+}
+
+// getSortedRevisions returns the revisions of an artifact sorted by the revision number
+func getSortedRevisions(accessToken string, artifact *revisionedArtifact) ([]utils.Revisions, error) {
+	_, revisions, err := GetRevisionsList(accessToken, artifact.endpoint+artifact.id+"/revisions")
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return getRevisionNumber(revisions[i]) < getRevisionNumber(revisions[j])
+	})
+	return revisions, nil
+}
+
+// getRevisionNumber returns the revision number of a revision, which is named as "Revision <number>"
+func getRevisionNumber(revision utils.Revisions) int {
+	number, _ := strconv.Atoi(utils.GetRevisionNumFromRevisionName(revision.RevisionNumber))
+	return number
+}
+
+// getRevisionByNumber returns the revision having the revision number, or nil if it does not exist
+func getRevisionByNumber(revisions []utils.Revisions, revisionNum string) *utils.Revisions {
+	for i := range revisions {
+		if utils.GetRevisionNumFromRevisionName(revisions[i].RevisionNumber) == revisionNum {
+			return &revisions[i]
+		}
+	}
+	return nil
+}
+
+// getRevisionDeployedIn returns the revision deployed in a gateway environment and the deployment, or nil if no
+// revision is deployed in it
+func getRevisionDeployedIn(revisions []utils.Revisions, gatewayEnv string) (*utils.Revisions, *utils.Deployment) {
+	for i := range revisions {
+		for j := range revisions[i].Deployments {
+			if revisions[i].Deployments[j].Name == gatewayEnv {
+				return &revisions[i], &revisions[i].Deployments[j]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// getRevisionRollbacks returns the rollbacks of the gateway environments where the revisions sorted by the revision
+// number are deployed. Each gateway environment is rolled back to the given revision number or, if it is empty, to
+// the revision recorded as deployed before the deployed revision. The revisions of the environment decide the
+// revision to roll back to if no prior deployment is recorded. The recorded deployment of the revision decides
+// whether it is displayed on the devportal, or else the current deployment.
+func getRevisionRollbacks(revisions []utils.Revisions, records []revisionDeploymentRecord, revisionNum string,
+	gatewayEnvs []string) ([]RevisionRollback, error) {
+	if revisionNum != "" && getRevisionByNumber(revisions, revisionNum) == nil {
+		return nil, fmt.Errorf("revision %s is not found", revisionNum)
+	}
+	if len(gatewayEnvs) == 0 {
+		for _, revision := range revisions {
+			for _, deployment := range revision.Deployments {
+				gatewayEnvs = append(gatewayEnvs, deployment.Name)
+			}
+		}
+		if len(gatewayEnvs) == 0 {
+			return nil, errors.New("no revision is deployed in a gateway environment")
+		}
+		sort.Strings(gatewayEnvs)
+	}
+
+	var rollbacks []RevisionRollback
+	for _, gatewayEnv := range gatewayEnvs {
+		deployed, deployment := getRevisionDeployedIn(revisions, gatewayEnv)
+		if deployed == nil {
+			return nil, fmt.Errorf("no revision is deployed in the gateway environment %s", gatewayEnv)
+		}
+		rollback := RevisionRollback{GatewayEnv: gatewayEnv, Vhost: deployment.Vhost,
+			FromRevision: utils.GetRevisionNumFromRevisionName(deployed.RevisionNumber), ToRevision: revisionNum,
+			DisplayOnDevportal: deployment.DisplayOnDevportal, historyIndex: -1}
+		if revisionNum == "" {
+			rollback.historyIndex = getPreviousRevisionDeployment(records, revisions, gatewayEnv,
+				rollback.FromRevision)
+			if rollback.historyIndex >= 0 {
+				rollback.ToRevision = records[rollback.historyIndex].Revision
+				rollback.DisplayOnDevportal = records[rollback.historyIndex].DisplayOnDevportal
+			} else if previous := getPreviousRevision(revisions, deployed); previous != nil {
+				rollback.ToRevision = utils.GetRevisionNumFromRevisionName(previous.RevisionNumber)
+			} else {
+				return nil, fmt.Errorf("no revision prior to revision %s deployed in the gateway environment %s "+
+					"is found. Specify the revision to roll back to using --rev", rollback.FromRevision, gatewayEnv)
+			}
+		} else {
+			for _, record := range records {
+				if record.GatewayEnv == gatewayEnv && record.Revision == revisionNum {
+					rollback.DisplayOnDevportal = record.DisplayOnDevportal
+				}
+			}
+		}
+		if rollback.ToRevision == rollback.FromRevision {
+			return nil, fmt.Errorf("revision %s is already deployed in the gateway environment %s",
+				rollback.ToRevision, gatewayEnv)
+		}
+		rollbacks = append(rollbacks, rollback)
+	}
+	return rollbacks, nil
+}
+
+// getPreviousRevision returns the revision to roll back to from a deployed revision when no prior deployment is
+// recorded, such as when the revision was deployed from another machine or from the publisher. It is the latest
+// revision older than the deployed revision that is deployed in another gateway environment, or else the latest
+// revision older than the deployed revision. Nil is returned if there is no older revision.
+func getPreviousRevision(revisions []utils.Revisions, deployed *utils.Revisions) *utils.Revisions {
+	var previous *utils.Revisions
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if getRevisionNumber(revisions[i]) >= getRevisionNumber(*deployed) {
+			continue
+		}
+		if len(revisions[i].Deployments) > 0 {
+			return &revisions[i]
+		}
+		if previous == nil {
+			previous = &revisions[i]
+		}
+	}
+	return previous
+}
+
+// getPrunableRevisions returns the revisions sorted by the revision number that are older than the given number of
+// the latest revisions and are not deployed in any gateway environment
+func getPrunableRevisions(revisions []utils.Revisions, keep int) []utils.Revisions {
+	var prunable []utils.Revisions
+	for i := 0; i < len(revisions)-keep; i++ {
+		if len(revisions[i].Deployments) == 0 {
+			prunable = append(prunable, revisions[i])
+		}
+	}
+	return prunable
+}
+
+// resolveDefaultVhosts sets the default vhost of the gateway environment to the deployments without a vhost
+func resolveDefaultVhosts(accessToken, environment string, deployments []utils.Deployment) error {
+	var settings *publisherSettings
+	for i := range deployments {
+		if deployments[i].Vhost != "" {
+			continue
+		}
+		if settings == nil {
+			var err error
+			if settings, err = getPublisherSettings(accessToken, environment); err != nil {
+				return err
+			}
+		}
+		for _, gatewayEnv := range settings.Environment {
+			if gatewayEnv.Name == deployments[i].Name && len(gatewayEnv.Vhosts) > 0 {
+				deployments[i].Vhost = gatewayEnv.Vhosts[0].Host
+			}
+		}
+		if deployments[i].Vhost == "" {
+			return fmt.Errorf("unable to find the default vhost of the gateway environment %s. Specify the vhost "+
+				"using --vhost", deployments[i].Name)
+		}
+	}
+	return nil
+}
+
+// getPublisherSettings returns the publisher settings having the gateway environments of an environment
+func getPublisherSettings(accessToken, environment string) (*publisherSettings, error) {
+	settingsEndpoint := utils.GetPublisherSettingsEndpointOfEnv(environment, utils.MainConfigFilePath)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	utils.Logln(utils.LogPrefixInfo+"URL:", settingsEndpoint)
+	resp, err := utils.InvokeGETRequest(settingsEndpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, utils.NewHttpResponseError(resp)
+	}
+	settings := &publisherSettings{}
+	if err := json.Unmarshal(resp.Body(), settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// deployRevision deploys a revision of an artifact to gateway environments
+func deployRevision(accessToken string, artifact *revisionedArtifact, revisionId string,
+	deployments []utils.Deployment) error {
+	deployRevisionEndpoint := artifact.endpoint + artifact.id + "/deploy-revision?revisionId=" + revisionId
+	utils.Logln(utils.LogPrefixInfo+"Deploy URL:", deployRevisionEndpoint)
+
+	headers := make(map[string]string)
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	body, err := json.Marshal(deployments)
+	if err != nil {
+		return err
+	}
+	resp, err := utils.InvokePOSTRequest(deployRevisionEndpoint, headers, string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return utils.NewHttpResponseError(resp)
+	}
+	return nil
+}
+
+// deleteRevision deletes a revision of an artifact
+func deleteRevision(accessToken string, artifact *revisionedArtifact, revisionId string) error {
+	deleteRevisionEndpoint := artifact.endpoint + artifact.id + "/revisions/" + revisionId
+	utils.Logln(utils.LogPrefixInfo+"Delete URL:", deleteRevisionEndpoint)
+
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	resp, err := utils.InvokeDELETERequest(deleteRevisionEndpoint, headers)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
+		return utils.NewHttpResponseError(resp)
+	}
+	return nil
+}
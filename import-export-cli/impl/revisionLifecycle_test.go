@@ -0,0 +1,260 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func getTestRevisions() []utils.Revisions {
+	return []utils.Revisions{
+		{ID: "rev-1", RevisionNumber: "Revision 1"},
+		{ID: "rev-2", RevisionNumber: "Revision 2",
+			Deployments: []utils.Deployment{{Name: "staging", Vhost: "staging.wso2.com"}}},
+		{ID: "rev-3", RevisionNumber: "Revision 3"},
+		{ID: "rev-4", RevisionNumber: "Revision 4",
+			Deployments: []utils.Deployment{{Name: "production", Vhost: "api.wso2.com"}}},
+		{ID: "rev-5", RevisionNumber: "Revision 5"},
+	}
+}
+
+func getTestRevisionDeploymentRecords() []revisionDeploymentRecord {
+	return []revisionDeploymentRecord{
+		{GatewayEnv: "production", Vhost: "api.wso2.com", Revision: "1", DisplayOnDevportal: true},
+		{GatewayEnv: "staging", Vhost: "staging.wso2.com", Revision: "3", DisplayOnDevportal: true},
+		{GatewayEnv: "production", Vhost: "api.wso2.com", Revision: "5"},
+		{GatewayEnv: "staging", Vhost: "staging.wso2.com", Revision: "6"},
+		{GatewayEnv: "staging", Vhost: "staging.wso2.com", Revision: "2", DisplayOnDevportal: true},
+		{GatewayEnv: "production", Vhost: "api.wso2.com", Revision: "4", DisplayOnDevportal: true},
+	}
+}
+
+func TestGetRevisionRollbacksToPreviousRevision(t *testing.T) {
+	records := getTestRevisionDeploymentRecords()
+	rollbacks, err := getRevisionRollbacks(getTestRevisions(), records, "", nil)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []RevisionRollback{
+		{GatewayEnv: "production", Vhost: "api.wso2.com", FromRevision: "4", ToRevision: "5", historyIndex: 2},
+		{GatewayEnv: "staging", Vhost: "staging.wso2.com", FromRevision: "2", ToRevision: "3",
+			DisplayOnDevportal: true, historyIndex: 1},
+	}, rollbacks, "All the gateway environments should be rolled back to the previously deployed revision "+
+		"that still exists")
+
+	rollbacks, err = getRevisionRollbacks(getTestRevisions(), records, "", []string{"production"})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []RevisionRollback{
+		{GatewayEnv: "production", Vhost: "api.wso2.com", FromRevision: "4", ToRevision: "5", historyIndex: 2},
+	}, rollbacks, "Only the given gateway environment should be rolled back")
+}
+
+func TestGetRevisionRollbacksToRevision(t *testing.T) {
+	records := getTestRevisionDeploymentRecords()
+	rollbacks, err := getRevisionRollbacks(getTestRevisions(), records, "1", []string{"production"})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []RevisionRollback{
+		{GatewayEnv: "production", Vhost: "api.wso2.com", FromRevision: "4", ToRevision: "1",
+			DisplayOnDevportal: true, historyIndex: -1},
+	}, rollbacks, "The devportal visibility should be the recorded one")
+
+	rollbacks, err = getRevisionRollbacks(getTestRevisions(), nil, "3", []string{"production"})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []RevisionRollback{
+		{GatewayEnv: "production", Vhost: "api.wso2.com", FromRevision: "4", ToRevision: "3", historyIndex: -1},
+	}, rollbacks, "The devportal visibility of the current deployment should be kept")
+
+	_, err = getRevisionRollbacks(getTestRevisions(), records, "2", []string{"staging"})
+	assert.Error(t, err, "Should return an error if the revision is already deployed")
+
+	_, err = getRevisionRollbacks(getTestRevisions(), records, "9", nil)
+	assert.Error(t, err, "Should return an error for a missing revision")
+}
+
+func TestGetRevisionRollbacksWithoutHistory(t *testing.T) {
+	rollbacks, err := getRevisionRollbacks(getTestRevisions(), nil, "", nil)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []RevisionRollback{
+		{GatewayEnv: "production", Vhost: "api.wso2.com", FromRevision: "4", ToRevision: "2", historyIndex: -1},
+		{GatewayEnv: "staging", Vhost: "staging.wso2.com", FromRevision: "2", ToRevision: "1", historyIndex: -1},
+	}, rollbacks, "The latest older revision deployed in another gateway environment, or else the latest older "+
+		"revision, should be rolled back to")
+
+	rollbacks, err = getRevisionRollbacks(getTestRevisions(), getTestRevisionDeploymentRecords()[3:5], "",
+		[]string{"staging"})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "1", rollbacks[0].ToRevision, "The deployments of deleted revisions should be ignored")
+}
+
+func TestGetRevisionRollbacksErrors(t *testing.T) {
+	_, err := getRevisionRollbacks(getTestRevisions()[1:2], nil, "", []string{"staging"})
+	assert.Error(t, err, "Should return an error if there is no older revision")
+
+	_, err = getRevisionRollbacks(getTestRevisions(), getTestRevisionDeploymentRecords(), "", []string{"dev"})
+	assert.Error(t, err, "Should return an error if no revision is deployed in the gateway environment")
+
+	_, err = getRevisionRollbacks(getTestRevisions()[:1], getTestRevisionDeploymentRecords(), "", nil)
+	assert.Error(t, err, "Should return an error if no revision is deployed")
+}
+
+func TestGetPrunableRevisions(t *testing.T) {
+	revisions := getTestRevisions()
+	assert.Equal(t, []utils.Revisions{revisions[0], revisions[2]}, getPrunableRevisions(revisions, 2),
+		"Deployed revisions and the latest revisions should be kept")
+	assert.Equal(t, []utils.Revisions{revisions[0], revisions[2], revisions[4]}, getPrunableRevisions(revisions, 0))
+	assert.Empty(t, getPrunableRevisions(revisions, 5), "All the revisions should be kept")
+	assert.Empty(t, getPrunableRevisions(revisions, 10), "All the revisions should be kept")
+}
+
+// newRevisionTestServer creates a publisher having the test revisions of the API pizza-id
+func newRevisionTestServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/search":
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"id":"pizza-id","name":"PizzaShackAPI"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/apis/pizza-id/revisions":
+			_, _ = w.Write([]byte(`{"count":5,"list":[
+				{"id":"rev-5","displayName":"Revision 5","deploymentInfo":[]},
+				{"id":"rev-4","displayName":"Revision 4","deploymentInfo":[{"name":"production","vhost":"api.wso2.com"}]},
+				{"id":"rev-3","displayName":"Revision 3","deploymentInfo":[]},
+				{"id":"rev-2","displayName":"Revision 2","deploymentInfo":[{"name":"staging","vhost":"staging.wso2.com"}]},
+				{"id":"rev-1","displayName":"Revision 1","deploymentInfo":[]}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/settings":
+			_, _ = w.Write([]byte(`{"environment":[{"name":"production","vhosts":[{"host":"api.wso2.com"}]},
+				{"name":"dr","vhosts":[{"host":"dr.wso2.com"},{"host":"dr2.wso2.com"}]}]}`))
+		default:
+			handler(w, r)
+		}
+	}))
+}
+
+func TestDeployRevisionPromotesToDefaultVhost(t *testing.T) {
+	var deployed string
+	server := newRevisionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/am/publisher/v4/apis/pizza-id/deploy-revision" {
+			body, _ := ioutil.ReadAll(r.Body)
+			deployed = r.URL.Query().Get("revisionId") + " " + string(body)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+	})
+	defer server.Close()
+	useTestEnvironment(t, "dev", server.URL)
+
+	revisionNum, err := DeployRevision("access-token", "dev", RevisionArtifactAPI, "PizzaShackAPI", "1.0.0", "",
+		"", "staging", []utils.Deployment{{Name: "dr", DisplayOnDevportal: true}})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "2", revisionNum, "The revision deployed in the staging gateway should be deployed")
+	assert.Equal(t, `rev-2 [{"name":"dr","vhost":"dr.wso2.com","displayOnDevportal":true}]`, deployed)
+
+	_, err = DeployRevision("access-token", "dev", RevisionArtifactAPI, "PizzaShackAPI", "1.0.0", "",
+		"7", "", []utils.Deployment{{Name: "dr"}})
+	assert.Error(t, err, "Should return an error for a missing revision")
+
+	_, err = DeployRevision("access-token", "dev", RevisionArtifactAPI, "PizzaShackAPI", "1.0.0", "",
+		"3", "", []utils.Deployment{{Name: "unknown"}})
+	assert.Error(t, err, "Should return an error if the default vhost is not found")
+}
+
+func TestPruneRevisions(t *testing.T) {
+	var deleted []string
+	server := newRevisionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			_, _ = w.Write([]byte(`{"count":0,"list":[]}`))
+			return
+		}
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+	})
+	defer server.Close()
+	useTestEnvironment(t, "dev", server.URL)
+
+	pruned, err := PruneRevisions("access-token", "dev", RevisionArtifactAPI, "PizzaShackAPI", "1.0.0", "", 2, true)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []string{"1", "3"}, pruned)
+	assert.Empty(t, deleted, "Revisions should not be deleted in a dry run")
+
+	pruned, err = PruneRevisions("access-token", "dev", RevisionArtifactAPI, "PizzaShackAPI", "1.0.0", "", 2, false)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []string{"1", "3"}, pruned)
+	assert.Equal(t, []string{"/api/am/publisher/v4/apis/pizza-id/revisions/rev-1",
+		"/api/am/publisher/v4/apis/pizza-id/revisions/rev-3"}, deleted)
+}
+
+func TestRollbackRevisionToRecordedDeployment(t *testing.T) {
+	var deployed []string
+	server := newRevisionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/am/publisher/v4/apis/pizza-id/deploy-revision" {
+			body, _ := ioutil.ReadAll(r.Body)
+			deployed = append(deployed, r.URL.Query().Get("revisionId")+" "+string(body))
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+	})
+	defer server.Close()
+	useTestEnvironment(t, "dev", server.URL)
+	key := getRevisionHistoryKey(&revisionedArtifact{endpoint: server.URL + "/api/am/publisher/v4/apis/",
+		id: "pizza-id"})
+
+	rollbacks, err := RollbackRevision("access-token", "dev", RevisionArtifactAPI, "PizzaShackAPI", "1.0.0", "", "",
+		[]string{"production"})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []RevisionRollback{{GatewayEnv: "production", Vhost: "api.wso2.com", FromRevision: "4",
+		ToRevision: "2", historyIndex: -1}}, rollbacks, "The revision deployed in staging should be rolled back to "+
+		"if no prior deployment is recorded")
+	assert.Equal(t, `rev-2 [{"name":"production","vhost":"api.wso2.com","displayOnDevportal":false}]`,
+		deployed[len(deployed)-1])
+	assert.Nil(t, saveRevisionHistory(make(revisionHistory)))
+
+	// The publisher keeps revision 4 in production, hence the deployment of revision 1 is the one rolled back to
+	_, err = DeployRevision("access-token", "dev", RevisionArtifactAPI, "PizzaShackAPI", "1.0.0", "", "1", "",
+		[]utils.Deployment{{Name: "production", Vhost: "api.wso2.com", DisplayOnDevportal: true}})
+	assert.Nil(t, err, "Error should be null")
+	history, err := loadRevisionHistory()
+	assert.Nil(t, err, "Error should be null")
+	records := history[key]
+	assert.Equal(t, 2, len(records), "The current deployment and the new deployment should be recorded")
+	assert.Equal(t, "4", records[0].Revision)
+	assert.Equal(t, "1", records[1].Revision)
+	records = append(records, revisionDeploymentRecord{GatewayEnv: "production", Vhost: "api.wso2.com",
+		Revision: "4"})
+	history[key] = records
+	assert.Nil(t, saveRevisionHistory(history))
+
+	rollbacks, err = RollbackRevision("access-token", "dev", RevisionArtifactAPI, "PizzaShackAPI", "1.0.0", "", "",
+		[]string{"production"})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, []RevisionRollback{{GatewayEnv: "production", Vhost: "api.wso2.com", FromRevision: "4",
+		ToRevision: "1", DisplayOnDevportal: true, historyIndex: 1}}, rollbacks)
+	assert.Equal(t, `rev-1 [{"name":"production","vhost":"api.wso2.com","displayOnDevportal":true}]`,
+		deployed[len(deployed)-1], "The recorded devportal visibility should be deployed")
+
+	history, err = loadRevisionHistory()
+	assert.Nil(t, err, "Error should be null")
+	records = history[key]
+	assert.Equal(t, 2, len(records), "The deployments after the one rolled back to should be removed")
+	assert.Equal(t, "1", records[1].Revision)
+}
@@ -0,0 +1,632 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Block states of a subscription
+const (
+	SubscriptionBlockStateBlocked         = "BLOCKED"
+	SubscriptionBlockStateProdOnlyBlocked = "PROD_ONLY_BLOCKED"
+)
+
+const (
+	subscriptionIdHeader               = "ID"
+	subscriptionApplicationHeader      = "APPLICATION"
+	subscriptionApiHeader              = "API"
+	subscriptionVersionHeader          = "VERSION"
+	subscriptionThrottlingPolicyHeader = "THROTTLING POLICY"
+	subscriptionStatusHeader           = "STATUS"
+
+	defaultSubscriptionTableFormat = "table {{.Id}}\t{{.Application}}\t{{.Api}}\t{{.Version}}\t{{.ThrottlingPolicy}}\t{{.Status}}"
+)
+
+// SubscriptionArtifact is the API, API Product or MCP Server of a subscription
+type SubscriptionArtifact struct {
+	// Type is one of utils.ProjectTypeApi, utils.ProjectTypeApiProduct or utils.ProjectTypeMcpServer
+	Type     string
+	Name     string
+	Version  string
+	Provider string
+}
+
+// subscription contains information about utils.Subscription
+type subscription struct {
+	id               string
+	application      string
+	api              string
+	version          string
+	throttlingPolicy string
+	status           string
+}
+
+// creates a new subscription definition from utils.Subscription
+func newSubscriptionDefinitionFromSubscription(s utils.Subscription) *subscription {
+	return &subscription{s.SubscriptionID, s.ApplicationInfo.Name, s.APIInfo.Name, s.APIInfo.Version,
+		s.ThrottlingPolicy, s.Status}
+}
+
+// Id of subscription
+func (s subscription) Id() string {
+	return s.id
+}
+
+// Application of subscription
+func (s subscription) Application() string {
+	return s.application
+}
+
+// Api of subscription
+func (s subscription) Api() string {
+	return s.api
+}
+
+// Version of the API of subscription
+func (s subscription) Version() string {
+	return s.version
+}
+
+// ThrottlingPolicy of subscription
+func (s subscription) ThrottlingPolicy() string {
+	return s.throttlingPolicy
+}
+
+// Status of subscription
+func (s subscription) Status() string {
+	return s.status
+}
+
+// MarshalJSON marshals subscription using custom marshaller which uses methods instead of fields
+func (s *subscription) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(s)
+}
+
+// GetSubscriptions returns the subscriptions of an application or of an API, API Product or MCP Server. The
+// subscriptions of an application are retrieved from the devportal, hence the application should be owned by the
+// user. The subscriptions of an API, API Product or MCP Server are retrieved from the publisher.
+// @param accessToken : Access token to call the REST APIs
+// @param environment : Environment of the subscriptions
+// @param appName : Name of the application. Could be blank if the artifact is given
+// @param artifact : API, API Product or MCP Server. The name could be blank if the application is given
+// @param limit : Max number of results to return
+// @return array of Subscription objects
+// @return error
+func GetSubscriptions(accessToken, environment, appName string, artifact SubscriptionArtifact,
+	limit string) ([]utils.Subscription, error) {
+	var apiId string
+	var err error
+	if artifact.Name != "" {
+		apiId, err = getSubscriptionArtifactId(accessToken, environment, artifact)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if appName == "" {
+		if apiId == "" {
+			return nil, errors.New("either the application or the API, API Product or MCP Server is required")
+		}
+		return getArtifactSubscriptions(accessToken, environment, apiId, artifact, limit)
+	}
+
+	appId, err := getSubscriptionApplicationId(accessToken, environment, appName)
+	if err != nil {
+		return nil, err
+	}
+	queryParams := map[string]string{"applicationId": appId}
+	if limit != "" {
+		queryParams["limit"] = limit
+	}
+	subscriptions, err := getDevPortalSubscriptions(accessToken, environment, queryParams)
+	if err != nil || apiId == "" {
+		return subscriptions, err
+	}
+	var filtered []utils.Subscription
+	for _, sub := range subscriptions {
+		if sub.APIID == apiId {
+			filtered = append(filtered, sub)
+		}
+	}
+	return filtered, nil
+}
+
+// Subscribe subscribes an application to an API, API Product or MCP Server. If the application is already subscribed,
+// the existing subscription is returned.
+// @param accessToken : Access token to call the REST APIs
+// @param environment : Environment of the application and the artifact
+// @param appName : Name of the application owned by the user
+// @param artifact : API, API Product or MCP Server to subscribe to
+// @param throttlingPolicy : Subscription throttling policy. The first policy of the artifact is used if blank
+// @return subscriptionId, error
+func Subscribe(accessToken, environment, appName string, artifact SubscriptionArtifact,
+	throttlingPolicy string) (string, error) {
+	apiId, err := getSubscriptionArtifactId(accessToken, environment, artifact)
+	if err != nil {
+		return "", err
+	}
+	appId, err := getSubscriptionApplicationId(accessToken, environment, appName)
+	if err != nil {
+		return "", err
+	}
+	if throttlingPolicy == "" {
+		throttlingPolicy, err = getDefaultSubscriptionPolicy(accessToken, environment, apiId, artifact)
+		if err != nil {
+			return "", err
+		}
+	}
+	utils.Logln(utils.LogPrefixInfo+"Subscribing with the throttling policy", throttlingPolicy)
+	return subscribeApiOrProduct(environment, apiId, appId, throttlingPolicy, accessToken)
+}
+
+// Unsubscribe removes the subscription of an application to an API, API Product or MCP Server
+// @param accessToken : Access token to call the REST APIs
+// @param environment : Environment of the application and the artifact
+// @param appName : Name of the application owned by the user
+// @param artifact : API, API Product or MCP Server to unsubscribe from
+// @return subscriptionId, error
+func Unsubscribe(accessToken, environment, appName string, artifact SubscriptionArtifact) (string, error) {
+	apiId, err := getSubscriptionArtifactId(accessToken, environment, artifact)
+	if err != nil {
+		return "", err
+	}
+	appId, err := getSubscriptionApplicationId(accessToken, environment, appName)
+	if err != nil {
+		return "", err
+	}
+	subscriptions, err := getDevPortalSubscriptions(accessToken, environment,
+		map[string]string{"applicationId": appId})
+	if err != nil {
+		return "", err
+	}
+	for _, sub := range subscriptions {
+		if sub.APIID == apiId {
+			endpoint := utils.GetDevPortalSubscriptionsEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" +
+				sub.SubscriptionID
+			return sub.SubscriptionID, invokeSubscriptionRequest(accessToken, http.MethodDelete, endpoint)
+		}
+	}
+	return "", fmt.Errorf("application %s is not subscribed to the %s %s %s", appName, artifact.Type,
+		artifact.Name, artifact.Version)
+}
+
+// BlockSubscription blocks the subscription of an application to an API, API Product or MCP Server
+// @param accessToken : Access token to call the REST APIs
+// @param environment : Environment of the application and the artifact
+// @param appName : Name of the application
+// @param appOwner : Owner of the application. Could be blank if the application name is unique
+// @param artifact : API, API Product or MCP Server of the subscription
+// @param blockState : SubscriptionBlockStateBlocked or SubscriptionBlockStateProdOnlyBlocked
+// @return subscriptionId, error
+func BlockSubscription(accessToken, environment, appName, appOwner string, artifact SubscriptionArtifact,
+	blockState string) (string, error) {
+	if blockState != SubscriptionBlockStateBlocked && blockState != SubscriptionBlockStateProdOnlyBlocked {
+		return "", errors.New("invalid block state " + blockState + ". Supported states: " +
+			SubscriptionBlockStateBlocked + ", " + SubscriptionBlockStateProdOnlyBlocked)
+	}
+	subscriptionId, err := getPublisherSubscriptionId(accessToken, environment, appName, appOwner, artifact)
+	if err != nil {
+		return "", err
+	}
+	endpoint := utils.GetPublisherSubscriptionsEndpointOfEnv(environment, utils.MainConfigFilePath) +
+		"/block-subscription?subscriptionId=" + subscriptionId + "&blockState=" + blockState
+	return subscriptionId, invokeSubscriptionRequest(accessToken, http.MethodPost, endpoint)
+}
+
+// UnblockSubscription unblocks the subscription of an application to an API, API Product or MCP Server
+// @param accessToken : Access token to call the REST APIs
+// @param environment : Environment of the application and the artifact
+// @param appName : Name of the application
+// @param appOwner : Owner of the application. Could be blank if the application name is unique
+// @param artifact : API, API Product or MCP Server of the subscription
+// @return subscriptionId, error
+func UnblockSubscription(accessToken, environment, appName, appOwner string,
+	artifact SubscriptionArtifact) (string, error) {
+	subscriptionId, err := getPublisherSubscriptionId(accessToken, environment, appName, appOwner, artifact)
+	if err != nil {
+		return "", err
+	}
+	endpoint := utils.GetPublisherSubscriptionsEndpointOfEnv(environment, utils.MainConfigFilePath) +
+		"/unblock-subscription?subscriptionId=" + subscriptionId
+	return subscriptionId, invokeSubscriptionRequest(accessToken, http.MethodPost, endpoint)
+}
+
+// GenerateKeys generates the keys of an application from a key manager
+// @param accessToken : Access token to call the devportal REST API
+// @param environment : Environment of the application
+// @param appName : Name of the application owned by the user
+// @param keygenRequest : Key type, key manager and grant types of the keys
+// @return KeygenResponse, error
+func GenerateKeys(accessToken, environment, appName string,
+	keygenRequest utils.KeygenRequest) (*utils.KeygenResponse, error) {
+	appId, err := getSubscriptionApplicationId(accessToken, environment, appName)
+	if err != nil {
+		return nil, err
+	}
+	return generateApplicationKeys(environment, appId, accessToken, keygenRequest)
+}
+
+// RegenerateSecret regenerates the consumer secret of the keys of an application
+// @param accessToken : Access token to call the devportal REST API
+// @param environment : Environment of the application
+// @param appName : Name of the application owned by the user
+// @param keyType : PRODUCTION or SANDBOX
+// @param keyManager : Key manager of the keys. Could be blank if the application has keys of one key manager
+// @return ConsumerSecretRegenResponse, error
+func RegenerateSecret(accessToken, environment, appName, keyType,
+	keyManager string) (*utils.ConsumerSecretRegenResponse, error) {
+	appId, err := getSubscriptionApplicationId(accessToken, environment, appName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := getApplicationOAuthKey(accessToken, environment, appId, keyType, keyManager)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + appId +
+		"/oauth-keys/" + key.KeyMappingID + "/regenerate-secret"
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokePOSTRequest(endpoint, headers, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, utils.NewHttpResponseError(resp)
+	}
+	regenResponse := &utils.ConsumerSecretRegenResponse{}
+	err = json.Unmarshal(resp.Body(), regenResponse)
+	return regenResponse, err
+}
+
+// RevokeToken revokes an access token issued to an application. The token is revoked at the revoke endpoint of the key
+// manager of the keys using the consumer key and the consumer secret of the keys. The consumer secret has to be given if the key manager does not
+// return the secret, such as when the secrets are hashed.
+// @param accessToken : Access token to call the devportal REST API
+// @param environment : Environment of the application
+// @param appName : Name of the application owned by the user
+// @param keyType : PRODUCTION or SANDBOX
+// @param keyManager : Key manager of the keys. Could be blank if the application has keys of one key manager
+// @param consumerSecret : Consumer secret of the keys. Could be blank if returned by the devportal
+// @param token : Access token to be revoked
+// @return error
+func RevokeToken(accessToken, environment, appName, keyType, keyManager, consumerSecret, token string) error {
+	appId, err := getSubscriptionApplicationId(accessToken, environment, appName)
+	if err != nil {
+		return err
+	}
+	key, err := getApplicationOAuthKey(accessToken, environment, appId, keyType, keyManager)
+	if err != nil {
+		return err
+	}
+	if consumerSecret == "" {
+		consumerSecret = key.ConsumerSecret
+	}
+	if consumerSecret == "" {
+		return errors.New("the consumer secret of the " + keyType + " keys of application " + appName +
+			" is not available. Provide the consumer secret to revoke the token")
+	}
+	revokeEndpoint, err := getKeyManagerRevokeEndpoint(accessToken, environment, key.KeyManager)
+	if err != nil {
+		return err
+	}
+	return credentials.RevokeAccessTokenOfEndpoint(credentials.Credential{ClientId: key.ConsumerKey,
+		ClientSecret: consumerSecret}, revokeEndpoint, token)
+}
+
+// getKeyManagerRevokeEndpoint returns the revoke endpoint of a key manager of the devportal. The revoke endpoint of
+// the environment is used for the resident key manager, which issues the tokens of apictl as well.
+func getKeyManagerRevokeEndpoint(accessToken, environment, keyManager string) (string, error) {
+	endpoint := utils.GetDevPortalKeyManagersEndpointOfEnv(environment, utils.MainConfigFilePath)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(endpoint, headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", utils.NewHttpResponseError(resp)
+	}
+	keyManagers := &utils.KeyManagerList{}
+	if err := json.Unmarshal(resp.Body(), keyManagers); err != nil {
+		return "", err
+	}
+	for _, km := range keyManagers.List {
+		if km.Name != keyManager {
+			continue
+		}
+		if km.Type == utils.ResidentKeyManagerType {
+			return utils.GetTokenRevokeEndpoint(environment, utils.MainConfigFilePath), nil
+		}
+		if km.RevokeEndpoint == "" {
+			return "", errors.New("the key manager " + keyManager + " does not have a revoke endpoint")
+		}
+		return km.RevokeEndpoint, nil
+	}
+	return "", errors.New("the key manager " + keyManager + " is not found")
+}
+
+// PrintSubscriptions prints the subscriptions using the given format
+func PrintSubscriptions(subscriptions []utils.Subscription, format string) {
+	if format == "" {
+		format = defaultSubscriptionTableFormat
+	} else if format == utils.JsonArrayFormatType {
+		var definitions []*subscription
+		for _, s := range subscriptions {
+			definitions = append(definitions, newSubscriptionDefinitionFromSubscription(s))
+		}
+		utils.ListArtifactsInJsonArrayFormat(definitions, utils.ProjectTypeSubscription)
+		return
+	}
+
+	// create new subscription context with standard output
+	subscriptionContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection of subscriptions
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, s := range subscriptions {
+			if err := t.Execute(w, newSubscriptionDefinitionFromSubscription(s)); err != nil {
+				return err
+			}
+			// write a new line after executing template
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	subscriptionTableHeaders := map[string]string{
+		"Id":               subscriptionIdHeader,
+		"Application":      subscriptionApplicationHeader,
+		"Api":              subscriptionApiHeader,
+		"Version":          subscriptionVersionHeader,
+		"ThrottlingPolicy": subscriptionThrottlingPolicyHeader,
+		"Status":           subscriptionStatusHeader,
+	}
+
+	// execute context
+	if err := subscriptionContext.Write(renderer, subscriptionTableHeaders); err != nil {
+		fmt.Fprintln(utils.Stdout, "Error executing template:", err.Error())
+	}
+}
+
+// getSubscriptionArtifactId returns the ID of the API, API Product or MCP Server of a subscription
+func getSubscriptionArtifactId(accessToken, environment string, artifact SubscriptionArtifact) (string, error) {
+	switch artifact.Type {
+	case utils.ProjectTypeApi:
+		return GetAPIId(accessToken, environment, artifact.Name, artifact.Version, artifact.Provider)
+	case utils.ProjectTypeApiProduct:
+		return GetAPIProductId(accessToken, environment, artifact.Name, artifact.Version, artifact.Provider)
+	case utils.ProjectTypeMcpServer:
+		return GetMCPServerId(accessToken, environment, artifact.Name, artifact.Version, artifact.Provider)
+	}
+	return "", errors.New("subscriptions are not supported for " + artifact.Type)
+}
+
+// getSubscriptionArtifactEndpoint returns the publisher endpoint of the API, API Product or MCP Server of a
+// subscription
+func getSubscriptionArtifactEndpoint(environment string, artifact SubscriptionArtifact) string {
+	switch artifact.Type {
+	case utils.ProjectTypeApiProduct:
+		return utils.GetApiProductListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	case utils.ProjectTypeMcpServer:
+		return utils.GetMcpServerListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	}
+	return utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+}
+
+// getSubscriptionApplicationId returns the ID of an application owned by the user
+func getSubscriptionApplicationId(accessToken, environment, appName string) (string, error) {
+	appId, err := searchApplication(environment, appName, accessToken)
+	if err != nil {
+		return "", err
+	}
+	if appId == "" {
+		return "", errors.New("application " + appName + " is not found")
+	}
+	return appId, nil
+}
+
+// getDefaultSubscriptionPolicy returns the first subscription throttling policy of an API, API Product or MCP Server
+func getDefaultSubscriptionPolicy(accessToken, environment, apiId string,
+	artifact SubscriptionArtifact) (string, error) {
+	endpoint := utils.AppendSlashToString(getSubscriptionArtifactEndpoint(environment, artifact)) + apiId
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(endpoint, headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", utils.NewHttpResponseError(resp)
+	}
+	apiData := &utils.APIData{}
+	if err := json.Unmarshal(resp.Body(), apiData); err != nil {
+		return "", err
+	}
+	if len(apiData.Policies) == 0 {
+		return "", errors.New("the " + artifact.Type + " " + artifact.Name + " does not have subscription " +
+			"throttling policies")
+	}
+	return apiData.Policies[0], nil
+}
+
+// getDevPortalSubscriptions returns the subscriptions of the devportal matching the query parameters
+func getDevPortalSubscriptions(accessToken, environment string,
+	queryParams map[string]string) ([]utils.Subscription, error) {
+	endpoint := utils.GetDevPortalSubscriptionsEndpointOfEnv(environment, utils.MainConfigFilePath)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, endpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, utils.NewHttpResponseError(resp)
+	}
+	subscriptionList := &utils.SubscriptionList{}
+	err = json.Unmarshal(resp.Body(), subscriptionList)
+	return subscriptionList.List, err
+}
+
+// getPublisherSubscriptions returns the subscriptions of an API, API Product or MCP Server from the publisher
+func getPublisherSubscriptions(accessToken, environment, apiId, limit string) ([]utils.PublisherSubscription, error) {
+	endpoint := utils.GetPublisherSubscriptionsEndpointOfEnv(environment, utils.MainConfigFilePath)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	queryParams := map[string]string{utils.ApiId: apiId}
+	if limit != "" {
+		queryParams["limit"] = limit
+	}
+	resp, err := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, endpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, utils.NewHttpResponseError(resp)
+	}
+	subscriptionList := &utils.PublisherSubscriptionList{}
+	err = json.Unmarshal(resp.Body(), subscriptionList)
+	return subscriptionList.List, err
+}
+
+// getArtifactSubscriptions returns the subscriptions of an API, API Product or MCP Server from the publisher in the
+// structure of the devportal subscriptions
+func getArtifactSubscriptions(accessToken, environment, apiId string, artifact SubscriptionArtifact,
+	limit string) ([]utils.Subscription, error) {
+	publisherSubscriptions, err := getPublisherSubscriptions(accessToken, environment, apiId, limit)
+	if err != nil {
+		return nil, err
+	}
+	var subscriptions []utils.Subscription
+	for _, publisherSubscription := range publisherSubscriptions {
+		sub := utils.Subscription{
+			SubscriptionID:   publisherSubscription.SubscriptionID,
+			ApplicationID:    publisherSubscription.ApplicationInfo.ApplicationID,
+			APIID:            apiId,
+			ThrottlingPolicy: publisherSubscription.ThrottlingPolicy,
+			Status:           publisherSubscription.SubscriptionStatus,
+		}
+		sub.APIInfo.ID = apiId
+		sub.APIInfo.Name = artifact.Name
+		sub.APIInfo.Version = artifact.Version
+		sub.APIInfo.Provider = artifact.Provider
+		sub.ApplicationInfo.ApplicationID = publisherSubscription.ApplicationInfo.ApplicationID
+		sub.ApplicationInfo.Name = publisherSubscription.ApplicationInfo.Name
+		sub.ApplicationInfo.Owner = publisherSubscription.ApplicationInfo.Subscriber
+		subscriptions = append(subscriptions, sub)
+	}
+	return subscriptions, nil
+}
+
+// getPublisherSubscriptionId returns the ID of the subscription of an application to an API, API Product or MCP
+// Server. The subscription is searched from the publisher since the application could be owned by another user.
+func getPublisherSubscriptionId(accessToken, environment, appName, appOwner string,
+	artifact SubscriptionArtifact) (string, error) {
+	apiId, err := getSubscriptionArtifactId(accessToken, environment, artifact)
+	if err != nil {
+		return "", err
+	}
+	subscriptions, err := getPublisherSubscriptions(accessToken, environment, apiId, "")
+	if err != nil {
+		return "", err
+	}
+	var matches []utils.PublisherSubscription
+	for _, sub := range subscriptions {
+		if sub.ApplicationInfo.Name == appName && (appOwner == "" || sub.ApplicationInfo.Subscriber == appOwner) {
+			matches = append(matches, sub)
+		}
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("application %s is not subscribed to the %s %s %s", appName, artifact.Type,
+			artifact.Name, artifact.Version)
+	}
+	if len(matches) > 1 {
+		return "", errors.New(strconv.Itoa(len(matches)) + " applications named " + appName + " are subscribed " +
+			"to the " + artifact.Type + ". Specify the owner of the application")
+	}
+	return matches[0].SubscriptionID, nil
+}
+
+// getApplicationOAuthKey returns the keys of an application generated for the key type from the key manager
+func getApplicationOAuthKey(accessToken, environment, appId, keyType,
+	keyManager string) (*utils.ApplicationKey, error) {
+	endpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + appId +
+		"/oauth-keys"
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	resp, err := utils.InvokeGETRequest(endpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, utils.NewHttpResponseError(resp)
+	}
+	keys := &utils.AppKeyList{}
+	if err := json.Unmarshal(resp.Body(), keys); err != nil {
+		return nil, err
+	}
+	var matches []utils.ApplicationKey
+	for _, key := range keys.List {
+		if key.KeyType == keyType && (keyManager == "" || key.KeyManager == keyManager) {
+			matches = append(matches, key)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, errors.New("the " + keyType + " keys of the application are not generated")
+	}
+	if len(matches) > 1 {
+		return nil, errors.New("the application has " + keyType + " keys of multiple key managers. Specify the " +
+			"key manager")
+	}
+	return &matches[0], nil
+}
+
+// invokeSubscriptionRequest invokes a request on a subscription which does not return content
+func invokeSubscriptionRequest(accessToken, method, endpoint string) error {
+	utils.Logln(utils.LogPrefixInfo+method+" URL:", endpoint)
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	var resp *resty.Response
+	var err error
+	if method == http.MethodDelete {
+		resp, err = utils.InvokeDELETERequest(endpoint, headers)
+	} else {
+		resp, err = utils.InvokePOSTRequest(endpoint, headers, "")
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
+		return utils.NewHttpResponseError(resp)
+	}
+	return nil
+}
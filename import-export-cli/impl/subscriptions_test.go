@@ -0,0 +1,221 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// newSubscriptionTestServer returns a server which resolves the API PizzaShackAPI 1.0.0 and the application SampleApp
+// and delegates the other requests to the handler
+func newSubscriptionTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access-token", r.Header.Get(utils.HeaderAuthorization))
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/search":
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"id":"pizza-id","name":"PizzaShackAPI"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/devportal/v3/applications":
+			// The query matches the applications having the query as a part of their names
+			assert.Contains(t, "SampleApp", r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"count":2,"list":[{"applicationId":"other-app-id","name":"SampleApp2"},` +
+				`{"applicationId":"app-id","name":"SampleApp"}]}`))
+		default:
+			handler(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	useTestEnvironment(t, "dev", server.URL)
+	return server
+}
+
+var pizzaShackAPI = SubscriptionArtifact{Type: utils.ProjectTypeApi, Name: "PizzaShackAPI", Version: "1.0.0"}
+
+func TestSubscribeWithDefaultPolicy(t *testing.T) {
+	newSubscriptionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/apis/pizza-id":
+			_, _ = w.Write([]byte(`{"id":"pizza-id","policies":["Gold","Unlimited"]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/devportal/v3/subscriptions":
+			assert.Equal(t, "pizza-id", r.URL.Query().Get(utils.ApiId))
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"subscriptionId":"other-sub-id","applicationId":"other-app-id"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/am/devportal/v3/subscriptions":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"applicationId":"app-id","apiId":"pizza-id","throttlingPolicy":"Gold"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"subscriptionId":"sub-id"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	subscriptionId, err := Subscribe("access-token", "dev", "SampleApp", pizzaShackAPI, "")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "sub-id", subscriptionId, "The ID of the new subscription should be returned")
+}
+
+func TestUnsubscribeNotSubscribed(t *testing.T) {
+	newSubscriptionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/am/devportal/v3/subscriptions" {
+			assert.Equal(t, "app-id", r.URL.Query().Get("applicationId"))
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"subscriptionId":"sub-id","apiId":"other-api-id"}]}`))
+			return
+		}
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+	})
+
+	_, err := Unsubscribe("access-token", "dev", "SampleApp", pizzaShackAPI)
+	assert.EqualError(t, err, "application SampleApp is not subscribed to the API PizzaShackAPI 1.0.0")
+}
+
+func TestGetSubscriptionsOfAPI(t *testing.T) {
+	newSubscriptionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/subscriptions" {
+			assert.Equal(t, "pizza-id", r.URL.Query().Get(utils.ApiId))
+			assert.Equal(t, "10", r.URL.Query().Get("limit"))
+			_, _ = w.Write([]byte(`{"count":1,"list":[{"subscriptionId":"sub-id","applicationInfo":` +
+				`{"applicationId":"app-id","name":"SampleApp","subscriber":"alice"},"throttlingPolicy":"Gold",` +
+				`"subscriptionStatus":"BLOCKED"}]}`))
+			return
+		}
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+	})
+
+	subscriptions, err := GetSubscriptions("access-token", "dev", "", pizzaShackAPI, "10")
+	assert.Nil(t, err, "Error should be null")
+	if assert.Len(t, subscriptions, 1) {
+		sub := subscriptions[0]
+		assert.Equal(t, "sub-id", sub.SubscriptionID)
+		assert.Equal(t, "SampleApp", sub.ApplicationInfo.Name)
+		assert.Equal(t, "alice", sub.ApplicationInfo.Owner)
+		assert.Equal(t, "PizzaShackAPI", sub.APIInfo.Name)
+		assert.Equal(t, "1.0.0", sub.APIInfo.Version)
+		assert.Equal(t, "Gold", sub.ThrottlingPolicy)
+		assert.Equal(t, "BLOCKED", sub.Status)
+	}
+}
+
+func TestBlockSubscriptionOfOwner(t *testing.T) {
+	var blocked bool
+	newSubscriptionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/publisher/v4/subscriptions":
+			_, _ = w.Write([]byte(`{"count":2,"list":[` +
+				`{"subscriptionId":"alice-sub-id","applicationInfo":{"name":"SampleApp","subscriber":"alice"}},` +
+				`{"subscriptionId":"bob-sub-id","applicationInfo":{"name":"SampleApp","subscriber":"bob"}}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/am/publisher/v4/subscriptions/block-subscription":
+			assert.Equal(t, "bob-sub-id", r.URL.Query().Get("subscriptionId"))
+			assert.Equal(t, SubscriptionBlockStateProdOnlyBlocked, r.URL.Query().Get("blockState"))
+			blocked = true
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	_, err := BlockSubscription("access-token", "dev", "SampleApp", "", pizzaShackAPI,
+		SubscriptionBlockStateBlocked)
+	assert.EqualError(t, err, "2 applications named SampleApp are subscribed to the API. Specify the owner of "+
+		"the application")
+
+	subscriptionId, err := BlockSubscription("access-token", "dev", "SampleApp", "bob", pizzaShackAPI,
+		SubscriptionBlockStateProdOnlyBlocked)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "bob-sub-id", subscriptionId)
+	assert.True(t, blocked, "The subscription of the owner should be blocked")
+}
+
+func TestRegenerateSecretOfKeyManager(t *testing.T) {
+	newSubscriptionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/devportal/v3/applications/app-id/oauth-keys":
+			_, _ = w.Write([]byte(`{"count":3,"list":[` +
+				`{"keyMappingId":"resident-prod","keyManager":"Resident Key Manager","keyType":"PRODUCTION"},` +
+				`{"keyMappingId":"keycloak-sandbox","keyManager":"Keycloak","keyType":"SANDBOX"},` +
+				`{"keyMappingId":"keycloak-prod","keyManager":"Keycloak","keyType":"PRODUCTION"}]}`))
+		case r.Method == http.MethodPost &&
+			r.URL.Path == "/api/am/devportal/v3/applications/app-id/oauth-keys/keycloak-prod/regenerate-secret":
+			_, _ = w.Write([]byte(`{"consumerKey":"key","consumerSecret":"new-secret"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	_, err := RegenerateSecret("access-token", "dev", "SampleApp", utils.ProductionKeyType, "")
+	assert.EqualError(t, err, "the application has PRODUCTION keys of multiple key managers. Specify the key manager")
+
+	keys, err := RegenerateSecret("access-token", "dev", "SampleApp", utils.ProductionKeyType, "Keycloak")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "new-secret", keys.ConsumerSecret)
+}
+
+func TestRevokeTokenAtKeyManagerRevokeEndpoint(t *testing.T) {
+	var revoked string
+	keyManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		revoked = r.URL.Path + " " + r.Header.Get(utils.HeaderAuthorization) + " " + string(body)
+	}))
+	defer keyManager.Close()
+	server := newSubscriptionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/devportal/v3/applications/app-id/oauth-keys":
+			_, _ = w.Write([]byte(`{"count":2,"list":[` +
+				`{"keyManager":"Resident Key Manager","consumerKey":"resident-key","keyType":"SANDBOX"},` +
+				`{"keyManager":"Keycloak","consumerKey":"keycloak-key","consumerSecret":"keycloak-secret",` +
+				`"keyType":"SANDBOX"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/am/devportal/v3/key-managers":
+			_, _ = w.Write([]byte(`{"count":3,"list":[` +
+				`{"name":"Resident Key Manager","type":"default","revokeEndpoint":"https://localhost:9443/oauth2/revoke"},` +
+				`{"name":"Keycloak","type":"KeyCloak","revokeEndpoint":"` + keyManager.URL + `/revoke"},` +
+				`{"name":"Okta","type":"Okta"}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	err := RevokeToken("access-token", "dev", "SampleApp", utils.SandboxKeyType, "Keycloak", "", "sandbox-token")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "/revoke Basic "+utils.GetBase64EncodedCredentials("keycloak-key", "keycloak-secret")+
+		" token=sandbox-token&token_type_hint=access_token", revoked,
+		"The token should be revoked at the revoke endpoint of the key manager")
+
+	endpoint, err := getKeyManagerRevokeEndpoint("access-token", "dev", "Resident Key Manager")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, server.URL+"/oauth2/revoke", endpoint,
+		"The revoke endpoint of the environment should be used for the resident key manager")
+
+	_, err = getKeyManagerRevokeEndpoint("access-token", "dev", "Okta")
+	assert.EqualError(t, err, "the key manager Okta does not have a revoke endpoint")
+	_, err = getKeyManagerRevokeEndpoint("access-token", "dev", "Auth0")
+	assert.EqualError(t, err, "the key manager Auth0 is not found")
+}
+
+func TestGenerateKeysApplicationNotFound(t *testing.T) {
+	newSubscriptionTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+	})
+
+	_, err := GenerateKeys("access-token", "dev", "Sample", utils.KeygenRequest{KeyType: utils.ProductionKeyType})
+	assert.EqualError(t, err, "application Sample is not found")
+}
@@ -0,0 +1,632 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/olekukonko/tablewriter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	APITestFormatTable = "table"
+	APITestFormatJSON  = "json"
+	APITestFormatJUnit = "junit"
+
+	APITestResultPassed  = "PASSED"
+	APITestResultFailed  = "FAILED"
+	APITestResultSkipped = "SKIPPED"
+)
+
+// APITestFormats are the supported formats of the test reports
+var APITestFormats = []string{APITestFormatTable, APITestFormatJSON, APITestFormatJUnit}
+
+// apiTestMethodOrder orders the operations of a resource so that it is read and created before it is deleted
+var apiTestMethodOrder = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPost,
+	http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// APITestOptions holds the user provided options of the tests of an API
+type APITestOptions struct {
+	// TokenEndpoint overrides the token endpoint of the environment
+	TokenEndpoint string
+	// GatewayEnvironment is the gateway environment whose URLs are used, instead of the first one of the API
+	GatewayEnvironment string
+	// GatewayURL overrides the scheme, host and port of the gateway URL
+	GatewayURL string
+	Data       *APITestData
+}
+
+// APITestData overrides the generated inputs of the requests. Operations are identified by their operation IDs
+// or by the method and the path, eg: "GET /menu/{id}".
+type APITestData struct {
+	Headers    map[string]string                `json:"headers"`
+	Operations map[string]*APITestOperationData `json:"operations"`
+}
+
+// APITestOperationData holds the inputs and the expected status of an operation
+type APITestOperationData struct {
+	Skip           bool                   `json:"skip"`
+	PathParams     map[string]interface{} `json:"pathParams"`
+	QueryParams    map[string]interface{} `json:"queryParams"`
+	Headers        map[string]string      `json:"headers"`
+	Body           interface{}            `json:"body"`
+	ExpectedStatus int                    `json:"expectedStatus"`
+}
+
+// APITestReport holds the results of the tests of the operations of an API
+type APITestReport struct {
+	API         string          `json:"api"`
+	Version     string          `json:"version"`
+	Environment string          `json:"environment"`
+	BaseURL     string          `json:"baseUrl"`
+	Tests       int             `json:"tests"`
+	Passed      int             `json:"passed"`
+	Failed      int             `json:"failed"`
+	Skipped     int             `json:"skipped"`
+	Duration    float64         `json:"durationSeconds"`
+	Results     []APITestResult `json:"results"`
+}
+
+// APITestResult holds the result of the test of an operation
+type APITestResult struct {
+	Operation string   `json:"operation"`
+	URL       string   `json:"url,omitempty"`
+	Status    int      `json:"status,omitempty"`
+	Result    string   `json:"result"`
+	Duration  float64  `json:"durationSeconds"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// LoadAPITestData reads a YAML or JSON test data file
+func LoadAPITestData(path string) (*APITestData, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test data file %s: %w", path, err)
+	}
+	data := &APITestData{}
+	if err := json.Unmarshal(jsonContent, data); err != nil {
+		return nil, fmt.Errorf("invalid test data file %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// RunAPITests generates a token of the default application for an API, then invokes every operation of the API
+// definition retrieved from the devportal through the gateway, and validates the responses against the definition
+// @param cred : Credentials of the environment
+// @param environment : Environment the API is deployed in
+// @param name : Name of the API or API Product
+// @param version : Version of the API or API Product
+// @param provider : Provider of the API or API Product
+// @param options : Test data and the overrides of the endpoints
+// @return report, error
+func RunAPITests(cred credentials.Credential, environment, name, version, provider string,
+	options APITestOptions) (*APITestReport, error) {
+	token, err := GenerateAccessToken(cred, environment, name, version, provider, options.TokenEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
+	if err != nil {
+		return nil, err
+	}
+	apiId, err := searchApiOrProduct(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := utils.GetDevPortalApiListEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + apiId +
+		"/swagger"
+	definition, err := getDevPortalAPIDefinition(accessToken, endpoint, options.GatewayEnvironment)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := loadOpenAPIDefinition(definition, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API definition of %s %s: %w", name, version, err)
+	}
+	baseURL, err := getAPITestBaseURL(doc, options.GatewayURL)
+	if err != nil {
+		return nil, err
+	}
+	report := runAPITestOperations(doc, baseURL, token, options.Data)
+	report.API = name
+	report.Version = version
+	report.Environment = environment
+	return report, nil
+}
+
+// getDevPortalAPIDefinition retrieves the definition of an API from the devportal, having the URLs of the gateway
+func getDevPortalAPIDefinition(accessToken, endpoint, gatewayEnvironment string) ([]byte, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	queryParams := make(map[string]string)
+	if gatewayEnvironment != "" {
+		queryParams["environmentName"] = gatewayEnvironment
+	}
+	resp, err := utils.InvokeGETRequestWithMultipleQueryParams(queryParams, endpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		utils.Logf("Error: %s\n", resp.Error())
+		utils.Logf("Body: %s\n", resp.Body())
+		if resp.StatusCode() == http.StatusUnauthorized {
+			// 401 Unauthorized
+			return nil, errors.New("authorization failed while retrieving the API definition from the devportal")
+		}
+		return nil, errors.New("Request didn't respond 200 OK for retrieving the API definition. Status: " +
+			resp.Status())
+	}
+	return resp.Body(), nil
+}
+
+// getAPITestBaseURL returns the URL of the gateway the API is invoked with. HTTPS URLs are preferred. The scheme,
+// host and port of the URL are replaced by gatewayURL if it is given.
+func getAPITestBaseURL(doc *openapi3.T, gatewayURL string) (string, error) {
+	serverURL := ""
+	for _, server := range doc.Servers {
+		resolved := server.URL
+		for name, variable := range server.Variables {
+			resolved = strings.ReplaceAll(resolved, "{"+name+"}", variable.Default)
+		}
+		if serverURL == "" || (strings.HasPrefix(resolved, "https://") && !strings.HasPrefix(serverURL, "https://")) {
+			serverURL = resolved
+		}
+	}
+	if gatewayURL == "" {
+		if serverURL == "" {
+			return "", errors.New("the API definition does not have a gateway URL, provide the gateway URL to invoke " +
+				"the API with")
+		}
+		return strings.TrimSuffix(serverURL, "/"), nil
+	}
+	basePath := ""
+	if parsed, err := url.Parse(serverURL); err == nil {
+		basePath = parsed.Path
+	}
+	return strings.TrimSuffix(gatewayURL, "/") + strings.TrimSuffix(basePath, "/"), nil
+}
+
+// apiTestOperation is an operation of the API definition to be tested
+type apiTestOperation struct {
+	method    string
+	path      string
+	pathItem  *openapi3.PathItem
+	operation *openapi3.Operation
+}
+
+func (o apiTestOperation) name() string {
+	return o.method + " " + o.path
+}
+
+// getAPITestOperations returns the operations of a definition ordered by their paths and methods
+func getAPITestOperations(doc *openapi3.T) []apiTestOperation {
+	var operations []apiTestOperation
+	if doc.Paths == nil {
+		return operations
+	}
+	paths := doc.Paths.Map()
+	var keys []string
+	for path := range paths {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+	for _, path := range keys {
+		for _, method := range apiTestMethodOrder {
+			if operation := paths[path].GetOperation(method); operation != nil {
+				operations = append(operations, apiTestOperation{method: method, path: path,
+					pathItem: paths[path], operation: operation})
+			}
+		}
+	}
+	return operations
+}
+
+// runAPITestOperations invokes the operations of a definition in the order of their paths and validates the
+// responses
+func runAPITestOperations(doc *openapi3.T, baseURL, token string, data *APITestData) *APITestReport {
+	if data == nil {
+		data = &APITestData{}
+	}
+	start := time.Now()
+	report := &APITestReport{BaseURL: baseURL}
+	for _, operation := range getAPITestOperations(doc) {
+		operationData := data.Operations[operation.name()]
+		if operationData == nil && operation.operation.OperationID != "" {
+			operationData = data.Operations[operation.operation.OperationID]
+		}
+		if operationData == nil {
+			operationData = &APITestOperationData{}
+		}
+		var result APITestResult
+		if operationData.Skip {
+			result = APITestResult{Operation: operation.name(), Result: APITestResultSkipped}
+		} else {
+			result = runAPITestOperation(doc, baseURL, token, data.Headers, operation, operationData)
+		}
+		switch result.Result {
+		case APITestResultPassed:
+			report.Passed++
+		case APITestResultFailed:
+			report.Failed++
+		default:
+			report.Skipped++
+		}
+		report.Results = append(report.Results, result)
+	}
+	report.Tests = len(report.Results)
+	report.Duration = time.Since(start).Seconds()
+	return report
+}
+
+// runAPITestOperation invokes an operation with the inputs of the test data or else with the examples of the
+// definition, and validates the status and the body of the response
+func runAPITestOperation(doc *openapi3.T, baseURL, token string, headers map[string]string,
+	operation apiTestOperation, data *APITestOperationData) APITestResult {
+	result := APITestResult{Operation: operation.name(), Result: APITestResultFailed}
+	request, pathParams, err := newAPITestRequest(baseURL, token, headers, operation, data)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	result.URL = request.URL.String()
+
+	var body string
+	if request.Body != nil {
+		content, _ := ioutil.ReadAll(request.Body)
+		body = string(content)
+		request.Body = ioutil.NopCloser(bytes.NewReader(content))
+	}
+	requestHeaders := make(map[string]string)
+	for key := range request.Header {
+		requestHeaders[key] = request.Header.Get(key)
+	}
+	start := time.Now()
+	// The operation is invoked once, since a retried request would hide the failures and the latency of the API
+	resp, err := utils.InvokeRequestWithoutRetries(operation.method, result.URL, requestHeaders, body)
+	result.Duration = time.Since(start).Seconds()
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	result.Status = resp.StatusCode()
+
+	if statusErr := validateAPITestStatus(operation.operation, result.Status, data.ExpectedStatus); statusErr != nil {
+		result.Errors = append(result.Errors, statusErr.Error())
+	}
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route: &routers.Route{Spec: doc, Path: operation.path, PathItem: operation.pathItem,
+				Method: operation.method, Operation: operation.operation},
+		},
+		Status: result.Status,
+		Header: resp.Header(),
+		Body:   ioutil.NopCloser(bytes.NewReader(resp.Body())),
+		Options: &openapi3filter.Options{
+			ExcludeResponseBody: !strings.Contains(resp.Header().Get(utils.HeaderContentType), "json"),
+			MultiError:          true,
+		},
+	}
+	if err := openapi3filter.ValidateResponse(context.Background(), input); err != nil {
+		var multiError openapi3.MultiError
+		if errors.As(err, &multiError) {
+			for _, e := range multiError {
+				result.Errors = append(result.Errors, e.Error())
+			}
+		} else {
+			result.Errors = append(result.Errors, err.Error())
+		}
+	}
+	if len(result.Errors) == 0 {
+		result.Result = APITestResultPassed
+	}
+	return result
+}
+
+// newAPITestRequest builds the request of an operation. Parameters and bodies of the test data take precedence over
+// the examples and the schemas of the definition. Optional query and header parameters are sent only if they are
+// in the test data.
+func newAPITestRequest(baseURL, token string, headers map[string]string, operation apiTestOperation,
+	data *APITestOperationData) (*http.Request, map[string]string, error) {
+	pathParams := make(map[string]string)
+	query := url.Values{}
+	requestHeaders := make(map[string]string)
+	requestHeaders[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + token
+
+	parameters := append(openapi3.Parameters{}, operation.pathItem.Parameters...)
+	parameters = append(parameters, operation.operation.Parameters...)
+	for _, parameterRef := range parameters {
+		parameter := parameterRef.Value
+		if parameter == nil {
+			continue
+		}
+		switch parameter.In {
+		case openapi3.ParameterInPath:
+			value, ok := data.PathParams[parameter.Name]
+			if !ok {
+				value = getAPITestParameterValue(parameter)
+			}
+			pathParams[parameter.Name] = formatAPITestValue(value)
+		case openapi3.ParameterInQuery:
+			if value, ok := data.QueryParams[parameter.Name]; ok {
+				query.Set(parameter.Name, formatAPITestValue(value))
+			} else if parameter.Required {
+				query.Set(parameter.Name, formatAPITestValue(getAPITestParameterValue(parameter)))
+			}
+		case openapi3.ParameterInHeader:
+			if _, ok := data.Headers[parameter.Name]; !ok && parameter.Required &&
+				!strings.EqualFold(parameter.Name, utils.HeaderAuthorization) {
+				requestHeaders[parameter.Name] = formatAPITestValue(getAPITestParameterValue(parameter))
+			}
+		}
+	}
+	// query parameters of the test data not defined in the definition are sent as well
+	for name, value := range data.QueryParams {
+		if !query.Has(name) {
+			query.Set(name, formatAPITestValue(value))
+		}
+	}
+
+	path := operation.path
+	for name, value := range pathParams {
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+	}
+	// resources matching any path are invoked with the path without the wildcard
+	path = strings.TrimSuffix(path, "/*")
+	requestURL := baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if requestBody := operation.operation.RequestBody; data.Body != nil ||
+		(requestBody != nil && requestBody.Value != nil && requestBody.Value.Required) {
+		contentType := utils.HeaderValueApplicationJSON
+		value := data.Body
+		if requestBody != nil && requestBody.Value != nil && len(requestBody.Value.Content) > 0 {
+			contentType = getMockMediaType(requestBody.Value.Content)
+			if value == nil {
+				value = getMockMediaValue(requestBody.Value.Content[contentType])
+			}
+		}
+		content, err := encodeAPITestBody(contentType, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error encoding the request body: %w", err)
+		}
+		body = bytes.NewReader(content)
+		requestHeaders[utils.HeaderContentType] = contentType
+	}
+	for name, value := range headers {
+		requestHeaders[name] = value
+	}
+	for name, value := range data.Headers {
+		requestHeaders[name] = value
+	}
+
+	request, err := http.NewRequest(operation.method, requestURL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, value := range requestHeaders {
+		request.Header.Set(name, value)
+	}
+	return request, pathParams, nil
+}
+
+// getAPITestParameterValue returns the example of a parameter, or a value generated from its schema
+func getAPITestParameterValue(parameter *openapi3.Parameter) interface{} {
+	if parameter.Example != nil {
+		return parameter.Example
+	}
+	if len(parameter.Examples) > 0 {
+		var names []string
+		for name := range parameter.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if example := parameter.Examples[names[0]]; example != nil && example.Value != nil {
+			return example.Value.Value
+		}
+	}
+	if value := getMockSchemaValue(parameter.Schema, 0); value != nil {
+		return value
+	}
+	if content := parameter.Content; len(content) > 0 {
+		return getMockMediaValue(content[getMockMediaType(content)])
+	}
+	return "1"
+}
+
+// formatAPITestValue formats a value of a parameter. Arrays are formatted as comma separated values.
+func formatAPITestValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, formatAPITestValue(item))
+		}
+		return strings.Join(values, ",")
+	case map[string]interface{}:
+		content, _ := json.Marshal(v)
+		return string(content)
+	}
+	return fmt.Sprint(value)
+}
+
+// encodeAPITestBody encodes a request body in its content type
+func encodeAPITestBody(contentType string, value interface{}) ([]byte, error) {
+	if text, ok := value.(string); ok && !strings.Contains(contentType, "json") {
+		return []byte(text), nil
+	}
+	if object, ok := value.(map[string]interface{}); ok && contentType == utils.HeaderValueXWWWFormUrlEncoded {
+		form := url.Values{}
+		for name, field := range object {
+			form.Set(name, formatAPITestValue(field))
+		}
+		return []byte(form.Encode()), nil
+	}
+	return json.Marshal(value)
+}
+
+// validateAPITestStatus checks the status of a response. The expected status of the test data should be returned if
+// it is given. Otherwise, the status should be successful and documented, unless the operation documents a default
+// response or no successful response at all.
+func validateAPITestStatus(operation *openapi3.Operation, status, expectedStatus int) error {
+	if expectedStatus != 0 {
+		if status != expectedStatus {
+			return fmt.Errorf("expected the status %d but the response status is %d", expectedStatus, status)
+		}
+		return nil
+	}
+	if status < 200 || status > 299 {
+		return fmt.Errorf("expected a successful response but the response status is %d", status)
+	}
+	if operation.Responses == nil || operation.Responses.Default() != nil {
+		return nil
+	}
+	documented := false
+	for code := range operation.Responses.Map() {
+		if code == strconv.Itoa(status) || strings.EqualFold(code, "2XX") {
+			return nil
+		}
+		if strings.HasPrefix(code, "2") {
+			documented = true
+		}
+	}
+	if documented {
+		return fmt.Errorf("the response status %d is not documented", status)
+	}
+	return nil
+}
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// PrintAPITestReport prints a test report in the given format
+func PrintAPITestReport(report *APITestReport, format string) error {
+	return WriteAPITestReport(os.Stdout, report, format)
+}
+
+// WriteAPITestReport writes a test report in the table, json or junit format
+func WriteAPITestReport(w io.Writer, report *APITestReport, format string) error {
+	switch format {
+	case APITestFormatJSON:
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(content))
+		return err
+	case APITestFormatJUnit:
+		suite := junitTestSuite{Name: report.API + ":" + report.Version, Tests: report.Tests,
+			Failures: report.Failed, Skipped: report.Skipped, Time: formatJUnitTime(report.Duration)}
+		for _, result := range report.Results {
+			testCase := junitTestCase{Name: result.Operation, ClassName: suite.Name,
+				Time: formatJUnitTime(result.Duration)}
+			switch result.Result {
+			case APITestResultFailed:
+				testCase.Failure = &junitFailure{Message: result.Errors[0], Text: strings.Join(result.Errors, "\n")}
+			case APITestResultSkipped:
+				testCase.Skipped = &struct{}{}
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		content, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, xml.Header+string(content))
+		return err
+	case APITestFormatTable, "":
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"Operation", "Status", "Result", "Errors"})
+		table.SetAutoWrapText(false)
+		for _, result := range report.Results {
+			status := ""
+			if result.Status != 0 {
+				status = strconv.Itoa(result.Status)
+			}
+			table.Append([]string{result.Operation, status, result.Result, strings.Join(result.Errors, "\n")})
+		}
+		table.Render()
+		_, err := fmt.Fprintf(w, "\n%d tests, %d passed, %d failed, %d skipped\n", report.Tests, report.Passed,
+			report.Failed, report.Skipped)
+		return err
+	}
+	return errors.New("unsupported format " + format + ". Supported formats: " + strings.Join(APITestFormats, ", "))
+}
+
+func formatJUnitTime(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
@@ -0,0 +1,212 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const apiTestDefinition = `openapi: 3.0.1
+info:
+  title: PizzaShackAPI
+  version: 1.0.0
+servers:
+  - url: http://gw.wso2.com:8280/pizzashack/1.0.0
+  - url: https://gw.wso2.com:8243/pizzashack/1.0.0
+paths:
+  /menu:
+    get:
+      operationId: getMenu
+      parameters:
+        - name: category
+          in: query
+          required: true
+          schema:
+            type: string
+            example: pizza
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/MenuItem'
+  /order/{orderId}:
+    get:
+      parameters:
+        - name: orderId
+          in: path
+          required: true
+          example: 12
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/MenuItem'
+    delete:
+      parameters:
+        - name: orderId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+  /order:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            example:
+              name: Margherita
+      responses:
+        "201":
+          description: Created
+components:
+  schemas:
+    MenuItem:
+      type: object
+      required: [name, price]
+      properties:
+        name:
+          type: string
+        price:
+          type: number
+`
+
+func TestRunAPITestOperations(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gateway-token", r.Header.Get(utils.HeaderAuthorization))
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/pizzashack/1.0.0/menu":
+			assert.Equal(t, "pizza", r.URL.Query().Get("category"), "Should send the example of the parameter")
+			_, _ = w.Write([]byte(`[{"name":"Margherita","price":10.5}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/pizzashack/1.0.0/order/34":
+			_, _ = w.Write([]byte(`{"name":"Margherita"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/pizzashack/1.0.0/order":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name":"Margherita"}`, string(body), "Should send the example of the body")
+			assert.Equal(t, "acme", r.Header.Get("X-Tenant"), "Should send the headers of the test data")
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer gateway.Close()
+
+	doc, err := loadOpenAPIDefinition([]byte(apiTestDefinition), "swagger.yaml")
+	assert.Nil(t, err, "Should load the definition")
+	data := &APITestData{
+		Headers: map[string]string{"X-Tenant": "acme"},
+		Operations: map[string]*APITestOperationData{
+			"GET /order/{orderId}":    {PathParams: map[string]interface{}{"orderId": float64(34)}},
+			"DELETE /order/{orderId}": {Skip: true},
+		},
+	}
+	report := runAPITestOperations(doc, gateway.URL+"/pizzashack/1.0.0", "gateway-token", data)
+
+	assert.Equal(t, 4, report.Tests)
+	assert.Equal(t, 1, report.Passed)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, 1, report.Skipped)
+	results := make(map[string]APITestResult)
+	for _, result := range report.Results {
+		results[result.Operation] = result
+	}
+	assert.Equal(t, APITestResultPassed, results["GET /menu"].Result)
+	assert.Equal(t, APITestResultFailed, results["GET /order/{orderId}"].Result,
+		"Should fail if the response does not match the schema")
+	assert.Contains(t, results["GET /order/{orderId}"].Errors[0], "price")
+	assert.Equal(t, APITestResultFailed, results["POST /order"].Result,
+		"Should fail if the status is not documented")
+	assert.Equal(t, []string{"the response status 200 is not documented"}, results["POST /order"].Errors)
+	assert.Equal(t, APITestResultSkipped, results["DELETE /order/{orderId}"].Result)
+}
+
+func TestRunAPITestOperationsWithoutRetries(t *testing.T) {
+	var calls int32
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer gateway.Close()
+
+	doc, err := loadOpenAPIDefinition([]byte(apiTestDefinition), "swagger.yaml")
+	assert.Nil(t, err, "Should load the definition")
+	data := &APITestData{Operations: map[string]*APITestOperationData{
+		"GET /order/{orderId}":    {Skip: true},
+		"POST /order":             {Skip: true},
+		"DELETE /order/{orderId}": {Skip: true},
+	}}
+	report := runAPITestOperations(doc, gateway.URL+"/pizzashack/1.0.0", "gateway-token", data)
+
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, int32(1), calls, "Should invoke a failing operation only once")
+}
+
+func TestGetAPITestBaseURL(t *testing.T) {
+	doc, err := loadOpenAPIDefinition([]byte(apiTestDefinition), "swagger.yaml")
+	assert.Nil(t, err, "Should load the definition")
+
+	baseURL, err := getAPITestBaseURL(doc, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://gw.wso2.com:8243/pizzashack/1.0.0", baseURL, "Should prefer the HTTPS URL")
+	baseURL, err = getAPITestBaseURL(doc, "https://localhost:9095/")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://localhost:9095/pizzashack/1.0.0", baseURL,
+		"Should replace the host of the URL with the gateway URL")
+
+	doc.Servers = nil
+	_, err = getAPITestBaseURL(doc, "")
+	assert.NotNil(t, err, "Should fail without a gateway URL")
+}
+
+func TestWriteAPITestReportJUnit(t *testing.T) {
+	report := &APITestReport{API: "PizzaShackAPI", Version: "1.0.0", Tests: 2, Passed: 1, Failed: 1,
+		Results: []APITestResult{
+			{Operation: "GET /menu", Status: 200, Result: APITestResultPassed},
+			{Operation: "POST /order", Status: 500, Result: APITestResultFailed,
+				Errors: []string{"expected a successful response but the response status is 500"}},
+		}}
+	var output bytes.Buffer
+	assert.Nil(t, WriteAPITestReport(&output, report, APITestFormatJUnit))
+	assert.Contains(t, output.String(), `<testsuite name="PizzaShackAPI:1.0.0" tests="2" failures="1" skipped="0"`)
+	assert.Contains(t, output.String(), `<testcase name="POST /order" classname="PizzaShackAPI:1.0.0" time="0.000">`)
+	assert.Contains(t, output.String(),
+		`<failure message="expected a successful response but the response status is 500">`)
+
+	assert.NotNil(t, WriteAPITestReport(&output, report, "yaml"), "Should not support other formats")
+}
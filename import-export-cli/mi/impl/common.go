@@ -33,7 +33,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
-// miHTTPRetryCount default retry count for HTTP calls
+// miHTTPRetryCount is the number of attempts of an HTTP call, renewing the access token if it has expired
 const miHTTPRetryCount = 2
 
 type updateArtifactRequestBody struct {
@@ -116,18 +116,22 @@ func handleResponse(resp *resty.Response, err error, url, messageTag, errorTag s
 	return "", errors.New(data[errorTag])
 }
 
+// retryHTTPCall invokes an HTTP call with the access token of the micro integrator. The call is repeated with a new
+// token if the token has expired. Network errors and throttled calls are retried by the shared HTTP client.
 func retryHTTPCall(attempts int, env string, f func(string) (*resty.Response, error)) (*resty.Response, error) {
 	cred, err := credentials.GetMICredentials(env)
+	if err != nil {
+		return nil, err
+	}
 	resp, err := f(cred.AccessToken)
-	if resp.StatusCode() == http.StatusUnauthorized {
-		if attempts--; attempts > 0 {
-			token, err := credentials.GetOAuthAccessTokenForMI(cred.Username, cred.Password, env)
-			if err != nil {
-				return nil, err
-			}
-			credentials.UpdateMIAccessToken(env, token)
-			return retryHTTPCall(attempts, env, f)
+	for attempts--; attempts > 0 && err == nil && resp.StatusCode() == http.StatusUnauthorized; attempts-- {
+		var token string
+		token, err = credentials.GetOAuthAccessTokenForMI(cred.Username, cred.Password, env)
+		if err != nil {
+			return nil, err
 		}
+		credentials.UpdateMIAccessToken(env, token)
+		resp, err = f(token)
 	}
 	return resp, err
 }
@@ -0,0 +1,132 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+// Package plugin is used by the plugins of apictl to reuse the configuration of apictl and the access token passed
+// by apictl.
+//
+// A plugin is an executable named apictl-<name> on the PATH or in the plugins directory of the apictl config
+// directory, which is run by apictl as the command "apictl <name>" with all the arguments following the name.
+// apictl passes the environment the plugin is run against, its config directory and, to the trusted plugins, a
+// short-lived access token of the environment to the plugin as environment variables. The credentials apictl is
+// logged in with are not available to the plugins.
+//
+//	func main() {
+//		ctx, err := plugin.NewContext()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		resp, err := ctx.InvokeGETRequest(ctx.PublisherEndpoint() + "/apis")
+//		...
+//	}
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Prefix of the executables discovered as plugins
+const Prefix = utils.ProjectName + "-"
+
+// Environment variables passed to a plugin
+const (
+	// EnvName is the name of the command the plugin is run as
+	EnvName = "APICTL_PLUGIN_NAME"
+	// EnvEnvironment is the environment given with --environment (-e), or the default environment
+	EnvEnvironment = "APICTL_ENVIRONMENT"
+	// EnvConfigDir is the directory containing the config directory of apictl, same as the one apictl is run with
+	EnvConfigDir = "APICTL_CONFIG_DIR"
+	// EnvAccessToken is an access token of the environment issued for the run of the plugin, with the
+	// plugin_token_scopes of the main config. It is empty if the path of the plugin is not listed in trusted_plugins of
+	// the main config or if apictl is not logged in to the environment
+	EnvAccessToken = "APICTL_ACCESS_TOKEN"
+)
+
+// Context of a plugin run by apictl
+type Context struct {
+	// Name of the command the plugin is run as
+	Name string
+	// Environment the plugin is run against
+	Environment string
+	// ConfigDir is the config directory of apictl
+	ConfigDir string
+	// AccessToken of the environment issued for the run of the plugin
+	AccessToken string
+}
+
+// NewContext returns the context passed by apictl to the plugin. An error is returned if the plugin is not run by
+// apictl.
+func NewContext() (*Context, error) {
+	name := os.Getenv(EnvName)
+	if name == "" {
+		return nil, fmt.Errorf("%s is not set, run the plugin as a command of %s", EnvName, utils.ProjectName)
+	}
+	return &Context{
+		Name:        name,
+		Environment: os.Getenv(EnvEnvironment),
+		ConfigDir:   filepath.Dir(utils.MainConfigFilePath),
+		AccessToken: os.Getenv(EnvAccessToken),
+	}, nil
+}
+
+// PublisherEndpoint returns the publisher REST API endpoint of the environment
+func (c *Context) PublisherEndpoint() string {
+	return utils.GetPublisherEndpointOfEnv(c.Environment, utils.MainConfigFilePath)
+}
+
+// ApiManagerEndpoint returns the API Manager endpoint of the environment
+func (c *Context) ApiManagerEndpoint() string {
+	return utils.GetApiManagerEndpointOfEnv(c.Environment, utils.MainConfigFilePath)
+}
+
+// AdminEndpoint returns the admin REST API endpoint of the environment
+func (c *Context) AdminEndpoint() string {
+	return utils.GetAdminEndpointOfEnv(c.Environment, utils.MainConfigFilePath)
+}
+
+// Headers returns the headers to invoke the REST APIs of the environment with
+func (c *Context) Headers() map[string]string {
+	headers := make(map[string]string)
+	if c.AccessToken != "" {
+		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + c.AccessToken
+	}
+	return headers
+}
+
+// InvokeGETRequest invokes a REST API of the environment with the access token
+func (c *Context) InvokeGETRequest(url string) (*resty.Response, error) {
+	if c.AccessToken == "" {
+		return nil, errors.New("no access token of " + c.Environment + " is available")
+	}
+	return utils.InvokeGETRequest(url, c.Headers())
+}
+
+// InvokeRequest invokes a REST API of the environment with the access token and a JSON body
+func (c *Context) InvokeRequest(method, url, body string) (*resty.Response, error) {
+	if c.AccessToken == "" {
+		return nil, errors.New("no access token of " + c.Environment + " is available")
+	}
+	headers := c.Headers()
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	return utils.InvokeRequest(method, url, headers, body)
+}
@@ -0,0 +1,204 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Strategies used to merge a list of the base configs with the list of an environment
+const (
+	// MergeStrategyReplace replaces the list of the base with the list of the environment
+	MergeStrategyReplace = "replace"
+	// MergeStrategyAppend adds the items of the environment after the items of the base
+	MergeStrategyAppend = "append"
+	// MergeStrategyPrepend adds the items of the environment before the items of the base
+	MergeStrategyPrepend = "prepend"
+	// MergeStrategyUnion adds the items of the environment that are not in the base
+	MergeStrategyUnion = "union"
+	// MergeStrategyMergeByKey merges the items having the same value for the key given after the prefix and adds the
+	// rest. Eg: merge:alias
+	MergeStrategyMergeByKey = "merge:"
+)
+
+// MergeStrategies are the supported strategies to merge lists
+var MergeStrategies = []string{MergeStrategyReplace, MergeStrategyAppend, MergeStrategyPrepend, MergeStrategyUnion,
+	MergeStrategyMergeByKey + "<key>"}
+
+// GetEffectiveEnv returns the environment associated for key with its configs deep merged on top of the base configs.
+// Maps are merged recursively, a null value removes the key from the base and other values replace the values of the
+// base. Lists are merged using the strategy given for their path in the configs (Eg: certs or endpoints.urls) by the
+// environment or the configuration, and are replaced by default. If not found returns nil.
+func (config EnvironmentParams) GetEffectiveEnv(key string) (*Environment, error) {
+	env := config.GetEnv(key)
+	if env == nil || config.Base == nil {
+		return env, nil
+	}
+
+	strategies := make(map[string]string)
+	for path, strategy := range config.MergeStrategies {
+		strategies[path] = strategy
+	}
+	for path, strategy := range env.MergeStrategies {
+		strategies[path] = strategy
+	}
+	if err := validateMergeStrategies(strategies); err != nil {
+		return nil, err
+	}
+
+	merged, err := mergeParamValues(toParamsMap(config.Base.Config), toParamsMap(env.Config), "", strategies)
+	if err != nil {
+		return nil, err
+	}
+	effective := &Environment{Name: env.Name, Config: make(map[string]interface{})}
+	for k, v := range merged.(map[interface{}]interface{}) {
+		effective.Config[fmt.Sprint(k)] = v
+	}
+	return effective, nil
+}
+
+// validateMergeStrategies returns an error for the first unsupported strategy, sorted by the path
+func validateMergeStrategies(strategies map[string]string) error {
+	paths := make([]string, 0, len(strategies))
+	for path := range strategies {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		switch strategy := strategies[path]; {
+		case strategy == MergeStrategyReplace, strategy == MergeStrategyAppend, strategy == MergeStrategyPrepend,
+			strategy == MergeStrategyUnion:
+		case strings.HasPrefix(strategy, MergeStrategyMergeByKey) && len(strategy) > len(MergeStrategyMergeByKey):
+		default:
+			return fmt.Errorf("unsupported merge strategy %s for %s. Supported strategies: %s", strategy, path,
+				strings.Join(MergeStrategies, ", "))
+		}
+	}
+	return nil
+}
+
+// toParamsMap converts the top level configs to the type of the maps nested in the configs
+func toParamsMap(config map[string]interface{}) map[interface{}]interface{} {
+	m := make(map[interface{}]interface{}, len(config))
+	for k, v := range config {
+		m[k] = v
+	}
+	return m
+}
+
+// mergeParamValues merges the overlay value on top of the base value at path. The values are not modified.
+func mergeParamValues(base, overlay interface{}, path string, strategies map[string]string) (interface{}, error) {
+	switch overlayValue := overlay.(type) {
+	case map[interface{}]interface{}:
+		merged := make(map[interface{}]interface{})
+		if baseMap, ok := base.(map[interface{}]interface{}); ok {
+			for k, v := range baseMap {
+				merged[k] = v
+			}
+		}
+		for k, v := range overlayValue {
+			if v == nil {
+				delete(merged, k)
+				continue
+			}
+			childPath := fmt.Sprint(k)
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+			value, err := mergeParamValues(merged[k], v, childPath, strategies)
+			if err != nil {
+				return nil, err
+			}
+			merged[k] = value
+		}
+		return merged, nil
+	case []interface{}:
+		baseList, ok := base.([]interface{})
+		if !ok {
+			return overlayValue, nil
+		}
+		return mergeParamLists(baseList, overlayValue, path, strategies)
+	default:
+		return overlay, nil
+	}
+}
+
+// mergeParamLists merges the list of an environment with the list of the base using the strategy for the path
+func mergeParamLists(base, overlay []interface{}, path string, strategies map[string]string) ([]interface{}, error) {
+	strategy := strategies[path]
+	switch {
+	case strategy == MergeStrategyAppend:
+		return append(append([]interface{}{}, base...), overlay...), nil
+	case strategy == MergeStrategyPrepend:
+		return append(append([]interface{}{}, overlay...), base...), nil
+	case strategy == MergeStrategyUnion:
+		merged := append([]interface{}{}, base...)
+		for _, item := range overlay {
+			if !containsParamValue(merged, item) {
+				merged = append(merged, item)
+			}
+		}
+		return merged, nil
+	case strings.HasPrefix(strategy, MergeStrategyMergeByKey):
+		key := strings.TrimPrefix(strategy, MergeStrategyMergeByKey)
+		merged := append([]interface{}{}, base...)
+		for _, item := range overlay {
+			index := indexOfParamItem(merged, key, item)
+			if index < 0 {
+				merged = append(merged, item)
+				continue
+			}
+			// items of the list are merged with the path of the list
+			value, err := mergeParamValues(merged[index], item, path, strategies)
+			if err != nil {
+				return nil, err
+			}
+			merged[index] = value
+		}
+		return merged, nil
+	default:
+		return overlay, nil
+	}
+}
+
+func containsParamValue(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// indexOfParamItem returns the index of the map in the list having the same value for key as the item, or -1
+func indexOfParamItem(list []interface{}, key string, item interface{}) int {
+	itemMap, ok := item.(map[interface{}]interface{})
+	if !ok || itemMap[key] == nil {
+		return -1
+	}
+	for i, v := range list {
+		if m, ok := v.(map[interface{}]interface{}); ok && reflect.DeepEqual(m[key], itemMap[key]) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,85 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEffectiveEnvMergesBase(t *testing.T) {
+	configData, err := LoadApiParamsFromFile("testdata/api_params-layered.yml")
+	assert.Nil(t, err, "Error should be nil for correct yaml loading")
+
+	env, err := configData.GetEffectiveEnv("production")
+	assert.Nil(t, err, "Error should be nil for supported merge strategies")
+	assert.Equal(t, map[interface{}]interface{}{
+		"production": map[interface{}]interface{}{"url": "https://backend.example.com"},
+	}, env.Config["endpoints"], "Should deep merge maps and remove null values")
+	assert.Equal(t, []interface{}{"Gold", "Unlimited"}, env.Config["policies"], "Should merge the union of the lists")
+	assert.Equal(t, []interface{}{
+		map[interface{}]interface{}{"hostName": "https://backend.example.com", "alias": "backend",
+			"path": "backend-prod.crt"},
+		map[interface{}]interface{}{"alias": "other", "path": "other.crt"},
+	}, env.Config["certs"], "Should merge the items of the lists having the same key")
+}
+
+func TestGetEffectiveEnvOverridesMergeStrategy(t *testing.T) {
+	configData, err := LoadApiParamsFromFile("testdata/api_params-layered.yml")
+	assert.Nil(t, err, "Error should be nil for correct yaml loading")
+
+	env, err := configData.GetEffectiveEnv("dev")
+	assert.Nil(t, err, "Error should be nil for supported merge strategies")
+	assert.Equal(t, []interface{}{"Bronze"}, env.Config["policies"], "Should replace the list of the base")
+	assert.NotContains(t, env.Config, "certs", "Should remove the list of the base for null")
+	assert.Contains(t, env.Config, "endpoints", "Should inherit the configs of the base")
+}
+
+func TestGetEffectiveEnvInvalidMergeStrategy(t *testing.T) {
+	configData, err := LoadApiParamsFromFile("testdata/api_params-layered.yml")
+	assert.Nil(t, err, "Error should be nil for correct yaml loading")
+
+	env, err := configData.GetEffectiveEnv("invalid")
+	assert.Error(t, err, "Should return an error for unsupported merge strategies")
+	assert.Nil(t, env, "Should return nil when errors are returned")
+
+	env, err = configData.GetEffectiveEnv("prod")
+	assert.Nil(t, err, "Error should be nil for undefined environments")
+	assert.Nil(t, env, "Should not contain undefined environment")
+}
+
+func TestGetEffectiveEnvWithoutBase(t *testing.T) {
+	configData, err := LoadApiParamsFromFile("testdata/api_params.yml")
+	assert.Nil(t, err, "Error should be nil for correct yaml loading")
+
+	env, err := configData.GetEffectiveEnv("dev")
+	assert.Nil(t, err, "Error should be nil without base configs")
+	assert.Equal(t, configData.GetEnv("dev"), env, "Should return the environment as it is")
+}
+
+func TestLoadEnvironmentParamsFromDirectory(t *testing.T) {
+	_, err := LoadEnvironmentParams("testdata")
+	assert.Error(t, err, "Should return an error when the directory does not have a params file")
+
+	configData, err := LoadEnvironmentParams("testdata/api_params-layered.yml")
+	assert.Nil(t, err, "Error should be nil for correct yaml loading")
+	assert.NotNil(t, configData.Base, "Should load the base configs")
+	assert.Len(t, configData.Environments, 3, "Should load all the environments")
+}
@@ -61,21 +61,40 @@ type APIIdentifier struct {
 type Environment struct {
 	Name   string                 `yaml:"name"`
 	Config map[string]interface{} `yaml:"configs"`
+	// MergeStrategies overrides the strategies used to merge the lists of the base configs for the environment
+	MergeStrategies map[string]string `yaml:"mergeStrategies,omitempty"`
 }
 
-// ApiParams represents environments defined in configuration file
-type ApiParams struct {
+// BaseParams contains the configs shared by all the environments of a configuration
+type BaseParams struct {
+	Config map[string]interface{} `yaml:"configs"`
+}
+
+// EnvironmentParams represents the environments of a configuration. The configs of an environment are deep merged
+// on top of the base configs, if provided.
+type EnvironmentParams struct {
+	// Base contains the configs shared by all the environments
+	Base *BaseParams `yaml:"base,omitempty"`
+	// MergeStrategies maps the paths of the lists in the configs to the strategies used to merge them
+	MergeStrategies map[string]string `yaml:"mergeStrategies,omitempty"`
 	// Environments contains all environments in a configuration
 	Environments []Environment `yaml:"environments"`
-	Deploy       APIVCSParams  `yaml:"deploy"`
+}
+
+// ApiParams represents environments defined in configuration file
+type ApiParams struct {
+	EnvironmentParams `yaml:",inline"`
+	Deploy            APIVCSParams `yaml:"deploy"`
 }
 
 type ApiProductParams struct {
-	Deploy ApiProductVCSParams `yaml:"deploy"`
+	EnvironmentParams `yaml:",inline"`
+	Deploy            ApiProductVCSParams `yaml:"deploy"`
 }
 
 type ApplicationParams struct {
-	Deploy ApplicationVCSParams `yaml:"deploy"`
+	EnvironmentParams `yaml:",inline"`
+	Deploy            ApplicationVCSParams `yaml:"deploy"`
 }
 
 // ------------------- Structs for VCS Import Params ----------------------------------
@@ -139,6 +158,7 @@ type APIEndpointConfig struct {
 }
 
 // loads the given file in path and substitutes environment variables that are defined as ${var} or $var in the file.
+// Relative paths referenced in the file are resolved against the directory of the file.
 //
 //	returns the file as string.
 func GetEnvSubstitutedFileContent(path string) (string, error) {
@@ -155,7 +175,7 @@ func GetEnvSubstitutedFileContent(path string) (string, error) {
 		return "", err
 	}
 
-	str, err := utils.EnvSubstituteForCurlyBraces(string(data))
+	str, err := utils.EnvSubstituteForCurlyBracesInDir(string(data), filepath.Dir(path))
 	if err != nil {
 		return "", err
 	}
@@ -248,8 +268,31 @@ func ExtractAPIEndpointConfig(b []byte) (string, error) {
 	return apiConfig.EPConfig, err
 }
 
-// GetEnv returns the EndpointData associated for key in the ApiParams, if not found returns nil
-func (config ApiParams) GetEnv(key string) *Environment {
+// LoadEnvironmentParams loads the environments of a configuration YAML file located in path, or of the configuration
+// file in the directory if a deployment directory is provided.
+//
+//	It returns an error or a valid EnvironmentParams
+func LoadEnvironmentParams(path string) (*EnvironmentParams, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, utils.ParamFile)
+	}
+	utils.Logln(utils.LogPrefixInfo + "Loading params from " + path)
+	fileContent, err := GetEnvSubstitutedFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+
+	envParams := &EnvironmentParams{}
+	err = yaml.Unmarshal([]byte(fileContent), &envParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return envParams, err
+}
+
+// GetEnv returns the Environment associated for key in the configuration as it is, if not found returns nil
+func (config EnvironmentParams) GetEnv(key string) *Environment {
 	for index, env := range config.Environments {
 		if env.Name == key {
 			return &config.Environments[index]
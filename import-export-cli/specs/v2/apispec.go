@@ -22,6 +22,20 @@ const (
 	EpHttp        = "http"
 	EpLoadbalance = "load_balance"
 	EpFailover    = "failover"
+	EpAddress     = "address"
+)
+
+// Types of the APIs
+const (
+	APITypeHTTP     = "HTTP"
+	APITypeSOAP     = "SOAP"
+	APITypeGraphQL  = "GRAPHQL"
+	APITypeWS       = "WS"
+	APITypeWebSub   = "WEBSUB"
+	APITypeSSE      = "SSE"
+	APITypeAsync    = "ASYNC"
+	defaultAuthType = "Application & Application User"
+	defaultPolicy   = "Unlimited"
 )
 
 // APIDefinition represents an API artifact in APIM
@@ -33,62 +47,97 @@ type APIDefinitionFile struct {
 
 // APIDTODefinition represents an APIDTO artifact in APIM
 type APIDTODefinition struct {
-	ID                              string        `json:"id,omitempty" yaml:"id,omitempty"`
-	Name                            string        `json:"name,omitempty" yaml:"name,omitempty"`
-	DisplayName                     string        `json:"displayName,omitempty" yaml:"displayName,omitempty"`
-	Description                     string        `json:"description,omitempty" yaml:"description,omitempty"`
-	Context                         string        `json:"context,omitempty" yaml:"context,omitempty"`
-	Version                         string        `json:"version,omitempty" yaml:"version,omitempty"`
-	Provider                        string        `json:"provider,omitempty" yaml:"provider,omitempty"`
-	LifeCycleStatus                 string        `json:"lifeCycleStatus,omitempty" yaml:"lifeCycleStatus,omitempty"`
-	WsdlInfo                        interface{}   `json:"wsdlInfo,omitempty" yaml:"wsdlInfo,omitempty"`
-	WsdlURL                         string        `json:"wsdlUrl,omitempty" yaml:"wsdlUrl,omitempty"`
-	ResponseCachingEnabledKey       bool          `json:"responseCachingEnabled,omitempty" yaml:"responseCachingEnabled,omitempty"`
-	CacheTimeout                    int           `json:"cacheTimeout,omitempty" yaml:"cacheTimeout,omitempty"`
-	HasThumbnail                    bool          `json:"hasThumbnail,omitempty" yaml:"hasThumbnail,omitempty"`
-	IsDefaultVersion                bool          `json:"isDefaultVersion,omitempty" yaml:"isDefaultVersion,omitempty"`
-	IsRevision                      bool          `json:"isRevision" yaml:"isRevision"`
-	RevisionID                      int32         `json:"revisionId" yaml:"revisionId"`
-	EnableSchemaValidation          bool          `json:"enableSchemaValidation,omitempty" yaml:"enableSchemaValidation,omitempty"`
-	Type                            string        `json:"type,omitempty" yaml:"type,omitempty"`
-	Transport                       []string      `json:"transport,omitempty" yaml:"transport,omitempty"`
-	Tags                            []string      `json:"tags,omitempty" yaml:"tags,omitempty"`
-	Policies                        []string      `json:"policies,omitempty" yaml:"policies,omitempty"`
-	APIThrottlingPolicy             string        `json:"apiThrottlingPolicy,omitempty" yaml:"apiThrottlingPolicy,omitempty"`
-	AuthorizationHeader             string        `json:"authorizationHeader,omitempty" yaml:"authorizationHeader,omitempty"`
-	ApiKeyHeader                    string        `json:"apiKeyHeader,omitempty" yaml:"apiKeyHeader,omitempty"`
-	SecurityScheme                  []string      `json:"securityScheme,omitempty" yaml:"securityScheme,omitempty"`
-	MaxTPS                          interface{}   `json:"maxTps,omitempty" yaml:"maxTps,omitempty"`
-	Visibility                      string        `json:"visibility,omitempty" yaml:"visibility,omitempty"`
-	VisibleRoles                    []string      `json:"visibleRoles,omitempty" yaml:"visibleRoles,omitempty"`
-	VisibleTenants                  []string      `json:"visibleTenants,omitempty" yaml:"visibleTenants,omitempty"`
-	MediationPolicies               []interface{} `json:"mediationPolicies,omitempty" yaml:"mediationPolicies,omitempty"`
-	SubscriptionAvailability        string        `json:"subscriptionAvailability,omitempty" yaml:"subscriptionAvailability,omitempty"`
-	SubscriptionAvailableTenants    []string      `json:"subscriptionAvailableTenants,omitempty" yaml:"subscriptionAvailableTenants,omitempty"`
-	AdditionalProperties            []interface{} `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
-	Monetization                    interface{}   `json:"monetization,omitempty" yaml:"monetization,omitempty"`
-	AccessControl                   string        `json:"accessControl,omitempty" yaml:"accessControl,omitempty"`
-	AccessControlRoles              []string      `json:"accessControlRoles,omitempty" yaml:"accessControlRoles,omitempty"`
-	BusinessInformation             interface{}   `json:"businessInformation,omitempty" yaml:"businessInformation,omitempty"`
-	CorsConfiguration               interface{}   `json:"corsConfiguration,omitempty" yaml:"corsConfiguration,omitempty"`
-	WorkflowStatus                  []string      `json:"workflowStatus,omitempty" yaml:"workflowStatus,omitempty"`
-	CreatedTime                     string        `json:"createdTime,omitempty" yaml:"createdTime,omitempty"`
-	LastUpdatedTimestamp            string        `json:"lastUpdatedTimestamp,omitempty" yaml:"lastUpdatedTimestamp,omitempty"`
-	LastUpdatedTime                 string        `json:"lastUpdatedTime,omitempty" yaml:"lastUpdatedTime,omitempty"`
-	EndpointConfig                  interface{}   `json:"endpointConfig,omitempty" yaml:"endpointConfig,omitempty"`
-	EndpointImplementationType      string        `json:"endpointImplementationType,omitempty" yaml:"endpointImplementationType,omitempty"`
-	Scopes                          []interface{} `json:"scopes,omitempty" yaml:"scopes,omitempty"`
-	Operations                      []interface{} `json:"operations,omitempty" yaml:"operations,omitempty"`
-	ThreatProtectionPolicies        interface{}   `json:"threatProtectionPolicies,omitempty" yaml:"threatProtectionPolicies,omitempty"`
-	Categories                      []string      `json:"categories,omitempty" yaml:"categories,omitempty"`
-	KeyManagers                     []string      `json:"keyManagers,omitempty" yaml:"keyManagers,omitempty"`
-	AdvertiseInformation            AdvertiseInfo `json:"advertiseInfo,omitempty" yaml:"advertiseInfo,omitempty"`
-	WebsubSubscriptionConfiguration interface{}   `json:"websubSubscriptionConfiguration" yaml:"websubSubscriptionConfiguration"`
-	GatewayVendor                   string        `json:"gatewayVendor,omitempty" yaml:"gatewayVendor,omitempty"`
-	AsyncTransportProtocols         []string      `json:"asyncTransportProtocols,omitempty" yaml:"asyncTransportProtocols,omitempty"`
-	GatewayType                     string        `json:"gatewayType,omitempty" yaml:"gatewayType,omitempty"`
-	InitiatedFromGateway            bool          `json:"initiatedFromGateway,omitempty" yaml:"initiatedFromGateway,omitempty"`
-	EnableSubscriberVerification    bool          `json:"enableSubscriberVerification,omitempty" yaml:"enableSubscriberVerification,omitempty"`
+	ID                              string             `json:"id,omitempty" yaml:"id,omitempty"`
+	Name                            string             `json:"name,omitempty" yaml:"name,omitempty"`
+	DisplayName                     string             `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	Description                     string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Context                         string             `json:"context,omitempty" yaml:"context,omitempty"`
+	Version                         string             `json:"version,omitempty" yaml:"version,omitempty"`
+	Provider                        string             `json:"provider,omitempty" yaml:"provider,omitempty"`
+	LifeCycleStatus                 string             `json:"lifeCycleStatus,omitempty" yaml:"lifeCycleStatus,omitempty"`
+	WsdlInfo                        interface{}        `json:"wsdlInfo,omitempty" yaml:"wsdlInfo,omitempty"`
+	WsdlURL                         string             `json:"wsdlUrl,omitempty" yaml:"wsdlUrl,omitempty"`
+	ResponseCachingEnabledKey       bool               `json:"responseCachingEnabled,omitempty" yaml:"responseCachingEnabled,omitempty"`
+	CacheTimeout                    int                `json:"cacheTimeout,omitempty" yaml:"cacheTimeout,omitempty"`
+	HasThumbnail                    bool               `json:"hasThumbnail,omitempty" yaml:"hasThumbnail,omitempty"`
+	IsDefaultVersion                bool               `json:"isDefaultVersion,omitempty" yaml:"isDefaultVersion,omitempty"`
+	IsRevision                      bool               `json:"isRevision" yaml:"isRevision"`
+	RevisionID                      int32              `json:"revisionId" yaml:"revisionId"`
+	EnableSchemaValidation          bool               `json:"enableSchemaValidation,omitempty" yaml:"enableSchemaValidation,omitempty"`
+	Type                            string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Transport                       []string           `json:"transport,omitempty" yaml:"transport,omitempty"`
+	Tags                            []string           `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Policies                        []string           `json:"policies,omitempty" yaml:"policies,omitempty"`
+	APIThrottlingPolicy             string             `json:"apiThrottlingPolicy,omitempty" yaml:"apiThrottlingPolicy,omitempty"`
+	AuthorizationHeader             string             `json:"authorizationHeader,omitempty" yaml:"authorizationHeader,omitempty"`
+	ApiKeyHeader                    string             `json:"apiKeyHeader,omitempty" yaml:"apiKeyHeader,omitempty"`
+	SecurityScheme                  []string           `json:"securityScheme,omitempty" yaml:"securityScheme,omitempty"`
+	MaxTPS                          interface{}        `json:"maxTps,omitempty" yaml:"maxTps,omitempty"`
+	Visibility                      string             `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+	VisibleRoles                    []string           `json:"visibleRoles,omitempty" yaml:"visibleRoles,omitempty"`
+	VisibleTenants                  []string           `json:"visibleTenants,omitempty" yaml:"visibleTenants,omitempty"`
+	MediationPolicies               []interface{}      `json:"mediationPolicies,omitempty" yaml:"mediationPolicies,omitempty"`
+	SubscriptionAvailability        string             `json:"subscriptionAvailability,omitempty" yaml:"subscriptionAvailability,omitempty"`
+	SubscriptionAvailableTenants    []string           `json:"subscriptionAvailableTenants,omitempty" yaml:"subscriptionAvailableTenants,omitempty"`
+	AdditionalProperties            []interface{}      `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Monetization                    interface{}        `json:"monetization,omitempty" yaml:"monetization,omitempty"`
+	AccessControl                   string             `json:"accessControl,omitempty" yaml:"accessControl,omitempty"`
+	AccessControlRoles              []string           `json:"accessControlRoles,omitempty" yaml:"accessControlRoles,omitempty"`
+	BusinessInformation             interface{}        `json:"businessInformation,omitempty" yaml:"businessInformation,omitempty"`
+	CorsConfiguration               interface{}        `json:"corsConfiguration,omitempty" yaml:"corsConfiguration,omitempty"`
+	WorkflowStatus                  []string           `json:"workflowStatus,omitempty" yaml:"workflowStatus,omitempty"`
+	CreatedTime                     string             `json:"createdTime,omitempty" yaml:"createdTime,omitempty"`
+	LastUpdatedTimestamp            string             `json:"lastUpdatedTimestamp,omitempty" yaml:"lastUpdatedTimestamp,omitempty"`
+	LastUpdatedTime                 string             `json:"lastUpdatedTime,omitempty" yaml:"lastUpdatedTime,omitempty"`
+	EndpointConfig                  interface{}        `json:"endpointConfig,omitempty" yaml:"endpointConfig,omitempty"`
+	EndpointImplementationType      string             `json:"endpointImplementationType,omitempty" yaml:"endpointImplementationType,omitempty"`
+	Scopes                          []interface{}      `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Operations                      []interface{}      `json:"operations,omitempty" yaml:"operations,omitempty"`
+	ThreatProtectionPolicies        interface{}        `json:"threatProtectionPolicies,omitempty" yaml:"threatProtectionPolicies,omitempty"`
+	Categories                      []string           `json:"categories,omitempty" yaml:"categories,omitempty"`
+	KeyManagers                     []string           `json:"keyManagers,omitempty" yaml:"keyManagers,omitempty"`
+	AdvertiseInformation            AdvertiseInfo      `json:"advertiseInfo,omitempty" yaml:"advertiseInfo,omitempty"`
+	WebsubSubscriptionConfiguration interface{}        `json:"websubSubscriptionConfiguration" yaml:"websubSubscriptionConfiguration"`
+	GatewayVendor                   string             `json:"gatewayVendor,omitempty" yaml:"gatewayVendor,omitempty"`
+	AsyncTransportProtocols         []string           `json:"asyncTransportProtocols,omitempty" yaml:"asyncTransportProtocols,omitempty"`
+	GatewayType                     string             `json:"gatewayType,omitempty" yaml:"gatewayType,omitempty"`
+	InitiatedFromGateway            bool               `json:"initiatedFromGateway,omitempty" yaml:"initiatedFromGateway,omitempty"`
+	EnableSubscriberVerification    bool               `json:"enableSubscriberVerification,omitempty" yaml:"enableSubscriberVerification,omitempty"`
+	APIPolicies                     *OperationPolicies `json:"apiPolicies,omitempty" yaml:"apiPolicies,omitempty"`
+}
+
+// APIOperation represents a resource of an API. The target is the path of a REST resource, the topic of an async
+// API or the field of a GraphQL API and the verb is the HTTP method or the kind of the operation.
+type APIOperation struct {
+	Target            string             `json:"target" yaml:"target"`
+	Verb              string             `json:"verb" yaml:"verb"`
+	AuthType          string             `json:"authType,omitempty" yaml:"authType,omitempty"`
+	ThrottlingPolicy  string             `json:"throttlingPolicy,omitempty" yaml:"throttlingPolicy,omitempty"`
+	OperationPolicies *OperationPolicies `json:"operationPolicies,omitempty" yaml:"operationPolicies,omitempty"`
+}
+
+// NewAPIOperation creates an operation secured with the default auth type and throttling policy
+func NewAPIOperation(target, verb string) APIOperation {
+	return APIOperation{Target: target, Verb: verb, AuthType: defaultAuthType, ThrottlingPolicy: defaultPolicy}
+}
+
+// OperationPolicies holds the policies applied to the request, response and fault flows of an API or an operation
+type OperationPolicies struct {
+	Request  []OperationPolicy `json:"request" yaml:"request"`
+	Response []OperationPolicy `json:"response" yaml:"response"`
+	Fault    []OperationPolicy `json:"fault" yaml:"fault"`
+}
+
+// OperationPolicy is a policy attached to a flow. The parameters depend on the policy.
+type OperationPolicy struct {
+	PolicyName    string                 `json:"policyName" yaml:"policyName"`
+	PolicyVersion string                 `json:"policyVersion" yaml:"policyVersion"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// IsEmpty returns true if no policy is attached to any of the flows
+func (p *OperationPolicies) IsEmpty() bool {
+	return p == nil || len(p.Request)+len(p.Response)+len(p.Fault) == 0
 }
 
 type CorsConfiguration struct {
@@ -112,6 +161,20 @@ type Data struct {
 	Visibility    string `json:"visibility,omitempty" yaml:"visibility,omitempty"`
 }
 
+// DeploymentEnvironmentsFile represents the deployment_environments.yaml file of a project
+type DeploymentEnvironmentsFile struct {
+	Type        string                  `json:"type,omitempty" yaml:"type,omitempty"`
+	ApimVersion string                  `json:"version,omitempty" yaml:"version,omitempty"`
+	Data        []DeploymentEnvironment `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// DeploymentEnvironment represents a gateway environment a revision is deployed to
+type DeploymentEnvironment struct {
+	DisplayOnDevportal    bool   `json:"displayOnDevportal" yaml:"displayOnDevportal"`
+	DeploymentEnvironment string `json:"deploymentEnvironment,omitempty" yaml:"deploymentEnvironment,omitempty"`
+	DeploymentVhost       string `json:"deploymentVhost,omitempty" yaml:"deploymentVhost,omitempty"`
+}
+
 // AdvertiseInfo : Advertise only information
 type AdvertiseInfo struct {
 	Advertised                    bool   `json:"advertised,omitempty" yaml:"advertised,omitempty"`
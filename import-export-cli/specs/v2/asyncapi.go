@@ -0,0 +1,302 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// asyncAPIDocument represents the parts of an AsyncAPI 2.x or 3.x definition used to populate an API
+type asyncAPIDocument struct {
+	AsyncAPI string `json:"asyncapi"`
+	Info     struct {
+		Title       string `json:"title"`
+		Version     string `json:"version"`
+		Description string `json:"description"`
+		Tags        []Tag  `json:"tags"`
+	} `json:"info"`
+	Tags       []Tag                        `json:"tags"`
+	Servers    map[string]asyncAPIServer    `json:"servers"`
+	Channels   map[string]asyncAPIChannel   `json:"channels"`
+	Operations map[string]asyncAPIOperation `json:"operations"`
+}
+
+// asyncAPIServer is a server of an AsyncAPI definition. AsyncAPI 2.x uses url while 3.x uses host and pathname.
+type asyncAPIServer struct {
+	URL      string `json:"url"`
+	Host     string `json:"host"`
+	Pathname string `json:"pathname"`
+	Protocol string `json:"protocol"`
+}
+
+// address returns the url of the server with the scheme of its protocol if the url does not have a scheme
+func (s asyncAPIServer) address() string {
+	address := s.URL
+	if address == "" {
+		address = s.Host + s.Pathname
+	}
+	if address != "" && !strings.Contains(address, "://") && s.Protocol != "" {
+		scheme := strings.ToLower(s.Protocol)
+		// server sent events and websub are served over http
+		if scheme == "sse" || scheme == "websub" {
+			scheme = "http"
+		}
+		address = scheme + "://" + address
+	}
+	return strings.TrimSuffix(address, "/")
+}
+
+// asyncAPIChannel is a channel of an AsyncAPI definition. The operations are defined in the channel in AsyncAPI 2.x
+type asyncAPIChannel struct {
+	Address   *string     `json:"address"`
+	Subscribe interface{} `json:"subscribe"`
+	Publish   interface{} `json:"publish"`
+}
+
+// asyncAPIOperation is an operation of an AsyncAPI 3.x definition
+type asyncAPIOperation struct {
+	Action  string `json:"action"`
+	Channel struct {
+		Ref string `json:"$ref"`
+	} `json:"channel"`
+}
+
+// Verbs of the topics of async APIs
+const (
+	asyncVerbSubscribe = "SUBSCRIBE"
+	asyncVerbPublish   = "PUBLISH"
+)
+
+// asyncAPITypes maps the protocols of AsyncAPI servers to the types of APIs
+var asyncAPITypes = map[string]string{
+	"ws":     APITypeWS,
+	"wss":    APITypeWS,
+	"websub": APITypeWebSub,
+	"http":   APITypeWebSub,
+	"https":  APITypeWebSub,
+	"sse":    APITypeSSE,
+}
+
+// AsyncAPIPopulate populates the API definition using an AsyncAPI 2.x or 3.x definition in YAML or JSON. The type
+// of the API is resolved from the protocol of the servers. Servers of the ws and wss protocols create WS APIs, sse
+// creates SSE APIs, websub, http and https create WEBSUB APIs and the rest create ASYNC APIs.
+func AsyncAPIPopulate(def *APIDTODefinition, content []byte) error {
+	data, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return err
+	}
+	var doc asyncAPIDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.AsyncAPI == "" {
+		return fmt.Errorf("not an AsyncAPI definition. The asyncapi field is missing")
+	}
+	var exts map[string]interface{}
+	if err := json.Unmarshal(data, &exts); err != nil {
+		return err
+	}
+
+	def.Name = strings.ReplaceAll(doc.Info.Title, " ", "")
+	def.Version = strings.ReplaceAll(doc.Info.Version, " ", "")
+	def.Provider = "admin"
+	def.Description = doc.Info.Description
+	def.Context = fmt.Sprintf("/%s", def.Name)
+	tags := doc.Tags
+	if len(tags) == 0 {
+		tags = doc.Info.Tags
+	}
+	def.Tags = nil
+	for _, tag := range tags {
+		def.Tags = append(def.Tags, tag.Name)
+	}
+
+	basepath, ok, err := oai3WSO2Basepath(exts)
+	if err != nil {
+		return err
+	}
+	if ok {
+		populateContext(def, basepath)
+	}
+	def.Context = strings.ReplaceAll(def.Context, " ", "")
+
+	// servers are sorted by the name since the order of a map is not preserved
+	names := make([]string, 0, len(doc.Servers))
+	for name := range doc.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	def.Type = APITypeWS
+	var protocols, urls []string
+	for i, name := range names {
+		server := doc.Servers[name]
+		protocol := strings.ToLower(server.Protocol)
+		if i == 0 {
+			if apiType, ok := asyncAPITypes[protocol]; ok {
+				def.Type = apiType
+			} else {
+				def.Type = APITypeAsync
+			}
+		}
+		if protocol != "" && !containsString(protocols, protocol) {
+			protocols = append(protocols, protocol)
+		}
+		if address := server.address(); address != "" {
+			urls = append(urls, address)
+		}
+	}
+
+	switch def.Type {
+	case APITypeWS:
+		def.Transport = []string{"ws", "wss"}
+	case APITypeAsync:
+		def.AsyncTransportProtocols = protocols
+	default:
+		def.Transport = []string{"http", "https"}
+	}
+
+	if err := populateAsyncEndpoints(def, exts, urls); err != nil {
+		return err
+	}
+	if def.Type == APITypeWebSub {
+		def.WebsubSubscriptionConfiguration = map[string]interface{}{
+			"enable":           false,
+			"secret":           "",
+			"signingAlgorithm": "SHA1",
+			"signatureHeader":  "x-hub-signature",
+		}
+	}
+
+	def.Operations = nil
+	for _, topic := range asyncAPITopics(&doc) {
+		target, verb := topic[0], topic[1]
+		if def.Type == APITypeWS && !strings.HasPrefix(target, "/") {
+			target = "/" + target
+		}
+		// Only WS and ASYNC APIs allow clients to publish to the topics
+		if verb == asyncVerbPublish && def.Type != APITypeWS && def.Type != APITypeAsync {
+			verb = asyncVerbSubscribe
+		}
+		operation := NewAPIOperation(target, verb)
+		if !containsOperation(def.Operations, operation) {
+			def.Operations = append(def.Operations, operation)
+		}
+	}
+	return nil
+}
+
+// populateAsyncEndpoints sets the endpoint config of an async API from the wso2 extensions or the servers. WEBSUB and
+// ASYNC APIs do not have endpoints.
+func populateAsyncEndpoints(def *APIDTODefinition, exts map[string]interface{}, urls []string) error {
+	if def.Type == APITypeWebSub || def.Type == APITypeAsync {
+		def.EndpointConfig = nil
+		return nil
+	}
+	endpointType := EpHttp
+	if def.Type == APITypeWS {
+		endpointType = "ws"
+	}
+	found, err := populateEndpoints(def, exts)
+	if err != nil {
+		return err
+	}
+	if found {
+		if endpointConfig, ok := def.EndpointConfig.(*map[string]interface{}); ok &&
+			(*endpointConfig)["endpoint_type"] == EpHttp {
+			(*endpointConfig)["endpoint_type"] = endpointType
+		}
+		return nil
+	}
+	url := "http://localhost:8080"
+	if def.Type == APITypeWS {
+		url = "ws://localhost:8080"
+	}
+	if len(urls) > 0 {
+		url = urls[0]
+	}
+	def.EndpointConfig = map[string]interface{}{
+		"endpoint_type":        endpointType,
+		"production_endpoints": map[string]interface{}{"url": url},
+		"sandbox_endpoints":    map[string]interface{}{"url": url},
+	}
+	return nil
+}
+
+// asyncAPITopics returns the channels of the definition with the verb of each operation on them, sorted by the channel
+func asyncAPITopics(doc *asyncAPIDocument) [][2]string {
+	var topics [][2]string
+	if len(doc.Operations) > 0 {
+		for _, operation := range doc.Operations {
+			name := path.Base(operation.Channel.Ref)
+			target := name
+			if channel, ok := doc.Channels[name]; ok && channel.Address != nil {
+				target = *channel.Address
+			}
+			// the application receives the messages of a channel it subscribes to
+			verb := asyncVerbSubscribe
+			if operation.Action == "send" {
+				verb = asyncVerbPublish
+			}
+			topics = append(topics, [2]string{target, verb})
+		}
+	} else {
+		for name, channel := range doc.Channels {
+			if channel.Address != nil {
+				name = *channel.Address
+			}
+			if channel.Publish != nil {
+				topics = append(topics, [2]string{name, asyncVerbPublish})
+			}
+			if channel.Subscribe != nil || channel.Publish == nil {
+				topics = append(topics, [2]string{name, asyncVerbSubscribe})
+			}
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if topics[i][0] != topics[j][0] {
+			return topics[i][0] < topics[j][0]
+		}
+		return topics[i][1] > topics[j][1]
+	})
+	return topics
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsOperation(operations []interface{}, operation APIOperation) bool {
+	for _, op := range operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncAPIPopulateWebSocket(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/streetlights_asyncapi2.yaml")
+	assert.Nil(t, err, "err should be nil")
+	var def APIDTODefinition
+	err = AsyncAPIPopulate(&def, content)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "StreetLights", def.Name, "Should return correct api name")
+	assert.Equal(t, "/streetlights", def.Context, "Should return context from the extension")
+	assert.Equal(t, APITypeWS, def.Type, "Should be a WS API")
+	assert.Equal(t, []string{"ws", "wss"}, def.Transport)
+	assert.Equal(t, []string{"lights"}, def.Tags)
+	endpointConfig := def.EndpointConfig.(map[string]interface{})
+	assert.Equal(t, "ws", endpointConfig["endpoint_type"])
+	assert.Equal(t, map[string]interface{}{"url": "wss://streetlights.example.com/ws"},
+		endpointConfig["production_endpoints"], "Should use the server as the endpoint")
+	assert.Equal(t, []interface{}{
+		NewAPIOperation("/light/dim", "SUBSCRIBE"),
+		NewAPIOperation("/light/dim", "PUBLISH"),
+		NewAPIOperation("/light/measured", "SUBSCRIBE"),
+		NewAPIOperation("/light/turn-on", "PUBLISH"),
+	}, def.Operations, "Should have a topic for each operation of the channels")
+}
+
+func TestAsyncAPIPopulateServerSentEvents(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/notifications_asyncapi3.yaml")
+	assert.Nil(t, err, "err should be nil")
+	var def APIDTODefinition
+	err = AsyncAPIPopulate(&def, content)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, APITypeSSE, def.Type, "Should be a SSE API")
+	assert.Equal(t, "/Notifications", def.Context)
+	endpointConfig := def.EndpointConfig.(map[string]interface{})
+	assert.Equal(t, EpHttp, endpointConfig["endpoint_type"])
+	assert.Equal(t, map[string]interface{}{"url": "http://notifications.example.com/events"},
+		endpointConfig["production_endpoints"])
+	assert.Equal(t, []interface{}{
+		NewAPIOperation("orders/created", "SUBSCRIBE"),
+		NewAPIOperation("orders/shipped", "SUBSCRIBE"),
+	}, def.Operations, "Should only allow subscribing to the topics")
+}
+
+func TestAsyncAPIPopulateInvalidDefinition(t *testing.T) {
+	var def APIDTODefinition
+	err := AsyncAPIPopulate(&def, []byte("openapi: 3.0.0\n"))
+	assert.NotNil(t, err, "Should fail for a definition that is not an AsyncAPI")
+}
@@ -0,0 +1,491 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Verbs of the operations of GraphQL APIs
+const (
+	graphQLVerbQuery        = "QUERY"
+	graphQLVerbMutation     = "MUTATION"
+	graphQLVerbSubscription = "SUBSCRIPTION"
+)
+
+// GraphQLPopulate populates the API definition using a GraphQL schema in SDL. An operation is created for each field
+// of the query, mutation and subscription root types.
+func GraphQLPopulate(def *APIDTODefinition, schema []byte) error {
+	roots, fields := parseGraphQLSchema(string(schema))
+	if len(fields[roots[graphQLVerbQuery]]) == 0 {
+		return fmt.Errorf("invalid GraphQL schema. The %s type does not have any fields", roots[graphQLVerbQuery])
+	}
+	def.Type = APITypeGraphQL
+	def.Transport = []string{"http", "https"}
+	def.Operations = nil
+	for _, verb := range []string{graphQLVerbQuery, graphQLVerbMutation, graphQLVerbSubscription} {
+		for _, field := range fields[roots[verb]] {
+			def.Operations = append(def.Operations, NewAPIOperation(field, verb))
+		}
+	}
+	return nil
+}
+
+// parseGraphQLSchema returns the names of the root types of the schema and the fields of each object type
+func parseGraphQLSchema(schema string) (map[string]string, map[string][]string) {
+	roots := map[string]string{
+		graphQLVerbQuery:        "Query",
+		graphQLVerbMutation:     "Mutation",
+		graphQLVerbSubscription: "Subscription",
+	}
+	fields := make(map[string][]string)
+	tokens := tokenizeGraphQL(schema)
+	for i := 0; i < len(tokens); i++ {
+		switch {
+		case tokens[i] == "schema" && (i == 0 || tokens[i-1] != "@"):
+			body, end := graphQLBlock(tokens, i+1)
+			for j := 0; j+2 < len(body); j++ {
+				if body[j+1] == ":" {
+					roots[strings.ToUpper(body[j])] = body[j+2]
+				}
+			}
+			i = end
+		case tokens[i] == "type" && i+1 < len(tokens):
+			name := tokens[i+1]
+			body, end := graphQLBlock(tokens, i+2)
+			fields[name] = append(fields[name], graphQLFields(body)...)
+			i = end
+		case tokens[i] == "{":
+			// skip the fields and values of interfaces, inputs and enums
+			_, end := graphQLBlock(tokens, i)
+			i = end
+		}
+	}
+	return roots, fields
+}
+
+// graphQLBlock returns the tokens inside the braces following the token at start and the index of the closing brace.
+// Tokens of interfaces and directives before the opening brace are skipped.
+func graphQLBlock(tokens []string, start int) ([]string, int) {
+	i := start
+	for i < len(tokens) && tokens[i] != "{" {
+		// a type without fields ends at the next definition
+		if tokens[i] == "type" || tokens[i] == "schema" || tokens[i] == "extend" {
+			return nil, i - 1
+		}
+		i++
+	}
+	depth := 0
+	for j := i; j < len(tokens); j++ {
+		switch tokens[j] {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return tokens[i+1 : j], j
+			}
+		}
+	}
+	return nil, len(tokens)
+}
+
+// graphQLFields returns the names of the fields of an object type body. Arguments are skipped and a name is a field
+// name if it is followed by the arguments or the type of the field.
+func graphQLFields(body []string) []string {
+	var fields []string
+	depth := 0
+	for i, token := range body {
+		switch token {
+		case "(":
+			depth++
+			continue
+		case ")":
+			depth--
+			continue
+		}
+		if depth > 0 || i+1 >= len(body) || (i > 0 && body[i-1] == "@") || !isGraphQLName(token) {
+			continue
+		}
+		if body[i+1] == ":" || body[i+1] == "(" {
+			fields = append(fields, token)
+		}
+	}
+	return fields
+}
+
+// tokenizeGraphQL splits a schema to names and punctuators. Comments and strings, including descriptions, are removed.
+func tokenizeGraphQL(schema string) []string {
+	var tokens []string
+	for i := 0; i < len(schema); i++ {
+		c := schema[i]
+		switch {
+		case c == '#':
+			for i < len(schema) && schema[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(schema[i:], `"""`):
+			end := strings.Index(schema[i+3:], `"""`)
+			if end < 0 {
+				return tokens
+			}
+			i += end + 5
+		case c == '"':
+			for i++; i < len(schema) && schema[i] != '"' && schema[i] != '\n'; i++ {
+				if schema[i] == '\\' {
+					i++
+				}
+			}
+		case isGraphQLNameRune(rune(c)):
+			start := i
+			for i+1 < len(schema) && isGraphQLNameRune(rune(schema[i+1])) {
+				i++
+			}
+			tokens = append(tokens, schema[start:i+1])
+		case unicode.IsSpace(rune(c)) || c == ',':
+		default:
+			tokens = append(tokens, string(c))
+		}
+	}
+	return tokens
+}
+
+func isGraphQLNameRune(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+func isGraphQLName(token string) bool {
+	r := []rune(token)[0]
+	return r == '_' || (r < unicode.MaxASCII && unicode.IsLetter(r))
+}
+
+// GraphQLSchema holds the types of a GraphQL schema needed to build responses for queries
+type GraphQLSchema struct {
+	// Roots maps QUERY, MUTATION and SUBSCRIPTION to the names of the root types
+	Roots map[string]string
+	// Types maps the names of the object and interface types to their fields
+	Types map[string][]GraphQLField
+	// Enums maps the names of the enums to their values
+	Enums map[string][]string
+}
+
+// GraphQLField is a field of an object type. Type is the name of the type of the field without the list and non null
+// modifiers.
+type GraphQLField struct {
+	Name string
+	Type string
+	List bool
+}
+
+// Field returns the field of a type, or nil if the type does not have the field
+func (s *GraphQLSchema) Field(typeName, fieldName string) *GraphQLField {
+	for i, field := range s.Types[typeName] {
+		if field.Name == fieldName {
+			return &s.Types[typeName][i]
+		}
+	}
+	return nil
+}
+
+// ParseGraphQLSchema parses the object types, interfaces and enums of a schema in SDL. Fields of types extended with
+// extend type are added to the type.
+func ParseGraphQLSchema(schema []byte) *GraphQLSchema {
+	roots, _ := parseGraphQLSchema(string(schema))
+	parsed := &GraphQLSchema{Roots: roots, Types: make(map[string][]GraphQLField),
+		Enums: make(map[string][]string)}
+	tokens := tokenizeGraphQL(string(schema))
+	for i := 0; i+1 < len(tokens); i++ {
+		if i > 0 && tokens[i-1] == "@" {
+			continue
+		}
+		switch tokens[i] {
+		case "type", "interface":
+			name := tokens[i+1]
+			body, end := graphQLBlock(tokens, i+2)
+			parsed.Types[name] = append(parsed.Types[name], graphQLTypedFields(body)...)
+			i = end
+		case "enum":
+			name := tokens[i+1]
+			body, end := graphQLBlock(tokens, i+2)
+			for j, token := range body {
+				if isGraphQLName(token) && (j == 0 || body[j-1] != "@") {
+					parsed.Enums[name] = append(parsed.Enums[name], token)
+				}
+			}
+			i = end
+		case "{":
+			_, end := graphQLBlock(tokens, i)
+			i = end
+		}
+	}
+	return parsed
+}
+
+// graphQLTypedFields returns the fields of an object type body with their types
+func graphQLTypedFields(body []string) []GraphQLField {
+	var fields []GraphQLField
+	for i := 0; i < len(body); i++ {
+		if body[i] == "(" {
+			// skip the arguments of the directives
+			i = graphQLClosingParen(body, i)
+			continue
+		}
+		if !isGraphQLName(body[i]) || (i > 0 && body[i-1] == "@") || i+1 >= len(body) {
+			continue
+		}
+		j := i + 1
+		if body[j] == "(" {
+			// skip the arguments of the field
+			j = graphQLClosingParen(body, j) + 1
+		}
+		if j >= len(body) || body[j] != ":" {
+			continue
+		}
+		field := GraphQLField{Name: body[i]}
+		for j++; j < len(body) && (body[j] == "[" || body[j] == "!"); j++ {
+			field.List = field.List || body[j] == "["
+		}
+		if j < len(body) {
+			field.Type = body[j]
+		}
+		fields = append(fields, field)
+		// skip the closing modifiers of the type
+		for i = j; i+1 < len(body) && (body[i+1] == "]" || body[i+1] == "!"); i++ {
+		}
+	}
+	return fields
+}
+
+// graphQLClosingParen returns the index of the parenthesis closing the one at start
+func graphQLClosingParen(tokens []string, start int) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens)
+}
+
+// GraphQLOperation is an operation of a GraphQL document with the fragments it spreads expanded
+type GraphQLOperation struct {
+	Verb       string
+	Selections []GraphQLSelection
+}
+
+// GraphQLSelection is a field selected by a query, or an inline fragment if the name is empty
+type GraphQLSelection struct {
+	Name          string
+	Alias         string
+	TypeCondition string
+	Selections    []GraphQLSelection
+	fragment      string
+}
+
+// Key returns the key of the selected field in the response
+func (s GraphQLSelection) Key() string {
+	if s.Alias != "" {
+		return s.Alias
+	}
+	return s.Name
+}
+
+// ParseGraphQLQuery parses a GraphQL document and returns the operation with the given name, or the only operation
+// of the document if the name is empty. Arguments, variables and directives are skipped.
+func ParseGraphQLQuery(query, operationName string) (*GraphQLOperation, error) {
+	tokens := tokenizeGraphQL(query)
+	fragments := make(map[string]GraphQLSelection)
+	var operations []*GraphQLOperation
+	var names []string
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "{":
+			selections, end, err := parseGraphQLSelections(tokens, i)
+			if err != nil {
+				return nil, err
+			}
+			operations = append(operations, &GraphQLOperation{Verb: graphQLVerbQuery, Selections: selections})
+			names = append(names, "")
+			i = end
+		case "query", "mutation", "subscription":
+			verb := strings.ToUpper(tokens[i])
+			name := ""
+			if i+1 < len(tokens) && isGraphQLName(tokens[i+1]) {
+				name = tokens[i+1]
+			}
+			start := graphQLSelectionStart(tokens, i+1)
+			selections, end, err := parseGraphQLSelections(tokens, start)
+			if err != nil {
+				return nil, err
+			}
+			operations = append(operations, &GraphQLOperation{Verb: verb, Selections: selections})
+			names = append(names, name)
+			i = end
+		case "fragment":
+			if i+3 >= len(tokens) || tokens[i+2] != "on" {
+				return nil, fmt.Errorf("invalid fragment definition")
+			}
+			selections, end, err := parseGraphQLSelections(tokens, graphQLSelectionStart(tokens, i+4))
+			if err != nil {
+				return nil, err
+			}
+			fragments[tokens[i+1]] = GraphQLSelection{TypeCondition: tokens[i+3], Selections: selections}
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected token %s", tokens[i])
+		}
+	}
+
+	var operation *GraphQLOperation
+	for i, op := range operations {
+		if operationName == "" || names[i] == operationName {
+			if operation != nil {
+				return nil, fmt.Errorf("operationName is required for documents with multiple operations")
+			}
+			operation = op
+		}
+	}
+	if operation == nil {
+		if operationName != "" {
+			return nil, fmt.Errorf("unknown operation %s", operationName)
+		}
+		return nil, fmt.Errorf("the document does not contain an operation")
+	}
+	selections, err := expandGraphQLFragments(operation.Selections, fragments, nil)
+	if err != nil {
+		return nil, err
+	}
+	operation.Selections = selections
+	return operation, nil
+}
+
+// graphQLSelectionStart returns the index of the selection set following the variables and directives at start
+func graphQLSelectionStart(tokens []string, start int) int {
+	i := start
+	for i < len(tokens) && tokens[i] != "{" {
+		if tokens[i] == "(" {
+			i = graphQLClosingParen(tokens, i)
+		}
+		i++
+	}
+	return i
+}
+
+// parseGraphQLSelections parses the selection set starting at the brace at start and returns the selections and the
+// index of the closing brace
+func parseGraphQLSelections(tokens []string, start int) ([]GraphQLSelection, int, error) {
+	if start >= len(tokens) || tokens[start] != "{" {
+		return nil, start, fmt.Errorf("expected a selection set")
+	}
+	var selections []GraphQLSelection
+	for i := start + 1; i < len(tokens); i++ {
+		var selection GraphQLSelection
+		switch {
+		case tokens[i] == "}":
+			return selections, i, nil
+		case tokens[i] == "." && i+2 < len(tokens) && tokens[i+1] == "." && tokens[i+2] == ".":
+			i += 3
+			if i < len(tokens) && isGraphQLName(tokens[i]) && tokens[i] != "on" {
+				selection.fragment = tokens[i]
+				// skip the directives of the spread
+				for i+1 < len(tokens) && tokens[i+1] == "@" {
+					i = graphQLSkipDirective(tokens, i+1)
+				}
+				selections = append(selections, selection)
+				continue
+			}
+			if i+1 < len(tokens) && tokens[i] == "on" {
+				selection.TypeCondition = tokens[i+1]
+				i += 2
+			}
+		case isGraphQLName(tokens[i]):
+			selection.Name = tokens[i]
+			if i+2 < len(tokens) && tokens[i+1] == ":" {
+				selection.Alias, selection.Name = tokens[i], tokens[i+2]
+				i += 2
+			}
+			i++
+			if i < len(tokens) && tokens[i] == "(" {
+				i = graphQLClosingParen(tokens, i) + 1
+			}
+		default:
+			return nil, i, fmt.Errorf("unexpected token %s", tokens[i])
+		}
+		for i < len(tokens) && tokens[i] == "@" {
+			i = graphQLSkipDirective(tokens, i) + 1
+		}
+		if i < len(tokens) && tokens[i] == "{" {
+			nested, end, err := parseGraphQLSelections(tokens, i)
+			if err != nil {
+				return nil, end, err
+			}
+			selection.Selections = nested
+			i = end
+		} else {
+			// the token following the selection is the next selection
+			i--
+		}
+		selections = append(selections, selection)
+	}
+	return nil, len(tokens), fmt.Errorf("unterminated selection set")
+}
+
+// graphQLSkipDirective returns the index of the last token of the directive at start
+func graphQLSkipDirective(tokens []string, start int) int {
+	i := start + 1
+	if i+1 < len(tokens) && tokens[i+1] == "(" {
+		return graphQLClosingParen(tokens, i+1)
+	}
+	return i
+}
+
+// expandGraphQLFragments replaces the fragment spreads of the selections with inline fragments
+func expandGraphQLFragments(selections []GraphQLSelection, fragments map[string]GraphQLSelection,
+	visited []string) ([]GraphQLSelection, error) {
+	var expanded []GraphQLSelection
+	for _, selection := range selections {
+		visiting := visited
+		if selection.fragment != "" {
+			fragment, ok := fragments[selection.fragment]
+			if !ok {
+				return nil, fmt.Errorf("unknown fragment %s", selection.fragment)
+			}
+			if containsString(visited, selection.fragment) {
+				return nil, fmt.Errorf("fragment %s spreads itself", selection.fragment)
+			}
+			visiting = append(append([]string{}, visited...), selection.fragment)
+			selection = fragment
+		}
+		nested, err := expandGraphQLFragments(selection.Selections, fragments, visiting)
+		if err != nil {
+			return nil, err
+		}
+		selection.Selections = nested
+		expanded = append(expanded, selection)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,105 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQLPopulate(t *testing.T) {
+	schema, err := ioutil.ReadFile("testdata/starwars.graphql")
+	assert.Nil(t, err, "err should be nil")
+	var def APIDTODefinition
+	err = GraphQLPopulate(&def, schema)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, APITypeGraphQL, def.Type, "Should be a GraphQL API")
+	assert.Equal(t, []interface{}{
+		NewAPIOperation("hero", "QUERY"),
+		NewAPIOperation("droid", "QUERY"),
+		NewAPIOperation("characters", "QUERY"),
+		NewAPIOperation("createReview", "MUTATION"),
+		NewAPIOperation("deleteReview", "MUTATION"),
+		NewAPIOperation("reviewAdded", "SUBSCRIPTION"),
+	}, def.Operations, "Should have an operation for each field of the root types")
+}
+
+func TestGraphQLPopulateWithoutQuery(t *testing.T) {
+	var def APIDTODefinition
+	err := GraphQLPopulate(&def, []byte("type Review {\n  stars: Int!\n}\n"))
+	assert.NotNil(t, err, "Should fail for a schema without a query type")
+}
+
+func TestParseGraphQLSchema(t *testing.T) {
+	schema := ParseGraphQLSchema([]byte(`
+schema { query: RootQuery }
+type RootQuery {
+  "The hero of a film"
+  hero(episode: Episode = NEWHOPE): Character
+  films: [Film!]! @deprecated(reason: "use allFilms")
+}
+interface Character { id: ID! name: String }
+type Film { title: String episode: Episode }
+enum Episode { NEWHOPE EMPIRE @deprecated JEDI }
+`))
+	assert.Equal(t, "RootQuery", schema.Roots[graphQLVerbQuery])
+	assert.Equal(t, []GraphQLField{{Name: "hero", Type: "Character"}, {Name: "films", Type: "Film", List: true}},
+		schema.Types["RootQuery"])
+	assert.Equal(t, &GraphQLField{Name: "name", Type: "String"}, schema.Field("Character", "name"))
+	assert.Nil(t, schema.Field("Film", "director"))
+	assert.Equal(t, []string{"NEWHOPE", "EMPIRE", "JEDI"}, schema.Enums["Episode"])
+}
+
+func TestParseGraphQLQuery(t *testing.T) {
+	operation, err := ParseGraphQLQuery(`
+query Hero($episode: Episode) {
+  leader: hero(episode: $episode) @include(if: true) {
+    name
+    ...Films
+    ... on Droid { primaryFunction }
+  }
+}
+fragment Films on Character { films { title } }
+mutation Rate { rate(stars: 5) }`, "Hero")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, graphQLVerbQuery, operation.Verb)
+	if !assert.Len(t, operation.Selections, 1) {
+		return
+	}
+	hero := operation.Selections[0]
+	assert.Equal(t, "hero", hero.Name)
+	assert.Equal(t, "leader", hero.Key())
+	assert.Equal(t, []GraphQLSelection{
+		{Name: "name"},
+		{TypeCondition: "Character", Selections: []GraphQLSelection{{Name: "films",
+			Selections: []GraphQLSelection{{Name: "title"}}}}},
+		{TypeCondition: "Droid", Selections: []GraphQLSelection{{Name: "primaryFunction"}}},
+	}, hero.Selections)
+
+	_, err = ParseGraphQLQuery(`query A { a } query B { b }`, "")
+	assert.Error(t, err, "Should require the operation name for documents with multiple operations")
+	_, err = ParseGraphQLQuery(`{ a { ...Missing } }`, "")
+	assert.Error(t, err, "Should return an error for unknown fragments")
+	_, err = ParseGraphQLQuery(`{ a { b }`, "")
+	assert.Error(t, err, "Should return an error for unterminated selection sets")
+}
@@ -20,44 +20,52 @@ package v2
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
-func oai3XWSO2Cors(exts map[string]interface{}) (*CorsConfiguration, bool, error) {
-	if v, ok := exts["x-wso2-cors"]; ok {
-		ep, ok := v.(json.RawMessage)
-		if ok {
-			var cors CorsConfiguration
-			err := json.Unmarshal(ep, &cors)
-			if err != nil {
-				return nil, true, err
-			}
-			cors.CorsConfigurationEnabled = true
-			return &cors, true, nil
+// oai3Extension decodes the vendor extension name to v. Extensions could either be raw json messages or values
+// already decoded by the loader, hence the value is marshalled back to json before decoding.
+func oai3Extension(exts map[string]interface{}, name string, v interface{}) (bool, error) {
+	ext, ok := exts[name]
+	if !ok || ext == nil {
+		return false, nil
+	}
+	data, ok := ext.(json.RawMessage)
+	if !ok {
+		var err error
+		data, err = json.Marshal(ext)
+		if err != nil {
+			return true, err
 		}
 	}
-	return nil, true, nil
+	return true, json.Unmarshal(data, v)
+}
+
+func oai3XWSO2Cors(exts map[string]interface{}) (*CorsConfiguration, bool, error) {
+	var cors CorsConfiguration
+	ok, err := oai3Extension(exts, "x-wso2-cors", &cors)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	cors.CorsConfigurationEnabled = true
+	return &cors, true, nil
 }
 
 type Tag struct {
 	Name string `json:"name"`
 }
 
-func oai3Tags(exts map[string]interface{}) []string {
-	if v, ok := exts["tags"]; ok {
-		b, ok := v.(json.RawMessage)
-		if ok {
-			var tags []Tag
-			_ = json.Unmarshal(b, &tags)
-			strs := make([]string, len(tags))
-			for i, t := range tags {
-				strs[i] = t.Name
-			}
-			return strs
-		}
+func oai3Tags(tags openapi3.Tags) []string {
+	strs := make([]string, len(tags))
+	for i, t := range tags {
+		strs[i] = t.Name
 	}
-	return nil
+	return strs
 }
 
 type Endpoints struct {
@@ -71,63 +79,39 @@ type AdvanceEndpointConfiguration struct {
 }
 
 func oai3XWSO2ProductionEndpoints(exts map[string]interface{}) (*Endpoints, bool, error) {
-	if v, ok := exts["x-wso2-production-endpoints"]; ok {
-		ep, ok := v.(json.RawMessage)
-		if ok {
-			var prodEp Endpoints
-			err := json.Unmarshal(ep, &prodEp)
-			if err != nil {
-				return nil, true, err
-			}
-			return &prodEp, true, nil
-		}
+	var prodEp Endpoints
+	ok, err := oai3Extension(exts, "x-wso2-production-endpoints", &prodEp)
+	if err != nil {
+		return nil, true, err
 	}
-	return nil, true, nil
+	return &prodEp, ok, nil
 }
 
 func oai3XWso2SandboxEndpoints(exts map[string]interface{}) (*Endpoints, bool, error) {
-	if v, ok := exts["x-wso2-sandbox-endpoints"]; ok {
-		ep, ok := v.(json.RawMessage)
-		if ok {
-			var sandboxEp Endpoints
-			err := json.Unmarshal(ep, &sandboxEp)
-			if err != nil {
-				return nil, true, err
-			}
-			return &sandboxEp, true, nil
-		}
+	var sandboxEp Endpoints
+	ok, err := oai3Extension(exts, "x-wso2-sandbox-endpoints", &sandboxEp)
+	if err != nil {
+		return nil, true, err
 	}
-	return nil, true, nil
+	return &sandboxEp, ok, nil
 }
 
 func oai3XWSO2AuthHeader(exts map[string]interface{}) (string, bool) {
-	if v, ok := exts["x-wso2-auth-header"]; ok {
-		data, ok := v.(json.RawMessage)
-		if ok {
-			var auth string
-			err := json.Unmarshal(data, &auth)
-			if err != nil {
-				return "", false
-			}
-			return auth, true
-		}
+	var auth string
+	ok, err := oai3Extension(exts, "x-wso2-auth-header", &auth)
+	if err != nil {
+		return "", false
 	}
-	return "", false
+	return auth, ok
 }
 
 func oai3WSO2Basepath(exts map[string]interface{}) (string, bool, error) {
-	if v, ok := exts["x-wso2-basePath"]; ok {
-		data, ok := v.(json.RawMessage)
-		if ok {
-			var basepath string
-			err := json.Unmarshal(data, &basepath)
-			if err != nil {
-				return "", false, err
-			}
-			return basepath, true, nil
-		}
+	var basepath string
+	ok, err := oai3Extension(exts, "x-wso2-basePath", &basepath)
+	if err != nil {
+		return "", false, err
 	}
-	return "", false, nil
+	return basepath, ok, nil
 }
 
 // oai3GetHttpVerbs generates verbs for api definition
@@ -155,3 +139,149 @@ func oai3GetHttpVerbs(item *openapi3.PathItem) (verbs []string) {
 	}
 	return
 }
+
+// oai3ServerUrls returns the absolute urls of the servers with the server variables replaced by their defaults
+func oai3ServerUrls(servers openapi3.Servers) []string {
+	var urls []string
+	for _, server := range servers {
+		serverUrl := server.URL
+		for name, variable := range server.Variables {
+			serverUrl = strings.ReplaceAll(serverUrl, "{"+name+"}", variable.Default)
+		}
+		if u, err := url.Parse(serverUrl); err == nil && u.IsAbs() {
+			urls = append(urls, strings.TrimSuffix(serverUrl, "/"))
+		}
+	}
+	return urls
+}
+
+// oai3BasePath returns the path of the first server of the definition
+func oai3BasePath(servers openapi3.Servers) string {
+	for _, server := range servers {
+		serverUrl := server.URL
+		for name, variable := range server.Variables {
+			serverUrl = strings.ReplaceAll(serverUrl, "{"+name+"}", variable.Default)
+		}
+		if u, err := url.Parse(serverUrl); err == nil && strings.Trim(u.Path, "/") != "" {
+			return u.Path
+		}
+	}
+	return ""
+}
+
+// populateContext sets the context of the API from a x-wso2-basePath extension. The version is removed from the
+// base path unless it is a {version} template, in which case the API becomes the default version.
+func populateContext(def *APIDTODefinition, basepath string) {
+	if !strings.Contains(basepath, "{version}") {
+		if strings.Contains(basepath, def.Version) {
+			def.Context = path.Clean(strings.Replace(basepath, def.Version, "",
+				strings.LastIndex(basepath, def.Version)))
+		} else {
+			def.Context = path.Clean(basepath)
+		}
+		def.IsDefaultVersion = true
+	} else {
+		def.Context = path.Clean(strings.ReplaceAll(basepath, "{version}", def.Version))
+	}
+}
+
+// populateEndpoints sets the endpoint config of the API from the x-wso2-production-endpoints and
+// x-wso2-sandbox-endpoints extensions
+func populateEndpoints(def *APIDTODefinition, exts map[string]interface{}) (bool, error) {
+	prodEp, foundProdEp, err := oai3XWSO2ProductionEndpoints(exts)
+	if err != nil && foundProdEp {
+		return true, err
+	}
+	sandboxEp, foundSandboxEp, err := oai3XWso2SandboxEndpoints(exts)
+	if err != nil && foundSandboxEp {
+		return true, err
+	}
+	if !foundProdEp && !foundSandboxEp {
+		return false, nil
+	}
+	ep, err := BuildAPIMEndpoints(prodEp, sandboxEp)
+	if err != nil {
+		return true, err
+	}
+	var endpointConfig map[string]interface{}
+	err = json.Unmarshal([]byte(ep), &endpointConfig)
+	if err != nil {
+		return true, err
+	}
+	def.EndpointConfig = &endpointConfig
+	return true, nil
+}
+
+// Oai3Populate populates the API definition using an OpenAPI 3.0 or 3.1 definition
+func Oai3Populate(def *APIDTODefinition, doc *openapi3.T) error {
+	if doc.Info == nil {
+		return fmt.Errorf("info object of the OpenAPI definition is missing")
+	}
+	def.Name = doc.Info.Title
+	def.Version = doc.Info.Version
+	def.Provider = "admin"
+	def.Description = doc.Info.Description
+	def.Context = fmt.Sprintf("/%s", def.Name)
+	def.Type = APITypeHTTP
+	def.Tags = oai3Tags(doc.Tags)
+
+	// fill basepath from the servers
+	if basepath := oai3BasePath(doc.Servers); basepath != "" {
+		def.Context = path.Clean(fmt.Sprintf("/%s", basepath))
+	}
+
+	// override basepath if wso2 extension provided
+	basepath, ok, err := oai3WSO2Basepath(doc.Extensions)
+	if err != nil {
+		return err
+	}
+	if ok {
+		populateContext(def, basepath)
+	}
+
+	// trim spaces if available
+	def.Name = strings.ReplaceAll(def.Name, " ", "")
+	def.Version = strings.ReplaceAll(def.Version, " ", "")
+	def.Context = strings.ReplaceAll(def.Context, " ", "")
+
+	cors, ok, err := oai3XWSO2Cors(doc.Extensions)
+	if err != nil && ok {
+		return err
+	}
+	if ok {
+		def.CorsConfiguration = cors
+	}
+
+	found, err := populateEndpoints(def, doc.Extensions)
+	if err != nil {
+		return err
+	}
+	// use the servers of the definition as the endpoints if the wso2 extensions are not provided
+	if urls := oai3ServerUrls(doc.Servers); !found && len(urls) > 0 {
+		ep, err := BuildAPIMEndpoints(&Endpoints{Urls: urls[:1]}, &Endpoints{Urls: urls[:1]})
+		if err != nil {
+			return err
+		}
+		var endpointConfig map[string]interface{}
+		err = json.Unmarshal([]byte(ep), &endpointConfig)
+		if err != nil {
+			return err
+		}
+		def.EndpointConfig = &endpointConfig
+	}
+
+	authHeader, ok := oai3XWSO2AuthHeader(doc.Extensions)
+	if ok {
+		def.AuthorizationHeader = authHeader
+	}
+
+	def.Operations = nil
+	if doc.Paths != nil {
+		for _, target := range doc.Paths.InMatchingOrder() {
+			for _, verb := range oai3GetHttpVerbs(doc.Paths.Value(target)) {
+				def.Operations = append(def.Operations, NewAPIOperation(target, verb))
+			}
+		}
+	}
+	return nil
+}
@@ -28,7 +28,7 @@ import (
 var petstoreProdUrls = []string{"https://petstore.swagger.io/v2", "https://petstore.swagger.io/v2/1", "https://petstore.swagger.io/v2/2"}
 
 func Test_oai3WSO2Basepath(t *testing.T) {
-	sw, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/petstore_basic.yaml")
+	sw, err := openapi3.NewLoader().LoadFromFile("testdata/petstore_basic.yaml")
 	assert.Nil(t, err, "err should be nil")
 	basepath, ok, err := oai3WSO2Basepath(sw.Extensions)
 	assert.Nil(t, err, "err should be nil")
@@ -37,7 +37,7 @@ func Test_oai3WSO2Basepath(t *testing.T) {
 }
 
 func Test_oai3WSO2ProductionEndpoints(t *testing.T) {
-	sw, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/petstore_basic.yaml")
+	sw, err := openapi3.NewLoader().LoadFromFile("testdata/petstore_basic.yaml")
 	assert.Nil(t, err, "err should be nil")
 	ep, ok, err := oai3XWSO2ProductionEndpoints(sw.Extensions)
 	assert.Nil(t, err, "err should be nil")
@@ -46,7 +46,7 @@ func Test_oai3WSO2ProductionEndpoints(t *testing.T) {
 }
 
 func Test_oai3WSO2SandboxEndpoints(t *testing.T) {
-	sw, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/petstore_basic.yaml")
+	sw, err := openapi3.NewLoader().LoadFromFile("testdata/petstore_basic.yaml")
 	assert.Nil(t, err, "err should be nil")
 	ep, ok, err := oai3XWso2SandboxEndpoints(sw.Extensions)
 	assert.Nil(t, err, "err should be nil")
@@ -55,15 +55,15 @@ func Test_oai3WSO2SandboxEndpoints(t *testing.T) {
 }
 
 func Test_oai3Tags(t *testing.T) {
-	sw, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/petstore_basic.yaml")
+	sw, err := openapi3.NewLoader().LoadFromFile("testdata/petstore_basic.yaml")
 	assert.Nil(t, err, "err should be nil")
-	tags := oai3Tags(sw.Extensions)
+	tags := oai3Tags(sw.Tags)
 	assert.Nil(t, err, "err should be nil")
 	assert.ElementsMatch(t, []string{"pet", "user", "store"}, tags, "should have same elements")
 }
 
 func Test_oai3WSO2Cors(t *testing.T) {
-	sw, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/petstore_basic.yaml")
+	sw, err := openapi3.NewLoader().LoadFromFile("testdata/petstore_basic.yaml")
 	assert.Nil(t, err, "err should be nil")
 	cors, ok, err := oai3XWSO2Cors(sw.Extensions)
 	assert.Nil(t, err, "err should be nil")
@@ -71,3 +71,42 @@ func Test_oai3WSO2Cors(t *testing.T) {
 	assert.ElementsMatch(t, []string{"GET", "PUT", "POST"}, cors.AccessControlAllowMethods, "should have same elements for access control")
 	assert.ElementsMatch(t, []string{"test.com", "example.com"}, cors.AccessControlAllowOrigins, "should have same elements for origins")
 }
+
+func TestOai3Populate(t *testing.T) {
+	sw, err := openapi3.NewLoader().LoadFromFile("testdata/petstore_basic.yaml")
+	assert.Nil(t, err, "err should be nil")
+	var def APIDTODefinition
+	err = Oai3Populate(&def, sw)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "SwaggerPetstoreNew", def.Name, "Should return correct api name")
+	assert.Equal(t, "/petstore/v1", def.Context, "Should return context from the extension")
+	assert.True(t, def.IsDefaultVersion, "Should be the default version")
+	assert.Equal(t, APITypeHTTP, def.Type, "Should be a HTTP API")
+	assert.ElementsMatch(t, []string{"pet", "user", "store"}, def.Tags, "should have same elements")
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, EpLoadbalance, endpointConfig["endpoint_type"], "Should use the endpoints of the extension")
+	assert.NotNil(t, def.CorsConfiguration, "Should have the cors configuration of the extension")
+	assert.Contains(t, def.Operations, NewAPIOperation("/pet/findByStatus", "GET"), "Should have the operations")
+}
+
+func TestOai3PopulateOpenAPI31(t *testing.T) {
+	sw, err := openapi3.NewLoader().LoadFromFile("testdata/petstore_oas31.yaml")
+	assert.Nil(t, err, "err should be nil")
+	var def APIDTODefinition
+	err = Oai3Populate(&def, sw)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "PetStore", def.Name, "Should return correct api name")
+	assert.Equal(t, "2.0.0", def.Version, "Should return correct api version")
+	assert.Equal(t, "/store/v2", def.Context, "Should return context from the servers")
+	assert.Equal(t, "X-Auth", def.AuthorizationHeader, "Should return the auth header of the extension")
+	endpointConfig := *def.EndpointConfig.(*map[string]interface{})
+	assert.Equal(t, EpHttp, endpointConfig["endpoint_type"])
+	assert.Equal(t, map[string]interface{}{"url": "https://petstore.example.com/store/v2"},
+		endpointConfig["production_endpoints"], "Should use the server as the endpoint")
+	assert.Equal(t, []interface{}{
+		NewAPIOperation("/pets", "GET"),
+		NewAPIOperation("/pets", "POST"),
+		NewAPIOperation("/pets/{petId}", "GET"),
+		NewAPIOperation("/pets/{petId}", "DELETE"),
+	}, def.Operations, "Should have an operation for each path and verb")
+}
@@ -0,0 +1,107 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Namespaces of the address elements of the WSDL ports
+const (
+	wsdlSoap11Namespace = "http://schemas.xmlsoap.org/wsdl/soap/"
+	wsdlSoap12Namespace = "http://schemas.xmlsoap.org/wsdl/soap12/"
+)
+
+// wsdlDocument represents the services of a WSDL 1.1 definitions or a WSDL 2.0 description
+type wsdlDocument struct {
+	XMLName  xml.Name
+	Name     string `xml:"name,attr"`
+	Services []struct {
+		Name  string `xml:"name,attr"`
+		Ports []struct {
+			Addresses []struct {
+				XMLName  xml.Name
+				Location string `xml:"location,attr"`
+			} `xml:",any"`
+		} `xml:"port"`
+		Endpoints []struct {
+			Address string `xml:"address,attr"`
+		} `xml:"endpoint"`
+	} `xml:"service"`
+}
+
+// addresses returns the addresses of the service endpoints. SOAP addresses are preferred over the rest.
+func (doc *wsdlDocument) addresses() []string {
+	var soap, other []string
+	for _, service := range doc.Services {
+		for _, port := range service.Ports {
+			for _, address := range port.Addresses {
+				if address.XMLName.Local != "address" || address.Location == "" {
+					continue
+				}
+				if address.XMLName.Space == wsdlSoap11Namespace || address.XMLName.Space == wsdlSoap12Namespace {
+					soap = append(soap, address.Location)
+				} else {
+					other = append(other, address.Location)
+				}
+			}
+		}
+		for _, endpoint := range service.Endpoints {
+			if endpoint.Address != "" {
+				other = append(other, endpoint.Address)
+			}
+		}
+	}
+	return append(soap, other...)
+}
+
+// WsdlPopulate populates the API definition using a WSDL 1.1 or 2.0 document. A pass through SOAP API is created with
+// the address of the first service as the address endpoint.
+func WsdlPopulate(def *APIDTODefinition, content []byte) error {
+	var doc wsdlDocument
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		return err
+	}
+	if doc.XMLName.Local != "definitions" && doc.XMLName.Local != "description" {
+		return fmt.Errorf("not a WSDL document. Unexpected root element %s", doc.XMLName.Local)
+	}
+
+	name := doc.Name
+	if name == "" && len(doc.Services) > 0 {
+		name = doc.Services[0].Name
+	}
+	if name != "" {
+		def.Name = strings.ReplaceAll(name, " ", "")
+		def.Context = fmt.Sprintf("/%s", def.Name)
+	}
+	def.Type = APITypeSOAP
+	def.WsdlInfo = map[string]interface{}{"type": "WSDL"}
+	def.Operations = []interface{}{NewAPIOperation("/*", "POST")}
+
+	if addresses := doc.addresses(); len(addresses) > 0 {
+		def.EndpointConfig = map[string]interface{}{
+			"endpoint_type":        EpAddress,
+			"production_endpoints": map[string]interface{}{"url": addresses[0]},
+			"sandbox_endpoints":    map[string]interface{}{"url": addresses[0]},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package v2
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWsdlPopulate(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/phoneverify.wsdl")
+	assert.Nil(t, err, "err should be nil")
+	var def APIDTODefinition
+	err = WsdlPopulate(&def, content)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "PhoneVerify", def.Name, "Should return the name of the definitions")
+	assert.Equal(t, "/PhoneVerify", def.Context)
+	assert.Equal(t, APITypeSOAP, def.Type, "Should be a SOAP API")
+	assert.Equal(t, map[string]interface{}{"type": "WSDL"}, def.WsdlInfo)
+	endpointConfig := def.EndpointConfig.(map[string]interface{})
+	assert.Equal(t, EpAddress, endpointConfig["endpoint_type"])
+	assert.Equal(t, map[string]interface{}{"url": "http://ws.example.com/phoneverify/phoneverify.asmx"},
+		endpointConfig["production_endpoints"], "Should prefer the SOAP address")
+}
+
+func TestWsdlPopulateInvalidDocument(t *testing.T) {
+	var def APIDTODefinition
+	err := WsdlPopulate(&def, []byte("<schema/>"))
+	assert.NotNil(t, err, "Should fail for a document that is not a WSDL")
+}
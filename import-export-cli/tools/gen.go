@@ -25,7 +25,8 @@ func main() {
 	}
 
 	log.Println("Generating bash completions...")
-	err = cmd.RootCmd.GenBashCompletionFile(filepath.FromSlash("./shell-completions/apictl_bash_completions.sh"))
+	err = cmd.RootCmd.GenBashCompletionFileV2(filepath.FromSlash("./shell-completions/apictl_bash_completions.sh"),
+		true)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -28,6 +28,7 @@ import (
 )
 
 var HttpRequestTimeout = DefaultHttpRequestTimeout
+var HttpRetryCount = DefaultHttpRetryCount
 var AIThreadCount = DefaultAIThreadCount
 var AIToken string
 var Insecure bool
@@ -59,6 +60,15 @@ func SetConfigVars(mainConfigFilePath string) error {
 	HttpRequestTimeout = mainConfig.Config.HttpRequestTimeout
 	Logln(LogPrefixInfo + "Setting HttpTimeoutRequest to " + fmt.Sprint(mainConfig.Config.HttpRequestTimeout))
 
+	// A negative retry count disables the retries, while zero means that the count is not set
+	HttpRetryCount = mainConfig.Config.HttpRetryCount
+	if HttpRetryCount == 0 {
+		HttpRetryCount = DefaultHttpRetryCount
+	} else if HttpRetryCount < 0 {
+		HttpRetryCount = 0
+	}
+	Logln(LogPrefixInfo + "Setting HttpRetryCount to " + fmt.Sprint(HttpRetryCount))
+
 	AIThreadCount = mainConfig.Config.AIThreadCount
 	Logln(LogPrefixInfo + "Setting AIThreadCount to " + fmt.Sprint(mainConfig.Config.AIThreadCount))
 
@@ -70,6 +80,13 @@ func SetConfigVars(mainConfigFilePath string) error {
 
 	setTLSRenegotiationMode(mainConfig)
 
+	provider, err := NewSecretProvider(mainConfig.Config.SecretProvider)
+	if err != nil {
+		return err
+	}
+	SetSecretProvider(provider)
+	Logln(LogPrefixInfo + "Setting SecretProvider to " + fmt.Sprint(mainConfig.Config.SecretProvider.Type))
+
 	return nil
 }
 
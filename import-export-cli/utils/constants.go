@@ -86,6 +86,7 @@ const LocalCredentialsDirectoryName = ".wso2apictl.local"
 const MILocalCredentialsDirectoryName = ".wso2mi.local"
 const EnvKeysAllFileName = "env_keys_all.yaml"
 const MainConfigFileName = "main_config.yaml"
+const DeploymentHistoryFileName = "deployment_history.yaml"
 const SampleMainConfigFileName = "main_config.yaml.sample"
 const DefaultAPISpecFileName = "default_api.yaml"
 
@@ -104,7 +105,9 @@ const ExportedAPIPoliciesDirName = "api"
 const ExportedApiProductsDirName = "api-products"
 const ExportedAppsDirName = "apps"
 const ExportedMigrationArtifactsDirName = "migration"
+const ExportedPostmanCollectionsDirName = "postman"
 const CertificatesDirName = "certs"
+const PluginsDirName = "plugins"
 
 const (
 	InitProjectDefinitions              = "Definitions"
@@ -134,9 +137,14 @@ const defaultMcpServerListEndpointSuffix = "api/am/publisher/v4/mcp-servers"
 const defaultAPIPolicyListEndpointSuffix = "api/am/publisher/v4/operation-policies"
 const defaultApiProductListEndpointSuffix = "api/am/publisher/v4/api-products"
 const defaultUnifiedSearchEndpointSuffix = "api/am/publisher/v4/search"
+const defaultPublisherSettingsEndpointSuffix = "api/am/publisher/v4/settings"
 const defaultAdminApplicationListEndpointSuffix = "api/am/admin/v4/applications"
 const defaultDevPortalApplicationListEndpointSuffix = "api/am/devportal/v3/applications"
 const defaultDevPortalThrottlingPoliciesEndpointSuffix = "api/am/devportal/v3/throttling-policies"
+const defaultDevPortalSubscriptionsEndpointSuffix = "api/am/devportal/v3/subscriptions"
+const defaultDevPortalApiListEndpointSuffix = "api/am/devportal/v3/apis"
+const defaultDevPortalKeyManagersEndpointSuffix = "api/am/devportal/v3/key-managers"
+const defaultPublisherSubscriptionsEndpointSuffix = "api/am/publisher/v4/subscriptions"
 const defaultClientRegistrationEndpointSuffix = "client-registration/v0.17/register"
 const defaultTokenEndPoint = "oauth2/token"
 const defaultRevokeEndpointSuffix = "oauth2/revoke"
@@ -159,6 +167,7 @@ const DefaultMcpServerType = "MCP"
 // Application keys related constants
 const ProductionKeyType = "PRODUCTION"
 const SandboxKeyType = "SANDBOX"
+const ResidentKeyManagerType = "default"
 
 var GrantTypesToBeSupported = []string{"refresh_token", "password", "client_credentials"}
 
@@ -198,6 +207,9 @@ const SearchAndTag = "&"
 const DefaultTokenValidityPeriod = 3600
 const DefaultHttpRequestTimeout = 10000
 
+// DefaultHttpRetryCount is the number of times a failed HTTP request is retried if http_retry_count is not set
+const DefaultHttpRetryCount = 3
+
 // AI
 const DefaultAIThreadCount = 3
 const DefaultAIEndpoint = "https://e95488c8-8511-4882-967f-ec3ae2a0f86f-prod.e1-us-east-azure.choreoapis.dev/lgpt/interceptor-service/interceptor-service-be2/v1.0"
@@ -251,14 +263,15 @@ var EnvReplacePoliciesFileExtensions = []string{
 
 // project types
 const (
-	ProjectTypeNone        = "None"
-	ProjectTypeApi         = "API"
-	ProjectTypeMcpServer   = "MCP Server"
-	ProjectTypeApiProduct  = "API Product"
-	ProjectTypeApplication = "Application"
-	ProjectTypeRevision    = "Revision"
-	ProjectTypePolicy      = "Policy"
-	ProjectTypeAPIPolicy   = "API Policy"
+	ProjectTypeNone         = "None"
+	ProjectTypeApi          = "API"
+	ProjectTypeMcpServer    = "MCP Server"
+	ProjectTypeApiProduct   = "API Product"
+	ProjectTypeApplication  = "Application"
+	ProjectTypeRevision     = "Revision"
+	ProjectTypePolicy       = "Policy"
+	ProjectTypeAPIPolicy    = "API Policy"
+	ProjectTypeSubscription = "Subscription"
 )
 
 // project param files
@@ -276,14 +276,14 @@ func getPlainTextSecrets(secretConfig SecretConfig) map[string]string {
 
 func printSecretsToConsole(secrets map[string]string) {
 	for alias, secret := range secrets {
-		fmt.Println(alias, ":", secret)
+		fmt.Fprintln(Stdout, alias, ":", secret)
 	}
 }
 
 func printSecretsToPropertiesFile(secrets map[string]string) {
 	secretFilePath := getSecretFilePath(encryptedSecretsPropertiesFileName)
 	WritePropertiesToFile(secrets, secretFilePath)
-	fmt.Println("Secret properties file created in", secretFilePath)
+	fmt.Fprintln(Stdout, "Secret properties file created in", secretFilePath)
 }
 
 func printSecretsToYamlFile(secrets map[string]string) {
@@ -298,8 +298,8 @@ func printSecretsToYamlFile(secrets map[string]string) {
 	}
 	secretFilePath := getSecretFilePath(encryptedSecretsYamlFileName)
 	WriteConfigFile(secretConfig, secretFilePath)
-	fmt.Println("Kubernetes secret file created in", secretFilePath, "with default name and namespace")
-	fmt.Println("You can change the default values as required before applying.")
+	fmt.Fprintln(Stdout, "Kubernetes secret file created in", secretFilePath, "with default name and namespace")
+	fmt.Fprintln(Stdout, "You can change the default values as required before applying.")
 }
 
 func getSecretFilePath(fileName string) string {
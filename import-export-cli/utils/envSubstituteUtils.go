@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -14,8 +16,33 @@ import (
 // Match for $VAR or ${VAR} and capture VAR inside a group
 var re = regexp.MustCompile(`\${?(\w+)}?`)
 
-// Match for ${VAR} and capture VAR inside a group
-var recb = regexp.MustCompile(`\${(\w+)}`)
+// Match for the variable expressions inside curly braces and capture the parts of them inside groups. The supported
+// expressions are ${VAR}, ${VAR:-default}, ${file:path} and ${secret:name}, each optionally followed by filters such
+// as ${file:cert.pem|base64}. The groups are the source (file or secret), its argument, the name of the environment
+// variable, the default value and the filters.
+var recb = regexp.MustCompile(`\${(?:(file|secret):([^-}|][^}|]*)|(\w+)(?::-([^}|]*))?)((?:\s*\|\s*\w+)*)\s*}`)
+
+// Sources of the values of the variable expressions other than the environment
+const (
+	substituteSourceFile   = "file"
+	substituteSourceSecret = "secret"
+)
+
+// substituteFilters are the filters that can be applied to the values of the variable expressions
+var substituteFilters = map[string]func(string) (string, error){
+	// base64 encodes the value, eg: to inline a keystore or a certificate in a single line
+	"base64": func(value string) (string, error) {
+		return base64.StdEncoding.EncodeToString([]byte(value)), nil
+	},
+	// json escapes the value to be used inside a double quoted JSON or YAML string
+	"json": func(value string) (string, error) {
+		escaped, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(escaped[1 : len(escaped)-1]), nil
+	},
+}
 
 // ErrRequiredEnvKeyMissing represents error used for indicate environment key missing
 type ErrRequiredEnvKeyMissing struct {
@@ -29,33 +56,90 @@ func (e ErrRequiredEnvKeyMissing) Error() string {
 
 // EnvSubstituteForCurlyBraces substitutes variables from environment to the content.
 // It uses regex to match in ${var} format for variables and look up them in the environment before processing.
+// A default value is used by ${var:-default} if the variable is not set, ${file:path} inlines the content of a file
+// relative to the current directory and ${secret:name} resolves the secret using the configured secret provider.
+// The values can be passed through the base64 and json filters, eg: ${file:certs/backend.pem|base64}
+// Use EnvSubstituteForCurlyBracesInDir to resolve the relative paths against the directory having the content.
 // returns an error if anything happen
 func EnvSubstituteForCurlyBraces(content string) (string, error) {
+	return EnvSubstituteForCurlyBracesInDir(content, "")
+}
+
+// EnvSubstituteForCurlyBracesInDir substitutes the variables the same way as EnvSubstituteForCurlyBraces, resolving
+// the relative paths of ${file:path} and of the secrets file against baseDir, the directory of the params file or the
+// project having the content
+func EnvSubstituteForCurlyBracesInDir(content, baseDir string) (string, error) {
 	var errorResults error
-	missingEnvKeys := false
-	matches := recb.FindAllStringSubmatch(content, -1) // matches is [][]string
-
-	for _, match := range matches {
-		Logln(LogPrefixInfo+"Looking for:", match[0])
-		if os.Getenv(match[1]) == "" {
-			missingEnvKeys = true
-			errorResults = multierror.Append(errorResults, &ErrRequiredEnvKeyMissing{Key: match[0]})
-		} else {
-			content = strings.ReplaceAll(content, match[0], os.Getenv(match[1]))
+	substituted := recb.ReplaceAllStringFunc(content, func(expression string) string {
+		Logln(LogPrefixInfo+"Looking for:", expression)
+		value, err := resolveSubstituteExpression(recb.FindStringSubmatch(expression), baseDir)
+		if err != nil {
+			errorResults = multierror.Append(errorResults, err)
 		}
-	}
+		return value
+	})
 
-	if missingEnvKeys {
+	if errorResults != nil {
 		return "", errorResults
 	}
 
-	return content, nil
+	return substituted, nil
+}
+
+// resolveSubstituteExpression resolves the value of a variable expression matched by recb and applies its filters.
+// Relative paths are resolved against baseDir, or the current directory if it is empty.
+func resolveSubstituteExpression(match []string, baseDir string) (string, error) {
+	var value string
+	switch source, argument := match[1], strings.TrimSpace(match[2]); source {
+	case substituteSourceFile:
+		if baseDir != "" && !filepath.IsAbs(argument) {
+			argument = filepath.Join(baseDir, argument)
+		}
+		content, err := ioutil.ReadFile(argument)
+		if err != nil {
+			return "", fmt.Errorf("%s could not be resolved: %w", match[0], err)
+		}
+		value = string(content)
+	case substituteSourceSecret:
+		var secret string
+		var err error
+		if provider, ok := GetSecretProvider().(DirSecretProvider); ok {
+			secret, err = provider.GetSecretInDir(argument, baseDir)
+		} else {
+			secret, err = GetSecretProvider().GetSecret(argument)
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s could not be resolved: %w", match[0], err)
+		}
+		value = secret
+	default:
+		value = os.Getenv(match[3])
+		if value == "" {
+			// The default value is only used if the expression has one, since ${VAR:-} defaults to an empty value
+			if !strings.Contains(match[0], ":-") {
+				return "", &ErrRequiredEnvKeyMissing{Key: match[0]}
+			}
+			value = match[4]
+		}
+	}
+
+	for _, name := range strings.Split(match[5], "|")[1:] {
+		filter, ok := substituteFilters[strings.TrimSpace(name)]
+		if !ok {
+			return "", fmt.Errorf("%s could not be resolved: unknown filter %s", match[0], strings.TrimSpace(name))
+		}
+		var err error
+		if value, err = filter(value); err != nil {
+			return "", fmt.Errorf("%s could not be resolved: %w", match[0], err)
+		}
+	}
+	return value, nil
 }
 
 // Substitutes all the environment variables added in the file specified in the 'file' input and changes are
-// updated in the file.
+// updated in the file. Relative file and secrets file paths are resolved against baseDir, the project directory.
 // If any required environment variable is not set will throw an error.
-func EnvSubstituteInFile(file string, fileExtensions []string) error {
+func EnvSubstituteInFile(file, baseDir string, fileExtensions []string) error {
 	var substitutedContent string
 	content, err := ioutil.ReadFile(file)
 	if err != nil {
@@ -63,7 +147,7 @@ func EnvSubstituteInFile(file string, fileExtensions []string) error {
 	}
 	// If the fileExtensions are nil, env variables will be substituted irrespective of the extension type
 	if fileExtensions == nil {
-		substitutedContent, err = EnvSubstituteForCurlyBraces(string(content))
+		substitutedContent, err = EnvSubstituteForCurlyBracesInDir(string(content), baseDir)
 		if err != nil {
 			return err
 		}
@@ -77,7 +161,7 @@ func EnvSubstituteInFile(file string, fileExtensions []string) error {
 		for _, extension := range fileExtensions {
 			if strings.HasSuffix(file, extension) {
 				Logln(LogPrefixInfo+"Substituting env variables to restricted extensions: ", extension)
-				substitutedContent, err = EnvSubstituteForCurlyBraces(string(content))
+				substitutedContent, err = EnvSubstituteForCurlyBracesInDir(string(content), baseDir)
 				if err != nil {
 					return err
 				}
@@ -92,9 +176,10 @@ func EnvSubstituteInFile(file string, fileExtensions []string) error {
 }
 
 // Walks through all the files in the given folder and substitutes all the environment variables added in
-// those files. The files will be updated with the substituted values.
+// those files. The files will be updated with the substituted values. Relative file and secrets file paths are
+// resolved against baseDir, the project directory.
 // If any required environment variable is not set will throw an error.
-func EnvSubstituteInFolder(folderPath string, fileExtensions []string) error {
+func EnvSubstituteInFolder(folderPath, baseDir string, fileExtensions []string) error {
 	err := filepath.Walk(folderPath,
 		func(path string, info os.FileInfo, err error) error {
 			if err != nil {
@@ -106,7 +191,7 @@ func EnvSubstituteInFolder(folderPath string, fileExtensions []string) error {
 			}
 			if fi.Mode().IsRegular() {
 				Logln(LogPrefixInfo+"Substituting env variables in: ", path)
-				err = EnvSubstituteInFile(path, fileExtensions)
+				err = EnvSubstituteInFile(path, baseDir, fileExtensions)
 				if err != nil {
 					return err
 				}
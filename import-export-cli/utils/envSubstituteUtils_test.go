@@ -1,7 +1,11 @@
 package utils
 
 import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +25,150 @@ func TestInjectEnvShouldPassWhenEnvPresents(t *testing.T) {
 	assert.Nil(t, err, "Error should be null")
 	assert.Equal(t, "myval", str, "Should correctly replace environment variable")
 }
+
+func TestInjectEnvShouldUseDefaultWhenEnvNotPresent(t *testing.T) {
+	_ = os.Unsetenv("MYMISSINGVAR")
+	str, err := EnvSubstituteForCurlyBraces(`url: ${MYMISSINGVAR:-http://localhost:8080}, empty: "${MYMISSINGVAR:-}"`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, `url: http://localhost:8080, empty: ""`, str, "Should use the default values")
+
+	_ = os.Setenv("MYVAR", "myval")
+	str, err = EnvSubstituteForCurlyBraces(`${MYVAR:-default}`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "myval", str, "Should prefer the environment variable over the default value")
+}
+
+func TestInjectEnvShouldInlineFiles(t *testing.T) {
+	certFile := filepath.Join(t.TempDir(), "backend.pem")
+	_ = ioutil.WriteFile(certFile, []byte("-----BEGIN CERTIFICATE-----\n\"cert\"\n"), 0644)
+
+	str, err := EnvSubstituteForCurlyBraces(`cert: ${file:` + certFile + `}`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "cert: -----BEGIN CERTIFICATE-----\n\"cert\"\n", str, "Should inline the file")
+
+	str, err = EnvSubstituteForCurlyBraces(`cert: "${file:` + certFile + ` | json}"`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, `cert: "-----BEGIN CERTIFICATE-----\n\"cert\"\n"`, str, "Should JSON escape the file")
+
+	str, err = EnvSubstituteForCurlyBraces(`cert: ${file:` + certFile + `|base64}`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "cert: LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tCiJjZXJ0Igo=", str, "Should base64 encode the file")
+
+	_, err = EnvSubstituteForCurlyBraces(`${file:` + certFile + `.missing}`)
+	assert.Error(t, err, "Should return an error for a missing file")
+}
+
+func TestInjectEnvShouldInlineFilesRelativeToBaseDir(t *testing.T) {
+	projectDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(projectDir, "certs"), os.ModePerm)
+	_ = ioutil.WriteFile(filepath.Join(projectDir, "certs", "backend.pem"), []byte("cert"), 0644)
+
+	str, err := EnvSubstituteForCurlyBracesInDir(`cert: ${file:certs/backend.pem}`, projectDir)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "cert: cert", str, "Should inline the file relative to the base directory")
+
+	_, err = EnvSubstituteForCurlyBraces(`cert: ${file:certs/backend.pem}`)
+	assert.Error(t, err, "Should not resolve the file relative to the current directory")
+
+	paramsFile := filepath.Join(projectDir, "params.yaml")
+	_ = ioutil.WriteFile(paramsFile, []byte(`cert: ${file:certs/backend.pem}`), 0644)
+	assert.Nil(t, EnvSubstituteInFile(paramsFile, projectDir, nil), "Error should be null")
+	content, _ := ioutil.ReadFile(paramsFile)
+	assert.Equal(t, "cert: cert", string(content), "Should substitute the file relative to the project directory")
+}
+
+func TestInjectEnvShouldFailForUnknownFilter(t *testing.T) {
+	_ = os.Setenv("MYVAR", "myval")
+	_, err := EnvSubstituteForCurlyBraces(`${MYVAR|upper}`)
+	assert.Error(t, err, "Should return an error")
+	assert.Contains(t, err.Error(), "unknown filter upper")
+}
+
+func TestInjectEnvShouldKeepOtherExpressions(t *testing.T) {
+	data := `${request.header} ${ } $MYVAR`
+	str, err := EnvSubstituteForCurlyBraces(data)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, data, str, "Should not change the expressions that are not variables")
+}
+
+type mockSecretProvider map[string]string
+
+func (p mockSecretProvider) GetSecret(name string) (string, error) {
+	if secret, ok := p[name]; ok {
+		return secret, nil
+	}
+	return "", errors.New("secret " + name + " not found")
+}
+
+func TestInjectEnvShouldResolveSecrets(t *testing.T) {
+	defer SetSecretProvider(GetSecretProvider())
+	SetSecretProvider(mockSecretProvider{"backend-password": "pass\"word"})
+
+	str, err := EnvSubstituteForCurlyBraces(`password: "${secret:backend-password|json}"`)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, `password: "pass\"word"`, str, "Should resolve the secret")
+
+	_, err = EnvSubstituteForCurlyBraces(`${secret:missing}`)
+	assert.Error(t, err, "Should return an error for a missing secret")
+}
+
+func TestNewSecretProvider(t *testing.T) {
+	provider, err := NewSecretProvider(SecretProviderConfig{})
+	assert.Nil(t, err, "Error should be null")
+	assert.IsType(t, &EnvSecretProvider{}, provider)
+
+	provider, err = NewSecretProvider(SecretProviderConfig{Type: SecretProviderFile})
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, DefaultSecretsFilePath, provider.(*FileSecretProvider).FilePath)
+
+	_, err = NewSecretProvider(SecretProviderConfig{Type: SecretProviderCommand})
+	assert.Error(t, err, "Should return an error for a blank command")
+
+	_, err = NewSecretProvider(SecretProviderConfig{Type: "vault"})
+	assert.Error(t, err, "Should return an error for an invalid provider")
+}
+
+func TestCommandSecretProvider(t *testing.T) {
+	provider := &CommandSecretProvider{Command: "echo secret-of"}
+	secret, err := provider.GetSecret("backend-password")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "secret-of backend-password", secret, "Should return the output of the command")
+
+	provider = &CommandSecretProvider{Command: "false"}
+	_, err = provider.GetSecret("backend-password")
+	assert.Error(t, err, "Should return an error if the command fails")
+}
+
+func TestFileSecretProvider(t *testing.T) {
+	defer func(configDirPath string) { ConfigDirPath = configDirPath }(ConfigDirPath)
+	ConfigDirPath = t.TempDir()
+	key := "0123456789abcdef0123456789abcdef"
+	_ = os.MkdirAll(GetEncryptionKeyDirectoryPath(), os.ModePerm)
+	WriteConfigFile(&EncryptionKeyConfig{Algorithm: SecretEncryptionAlgorithmAES256,
+		EncryptionKey: base64.StdEncoding.EncodeToString([]byte(key))}, GetEncryptionKeyConfigFilePath())
+
+	encrypted, err := EncryptAES256([]byte(key), "password")
+	assert.Nil(t, err, "Error should be null")
+	secretsFile := filepath.Join(t.TempDir(), "wso2-secrets.properties")
+	WritePropertiesToFile(map[string]string{"backend-password": encrypted}, secretsFile)
+
+	provider := &FileSecretProvider{FilePath: secretsFile}
+	secret, err := provider.GetSecret("backend-password")
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "password", secret, "Should decrypt the secret")
+
+	_, err = provider.GetSecret("missing")
+	assert.Error(t, err, "Should return an error for a missing secret")
+
+	projectDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(projectDir, "security"), os.ModePerm)
+	WritePropertiesToFile(map[string]string{"backend-password": encrypted},
+		filepath.Join(projectDir, DefaultSecretsFilePath))
+	relativeProvider := &FileSecretProvider{FilePath: DefaultSecretsFilePath}
+	secret, err = relativeProvider.GetSecretInDir("backend-password", projectDir)
+	assert.Nil(t, err, "Error should be null")
+	assert.Equal(t, "password", secret, "Should read the secrets file relative to the project directory")
+
+	_, err = relativeProvider.GetSecret("backend-password")
+	assert.Error(t, err, "Should not read the secrets file relative to the current directory")
+}
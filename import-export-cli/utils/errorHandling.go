@@ -28,6 +28,9 @@ import (
 
 func HandleErrorAndExit(msg string, err error) {
 	HandleErrorAndContinue(msg, err)
+	if IsJSONOutput() {
+		os.Exit(printCommandFailure(msg, err))
+	}
 	printAndExit()
 }
 
@@ -47,18 +50,30 @@ func HandleErrorAndContinue(msg string, err error) {
 	}
 }
 
+// HandleErrorResponseAndExit prints an unexpected response of API Manager along with its body and exits
+func HandleErrorResponseAndExit(msg string, response *resty.Response) {
+	fmt.Fprintln(Stdout, msg+": ", response.Status(), "\n", cast.ToString(response.Body()))
+	if IsJSONOutput() {
+		os.Exit(printCommandFailure(msg, NewHttpResponseError(response)))
+	}
+	printAndExit()
+}
+
 func printAndExit() {
-	fmt.Println("Exit status 1")
+	fmt.Fprintln(Stdout, "Exit status 1")
 	os.Exit(1)
 }
 
 // Log information of erroneous http response and exit program
 func PrintErrorResponseAndExit(response *resty.Response) {
-	fmt.Printf("\nResponse Status: %v. \n", response.Status())
+	fmt.Fprintf(Stdout, "\nResponse Status: %v. \n", response.Status())
 	Logf("\nResponse :%v", cast.ToString(response.Body()))
 	Logf("\nResponse Headers: %v", response.Header())
 	Logf("\nResponse Time:%v", response.Time())
 	Logf("\nResponse Received At:%v", response.ReceivedAt())
+	if IsJSONOutput() {
+		os.Exit(printCommandFailure("Unexpected response from the server", NewHttpResponseError(response)))
+	}
 	printAndExit()
 }
 
@@ -61,14 +61,14 @@ func writeConfigFile(c interface{}, configFilePath string, mode os.FileMode) {
 func GetEnvKeysAllFromFile(envKeysAllFilePath string) *EnvKeysAll {
 	data, err := ioutil.ReadFile(envKeysAllFilePath)
 	if err != nil {
-		fmt.Println("Error reading " + envKeysAllFilePath)
+		fmt.Fprintln(Stdout, "Error reading "+envKeysAllFilePath)
 		os.Create(envKeysAllFilePath)
 		data, err = ioutil.ReadFile(envKeysAllFilePath)
 	}
 
 	var envKeysAll EnvKeysAll
 	if err := envKeysAll.ParseEnvKeysFromFile(data); err != nil {
-		fmt.Println(LogPrefixError + "parsing " + envKeysAllFilePath)
+		fmt.Fprintln(Stdout, LogPrefixError+"parsing "+envKeysAllFilePath)
 		return nil
 	}
 
@@ -177,7 +177,7 @@ func CreateDirIfNotExist(path string) (err error) {
 func CreateDir(path string) (err error) {
 	err = os.Mkdir(path, os.ModePerm)
 	if err != nil {
-		fmt.Println("Error in creating the directory:" + path + "\n" + err.Error())
+		fmt.Fprintln(Stdout, "Error in creating the directory:"+path+"\n"+err.Error())
 	}
 	return err
 }
@@ -185,7 +185,7 @@ func CreateDir(path string) (err error) {
 func RemoveDirectory(path string) (err error) {
 	err = os.RemoveAll(path)
 	if err != nil {
-		fmt.Println("Error in deleting the directory:" + path + "\n" + err.Error())
+		fmt.Fprintln(Stdout, "Error in deleting the directory:"+path+"\n"+err.Error())
 	}
 	return err
 }
@@ -195,7 +195,7 @@ func RemoveDirectoryIfExists(path string) (err error) {
 	if exists, err := IsDirExists(path); exists {
 		err = os.RemoveAll(path)
 		if err != nil {
-			fmt.Println("Error in deleting the directory:" + path + "\n" + err.Error())
+			fmt.Fprintln(Stdout, "Error in deleting the directory:"+path+"\n"+err.Error())
 		}
 	}
 	return err
@@ -206,7 +206,7 @@ func RemoveFileIfExists(path string) (err error) {
 	if exists := IsFileExist(path); exists {
 		err = os.Remove(path)
 		if err != nil {
-			fmt.Println("Error in deleting the directory:" + path + "\n" + err.Error())
+			fmt.Fprintln(Stdout, "Error in deleting the directory:"+path+"\n"+err.Error())
 		}
 	}
 	return err
@@ -0,0 +1,392 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Wait times between the retries of a failed HTTP request. The wait time grows exponentially from the minimum up to
+// the maximum, which also caps the time given by a Retry-After header. These are shortened in tests.
+var (
+	httpRetryWaitTime    = 1 * time.Second
+	httpRetryMaxWaitTime = 30 * time.Second
+)
+
+// httpTraceBodyLimit is the maximum number of characters of a body written by --trace
+const httpTraceBodyLimit = 8192
+
+// httpTraceRedacted replaces the sensitive values written by --trace
+const httpTraceRedacted = "[REDACTED]"
+
+// HttpTrace writes the HTTP requests and responses to stderr, with credentials and tokens redacted
+var HttpTrace bool
+
+// httpClients are shared by all the requests to an environment. The requests to a url that does not belong to an
+// environment with its own network settings use the client of the environment "".
+var httpClients = make(map[httpClientKey]*resty.Client)
+var httpClientsLock sync.Mutex
+
+// httpClientKey identifies a client by the environment, the --insecure mode it was created for and whether it
+// retries the failed requests
+type httpClientKey struct {
+	env       string
+	insecure  bool
+	noRetries bool
+}
+
+// httpMainConfig is read when the first request is sent. httpEnvsByHost maps the hosts of the endpoints of the
+// environments with network settings to the environments.
+var httpMainConfig *MainConfig
+var httpEnvsByHost map[string]string
+
+// httpTraceCount numbers the traced requests so that concurrent requests can be told apart
+var httpTraceCount int64
+var httpTraceIDs sync.Map
+var httpTraceLock sync.Mutex
+
+// Headers and body fields whose values are redacted by --trace
+var httpTraceSensitiveHeaders = []string{HeaderAuthorization, "Proxy-Authorization", "Cookie", "Set-Cookie",
+	"Internal-Key", "ApiKey"}
+var httpTraceSensitiveFields = regexp.MustCompile(
+	`(?i)("?(?:password|client_secret|clientSecret|consumerSecret|access_token|accessToken|refresh_token|` +
+		`refreshToken|id_token|device_code|token|passphrase)"?\s*[=:]\s*"?)([^"&,}\s]*)`)
+
+// EnableHttpTrace makes the HTTP requests and responses to be written to stderr
+func EnableHttpTrace() {
+	HttpTrace = true
+}
+
+// newHttpRequest returns a request of the HTTP client for the environment a url belongs to
+func newHttpRequest(url string) (*resty.Request, error) {
+	client, err := getHttpClient(url, true)
+	if err != nil {
+		return nil, err
+	}
+	return client.R(), nil
+}
+
+// newHttpRequestWithoutRetries returns a request of the HTTP client for the environment a url belongs to, which
+// does not retry the failed requests
+func newHttpRequestWithoutRetries(url string) (*resty.Request, error) {
+	client, err := getHttpClient(url, false)
+	if err != nil {
+		return nil, err
+	}
+	return client.R(), nil
+}
+
+// getHttpClient returns the HTTP client for the environment a url belongs to. The client is created on first use,
+// after the configuration and the flags such as --insecure are read.
+func getHttpClient(rawUrl string, retry bool) (*resty.Client, error) {
+	httpClientsLock.Lock()
+	defer httpClientsLock.Unlock()
+	if httpMainConfig == nil {
+		httpMainConfig = GetMainConfigFromFileSilently(MainConfigFilePath)
+		httpEnvsByHost = getHttpEnvsByHost(httpMainConfig)
+	}
+	env := ""
+	if parsedUrl, err := url.Parse(rawUrl); err == nil {
+		env = httpEnvsByHost[parsedUrl.Host]
+	}
+	key := httpClientKey{env: env, insecure: Insecure, noRetries: !retry}
+	if client, ok := httpClients[key]; ok {
+		return client, nil
+	}
+	client, err := newHttpClient(env, httpMainConfig.Environments[env], retry)
+	if err != nil {
+		return nil, err
+	}
+	httpClients[key] = client
+	return client, nil
+}
+
+// getHttpEnvsByHost maps the hosts of the endpoints of the environments having a proxy or a client certificate to
+// the environments. A host shared by several environments is mapped to the first of them in the order of the names.
+func getHttpEnvsByHost(mainConfig *MainConfig) map[string]string {
+	envsByHost := make(map[string]string)
+	var envs []string
+	for env := range mainConfig.Environments {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	for _, env := range envs {
+		endpoints := mainConfig.Environments[env]
+		if endpoints.Proxy == "" && endpoints.ClientCertificate == "" {
+			continue
+		}
+		for _, endpoint := range []string{endpoints.ApiManagerEndpoint, endpoints.PublisherEndpoint,
+			endpoints.DevPortalEndpoint, endpoints.RegistrationEndpoint, endpoints.AdminEndpoint,
+			endpoints.TokenEndpoint, endpoints.MiManagementEndpoint} {
+			parsedUrl, err := url.Parse(endpoint)
+			if endpoint == "" || err != nil {
+				continue
+			}
+			if other, ok := envsByHost[parsedUrl.Host]; ok && other != env {
+				Logln(LogPrefixWarning+"Network settings of", other, "are used for", parsedUrl.Host,
+					"which is shared with", env)
+				continue
+			}
+			envsByHost[parsedUrl.Host] = env
+		}
+	}
+	return envsByHost
+}
+
+// newHttpClient creates an HTTP client with the network settings of an environment. Failed requests are retried
+// only if retry is true.
+func newHttpClient(env string, endpoints EnvEndpoints, retry bool) (*resty.Client, error) {
+	client := resty.New()
+
+	var tlsConfig *tls.Config
+	if Insecure {
+		tlsConfig = &tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
+			Renegotiation: TLSRenegotiationMode}
+	} else {
+		tlsConfig = GetTlsConfigWithCertificate()
+	}
+	if endpoints.ClientCertificate != "" {
+		certificate, err := tls.LoadX509KeyPair(endpoints.ClientCertificate, endpoints.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate of %s: %v", env, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	client.SetTLSClientConfig(tlsConfig)
+
+	if endpoints.Proxy != "" {
+		transport, ok := client.GetClient().Transport.(*http.Transport)
+		if ok {
+			proxyFunc := (&httpproxy.Config{
+				HTTPProxy:  endpoints.Proxy,
+				HTTPSProxy: endpoints.Proxy,
+				NoProxy:    endpoints.NoProxy,
+			}).ProxyFunc()
+			transport.Proxy = func(request *http.Request) (*url.URL, error) {
+				return proxyFunc(request.URL)
+			}
+		}
+	}
+
+	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	client.SetLogger(&httpClientLogger{})
+	if retry {
+		client.SetRetryCount(HttpRetryCount).
+			SetRetryWaitTime(httpRetryWaitTime).
+			SetRetryMaxWaitTime(httpRetryMaxWaitTime).
+			SetRetryAfter(getRetryAfter).
+			AddRetryCondition(shouldRetryHttpRequest)
+	}
+
+	if HttpTrace {
+		client.OnBeforeRequest(traceHttpRequest)
+		client.OnAfterResponse(traceHttpResponse)
+		client.OnError(traceHttpError)
+	}
+	return client, nil
+}
+
+// shouldRetryHttpRequest retries idempotent requests that failed due to a network error or a gateway error. Any
+// request rejected with 429 or 503 is retried, since the server has not processed it.
+func shouldRetryHttpRequest(resp *resty.Response, err error) bool {
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotentHttpMethod(resp.Request.Method)
+	}
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotentHttpMethod(resp.Request.Method)
+	}
+	return false
+}
+
+func isIdempotentHttpMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// getRetryAfter returns the wait time given by the Retry-After header of a response in seconds or as a date. A zero
+// wait time makes the client to use the exponential backoff.
+func getRetryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	retryAfter := resp.Header().Get("Retry-After")
+	if retryAfter == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil && time.Until(date) > 0 {
+		return time.Until(date), nil
+	}
+	return 0, nil
+}
+
+// httpClientLogger writes the messages of the HTTP client, such as the failed attempts of a request, in verbose mode
+type httpClientLogger struct{}
+
+func (l *httpClientLogger) Errorf(format string, v ...interface{}) {
+	l.log(format, v...)
+}
+
+func (l *httpClientLogger) Warnf(format string, v ...interface{}) {
+	l.log(format, v...)
+}
+
+func (l *httpClientLogger) Debugf(format string, v ...interface{}) {
+	l.log(format, v...)
+}
+
+func (l *httpClientLogger) log(format string, v ...interface{}) {
+	message := strings.TrimSuffix(fmt.Sprintf(format, v...), "\n")
+	if HttpTrace {
+		fmt.Fprintln(os.Stderr, "* "+message)
+		return
+	}
+	Logln(LogPrefixInfo + message)
+}
+
+// traceHttpRequest writes a request to stderr before it is sent
+func traceHttpRequest(_ *resty.Client, request *resty.Request) error {
+	id := atomic.AddInt64(&httpTraceCount, 1)
+	httpTraceIDs.Store(request, id)
+	var trace strings.Builder
+	fmt.Fprintf(&trace, "> [%d] %s %s\n", id, request.Method, request.URL)
+	writeHttpTraceHeaders(&trace, "> ", request.Header)
+	if body := formatHttpTraceRequestBody(request); body != "" {
+		trace.WriteString(">\n" + body + "\n")
+	}
+	writeHttpTrace(trace.String())
+	return nil
+}
+
+// traceHttpResponse writes a response to stderr after it is received
+func traceHttpResponse(_ *resty.Client, resp *resty.Response) error {
+	var trace strings.Builder
+	fmt.Fprintf(&trace, "< [%d] %s (%v)\n", getHttpTraceID(resp.Request), resp.Status(),
+		resp.Time().Round(time.Millisecond))
+	writeHttpTraceHeaders(&trace, "< ", resp.Header())
+	if body := formatHttpTraceBody(resp.Header().Get(HeaderContentType), resp.Body()); body != "" {
+		trace.WriteString("<\n" + body + "\n")
+	}
+	writeHttpTrace(trace.String())
+	return nil
+}
+
+// traceHttpError writes the error of a request that did not receive a response
+func traceHttpError(request *resty.Request, err error) {
+	if _, ok := err.(*resty.ResponseError); ok {
+		return
+	}
+	writeHttpTrace(fmt.Sprintf("< [%d] %v\n", getHttpTraceID(request), err))
+}
+
+// getHttpTraceID returns the number of the last attempt of a traced request
+func getHttpTraceID(request *resty.Request) int64 {
+	if id, ok := httpTraceIDs.Load(request); ok {
+		return id.(int64)
+	}
+	return 0
+}
+
+func writeHttpTrace(trace string) {
+	httpTraceLock.Lock()
+	defer httpTraceLock.Unlock()
+	fmt.Fprint(os.Stderr, trace)
+}
+
+func writeHttpTraceHeaders(trace *strings.Builder, prefix string, headers http.Header) {
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(headers[name], ", ")
+		for _, sensitive := range httpTraceSensitiveHeaders {
+			if strings.EqualFold(name, sensitive) {
+				value = httpTraceRedacted
+				if scheme := strings.Fields(headers.Get(name)); len(scheme) > 1 {
+					value = scheme[0] + " " + httpTraceRedacted
+				}
+			}
+		}
+		fmt.Fprintf(trace, "%s%s: %s\n", prefix, name, value)
+	}
+}
+
+// formatHttpTraceRequestBody returns the body of a request to be traced
+func formatHttpTraceRequestBody(request *resty.Request) string {
+	switch body := request.Body.(type) {
+	case nil:
+		if len(request.FormData) > 0 {
+			return "[multipart form data]"
+		}
+		return ""
+	case string:
+		return formatHttpTraceBody(request.Header.Get(HeaderContentType), []byte(body))
+	case []byte:
+		return formatHttpTraceBody(request.Header.Get(HeaderContentType), body)
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Sprintf("[%T]", body)
+		}
+		return formatHttpTraceBody(HeaderValueApplicationJSON, data)
+	}
+}
+
+// formatHttpTraceBody returns a textual body with its sensitive values redacted. Binary bodies such as zip
+// archives are not written.
+func formatHttpTraceBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if contentType != "" && !strings.Contains(contentType, "json") && !strings.Contains(contentType, "text") &&
+		!strings.Contains(contentType, "yaml") && !strings.Contains(contentType, "xml") &&
+		!strings.Contains(contentType, HeaderValueXWWWFormUrlEncoded) {
+		return fmt.Sprintf("[%d bytes of %s]", len(body), contentType)
+	}
+	text := httpTraceSensitiveFields.ReplaceAllString(string(body), "${1}"+httpTraceRedacted)
+	if len(text) > httpTraceBodyLimit {
+		text = text[:httpTraceBodyLimit] + fmt.Sprintf("... [%d more bytes]", len(text)-httpTraceBodyLimit)
+	}
+	return text
+}
@@ -0,0 +1,216 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// useHttpTestConfig makes the HTTP clients to be created for a main config with the given environments
+func useHttpTestConfig(t *testing.T, environments map[string]EnvEndpoints) {
+	path := filepath.Join(t.TempDir(), MainConfigFileName)
+	WriteConfigFile(&MainConfig{Config: Config{ExportDirectory: t.TempDir()}, Environments: environments}, path)
+	originalPath, originalWaitTime, originalMaxWaitTime := MainConfigFilePath, httpRetryWaitTime, httpRetryMaxWaitTime
+	MainConfigFilePath, httpRetryWaitTime, httpRetryMaxWaitTime = path, time.Millisecond, 10*time.Millisecond
+	resetHttpClients()
+	t.Cleanup(func() {
+		MainConfigFilePath, httpRetryWaitTime, httpRetryMaxWaitTime = originalPath, originalWaitTime,
+			originalMaxWaitTime
+		resetHttpClients()
+	})
+}
+
+func resetHttpClients() {
+	httpClientsLock.Lock()
+	defer httpClientsLock.Unlock()
+	httpClients = make(map[httpClientKey]*resty.Client)
+	httpMainConfig = nil
+	httpEnvsByHost = nil
+}
+
+func TestHttpClientRetriesThrottledRequests(t *testing.T) {
+	useHttpTestConfig(t, nil)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	resp, err := InvokePOSTRequest(server.URL, nil, "{}")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode())
+	assert.Equal(t, int32(2), calls, "Throttled POST request should be retried")
+}
+
+func TestHttpClientRetriesOnlyIdempotentRequestsOnGatewayErrors(t *testing.T) {
+	useHttpTestConfig(t, nil)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	resp, err := InvokePOSTRequest(server.URL, nil, "{}")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode())
+	assert.Equal(t, int32(1), calls, "POST request should not be retried")
+
+	calls = 0
+	_, err = InvokeGETRequest(server.URL, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1+HttpRetryCount), calls, "GET request should be retried")
+}
+
+func TestInvokeRequestWithoutRetries(t *testing.T) {
+	useHttpTestConfig(t, nil)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resp, err := InvokeRequestWithoutRetries(http.MethodGet, server.URL, nil, "")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode())
+	assert.Equal(t, int32(1), calls, "Request should not be retried")
+
+	calls = 0
+	_, err = InvokeGETRequest(server.URL, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1+HttpRetryCount), calls, "Request of the shared client should still be retried")
+}
+
+func TestHttpClientUsesProxyOfEnvironment(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.Host)
+	}))
+	defer proxy.Close()
+	useHttpTestConfig(t, map[string]EnvEndpoints{
+		"dev": {
+			ApiManagerEndpoint:   "http://apim.example.com:9443",
+			TokenEndpoint:        "http://apim.example.com:9443/oauth2/token",
+			MiManagementEndpoint: "http://mi.example.com:9164",
+			Proxy:                proxy.URL,
+			NoProxy:              "mi.example.com",
+		},
+	})
+
+	_, err := InvokeGETRequest("http://apim.example.com:9443/api/am/publisher/v4/apis", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"apim.example.com:9443"}, proxied)
+
+	// A host in the no proxy list is reached directly, which fails since it does not exist
+	_, err = InvokeGETRequest("http://mi.example.com:9164/management/apis", nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(proxied))
+}
+
+func TestHttpClientUsesClientCertificateOfEnvironment(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 1 {
+			_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	certFile, keyFile := writeTestClientCertificate(t)
+	useHttpTestConfig(t, map[string]EnvEndpoints{
+		"dev": {
+			ApiManagerEndpoint: server.URL,
+			TokenEndpoint:      server.URL + "/oauth2/token",
+			ClientCertificate:  certFile,
+			ClientKey:          keyFile,
+		},
+	})
+	Insecure = true
+	defer func() { Insecure = false }()
+
+	resp, err := InvokeGETRequest(server.URL, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "apictl", string(resp.Body()))
+}
+
+func TestFormatHttpTraceRedactsCredentials(t *testing.T) {
+	body := formatHttpTraceBody(HeaderValueXWWWFormUrlEncoded,
+		[]byte("grant_type=password&username=admin&password=secret&scope=apim:api_view"))
+	assert.Equal(t, "grant_type=password&username=admin&password=[REDACTED]&scope=apim:api_view", body)
+
+	body = formatHttpTraceBody(HeaderValueApplicationJSON,
+		[]byte(`{"access_token":"abc","refresh_token": "def","expires_in":3600}`))
+	assert.Equal(t, `{"access_token":"[REDACTED]","refresh_token": "[REDACTED]","expires_in":3600}`, body)
+
+	assert.Equal(t, "[3 bytes of application/zip]", formatHttpTraceBody(HeaderValueApplicationZip, []byte("zip")))
+
+	var trace strings.Builder
+	writeHttpTraceHeaders(&trace, "> ", http.Header{HeaderAuthorization: {"Bearer abc"},
+		HeaderAccept: {HeaderValueApplicationJSON}})
+	assert.Equal(t, "> Accept: application/json\n> Authorization: Bearer [REDACTED]\n", trace.String())
+}
+
+// writeTestClientCertificate writes a self signed client certificate and its key as PEM files
+func writeTestClientCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "apictl"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	privateKey, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	assert.Nil(t, ioutil.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600))
+	assert.Nil(t, ioutil.WriteFile(keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKey}), 0600))
+	return certFile, keyFile
+}
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"net/http"
 	"net/url"
 	"path/filepath"
 )
@@ -14,21 +13,15 @@ import (
 const PlainTextWarnMessage = "WARNING: Error importing the certificate %s\n"
 
 func ReadFromUrl(url string) ([]byte, error) {
-	response, err := http.Get(url)
+	response, err := InvokeGETRequest(url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := ioutil.ReadAll(response.Body)
-	_ = response.Body.Close()
-	if err != nil {
-		return nil, err
-	}
-
-	if response.StatusCode != 200 {
-		return nil, errors.New(response.Status)
+	if response.StatusCode() != 200 {
+		return nil, errors.New(response.Status())
 	}
-	return body, nil
+	return response.Body(), nil
 }
 
 func GetTlsConfigWithCertificate() *tls.Config {
@@ -82,7 +75,7 @@ func ReadCertsFromDir() *x509.CertPool {
 						certs.AppendCertsFromPEM(fileData)
 					}
 				} else {
-					fmt.Printf(PlainTextWarnMessage, certificate.Name())
+					fmt.Fprintf(Stdout, PlainTextWarnMessage, certificate.Name())
 				}
 			}
 		}
@@ -42,22 +42,22 @@ func ListArtifactsInJsonArrayFormat(artifacts interface{}, artifactType string)
 
 	data, err := json.Marshal(artifacts)
 	if err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(Stdout, "Error executing template:", err.Error())
 	}
 
 	op, err := jq.Parse("")
 	if err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(Stdout, "Error executing template:", err.Error())
 	}
 
 	formattedData, _ := selectTypeOfOutputEntry(data, artifactType)
 	output, err := op.Apply(formattedData)
 	if err != nil {
-		fmt.Println("Error executing template:", err.Error())
+		fmt.Fprintln(Stdout, "Error executing template:", err.Error())
 	}
 
 	// Return JsonArray format output to CLI
-	fmt.Println(string(output))
+	fmt.Fprintln(Stdout, string(output))
 }
 
 // Get formatted output based on the type of artifact
@@ -96,6 +96,12 @@ func selectTypeOfOutputEntry(data []byte, artifactType string) ([]byte, error) {
 
 		// Formatting data to get the JsonArray object in prettyPrint format
 		return json.MarshalIndent(policyEntries, "", " ")
+	} else if artifactType == ProjectTypeSubscription {
+		var subscriptionEntries []SubscriptionEntry
+		json.Unmarshal(data, &subscriptionEntries)
+
+		// Formatting data to get the JsonArray object in prettyPrint format
+		return json.MarshalIndent(subscriptionEntries, "", " ")
 	} else {
 		var revisionEntries []RevisionEntry
 		// Map API information to APIEntry struct
@@ -0,0 +1,292 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	encodeURL "net/url"
+	"strings"
+	"time"
+)
+
+// Grant types used to login to API Manager
+const (
+	GrantTypePassword          = "password"
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeDeviceCode        = "device_code"
+	GrantTypeRefreshToken      = "refresh_token"
+)
+
+// grantTypeDeviceCodeURN is the grant type of the device access token request as defined in RFC 8628
+const grantTypeDeviceCodeURN = "urn:ietf:params:oauth:grant-type:device_code"
+
+// Errors returned by the token endpoint while the user completes the device authorization
+const (
+	deviceAuthorizationPending  = "authorization_pending"
+	deviceAuthorizationSlowDown = "slow_down"
+)
+
+// defaultDeviceCodePollInterval is used if the authorization server does not specify the polling interval
+const defaultDeviceCodePollInterval = 5 * time.Second
+
+// sleep waits between the polls of the device flow and is replaced in tests
+var sleep = time.Sleep
+
+// DeviceAuthorizationResponse is the response of the device authorization endpoint
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// oauthErrorResponse is the error returned by the token endpoint
+type oauthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// GetDeviceAuthorizationEndpoint returns the device authorization endpoint of the key manager of an environment. The
+// endpoint is resolved relative to the token endpoint. Eg: https://localhost:9443/oauth2/device_authorize
+func GetDeviceAuthorizationEndpoint(tokenEndpoint string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(tokenEndpoint, "/"), "/token") + "/device_authorize"
+}
+
+// GetOAuthTokensWithClientCredentials requests tokens using the client credentials grant
+// @param clientID : Client ID of the OAuth application
+// @param clientSecret : Client secret of the OAuth application
+// @param url : OAuth token endpoint
+// @return response as a map
+// @return error
+func GetOAuthTokensWithClientCredentials(clientID, clientSecret, url string) (map[string]string, error) {
+	body := "grant_type=" + GrantTypeClientCredentials + "&scope=" + OAuthTokenScopes
+	return requestOAuthTokens(clientID, clientSecret, body, url)
+}
+
+// GetOAuthTokensWithRefreshToken requests tokens using the refresh token grant
+// @param refreshToken : Refresh token returned with the previous tokens
+// @param clientID : Client ID of the OAuth application
+// @param clientSecret : Client secret of the OAuth application. Could be blank for public clients
+// @param url : OAuth token endpoint
+// @return response as a map
+// @return error
+func GetOAuthTokensWithRefreshToken(refreshToken, clientID, clientSecret, url string) (map[string]string, error) {
+	body := "grant_type=" + GrantTypeRefreshToken + "&refresh_token=" + encodeURL.QueryEscape(refreshToken)
+	return requestOAuthTokens(clientID, clientSecret, body, url)
+}
+
+// DefaultPluginTokenScopes are the scopes of the tokens issued to the trusted plugins if plugin_token_scopes is not
+// set in the main config. The scopes only allow reading the APIs, the API Products and the applications.
+var DefaultPluginTokenScopes = []string{"apim:api_view", "apim:subscribe", "apim:mcp_server_view",
+	"apim:mcp_server_list_view"}
+
+// PluginTokenValidityPeriod is the validity period, in seconds, of the tokens issued to the plugins
+const PluginTokenValidityPeriod = 900
+
+// GetPluginOAuthTokens requests tokens for a plugin using the password or the client credentials grant. The tokens
+// are requested with the given scopes, which have to differ from the scopes of apictl so that the key manager issues
+// a token other than the active token of apictl, and with a validity period of PluginTokenValidityPeriod.
+// @param username : Username of the user, blank for the client credentials grant
+// @param password : Password of the user, blank for the client credentials grant
+// @param clientID : Client ID of the OAuth application
+// @param clientSecret : Client secret of the OAuth application
+// @param scopes : Scopes of the token
+// @param url : OAuth token endpoint
+// @return response as a map
+// @return error
+func GetPluginOAuthTokens(username, password, clientID, clientSecret string, scopes []string,
+	url string) (map[string]string, error) {
+	if err := ValidatePluginTokenScopes(scopes); err != nil {
+		return nil, err
+	}
+	body := "grant_type=" + GrantTypeClientCredentials
+	if username != "" {
+		body = "grant_type=" + GrantTypePassword + "&username=" + encodeURL.QueryEscape(username) +
+			"&password=" + encodeURL.QueryEscape(password)
+	}
+	body += "&scope=" + strings.Join(scopes, "+") + "&validity_period=" + fmt.Sprint(PluginTokenValidityPeriod)
+	return requestOAuthTokens(clientID, clientSecret, body, url)
+}
+
+// ValidatePluginTokenScopes returns an error if the scopes of the plugin tokens are empty or include all the scopes
+// of apictl, in which case the key manager would return the active token of apictl instead of a new token.
+func ValidatePluginTokenScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return errors.New("no scopes are given for the plugin token")
+	}
+	given := make(map[string]bool)
+	for _, scope := range scopes {
+		given[scope] = true
+	}
+	for _, scope := range strings.Split(OAuthTokenScopes, "+") {
+		if !given[scope] {
+			return nil
+		}
+	}
+	return errors.New("the plugin token scopes include all the scopes of " + ProjectName +
+		", remove the scopes not needed by the plugins from plugin_token_scopes")
+}
+
+// RequestDeviceAuthorization starts the device flow of RFC 8628 by requesting a device code and a user code
+// @param clientID : Client ID of the OAuth application
+// @param url : Device authorization endpoint
+// @return response of the device authorization endpoint
+// @return error
+func RequestDeviceAuthorization(clientID, url string) (*DeviceAuthorizationResponse, error) {
+	headers := make(map[string]string)
+	headers[HeaderContentType] = HeaderValueXWWWFormUrlEncoded
+	headers[HeaderAccept] = HeaderValueApplicationJSON
+	body := "client_id=" + encodeURL.QueryEscape(clientID) + "&scope=" + OAuthTokenScopes
+
+	Logln(LogPrefixInfo + "connecting to " + url)
+	resp, err := InvokePOSTRequest(url, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New("Unable to start the device authorization. Status: " + resp.Status() + " " +
+			getOAuthErrorDescription(resp.Body()))
+	}
+	authorization := &DeviceAuthorizationResponse{}
+	if err := json.Unmarshal(resp.Body(), authorization); err != nil {
+		return nil, err
+	}
+	if authorization.DeviceCode == "" || authorization.VerificationURI == "" {
+		return nil, errors.New("invalid response from the device authorization endpoint " + url)
+	}
+	return authorization, nil
+}
+
+// PollDeviceAccessToken polls the token endpoint until the user completes the device authorization, the
+// authorization is denied or the device code expires
+// @param authorization : Response of the device authorization endpoint
+// @param clientID : Client ID of the OAuth application
+// @param clientSecret : Client secret of the OAuth application. Could be blank for public clients
+// @param url : OAuth token endpoint
+// @return response as a map
+// @return error
+func PollDeviceAccessToken(authorization *DeviceAuthorizationResponse, clientID, clientSecret,
+	url string) (map[string]string, error) {
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDeviceCodePollInterval
+	}
+	deadline := time.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+	body := "grant_type=" + encodeURL.QueryEscape(grantTypeDeviceCodeURN) + "&device_code=" +
+		encodeURL.QueryEscape(authorization.DeviceCode)
+	for {
+		sleep(interval)
+		tokens, err := requestOAuthTokens(clientID, clientSecret, body, url)
+		if err == nil {
+			return tokens, nil
+		}
+		var oauthErr *oauthError
+		if !errors.As(err, &oauthErr) {
+			return nil, err
+		}
+		switch oauthErr.response.Error {
+		case deviceAuthorizationPending:
+		case deviceAuthorizationSlowDown:
+			interval += defaultDeviceCodePollInterval
+		default:
+			return nil, err
+		}
+		if authorization.ExpiresIn > 0 && time.Now().After(deadline) {
+			return nil, errors.New("the device code expired before the authorization was completed")
+		}
+	}
+}
+
+// oauthError is returned when the token endpoint rejects a token request with an OAuth error
+type oauthError struct {
+	status   string
+	response oauthErrorResponse
+}
+
+func (e *oauthError) Error() string {
+	message := "Unable to connect. Status: " + e.status + ". " + e.response.Error
+	if e.response.ErrorDescription != "" {
+		message += ": " + e.response.ErrorDescription
+	}
+	return message
+}
+
+// requestOAuthTokens sends a token request authenticated with the client credentials. Public clients without a
+// secret send the client ID in the body.
+func requestOAuthTokens(clientID, clientSecret, body, url string) (map[string]string, error) {
+	headers := make(map[string]string)
+	headers[HeaderContentType] = HeaderValueXWWWFormUrlEncoded
+	headers[HeaderAccept] = HeaderValueApplicationJSON
+	if clientSecret != "" {
+		headers[HeaderAuthorization] = HeaderValueAuthBasicPrefix + " " +
+			GetBase64EncodedCredentials(clientID, clientSecret)
+	} else {
+		body += "&client_id=" + encodeURL.QueryEscape(clientID)
+	}
+
+	Logln(LogPrefixInfo + "connecting to " + url)
+	resp, err := InvokePOSTRequest(url, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		var errorResponse oauthErrorResponse
+		if json.Unmarshal(resp.Body(), &errorResponse) == nil && errorResponse.Error != "" {
+			return nil, &oauthError{status: resp.Status(), response: errorResponse}
+		}
+		return nil, errors.New("Unable to connect. Status: " + resp.Status())
+	}
+
+	return decodeOAuthTokens(resp.Body())
+}
+
+// decodeOAuthTokens decodes a token response to a map of strings. Values such as expires_in are numbers, hence the
+// response is not decoded directly to a map of strings.
+func decodeOAuthTokens(body []byte) (map[string]string, error) {
+	var response map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// Numbers are kept as they are, since a large expires_in would be formatted in exponent form as a float
+	decoder.UseNumber()
+	if err := decoder.Decode(&response); err != nil {
+		return nil, err
+	}
+	tokens := make(map[string]string)
+	for key, value := range response {
+		tokens[key] = fmt.Sprint(value)
+	}
+	return tokens, nil
+}
+
+// getOAuthErrorDescription returns the description of an OAuth error response body
+func getOAuthErrorDescription(body []byte) string {
+	var errorResponse oauthErrorResponse
+	if json.Unmarshal(body, &errorResponse) != nil {
+		return ""
+	}
+	if errorResponse.ErrorDescription != "" {
+		return errorResponse.ErrorDescription
+	}
+	return errorResponse.Error
+}
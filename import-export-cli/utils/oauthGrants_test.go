@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeviceAuthorizationEndpoint(t *testing.T) {
+	assert.Equal(t, "https://localhost:9443/oauth2/device_authorize",
+		GetDeviceAuthorizationEndpoint("https://localhost:9443/oauth2/token"))
+	assert.Equal(t, "https://localhost:9443/oauth2/device_authorize",
+		GetDeviceAuthorizationEndpoint("https://localhost:9443/oauth2/token/"))
+}
+
+func TestGetOAuthTokensWithClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, GrantTypeClientCredentials, r.PostForm.Get("grant_type"))
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "ci-client" || clientSecret != "ci-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"Client Authentication failed."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	tokens, err := GetOAuthTokensWithClientCredentials("ci-client", "ci-secret", server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "token", tokens["access_token"])
+	assert.Equal(t, "3600", tokens["expires_in"])
+
+	_, err = GetOAuthTokensWithClientCredentials("ci-client", "wrong", server.URL)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Client Authentication failed.")
+	}
+}
+
+func TestGetOAuthTokensWithRefreshTokenForPublicClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, GrantTypeRefreshToken, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "refresh-1", r.PostForm.Get("refresh_token"))
+		assert.Equal(t, "public-client", r.PostForm.Get("client_id"))
+		assert.Empty(t, r.Header.Get(HeaderAuthorization))
+		_, _ = w.Write([]byte(`{"access_token":"token","refresh_token":"refresh-2"}`))
+	}))
+	defer server.Close()
+
+	tokens, err := GetOAuthTokensWithRefreshToken("refresh-1", "public-client", "", server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh-2", tokens["refresh_token"])
+}
+
+func TestDeviceCodeFlowPollsUntilAuthorized(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		if r.URL.Path == "/oauth2/device_authorize" {
+			assert.Equal(t, "sso-client", r.PostForm.Get("client_id"))
+			_, _ = w.Write([]byte(`{"device_code":"device","user_code":"ABCD-EFGH",` +
+				`"verification_uri":"https://localhost:9443/authenticationendpoint/device.do",` +
+				`"expires_in":600,"interval":5}`))
+			return
+		}
+		assert.Equal(t, grantTypeDeviceCodeURN, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "device", r.PostForm.Get("device_code"))
+		switch atomic.AddInt32(&polls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"slow_down"}`))
+		default:
+			_, _ = w.Write([]byte(`{"access_token":"token","refresh_token":"refresh"}`))
+		}
+	}))
+	defer server.Close()
+	var waits []time.Duration
+	sleep = func(d time.Duration) { waits = append(waits, d) }
+	defer func() { sleep = time.Sleep }()
+
+	authorization, err := RequestDeviceAuthorization("sso-client",
+		GetDeviceAuthorizationEndpoint(server.URL+"/oauth2/token"))
+	assert.Nil(t, err)
+	assert.Equal(t, "ABCD-EFGH", authorization.UserCode)
+
+	tokens, err := PollDeviceAccessToken(authorization, "sso-client", "", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh", tokens["refresh_token"])
+	assert.Equal(t, []time.Duration{5 * time.Second, 5 * time.Second, 10 * time.Second}, waits)
+}
+
+func TestDeviceCodeFlowStopsWhenDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"access_denied","error_description":"The user denied the request"}`))
+	}))
+	defer server.Close()
+	sleep = func(time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+
+	_, err := PollDeviceAccessToken(&DeviceAuthorizationResponse{DeviceCode: "device", ExpiresIn: 600},
+		"sso-client", "", server.URL)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "The user denied the request")
+	}
+}
+
+func TestGetPluginOAuthTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, GrantTypePassword, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "admin", r.PostForm.Get("username"))
+		assert.Equal(t, "apim:api_view apim:subscribe", r.PostForm.Get("scope"))
+		assert.Equal(t, "900", r.PostForm.Get("validity_period"))
+		_, _ = w.Write([]byte(`{"access_token":"plugin-token","expires_in":900}`))
+	}))
+	defer server.Close()
+
+	tokens, err := GetPluginOAuthTokens("admin", "admin", "client", "secret",
+		[]string{"apim:api_view", "apim:subscribe"}, server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "plugin-token", tokens["access_token"])
+}
+
+func TestValidatePluginTokenScopes(t *testing.T) {
+	assert.Nil(t, ValidatePluginTokenScopes(DefaultPluginTokenScopes))
+	assert.Error(t, ValidatePluginTokenScopes(nil))
+	assert.Error(t, ValidatePluginTokenScopes(append(strings.Split(OAuthTokenScopes, "+"), "openid")))
+}
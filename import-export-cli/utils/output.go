@@ -0,0 +1,268 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+)
+
+// Exit codes of the commands run with --output json. Without --output json every failure exits with
+// ExitCodeError to stay compatible with existing scripts.
+const (
+	// ExitCodeSuccess is returned when the command succeeded
+	ExitCodeSuccess = 0
+	// ExitCodeError is returned for failures that do not fall into any other category
+	ExitCodeError = 1
+	// ExitCodeAuthFailure is returned when API Manager rejected the credentials or the token (401, 403)
+	ExitCodeAuthFailure = 2
+	// ExitCodeNotFound is returned when the artifact does not exist in the environment (404)
+	ExitCodeNotFound = 3
+	// ExitCodeConflict is returned when the artifact conflicts with an existing one (409)
+	ExitCodeConflict = 4
+	// ExitCodeValidationFailure is returned when API Manager rejected the request as invalid (400, 412, 422)
+	ExitCodeValidationFailure = 5
+	// ExitCodeNetworkError is returned when API Manager could not be reached
+	ExitCodeNetworkError = 6
+)
+
+// OutputFormat is the value of the global --output flag
+var OutputFormat string
+
+// Stdout is where the commands write their human readable output. It is stdout, except in json mode where the
+// human readable output is written to stderr so that stdout only contains the result of the command.
+var Stdout io.Writer = os.Stdout
+
+// resultOutput is where the command result is written in json mode
+var resultOutput io.Writer = os.Stdout
+
+// commandResult is the result of the running command, reported on exit in json mode
+var commandResult *formatter.CommandResult
+
+// commandResultLock guards the result since bulk commands report their artifacts concurrently
+var commandResultLock sync.Mutex
+
+var (
+	leadingHttpStatusRegex  = regexp.MustCompile(`^([1-5][0-9]{2})\b`)
+	embeddedHttpStatusRegex = regexp.MustCompile(`Status: ([1-5][0-9]{2})\b`)
+)
+
+// HttpResponseError is returned when API Manager responds with an unexpected status. The message is the status of
+// the response, while the body is kept for machine readable output.
+type HttpResponseError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+}
+
+func (e *HttpResponseError) Error() string {
+	return e.Status
+}
+
+// NewHttpResponseError creates an error from an unexpected response
+func NewHttpResponseError(resp *resty.Response) error {
+	return &HttpResponseError{StatusCode: resp.StatusCode(), Status: resp.Status(), Header: resp.Header(),
+		Body: resp.Body()}
+}
+
+// IsJSONOutput returns true if the command result should be written as json
+func IsJSONOutput() bool {
+	return OutputFormat == formatter.JSONFormatKey
+}
+
+// EnableJSONOutput moves the human readable output of the command to stderr and prepares the result of the command
+// @param command : Command path without the project name. Eg: "import api"
+// @param action : Action performed by the command on success. Eg: "imported"
+// @param artifact : Artifact the command acts on
+func EnableJSONOutput(command, action string, artifact *formatter.ResultArtifact) {
+	Stdout = os.Stderr
+	commandResult = &formatter.CommandResult{Command: command, Action: action, Artifact: artifact}
+}
+
+// GetCommandResult returns the result of the running command so that commands can add the details they learn, such
+// as the UUID of the artifact. A detached result is returned if json output is not enabled.
+func GetCommandResult() *formatter.CommandResult {
+	if commandResult == nil {
+		return &formatter.CommandResult{}
+	}
+	return commandResult
+}
+
+// AddCommandWarning records a warning in the result of the running command
+func AddCommandWarning(warning string) {
+	commandResultLock.Lock()
+	defer commandResultLock.Unlock()
+	result := GetCommandResult()
+	result.Warnings = append(result.Warnings, warning)
+}
+
+// RecordArtifactIdFromResponse records the UUID in the response body of API Manager as the id of the result. The id
+// is only recorded if the running command acts on a single artifact of the given type.
+// @param artifactType : Type of the artifact in the response. Eg: "api"
+// @param body : Body of the response
+func RecordArtifactIdFromResponse(artifactType string, body []byte) {
+	commandResultLock.Lock()
+	defer commandResultLock.Unlock()
+	if commandResult == nil || commandResult.Artifact == nil || commandResult.Artifact.Type != artifactType {
+		return
+	}
+	var artifact struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(body, &artifact) == nil && artifact.ID != "" {
+		commandResult.ID = artifact.ID
+	}
+}
+
+// PrintCommandSuccess writes the successful result of the running command in json mode
+func PrintCommandSuccess() {
+	if !IsJSONOutput() || commandResult == nil {
+		return
+	}
+	commandResult.Status = formatter.ResultStatusSuccess
+	commandResult.ExitCode = ExitCodeSuccess
+	_ = commandResult.Write(resultOutput)
+}
+
+// printCommandFailure writes the failed result of the running command in json mode and returns the exit code
+func printCommandFailure(msg string, err error) int {
+	result := commandResult
+	if result == nil {
+		result = &formatter.CommandResult{}
+	}
+	result.Status = formatter.ResultStatusFailure
+	result.Error = &formatter.ResultError{Message: strings.TrimSpace(msg)}
+	result.ExitCode = ExitCodeError
+	if err != nil {
+		result.Error.Reason = err.Error()
+		result.Error.HTTPStatus = GetHttpStatusCodeFromError(err)
+		if errorResponse := GetHttpErrorResponseFromError(err); errorResponse != nil {
+			result.Error.Code = errorResponse.Code
+			result.Error.Description = errorResponse.Description
+			result.Error.MoreInfo = errorResponse.MoreInfo
+			if result.Error.HTTPStatus == 0 && isHttpStatusCode(errorResponse.Code) {
+				result.Error.HTTPStatus = errorResponse.Code
+			}
+		}
+		result.ExitCode = GetExitCode(err)
+	}
+	_ = result.Write(resultOutput)
+	return result.ExitCode
+}
+
+// GetExitCode maps an error to the exit code of its category
+func GetExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	var urlErr *url.Error
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &urlErr) || errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return ExitCodeNetworkError
+	}
+	status := GetHttpStatusCodeFromError(err)
+	if status == 0 {
+		if errorResponse := GetHttpErrorResponseFromError(err); errorResponse != nil &&
+			isHttpStatusCode(errorResponse.Code) {
+			status = errorResponse.Code
+		}
+	}
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ExitCodeAuthFailure
+	case http.StatusNotFound:
+		return ExitCodeNotFound
+	case http.StatusConflict:
+		return ExitCodeConflict
+	case http.StatusBadRequest, http.StatusPreconditionFailed, http.StatusUnprocessableEntity:
+		return ExitCodeValidationFailure
+	}
+	return ExitCodeError
+}
+
+// GetHttpStatusCodeFromError returns the HTTP status of the response that caused the error, or 0 if the error was
+// not caused by a response. Besides HttpResponseError, errors carrying the status as a prefix (Eg: "404 Not Found",
+// "404:<...>") or as "Status: 404" are recognized.
+func GetHttpStatusCodeFromError(err error) int {
+	if err == nil {
+		return 0
+	}
+	var respErr *HttpResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode
+	}
+	message := strings.TrimSpace(err.Error())
+	for _, regex := range []*regexp.Regexp{leadingHttpStatusRegex, embeddedHttpStatusRegex} {
+		if match := regex.FindStringSubmatch(message); match != nil {
+			status, _ := strconv.Atoi(match[1])
+			return status
+		}
+	}
+	return 0
+}
+
+// isHttpStatusCode returns true if the code of an error body is an HTTP status rather than an API Manager error code
+func isHttpStatusCode(code int) bool {
+	return code >= 100 && code < 600
+}
+
+// GetHttpErrorResponseFromError extracts the error body returned by API Manager from the error, or returns nil if
+// the error does not carry one
+func GetHttpErrorResponseFromError(err error) *HttpErrorResponse {
+	var body string
+	var respErr *HttpResponseError
+	if errors.As(err, &respErr) {
+		body = string(respErr.Body)
+	} else {
+		body = err.Error()
+	}
+	start, end := strings.Index(body, "{"), strings.LastIndex(body, "}")
+	if start < 0 || end < start {
+		return nil
+	}
+	// The error list of the body is skipped since it cannot be decoded to HttpErrorResponse.Error
+	var errorResponse struct {
+		Code        int    `json:"code"`
+		Status      string `json:"message"`
+		Description string `json:"description"`
+		MoreInfo    string `json:"moreInfo"`
+	}
+	if json.Unmarshal([]byte(body[start:end+1]), &errorResponse) != nil {
+		return nil
+	}
+	if errorResponse.Code == 0 && errorResponse.Description == "" && errorResponse.Status == "" {
+		return nil
+	}
+	return &HttpErrorResponse{Code: errorResponse.Code, Status: errorResponse.Status,
+		Description: errorResponse.Description, MoreInfo: errorResponse.MoreInfo}
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+)
+
+func TestGetExitCodeMapsErrorCategories(t *testing.T) {
+	assert.Equal(t, ExitCodeSuccess, GetExitCode(nil))
+	assert.Equal(t, ExitCodeAuthFailure, GetExitCode(&HttpResponseError{StatusCode: 401, Status: "401 Unauthorized"}))
+	assert.Equal(t, ExitCodeAuthFailure, GetExitCode(errors.New("403 Forbidden")))
+	assert.Equal(t, ExitCodeNotFound, GetExitCode(errors.New("404:<{\"code\":404}>")))
+	assert.Equal(t, ExitCodeConflict, GetExitCode(errors.New("Request failed. Status: 409 Conflict")))
+	assert.Equal(t, ExitCodeValidationFailure, GetExitCode(errors.New("400 Bad Request")))
+	assert.Equal(t, ExitCodeValidationFailure, GetExitCode(errors.New("422 Unprocessable Entity")))
+	assert.Equal(t, ExitCodeNetworkError, GetExitCode(&url.Error{Op: "Get", URL: "https://localhost:9443",
+		Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}))
+	assert.Equal(t, ExitCodeError, GetExitCode(errors.New("500 Internal Server Error")))
+	assert.Equal(t, ExitCodeError, GetExitCode(errors.New("invalid project")))
+}
+
+func TestGetExitCodeIgnoresAPIManagerErrorCodes(t *testing.T) {
+	err := errors.New(`Error: {"code":900910,"message":"The access token does not allow you to access the resource"}`)
+	assert.Equal(t, ExitCodeError, GetExitCode(err))
+}
+
+func TestGetHttpErrorResponseFromError(t *testing.T) {
+	body := `{"code":404,"message":"Not Found","description":"Requested API with id 'x' not found",` +
+		`"moreInfo":"","error":[{"code":1,"message":"field"}]}`
+	errorResponse := GetHttpErrorResponseFromError(errors.New("404:<" + body + ">"))
+	if assert.NotNil(t, errorResponse) {
+		assert.Equal(t, 404, errorResponse.Code)
+		assert.Equal(t, "Not Found", errorResponse.Status)
+		assert.Equal(t, "Requested API with id 'x' not found", errorResponse.Description)
+	}
+
+	errorResponse = GetHttpErrorResponseFromError(&HttpResponseError{StatusCode: 409, Status: "409 Conflict",
+		Body: []byte(`{"code":409,"description":"API already exists"}`)})
+	if assert.NotNil(t, errorResponse) {
+		assert.Equal(t, "API already exists", errorResponse.Description)
+	}
+
+	assert.Nil(t, GetHttpErrorResponseFromError(errors.New("connection refused")))
+	assert.Nil(t, GetHttpErrorResponseFromError(errors.New("{not json}")))
+}
+
+func TestPrintCommandFailureWritesResult(t *testing.T) {
+	var output bytes.Buffer
+	resultOutput = &output
+	commandResult = &formatter.CommandResult{Command: "delete api", Action: "deleted",
+		Artifact: &formatter.ResultArtifact{Type: "api", Name: "PizzaShackAPI", Version: "1.0.0"}}
+	defer func() {
+		resultOutput = os.Stdout
+		commandResult = nil
+	}()
+
+	exitCode := printCommandFailure("Error while deleting API", errors.New("404:<{\"code\":404,"+
+		"\"message\":\"Not Found\",\"description\":\"API not found\"}>"))
+
+	assert.Equal(t, ExitCodeNotFound, exitCode)
+	var result formatter.CommandResult
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &result))
+	assert.Equal(t, formatter.ResultStatusFailure, result.Status)
+	assert.Equal(t, "PizzaShackAPI", result.Artifact.Name)
+	assert.Equal(t, 404, result.Error.HTTPStatus)
+	assert.Equal(t, "API not found", result.Error.Description)
+	assert.Equal(t, ExitCodeNotFound, result.ExitCode)
+}
+
+func TestRecordArtifactIdFromResponse(t *testing.T) {
+	commandResult = &formatter.CommandResult{Artifact: &formatter.ResultArtifact{Type: "api"}}
+	defer func() {
+		commandResult = nil
+	}()
+
+	RecordArtifactIdFromResponse("api-product", []byte(`{"id":"product-id"}`))
+	assert.Equal(t, "", commandResult.ID)
+	RecordArtifactIdFromResponse("api", []byte(`API imported successfully`))
+	assert.Equal(t, "", commandResult.ID)
+	RecordArtifactIdFromResponse("api", []byte(`{"id":"api-id","name":"PizzaShackAPI"}`))
+	assert.Equal(t, "api-id", commandResult.ID)
+}
+
+func TestGetExitCodeDoesNotTreatSystemErrorsAsNetworkErrors(t *testing.T) {
+	assert.Equal(t, ExitCodeError, GetExitCode(syscall.ENOTTY))
+}
+
+func TestEnableJSONOutputMovesHumanReadableOutputToStderr(t *testing.T) {
+	stdout := os.Stdout
+	defer func() {
+		Stdout = os.Stdout
+		commandResult = nil
+	}()
+
+	EnableJSONOutput("vcs deploy", "deployed", &formatter.ResultArtifact{Type: "project"})
+	assert.Equal(t, os.Stderr, Stdout, "Human readable output should be written to stderr")
+	assert.Equal(t, stdout, os.Stdout, "The process stdout should be left as it is")
+	assert.Equal(t, os.Stdout, resultOutput, "The result should be written to stdout")
+}
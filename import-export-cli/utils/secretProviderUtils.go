@@ -0,0 +1,216 @@
+/*
+*  Copyright (c) WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 LLC. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/magiconair/properties"
+)
+
+// Types of the providers resolving the secrets referenced as ${secret:name}
+const (
+	SecretProviderEnv     = "env"
+	SecretProviderFile    = "file"
+	SecretProviderCommand = "command"
+)
+
+// DefaultSecretsFilePath is the properties file created by "apictl secret create symmetric -o file", relative to the
+// directory of the params file or the project referencing the secrets
+var DefaultSecretsFilePath = filepath.Join("security", encryptedSecretsPropertiesFileName)
+
+// SecretProvider resolves the value of a secret referenced in a project or a params file
+type SecretProvider interface {
+	GetSecret(name string) (string, error)
+}
+
+// DirSecretProvider is a SecretProvider reading the secrets from a location relative to the directory of the params
+// file or the project referencing them
+type DirSecretProvider interface {
+	SecretProvider
+	GetSecretInDir(name, baseDir string) (string, error)
+}
+
+// secretProvider is the provider used while substituting the variables. Secrets are read from the environment by
+// default.
+var secretProvider SecretProvider = &EnvSecretProvider{}
+
+// GetSecretProvider returns the provider used to resolve the secrets
+func GetSecretProvider() SecretProvider {
+	return secretProvider
+}
+
+// SetSecretProvider sets the provider used to resolve the secrets
+func SetSecretProvider(provider SecretProvider) {
+	secretProvider = provider
+}
+
+// NewSecretProvider creates the secret provider defined in the main config. The env provider is used if the type is
+// not set.
+// @param config : Secret provider config
+// @return secret provider
+// @return error
+func NewSecretProvider(config SecretProviderConfig) (SecretProvider, error) {
+	switch strings.ToLower(config.Type) {
+	case "", SecretProviderEnv:
+		return &EnvSecretProvider{}, nil
+	case SecretProviderFile:
+		filePath := config.File
+		if filePath == "" {
+			filePath = DefaultSecretsFilePath
+		}
+		return &FileSecretProvider{FilePath: filePath}, nil
+	case SecretProviderCommand:
+		if strings.TrimSpace(config.Command) == "" {
+			return nil, errors.New("command of the secret provider cannot be blank")
+		}
+		return &CommandSecretProvider{Command: config.Command}, nil
+	default:
+		return nil, fmt.Errorf("invalid secret provider %s. It should be one of %s, %s or %s", config.Type,
+			SecretProviderEnv, SecretProviderFile, SecretProviderCommand)
+	}
+}
+
+// EnvSecretProvider reads the secrets from the environment variables having the name of the secret
+type EnvSecretProvider struct{}
+
+// GetSecret returns the value of the environment variable
+func (p *EnvSecretProvider) GetSecret(name string) (string, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return "", fmt.Errorf("secret %s is required, please set the environment variable", name)
+	}
+	return value, nil
+}
+
+// FileSecretProvider reads the secrets from a properties file encrypted with the symmetric key initialized using
+// "apictl secret init symmetric". A relative FilePath is resolved against the directory of the params file or the
+// project referencing the secret.
+type FileSecretProvider struct {
+	FilePath string
+
+	lock  sync.Mutex
+	files map[string]*secretsFile
+}
+
+// secretsFile holds the encrypted secrets and the encryption key read from a secrets file
+type secretsFile struct {
+	once    sync.Once
+	secrets map[string]string
+	key     []byte
+	err     error
+}
+
+// GetSecret decrypts the secret having the name as the alias in the properties file. A relative FilePath is
+// resolved against the current directory.
+func (p *FileSecretProvider) GetSecret(name string) (string, error) {
+	return p.GetSecretInDir(name, "")
+}
+
+// GetSecretInDir decrypts the secret having the name as the alias in the properties file, resolving a relative
+// FilePath against baseDir
+func (p *FileSecretProvider) GetSecretInDir(name, baseDir string) (string, error) {
+	filePath := p.FilePath
+	if baseDir != "" && !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(baseDir, filePath)
+	}
+	file := p.getSecretsFile(filePath)
+	if file.err != nil {
+		return "", file.err
+	}
+	encrypted, ok := file.secrets[name]
+	if !ok {
+		return "", fmt.Errorf("secret %s is not found in %s", name, filePath)
+	}
+	secret, err := DecryptAES256(file.key, encrypted)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt the secret %s: %w", name, err)
+	}
+	return secret, nil
+}
+
+// getSecretsFile returns the secrets of the file, reading it only once
+func (p *FileSecretProvider) getSecretsFile(filePath string) *secretsFile {
+	p.lock.Lock()
+	if p.files == nil {
+		p.files = make(map[string]*secretsFile)
+	}
+	file, ok := p.files[filePath]
+	if !ok {
+		file = &secretsFile{}
+		p.files[filePath] = file
+	}
+	p.lock.Unlock()
+	file.once.Do(func() { file.load(filePath) })
+	return file
+}
+
+// load reads the encrypted secrets and the stored encryption key
+func (f *secretsFile) load(filePath string) {
+	props, err := properties.LoadFile(filePath, properties.UTF8)
+	if err != nil {
+		f.err = fmt.Errorf("unable to read the secrets file %s: %w", filePath, err)
+		return
+	}
+	f.secrets = props.Map()
+
+	config, err := GetEncryptionKeyConfigFromFile(GetEncryptionKeyConfigFilePath())
+	if err != nil || config == nil || !IsValidSymmetricEncryptionConfig(config) {
+		f.err = errors.New("encryption key has not been initialized. Execute 'apictl secret init symmetric'")
+		return
+	}
+	encryptionKey, err := GetStoredEncryptionKey(config)
+	if err != nil {
+		f.err = err
+		return
+	}
+	f.key, f.err = ResolveAES256Key(encryptionKey)
+}
+
+// CommandSecretProvider executes an external command with the name of the secret as the last argument and reads the
+// secret from its output. Eg: a command such as "vault kv get -field=value secret/apictl" or a script of a password
+// manager.
+type CommandSecretProvider struct {
+	Command string
+}
+
+// GetSecret executes the command and returns its output without the trailing line break
+func (p *CommandSecretProvider) GetSecret(name string) (string, error) {
+	args := strings.Fields(p.Command)
+	if len(args) == 0 {
+		return "", errors.New("command of the secret provider cannot be blank")
+	}
+	var stdout, stderr bytes.Buffer
+	command := exec.Command(args[0], append(args[1:], name)...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	Logln(LogPrefixInfo + "Resolving secret " + name + " using " + args[0])
+	if err := command.Run(); err != nil {
+		return "", fmt.Errorf("unable to resolve the secret %s using %s: %w %s", name, args[0], err,
+			strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
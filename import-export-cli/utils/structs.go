@@ -39,17 +39,32 @@ type MainConfig struct {
 }
 
 type Config struct {
-	HttpRequestTimeout    int    `yaml:"http_request_timeout"`
-	ExportDirectory       string `yaml:"export_directory"`
-	KubernetesMode        bool   `yaml:"kubernetes_mode"`
-	TokenType             string `yaml:"token_type"`
-	VCSDeletionEnabled    bool   `yaml:"vcs_deletion_enabled"`
-	VCSConfigFilePath     string `yaml:"vcs_config_file_path"`
-	VCSSourceRepoPath     string `yaml:"vcs_source_repo_path"`
-	VCSDeploymentRepoPath string `yaml:"vcs_deployment_repo_path"`
-	TLSRenegotiationMode  string `yaml:"tls-renegotiation-mode"`
-	AIThreadCount         int    `yaml:"ai_thread_count"`
-	AIToken               string `yaml:"ai_token"`
+	HttpRequestTimeout    int                  `yaml:"http_request_timeout"`
+	HttpRetryCount        int                  `yaml:"http_retry_count,omitempty"`
+	ExportDirectory       string               `yaml:"export_directory"`
+	KubernetesMode        bool                 `yaml:"kubernetes_mode"`
+	TokenType             string               `yaml:"token_type"`
+	VCSDeletionEnabled    bool                 `yaml:"vcs_deletion_enabled"`
+	VCSConfigFilePath     string               `yaml:"vcs_config_file_path"`
+	VCSSourceRepoPath     string               `yaml:"vcs_source_repo_path"`
+	VCSDeploymentRepoPath string               `yaml:"vcs_deployment_repo_path"`
+	TLSRenegotiationMode  string               `yaml:"tls-renegotiation-mode"`
+	AIThreadCount         int                  `yaml:"ai_thread_count"`
+	AIToken               string               `yaml:"ai_token"`
+	SecretProvider        SecretProviderConfig `yaml:"secret_provider,omitempty"`
+	TrustedPlugins        []string             `yaml:"trusted_plugins,omitempty"`
+	PluginTokenScopes     []string             `yaml:"plugin_token_scopes,omitempty"`
+}
+
+// SecretProviderConfig defines the provider resolving the secrets referenced as ${secret:name} in projects and
+// params files
+type SecretProviderConfig struct {
+	// Type is one of env, file or command
+	Type string `yaml:"type,omitempty"`
+	// File is the properties file of the secrets encrypted with the symmetric key. Used by the file provider.
+	File string `yaml:"file,omitempty"`
+	// Command is executed with the name of the secret as the last argument. Used by the command provider.
+	Command string `yaml:"command,omitempty"`
 }
 
 type EnvKeys struct {
@@ -69,6 +84,13 @@ type EnvEndpoints struct {
 	AIServiceEndpoint      string `yaml:"ai_service"`
 	AITokenServiceEndpoint string `yaml:"ai_token_endpoint"`
 	AIKey                  string `yaml:"ai_key"`
+	// Proxy is the HTTP(S) proxy used to reach the endpoints of the environment
+	Proxy string `yaml:"proxy,omitempty"`
+	// NoProxy is a comma separated list of hosts reached without the proxy
+	NoProxy string `yaml:"no_proxy,omitempty"`
+	// ClientCertificate and ClientKey are the PEM files of the client certificate used for mutual TLS
+	ClientCertificate string `yaml:"client_cert,omitempty"`
+	ClientKey         string `yaml:"client_key,omitempty"`
 }
 
 type MgwEndpoints struct {
@@ -215,12 +237,17 @@ type HttpErrorResponse struct {
 // Key generation request
 type KeygenRequest struct {
 	KeyType                 string   `json:"keyType"`
+	KeyManager              string   `json:"keyManager,omitempty"`
 	GrantTypesToBeSupported []string `json:"grantTypesToBeSupported"`
+	CallbackURL             string   `json:"callbackUrl,omitempty"`
+	Scopes                  []string `json:"scopes,omitempty"`
 	ValidityTime            int      `json:"validityTime"`
 }
 
 // Key generation response
 type KeygenResponse struct {
+	KeyMappingID        string      `json:"keyMappingId"`
+	KeyManager          string      `json:"keyManager"`
 	CallbackURL         interface{} `json:"callbackUrl"`
 	ConsumerKey         string      `json:"consumerKey"`
 	ConsumerSecret      string      `json:"consumerSecret"`
@@ -241,6 +268,20 @@ type AppKeyList struct {
 	List  []ApplicationKey `json:"list"`
 }
 
+// Key managers available in the devportal
+type KeyManagerList struct {
+	Count int              `json:"count"`
+	List  []KeyManagerInfo `json:"list"`
+}
+
+type KeyManagerInfo struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Enabled        bool   `json:"enabled"`
+	TokenEndpoint  string `json:"tokenEndpoint"`
+	RevokeEndpoint string `json:"revokeEndpoint"`
+}
+
 // Consumer Secret regeneration response
 type ConsumerSecretRegenResponse struct {
 	ConsumerKey    string `json:"consumerKey"`
@@ -296,6 +337,8 @@ type AppDetails struct {
 
 // Application key details
 type ApplicationKey struct {
+	KeyMappingID        string      `json:"keyMappingId"`
+	KeyManager          string      `json:"keyManager"`
 	ConsumerKey         string      `json:"consumerKey"`
 	ConsumerSecret      string      `json:"consumerSecret"`
 	SupportedGrantTypes []string    `json:"supportedGrantTypes"`
@@ -422,6 +465,24 @@ type PolicyAttribute struct {
 	Required      bool     `json:"required"`
 }
 
+// Subscription of an API, API Product or MCP Server as returned by the publisher REST API
+type PublisherSubscription struct {
+	SubscriptionID  string `json:"subscriptionId"`
+	ApplicationInfo struct {
+		ApplicationID string `json:"applicationId"`
+		Name          string `json:"name"`
+		Subscriber    string `json:"subscriber"`
+	} `json:"applicationInfo"`
+	ThrottlingPolicy   string `json:"throttlingPolicy"`
+	SubscriptionStatus string `json:"subscriptionStatus"`
+}
+
+// Publisher subscriptions List response struct
+type PublisherSubscriptionList struct {
+	Count int                     `json:"count"`
+	List  []PublisherSubscription `json:"list"`
+}
+
 // Subscription creation request
 type SubscriptionCreateRequest struct {
 	ApplicationID    string `json:"applicationId"`
@@ -544,6 +605,7 @@ type Revisions struct {
 
 type Deployment struct {
 	Name               string `json:"name"`
+	Vhost              string `json:"vhost,omitempty"`
 	DisplayOnDevportal bool   `json:"displayOnDevportal"`
 }
 
@@ -575,6 +637,16 @@ type ApplicationEntry struct {
 	GroupId string
 }
 
+// SubscriptionEntry Subscription List Entry struct to support  different formats of output in the list command
+type SubscriptionEntry struct {
+	Id               string
+	Application      string
+	Api              string
+	Version          string
+	ThrottlingPolicy string
+	Status           string
+}
+
 // RevisionEntry Revision List Entry struct to support  different formats of output in the list command
 type RevisionEntry struct {
 	Id             string
@@ -54,14 +54,14 @@ func ExecutePreCommandWithBasicAuth(environment, flagUsername, flagPassword, mai
 				if flagUsername != username {
 					// username entered with flag -u is not the same as username found
 					// in env_keys_all.yaml file
-					fmt.Println("Username entered with flag -u for the environment '" +
-						environment + "' is not the same as username found in file '" + EnvKeysAllFilePath + "'")
-					fmt.Println("Execute '" + ProjectName + " reset-user -e " + environment + "' to clear user data")
+					fmt.Fprintln(Stdout, "Username entered with flag -u for the environment '"+
+						environment+"' is not the same as username found in file '"+EnvKeysAllFilePath+"'")
+					fmt.Fprintln(Stdout, "Execute '"+ProjectName+" reset-user -e "+environment+"' to clear user data")
 					HandleErrorAndExit("Username mismatch", nil)
 				} else {
 					// username entered with flag -u is the same as username found in env_keys_all.yaml file
 					if flagPassword == "" {
-						fmt.Println("For Username: " + username)
+						fmt.Fprintln(Stdout, "For Username: "+username)
 						password = PromptForPassword()
 					} else {
 						// flagPassword is not blank
@@ -76,7 +76,7 @@ func ExecutePreCommandWithBasicAuth(environment, flagUsername, flagPassword, mai
 					password = flagPassword
 				} else {
 					// flagPassword is blank
-					fmt.Println("For username: " + username)
+					fmt.Fprintln(Stdout, "For username: "+username)
 					password = PromptForPassword()
 				}
 			}
@@ -92,7 +92,7 @@ func ExecutePreCommandWithBasicAuth(environment, flagUsername, flagPassword, mai
 				username = flagUsername
 				if flagPassword == "" {
 					// flagPassword is blank
-					fmt.Println("For Username: " + username)
+					fmt.Fprintln(Stdout, "For Username: "+username)
 					password = PromptForPassword()
 				} else {
 					// flagPassword is not blank
@@ -106,7 +106,7 @@ func ExecutePreCommandWithBasicAuth(environment, flagUsername, flagPassword, mai
 			}
 
 			if err != nil {
-				fmt.Println("Error:", err)
+				fmt.Fprintln(Stdout, "Error:", err)
 			}
 		}
 
@@ -157,14 +157,14 @@ func ExecutePreCommandWithOAuth(environment, flagUsername, flagPassword, mainCon
 				if flagUsername != username {
 					// username entered with flag -u is not the same as username found
 					// in env_keys_all.yaml file
-					fmt.Println("Username entered with flag -u for the environment '" +
-						environment + "' is not the same as username found in file '" + EnvKeysAllFilePath + "'")
-					fmt.Println("Execute '" + ProjectName + " reset-user -e " + environment + "' to clear user data")
+					fmt.Fprintln(Stdout, "Username entered with flag -u for the environment '"+
+						environment+"' is not the same as username found in file '"+EnvKeysAllFilePath+"'")
+					fmt.Fprintln(Stdout, "Execute '"+ProjectName+" reset-user -e "+environment+"' to clear user data")
 					HandleErrorAndExit("Username mismatch", nil)
 				} else {
 					// username entered with flag -u is the same as username found in env_keys_all.yaml file
 					if flagPassword == "" {
-						fmt.Println("For Username: " + username)
+						fmt.Fprintln(Stdout, "For Username: "+username)
 						password = PromptForPassword()
 					} else {
 						// flagPassword is not blank
@@ -179,7 +179,7 @@ func ExecutePreCommandWithOAuth(environment, flagUsername, flagPassword, mainCon
 					password = flagPassword
 				} else {
 					// flagPassword is blank
-					fmt.Println("For username: " + username)
+					fmt.Fprintln(Stdout, "For username: "+username)
 					password = PromptForPassword()
 				}
 			}
@@ -200,7 +200,7 @@ func ExecutePreCommandWithOAuth(environment, flagUsername, flagPassword, mainCon
 				username = flagUsername
 				if flagPassword == "" {
 					// flagPassword is blank
-					fmt.Println("For Username: " + username)
+					fmt.Fprintln(Stdout, "For Username: "+username)
 					password = PromptForPassword()
 				} else {
 					// flagPassword is not blank
@@ -305,6 +305,13 @@ func GetBase64EncodedCredentials(key, secret string) (encodedValue string) {
 	return encoded
 }
 
+// OAuthTokenScopes are the scopes requested for the tokens of the CLI, separated by "+"
+const OAuthTokenScopes = "apim:app_import_export+apim:api_import_export+apim:api_product_import_export+" +
+	"apim:app_manage+apim:sub_manage+apim:api_view+apim:api_delete+apim:app_owner_change+apim:subscribe+" +
+	"apim:api_publish+apim:admin+apim:policies_import_export+apim:mcp_server_view+apim:mcp_server_list_view+" +
+	"apim:mcp_server_create+apim:mcp_server_delete+apim:mcp_server_publish+apim:mcp_server_manage+" +
+	"apim:mcp_server_import_export"
+
 // GetOAuthTokens implemented using go-resty/resty
 // @param username
 // @param password
@@ -314,10 +321,7 @@ func GetBase64EncodedCredentials(key, secret string) (encodedValue string) {
 // @return error
 func GetOAuthTokens(username, password, b64EncodedClientIDClientSecret, url string) (map[string]string, error) {
 	body := "grant_type=password&username=" + username + "&password=" + encodeURL.QueryEscape(password) +
-		"&scope=apim:app_import_export+apim:api_import_export+apim:api_product_import_export+apim:app_manage+" +
-		"apim:sub_manage+apim:api_view+apim:api_delete+apim:app_owner_change+apim:subscribe+apim:api_publish+" +
-		"apim:admin+apim:policies_import_export+apim:mcp_server_view+apim:mcp_server_list_view+apim:mcp_server_create+" +
-		"apim:mcp_server_delete+apim:mcp_server_publish+apim:mcp_server_manage+apim:mcp_server_import_export"
+		"&scope=" + OAuthTokenScopes
 
 	// set headers
 	headers := make(map[string]string)
@@ -337,9 +341,5 @@ func GetOAuthTokens(username, password, b64EncodedClientIDClientSecret, url stri
 			"Status: " + resp.Status())
 	}
 
-	responseDataMap := make(map[string]string) // a map to hold response data
-	data := []byte(resp.Body())
-	json.Unmarshal(data, &responseDataMap) // add response data to the map
-
-	return responseDataMap, nil // contains 'access_token', 'refresh_token' etc
+	return decodeOAuthTokens(resp.Body()) // contains 'access_token', 'refresh_token', 'expires_in' etc
 }
@@ -20,7 +20,6 @@ package utils
 
 import (
 	"bufio"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,7 +28,6 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/go-resty/resty/v2"
 	"golang.org/x/crypto/ssh/terminal"
@@ -38,70 +36,42 @@ import (
 
 // Invoke http-post request using go-resty
 func InvokePOSTRequest(url string, headers map[string]string, body interface{}) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetBody(body).Post(url)
+	return request.SetHeaders(headers).SetBody(body).Post(url)
 }
 
 // Invoke http-post request without body using go-resty
 func InvokePOSTRequestWithoutBody(url string, headers map[string]string) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).Post(url)
+	return request.SetHeaders(headers).Post(url)
 }
 
 // Invoke http-post request with query parameters using go-resty
 func InvokePOSTRequestWithQueryParam(queryParam map[string]string, url string, headers map[string]string,
 	body string) (*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetQueryParams(queryParam).SetBody(body).Post(url)
+	return request.SetHeaders(headers).SetQueryParams(queryParam).SetBody(body).Post(url)
 }
 
 // Invoke http-post request with file & query parameters using go-resty
 func InvokePOSTRequestWithFileAndQueryParams(queryParam map[string]string, url string, headers map[string]string,
 	fileParamName, filePath string) (*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetQueryParams(queryParam).
+	return request.SetHeaders(headers).SetQueryParams(queryParam).
 		SetFile(fileParamName, filePath).Post(url)
 }
 
@@ -109,194 +79,152 @@ func InvokePOSTRequestWithFileAndQueryParams(queryParam map[string]string, url s
 func InvokePOSTRequestWithFile(url string, headers map[string]string,
 	fileParamName, filePath string) (*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).
+	return request.SetHeaders(headers).
 		SetFile(fileParamName, filePath).Post(url)
 }
 
 // Invoke http-get request using go-resty
 func InvokeGETRequest(url string, headers map[string]string) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).Get(url)
+	return request.SetHeaders(headers).Get(url)
 }
 
 // Invoke http-get request with query param
 func InvokeGETRequestWithQueryParam(queryParam string, paramValue string, url string, headers map[string]string) (
 	*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetQueryParam(queryParam, paramValue).Get(url)
+	return request.SetHeaders(headers).SetQueryParam(queryParam, paramValue).Get(url)
 }
 
 // Invoke http-get request with multiple query params
 func InvokeGETRequestWithMultipleQueryParams(queryParam map[string]string, url string, headers map[string]string) (
 	*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetQueryParams(queryParam).Get(url)
+	return request.SetHeaders(headers).SetQueryParams(queryParam).Get(url)
 }
 
 // Invoke http-get request with query params as string
 func InvokeGETRequestWithQueryParamsString(url, queryParams string, headers map[string]string) (
 	*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetQueryString(queryParams).Get(url)
+	return request.SetHeaders(headers).SetQueryString(queryParams).Get(url)
 }
 
 // Invoke http-put request with multiple query params
 func InvokePutRequest(queryParam map[string]string, url string, headers map[string]string, body string) (
 	*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetQueryParams(queryParam).SetBody(body).Put(url)
+	return request.SetHeaders(headers).SetQueryParams(queryParam).SetBody(body).Put(url)
 }
 
 func InvokePUTRequestWithoutQueryParams(url string, headers map[string]string, body interface{}) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetBody(body).Put(url)
+	return request.SetHeaders(headers).SetBody(body).Put(url)
 }
 
 // Invoke http-delete request using go-resty
 func InvokeDELETERequest(url string, headers map[string]string) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).Delete(url)
+	return request.SetHeaders(headers).Delete(url)
 }
 
 // Invoke http-delete request with multiple query params
 func InvokeDELETERequestWithParams(url string, params map[string]string, headers map[string]string) (
 	*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetQueryParams(params).Delete(url)
+	return request.SetHeaders(headers).SetQueryParams(params).Delete(url)
 }
 
 // Invoke http-patch request using go-resty
 func InvokePATCHRequest(url string, headers map[string]string, body map[string]string) (*resty.Response, error) {
-	client := resty.New()
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	return request.SetHeaders(headers).SetBody(body).Patch(url)
+}
 
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+// Invoke an http request of any method using go-resty
+func InvokeRequest(method, url string, headers map[string]string, body string) (*resty.Response, error) {
+	request, err := newHttpRequest(url)
+	if err != nil {
+		return nil, err
 	}
+	request.SetHeaders(headers)
+	if body != "" {
+		request.SetBody(body)
+	}
+	return request.Execute(method, url)
+}
 
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
-	return client.R().SetHeaders(headers).SetBody(body).Patch(url)
+// InvokeRequestWithoutRetries invokes an http request of any method once, without retrying it if it fails. Used when
+// the response of every attempt matters, such as when testing an API.
+func InvokeRequestWithoutRetries(method, url string, headers map[string]string, body string) (*resty.Response,
+	error) {
+	request, err := newHttpRequestWithoutRetries(url)
+	if err != nil {
+		return nil, err
+	}
+	request.SetHeaders(headers)
+	if body != "" {
+		request.SetBody(body)
+	}
+	return request.Execute(method, url)
 }
 
 func PromptForUsername() string {
 	reader := bufio.NewReader(os.Stdin)
 
-	fmt.Print("Enter Username: ")
+	fmt.Fprint(Stdout, "Enter Username: ")
 	username, _ := reader.ReadString('\n')
 
 	return username
 }
 
 func PromptForPassword() string {
-	fmt.Print("Enter Password: ")
+	fmt.Fprint(Stdout, "Enter Password: ")
 	bytePassword, _ := terminal.ReadPassword(0)
 	password := string(bytePassword)
-	fmt.Println()
+	fmt.Fprintln(Stdout)
 	return password
 }
 
 // ShowHelpCommandTip function will print the instructions for displaying help info on a specific command
 // @params cmdLiteral Command on which help command is to be displayed
 func ShowHelpCommandTip(cmdLiteral string) {
-	fmt.Printf("Execute '%s %s --help' for more info.\n", ProjectName, cmdLiteral)
+	fmt.Fprintf(Stdout, "Execute '%s %s --help' for more info.\n", ProjectName, cmdLiteral)
 }
 
 // return a string containing the file name, function name
@@ -345,8 +273,8 @@ func WriteToFileSystem(exportAPIName, exportAPIVersion, exportEnvironment, expor
 	if err != nil {
 		HandleErrorAndExit("Error creating zip archive", err)
 	}
-	fmt.Println("Successfully exported API!")
-	fmt.Println("Find the exported API at " + pFile)
+	fmt.Fprintln(Stdout, "Successfully exported API!")
+	fmt.Fprintln(Stdout, "Find the exported API at "+pFile)
 
 }
 